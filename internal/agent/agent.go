@@ -5,66 +5,24 @@ import (
 	"path/filepath"
 	"strings"
 
-	"gopkg.in/yaml.v3"
+	"github.com/itda-skills/jindo/internal/frontmatter"
 )
 
 // Agent represents a Claude Code agent
 type Agent struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Model       string `json:"model"`
-	Path        string `json:"path"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Model       string   `json:"model"`
+	Tags        []string `json:"tags,omitempty"`
+	Path        string   `json:"path"`
 }
 
 // agentFrontmatter represents the YAML frontmatter structure
 type agentFrontmatter struct {
-	Name        string `yaml:"name"`
-	Description string `yaml:"description"`
-	Model       string `yaml:"model"`
-}
-
-// extractFrontmatter extracts YAML frontmatter from markdown content
-func extractFrontmatter(content string) (string, bool) {
-	lines := strings.Split(content, "\n")
-	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
-		return "", false
-	}
-
-	var frontmatterLines []string
-	for i := 1; i < len(lines); i++ {
-		if strings.TrimSpace(lines[i]) == "---" {
-			return strings.Join(frontmatterLines, "\n"), true
-		}
-		frontmatterLines = append(frontmatterLines, lines[i])
-	}
-
-	return "", false
-}
-
-// parseSimpleFrontmatter parses frontmatter using simple line-based approach
-// This is a fallback for when YAML parsing fails due to special characters
-func parseSimpleFrontmatter(frontmatter string) map[string]string {
-	result := make(map[string]string)
-	lines := strings.Split(frontmatter, "\n")
-
-	for _, line := range lines {
-		// Find first colon
-		idx := strings.Index(line, ":")
-		if idx <= 0 {
-			continue
-		}
-
-		key := strings.TrimSpace(line[:idx])
-		value := strings.TrimSpace(line[idx+1:])
-
-		// Only capture simple keys we care about
-		switch key {
-		case "name", "description", "model":
-			result[key] = value
-		}
-	}
-
-	return result
+	Name        string           `yaml:"name"`
+	Description string           `yaml:"description"`
+	Model       string           `yaml:"model"`
+	Tags        frontmatter.List `yaml:"tags"`
 }
 
 // ParseAgentFile parses an agent .md file and returns an Agent
@@ -78,22 +36,16 @@ func ParseAgentFile(path string) (*Agent, error) {
 		Path: path,
 	}
 
-	frontmatter, found := extractFrontmatter(string(content))
-	if found && frontmatter != "" {
-		var fm agentFrontmatter
-		if err := yaml.Unmarshal([]byte(frontmatter), &fm); err != nil {
-			// If YAML parsing fails, fall back to simple parsing
-			simple := parseSimpleFrontmatter(frontmatter)
-			agent.Name = simple["name"]
-			agent.Description = simple["description"]
-			agent.Model = simple["model"]
-			return agent, nil
-		}
-		agent.Name = fm.Name
-		agent.Description = fm.Description
-		agent.Model = fm.Model
+	var fm agentFrontmatter
+	if _, err := frontmatter.Parse(string(content), &fm); err != nil {
+		return agent, nil
 	}
 
+	agent.Name = fm.Name
+	agent.Description = fm.Description
+	agent.Model = fm.Model
+	agent.Tags = frontmatter.NormalizeTags(fm.Tags)
+
 	return agent, nil
 }
 
@@ -120,14 +72,19 @@ func (s *Store) expandDir() (string, error) {
 	return dir, nil
 }
 
-// Get retrieves a specific agent by name
+// agentFilePath converts an agent name to its file path (supports subdir:name format)
+func agentFilePath(dir, name string) string {
+	return filepath.Join(dir, filepath.Join(strings.Split(name, ":")...)+".md")
+}
+
+// Get retrieves a specific agent by name (supports subdir:name format)
 func (s *Store) Get(name string) (*Agent, error) {
 	dir, err := s.expandDir()
 	if err != nil {
 		return nil, err
 	}
 
-	agentFile := filepath.Join(dir, name+".md")
+	agentFile := agentFilePath(dir, name)
 
 	if _, err := os.Stat(agentFile); os.IsNotExist(err) {
 		return nil, os.ErrNotExist
@@ -152,7 +109,7 @@ func (s *Store) GetContent(name string) (string, error) {
 		return "", err
 	}
 
-	agentFile := filepath.Join(dir, name+".md")
+	agentFile := agentFilePath(dir, name)
 
 	if _, err := os.Stat(agentFile); os.IsNotExist(err) {
 		return "", os.ErrNotExist
@@ -175,7 +132,7 @@ func (s *Store) List() ([]*Agent, error) {
 		return nil, err
 	}
 
-	entries, err := os.ReadDir(dir)
+	err = s.walkDir(dir, "", &agents)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return agents, nil
@@ -183,17 +140,37 @@ func (s *Store) List() ([]*Agent, error) {
 		return nil, err
 	}
 
+	return agents, nil
+}
+
+// walkDir recursively walks the directory and collects agents
+func (s *Store) walkDir(dir, prefix string, agents *[]*Agent) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
 	for _, entry := range entries {
+		name := entry.Name()
+		fullPath := filepath.Join(dir, name)
+
 		if entry.IsDir() {
+			// Skip hidden directories, such as the .history of saved versions
+			if strings.HasPrefix(name, ".") {
+				continue
+			}
+			// Recurse into subdirectory with prefix
+			newPrefix := name
+			if prefix != "" {
+				newPrefix = prefix + ":" + name
+			}
+			_ = s.walkDir(fullPath, newPrefix, agents) // Skip unreadable subdirectories
 			continue
 		}
-
-		name := entry.Name()
 		if !strings.HasSuffix(name, ".md") {
 			continue
 		}
 
-		fullPath := filepath.Join(dir, name)
 		agent, err := ParseAgentFile(fullPath)
 		if err != nil {
 			continue
@@ -202,10 +179,13 @@ func (s *Store) List() ([]*Agent, error) {
 		// Use filename if name is empty
 		if agent.Name == "" {
 			agent.Name = strings.TrimSuffix(name, ".md")
+			if prefix != "" {
+				agent.Name = prefix + ":" + agent.Name
+			}
 		}
 
-		agents = append(agents, agent)
+		*agents = append(*agents, agent)
 	}
 
-	return agents, nil
+	return nil
 }
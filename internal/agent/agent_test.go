@@ -0,0 +1,37 @@
+package agent
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStoreListSkipsHistory(t *testing.T) {
+	dir := t.TempDir()
+	for path, content := range map[string]string{
+		"rev.md":                               "---\ndescription: Reviews code\n---\nReview.\n",
+		"team/lint.md":                         "---\ndescription: Lints\n---\nLint.\n",
+		".history/rev/v001-20260101-000000.md": "---\ndescription: Reviews code\n---\nOld.\n",
+		".history/rev/manifest.json":           "{}\n",
+	} {
+		full := filepath.Join(dir, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	agents, err := NewStore(dir).List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, a := range agents {
+		names = append(names, a.Name)
+	}
+	if len(names) != 2 || names[0] != "rev" || names[1] != "team:lint" {
+		t.Errorf("List() = %v, want [rev team:lint]", names)
+	}
+}
@@ -0,0 +1,223 @@
+// Package aicost estimates what the Claude calls of jd's AI-powered commands
+// cost, and keeps a local tally of the estimates by month. Token counts are
+// estimated from text length; they are meant for budgeting, not billing.
+package aicost
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+	"unicode/utf8"
+)
+
+// TallyFile is the usage tally, in the jd data directory
+const TallyFile = "ai-usage.jsonl"
+
+// MonthFormat is the layout of month names, such as 2026-10
+const MonthFormat = "2006-01"
+
+// charsPerToken is the number of ASCII characters in a token of English text
+// or code. Other characters, such as Hangul and CJK, take about a token each.
+const charsPerToken = 4
+
+// Pricing is the price of tokens in USD per million
+type Pricing struct {
+	InputPerMTok  float64
+	OutputPerMTok float64
+}
+
+// DefaultPricing is the price of the model Claude Code uses by default
+var DefaultPricing = Pricing{InputPerMTok: 3, OutputPerMTok: 15}
+
+// Estimate is the estimated tokens and cost of Claude calls
+type Estimate struct {
+	InputTokens  int     `json:"input_tokens"`
+	OutputTokens int     `json:"output_tokens"`
+	Cost         float64 `json:"cost_usd"`
+}
+
+// Estimate returns the estimate of a call reading inputTokens and writing outputTokens
+func (p Pricing) Estimate(inputTokens, outputTokens int) Estimate {
+	return Estimate{
+		InputTokens:  inputTokens,
+		OutputTokens: outputTokens,
+		Cost:         roundCost((float64(inputTokens)*p.InputPerMTok + float64(outputTokens)*p.OutputPerMTok) / 1e6),
+	}
+}
+
+// Add returns the sum of two estimates
+func (e Estimate) Add(o Estimate) Estimate {
+	return Estimate{
+		InputTokens:  e.InputTokens + o.InputTokens,
+		OutputTokens: e.OutputTokens + o.OutputTokens,
+		Cost:         roundCost(e.Cost + o.Cost),
+	}
+}
+
+// roundCost rounds a cost to a millionth of a dollar, so sums do not show
+// floating-point noise
+func roundCost(cost float64) float64 {
+	return math.Round(cost*1e6) / 1e6
+}
+
+// EstimateTokens estimates the number of tokens in text: a token per
+// charsPerToken ASCII characters, and one per other character
+func EstimateTokens(text string) int {
+	ascii, other := 0, 0
+	for _, r := range text {
+		if r < utf8.RuneSelf {
+			ascii++
+		} else {
+			other++
+		}
+	}
+	return (ascii+charsPerToken-1)/charsPerToken + other
+}
+
+// FormatCost formats a cost in USD, in cents, or to a hundredth of a cent
+// below a cent so small costs stay visible
+func FormatCost(cost float64) string {
+	if cost == 0 || cost >= 0.01 {
+		return fmt.Sprintf("$%.2f", cost)
+	}
+	return fmt.Sprintf("$%.4f", cost)
+}
+
+// Usage is a recorded AI-powered command run
+type Usage struct {
+	Time    time.Time `json:"time"`
+	Command string    `json:"command"`          // Such as "guide skills" or "claudemd tidy"
+	Target  string    `json:"target,omitempty"` // The resource the command ran on
+	Estimate
+}
+
+// Tally is the log of AI-powered command runs, one JSON object per line
+type Tally struct {
+	path string
+}
+
+// NewTally returns the tally kept in dataDir
+func NewTally(dataDir string) *Tally {
+	return &Tally{path: filepath.Join(dataDir, TallyFile)}
+}
+
+// Path returns the file the tally is kept in
+func (t *Tally) Path() string {
+	return t.path
+}
+
+// Record appends a run to the tally
+func (t *Tally) Record(u Usage) error {
+	if u.Time.IsZero() {
+		u.Time = time.Now()
+	}
+	line, err := json.Marshal(u)
+	if err != nil {
+		return fmt.Errorf("encode usage: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(t.path), 0755); err != nil {
+		return fmt.Errorf("create data directory: %w", err)
+	}
+	f, err := os.OpenFile(t.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("open tally: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("write tally: %w", err)
+	}
+	return nil
+}
+
+// Month returns the runs recorded in a month, such as 2026-10, oldest first.
+// Lines that cannot be read, such as one cut short by a crash, are skipped.
+func (t *Tally) Month(month string) ([]Usage, error) {
+	return t.read(func(u Usage) bool { return u.Time.Local().Format(MonthFormat) == month })
+}
+
+// Recent returns the last n runs recorded, oldest first
+func (t *Tally) Recent(n int) ([]Usage, error) {
+	usages, err := t.read(func(Usage) bool { return true })
+	if len(usages) > n {
+		usages = usages[len(usages)-n:]
+	}
+	return usages, err
+}
+
+// read returns the runs keep reports true for, in the order they were recorded,
+// skipping lines that cannot be read
+func (t *Tally) read(keep func(Usage) bool) ([]Usage, error) {
+	f, err := os.Open(t.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open tally: %w", err)
+	}
+	defer f.Close()
+
+	var usages []Usage
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var u Usage
+		if err := json.Unmarshal(scanner.Bytes(), &u); err != nil {
+			continue
+		}
+		if keep(u) {
+			usages = append(usages, u)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read tally: %w", err)
+	}
+	return usages, nil
+}
+
+// CommandTotal is the total of a command's runs
+type CommandTotal struct {
+	Command string `json:"command"`
+	Runs    int    `json:"runs"`
+	Estimate
+}
+
+// Summary is the total of runs, overall and by command
+type Summary struct {
+	Runs int `json:"runs"`
+	Estimate
+	Commands []CommandTotal `json:"commands"`
+}
+
+// Summarize totals runs, with the commands sorted by cost, highest first
+func Summarize(usages []Usage) Summary {
+	summary := Summary{Commands: []CommandTotal{}}
+	byCommand := make(map[string]*CommandTotal)
+	for _, u := range usages {
+		summary.Runs++
+		summary.Estimate = summary.Estimate.Add(u.Estimate)
+
+		total, ok := byCommand[u.Command]
+		if !ok {
+			total = &CommandTotal{Command: u.Command}
+			byCommand[u.Command] = total
+		}
+		total.Runs++
+		total.Estimate = total.Estimate.Add(u.Estimate)
+	}
+
+	for _, total := range byCommand {
+		summary.Commands = append(summary.Commands, *total)
+	}
+	sort.Slice(summary.Commands, func(i, j int) bool {
+		a, b := summary.Commands[i], summary.Commands[j]
+		if a.Cost != b.Cost {
+			return a.Cost > b.Cost
+		}
+		return a.Command < b.Command
+	})
+	return summary
+}
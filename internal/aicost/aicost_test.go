@@ -0,0 +1,119 @@
+package aicost
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestEstimateTokens(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want int
+	}{
+		{"empty", "", 0},
+		{"ascii rounds up", "hello", 2},
+		{"ascii", "abcdefgh", 2},
+		{"hangul takes a token per character", "스킬 가이드", 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EstimateTokens(tt.text); got != tt.want {
+				t.Errorf("EstimateTokens(%q) = %d, want %d", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPricingEstimate(t *testing.T) {
+	e := DefaultPricing.Estimate(100_000, 10_000)
+	if want := 0.3 + 0.15; math.Abs(e.Cost-want) > 1e-9 {
+		t.Errorf("Cost = %v, want %v", e.Cost, want)
+	}
+
+	sum := e.Add(DefaultPricing.Estimate(1_000_000, 0))
+	if sum.InputTokens != 1_100_000 || sum.OutputTokens != 10_000 || math.Abs(sum.Cost-3.45) > 1e-9 {
+		t.Errorf("Add() = %+v", sum)
+	}
+}
+
+func TestFormatCost(t *testing.T) {
+	for cost, want := range map[float64]string{0: "$0.00", 0.00417: "$0.0042", 0.456: "$0.46", 12: "$12.00"} {
+		if got := FormatCost(cost); got != want {
+			t.Errorf("FormatCost(%v) = %q, want %q", cost, got, want)
+		}
+	}
+}
+
+func TestTally(t *testing.T) {
+	dir := t.TempDir()
+	tally := NewTally(filepath.Join(dir, "data"))
+
+	october := time.Date(2026, 10, 16, 12, 0, 0, 0, time.Local)
+	september := time.Date(2026, 9, 30, 12, 0, 0, 0, time.Local)
+	usages := []Usage{
+		{Time: october, Command: "guide skills", Target: "web-fetch", Estimate: DefaultPricing.Estimate(1000, 2000)},
+		{Time: september, Command: "guide skills", Target: "old", Estimate: DefaultPricing.Estimate(1000, 2000)},
+		{Time: october, Command: "claudemd tidy", Estimate: DefaultPricing.Estimate(4000, 4000)},
+		{Time: october, Command: "guide skills", Target: "pdf", Estimate: DefaultPricing.Estimate(1000, 2000)},
+	}
+	for _, u := range usages {
+		if err := tally.Record(u); err != nil {
+			t.Fatalf("Record() error: %v", err)
+		}
+	}
+
+	// A line cut short is skipped
+	f, err := os.OpenFile(tally.Path(), os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString(`{"time":"2026-10-`)
+	f.Close()
+
+	got, err := tally.Month("2026-10")
+	if err != nil {
+		t.Fatalf("Month() error: %v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("Month() returned %d runs, want 3", len(got))
+	}
+	if got[0].Target != "web-fetch" || got[0].InputTokens != 1000 {
+		t.Errorf("Month()[0] = %+v", got[0])
+	}
+
+	summary := Summarize(got)
+	if summary.Runs != 3 || summary.InputTokens != 6000 || summary.OutputTokens != 8000 {
+		t.Errorf("Summarize() = %+v", summary)
+	}
+	if len(summary.Commands) != 2 {
+		t.Fatalf("Summarize() has %d commands, want 2", len(summary.Commands))
+	}
+	if c := summary.Commands[0]; c.Command != "claudemd tidy" || c.Runs != 1 {
+		t.Errorf("most expensive command = %+v, want claudemd tidy", c)
+	}
+	if c := summary.Commands[1]; c.Command != "guide skills" || c.Runs != 2 {
+		t.Errorf("second command = %+v, want guide skills with 2 runs", c)
+	}
+
+	recent, err := tally.Recent(2)
+	if err != nil {
+		t.Fatalf("Recent() error: %v", err)
+	}
+	if len(recent) != 2 || recent[0].Command != "claudemd tidy" || recent[1].Target != "pdf" {
+		t.Errorf("Recent(2) = %+v, want the last 2 runs, oldest first", recent)
+	}
+}
+
+func TestTallyMissingFile(t *testing.T) {
+	got, err := NewTally(t.TempDir()).Month("2026-10")
+	if err != nil || got != nil {
+		t.Errorf("Month() = %v, %v; want nil, nil", got, err)
+	}
+	if got, err := NewTally(t.TempDir()).Recent(5); err != nil || got != nil {
+		t.Errorf("Recent() = %v, %v; want nil, nil", got, err)
+	}
+}
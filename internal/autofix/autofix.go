@@ -0,0 +1,221 @@
+// Package autofix makes safe, mechanical fixes to the markdown files of skills,
+// commands, and agents: a byte order mark, trailing whitespace, malformed
+// frontmatter delimiters, a missing name, and the casing of tool names.
+// Fixes never change what a file says, only how it is written.
+package autofix
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Options selects the fixes that depend on the file
+type Options struct {
+	Name       string   // Added as the name field when the frontmatter has none; "" to add no name
+	ToolFields []string // Frontmatter fields listing tools, such as allowed-tools
+	Tools      []string // Canonical tool names, which tool fields are normalized to regardless of case
+}
+
+// delimiterRegex matches a frontmatter delimiter, allowing more than three dashes
+var delimiterRegex = regexp.MustCompile(`^-{3,}$`)
+
+// keyRegex matches a top-level frontmatter key
+var keyRegex = regexp.MustCompile(`^([A-Za-z0-9_-]+):`)
+
+// toolRegex matches a tool name at the start of a tool list item, after any
+// bracket and quote, leaving arguments such as (git:*) alone
+var toolRegex = regexp.MustCompile(`^(\s*\[?\s*["']?)([A-Za-z][A-Za-z0-9_]*)`)
+
+// Fix applies the fixes to the content of a markdown file, returning the fixed
+// content and a description of each fix made. Content that needs no fix is
+// returned unchanged with no fixes.
+func Fix(content string, opts Options) (string, []string) {
+	var fixes []string
+
+	if rest, ok := strings.CutPrefix(content, "\ufeff"); ok {
+		content = rest
+		fixes = append(fixes, "removed byte order mark")
+	}
+
+	lines := strings.Split(content, "\n")
+	trimmed := 0
+	for i, line := range lines {
+		if t := strings.TrimRight(line, " \t\r"); t != line {
+			lines[i] = t
+			trimmed++
+		}
+	}
+	if trimmed > 0 {
+		fixes = append(fixes, fmt.Sprintf("removed trailing whitespace from %d line(s)", trimmed))
+	}
+
+	// Blank lines at the end collapse to the final newline
+	end := len(lines)
+	for end > 1 && lines[end-1] == "" && lines[end-2] == "" {
+		end--
+	}
+	if end < len(lines) {
+		lines = lines[:end]
+		fixes = append(fixes, "removed blank lines at end of file")
+	}
+
+	lines, fixes = fixFrontmatter(lines, opts, fixes)
+
+	fixed := strings.Join(lines, "\n")
+	if len(fixes) == 0 {
+		return content, nil
+	}
+	return fixed, fixes
+}
+
+// fixFrontmatter fixes the delimiters of the frontmatter, then adds the name and
+// normalizes the tool fields
+func fixFrontmatter(lines []string, opts Options, fixes []string) ([]string, []string) {
+	start := 0
+	for start < len(lines) && lines[start] == "" {
+		start++
+	}
+	if start == len(lines) || !delimiterRegex.MatchString(lines[start]) {
+		if opts.Name == "" {
+			return lines, fixes
+		}
+		header := []string{"---", "name: " + opts.Name, "---"}
+		return append(header, lines...), append(fixes, fmt.Sprintf("added frontmatter with name: %s", opts.Name))
+	}
+
+	// Find the closing delimiter, or where the frontmatter's fields end
+	closing := -1
+	for i := start + 1; i < len(lines); i++ {
+		if delimiterRegex.MatchString(lines[i]) {
+			closing = i
+			break
+		}
+	}
+	if closing < 0 {
+		closing = start + 1
+		for closing < len(lines) && isFrontmatterLine(lines[closing]) {
+			closing++
+		}
+		if closing == start+1 {
+			// A rule at the top of a file without frontmatter
+			return lines, fixes
+		}
+		lines = slices.Insert(lines, closing, "---")
+		fixes = append(fixes, "added missing closing frontmatter delimiter")
+	}
+
+	if start > 0 {
+		lines = lines[start:]
+		closing -= start
+		fixes = append(fixes, "removed blank lines before frontmatter")
+	}
+	if lines[0] != "---" || lines[closing] != "---" {
+		lines[0], lines[closing] = "---", "---"
+		fixes = append(fixes, "normalized frontmatter delimiters to ---")
+	}
+
+	if opts.Name != "" && !hasKey(lines[1:closing], "name") {
+		lines = slices.Insert(lines, 1, "name: "+opts.Name)
+		closing++
+		fixes = append(fixes, fmt.Sprintf("added name: %s", opts.Name))
+	}
+
+	if renamed := normalizeTools(lines[1:closing], opts); len(renamed) > 0 {
+		fixes = append(fixes, "normalized tool names: "+strings.Join(renamed, ", "))
+	}
+	return lines, fixes
+}
+
+// isFrontmatterLine reports whether a line can belong to frontmatter: a key, or an
+// indented continuation or list item
+func isFrontmatterLine(line string) bool {
+	if line == "" {
+		return false
+	}
+	return keyRegex.MatchString(line) || line[0] == ' ' || line[0] == '\t' || strings.HasPrefix(line, "- ")
+}
+
+// hasKey reports whether frontmatter lines define a top-level key
+func hasKey(lines []string, key string) bool {
+	for _, line := range lines {
+		if m := keyRegex.FindStringSubmatch(line); m != nil && m[1] == key {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeTools rewrites, in place, the tool names of the tool fields in
+// frontmatter lines to their canonical casing. Returns the renames made, as "old → new".
+func normalizeTools(lines []string, opts Options) []string {
+	canonical := make(map[string]string, len(opts.Tools))
+	for _, t := range opts.Tools {
+		canonical[strings.ToLower(t)] = t
+	}
+
+	var renamed []string
+	fix := func(item string) string {
+		m := toolRegex.FindStringSubmatchIndex(item)
+		if m == nil {
+			return item
+		}
+		name := item[m[4]:m[5]]
+		want, ok := canonical[strings.ToLower(name)]
+		if !ok || want == name {
+			return item
+		}
+		renamed = append(renamed, fmt.Sprintf("%s → %s", name, want))
+		return item[:m[4]] + want + item[m[5]:]
+	}
+
+	for i := 0; i < len(lines); i++ {
+		m := keyRegex.FindStringSubmatch(lines[i])
+		if m == nil || !slices.Contains(opts.ToolFields, m[1]) {
+			continue
+		}
+
+		key, value := lines[i][:len(m[0])], lines[i][len(m[0]):]
+		if strings.TrimSpace(value) != "" {
+			items := splitItems(value)
+			for j := range items {
+				items[j] = fix(items[j])
+			}
+			lines[i] = key + strings.Join(items, ",")
+			continue
+		}
+
+		// A block list on the following lines
+		for i+1 < len(lines) && !keyRegex.MatchString(lines[i+1]) {
+			i++
+			if dash := strings.Index(lines[i], "- "); dash >= 0 && strings.TrimSpace(lines[i][:dash]) == "" {
+				lines[i] = lines[i][:dash+2] + fix(lines[i][dash+2:])
+			}
+		}
+	}
+	return renamed
+}
+
+// splitItems splits a tool list on the commas outside parentheses, since tool
+// arguments such as Bash(git add:*, git commit:*) may contain commas
+func splitItems(value string) []string {
+	var items []string
+	depth, last := 0, 0
+	for i, r := range value {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			if depth > 0 {
+				depth--
+			}
+		case ',':
+			if depth == 0 {
+				items = append(items, value[last:i])
+				last = i + 1
+			}
+		}
+	}
+	return append(items, value[last:])
+}
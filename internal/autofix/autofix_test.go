@@ -0,0 +1,113 @@
+package autofix
+
+import (
+	"reflect"
+	"testing"
+)
+
+var tools = []string{"Bash", "Read", "WebFetch"}
+
+func TestFix(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		opts    Options
+		want    string
+		fixes   []string
+	}{
+		{
+			name:    "clean file",
+			content: "---\nname: fetch\nallowed-tools: Bash, Read\n---\n\nBody\n",
+			opts:    Options{Name: "fetch", ToolFields: []string{"allowed-tools"}, Tools: tools},
+			want:    "---\nname: fetch\nallowed-tools: Bash, Read\n---\n\nBody\n",
+		},
+		{
+			name:    "byte order mark and trailing whitespace",
+			content: "\ufeff---\r\ndescription: Fetch  \r\n---\r\nBody\t\r\n\n\n",
+			want:    "---\ndescription: Fetch\n---\nBody\n",
+			fixes:   []string{"removed byte order mark", "removed trailing whitespace from 4 line(s)", "removed blank lines at end of file"},
+		},
+		{
+			name:    "missing name",
+			content: "---\ndescription: Fetch\n---\nBody\n",
+			opts:    Options{Name: "fetch"},
+			want:    "---\nname: fetch\ndescription: Fetch\n---\nBody\n",
+			fixes:   []string{"added name: fetch"},
+		},
+		{
+			name:    "no frontmatter",
+			content: "# Fetch\n",
+			opts:    Options{Name: "fetch"},
+			want:    "---\nname: fetch\n---\n# Fetch\n",
+			fixes:   []string{"added frontmatter with name: fetch"},
+		},
+		{
+			name:    "no frontmatter and no name to add",
+			content: "# Fetch\n",
+			want:    "# Fetch\n",
+		},
+		{
+			name:    "delimiters",
+			content: "\n-----\ndescription: Fetch\n----\nBody\n",
+			want:    "---\ndescription: Fetch\n---\nBody\n",
+			fixes:   []string{"removed blank lines before frontmatter", "normalized frontmatter delimiters to ---"},
+		},
+		{
+			name:    "missing closing delimiter",
+			content: "---\ndescription: Fetch\ntags:\n  - web\n\n# Fetch\n",
+			want:    "---\ndescription: Fetch\ntags:\n  - web\n---\n\n# Fetch\n",
+			fixes:   []string{"added missing closing frontmatter delimiter"},
+		},
+		{
+			name:    "horizontal rule is not frontmatter",
+			content: "---\n\n# Fetch\n",
+			want:    "---\n\n# Fetch\n",
+		},
+		{
+			name:    "inline tools",
+			content: "---\nallowed-tools: bash(git add:*, git commit:*), read, webfetch, mcp__github\n---\n",
+			opts:    Options{ToolFields: []string{"allowed-tools"}, Tools: tools},
+			want:    "---\nallowed-tools: Bash(git add:*, git commit:*), Read, WebFetch, mcp__github\n---\n",
+			fixes:   []string{"normalized tool names: bash → Bash, read → Read, webfetch → WebFetch"},
+		},
+		{
+			name:    "tool list",
+			content: "---\ntools:\n  - \"bash\"\n  - Read\nmodel: sonnet\nallowed-tools: bash\n---\n",
+			opts:    Options{ToolFields: []string{"tools"}, Tools: tools},
+			want:    "---\ntools:\n  - \"Bash\"\n  - Read\nmodel: sonnet\nallowed-tools: bash\n---\n",
+			fixes:   []string{"normalized tool names: bash → Bash"},
+		},
+		{
+			name:    "tools in brackets",
+			content: "---\ntools: [\"read\", bash]\n---\n",
+			opts:    Options{ToolFields: []string{"tools"}, Tools: tools},
+			want:    "---\ntools: [\"Read\", Bash]\n---\n",
+			fixes:   []string{"normalized tool names: read → Read, bash → Bash"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, fixes := Fix(tt.content, tt.opts)
+			if got != tt.want {
+				t.Errorf("Fix() content = %q, want %q", got, tt.want)
+			}
+			if !reflect.DeepEqual(fixes, tt.fixes) {
+				t.Errorf("Fix() fixes = %q, want %q", fixes, tt.fixes)
+			}
+		})
+	}
+}
+
+func TestFixIsIdempotent(t *testing.T) {
+	content := "\ufeff\n----\ntools: bash, read  \n\n# Agent\n\n\n"
+	opts := Options{Name: "agent", ToolFields: []string{"tools"}, Tools: tools}
+
+	fixed, fixes := Fix(content, opts)
+	if len(fixes) == 0 {
+		t.Fatal("Fix() made no fixes")
+	}
+	if again, fixes := Fix(fixed, opts); again != fixed || fixes != nil {
+		t.Errorf("Fix() of fixed content = %q, %q; want no change", again, fixes)
+	}
+}
@@ -0,0 +1,107 @@
+// Package basedir resolves the directories jd reads and writes: its own data
+// directory and the global Claude Code configuration directory.
+//
+// Each is taken from an environment variable, then a config key, then the default:
+//
+//	Data dir (repos.json, installed.json, repository clones):
+//	  JINDO_DATA_DIR, paths.data_dir, ~/.itda-skills
+//	Claude dir (global skills, commands, agents, hooks, settings.json):
+//	  CLAUDE_CONFIG_DIR, paths.claude_dir, ~/.claude
+package basedir
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/itda-skills/jindo/pkg/config"
+)
+
+const (
+	// DataDirEnv overrides the jd data directory
+	DataDirEnv = "JINDO_DATA_DIR"
+	// ClaudeDirEnv overrides the global Claude directory (the variable Claude Code itself reads)
+	ClaudeDirEnv = "CLAUDE_CONFIG_DIR"
+
+	// DataDirKey is the config key for the jd data directory
+	DataDirKey = "paths.data_dir"
+	// ClaudeDirKey is the config key for the global Claude directory
+	ClaudeDirKey = "paths.claude_dir"
+
+	// DefaultDataDir is the jd data directory when nothing overrides it
+	DefaultDataDir = "~/.itda-skills"
+	// DefaultClaudeDir is the global Claude directory when nothing overrides it
+	DefaultClaudeDir = "~/.claude"
+)
+
+// loadConfig reads the global config file once; a missing or broken config counts as empty.
+// The project config is not consulted, since finding the project needs these paths.
+var loadConfig = sync.OnceValue(func() *config.Config {
+	cfg, err := config.LoadGlobal()
+	if err != nil {
+		return config.New()
+	}
+	return cfg
+})
+
+// DataDir returns the jd data directory. The default keeps its ~/ prefix.
+func DataDir() string {
+	return resolve(DataDirEnv, DataDirKey, DefaultDataDir)
+}
+
+// ClaudeDir returns the global Claude directory. The default keeps its ~/ prefix.
+func ClaudeDir() string {
+	return resolve(ClaudeDirEnv, ClaudeDirKey, DefaultClaudeDir)
+}
+
+func resolve(envVar, key, fallback string) string {
+	if dir := os.Getenv(envVar); dir != "" {
+		return clean(dir)
+	}
+	if dir := configValue(key); dir != "" {
+		return clean(dir)
+	}
+	return fallback
+}
+
+func configValue(key string) string {
+	value, err := loadConfig().Get(key)
+	if err != nil {
+		return ""
+	}
+	dir, _ := value.(string)
+	return dir
+}
+
+// clean makes a configured directory absolute, keeping a ~/ prefix
+func clean(dir string) string {
+	if dir == "~" || strings.HasPrefix(dir, "~/") {
+		return dir
+	}
+	if abs, err := filepath.Abs(dir); err == nil {
+		return abs
+	}
+	return dir
+}
+
+// Expand expands a leading ~/ to the home directory
+func Expand(dir string) (string, error) {
+	if dir != "~" && !strings.HasPrefix(dir, "~/") {
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, strings.TrimPrefix(dir[1:], "/")), nil
+}
+
+// ClaudePath returns the absolute path of elem inside the global Claude directory
+func ClaudePath(elem ...string) (string, error) {
+	dir, err := Expand(ClaudeDir())
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(append([]string{dir}, elem...)...), nil
+}
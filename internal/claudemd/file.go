@@ -0,0 +1,85 @@
+package claudemd
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/skill"
+)
+
+// UpdateFile rewrites the managed section of the CLAUDE.md at mdPath: skills in add
+// are appended, skills in remove are dropped, and every entry is refreshed from skillsDir
+// (entries whose skill no longer exists are dropped). Without a section, nothing is written
+// unless create is set. Reports whether the file changed.
+func UpdateFile(mdPath, skillsDir string, add, remove []string, create bool) (bool, error) {
+	data, err := os.ReadFile(mdPath)
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+	content := string(data)
+
+	existing, ok := ParseReferences(content)
+	if !ok && !create {
+		return false, nil
+	}
+
+	var ids []string
+	for _, r := range existing {
+		ids = append(ids, r.ID)
+	}
+	for _, id := range add {
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+
+	store := skill.NewStore(skillsDir)
+	var refs []Reference
+	for _, id := range ids {
+		if slices.Contains(remove, id) {
+			continue
+		}
+		s, err := store.Get(id)
+		if err != nil {
+			continue
+		}
+		refs = append(refs, NewReference(id, s.Name, s.Description))
+	}
+
+	updated := SetReferences(content, refs)
+	if updated == content {
+		return false, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(mdPath), 0755); err != nil {
+		return false, err
+	}
+	if err := os.WriteFile(mdPath, []byte(updated), 0644); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// SyncSkill adds (installed) or removes a skill in the managed section of the CLAUDE.md
+// in claudeDir (the global Claude directory if empty), if it has one. Returns the
+// CLAUDE.md path and whether it changed.
+func SyncSkill(claudeDir, id string, installed bool) (string, bool, error) {
+	if claudeDir == "" {
+		dir, err := basedir.ClaudePath()
+		if err != nil {
+			return "", false, err
+		}
+		claudeDir = dir
+	}
+
+	var add, remove []string
+	if installed {
+		add = []string{id}
+	} else {
+		remove = []string{id}
+	}
+	mdPath := filepath.Join(claudeDir, "CLAUDE.md")
+	changed, err := UpdateFile(mdPath, filepath.Join(claudeDir, "skills"), add, remove, false)
+	return mdPath, changed, err
+}
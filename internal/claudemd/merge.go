@@ -0,0 +1,111 @@
+package claudemd
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// hunk is a change to a range of base lines: lines [start, end) are replaced by lines
+type hunk struct {
+	start, end int
+	lines      []string
+	ours       bool
+}
+
+// splitLines splits text into lines, each keeping its newline
+func splitLines(text string) []string {
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// lineHunks returns the changes from base to other, line by line
+func lineHunks(base, other string, ours bool) []hunk {
+	dmp := diffmatchpatch.New()
+	a, b, lines := dmp.DiffLinesToChars(base, other)
+	diffs := dmp.DiffCharsToLines(dmp.DiffMain(a, b, false), lines)
+
+	var hunks []hunk
+	var cur *hunk
+	pos := 0
+	for _, d := range diffs {
+		changed := splitLines(d.Text)
+		if d.Type == diffmatchpatch.DiffEqual {
+			if cur != nil {
+				hunks = append(hunks, *cur)
+				cur = nil
+			}
+			pos += len(changed)
+			continue
+		}
+		if cur == nil {
+			cur = &hunk{start: pos, end: pos, ours: ours}
+		}
+		if d.Type == diffmatchpatch.DiffDelete {
+			pos += len(changed)
+			cur.end = pos
+		} else {
+			cur.lines = append(cur.lines, changed...)
+		}
+	}
+	if cur != nil {
+		hunks = append(hunks, *cur)
+	}
+	return hunks
+}
+
+// sameHunk reports whether two hunks make the same change
+func sameHunk(a, b hunk) bool {
+	return a.start == b.start && a.end == b.end && strings.Join(a.lines, "") == strings.Join(b.lines, "")
+}
+
+// Merge3 merges two versions of a text changed from the same base, such as
+// edits made by hand (ours) and a tidied rewrite (theirs), line by line. It
+// reports false if both change the same or adjacent lines differently, which
+// would need a person to resolve.
+func Merge3(base, ours, theirs string) (string, bool) {
+	hunks := append(lineHunks(base, ours, true), lineHunks(base, theirs, false)...)
+	sort.SliceStable(hunks, func(i, j int) bool { return hunks[i].start < hunks[j].start })
+
+	baseLines := splitLines(base)
+	var out strings.Builder
+	pos := 0
+	for i := 0; i < len(hunks); {
+		// A cluster of hunks touching each other; from both sides, they conflict
+		// unless they make the same change
+		cluster := []hunk{hunks[i]}
+		end := hunks[i].end
+		for i++; i < len(hunks) && hunks[i].start <= end; i++ {
+			cluster = append(cluster, hunks[i])
+			end = max(end, hunks[i].end)
+		}
+		sides := make(map[bool]bool)
+		for _, h := range cluster {
+			sides[h.ours] = true
+		}
+		if len(sides) == 2 {
+			if len(cluster) != 2 || !sameHunk(cluster[0], cluster[1]) {
+				return "", false
+			}
+			cluster = cluster[:1]
+		}
+
+		for _, h := range cluster {
+			for ; pos < h.start; pos++ {
+				out.WriteString(baseLines[pos])
+			}
+			for _, line := range h.lines {
+				out.WriteString(line)
+			}
+			pos = h.end
+		}
+	}
+	for ; pos < len(baseLines); pos++ {
+		out.WriteString(baseLines[pos])
+	}
+	return out.String(), true
+}
@@ -0,0 +1,58 @@
+package claudemd
+
+import "testing"
+
+func TestMerge3(t *testing.T) {
+	base := "# Project\n\nUse tabs.\nRun tests.\n\n## Style\n\nShort names.\n"
+	tests := []struct {
+		name         string
+		ours, theirs string
+		want         string
+		ok           bool
+	}{
+		{
+			name:   "separate changes",
+			ours:   "# Project\n\nUse tabs.\nRun tests.\n\n## Style\n\nShort names.\nNo globals.\n",
+			theirs: "# Project\n\n- Use tabs.\n- Run tests.\n\n## Style\n\nShort names.\n",
+			want:   "# Project\n\n- Use tabs.\n- Run tests.\n\n## Style\n\nShort names.\nNo globals.\n",
+			ok:     true,
+		},
+		{
+			name:   "only theirs",
+			ours:   base,
+			theirs: "# Project\n\nUse tabs. Run tests.\n",
+			want:   "# Project\n\nUse tabs. Run tests.\n",
+			ok:     true,
+		},
+		{
+			name:   "same change on both sides",
+			ours:   "# Project\n\nUse spaces.\nRun tests.\n\n## Style\n\nShort names.\n",
+			theirs: "# Project\n\nUse spaces.\nRun tests.\n\n## Style\n\nShort names.\n",
+			want:   "# Project\n\nUse spaces.\nRun tests.\n\n## Style\n\nShort names.\n",
+			ok:     true,
+		},
+		{
+			name:   "conflicting changes",
+			ours:   "# Project\n\nUse spaces.\nRun tests.\n\n## Style\n\nShort names.\n",
+			theirs: "# Project\n\n- Use tabs.\n- Run tests.\n\n## Style\n\nShort names.\n",
+			ok:     false,
+		},
+		{
+			name:   "adjacent changes",
+			ours:   "# Project\n\nUse tabs.\nRun all tests.\n\n## Style\n\nShort names.\n",
+			theirs: "# Project\n\nIndent with tabs.\nRun tests.\n\n## Style\n\nShort names.\n",
+			ok:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Merge3(base, tt.ours, tt.theirs)
+			if ok != tt.ok {
+				t.Fatalf("Merge3() ok = %v, want %v (merged %q)", ok, tt.ok, got)
+			}
+			if ok && got != tt.want {
+				t.Errorf("Merge3() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
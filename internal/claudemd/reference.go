@@ -0,0 +1,136 @@
+// Package claudemd edits the section of CLAUDE.md that jd manages: a list of
+// available skills, so Claude discovers installed capabilities.
+package claudemd
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	// SectionStart and SectionEnd delimit the managed section in CLAUDE.md
+	SectionStart = "<!-- jd:skills:start -->"
+	SectionEnd   = "<!-- jd:skills:end -->"
+
+	// maxWhenLen is the longest "when to use" text written for a skill
+	maxWhenLen = 200
+)
+
+const sectionHeader = `## Available Skills
+
+Use these skills when a task matches their description. This section is managed by ` + "`jd claudemd reference`" + `; manual edits are overwritten.
+`
+
+// referenceLine matches a skill entry: - **name** (id): when to use
+// The id is omitted when it equals the name.
+var referenceLine = regexp.MustCompile(`^- \*\*(.+?)\*\*(?: \(([^)]+)\))?: ?(.*)$`)
+
+// Reference is a skill listed in the managed section
+type Reference struct {
+	ID   string // Skill directory name (e.g., affa-ever--web-fetch)
+	Name string // Skill name from its frontmatter
+	When string // When to use the skill, from its description
+}
+
+// NewReference returns the reference for a skill, shortening its description to a single line
+func NewReference(id, name, description string) Reference {
+	if name == "" {
+		name = id
+	}
+	when := strings.Join(strings.Fields(description), " ")
+	if runes := []rune(when); len(runes) > maxWhenLen {
+		when = strings.TrimSpace(string(runes[:maxWhenLen-1])) + "…"
+	}
+	return Reference{ID: id, Name: name, When: when}
+}
+
+// line formats the reference as a list entry
+func (r Reference) line() string {
+	line := "- **" + r.Name + "**"
+	if r.ID != r.Name {
+		line += " (" + r.ID + ")"
+	}
+	return line + ": " + r.When
+}
+
+// findSection returns the byte offsets of the managed section, including its markers
+// and the line break after the end marker
+func findSection(content string) (start, end int, ok bool) {
+	start = strings.Index(content, SectionStart)
+	if start < 0 {
+		return 0, 0, false
+	}
+	rel := strings.Index(content[start:], SectionEnd)
+	if rel < 0 {
+		return 0, 0, false
+	}
+	end = start + rel + len(SectionEnd)
+	if strings.HasPrefix(content[end:], "\n") {
+		end++
+	}
+	return start, end, true
+}
+
+// ParseReferences returns the skills listed in the managed section of content, and
+// whether content has a managed section
+func ParseReferences(content string) ([]Reference, bool) {
+	start, end, ok := findSection(content)
+	if !ok {
+		return nil, false
+	}
+
+	var refs []Reference
+	for _, line := range strings.Split(content[start:end], "\n") {
+		m := referenceLine.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		ref := Reference{ID: m[2], Name: m[1], When: m[3]}
+		if ref.ID == "" {
+			ref.ID = ref.Name
+		}
+		refs = append(refs, ref)
+	}
+	return refs, true
+}
+
+// SetReferences returns content with its managed section listing refs. The section
+// replaces an existing one in place, or is appended to the end. With no refs, the
+// section is removed.
+func SetReferences(content string, refs []Reference) string {
+	var section string
+	if len(refs) > 0 {
+		var b strings.Builder
+		b.WriteString(SectionStart + "\n")
+		b.WriteString(sectionHeader + "\n")
+		for _, r := range refs {
+			b.WriteString(r.line() + "\n")
+		}
+		b.WriteString(SectionEnd + "\n")
+		section = b.String()
+	}
+
+	if start, end, ok := findSection(content); ok {
+		if section == "" {
+			// Drop the blank line that separated the section from the text before it
+			before := strings.TrimRight(content[:start], "\n")
+			after := content[end:]
+			if before == "" {
+				return strings.TrimLeft(after, "\n")
+			}
+			if after == "" {
+				return before + "\n"
+			}
+			return before + "\n\n" + strings.TrimLeft(after, "\n")
+		}
+		return content[:start] + section + content[end:]
+	}
+
+	if section == "" {
+		return content
+	}
+	if content == "" {
+		return section
+	}
+	return strings.TrimRight(content, "\n") + "\n\n" + section
+}
@@ -0,0 +1,55 @@
+package claudemd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSetReferences(t *testing.T) {
+	refs := []Reference{
+		NewReference("affa-ever--web-fetch", "web-fetch", "Fetch web pages.\n  Use when a URL is given."),
+		NewReference("deploy", "", "Deploy the app"),
+	}
+
+	content := SetReferences("# Project\n\nUse tabs.\n", refs)
+	if !strings.HasPrefix(content, "# Project\n\nUse tabs.\n\n"+SectionStart+"\n") {
+		t.Errorf("section not appended after existing content:\n%s", content)
+	}
+	if !strings.Contains(content, "- **web-fetch** (affa-ever--web-fetch): Fetch web pages. Use when a URL is given.\n") {
+		t.Errorf("missing web-fetch entry:\n%s", content)
+	}
+	if !strings.Contains(content, "- **deploy**: Deploy the app\n") {
+		t.Errorf("missing deploy entry:\n%s", content)
+	}
+
+	got, ok := ParseReferences(content)
+	if !ok {
+		t.Fatal("ParseReferences() found no section")
+	}
+	if !reflect.DeepEqual(got, refs) {
+		t.Errorf("ParseReferences() = %+v, want %+v", got, refs)
+	}
+
+	// Replacing keeps the surrounding content
+	content += "\n## Notes\n"
+	replaced := SetReferences(content, refs[1:])
+	if !strings.HasSuffix(replaced, SectionEnd+"\n\n## Notes\n") || strings.Contains(replaced, "web-fetch") {
+		t.Errorf("section not replaced in place:\n%s", replaced)
+	}
+
+	// Removing the last reference removes the section
+	if removed := SetReferences(replaced, nil); removed != "# Project\n\nUse tabs.\n\n## Notes\n" {
+		t.Errorf("SetReferences(nil) = %q", removed)
+	}
+	if _, ok := ParseReferences("# Project\n"); ok {
+		t.Error("ParseReferences() found a section in content without one")
+	}
+}
+
+func TestNewReferenceTruncates(t *testing.T) {
+	ref := NewReference("long", "long", strings.Repeat("word ", 100))
+	if n := len([]rune(ref.When)); n != maxWhenLen || !strings.HasSuffix(ref.When, "…") {
+		t.Errorf("When has %d runes (%q), want %d ending in …", n, ref.When, maxWhenLen)
+	}
+}
@@ -0,0 +1,119 @@
+package claudemd
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// FileName is the name of the files Claude Code reads instructions from
+const FileName = "CLAUDE.md"
+
+// skippedDirs are directories Find does not descend into: dependencies and
+// build output, which hold other projects' CLAUDE.md files
+var skippedDirs = map[string]bool{"node_modules": true, "vendor": true, "dist": true, "build": true, "target": true}
+
+// File is a CLAUDE.md file found in a project
+type File struct {
+	Path     string    `json:"path"`
+	Rel      string    `json:"rel"` // Path relative to the project root, with slashes
+	Size     int64     `json:"size"`
+	Headings []Heading `json:"headings"`
+}
+
+// Heading is a Markdown heading of a CLAUDE.md file
+type Heading struct {
+	Level int    `json:"level"`
+	Text  string `json:"text"`
+}
+
+// Find returns the CLAUDE.md files under root, the project's own
+// .claude/CLAUDE.md included, sorted by path with a directory's file before
+// those of its subdirectories. Hidden directories other than .claude at the
+// root, and dependency and build directories, are skipped.
+func Find(root string) ([]File, error) {
+	var files []File
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// An unreadable directory is skipped rather than failing the search
+			if d != nil && d.IsDir() && path != root {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() {
+			if path == root {
+				return nil
+			}
+			name := d.Name()
+			if skippedDirs[name] || (strings.HasPrefix(name, ".") && !(name == ".claude" && filepath.Dir(path) == root)) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Name() != FileName || !d.Type().IsRegular() {
+			return nil
+		}
+		f, err := ReadFile(path)
+		if err != nil {
+			return nil
+		}
+		rel, _ := filepath.Rel(root, path)
+		f.Rel = filepath.ToSlash(rel)
+		files = append(files, *f)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(files, func(i, j int) bool {
+		return pathKey(files[i].Rel) < pathKey(files[j].Rel)
+	})
+	return files, nil
+}
+
+// pathKey orders a relative path by its directories, so that CLAUDE.md comes
+// before src/CLAUDE.md
+func pathKey(rel string) string {
+	return strings.ReplaceAll(filepath.ToSlash(filepath.Dir(rel)), "/", "\x00")
+}
+
+// ReadFile reads the size and headings of a CLAUDE.md file
+func ReadFile(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return &File{Path: path, Rel: filepath.Base(path), Size: int64(len(data)), Headings: Headings(string(data))}, nil
+}
+
+// Headings returns the ATX headings (# Title) of Markdown content, leaving out
+// lines in fenced code blocks
+func Headings(content string) []Heading {
+	headings := []Heading{}
+	fence := ""
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+			continue
+		}
+		level := len(trimmed) - len(strings.TrimLeft(trimmed, "#"))
+		if level == 0 || level > 6 || (len(trimmed) > level && trimmed[level] != ' ') {
+			continue
+		}
+		text := strings.TrimSpace(strings.TrimRight(trimmed[level:], "#"))
+		if text != "" {
+			headings = append(headings, Heading{Level: level, Text: text})
+		}
+	}
+	return headings
+}
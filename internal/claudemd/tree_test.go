@@ -0,0 +1,56 @@
+package claudemd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHeadings(t *testing.T) {
+	content := "# Project\n\nIntro\n\n## Build ##\n\n```sh\n# not a heading\n```\n#hashtag\n### Test\n"
+	got := Headings(content)
+	want := []Heading{{1, "Project"}, {2, "Build"}, {3, "Test"}}
+	if len(got) != len(want) {
+		t.Fatalf("Headings() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("heading %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestFind(t *testing.T) {
+	root := t.TempDir()
+	for _, rel := range []string{
+		"src/api/CLAUDE.md",
+		"CLAUDE.md",
+		".claude/CLAUDE.md",
+		"src/CLAUDE.md",
+		"src-extra/CLAUDE.md",
+		"node_modules/lib/CLAUDE.md",
+		".git/CLAUDE.md",
+		"src/.cache/CLAUDE.md",
+	} {
+		path := filepath.Join(root, rel)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		os.WriteFile(path, []byte("# "+rel+"\n"), 0644)
+	}
+
+	files, err := Find(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"CLAUDE.md", ".claude/CLAUDE.md", "src/CLAUDE.md", "src/api/CLAUDE.md", "src-extra/CLAUDE.md"}
+	if len(files) != len(want) {
+		t.Fatalf("Find() found %d files, want %v", len(files), want)
+	}
+	for i, rel := range want {
+		if files[i].Rel != rel {
+			t.Errorf("file %d = %s, want %s", i, files[i].Rel, rel)
+		}
+	}
+	if files[0].Size == 0 || len(files[0].Headings) != 1 {
+		t.Errorf("Find() read %+v", files[0])
+	}
+}
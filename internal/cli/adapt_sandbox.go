@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"os"
+	"strings"
+
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+// Configuration keys of the defaults of --allowed-tools, --add-dir, and
+// --permission-mode of adapt sessions
+const (
+	adaptAllowedToolsKey   = "adapt.allowed_tools"
+	adaptAddDirsKey        = "adapt.add_dirs"
+	adaptPermissionModeKey = "adapt.permission_mode"
+)
+
+// Tools Claude may use without asking in adapt sessions, unless
+// adapt.allowed_tools or --allowed-tools says otherwise
+var (
+	adaptDefaultTools     = []string{"Edit", "Read", "Write", "Glob", "Grep"}
+	adaptHookDefaultTools = []string{"Edit", "Read", "Write", "Bash"}
+)
+
+// adaptPermissionModes are the permission modes of claude, for completion
+var adaptPermissionModes = []string{"default", "acceptEdits", "plan", "bypassPermissions"}
+
+var (
+	adaptAllowedTools   []string
+	adaptAddDirs        []string
+	adaptPermissionMode string
+)
+
+// addAdaptSandboxFlags adds the flags passed through to the claude session of
+// an adapt command
+func addAdaptSandboxFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&adaptAllowedTools, "allowed-tools", nil, "Tools Claude may use without asking, comma-separated; \"\" for none (default: config "+adaptAllowedToolsKey+", or Edit, Read, Write and Glob, Grep or Bash)")
+	cmd.Flags().StringArrayVar(&adaptAddDirs, "add-dir", nil, "Another directory Claude may access (repeatable; default: config "+adaptAddDirsKey+")")
+	cmd.Flags().StringVar(&adaptPermissionMode, "permission-mode", "", "Permission mode of the Claude session: "+strings.Join(adaptPermissionModes, ", ")+" (default: config "+adaptPermissionModeKey+", or Claude's own)")
+	_ = cmd.RegisterFlagCompletionFunc("permission-mode", cobra.FixedCompletions(adaptPermissionModes, cobra.ShellCompDirectiveNoFileComp))
+	_ = cmd.RegisterFlagCompletionFunc("add-dir", func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveFilterDirs
+	})
+}
+
+// adaptSandboxArgs returns the claude arguments that limit what Claude may do
+// in an adapt session: each of --allowed-tools, --add-dir, and
+// --permission-mode given to cmd, or else its adapt.* config default.
+// defaultTools are allowed when neither sets the tools. The defaults are read
+// from the global config only, so that a cloned project cannot widen them.
+func adaptSandboxArgs(cmd *cobra.Command, defaultTools []string) ([]string, error) {
+	cfg, err := config.LoadGlobal()
+	if err != nil {
+		cfg = config.New()
+	}
+	changed := func(name string) bool {
+		return cmd.Flags().Lookup(name) != nil && cmd.Flags().Changed(name)
+	}
+
+	tools := defaultTools
+	if changed("allowed-tools") {
+		tools = adaptAllowedTools
+	} else if list := cfg.GetStringSlice(adaptAllowedToolsKey); list != nil {
+		// An empty list allows no tools
+		tools = list
+	}
+	dirs := adaptAddDirs
+	if !changed("add-dir") {
+		dirs = cfg.GetStringSlice(adaptAddDirsKey)
+	}
+	mode := adaptPermissionMode
+	if !changed("permission-mode") {
+		if value, err := cfg.Get(adaptPermissionModeKey); err == nil {
+			mode, _ = value.(string)
+		}
+	}
+
+	var args []string
+	var allowed []string
+	for _, tool := range tools {
+		if tool = strings.TrimSpace(tool); tool != "" {
+			allowed = append(allowed, tool)
+		}
+	}
+	// Without --allowedTools, Claude asks before every tool use
+	if len(allowed) > 0 {
+		args = append(args, "--allowedTools", strings.Join(allowed, ","))
+	}
+	for _, dir := range dirs {
+		path, err := basedir.Expand(dir)
+		if err != nil {
+			return nil, err
+		}
+		if info, err := os.Stat(path); err != nil || !info.IsDir() {
+			return nil, validationErrorf("--add-dir %s: not a directory", dir)
+		}
+		args = append(args, "--add-dir", path)
+	}
+	if mode != "" {
+		args = append(args, "--permission-mode", mode)
+	}
+	return args, nil
+}
@@ -30,13 +30,21 @@ This command:
 3. Modifies the agent based on the conversation
 4. Saves changes and updates version history
 
-Default scope is local if a .claude directory exists in the current working directory, otherwise global.
-Use --global or --local to override.`,
+Default scope is local if a .claude directory exists in the current or a parent directory (the nearest one is used), otherwise global.
+Use --global or --local to override.
+
+Claude may edit, read, and write files and search with Glob and Grep without
+asking. --allowed-tools, --add-dir, and --permission-mode are passed to claude
+to change that; adapt.allowed_tools, adapt.add_dirs, and adapt.permission_mode
+in the config set their defaults.`,
 	Example: `  # Adapt a global agent
   jd agents adapt my-agent
 
   # Adapt a local agent
-  jd agents adapt my-agent --local`,
+  jd agents adapt my-agent --local
+
+  # Plan the changes before Claude makes them
+  jd agents adapt my-agent --permission-mode plan`,
 	Args:              cobra.ExactArgs(1),
 	RunE:              runAgentsAdapt,
 	ValidArgsFunction: agentNameCompletion,
@@ -46,6 +54,7 @@ func init() {
 	agentsCmd.AddCommand(agentsAdaptCmd)
 	agentsAdaptCmd.Flags().BoolVarP(&agentsAdaptGlobal, "global", "g", false, "Adapt from global ~/.claude/agents/")
 	agentsAdaptCmd.Flags().BoolVarP(&agentsAdaptLocal, "local", "l", false, "Adapt from local .claude/agents/")
+	addAdaptSandboxFlags(agentsAdaptCmd)
 }
 
 func runAgentsAdapt(cmd *cobra.Command, args []string) error {
@@ -56,8 +65,16 @@ func runAgentsAdapt(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	agentID := args[0]
+	sandbox, err := adaptSandboxArgs(cmd, adaptDefaultTools)
+	if err != nil {
+		return err
+	}
+	return adaptAgent(scope, args[0], sandbox)
+}
 
+// adaptAgent backs up an agent and starts an AI conversation to customize it;
+// sandbox holds the claude arguments of adaptSandboxArgs
+func adaptAgent(scope PathScope, agentID string, sandbox []string) error {
 	agentsDir := GetPathByScope(scope, "agents")
 	store := agent.NewStore(agentsDir)
 
@@ -65,7 +82,7 @@ func runAgentsAdapt(cmd *cobra.Command, args []string) error {
 	a, err := store.Get(agentID)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return fmt.Errorf("agent not found in %s: %s", ScopeDescription(scope), agentID)
+			return notFoundErrorf("agent not found in %s: %s", ScopeDescription(scope), agentID)
 		}
 		return fmt.Errorf("failed to get agent: %w", err)
 	}
@@ -125,14 +142,17 @@ func runAgentsAdapt(cmd *cobra.Command, args []string) error {
 
 	// Initial prompt to make Claude start the conversation (passed as positional argument for interactive mode)
 	initialPrompt := fmt.Sprintf("I want to customize the '%s' agent. Please start by asking me about my specific needs and how I'd like to adapt this agent to my workflow.", agentID)
+	run := newAIRun("agents adapt", agentID, firstReplyOutputTokens, systemPrompt.String(), initialPrompt)
+	if err := confirmAICost(run.estimate, aiFirstReplyNote, false); err != nil {
+		// Nothing was adapted: drop the backup
+		_ = historyMgr.DeleteVersion(version.Number)
+		return err
+	}
 
 	// Run claude command with the system prompt and initial message
 	// Note: positional argument (not -p) keeps interactive mode
-	claudeCmd := exec.Command("claude",
-		"--system-prompt", systemPrompt.String(),
-		"--allowedTools", "Edit,Read,Write,Glob,Grep",
-		initialPrompt,
-	)
+	claudeArgs := append([]string{"--system-prompt", systemPrompt.String()}, sandbox...)
+	claudeCmd := exec.Command("claude", append(claudeArgs, initialPrompt)...)
 	claudeCmd.Stdin = os.Stdin
 	claudeCmd.Stdout = os.Stdout
 	claudeCmd.Stderr = os.Stderr
@@ -141,12 +161,14 @@ func runAgentsAdapt(cmd *cobra.Command, args []string) error {
 		// Check if it's just a user exit
 		if exitErr, ok := err.(*exec.ExitError); ok {
 			if exitErr.ExitCode() == 130 { // Ctrl+C
+				recordAIRun(run, "")
 				fmt.Println("\n⚠️  Adaptation cancelled")
 				return nil
 			}
 		}
 		return fmt.Errorf("claude command failed: %w", err)
 	}
+	recordAIRun(run, "")
 
 	// Read the potentially updated content
 	newContent, err := store.GetContent(agentID)
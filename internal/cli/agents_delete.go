@@ -1,10 +1,8 @@
 package cli
 
 import (
-	"bufio"
 	"fmt"
 	"os"
-	"strings"
 
 	"github.com/itda-skills/jindo/internal/agent"
 	"github.com/spf13/cobra"
@@ -12,6 +10,7 @@ import (
 
 var (
 	agentsDeleteForce  bool
+	agentsDeleteYes    bool
 	agentsDeleteGlobal bool
 	agentsDeleteLocal  bool
 )
@@ -22,9 +21,19 @@ var agentsDeleteCmd = &cobra.Command{
 	Short:   "Delete an agent",
 	Long: `Delete an agent from ~/.claude/agents/ (global) or .claude/agents/ (local) directory.
 
-This will delete the agent file.
-Use --force to skip the confirmation prompt.
-Default scope is local if a .claude directory exists in the current working directory, otherwise global.
+This removes the agent file, after listing the files, and the skills,
+commands, and agents that still mention it, and asking. The files are moved to the trash
+(trash/ in the jd data directory, ~/.itda-skills by default), from where they
+can be restored by hand.
+
+A agent installed by a package is not deleted; uninstall the package with
+'jd pkg uninstall' instead, which also removes its record.
+
+Use --yes to skip the confirmation in scripts. Use --force to skip it, delete
+the files outright instead of moving them to the trash, and delete a agent
+installed by a package anyway.
+
+Default scope is local if a .claude directory exists in the current or a parent directory (the nearest one is used), otherwise global.
 Use --global or --local to override.`,
 	Args:              cobra.ExactArgs(1),
 	RunE:              runAgentsDelete,
@@ -33,7 +42,8 @@ Use --global or --local to override.`,
 
 func init() {
 	agentsCmd.AddCommand(agentsDeleteCmd)
-	agentsDeleteCmd.Flags().BoolVarP(&agentsDeleteForce, "force", "f", false, "Skip confirmation prompt")
+	agentsDeleteCmd.Flags().BoolVarP(&agentsDeleteForce, "force", "f", false, "Skip confirmation and delete outright, even if installed by a package")
+	agentsDeleteCmd.Flags().BoolVarP(&agentsDeleteYes, "yes", "y", false, "Skip confirmation and move the files to the trash")
 	agentsDeleteCmd.Flags().BoolVarP(&agentsDeleteGlobal, "global", "g", false, "Delete from global ~/.claude/agents/")
 	agentsDeleteCmd.Flags().BoolVarP(&agentsDeleteLocal, "local", "l", false, "Delete from local .claude/agents/")
 }
@@ -54,35 +64,16 @@ func runAgentsDelete(cmd *cobra.Command, args []string) error {
 	a, err := store.Get(name)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return fmt.Errorf("agent not found in %s: %s", ScopeDescription(scope), name)
+			return notFoundErrorf("agent not found in %s: %s", ScopeDescription(scope), name)
 		}
 		return fmt.Errorf("failed to get agent: %w", err)
 	}
 
-	// Confirm deletion unless --force
-	if !agentsDeleteForce {
-		fmt.Printf("Delete agent '%s'?\n", name)
-		fmt.Printf("  Path: %s\n", a.Path)
-		fmt.Print("Type 'yes' to confirm: ")
-
-		reader := bufio.NewReader(os.Stdin)
-		response, err := reader.ReadString('\n')
-		if err != nil {
-			return fmt.Errorf("failed to read input: %w", err)
-		}
-
-		response = strings.TrimSpace(strings.ToLower(response))
-		if response != "yes" {
-			fmt.Println("Cancelled.")
-			return nil
-		}
-	}
-
-	// Delete the agent file
-	if err := os.Remove(a.Path); err != nil {
-		return fmt.Errorf("failed to delete agent: %w", err)
-	}
-
-	fmt.Printf("Deleted agent: %s\n", name)
-	return nil
+	return deleteResource(resourceDeletion{
+		kind:  "agent",
+		name:  name,
+		file:  a.Path,
+		path:  a.Path,
+		scope: scope,
+	}, agentsDeleteForce, agentsDeleteYes)
 }
@@ -23,7 +23,7 @@ var agentsEditCmd = &cobra.Command{
 
 By default, uses Claude CLI to interactively edit the agent content.
 Use --editor to open the agent file directly in your editor.
-Default scope is local if a .claude directory exists in the current working directory, otherwise global.
+Default scope is local if a .claude directory exists in the current or a parent directory (the nearest one is used), otherwise global.
 Use --global or --local to override.`,
 	Args:              cobra.ExactArgs(1),
 	RunE:              runAgentsEdit,
@@ -53,11 +53,14 @@ func runAgentsEdit(cmd *cobra.Command, args []string) error {
 	a, err := store.Get(name)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return fmt.Errorf("agent not found in %s: %s", ScopeDescription(scope), name)
+			return notFoundErrorf("agent not found in %s: %s", ScopeDescription(scope), name)
 		}
 		return fmt.Errorf("failed to get agent: %w", err)
 	}
 
+	// Snapshot prior content before it is overwritten (history.auto)
+	autoSnapshotAgent(a.Path)
+
 	// If --editor flag, just open in editor
 	if agentsEditEditor {
 		return openEditor(a.Path)
@@ -22,7 +22,8 @@ var agentsHistoryCmd = &cobra.Command{
 	Long: `Show the version history of an agent.
 
 Each time an agent is adapted, a new version is saved to .history/.
-Use 'jd agents revert' to restore a previous version.`,
+Use 'jd agents history show' to print a version, 'jd agents history diff' to compare
+versions, and 'jd agents revert' to restore a previous version.`,
 	Example: `  # Show history of a global agent
   jd agents history my-agent
 
@@ -56,7 +57,7 @@ func runAgentsHistory(cmd *cobra.Command, args []string) error {
 	a, err := store.Get(agentID)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return fmt.Errorf("agent not found in %s: %s", ScopeDescription(scope), agentID)
+			return notFoundErrorf("agent not found in %s: %s", ScopeDescription(scope), agentID)
 		}
 		return fmt.Errorf("failed to get agent: %w", err)
 	}
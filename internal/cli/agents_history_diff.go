@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/itda-skills/jindo/internal/agent"
+	"github.com/spf13/cobra"
+)
+
+var (
+	agentsHistoryDiffGlobal bool
+	agentsHistoryDiffLocal  bool
+)
+
+var agentsHistoryDiffCmd = &cobra.Command{
+	Use:   "diff <agent-id> <v1> <v2>",
+	Short: "Compare two versions of an agent",
+	Long: `Show a unified diff between two versions of an agent.
+
+Versions can be a number (e.g., 1, 2), 'latest', or 'current' for the agent file as it is now.`,
+	Example: `  # Compare version 1 and 3
+  jd agents history diff my-agent 1 3
+
+  # Compare the latest saved version with the current file
+  jd agents history diff my-agent latest current`,
+	Args:              cobra.ExactArgs(3),
+	RunE:              runAgentsHistoryDiff,
+	ValidArgsFunction: agentNameCompletion,
+}
+
+func init() {
+	agentsHistoryCmd.AddCommand(agentsHistoryDiffCmd)
+	agentsHistoryDiffCmd.Flags().BoolVarP(&agentsHistoryDiffGlobal, "global", "g", false, "Compare in global ~/.claude/agents/")
+	agentsHistoryDiffCmd.Flags().BoolVarP(&agentsHistoryDiffLocal, "local", "l", false, "Compare in local .claude/agents/")
+}
+
+func runAgentsHistoryDiff(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	agentID := args[0]
+
+	scope, err := ResolveScope(agentsHistoryDiffGlobal, agentsHistoryDiffLocal)
+	if err != nil {
+		return err
+	}
+
+	historyMgr, a, err := agentHistoryManager(scope, agentID)
+	if err != nil {
+		return err
+	}
+
+	oldContent, oldName, err := agentVersionContent(historyMgr, a.Path, args[1])
+	if err != nil {
+		return err
+	}
+	newContent, newName, err := agentVersionContent(historyMgr, a.Path, args[2])
+	if err != nil {
+		return err
+	}
+
+	diff := unifiedDiff(oldName, newName, oldContent, newContent)
+	if diff == "" {
+		fmt.Printf("No differences between %s and %s\n", oldName, newName)
+		return nil
+	}
+
+	fmt.Print(diff)
+	return nil
+}
+
+// agentHistoryManager returns the agent and its history manager in scope
+func agentHistoryManager(scope PathScope, agentID string) (*agent.HistoryManager, *agent.Agent, error) {
+	agentsDir := GetPathByScope(scope, "agents")
+	store := agent.NewStore(agentsDir)
+
+	a, err := store.Get(agentID)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, notFoundErrorf("agent not found in %s: %s", ScopeDescription(scope), agentID)
+		}
+		return nil, nil, fmt.Errorf("failed to get agent: %w", err)
+	}
+
+	expandedAgentsDir, err := expandScopeDir(agentsDir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return agent.NewHistoryManager(expandedAgentsDir, agentID), a, nil
+}
+
+// agentVersionContent returns the content and display name of a version argument.
+// 'current' reads the agent file itself.
+func agentVersionContent(historyMgr *agent.HistoryManager, agentPath, arg string) (string, string, error) {
+	if strings.ToLower(arg) == "current" {
+		content, err := os.ReadFile(agentPath)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to read agent: %w", err)
+		}
+		return string(content), "current", nil
+	}
+
+	versionNum, err := agent.ParseVersionArg(arg)
+	if err != nil {
+		return "", "", err
+	}
+
+	if versionNum == -1 {
+		latest, err := historyMgr.GetLatestVersion()
+		if err != nil {
+			return "", "", fmt.Errorf("failed to get latest version: %w", err)
+		}
+		versionNum = latest.Number
+	}
+
+	content, version, err := historyMgr.GetVersion(versionNum)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get version: %w", err)
+	}
+	return content, agent.FormatVersionName(version), nil
+}
@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	agentsHistoryShowGlobal bool
+	agentsHistoryShowLocal  bool
+)
+
+var agentsHistoryShowCmd = &cobra.Command{
+	Use:   "show <agent-id> <version>",
+	Short: "Print a saved version of an agent",
+	Long: `Print the content of a specific version from an agent's history.
+
+Version can be a number (e.g., 1, 2) or 'latest'.`,
+	Example: `  # Print version 2
+  jd agents history show my-agent 2`,
+	Args:              cobra.ExactArgs(2),
+	RunE:              runAgentsHistoryShow,
+	ValidArgsFunction: agentNameCompletion,
+}
+
+func init() {
+	agentsHistoryCmd.AddCommand(agentsHistoryShowCmd)
+	agentsHistoryShowCmd.Flags().BoolVarP(&agentsHistoryShowGlobal, "global", "g", false, "Show from global ~/.claude/agents/")
+	agentsHistoryShowCmd.Flags().BoolVarP(&agentsHistoryShowLocal, "local", "l", false, "Show from local .claude/agents/")
+}
+
+func runAgentsHistoryShow(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	scope, err := ResolveScope(agentsHistoryShowGlobal, agentsHistoryShowLocal)
+	if err != nil {
+		return err
+	}
+
+	historyMgr, a, err := agentHistoryManager(scope, args[0])
+	if err != nil {
+		return err
+	}
+
+	content, _, err := agentVersionContent(historyMgr, a.Path, args[1])
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(content)
+	return nil
+}
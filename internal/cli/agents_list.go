@@ -3,9 +3,10 @@ package cli
 import (
 	"encoding/json"
 	"fmt"
-	"strings"
+	"os"
 
 	"github.com/itda-skills/jindo/internal/agent"
+	"github.com/itda-skills/jindo/internal/table"
 	"github.com/spf13/cobra"
 )
 
@@ -22,6 +23,8 @@ var agentsListCmd = &cobra.Command{
 func init() {
 	agentsCmd.AddCommand(agentsListCmd)
 	agentsListCmd.Flags().BoolVar(&agentsListJSON, "json", false, "Output in JSON format")
+	addOutputFlags(agentsListCmd)
+	addNoTruncFlag(agentsListCmd)
 }
 
 // agentsListOutput represents JSON output for agents list with scope
@@ -47,21 +50,16 @@ func runAgentsList(cmd *cobra.Command, _ []string) error {
 		localAgents, _ = localStore.List()
 	}
 
-	if agentsListJSON {
+	if agentsListJSON || formattedOutput() {
 		output := agentsListOutput{
 			Global: globalAgents,
 			Local:  localAgents,
 		}
-		jsonOutput, err := json.MarshalIndent(output, "", "  ")
-		if err != nil {
-			return err
-		}
-		fmt.Println(string(jsonOutput))
-		return nil
+		return printJSON(output)
 	}
 
 	// Print global section
-	fmt.Println("=== Global (~/.claude/agents/) ===")
+	fmt.Printf("=== Global (%s/) ===\n", GetGlobalPath("agents"))
 	if len(globalAgents) == 0 {
 		fmt.Println("No agents found.")
 	} else {
@@ -88,60 +86,34 @@ func printAgentsJSON(agents []*agent.Agent) error {
 }
 
 func printAgentsTable(agents []*agent.Agent) {
-	// Calculate column widths
-	nameWidth := len("NAME")
-	modelWidth := len("MODEL")
+	// Favorites get a ★ marker column when any agent in the table is a favorite
+	var names []string
+	for _, a := range agents {
+		names = append(names, a.Name)
+	}
+	markFavorites := anyFavorite(names...)
 
+	// Tags column is shown only when at least one agent has tags
+	showTags := false
 	for _, a := range agents {
-		if len(a.Name) > nameWidth {
-			nameWidth = len(a.Name)
-		}
-		if len(a.Model) > modelWidth {
-			modelWidth = len(a.Model)
+		if len(a.Tags) > 0 {
+			showTags = true
 		}
 	}
 
-	// Cap widths
-	if nameWidth > 25 {
-		nameWidth = 25
+	columns := []table.Column{
+		{Header: favoriteLabel("NAME", markFavorites), Max: 25},
+		{Header: "MODEL", Max: 10},
+		{Header: "DESCRIPTION", Max: 50, Min: 20},
 	}
-	if modelWidth > 10 {
-		modelWidth = 10
+	if showTags {
+		columns = append(columns, table.Column{Header: "TAGS", Max: 25})
 	}
-	const descWidth = 50
-
-	// Print header
-	fmt.Printf("%-*s  %-*s  %-*s\n",
-		nameWidth, "NAME",
-		modelWidth, "MODEL",
-		descWidth, "DESCRIPTION")
-	fmt.Printf("%s  %s  %s\n",
-		strings.Repeat("-", nameWidth),
-		strings.Repeat("-", modelWidth),
-		strings.Repeat("-", descWidth))
-
-	// Print rows
+	t := newTable(columns...)
 	for _, a := range agents {
-		name := a.Name
-		if len(name) > nameWidth {
-			name = name[:nameWidth-3] + "..."
-		}
-
-		model := a.Model
-		if len(model) > modelWidth {
-			model = model[:modelWidth-3] + "..."
-		}
-
-		desc := a.Description
-		if len(desc) > descWidth {
-			desc = desc[:descWidth-3] + "..."
-		}
-
-		fmt.Printf("%-*s  %-*s  %-*s\n",
-			nameWidth, name,
-			modelWidth, model,
-			descWidth, desc)
+		t.AddRow(favoriteLabel(a.Name, markFavorites), a.Model, a.Description, formatTags(a.Tags))
 	}
+	t.Render(os.Stdout)
 
 	fmt.Printf("\nTotal: %d agents\n", len(agents))
 }
@@ -1,11 +1,18 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 
+	"github.com/itda-skills/jindo/internal/agent"
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/frontmatter"
+	"github.com/itda-skills/jindo/internal/pkg/pkgmgr"
+	"github.com/itda-skills/jindo/internal/pkg/repo"
 	"github.com/spf13/cobra"
 )
 
@@ -16,6 +23,8 @@ var (
 	agentsNewModel  string
 	agentsNewGlobal bool
 	agentsNewLocal  bool
+	agentsNewFrom   string
+	agentsNewTmpl   string
 )
 
 var agentsNewCmd = &cobra.Command{
@@ -26,8 +35,24 @@ var agentsNewCmd = &cobra.Command{
 
 By default, uses Claude CLI to interactively generate the agent content.
 Use --no-ai to create a minimal template without AI assistance.
-Default scope is local if a .claude directory exists in the current working directory, otherwise global.
-Use --global or --local to override.`,
+Default scope is local if a .claude directory exists in the current or a parent directory (the nearest one is used), otherwise global.
+Use --global or --local to override.
+
+Use --from to start from something similar: an installed agent ID (looked up in
+the local scope first, then global) or an agent file in a registered repository
+(namespace:agents/name.md). Its content is copied under the new name and adapt
+mode starts right away; with --no-ai the copy is only created.
+
+Use --template to start from a template contributed by a registered repository
+(namespace:name, from the repository's templates/agents/<name>.md). The
+placeholders {{name}}, {{title}}, {{description}}, {{model}}, and {{date}} are
+filled in from the agent name and --description/--model. Other placeholders are
+left for you to complete.`,
+	Example: `  jd agents new reviewer
+  jd agents new reviewer --no-ai -d "Reviews pull requests"
+  jd agents new go-reviewer --from code-reviewer
+  jd agents new go-reviewer --from affa-ever:agents/code-reviewer.md
+  jd agents new payments-reviewer --template acme:reviewer -d "Reviews payment service changes"`,
 	Args: cobra.ExactArgs(1),
 	RunE: runAgentsNew,
 }
@@ -40,6 +65,11 @@ func init() {
 	agentsNewCmd.Flags().StringVarP(&agentsNewModel, "model", "m", "", "Model to use (for --no-ai mode)")
 	agentsNewCmd.Flags().BoolVarP(&agentsNewGlobal, "global", "g", false, "Create in global ~/.claude/agents/")
 	agentsNewCmd.Flags().BoolVarP(&agentsNewLocal, "local", "l", false, "Create in local .claude/agents/")
+	agentsNewCmd.Flags().StringVar(&agentsNewFrom, "from", "", "Copy an installed agent ID or a repository agent (namespace:agents/name.md)")
+	agentsNewCmd.Flags().StringVar(&agentsNewTmpl, "template", "", "Start from a repository template (namespace:name)")
+	agentsNewCmd.MarkFlagsMutuallyExclusive("from", "template")
+	_ = agentsNewCmd.RegisterFlagCompletionFunc("from", agentNameCompletion)
+	_ = agentsNewCmd.RegisterFlagCompletionFunc("template", templateCompletion(repo.TypeAgent))
 }
 
 func runAgentsNew(cmd *cobra.Command, args []string) error {
@@ -52,6 +82,14 @@ func runAgentsNew(cmd *cobra.Command, args []string) error {
 
 	name := args[0]
 
+	// A copy is adapted in a Claude session limited by the adapt.* config
+	var sandbox []string
+	if agentsNewFrom != "" && !agentsNewNoAI {
+		if sandbox, err = adaptSandboxArgs(cmd, adaptDefaultTools); err != nil {
+			return err
+		}
+	}
+
 	// Get agents directory based on scope
 	var agentsDir string
 	if scope == ScopeLocal {
@@ -61,11 +99,11 @@ func runAgentsNew(cmd *cobra.Command, args []string) error {
 		}
 		agentsDir = localPath
 	} else {
-		home, err := os.UserHomeDir()
+		dir, err := basedir.ClaudePath("agents")
 		if err != nil {
 			return fmt.Errorf("failed to get home directory: %w", err)
 		}
-		agentsDir = filepath.Join(home, ".claude", "agents")
+		agentsDir = dir
 	}
 	agentFile := filepath.Join(agentsDir, name+".md")
 
@@ -74,13 +112,42 @@ func runAgentsNew(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("agent already exists: %s", name)
 	}
 
+	// Find the template or read the agent to copy before creating anything
+	var tmpl *repo.Template
+	if agentsNewTmpl != "" {
+		tmpl, err = findTemplate(agentsNewTmpl, repo.TypeAgent)
+		if err != nil {
+			return err
+		}
+	}
+	var source string
+	if agentsNewFrom != "" {
+		source, err = readAgentSource(agentsNewFrom)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Create directory if needed
 	if err := os.MkdirAll(agentsDir, 0755); err != nil {
 		return fmt.Errorf("failed to create agents directory: %w", err)
 	}
 
 	var content string
-	if agentsNewNoAI {
+	var unknown []string
+	if tmpl != nil {
+		model := agentsNewModel
+		if model == "" {
+			model = "claude-sonnet-4-20250514"
+		}
+		values := templateValues(name, agentsNewDesc, map[string]string{"model": model})
+		content, unknown, err = renderAgentTemplate(tmpl, values)
+		if err != nil {
+			return err
+		}
+	} else if agentsNewFrom != "" {
+		content = setFrontmatterName(source, name)
+	} else if agentsNewNoAI {
 		content = generateAgentTemplate(name, agentsNewDesc, agentsNewModel)
 	} else {
 		// Use Claude CLI to generate agent content
@@ -97,6 +164,16 @@ func runAgentsNew(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Printf("Created agent: %s\n", agentFile)
+	if tmpl != nil {
+		fmt.Printf("   (from template %s)\n", agentsNewTmpl)
+		warnUnknownPlaceholders(unknown)
+	}
+
+	// Start adapting the copy right away
+	if agentsNewFrom != "" && !agentsNewNoAI {
+		fmt.Printf("   (copied from %s)\n", agentsNewFrom)
+		return adaptAgent(scope, name, sandbox)
+	}
 
 	// Open editor if requested
 	if agentsNewEdit {
@@ -174,3 +251,43 @@ Start by asking: "What should the '%s' agent specialize in? Please describe its
 
 	return string(output), nil
 }
+
+// readAgentSource reads the agent to copy for --from: a repository agent
+// (namespace:agents/name.md) or an installed agent ID, local scope first
+func readAgentSource(from string) (string, error) {
+	if spec, err := pkgmgr.ParseSpec(from); err == nil && strings.HasSuffix(spec.Path, ".md") {
+		store := repo.NewStore(basedir.DataDir())
+		if _, err := store.Get(spec.Namespace); err == nil {
+			root, err := store.PackageRoot(spec.Namespace)
+			if err != nil {
+				return "", fmt.Errorf("failed to locate repository %s: %w", spec.Namespace, err)
+			}
+			content, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(spec.Path)))
+			if err != nil {
+				return "", notFoundErrorf("agent not found in repository %s: %s", spec.Namespace, spec.Path)
+			}
+			return string(content), nil
+		}
+	}
+
+	scopes := []PathScope{ScopeGlobal}
+	if LocalClaudeDirExists() {
+		scopes = []PathScope{ScopeLocal, ScopeGlobal}
+	}
+	for _, scope := range scopes {
+		content, err := agent.NewStore(GetPathByScope(scope, "agents")).GetContent(from)
+		if err == nil {
+			return content, nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("failed to read agent %s: %w", from, err)
+		}
+	}
+	return "", notFoundErrorf("agent not found: %s (use an agent ID or namespace:agents/name.md)", from)
+}
+
+// setFrontmatterName sets the name field of a markdown file's YAML frontmatter,
+// adding a frontmatter block if there is none
+func setFrontmatterName(content, name string) string {
+	return frontmatter.Set(content, "name", name)
+}
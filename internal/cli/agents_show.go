@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/itda-skills/jindo/internal/agent"
+	"github.com/itda-skills/jindo/internal/pkg/repo"
 	"github.com/spf13/cobra"
 )
 
@@ -22,7 +23,7 @@ var agentsShowCmd = &cobra.Command{
 	Short:   "Show agent details",
 	Long: `Show the full content of a specific agent from ~/.claude/agents/ (global) or .claude/agents/ (local) directory.
 
-Default scope is local if a .claude directory exists in the current working directory, otherwise global.
+Default scope is local if a .claude directory exists in the current or a parent directory (the nearest one is used), otherwise global.
 Use --global or --local to override.`,
 	Args:              cobra.ExactArgs(1),
 	RunE:              runAgentsShow,
@@ -58,7 +59,7 @@ func showAgentBrief(store *agent.Store, name string, scope PathScope) error {
 	a, err := store.Get(name)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return fmt.Errorf("agent not found in %s: %s", ScopeDescription(scope), name)
+			return notFoundErrorf("agent not found in %s: %s", ScopeDescription(scope), name)
 		}
 		return fmt.Errorf("failed to get agent: %w", err)
 	}
@@ -75,7 +76,7 @@ func showAgentFull(store *agent.Store, name string, scope PathScope) error {
 	content, err := store.GetContent(name)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return fmt.Errorf("agent not found in %s: %s", ScopeDescription(scope), name)
+			return notFoundErrorf("agent not found in %s: %s", ScopeDescription(scope), name)
 		}
 		return fmt.Errorf("failed to get agent content: %w", err)
 	}
@@ -103,14 +104,19 @@ func agentNameCompletion(cmd *cobra.Command, args []string, toComplete string) (
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
 
-	var names []string
-	for _, a := range agents {
+	ids := make([]string, len(agents))
+	for i, a := range agents {
 		// Use filename without .md extension (the actual ID used for lookup)
-		fileName := strings.TrimSuffix(filepath.Base(a.Path), ".md")
-		if a.Description != "" {
-			names = append(names, fmt.Sprintf("%s\t%s", fileName, a.Description))
+		ids[i] = strings.TrimSuffix(filepath.Base(a.Path), ".md")
+	}
+	short := loadShortNames(map[repo.PackageType][]string{repo.TypeAgent: ids})
+
+	var names []string
+	for i, a := range agents {
+		if description := short.describe(repo.TypeAgent, ids[i], a.Description); description != "" {
+			names = append(names, fmt.Sprintf("%s\t%s", ids[i], description))
 		} else {
-			names = append(names, fileName)
+			names = append(names, ids[i])
 		}
 	}
 
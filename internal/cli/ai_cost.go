@@ -0,0 +1,140 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/itda-skills/jindo/internal/aicost"
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/pkg/config"
+)
+
+// Configuration keys of the Claude usage estimates
+const (
+	aiPriceInputKey    = "ai.price.input_per_mtok"
+	aiPriceOutputKey   = "ai.price.output_per_mtok"
+	aiConfirmAboveKey  = "ai.budget.confirm_above"
+	aiMonthlyBudgetKey = "ai.budget.monthly"
+)
+
+// Output tokens expected from Claude, where the output is not a rewrite of the input
+const (
+	guideOutputTokens      = 2000 // A usage guide
+	firstReplyOutputTokens = 1000 // The first reply of a conversation, such as adapt
+)
+
+// aiBudget is the pricing and budget thresholds from the configuration.
+// Thresholds of 0 never ask.
+type aiBudget struct {
+	pricing      aicost.Pricing
+	confirmAbove float64
+	monthly      float64
+}
+
+// loadAIBudget reads the pricing and budget thresholds, falling back to the defaults
+func loadAIBudget() aiBudget {
+	budget := aiBudget{pricing: aicost.DefaultPricing}
+	cfg, err := config.Load()
+	if err != nil {
+		return budget
+	}
+	budget.pricing.InputPerMTok = cfg.GetFloat(aiPriceInputKey, budget.pricing.InputPerMTok)
+	budget.pricing.OutputPerMTok = cfg.GetFloat(aiPriceOutputKey, budget.pricing.OutputPerMTok)
+	budget.confirmAbove = cfg.GetFloat(aiConfirmAboveKey, 0)
+	budget.monthly = cfg.GetFloat(aiMonthlyBudgetKey, 0)
+	return budget
+}
+
+// aiRun is a Claude call of an AI-powered command, with its estimated cost
+type aiRun struct {
+	command  string // Recorded in the tally, such as "guide skills"
+	target   string
+	estimate aicost.Estimate
+}
+
+// newAIRun estimates a call sending prompts and writing about outputTokens
+func newAIRun(command, target string, outputTokens int, prompts ...string) *aiRun {
+	input := 0
+	for _, p := range prompts {
+		input += aicost.EstimateTokens(p)
+	}
+	return &aiRun{command: command, target: target, estimate: loadAIBudget().pricing.Estimate(input, outputTokens)}
+}
+
+// aiTally returns the monthly usage tally in the jd data directory
+func aiTally() (*aicost.Tally, error) {
+	dataDir, err := basedir.Expand(basedir.DataDir())
+	if err != nil {
+		return nil, err
+	}
+	return aicost.NewTally(dataDir), nil
+}
+
+// aiFirstReplyNote follows the estimate of a conversation
+const aiFirstReplyNote = " for the first reply (each reply resends the conversation)"
+
+// confirmAICost prints the estimated cost of Claude calls and asks whether to
+// go on when it is above ai.budget.confirm_above, or would take this month's
+// tally past ai.budget.monthly. note follows the estimate, e.g. to say it only
+// covers the first reply. yes skips the question.
+func confirmAICost(estimate aicost.Estimate, note string, yes bool) error {
+	fmt.Fprintf(os.Stderr, "💰 Estimated Claude usage: ~%d input + ~%d output tokens ≈ %s%s\n",
+		estimate.InputTokens, estimate.OutputTokens, aicost.FormatCost(estimate.Cost), note)
+
+	budget := loadAIBudget()
+	var reasons []string
+	if budget.confirmAbove > 0 && estimate.Cost > budget.confirmAbove {
+		reasons = append(reasons, fmt.Sprintf("the estimate is above %s (%s)", aicost.FormatCost(budget.confirmAbove), aiConfirmAboveKey))
+	}
+	if budget.monthly > 0 {
+		if spent, err := aiMonthSpend(); err == nil && spent+estimate.Cost > budget.monthly {
+			reasons = append(reasons, fmt.Sprintf("it would take this month's %s past the %s budget (%s)", aicost.FormatCost(spent), aicost.FormatCost(budget.monthly), aiMonthlyBudgetKey))
+		}
+	}
+	if len(reasons) == 0 || yes {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "⚠️  Over budget: %s\n", strings.Join(reasons, "; "))
+	fmt.Print("Run it anyway? (y/N): ")
+	reader := bufio.NewReader(os.Stdin)
+	response, _ := reader.ReadString('\n')
+	response = strings.TrimSpace(strings.ToLower(response))
+	if response != "y" && response != "yes" {
+		fmt.Println("Cancelled.")
+		return errCancelled
+	}
+	return nil
+}
+
+// aiMonthSpend returns the estimated cost of this month's recorded runs
+func aiMonthSpend() (float64, error) {
+	tally, err := aiTally()
+	if err != nil {
+		return 0, err
+	}
+	usages, err := tally.Month(time.Now().Format(aicost.MonthFormat))
+	if err != nil {
+		return 0, err
+	}
+	return aicost.Summarize(usages).Cost, nil
+}
+
+// recordAIRun adds a finished run to the monthly tally. When Claude's output is
+// known it replaces the estimated output tokens. Failing to record only warns.
+func recordAIRun(run *aiRun, output string) {
+	estimate := run.estimate
+	if output != "" {
+		estimate = loadAIBudget().pricing.Estimate(estimate.InputTokens, aicost.EstimateTokens(output))
+	}
+	tally, err := aiTally()
+	if err == nil {
+		err = tally.Record(aicost.Usage{Command: run.command, Target: run.target, Estimate: estimate})
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to record Claude usage: %v\n", err)
+	}
+}
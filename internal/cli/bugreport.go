@@ -0,0 +1,459 @@
+package cli
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/itda-skills/jindo/internal/aicost"
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/pkg/git"
+	"github.com/itda-skills/jindo/internal/pkg/pkgmgr"
+	"github.com/itda-skills/jindo/internal/pkg/repo"
+	"github.com/spf13/cobra"
+)
+
+var (
+	bugreportOutput string
+	bugreportStdout bool
+	bugreportJSON   bool
+	bugreportYes    bool
+)
+
+var bugreportCmd = &cobra.Command{
+	Use:   "bugreport",
+	Short: "Gather an anonymous report of your jd setup to attach to an issue",
+	Long: `Gather what helps diagnose a jd problem into one report to attach to an issue:
+  - jd version, OS and architecture, and the git and Claude Code versions
+  - How many skills, agents, commands, and hooks each scope has, and how
+    many packages and repositories are installed
+  - The configuration keys set, with the problems 'jd config doctor' finds
+  - The last AI-powered command runs ('jd stats ai'), without their targets
+
+The report is anonymous: paths in the home directory are shown as ~, the
+project as <project>, resource, package, and repository names are left out,
+and the values of secrets and of keys jd does not read are redacted.
+
+The report is shown for review first, and written only after you confirm, to
+jd-bugreport-<time>.md in the current directory, or to --output. An --output
+ending in .tar.gz holds the report as bugreport.md and bugreport.json.
+
+Use --stdout to print the report without writing a file (e.g., to pipe it to
+'gh issue create --body-file -'), or --json for the report as JSON.
+
+Examples:
+  jd bugreport
+  jd bugreport -o report.tar.gz
+  jd bugreport --stdout | pbcopy`,
+	Args: cobra.NoArgs,
+	RunE: runBugreport,
+}
+
+func init() {
+	rootCmd.AddCommand(bugreportCmd)
+	bugreportCmd.Flags().StringVarP(&bugreportOutput, "output", "o", "", "File to write the report to (.md, or .tar.gz for an archive)")
+	bugreportCmd.Flags().BoolVar(&bugreportStdout, "stdout", false, "Print the report as markdown without writing a file")
+	bugreportCmd.Flags().BoolVar(&bugreportJSON, "json", false, "Print the report as JSON without writing a file")
+	bugreportCmd.Flags().BoolVarP(&bugreportYes, "yes", "y", false, "Write the report without asking")
+	bugreportCmd.MarkFlagsMutuallyExclusive("output", "stdout", "json")
+}
+
+// bugreportRecentRuns is how many AI-powered command runs a report shows
+const bugreportRecentRuns = 10
+
+// bugreportVersionTimeout bounds how long a tool may take to print its version
+const bugreportVersionTimeout = 5 * time.Second
+
+// bugReport is the anonymous environment fingerprint jd bugreport gathers
+type bugReport struct {
+	Generated   time.Time            `json:"generated"`
+	Environment bugReportEnvironment `json:"environment"`
+	Resources   []bugReportScope     `json:"resources"`
+	Packages    bugReportPackages    `json:"packages"`
+	Config      bugReportConfig      `json:"config"`
+	RecentRuns  []bugReportRun       `json:"recent_ai_runs"`
+	Errors      []string             `json:"errors,omitempty"` // What could not be gathered
+
+	redactor *strings.Replacer
+}
+
+// bugReportEnvironment is the software jd runs with
+type bugReportEnvironment struct {
+	Jindo     string `json:"jindo"`
+	BuildDate string `json:"build_date"`
+	OS        string `json:"os"`
+	Arch      string `json:"arch"`
+	GoVersion string `json:"go_version"`
+	Git       string `json:"git"`
+	Claude    string `json:"claude"`
+	Shell     string `json:"shell,omitempty"`
+	Terminal  bool   `json:"terminal"`
+}
+
+// bugReportScope counts the resources of a scope
+type bugReportScope struct {
+	Scope    string `json:"scope"`
+	Skills   int    `json:"skills"`
+	Agents   int    `json:"agents"`
+	Commands int    `json:"commands"`
+	Hooks    int    `json:"hooks"`
+}
+
+// bugReportPackages counts installed packages, by type, and registered repositories
+type bugReportPackages struct {
+	Installed    int            `json:"installed"`
+	ByType       map[string]int `json:"by_type"`
+	Repositories int            `json:"repositories"`
+}
+
+// bugReportConfig is the redacted configuration
+type bugReportConfig struct {
+	Files    []bugReportConfigFile `json:"files"`
+	Keys     []bugReportConfigKey  `json:"keys"` // Keys set, in a file or the environment
+	Problems []string              `json:"problems"`
+}
+
+// bugReportConfigFile is whether a config file exists
+type bugReportConfigFile struct {
+	Scope  string `json:"scope"`
+	Exists bool   `json:"exists"`
+}
+
+// bugReportConfigKey is a configuration key that is set, with its value redacted if need be
+type bugReportConfigKey struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
+// bugReportRun is an AI-powered command run, without the resource it ran on
+type bugReportRun struct {
+	Time         time.Time `json:"time"`
+	Command      string    `json:"command"`
+	InputTokens  int       `json:"input_tokens"`
+	OutputTokens int       `json:"output_tokens"`
+}
+
+// redactedValue replaces configuration values that may identify the user
+const redactedValue = "<redacted>"
+
+func runBugreport(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+
+	report := gatherBugReport()
+
+	if bugreportJSON {
+		return printJSON(report)
+	}
+	markdown := report.markdown()
+	if bugreportStdout {
+		fmt.Print(markdown)
+		return nil
+	}
+
+	path := bugreportOutput
+	if path == "" {
+		path = fmt.Sprintf("jd-bugreport-%s.md", report.Generated.Format("20060102-150405"))
+	}
+
+	if !bugreportYes {
+		fmt.Println(markdown)
+		fmt.Println("---")
+		fmt.Println("Review the report above; nothing has been written yet.")
+		fmt.Printf("Write it to %s? (y/N): ", path)
+
+		reader := bufio.NewReader(os.Stdin)
+		response, _ := reader.ReadString('\n')
+		response = strings.TrimSpace(strings.ToLower(response))
+		if response != "y" && response != "yes" {
+			fmt.Println("Cancelled.")
+			return errCancelled
+		}
+	}
+
+	if err := writeBugReport(path, report, markdown); err != nil {
+		return fmt.Errorf("failed to write bug report: %w", err)
+	}
+	fmt.Printf("✓ Wrote %s; attach it to your issue.\n", path)
+	return nil
+}
+
+// gatherBugReport collects the report. What cannot be gathered is noted in
+// it rather than failing, since a broken setup is what it is for.
+func gatherBugReport() *bugReport {
+	report := &bugReport{
+		Generated:  time.Now(),
+		Resources:  []bugReportScope{},
+		RecentRuns: []bugReportRun{},
+		redactor:   bugReportRedactor(),
+	}
+
+	report.Environment = bugReportEnvironment{
+		Jindo:     Version,
+		BuildDate: BuildDate,
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		GoVersion: runtime.Version(),
+		Git:       "not found",
+		Claude:    "not found",
+		Shell:     filepath.Base(os.Getenv("SHELL")),
+		Terminal:  isTerminal(os.Stdout),
+	}
+	if report.Environment.Shell == "." {
+		report.Environment.Shell = ""
+	}
+	if v, err := git.Version(); err == nil {
+		report.Environment.Git = v
+	}
+	if v, err := toolVersion("claude"); err == nil {
+		report.Environment.Claude = v
+	}
+
+	// Resources
+	scopes := []struct{ name, dir string }{{string(ScopeGlobal), basedir.ClaudeDir()}}
+	if LocalClaudeDirExists() {
+		if root, err := ProjectRoot(); err == nil {
+			scopes = append(scopes, struct{ name, dir string }{string(ScopeLocal), filepath.Join(root, localClaudeDir)})
+		}
+	}
+	for _, s := range scopes {
+		items := loadScopeItems(s.dir)
+		report.Resources = append(report.Resources, bugReportScope{
+			Scope:    s.name,
+			Skills:   len(items.skills),
+			Agents:   len(items.agents),
+			Commands: len(items.commands),
+			Hooks:    len(items.hooks),
+		})
+	}
+
+	// Packages and repositories, counted without their names
+	report.Packages.ByType = make(map[string]int)
+	if packages, err := pkgmgr.NewManager(basedir.DataDir()).List(); err != nil {
+		report.addError("packages", err)
+	} else {
+		report.Packages.Installed = len(packages)
+		for _, p := range packages {
+			report.Packages.ByType[string(p.Type)]++
+		}
+	}
+	if repos, err := repo.NewStore(basedir.DataDir()).List(); err != nil {
+		report.addError("repositories", err)
+	} else {
+		report.Packages.Repositories = len(repos)
+	}
+
+	// Configuration: known keys that are set, with values that may identify the user redacted
+	report.Config = bugReportConfig{Files: []bugReportConfigFile{}, Keys: []bugReportConfigKey{}, Problems: []string{}}
+	if doctor, err := buildConfigDoctorReport(); err != nil {
+		report.addError("config", err)
+	} else {
+		for _, f := range doctor.Files {
+			report.Config.Files = append(report.Config.Files, bugReportConfigFile{Scope: f.Scope, Exists: f.Exists})
+		}
+		for _, k := range doctor.Keys {
+			if k.Source == "default" {
+				continue
+			}
+			value := report.redact(k.Value)
+			if !k.Known || isSecretConfigKey(k.Key) {
+				value = redactedValue
+			}
+			report.Config.Keys = append(report.Config.Keys, bugReportConfigKey{Key: k.Key, Value: value, Source: k.Source})
+		}
+		for _, p := range doctor.Problems {
+			report.Config.Problems = append(report.Config.Problems, report.redact(p))
+		}
+	}
+
+	// Recent AI-powered command runs, without their targets
+	if runs, err := aicost.NewTally(basedir.DataDir()).Recent(bugreportRecentRuns); err != nil {
+		report.addError("AI usage log", err)
+	} else {
+		for _, u := range runs {
+			report.RecentRuns = append(report.RecentRuns, bugReportRun{
+				Time:         u.Time,
+				Command:      u.Command,
+				InputTokens:  u.InputTokens,
+				OutputTokens: u.OutputTokens,
+			})
+		}
+	}
+	return report
+}
+
+// toolVersion returns the first line a program prints with --version
+func toolVersion(name string) (string, error) {
+	if _, err := exec.LookPath(name); err != nil {
+		return "", err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), bugreportVersionTimeout)
+	defer cancel()
+	output, err := exec.CommandContext(ctx, name, "--version").Output()
+	if err != nil {
+		return "", err
+	}
+	line, _, _ := strings.Cut(strings.TrimSpace(string(output)), "\n")
+	return line, nil
+}
+
+// bugReportRedactor replaces the project root and the home directory in paths
+func bugReportRedactor() *strings.Replacer {
+	var pairs []string
+	if LocalClaudeDirExists() {
+		if root, err := ProjectRoot(); err == nil && root != "" {
+			pairs = append(pairs, root, "<project>")
+		}
+	}
+	if home, err := os.UserHomeDir(); err == nil && home != "" && home != "/" {
+		pairs = append(pairs, home, "~")
+	}
+	return strings.NewReplacer(pairs...)
+}
+
+// redact removes the project and home paths from s
+func (r *bugReport) redact(s string) string {
+	return r.redactor.Replace(s)
+}
+
+// addError notes that part of the report could not be gathered
+func (r *bugReport) addError(what string, err error) {
+	r.Errors = append(r.Errors, r.redact(fmt.Sprintf("%s: %v", what, err)))
+}
+
+// markdown renders the report as a markdown block to paste into an issue
+func (r *bugReport) markdown() string {
+	var b strings.Builder
+	env := r.Environment
+
+	b.WriteString("## jd bug report\n\n")
+	fmt.Fprintf(&b, "Generated %s by `jd bugreport`. Home directory paths are shown as `~`, the project as `<project>`, and secret values are redacted.\n\n", r.Generated.UTC().Format("2006-01-02 15:04 UTC"))
+
+	b.WriteString("### Environment\n\n")
+	b.WriteString("| | |\n|---|---|\n")
+	fmt.Fprintf(&b, "| jd | %s (built %s) |\n", env.Jindo, env.BuildDate)
+	fmt.Fprintf(&b, "| OS/arch | %s/%s |\n", env.OS, env.Arch)
+	fmt.Fprintf(&b, "| Go | %s |\n", env.GoVersion)
+	fmt.Fprintf(&b, "| git | %s |\n", env.Git)
+	fmt.Fprintf(&b, "| Claude Code | %s |\n", env.Claude)
+	if env.Shell != "" {
+		fmt.Fprintf(&b, "| Shell | %s |\n", env.Shell)
+	}
+	fmt.Fprintf(&b, "| Terminal | %t |\n\n", env.Terminal)
+
+	b.WriteString("### Resources\n\n")
+	b.WriteString("| Scope | Skills | Agents | Commands | Hooks |\n|---|---|---|---|---|\n")
+	for _, s := range r.Resources {
+		fmt.Fprintf(&b, "| %s | %d | %d | %d | %d |\n", s.Scope, s.Skills, s.Agents, s.Commands, s.Hooks)
+	}
+	types := make([]string, 0, len(r.Packages.ByType))
+	for t, n := range r.Packages.ByType {
+		types = append(types, fmt.Sprintf("%d %s", n, t))
+	}
+	sort.Strings(types)
+	fmt.Fprintf(&b, "\nPackages: %d installed", r.Packages.Installed)
+	if len(types) > 0 {
+		fmt.Fprintf(&b, " (%s)", strings.Join(types, ", "))
+	}
+	fmt.Fprintf(&b, " from %d registered repositories\n\n", r.Packages.Repositories)
+
+	b.WriteString("### Configuration\n\n")
+	var files []string
+	for _, f := range r.Config.Files {
+		status := "not found"
+		if f.Exists {
+			status = "found"
+		}
+		files = append(files, fmt.Sprintf("%s %s", f.Scope, status))
+	}
+	fmt.Fprintf(&b, "Files: %s\n\n", strings.Join(files, ", "))
+	if len(r.Config.Keys) == 0 {
+		b.WriteString("All keys have their defaults.\n")
+	} else {
+		b.WriteString("| Key | Value | Source |\n|---|---|---|\n")
+		for _, k := range r.Config.Keys {
+			fmt.Fprintf(&b, "| %s | %s | %s |\n", k.Key, markdownCell(k.Value), k.Source)
+		}
+	}
+	if len(r.Config.Problems) > 0 {
+		b.WriteString("\nProblems:\n")
+		for _, p := range r.Config.Problems {
+			fmt.Fprintf(&b, "- %s\n", p)
+		}
+	}
+
+	b.WriteString("\n### Recent AI-powered command runs\n\n")
+	if len(r.RecentRuns) == 0 {
+		b.WriteString("None recorded.\n")
+	} else {
+		b.WriteString("| Time | Command | Input tokens | Output tokens |\n|---|---|---|---|\n")
+		for _, u := range r.RecentRuns {
+			fmt.Fprintf(&b, "| %s | %s | %d | %d |\n", u.Time.UTC().Format("2006-01-02 15:04"), u.Command, u.InputTokens, u.OutputTokens)
+		}
+	}
+
+	if len(r.Errors) > 0 {
+		b.WriteString("\n### Could not gather\n\n")
+		for _, e := range r.Errors {
+			fmt.Fprintf(&b, "- %s\n", e)
+		}
+	}
+	return b.String()
+}
+
+// markdownCell escapes a value for a markdown table cell
+func markdownCell(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(s)
+}
+
+// writeBugReport writes the markdown report, or an archive of it and its JSON
+// form if path ends with .tar.gz or .tgz
+func writeBugReport(path string, report *bugReport, markdown string) error {
+	if !strings.HasSuffix(path, ".tar.gz") && !strings.HasSuffix(path, ".tgz") {
+		return os.WriteFile(path, []byte(markdown), 0644)
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, f := range []struct {
+		name    string
+		content []byte
+	}{
+		{"bugreport.md", []byte(markdown)},
+		{"bugreport.json", append(data, '\n')},
+	} {
+		header := &tar.Header{Name: f.name, Mode: 0644, Size: int64(len(f.content)), ModTime: report.Generated}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := tw.Write(f.content); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	claudemdBackupGlobal bool
+	claudemdBackupLocal  bool
+	claudemdBackupPath   string
+)
+
+var claudemdBackupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Back up CLAUDE.md",
+	Long: `Save a timestamped copy of CLAUDE.md, as tidy and edit do before they change
+it. 'jd claudemd restore' puts a backup back.
+
+Backups of ~/.claude/CLAUDE.md and .claude/CLAUDE.md go to backups/ next to
+them. Backups of a CLAUDE.md elsewhere in the project, picked with --path, go
+to .claude/backups/project/<directory>/, so they stay out of your sources.
+
+Default scope is local (.claude/CLAUDE.md) if present, otherwise global (~/.claude/CLAUDE.md).`,
+	Example: `  jd claudemd backup
+  jd claudemd backup --global
+  jd claudemd backup --path src/api`,
+	Args: cobra.NoArgs,
+	RunE: runClaudemdBackup,
+}
+
+func init() {
+	claudemdCmd.AddCommand(claudemdBackupCmd)
+	claudemdBackupCmd.Flags().BoolVarP(&claudemdBackupGlobal, "global", "g", false, "Back up global ~/.claude/CLAUDE.md")
+	claudemdBackupCmd.Flags().BoolVarP(&claudemdBackupLocal, "local", "l", false, "Back up local .claude/CLAUDE.md")
+	addCLAUDEmdPathFlag(claudemdBackupCmd, &claudemdBackupPath)
+}
+
+func runClaudemdBackup(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+
+	path, err := resolveCLAUDEmdPath(claudemdBackupPath, claudemdBackupGlobal, claudemdBackupLocal)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return notFoundErrorf("CLAUDE.md not found at %s", path)
+	}
+
+	backupPath, err := backupCLAUDEmd(path)
+	if err != nil {
+		return fmt.Errorf("failed to backup CLAUDE.md: %w", err)
+	}
+	fmt.Printf("💾 Backup: %s\n", backupPath)
+	return nil
+}
+
+// claudemdBackups returns the backups of the CLAUDE.md at path, newest first
+func claudemdBackups(path string) ([]string, error) {
+	dir := claudemdBackupDir(path)
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var backups []string
+	for _, e := range entries {
+		if !e.IsDir() && backupStamp(e.Name()) != "" {
+			backups = append(backups, filepath.Join(dir, e.Name()))
+		}
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		return backupStamp(filepath.Base(backups[i])) > backupStamp(filepath.Base(backups[j]))
+	})
+	return backups, nil
+}
+
+// backupStamp returns a key that orders backup names by when they were
+// written: the timestamp of CLAUDE.md.20260122-153045.bak, followed by the
+// number of a second backup within the same second (-2). Returns "" for names
+// of other files.
+func backupStamp(name string) string {
+	stamp, ok := strings.CutPrefix(name, "CLAUDE.md.")
+	if !ok {
+		return ""
+	}
+	if stamp, ok = strings.CutSuffix(stamp, ".bak"); !ok || len(stamp) < len("20060102-150405") {
+		return ""
+	}
+	n := 1
+	if rest := stamp[len("20060102-150405"):]; rest != "" {
+		var err error
+		if n, err = strconv.Atoi(strings.TrimPrefix(rest, "-")); err != nil || !strings.HasPrefix(rest, "-") {
+			return ""
+		}
+	}
+	return fmt.Sprintf("%s-%06d", stamp[:len("20060102-150405")], n)
+}
@@ -4,8 +4,12 @@ import (
 	"bytes"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"text/template"
 
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/detect"
 	"github.com/itda-skills/jindo/internal/guide"
 	"github.com/itda-skills/jindo/internal/prompt"
 	"github.com/spf13/cobra"
@@ -19,6 +23,7 @@ var (
 	claudemdGuideFormat      string
 	claudemdGuideAnalyze     bool
 	claudemdGuideTemplate    bool
+	claudemdGuidePath        string
 )
 
 var claudemdGuideCmd = &cobra.Command{
@@ -33,7 +38,9 @@ The guide provides:
 - Common patterns and anti-patterns
 - Templates for different project types
 
-Use --analyze to get improvement suggestions for your current CLAUDE.md.
+Use --analyze to get improvement suggestions for your current CLAUDE.md, or
+--path to analyze a CLAUDE.md in a subdirectory of the project, checked against
+the stack detected in that directory.
 Use --template to get ready-to-use templates.
 Use -i for interactive mode where AI asks about your context.`,
 	Example: `  # Get general CLAUDE.md best practices guide
@@ -45,6 +52,9 @@ Use -i for interactive mode where AI asks about your context.`,
   # Analyze local CLAUDE.md specifically
   jd claudemd guide --analyze --local
 
+  # Analyze the CLAUDE.md of a subdirectory
+  jd claudemd guide --path src/api
+
   # Get ready-to-use templates
   jd claudemd guide --template
 
@@ -69,6 +79,8 @@ func init() {
 	claudemdGuideCmd.Flags().StringVarP(&claudemdGuideFormat, "format", "f", "", "Output format: html (opens in browser)")
 	claudemdGuideCmd.Flags().BoolVarP(&claudemdGuideAnalyze, "analyze", "a", false, "Analyze current CLAUDE.md and suggest improvements")
 	claudemdGuideCmd.Flags().BoolVarP(&claudemdGuideTemplate, "template", "t", false, "Show ready-to-use CLAUDE.md templates")
+	addCLAUDEmdPathFlag(claudemdGuideCmd, &claudemdGuidePath)
+	claudemdGuideCmd.MarkFlagsMutuallyExclusive("path", "template")
 }
 
 func runClaudemdGuide(cmd *cobra.Command, _ []string) error {
@@ -88,6 +100,11 @@ func runClaudemdGuide(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
+	// A nested CLAUDE.md is only ever analyzed
+	if claudemdGuidePath != "" {
+		claudemdGuideAnalyze = true
+	}
+
 	// Determine mode
 	mode := "general"
 	if claudemdGuideAnalyze {
@@ -97,23 +114,31 @@ func runClaudemdGuide(cmd *cobra.Command, _ []string) error {
 	}
 
 	// For analyze mode, read current CLAUDE.md
-	var claudemdContent string
+	var claudemdContent, stack string
 	if claudemdGuideAnalyze {
-		scope, err := ResolveScope(claudemdGuideGlobal, claudemdGuideLocal)
+		claudemdPath, err := resolveCLAUDEmdPath(claudemdGuidePath, claudemdGuideGlobal, claudemdGuideLocal)
 		if err != nil {
 			return err
 		}
-
-		claudemdPath := getCLAUDEmdPath(scope)
 		content, err := os.ReadFile(claudemdPath)
 		if err != nil {
 			if os.IsNotExist(err) {
-				return fmt.Errorf("CLAUDE.md not found at %s\nCreate one first or use a different scope (--global/--local)", claudemdPath)
+				return notFoundErrorf("CLAUDE.md not found at %s\nCreate one first or use a different scope (--global/--local)", claudemdPath)
 			}
 			return fmt.Errorf("failed to read CLAUDE.md: %w", err)
 		}
 		claudemdContent = string(content)
-		fmt.Printf("📄 분석 대상: %s\n\n", claudemdPath)
+		fmt.Printf("📄 분석 대상: %s\n", claudemdPath)
+
+		// A project CLAUDE.md is checked against the project's detected stack,
+		// and a nested one against the stack of its directory
+		if dir, ok := claudemdStackDir(claudemdPath); ok {
+			if profile := detect.Detect(dir); !profile.Empty() {
+				stack = describeStack(profile)
+				fmt.Printf("🧰 감지된 스택: %s\n", strings.Join(profile.Tags(), ", "))
+			}
+		}
+		fmt.Println()
 	}
 
 	// Interactive mode
@@ -121,7 +146,7 @@ func runClaudemdGuide(cmd *cobra.Command, _ []string) error {
 		if claudemdGuideFormat == "html" {
 			return fmt.Errorf("--format html cannot be used with --interactive")
 		}
-		systemPrompt, err := buildClaudemdGuideSystemPrompt(mode, claudemdContent)
+		systemPrompt, err := buildClaudemdGuideSystemPrompt(mode, claudemdContent, stack)
 		if err != nil {
 			return err
 		}
@@ -149,7 +174,7 @@ func runClaudemdGuide(cmd *cobra.Command, _ []string) error {
 	}
 
 	// Generate new guide
-	systemPrompt, err := buildClaudemdGuideSystemPrompt(mode, claudemdContent)
+	systemPrompt, err := buildClaudemdGuideSystemPrompt(mode, claudemdContent, stack)
 	if err != nil {
 		return err
 	}
@@ -165,7 +190,7 @@ func runClaudemdGuide(cmd *cobra.Command, _ []string) error {
 		// Save to cache (skip for analyze mode as content is context-specific)
 		var createdAt = guide.Guide{}.CreatedAt
 		if mode != "analyze" {
-			savedGuide, err := guideStore.Save(guide.TypeClaudemd, cacheKey, generatedContent)
+			savedGuide, err := guideStore.Save(guide.TypeClaudemd, cacheKey, generatedContent, "")
 			if err != nil {
 				fmt.Printf("⚠️  가이드 저장 실패: %v\n", err)
 			} else {
@@ -183,7 +208,7 @@ func runClaudemdGuide(cmd *cobra.Command, _ []string) error {
 	return nil
 }
 
-func buildClaudemdGuideSystemPrompt(mode, content string) (string, error) {
+func buildClaudemdGuideSystemPrompt(mode, content, stack string) (string, error) {
 	promptTemplate, err := prompt.Load("guide-claudemd")
 	if err != nil {
 		return "", fmt.Errorf("failed to load guide prompt: %w", err)
@@ -198,6 +223,7 @@ func buildClaudemdGuideSystemPrompt(mode, content string) (string, error) {
 	err = tmpl.Execute(&systemPrompt, map[string]string{
 		"Mode":    mode,
 		"Content": content,
+		"Stack":   stack,
 	})
 	if err != nil {
 		return "", fmt.Errorf("failed to render prompt: %w", err)
@@ -206,6 +232,22 @@ func buildClaudemdGuideSystemPrompt(mode, content string) (string, error) {
 	return systemPrompt.String(), nil
 }
 
+// describeStack lists a detected stack for the analyze prompt, with each language's commands
+func describeStack(profile *detect.Profile) string {
+	var b strings.Builder
+	for _, line := range profile.Lines() {
+		fmt.Fprintf(&b, "- %s\n", line)
+	}
+	for _, l := range profile.Languages {
+		for _, c := range [][2]string{{"build", l.Build}, {"test", l.Test}, {"lint", l.Lint}, {"formatter", l.Formatter}} {
+			if c[1] != "" {
+				fmt.Fprintf(&b, "- %s %s: %s\n", l.Name, c[0], c[1])
+			}
+		}
+	}
+	return b.String()
+}
+
 func getGuideTitle(mode string) string {
 	switch mode {
 	case "analyze":
@@ -227,3 +269,17 @@ func getGuideUserPrompt(mode string) string {
 		return "CLAUDE.md 작성에 대한 베스트 프랙티스 가이드를 작성해주세요."
 	}
 }
+
+// claudemdStackDir returns the directory whose stack a CLAUDE.md is checked
+// against: the project root for .claude/CLAUDE.md, or the directory of a
+// CLAUDE.md elsewhere in the project. The global CLAUDE.md has none.
+func claudemdStackDir(path string) (string, bool) {
+	if global, err := basedir.ClaudePath("CLAUDE.md"); err == nil && filepath.Clean(global) == filepath.Clean(path) {
+		return "", false
+	}
+	dir := filepath.Dir(path)
+	if filepath.Base(dir) == localClaudeDir {
+		dir = filepath.Dir(dir)
+	}
+	return dir, true
+}
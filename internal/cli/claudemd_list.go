@@ -0,0 +1,219 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/itda-skills/jindo/internal/aicost"
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/claudemd"
+	"github.com/itda-skills/jindo/internal/table"
+	"github.com/spf13/cobra"
+)
+
+var (
+	claudemdListTree bool
+	claudemdListJSON bool
+)
+
+var claudemdListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List CLAUDE.md files with their sizes and headings",
+	Long: `List the CLAUDE.md files Claude Code reads in this project: the global
+~/.claude/CLAUDE.md, the project's .claude/CLAUDE.md, and CLAUDE.md at the
+project root, with the size, estimated tokens, and headings of each. Files
+over ` + tokensClaudemdWarnKey + ` estimated tokens (default 4000; 0 turns it
+off) are reported, with the tidy command that compresses them.
+
+Claude Code also reads CLAUDE.md files in subdirectories when it works on
+files there. With --tree, every CLAUDE.md under the project root is listed,
+with its headings, so you can see which instructions apply where.
+Dependency and build directories (node_modules, vendor, dist, build, target)
+and hidden directories other than .claude are skipped.
+
+A nested file is picked with --path in tidy, guide, backup, and restore.`,
+	Example: `  jd claudemd list
+  jd claudemd list --tree
+  jd claudemd list --tree --json
+  jd claudemd tidy --path src/api`,
+	Args: cobra.NoArgs,
+	RunE: runClaudemdList,
+}
+
+func init() {
+	claudemdCmd.AddCommand(claudemdListCmd)
+	claudemdListCmd.Flags().BoolVar(&claudemdListTree, "tree", false, "List every CLAUDE.md under the project root with its headings")
+	claudemdListCmd.Flags().BoolVar(&claudemdListJSON, "json", false, "Output in JSON format")
+	addOutputFlags(claudemdListCmd)
+}
+
+// claudemdListEntry is a CLAUDE.md file in the output of jd claudemd list
+type claudemdListEntry struct {
+	claudemd.File
+	Scope  string `json:"scope"` // global or local
+	Tokens int    `json:"tokens"`
+}
+
+func runClaudemdList(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+
+	root, entries, files, err := collectClaudemdFiles(claudemdListTree)
+	if err != nil {
+		return err
+	}
+	home, _ := os.UserHomeDir()
+	inHome := filepath.Clean(root) == filepath.Clean(home)
+
+	if claudemdListJSON || formattedOutput() {
+		return printJSON(entries)
+	}
+	if len(entries) == 0 {
+		fmt.Println("No CLAUDE.md files found.")
+		return nil
+	}
+	if claudemdListTree {
+		printClaudemdTree(root, entries)
+		warnClaudemdTokens(entries)
+		return nil
+	}
+
+	t := newTable(
+		table.Column{Header: "SCOPE"},
+		table.Column{Header: "PATH", Max: 50},
+		table.Column{Header: "SIZE"},
+		table.Column{Header: "TOKENS"},
+		table.Column{Header: "HEADINGS", Max: 60, Wrap: true},
+	)
+	for _, e := range entries {
+		t.AddRow(e.Scope, e.Rel, formatBytes(e.Size), formatTokens(e.Tokens), topHeadings(e.Headings))
+	}
+	t.Render(os.Stdout)
+	if !inHome {
+		if nested, err := claudemd.Find(root); err == nil && len(nested) > len(files) {
+			fmt.Printf("\n%d more in subdirectories; see 'jd claudemd list --tree'\n", len(nested)-len(files))
+		}
+	}
+	warnClaudemdTokens(entries)
+	return nil
+}
+
+// warnClaudemdTokens warns about the CLAUDE.md files over tokens.claudemd_warn
+func warnClaudemdTokens(entries []claudemdListEntry) {
+	limit, _ := tokenThresholds()
+	for _, e := range entries {
+		if overTokens(e.Tokens, limit) {
+			printTokenWarning(e.Rel, e.Tokens, limit, tokensClaudemdWarnKey, claudemdTidyCommand(e, limit))
+		}
+	}
+}
+
+// claudemdTidyCommand returns the command that compresses a CLAUDE.md to a token budget
+func claudemdTidyCommand(e claudemdListEntry, target int) string {
+	cmd := fmt.Sprintf("jd claudemd tidy --target-tokens %d", target)
+	switch {
+	case e.Scope == string(ScopeGlobal):
+		return cmd + " --global"
+	case e.Rel == localClaudeDir+"/"+claudemd.FileName:
+		return cmd + " --local"
+	}
+	dir := filepath.Dir(e.Path)
+	if cwd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(cwd, dir); err == nil {
+			dir = rel
+		}
+	}
+	return cmd + " --path " + dir
+}
+
+// collectClaudemdFiles returns the project root and the CLAUDE.md files of the
+// global scope and the project: with tree, every one under the project root.
+// files are the project's, without the global one.
+func collectClaudemdFiles(tree bool) (root string, entries []claudemdListEntry, files []claudemd.File, err error) {
+	root, err = ProjectRoot()
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("failed to find the project root: %w", err)
+	}
+	// Outside a project the root is the working directory, which may be home
+	home, _ := os.UserHomeDir()
+	inHome := filepath.Clean(root) == filepath.Clean(home)
+	if tree && inHome {
+		return "", nil, nil, validationErrorf("--tree lists a project's CLAUDE.md files; run it in a project, not in the home directory")
+	}
+	global, _ := basedir.ClaudePath(claudemd.FileName)
+
+	if tree {
+		if files, err = claudemd.Find(root); err != nil {
+			return "", nil, nil, fmt.Errorf("failed to find CLAUDE.md files: %w", err)
+		}
+	} else {
+		for _, rel := range []string{localClaudeDir + "/" + claudemd.FileName, claudemd.FileName} {
+			path := filepath.Join(root, rel)
+			if filepath.Clean(path) == filepath.Clean(global) {
+				continue
+			}
+			if f, err := claudemd.ReadFile(path); err == nil {
+				f.Rel = rel
+				files = append(files, *f)
+			}
+		}
+	}
+
+	entries = []claudemdListEntry{}
+	// The global file applies everywhere, so it is listed first
+	if f, err := claudemd.ReadFile(global); err == nil {
+		f.Rel = basedir.ClaudeDir() + "/" + claudemd.FileName
+		entries = append(entries, claudemdEntry(*f, string(ScopeGlobal)))
+	}
+	for _, f := range files {
+		entries = append(entries, claudemdEntry(f, string(ScopeLocal)))
+	}
+	return root, entries, files, nil
+}
+
+// claudemdEntry returns the list entry of a CLAUDE.md file
+func claudemdEntry(f claudemd.File, scope string) claudemdListEntry {
+	tokens := 0
+	if data, err := os.ReadFile(f.Path); err == nil {
+		tokens = aicost.EstimateTokens(string(data))
+	}
+	return claudemdListEntry{File: f, Scope: scope, Tokens: tokens}
+}
+
+// topHeadings joins the top-level headings of a file: those of the smallest
+// heading level it uses, and the level below
+func topHeadings(headings []claudemd.Heading) string {
+	if len(headings) == 0 {
+		return "-"
+	}
+	top := headings[0].Level
+	for _, h := range headings {
+		top = min(top, h.Level)
+	}
+	var texts []string
+	for _, h := range headings {
+		if h.Level <= top+1 {
+			texts = append(texts, h.Text)
+		}
+	}
+	return strings.Join(texts, " · ")
+}
+
+// printClaudemdTree prints CLAUDE.md files with their headings, indented by level
+func printClaudemdTree(root string, entries []claudemdListEntry) {
+	fmt.Printf("%s\n\n", root)
+	var total int64
+	for _, e := range entries {
+		total += e.Size
+		fmt.Printf("%s  (%s, %s tokens)\n", e.Rel, formatBytes(e.Size), formatTokens(e.Tokens))
+		for _, h := range e.Headings {
+			if h.Level <= 3 {
+				fmt.Printf("  %s%s %s\n", strings.Repeat("  ", h.Level-1), strings.Repeat("#", h.Level), h.Text)
+			}
+		}
+		fmt.Println()
+	}
+	fmt.Printf("%d CLAUDE.md files, %s\n", len(entries), formatBytes(total))
+}
@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/itda-skills/jindo/internal/claudemd"
+	"github.com/itda-skills/jindo/internal/pkg/pkgmgr"
+	"github.com/itda-skills/jindo/internal/pkg/repo"
+	"github.com/spf13/cobra"
+)
+
+var claudemdReferenceCmd = &cobra.Command{
+	Use:     "reference",
+	Aliases: []string{"ref"},
+	Short:   "Manage the list of available skills in CLAUDE.md",
+	Long: `Manage the "Available Skills" section of CLAUDE.md.
+
+The section lists each referenced skill with its name and when to use it
+(from its description), so Claude reliably discovers installed skills. It is
+delimited by <!-- jd:skills:start --> and <!-- jd:skills:end --> comments;
+the rest of CLAUDE.md is left untouched.
+
+Once the section exists, 'jd pkg install' adds newly installed skills to it,
+'jd pkg update' refreshes their descriptions, and 'jd pkg uninstall' removes
+them.`,
+}
+
+func init() {
+	claudemdCmd.AddCommand(claudemdReferenceCmd)
+}
+
+// syncPackageSkillReference updates the skill references in the CLAUDE.md of the directory
+// a skill package is installed into, if that CLAUDE.md has a managed section. Failures are
+// reported as warnings, since the package operation itself succeeded.
+func syncPackageSkillReference(pkg *pkgmgr.InstalledPackage, installed bool) {
+	if pkg.Type != repo.TypeSkill || pkg.Target != "" {
+		return
+	}
+	mdPath, changed, err := claudemd.SyncSkill(pkg.ClaudeDir, pkg.Name, installed)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to update skill references: %v\n", err)
+		return
+	}
+	if changed {
+		fmt.Printf("Updated skill references in %s\n", mdPath)
+	}
+}
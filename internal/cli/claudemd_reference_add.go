@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/itda-skills/jindo/internal/claudemd"
+	"github.com/itda-skills/jindo/internal/skill"
+	"github.com/spf13/cobra"
+)
+
+var (
+	claudemdReferenceAddGlobal bool
+	claudemdReferenceAddLocal  bool
+)
+
+var claudemdReferenceAddCmd = &cobra.Command{
+	Use:   "add <skill-id>...",
+	Short: "Add skills to the Available Skills section of CLAUDE.md",
+	Long: `Add skills to the "Available Skills" section of CLAUDE.md, creating the
+section at the end of the file if it does not exist yet.
+
+Skills are looked up in the skills directory of the same scope as CLAUDE.md.
+Default scope is local (.claude/CLAUDE.md) if present, otherwise global
+(~/.claude/CLAUDE.md).`,
+	Example: `  jd claudemd reference add affa-ever--web-fetch
+  jd claudemd reference add deploy code-review --global`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runClaudemdReferenceAdd,
+}
+
+func init() {
+	claudemdReferenceCmd.AddCommand(claudemdReferenceAddCmd)
+	claudemdReferenceAddCmd.Flags().BoolVarP(&claudemdReferenceAddGlobal, "global", "g", false, "Use global ~/.claude/CLAUDE.md")
+	claudemdReferenceAddCmd.Flags().BoolVarP(&claudemdReferenceAddLocal, "local", "l", false, "Use local .claude/CLAUDE.md")
+}
+
+func runClaudemdReferenceAdd(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	scope, err := ResolveScope(claudemdReferenceAddGlobal, claudemdReferenceAddLocal)
+	if err != nil {
+		return err
+	}
+	skillsDir, err := expandScopeDir(GetPathByScope(scope, "skills"))
+	if err != nil {
+		return err
+	}
+
+	store := skill.NewStore(skillsDir)
+	for _, id := range args {
+		if _, err := store.Get(id); err != nil {
+			return notFoundErrorf("skill '%s' not found in %s", id, ScopeDescription(scope))
+		}
+	}
+
+	mdPath, err := getCLAUDEmdPathForWrite(scope)
+	if err != nil {
+		return err
+	}
+	changed, err := claudemd.UpdateFile(mdPath, skillsDir, args, nil, true)
+	if err != nil {
+		return fmt.Errorf("failed to update skill references: %w", err)
+	}
+	if !changed {
+		fmt.Printf("%s already references the given skill(s).\n", mdPath)
+		return nil
+	}
+	fmt.Printf("Added %d skill reference(s) to %s\n", len(args), mdPath)
+	return nil
+}
@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/itda-skills/jindo/internal/claudemd"
+	"github.com/spf13/cobra"
+)
+
+var (
+	claudemdReferenceRemoveGlobal bool
+	claudemdReferenceRemoveLocal  bool
+)
+
+var claudemdReferenceRemoveCmd = &cobra.Command{
+	Use:     "remove <skill-id>...",
+	Aliases: []string{"rm"},
+	Short:   "Remove skills from the Available Skills section of CLAUDE.md",
+	Long: `Remove skills from the "Available Skills" section of CLAUDE.md.
+
+Removing the last skill removes the section, which also stops 'jd pkg install'
+from adding installed skills to it.`,
+	Example: `  jd claudemd reference remove affa-ever--web-fetch`,
+	Args:    cobra.MinimumNArgs(1),
+	RunE:    runClaudemdReferenceRemove,
+}
+
+func init() {
+	claudemdReferenceCmd.AddCommand(claudemdReferenceRemoveCmd)
+	claudemdReferenceRemoveCmd.Flags().BoolVarP(&claudemdReferenceRemoveGlobal, "global", "g", false, "Use global ~/.claude/CLAUDE.md")
+	claudemdReferenceRemoveCmd.Flags().BoolVarP(&claudemdReferenceRemoveLocal, "local", "l", false, "Use local .claude/CLAUDE.md")
+}
+
+func runClaudemdReferenceRemove(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	scope, err := ResolveScope(claudemdReferenceRemoveGlobal, claudemdReferenceRemoveLocal)
+	if err != nil {
+		return err
+	}
+	skillsDir, err := expandScopeDir(GetPathByScope(scope, "skills"))
+	if err != nil {
+		return err
+	}
+
+	mdPath := getCLAUDEmdPath(scope)
+	content, err := os.ReadFile(mdPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", mdPath, err)
+	}
+	refs, _ := claudemd.ParseReferences(string(content))
+	for _, id := range args {
+		found := false
+		for _, r := range refs {
+			if r.ID == id {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return notFoundErrorf("skill '%s' is not referenced in %s", id, mdPath)
+		}
+	}
+
+	if _, err := claudemd.UpdateFile(mdPath, skillsDir, nil, args, false); err != nil {
+		return fmt.Errorf("failed to update skill references: %w", err)
+	}
+	fmt.Printf("Removed %d skill reference(s) from %s\n", len(args), mdPath)
+	return nil
+}
@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/itda-skills/jindo/internal/claudemd"
+	"github.com/spf13/cobra"
+)
+
+var (
+	claudemdReferenceSyncGlobal bool
+	claudemdReferenceSyncLocal  bool
+)
+
+var claudemdReferenceSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Refresh the Available Skills section of CLAUDE.md",
+	Long: `Refresh the "Available Skills" section of CLAUDE.md from the skills on disk:
+names and descriptions are updated, and skills that no longer exist are removed.
+
+Package installs, updates, and uninstalls do this automatically; use sync
+after creating, editing, or deleting skills by hand.`,
+	Example: `  jd claudemd reference sync
+  jd claudemd reference sync --global`,
+	Args: cobra.NoArgs,
+	RunE: runClaudemdReferenceSync,
+}
+
+func init() {
+	claudemdReferenceCmd.AddCommand(claudemdReferenceSyncCmd)
+	claudemdReferenceSyncCmd.Flags().BoolVarP(&claudemdReferenceSyncGlobal, "global", "g", false, "Use global ~/.claude/CLAUDE.md")
+	claudemdReferenceSyncCmd.Flags().BoolVarP(&claudemdReferenceSyncLocal, "local", "l", false, "Use local .claude/CLAUDE.md")
+}
+
+func runClaudemdReferenceSync(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+
+	scope, err := ResolveScope(claudemdReferenceSyncGlobal, claudemdReferenceSyncLocal)
+	if err != nil {
+		return err
+	}
+	skillsDir, err := expandScopeDir(GetPathByScope(scope, "skills"))
+	if err != nil {
+		return err
+	}
+
+	mdPath := getCLAUDEmdPath(scope)
+	changed, err := claudemd.UpdateFile(mdPath, skillsDir, nil, nil, false)
+	if err != nil {
+		return fmt.Errorf("failed to update skill references: %w", err)
+	}
+	if changed {
+		fmt.Printf("Updated skill references in %s\n", mdPath)
+	} else {
+		fmt.Printf("Skill references in %s are up to date.\n", mdPath)
+	}
+	return nil
+}
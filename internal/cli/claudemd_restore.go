@@ -0,0 +1,145 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/itda-skills/jindo/internal/table"
+	"github.com/spf13/cobra"
+)
+
+var (
+	claudemdRestoreGlobal bool
+	claudemdRestoreLocal  bool
+	claudemdRestorePath   string
+	claudemdRestoreList   bool
+	claudemdRestoreYes    bool
+)
+
+var claudemdRestoreCmd = &cobra.Command{
+	Use:   "restore [backup]",
+	Short: "Restore CLAUDE.md from a backup",
+	Long: `Restore CLAUDE.md from a backup written by backup, tidy, or edit: the newest
+one, or the one named (as listed by --list). The current content is backed up
+first, so a restore can be undone by restoring that backup.
+
+Default scope is local (.claude/CLAUDE.md) if present, otherwise global (~/.claude/CLAUDE.md).
+Use --path for a CLAUDE.md elsewhere in the project.`,
+	Example: `  jd claudemd restore --list
+  jd claudemd restore
+  jd claudemd restore CLAUDE.md.20260122-153045.bak
+  jd claudemd restore --path src/api -y`,
+	Args:              cobra.MaximumNArgs(1),
+	RunE:              runClaudemdRestore,
+	ValidArgsFunction: claudemdBackupCompletion,
+}
+
+func init() {
+	claudemdCmd.AddCommand(claudemdRestoreCmd)
+	claudemdRestoreCmd.Flags().BoolVarP(&claudemdRestoreGlobal, "global", "g", false, "Restore global ~/.claude/CLAUDE.md")
+	claudemdRestoreCmd.Flags().BoolVarP(&claudemdRestoreLocal, "local", "l", false, "Restore local .claude/CLAUDE.md")
+	addCLAUDEmdPathFlag(claudemdRestoreCmd, &claudemdRestorePath)
+	claudemdRestoreCmd.Flags().BoolVar(&claudemdRestoreList, "list", false, "List the backups instead of restoring one")
+	claudemdRestoreCmd.Flags().BoolVarP(&claudemdRestoreYes, "yes", "y", false, "Restore without asking")
+}
+
+func runClaudemdRestore(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	path, err := resolveCLAUDEmdPath(claudemdRestorePath, claudemdRestoreGlobal, claudemdRestoreLocal)
+	if err != nil {
+		return err
+	}
+	backups, err := claudemdBackups(path)
+	if err != nil {
+		return fmt.Errorf("failed to list backups: %w", err)
+	}
+	if len(backups) == 0 {
+		return notFoundErrorf("no backups of %s in %s", path, claudemdBackupDir(path))
+	}
+
+	if claudemdRestoreList {
+		t := newTable(table.Column{Header: "BACKUP"}, table.Column{Header: "WRITTEN"}, table.Column{Header: "SIZE"})
+		for _, b := range backups {
+			info, err := os.Stat(b)
+			if err != nil {
+				continue
+			}
+			t.AddRow(filepath.Base(b), info.ModTime().Format("2006-01-02 15:04:05"), formatBytes(info.Size()))
+		}
+		fmt.Printf("Backups of %s (newest first):\n\n", path)
+		t.Render(os.Stdout)
+		return nil
+	}
+
+	backup := backups[0]
+	if len(args) > 0 {
+		backup = ""
+		for _, b := range backups {
+			if args[0] == filepath.Base(b) || filepath.Clean(args[0]) == b {
+				backup = b
+			}
+		}
+		if backup == "" {
+			return notFoundErrorf("backup not found: %s (see 'jd claudemd restore --list')", args[0])
+		}
+	}
+
+	content, err := os.ReadFile(backup)
+	if err != nil {
+		return fmt.Errorf("failed to read backup: %w", err)
+	}
+	current, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read CLAUDE.md: %w", err)
+	}
+	if err == nil && string(current) == string(content) {
+		fmt.Printf("%s already matches %s.\n", path, filepath.Base(backup))
+		return nil
+	}
+
+	if !claudemdRestoreYes {
+		fmt.Printf("Replace %s with %s? (y/N): ", path, filepath.Base(backup))
+		reader := bufio.NewReader(os.Stdin)
+		response, _ := reader.ReadString('\n')
+		response = strings.TrimSpace(strings.ToLower(response))
+		if response != "y" && response != "yes" {
+			fmt.Println("Cancelled.")
+			return errCancelled
+		}
+	}
+
+	// The content being replaced is kept, so the restore can be undone
+	if current != nil {
+		saved, err := writeCLAUDEmdBackup(path, string(current))
+		if err != nil {
+			return fmt.Errorf("failed to backup CLAUDE.md: %w", err)
+		}
+		fmt.Printf("💾 Backup of the replaced content: %s\n", saved)
+	}
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("failed to restore CLAUDE.md: %w", err)
+	}
+	fmt.Printf("✅ Restored %s from %s\n", path, filepath.Base(backup))
+	return nil
+}
+
+// claudemdBackupCompletion completes the backups of the CLAUDE.md picked by the flags given so far
+func claudemdBackupCompletion(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	path, err := resolveCLAUDEmdPath(claudemdRestorePath, claudemdRestoreGlobal, claudemdRestoreLocal)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	backups, _ := claudemdBackups(path)
+	names := make([]string, 0, len(backups))
+	for _, b := range backups {
+		names = append(names, filepath.Base(b))
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
+}
@@ -1,15 +1,22 @@
 package cli
 
 import (
+	"bufio"
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"text/template"
 	"time"
 
+	"github.com/itda-skills/jindo/internal/aicost"
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/claudemd"
 	"github.com/itda-skills/jindo/internal/prompt"
 	"github.com/sergi/go-diff/diffmatchpatch"
 	"github.com/spf13/cobra"
@@ -20,6 +27,9 @@ var (
 	claudemdTidyLocal  bool
 	claudemdTidyDryRun bool
 	claudemdTidyStyle  string
+	claudemdTidyYes    bool
+	claudemdTidyPath   string
+	claudemdTidyTarget int
 )
 
 var claudemdTidyCmd = &cobra.Command{
@@ -33,8 +43,19 @@ Uses Claude CLI to analyze the CLAUDE.md file and:
 - Ensure consistency
 - Apply style preferences
 
-The original file is backed up before any changes.
+The original file is backed up before any changes. If the file is edited
+while Claude works on it, the edits are merged into the tidied version after
+confirmation; when they change the same lines (or without a terminal), the
+file is left as is and the tidied version is saved to CLAUDE.md.tidy-proposed.
+With --target-tokens, Claude is asked to compress the file to at most that
+many estimated tokens, keeping every rule that changes how it works; the
+counts before and after are printed. 'jd claudemd list' reports files over
+` + tokensClaudemdWarnKey + `.
+The estimated Claude usage
+is printed first; see 'jd stats ai' for the budget settings.
 Default scope is local (.claude/CLAUDE.md) if present, otherwise global (~/.claude/CLAUDE.md).
+Use --path to tidy a CLAUDE.md in a subdirectory of the project instead; its
+backups go to .claude/backups/project/<directory>/.
 
 Requires Claude CLI: npm install -g @anthropic-ai/claude-cli`,
 	Example: `  # Tidy local CLAUDE.md (if exists) or global
@@ -46,8 +67,14 @@ Requires Claude CLI: npm install -g @anthropic-ai/claude-cli`,
   # Preview changes without applying
   jd claudemd tidy --dry-run
 
+  # Compress to a token budget
+  jd claudemd tidy --target-tokens 2000
+
   # Tidy global CLAUDE.md explicitly
-  jd claudemd tidy --global`,
+  jd claudemd tidy --global
+
+  # Tidy the CLAUDE.md of a subdirectory
+  jd claudemd tidy --path src/api`,
 	RunE: runClaudemdTidy,
 }
 
@@ -58,6 +85,9 @@ func init() {
 	claudemdTidyCmd.Flags().BoolVarP(&claudemdTidyLocal, "local", "l", false, "Tidy local .claude/CLAUDE.md")
 	claudemdTidyCmd.Flags().BoolVar(&claudemdTidyDryRun, "dry-run", false, "Preview changes without applying")
 	claudemdTidyCmd.Flags().StringVar(&claudemdTidyStyle, "style", "structured", "Style: minimal, detailed, structured")
+	claudemdTidyCmd.Flags().BoolVarP(&claudemdTidyYes, "yes", "y", false, "Run even if the estimated cost is over budget")
+	addCLAUDEmdPathFlag(claudemdTidyCmd, &claudemdTidyPath)
+	claudemdTidyCmd.Flags().IntVar(&claudemdTidyTarget, "target-tokens", 0, "Compress CLAUDE.md to at most this many estimated tokens")
 }
 
 func runClaudemdTidy(cmd *cobra.Command, _ []string) error {
@@ -67,9 +97,12 @@ func runClaudemdTidy(cmd *cobra.Command, _ []string) error {
 	if err := validateStyle(claudemdTidyStyle); err != nil {
 		return err
 	}
+	if claudemdTidyTarget < 0 {
+		return validationErrorf("--target-tokens must be positive")
+	}
 
-	// Validate and resolve scope
-	scope, err := ResolveScope(claudemdTidyGlobal, claudemdTidyLocal)
+	// Get CLAUDE.md path
+	claudemdPath, err := resolveCLAUDEmdPath(claudemdTidyPath, claudemdTidyGlobal, claudemdTidyLocal)
 	if err != nil {
 		return err
 	}
@@ -79,12 +112,9 @@ func runClaudemdTidy(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
-	// Get CLAUDE.md path
-	claudemdPath := getCLAUDEmdPath(scope)
-
 	// Check if CLAUDE.md exists
 	if _, err := os.Stat(claudemdPath); os.IsNotExist(err) {
-		return fmt.Errorf("CLAUDE.md not found at %s", claudemdPath)
+		return notFoundErrorf("CLAUDE.md not found at %s", claudemdPath)
 	}
 
 	// Read current content
@@ -93,6 +123,16 @@ func runClaudemdTidy(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("failed to read CLAUDE.md: %w", err)
 	}
 
+	// Estimate the cost before spending it
+	tidyPrompt, err := renderTidyPrompt(string(originalContent), claudemdTidyStyle, claudemdTidyTarget)
+	if err != nil {
+		return err
+	}
+	run := newAIRun("claudemd tidy", claudemdPath, aicost.EstimateTokens(string(originalContent)), tidyPrompt)
+	if err := confirmAICost(run.estimate, "", claudemdTidyYes); err != nil {
+		return err
+	}
+
 	// Create backup (unless dry-run)
 	var backupPath string
 	if !claudemdTidyDryRun {
@@ -104,13 +144,14 @@ func runClaudemdTidy(cmd *cobra.Command, _ []string) error {
 
 	// Run Claude to tidy the content
 	fmt.Printf("🔍 Analyzing CLAUDE.md with Claude CLI (style: %s)...\n", claudemdTidyStyle)
-	tidiedContent, err := runClaudeTidy(string(originalContent), claudemdTidyStyle)
+	tidiedContent, err := runClaudeTidy(tidyPrompt)
 	if err != nil {
 		if backupPath != "" {
 			return fmt.Errorf("%w\n\nBackup preserved at: %s", err, backupPath)
 		}
 		return err
 	}
+	recordAIRun(run, tidiedContent)
 
 	// Validate output
 	if len(strings.TrimSpace(tidiedContent)) == 0 {
@@ -123,10 +164,21 @@ func runClaudemdTidy(cmd *cobra.Command, _ []string) error {
 	// If dry-run, show diff and exit
 	if claudemdTidyDryRun {
 		showDiff(string(originalContent), tidiedContent)
+		printTidyTokens(string(originalContent), tidiedContent)
 		fmt.Println("\n💡 To apply changes, run without --dry-run")
 		return nil
 	}
 
+	// The file may have been edited while Claude was working on it (an open
+	// editor, another process): merge those edits instead of overwriting them
+	currentContent, err := os.ReadFile(claudemdPath)
+	if err != nil {
+		return fmt.Errorf("failed to read CLAUDE.md: %w\n\nBackup preserved at: %s", err, backupPath)
+	}
+	if contentHash(currentContent) != contentHash(originalContent) {
+		return resolveTidyConflict(claudemdPath, string(originalContent), string(currentContent), tidiedContent, backupPath)
+	}
+
 	// Write tidied content to file
 	if err := os.WriteFile(claudemdPath, []byte(tidiedContent), 0644); err != nil {
 		return fmt.Errorf("failed to write tidied CLAUDE.md: %w\n\nBackup preserved at: %s", err, backupPath)
@@ -137,7 +189,97 @@ func runClaudemdTidy(cmd *cobra.Command, _ []string) error {
 	fmt.Printf("\n📍 Location: %s\n", claudemdPath)
 	fmt.Printf("💾 Backup: %s\n", backupPath)
 	fmt.Printf("🎨 Style: %s\n", claudemdTidyStyle)
+	printTidyTokens(string(originalContent), tidiedContent)
+
+	return nil
+}
+
+// printTidyTokens prints the estimated tokens of CLAUDE.md before and after
+// tidying, and whether the result is over --target-tokens
+func printTidyTokens(original, tidied string) {
+	before, after := aicost.EstimateTokens(original), aicost.EstimateTokens(tidied)
+	fmt.Printf("🔢 Tokens: %s → %s\n", formatTokens(before), formatTokens(after))
+	if overTokens(after, claudemdTidyTarget) {
+		fmt.Fprintf(os.Stderr, "⚠️  Still over --target-tokens %d; run tidy again, or trim what Claude kept by hand\n", claudemdTidyTarget)
+	}
+}
+
+// tidyProposedSuffix is appended to the path of a CLAUDE.md to save a tidied
+// version that could not be written over it
+const tidyProposedSuffix = ".tidy-proposed"
+
+// contentHash returns the SHA-256 of file content, to tell whether it changed
+func contentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// resolveTidyConflict handles a CLAUDE.md edited while it was being tidied. The
+// edits and the tidied version are merged line by line; the merge is written
+// after confirmation, with the edited file backed up. If they change the same
+// lines, or the merge is declined, the file is left as is and the tidied
+// version is saved next to it.
+func resolveTidyConflict(path, original, current, tidied, backupPath string) error {
+	fmt.Println("\n⚠️  CLAUDE.md was changed while it was being tidied.")
+
+	// Claude's output is trimmed: end it like the file, so the last line merges
+	theirs := tidied
+	if strings.HasSuffix(original, "\n") && !strings.HasSuffix(theirs, "\n") {
+		theirs += "\n"
+	}
+	merged, ok := claudemd.Merge3(original, current, theirs)
+	switch {
+	case !ok:
+		fmt.Println("Your changes and the tidied version change the same lines, so they cannot be merged automatically.")
+	case !isTerminal(os.Stdin):
+		fmt.Println("Your changes and the tidied version can be merged, but there is no terminal to confirm it.")
+	default:
+		fmt.Println("Your changes and the tidied version can be merged. Changes the merge makes to your file:")
+		showDiff(current, merged)
+		fmt.Print("Write the merged version? (y/N): ")
+		reader := bufio.NewReader(os.Stdin)
+		response, _ := reader.ReadString('\n')
+		response = strings.TrimSpace(strings.ToLower(response))
+		if response == "y" || response == "yes" {
+			return writeTidyMerge(path, current, merged, backupPath)
+		}
+	}
+
+	proposedPath := path + tidyProposedSuffix
+	if err := os.WriteFile(proposedPath, []byte(tidied), 0644); err != nil {
+		return fmt.Errorf("failed to write tidied version: %w", err)
+	}
+	fmt.Println("\n📝 CLAUDE.md was left as is. The tidied version is saved for you to merge by hand:")
+	fmt.Printf("   %s\n", proposedPath)
+	fmt.Printf("💾 Backup of the file before your changes: %s\n", backupPath)
+	return nil
+}
+
+// writeTidyMerge writes the merge of a tidied CLAUDE.md and edits made meanwhile,
+// unless the file changed again since, backing up the edited file first
+func writeTidyMerge(path, current, merged, backupPath string) error {
+	latest, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read CLAUDE.md: %w", err)
+	}
+	if contentHash(latest) != contentHash([]byte(current)) {
+		if err := os.WriteFile(path+tidyProposedSuffix, []byte(merged), 0644); err != nil {
+			return fmt.Errorf("failed to write merged version: %w", err)
+		}
+		return fmt.Errorf("CLAUDE.md changed again; the merged version is saved to %s", path+tidyProposedSuffix)
+	}
+
+	editedBackup, err := writeCLAUDEmdBackup(path, current)
+	if err != nil {
+		return fmt.Errorf("failed to backup CLAUDE.md: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(merged), 0644); err != nil {
+		return fmt.Errorf("failed to write merged CLAUDE.md: %w\n\nBackup preserved at: %s", err, editedBackup)
+	}
 
+	fmt.Println("\n✅ CLAUDE.md tidied, keeping your changes!")
+	fmt.Printf("\n📍 Location: %s\n", path)
+	fmt.Printf("💾 Backups: %s (before tidy), %s (with your changes)\n", backupPath, editedBackup)
 	return nil
 }
 
@@ -154,22 +296,105 @@ func checkClaudeInstalled() error {
 func getCLAUDEmdPath(scope PathScope) string {
 	switch scope {
 	case ScopeGlobal:
-		home, _ := os.UserHomeDir()
-		return filepath.Join(home, ".claude", "CLAUDE.md")
+		path, _ := basedir.ClaudePath("CLAUDE.md")
+		return path
 	case ScopeLocal:
-		cwd, _ := os.Getwd()
-		return filepath.Join(cwd, ".claude", "CLAUDE.md")
+		root, _ := ProjectRoot()
+		return filepath.Join(root, ".claude", "CLAUDE.md")
 	default:
 		// This shouldn't happen as ResolveScope handles it, but handle anyway
 		if LocalClaudeDirExists() {
-			cwd, _ := os.Getwd()
-			return filepath.Join(cwd, ".claude", "CLAUDE.md")
+			root, _ := ProjectRoot()
+			return filepath.Join(root, ".claude", "CLAUDE.md")
 		}
-		home, _ := os.UserHomeDir()
-		return filepath.Join(home, ".claude", "CLAUDE.md")
+		path, _ := basedir.ClaudePath("CLAUDE.md")
+		return path
 	}
 }
 
+// resolveCLAUDEmdPath returns the CLAUDE.md a command works on: the one of
+// --path, a file or a directory holding one (relative to the working
+// directory), or else the one of the scope of --global/--local
+func resolveCLAUDEmdPath(pathFlag string, global, local bool) (string, error) {
+	if pathFlag == "" {
+		scope, err := ResolveScope(global, local)
+		if err != nil {
+			return "", err
+		}
+		return getCLAUDEmdPath(scope), nil
+	}
+	path, err := basedir.Expand(pathFlag)
+	if err != nil {
+		return "", err
+	}
+	if path, err = filepath.Abs(path); err != nil {
+		return "", err
+	}
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		path = filepath.Join(path, claudemd.FileName)
+	}
+	return path, nil
+}
+
+// addCLAUDEmdPathFlag adds --path, which picks a nested CLAUDE.md instead of
+// the one of --global/--local
+func addCLAUDEmdPathFlag(cmd *cobra.Command, p *string) {
+	cmd.Flags().StringVar(p, "path", "", "A nested CLAUDE.md, or the directory holding it (see 'jd claudemd list --tree')")
+	cmd.MarkFlagsMutuallyExclusive("path", "global")
+	cmd.MarkFlagsMutuallyExclusive("path", "local")
+	_ = cmd.RegisterFlagCompletionFunc("path", claudemdPathCompletion)
+}
+
+// claudemdPathCompletion completes --path with the directories of the
+// project's CLAUDE.md files, relative to the working directory
+func claudemdPathCompletion(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	root, err := ProjectRoot()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	files, err := claudemd.Find(root)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	cwd, _ := os.Getwd()
+	var dirs []string
+	for _, f := range files {
+		if rel, err := filepath.Rel(cwd, filepath.Dir(f.Path)); err == nil {
+			dirs = append(dirs, rel)
+		}
+	}
+	return dirs, cobra.ShellCompDirectiveNoFileComp
+}
+
+// getCLAUDEmdPathForWrite returns the path to CLAUDE.md based on scope for
+// writing, creating the local .claude directory if needed (see EnsureLocalClaudeDir)
+func getCLAUDEmdPathForWrite(scope PathScope) (string, error) {
+	if scope != ScopeLocal {
+		return getCLAUDEmdPath(scope), nil
+	}
+	claudeDir, err := EnsureLocalClaudeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(claudeDir, "CLAUDE.md"), nil
+}
+
+// claudemdBackupDir returns the directory backups of the CLAUDE.md at path go
+// to: backups/ next to a CLAUDE.md in a .claude directory, and for a CLAUDE.md
+// elsewhere in a project, .claude/backups/project/ followed by its directory,
+// so backups do not end up among the project's files
+func claudemdBackupDir(path string) string {
+	dir := filepath.Dir(path)
+	if filepath.Base(dir) != localClaudeDir {
+		if root, err := ProjectRoot(); err == nil {
+			if rel, err := filepath.Rel(root, dir); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				return filepath.Join(root, localClaudeDir, "backups", "project", rel)
+			}
+		}
+	}
+	return filepath.Join(dir, "backups")
+}
+
 // backupCLAUDEmd creates a timestamped backup of CLAUDE.md
 func backupCLAUDEmd(path string) (string, error) {
 	content, err := os.ReadFile(path)
@@ -177,8 +402,12 @@ func backupCLAUDEmd(path string) (string, error) {
 		return "", err
 	}
 
-	// Create .claude/backups/ directory
-	backupDir := filepath.Join(filepath.Dir(path), "backups")
+	return writeCLAUDEmdBackup(path, string(content))
+}
+
+// writeCLAUDEmdBackup writes content as a timestamped backup of the CLAUDE.md at path
+func writeCLAUDEmdBackup(path, content string) (string, error) {
+	backupDir := claudemdBackupDir(path)
 	if err := os.MkdirAll(backupDir, 0755); err != nil {
 		return "", err
 	}
@@ -186,16 +415,25 @@ func backupCLAUDEmd(path string) (string, error) {
 	// Timestamped backup: CLAUDE.md.20260122-153045.bak
 	timestamp := time.Now().Format("20060102-150405")
 	backupPath := filepath.Join(backupDir, fmt.Sprintf("CLAUDE.md.%s.bak", timestamp))
+	// A second backup within the same second (e.g., of edits merged into a tidy)
+	// must not replace the first: CLAUDE.md.20260122-153045-2.bak
+	for n := 2; ; n++ {
+		if _, err := os.Stat(backupPath); os.IsNotExist(err) {
+			break
+		}
+		backupPath = filepath.Join(backupDir, fmt.Sprintf("CLAUDE.md.%s-%d.bak", timestamp, n))
+	}
 
-	if err := os.WriteFile(backupPath, content, 0644); err != nil {
+	if err := os.WriteFile(backupPath, []byte(content), 0644); err != nil {
 		return "", err
 	}
 
 	return backupPath, nil
 }
 
-// runClaudeTidy executes Claude CLI to tidy the CLAUDE.md content
-func runClaudeTidy(content, style string) (string, error) {
+// renderTidyPrompt renders the tidy-claudemd prompt for the CLAUDE.md content
+// and, if targetTokens is not 0, the token budget to compress it to
+func renderTidyPrompt(content, style string, targetTokens int) (string, error) {
 	// Load prompt template
 	promptTemplate, err := prompt.Load("tidy-claudemd")
 	if err != nil {
@@ -209,17 +447,26 @@ func runClaudeTidy(content, style string) (string, error) {
 	}
 
 	var buf bytes.Buffer
-	err = tmpl.Execute(&buf, map[string]string{
-		"Content": content,
-		"Style":   style,
-	})
+	vars := map[string]string{
+		"Content":       content,
+		"Style":         style,
+		"TargetTokens":  "",
+		"CurrentTokens": strconv.Itoa(aicost.EstimateTokens(content)),
+	}
+	if targetTokens > 0 {
+		vars["TargetTokens"] = strconv.Itoa(targetTokens)
+	}
+	err = tmpl.Execute(&buf, vars)
 	if err != nil {
 		return "", err
 	}
+	return buf.String(), nil
+}
 
-	// Execute claude CLI
+// runClaudeTidy executes Claude CLI with the rendered tidy prompt
+func runClaudeTidy(tidyPrompt string) (string, error) {
 	cmd := exec.Command("claude",
-		"-p", buf.String(),
+		"-p", tidyPrompt,
 		"--output-format", "text",
 	)
 
@@ -0,0 +1,183 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/command"
+	"github.com/itda-skills/jindo/internal/pkg/pkgmgr"
+	"github.com/spf13/cobra"
+)
+
+var commandsConflictsJSON bool
+
+var commandsConflictsCmd = &cobra.Command{
+	Use:   "conflicts",
+	Short: "Find slash commands defined more than once",
+	Long: `Report slash command names defined in both ~/.claude/commands/ and the
+project's .claude/commands/.
+
+Claude Code runs the project (local) command and ignores the global one, so
+the global command is shadowed. Commands installed by 'jd pkg install' are
+shown with their package, which makes collisions such as a local
+affa-ever--commit.md next to an installed package easy to spot.
+
+For each shadowed command a rename (or uninstall, for packages) is suggested.
+'jd validate' reports the same collisions as warnings.`,
+	Example: `  jd commands conflicts
+  jd commands conflicts --json`,
+	RunE: runCommandsConflicts,
+}
+
+func init() {
+	commandsCmd.AddCommand(commandsConflictsCmd)
+	commandsConflictsCmd.Flags().BoolVar(&commandsConflictsJSON, "json", false, "Output in JSON format")
+}
+
+// commandDefinition is one file defining a slash command
+type commandDefinition struct {
+	Scope      PathScope `json:"scope"`
+	Path       string    `json:"path"`
+	Package    string    `json:"package,omitempty"`    // Installed package that owns the file
+	Suggestion string    `json:"suggestion,omitempty"` // How to resolve the collision (shadowed definitions only)
+}
+
+// commandConflict is a slash command name defined in more than one place
+type commandConflict struct {
+	Name     string              `json:"name"`
+	Winner   commandDefinition   `json:"winner"`
+	Shadowed []commandDefinition `json:"shadowed"`
+}
+
+func runCommandsConflicts(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+
+	conflicts := findCommandConflicts()
+
+	if commandsConflictsJSON {
+		if conflicts == nil {
+			conflicts = []commandConflict{}
+		}
+		output, err := json.MarshalIndent(conflicts, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(output))
+		return nil
+	}
+
+	if len(conflicts) == 0 {
+		fmt.Println("No conflicting slash commands found.")
+		return nil
+	}
+
+	fmt.Printf("%d slash command(s) defined more than once:\n", len(conflicts))
+	for _, c := range conflicts {
+		fmt.Printf("\n/%s\n", c.Name)
+		fmt.Printf("  ✓ %-6s %s%s\n", c.Winner.Scope, c.Winner.Path, packageSuffix(c.Winner.Package))
+		for _, d := range c.Shadowed {
+			fmt.Printf("  ✗ %-6s %s%s (shadowed)\n", d.Scope, d.Path, packageSuffix(d.Package))
+			fmt.Printf("           → %s\n", d.Suggestion)
+		}
+	}
+	return nil
+}
+
+// packageSuffix formats the owning package of a command file for display
+func packageSuffix(pkg string) string {
+	if pkg == "" {
+		return ""
+	}
+	return fmt.Sprintf(" [package %s]", pkg)
+}
+
+// findCommandConflicts returns slash command names defined in both the local and global
+// scope, sorted by name. The local definition wins, as it does in Claude Code.
+func findCommandConflicts() []commandConflict {
+	globalDir, err := expandScopeDir(GetGlobalPath("commands"))
+	if err != nil {
+		return nil
+	}
+	localDir := GetLocalPath("commands")
+	if localDir == "" {
+		return nil
+	}
+	// Inside the home directory the project may resolve to ~/.claude itself
+	if abs, err := filepath.Abs(localDir); err != nil || abs == globalDir {
+		return nil
+	}
+
+	globalCommands, _ := command.NewStore(globalDir).List()
+	localCommands, _ := command.NewStore(localDir).List()
+	if len(globalCommands) == 0 || len(localCommands) == 0 {
+		return nil
+	}
+
+	owners := installedFileOwners()
+	globalByName := make(map[string]*command.Command)
+	for _, c := range globalCommands {
+		globalByName[c.Name] = c
+	}
+
+	var conflicts []commandConflict
+	for _, local := range localCommands {
+		global, ok := globalByName[local.Name]
+		if !ok {
+			continue
+		}
+
+		shadowed := commandDefinition{Scope: ScopeGlobal, Path: global.Path, Package: owners[global.Path]}
+		if shadowed.Package != "" {
+			shadowed.Suggestion = fmt.Sprintf("jd pkg uninstall %s, or rename the local command", shadowed.Package)
+		} else {
+			shadowed.Suggestion = "rename to " + suggestCommandRename(globalDir, local.Name, "global")
+		}
+
+		conflicts = append(conflicts, commandConflict{
+			Name:     local.Name,
+			Winner:   commandDefinition{Scope: ScopeLocal, Path: local.Path, Package: owners[local.Path]},
+			Shadowed: []commandDefinition{shadowed},
+		})
+	}
+
+	sort.Slice(conflicts, func(i, j int) bool { return conflicts[i].Name < conflicts[j].Name })
+	return conflicts
+}
+
+// installedFileOwners maps installed package files to their package names
+func installedFileOwners() map[string]string {
+	owners := make(map[string]string)
+	packages, err := pkgmgr.NewManager(basedir.DataDir()).List()
+	if err != nil {
+		return owners
+	}
+	for _, pkg := range packages {
+		for _, f := range pkg.Files {
+			owners[f.Target] = pkg.Name
+		}
+	}
+	return owners
+}
+
+// suggestCommandRename returns a free file name in dir for a command, as a path relative to dir
+// (e.g., deploy-global.md or team/deploy-global.md for team:deploy)
+func suggestCommandRename(dir, name, suffix string) string {
+	parts := strings.Split(name, ":")
+	base := parts[len(parts)-1]
+	for i := 1; ; i++ {
+		candidate := base + "-" + suffix
+		if i > 1 {
+			candidate = fmt.Sprintf("%s-%s%d", base, suffix, i)
+		}
+		parts[len(parts)-1] = candidate + ".md"
+		rel := filepath.Join(parts...)
+		if _, err := os.Stat(filepath.Join(dir, rel)); os.IsNotExist(err) {
+			return rel
+		}
+	}
+}
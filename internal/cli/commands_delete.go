@@ -1,10 +1,8 @@
 package cli
 
 import (
-	"bufio"
 	"fmt"
 	"os"
-	"strings"
 
 	"github.com/itda-skills/jindo/internal/command"
 	"github.com/spf13/cobra"
@@ -12,6 +10,7 @@ import (
 
 var (
 	commandsDeleteForce  bool
+	commandsDeleteYes    bool
 	commandsDeleteGlobal bool
 	commandsDeleteLocal  bool
 )
@@ -22,9 +21,19 @@ var commandsDeleteCmd = &cobra.Command{
 	Short:   "Delete a command",
 	Long: `Delete a command from ~/.claude/commands/ (global) or .claude/commands/ (local) directory.
 
-This will delete the command file.
-Use --force to skip the confirmation prompt.
-Default scope is local if a .claude directory exists in the current working directory, otherwise global.
+This removes the command file, after listing the files, and the skills,
+commands, and agents that still mention it, and asking. The files are moved to the trash
+(trash/ in the jd data directory, ~/.itda-skills by default), from where they
+can be restored by hand.
+
+A command installed by a package is not deleted; uninstall the package with
+'jd pkg uninstall' instead, which also removes its record.
+
+Use --yes to skip the confirmation in scripts. Use --force to skip it, delete
+the files outright instead of moving them to the trash, and delete a command
+installed by a package anyway.
+
+Default scope is local if a .claude directory exists in the current or a parent directory (the nearest one is used), otherwise global.
 Use --global or --local to override.`,
 	Args: cobra.ExactArgs(1),
 	RunE: runCommandsDelete,
@@ -32,7 +41,8 @@ Use --global or --local to override.`,
 
 func init() {
 	commandsCmd.AddCommand(commandsDeleteCmd)
-	commandsDeleteCmd.Flags().BoolVarP(&commandsDeleteForce, "force", "f", false, "Skip confirmation prompt")
+	commandsDeleteCmd.Flags().BoolVarP(&commandsDeleteForce, "force", "f", false, "Skip confirmation and delete outright, even if installed by a package")
+	commandsDeleteCmd.Flags().BoolVarP(&commandsDeleteYes, "yes", "y", false, "Skip confirmation and move the files to the trash")
 	commandsDeleteCmd.Flags().BoolVarP(&commandsDeleteGlobal, "global", "g", false, "Delete from global ~/.claude/commands/")
 	commandsDeleteCmd.Flags().BoolVarP(&commandsDeleteLocal, "local", "l", false, "Delete from local .claude/commands/")
 }
@@ -53,35 +63,16 @@ func runCommandsDelete(cmd *cobra.Command, args []string) error {
 	c, err := store.Get(name)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return fmt.Errorf("command not found in %s: %s", ScopeDescription(scope), name)
+			return notFoundErrorf("command not found in %s: %s", ScopeDescription(scope), name)
 		}
 		return fmt.Errorf("failed to get command: %w", err)
 	}
 
-	// Confirm deletion unless --force
-	if !commandsDeleteForce {
-		fmt.Printf("Delete command '%s'?\n", name)
-		fmt.Printf("  Path: %s\n", c.Path)
-		fmt.Print("Type 'yes' to confirm: ")
-
-		reader := bufio.NewReader(os.Stdin)
-		response, err := reader.ReadString('\n')
-		if err != nil {
-			return fmt.Errorf("failed to read input: %w", err)
-		}
-
-		response = strings.TrimSpace(strings.ToLower(response))
-		if response != "yes" {
-			fmt.Println("Cancelled.")
-			return nil
-		}
-	}
-
-	// Delete the command file
-	if err := os.Remove(c.Path); err != nil {
-		return fmt.Errorf("failed to delete command: %w", err)
-	}
-
-	fmt.Printf("Deleted command: %s\n", name)
-	return nil
+	return deleteResource(resourceDeletion{
+		kind:  "command",
+		name:  name,
+		file:  c.Path,
+		path:  c.Path,
+		scope: scope,
+	}, commandsDeleteForce, commandsDeleteYes)
 }
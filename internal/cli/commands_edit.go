@@ -23,7 +23,7 @@ var commandsEditCmd = &cobra.Command{
 
 By default, uses Claude CLI to interactively edit the command content.
 Use --editor to open the command file directly in your editor.
-Default scope is local if a .claude directory exists in the current working directory, otherwise global.
+Default scope is local if a .claude directory exists in the current or a parent directory (the nearest one is used), otherwise global.
 Use --global or --local to override.`,
 	Args: cobra.ExactArgs(1),
 	RunE: runCommandsEdit,
@@ -52,7 +52,7 @@ func runCommandsEdit(cmd *cobra.Command, args []string) error {
 	c, err := store.Get(name)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return fmt.Errorf("command not found in %s: %s", ScopeDescription(scope), name)
+			return notFoundErrorf("command not found in %s: %s", ScopeDescription(scope), name)
 		}
 		return fmt.Errorf("failed to get command: %w", err)
 	}
@@ -3,9 +3,10 @@ package cli
 import (
 	"encoding/json"
 	"fmt"
-	"strings"
+	"os"
 
 	"github.com/itda-skills/jindo/internal/command"
+	"github.com/itda-skills/jindo/internal/table"
 	"github.com/spf13/cobra"
 )
 
@@ -22,6 +23,8 @@ var commandsListCmd = &cobra.Command{
 func init() {
 	commandsCmd.AddCommand(commandsListCmd)
 	commandsListCmd.Flags().BoolVar(&commandsListJSON, "json", false, "Output in JSON format")
+	addOutputFlags(commandsListCmd)
+	addNoTruncFlag(commandsListCmd)
 }
 
 // commandsListOutput represents JSON output for commands list with scope
@@ -47,21 +50,16 @@ func runCommandsList(cmd *cobra.Command, _ []string) error {
 		localCommands, _ = localStore.List()
 	}
 
-	if commandsListJSON {
+	if commandsListJSON || formattedOutput() {
 		output := commandsListOutput{
 			Global: globalCommands,
 			Local:  localCommands,
 		}
-		jsonOutput, err := json.MarshalIndent(output, "", "  ")
-		if err != nil {
-			return err
-		}
-		fmt.Println(string(jsonOutput))
-		return nil
+		return printJSON(output)
 	}
 
 	// Print global section
-	fmt.Println("=== Global (~/.claude/commands/) ===")
+	fmt.Printf("=== Global (%s/) ===\n", GetGlobalPath("commands"))
 	if len(globalCommands) == 0 {
 		fmt.Println("No commands found.")
 	} else {
@@ -88,45 +86,33 @@ func printCommandsJSON(commands []*command.Command) error {
 }
 
 func printCommandsTable(commands []*command.Command) {
-	// Calculate column widths
-	nameWidth := len("NAME")
+	// Favorites get a ★ marker column when any command in the table is a favorite
+	var names []string
+	for _, c := range commands {
+		names = append(names, c.Name)
+	}
+	markFavorites := anyFavorite(names...)
 
+	// Tags column is shown only when at least one command has tags
+	showTags := false
 	for _, c := range commands {
-		if len(c.Name) > nameWidth {
-			nameWidth = len(c.Name)
+		if len(c.Tags) > 0 {
+			showTags = true
 		}
 	}
 
-	// Cap widths
-	if nameWidth > 30 {
-		nameWidth = 30
+	columns := []table.Column{
+		{Header: favoriteLabel("NAME", markFavorites), Max: 30},
+		{Header: "DESCRIPTION", Max: 50, Min: 20},
 	}
-	const descWidth = 50
-
-	// Print header
-	fmt.Printf("%-*s  %-*s\n",
-		nameWidth, "NAME",
-		descWidth, "DESCRIPTION")
-	fmt.Printf("%s  %s\n",
-		strings.Repeat("-", nameWidth),
-		strings.Repeat("-", descWidth))
-
-	// Print rows
+	if showTags {
+		columns = append(columns, table.Column{Header: "TAGS", Max: 25})
+	}
+	t := newTable(columns...)
 	for _, c := range commands {
-		name := c.Name
-		if len(name) > nameWidth {
-			name = name[:nameWidth-3] + "..."
-		}
-
-		desc := c.Description
-		if len(desc) > descWidth {
-			desc = desc[:descWidth-3] + "..."
-		}
-
-		fmt.Printf("%-*s  %-*s\n",
-			nameWidth, name,
-			descWidth, desc)
+		t.AddRow(favoriteLabel(c.Name, markFavorites), c.Description, formatTags(c.Tags))
 	}
+	t.Render(os.Stdout)
 
 	fmt.Printf("\nTotal: %d commands\n", len(commands))
 }
@@ -8,6 +8,8 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+
+	"github.com/itda-skills/jindo/internal/basedir"
 )
 
 var (
@@ -26,7 +28,7 @@ var commandsNewCmd = &cobra.Command{
 
 By default, uses Claude CLI to interactively generate the command content.
 Use --no-ai to create a minimal template without AI assistance.
-Default scope is local if a .claude directory exists in the current working directory, otherwise global.
+Default scope is local if a .claude directory exists in the current or a parent directory (the nearest one is used), otherwise global.
 Use --global or --local to override.
 
 Command names can include subdirectory prefix (e.g., "game:asset" creates game/asset.md).`,
@@ -62,11 +64,11 @@ func runCommandsNew(cmd *cobra.Command, args []string) error {
 		}
 		baseDir = localPath
 	} else {
-		home, err := os.UserHomeDir()
+		dir, err := basedir.ClaudePath("commands")
 		if err != nil {
 			return fmt.Errorf("failed to get home directory: %w", err)
 		}
-		baseDir = filepath.Join(home, ".claude", "commands")
+		baseDir = dir
 	}
 
 	// Convert name:subname format to path
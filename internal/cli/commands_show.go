@@ -20,7 +20,7 @@ var commandsShowCmd = &cobra.Command{
 	Short:   "Show command details",
 	Long: `Show the full content of a specific command from ~/.claude/commands/ (global) or .claude/commands/ (local) directory.
 
-Default scope is local if a .claude directory exists in the current working directory, otherwise global.
+Default scope is local if a .claude directory exists in the current or a parent directory (the nearest one is used), otherwise global.
 Use --global or --local to override.`,
 	Args: cobra.ExactArgs(1),
 	RunE: runCommandsShow,
@@ -55,7 +55,7 @@ func showCommandBrief(store *command.Store, name string, scope PathScope) error
 	cmd, err := store.Get(name)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return fmt.Errorf("command not found in %s: %s", ScopeDescription(scope), name)
+			return notFoundErrorf("command not found in %s: %s", ScopeDescription(scope), name)
 		}
 		return fmt.Errorf("failed to get command: %w", err)
 	}
@@ -71,7 +71,7 @@ func showCommandFull(store *command.Store, name string, scope PathScope) error {
 	content, err := store.GetContent(name)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return fmt.Errorf("command not found in %s: %s", ScopeDescription(scope), name)
+			return notFoundErrorf("command not found in %s: %s", ScopeDescription(scope), name)
 		}
 		return fmt.Errorf("failed to get command content: %w", err)
 	}
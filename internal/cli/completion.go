@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// completionShells lists the shells jd can generate completion scripts for
+var completionShells = []string{"bash", "zsh", "fish", "powershell"}
+
+var completionCmd = &cobra.Command{
+	Use:   "completion",
+	Short: "Generate or install shell completion scripts",
+	Long: `Generate the autocompletion script for jd for the specified shell,
+or install it with 'jd completion install'.
+
+Installing detects your shell, writes the script to the location the shell
+loads completions from, and verifies that it loads.`,
+	Example: `  # Install completion for the current shell
+  jd completion install
+
+  # Print the zsh completion script
+  jd completion zsh`,
+}
+
+func init() {
+	rootCmd.AddCommand(completionCmd)
+
+	for _, shell := range completionShells {
+		completionCmd.AddCommand(&cobra.Command{
+			Use:   shell,
+			Short: fmt.Sprintf("Generate the autocompletion script for %s", shell),
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				cmd.SilenceUsage = true
+				return generateCompletion(shell, os.Stdout)
+			},
+		})
+	}
+}
+
+// generateCompletion writes the completion script for shell to w
+func generateCompletion(shell string, w io.Writer) error {
+	switch shell {
+	case "bash":
+		return rootCmd.GenBashCompletionV2(w, true)
+	case "zsh":
+		return rootCmd.GenZshCompletion(w)
+	case "fish":
+		return rootCmd.GenFishCompletion(w, true)
+	case "powershell":
+		return rootCmd.GenPowerShellCompletionWithDesc(w)
+	default:
+		return fmt.Errorf("unsupported shell: %s (supported: bash, zsh, fish, powershell)", shell)
+	}
+}
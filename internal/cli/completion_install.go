@@ -0,0 +1,254 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/itda-skills/jindo/internal/basedir"
+)
+
+// completionRCMarker marks the block jd adds to a shell rc file
+const completionRCMarker = "# jd shell completion"
+
+var completionInstallDryRun bool
+
+var completionInstallCmd = &cobra.Command{
+	Use:   "install [shell]",
+	Short: "Install the completion script for your shell",
+	Long: `Install the jd completion script for bash, zsh, or fish.
+
+The shell is detected from $SHELL unless given as an argument.
+The script is written to the location the shell loads completions from:
+  bash  $(brew --prefix)/etc/bash_completion.d/jd if Homebrew is installed,
+        otherwise ~/.local/share/bash-completion/completions/jd
+  zsh   $(brew --prefix)/share/zsh/site-functions/_jd if Homebrew is installed,
+        otherwise completions/_jd in the jd data directory
+        (~/.itda-skills by default), sourced from ~/.zshrc
+  fish  ~/.config/fish/completions/jd.fish
+
+After writing, the script is loaded in the target shell to verify it works.
+Running install again overwrites the script with the current version.`,
+	Example: `  # Install for the current shell
+  jd completion install
+
+  # Install for zsh explicitly
+  jd completion install zsh
+
+  # Show where the script would be written
+  jd completion install --dry-run`,
+	Args:      cobra.MaximumNArgs(1),
+	ValidArgs: []string{"bash", "zsh", "fish"},
+	RunE:      runCompletionInstall,
+}
+
+func init() {
+	completionCmd.AddCommand(completionInstallCmd)
+	completionInstallCmd.Flags().BoolVarP(&completionInstallDryRun, "dry-run", "n", false, "Show what would be written without changing anything")
+}
+
+// completionTarget describes where a shell's completion script is installed
+type completionTarget struct {
+	Shell  string
+	Path   string // completion script path
+	RCFile string // rc file that must source the script (empty if the shell loads it itself)
+}
+
+func runCompletionInstall(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	shell := ""
+	if len(args) > 0 {
+		shell = args[0]
+	} else {
+		shell = filepath.Base(os.Getenv("SHELL"))
+		if shell == "." || shell == "" {
+			return fmt.Errorf("could not detect shell from $SHELL; specify one: jd completion install <bash|zsh|fish>")
+		}
+		fmt.Printf("Detected shell: %s\n", shell)
+	}
+
+	target, err := completionTargetFor(shell)
+	if err != nil {
+		return err
+	}
+
+	var script bytes.Buffer
+	if err := generateCompletion(shell, &script); err != nil {
+		return fmt.Errorf("failed to generate completion script: %w", err)
+	}
+
+	if completionInstallDryRun {
+		fmt.Printf("Would write %s completion to: %s\n", shell, target.Path)
+		if target.RCFile != "" {
+			fmt.Printf("Would source it from: %s\n", target.RCFile)
+		}
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target.Path), 0755); err != nil {
+		return fmt.Errorf("failed to create completion directory: %w", err)
+	}
+	if err := os.WriteFile(target.Path, script.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write completion script: %w", err)
+	}
+	fmt.Printf("✅ Wrote %s completion: %s\n", shell, target.Path)
+
+	if target.RCFile != "" {
+		added, err := ensureCompletionSourced(target.RCFile, target.Path)
+		if err != nil {
+			return fmt.Errorf("failed to update %s: %w", target.RCFile, err)
+		}
+		if added {
+			fmt.Printf("✅ Added completion to %s\n", target.RCFile)
+		}
+	}
+
+	if err := verifyCompletion(shell, target.Path); err != nil {
+		if errors.Is(err, errShellNotFound) {
+			fmt.Printf("Skipped verification: %s not found in PATH\n", shell)
+		} else {
+			return fmt.Errorf("completion script was written but failed to load: %w", err)
+		}
+	} else {
+		fmt.Println("✅ Verified the completion script loads")
+	}
+
+	fmt.Println("\nRestart your shell (or open a new terminal) to enable completion.")
+	return nil
+}
+
+// completionTargetFor returns the install location for shell, preferring Homebrew's
+// completion directories when they exist
+func completionTargetFor(shell string) (*completionTarget, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	switch shell {
+	case "bash":
+		if dir := brewCompletionDir("etc/bash_completion.d"); dir != "" {
+			return &completionTarget{Shell: shell, Path: filepath.Join(dir, "jd")}, nil
+		}
+		dataHome := os.Getenv("XDG_DATA_HOME")
+		if dataHome == "" {
+			dataHome = filepath.Join(home, ".local", "share")
+		}
+		return &completionTarget{Shell: shell, Path: filepath.Join(dataHome, "bash-completion", "completions", "jd")}, nil
+	case "zsh":
+		if dir := brewCompletionDir("share/zsh/site-functions"); dir != "" {
+			return &completionTarget{Shell: shell, Path: filepath.Join(dir, "_jd")}, nil
+		}
+		zdotdir := os.Getenv("ZDOTDIR")
+		if zdotdir == "" {
+			zdotdir = home
+		}
+		dataDir, err := basedir.Expand(basedir.DataDir())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get home directory: %w", err)
+		}
+		return &completionTarget{
+			Shell:  shell,
+			Path:   filepath.Join(dataDir, "completions", "_jd"),
+			RCFile: filepath.Join(zdotdir, ".zshrc"),
+		}, nil
+	case "fish":
+		configHome := os.Getenv("XDG_CONFIG_HOME")
+		if configHome == "" {
+			configHome = filepath.Join(home, ".config")
+		}
+		return &completionTarget{Shell: shell, Path: filepath.Join(configHome, "fish", "completions", "jd.fish")}, nil
+	case "powershell":
+		return nil, fmt.Errorf("install is not supported for powershell; add 'jd completion powershell | Out-String | Invoke-Expression' to your profile")
+	default:
+		return nil, fmt.Errorf("unsupported shell: %s (supported: bash, zsh, fish)", shell)
+	}
+}
+
+// brewCompletionDir returns the given directory under the Homebrew prefix if Homebrew
+// is installed and the directory exists and is writable, otherwise an empty string
+func brewCompletionDir(subdir string) string {
+	if _, err := exec.LookPath("brew"); err != nil {
+		return ""
+	}
+	out, err := exec.Command("brew", "--prefix").Output()
+	if err != nil {
+		return ""
+	}
+	dir := filepath.Join(strings.TrimSpace(string(out)), subdir)
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return ""
+	}
+	probe, err := os.CreateTemp(dir, ".jd-probe-*")
+	if err != nil {
+		return ""
+	}
+	probe.Close()
+	os.Remove(probe.Name())
+	return dir
+}
+
+// ensureCompletionSourced appends a block sourcing scriptPath to rcFile unless it is
+// already present. Returns true if the rc file was changed.
+func ensureCompletionSourced(rcFile, scriptPath string) (bool, error) {
+	content, err := os.ReadFile(rcFile)
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+	if strings.Contains(string(content), completionRCMarker) {
+		return false, nil
+	}
+
+	block := fmt.Sprintf("\n%s\n(( $+functions[compdef] )) || { autoload -Uz compinit && compinit }\n[ -f %q ] && source %q\n",
+		completionRCMarker, scriptPath, scriptPath)
+
+	f, err := os.OpenFile(rcFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(block); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// errShellNotFound is returned by verifyCompletion when the shell binary is not installed
+var errShellNotFound = errors.New("shell not found")
+
+// verifyCompletion loads the completion script in a non-interactive shell and checks
+// that it registers completion for jd
+func verifyCompletion(shell, scriptPath string) error {
+	if _, err := exec.LookPath(shell); err != nil {
+		return errShellNotFound
+	}
+
+	var c *exec.Cmd
+	switch shell {
+	case "bash":
+		c = exec.Command("bash", "--norc", "--noprofile", "-c", `source "$1" && complete -p jd >/dev/null`, "bash", scriptPath)
+	case "zsh":
+		c = exec.Command("zsh", "-f", "-c", `autoload -Uz compinit && compinit -u -D && source "$1" && (( $+functions[_jd] ))`, "zsh", scriptPath)
+	case "fish":
+		c = exec.Command("fish", "--no-config", "-c", `source $argv[1]; and complete -c jd | string length -q`, scriptPath)
+	default:
+		return nil
+	}
+
+	out, err := c.CombinedOutput()
+	if err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
@@ -13,13 +13,17 @@ var configCmd = &cobra.Command{
 Configuration is stored in config.toml under the OS config directory (TOML format).
   - Linux/macOS: ~/.config/itda-skills/config.toml
   - Windows:     %AppData%\itda-skills\config.toml
+A project's .claude/jindo.toml overrides it for that project.
 Use dot notation to access nested values: common.api_keys.tiingo
 
 Examples:
   jd config init                              # Initialize config file
   jd config set common.api_keys.tiingo KEY    # Set a value
+  jd config set -l common.default_market us   # Set a value for this project
   jd config get common.api_keys.tiingo        # Get a value
   jd config list                              # Show all settings
+  jd config doctor                            # Show where each value comes from
+  jd config check quant-data                  # Check the keys a skill reads
   jd config edit                              # Open in editor`,
 }
 
@@ -0,0 +1,279 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/itda-skills/jindo/internal/skill"
+	"github.com/itda-skills/jindo/internal/table"
+	"github.com/itda-skills/jindo/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	configCheckGlobal bool
+	configCheckLocal  bool
+	configCheckJSON   bool
+)
+
+var configCheckCmd = &cobra.Command{
+	Use:   "check [skill-name]",
+	Short: "Check the configuration keys a skill reads",
+	Long: `Check that the configuration keys a skill declares in its SKILL.md are set.
+
+A skill declares the jd config keys it reads under config: in its frontmatter,
+each with a type (string, int, float, or bool; default string), an optional
+default, and whether it is a secret. Keys without a default are required
+unless they say required: false.
+  ---
+  name: quant-data
+  config:
+    - name: common.api_keys.tiingo
+      description: Tiingo API key
+      secret: true
+      env: TIINGO_API_KEY
+    - name: skills.quant-data.market
+      default: KRX
+    - name: skills.quant-data.limit
+      type: int
+      required: false
+  ---
+
+Each key is reported with where its value comes from (env, local file, global
+file, or default) and its status:
+  ok          Set, with a value of the declared type
+  default     Not set; the skill uses its default
+  missing     Required and not set
+  unset       Optional and not set
+  wrong type  Set to a value that is not of the declared type
+Secrets are masked. 'jd pkg install' asks for missing required keys when it
+installs a skill package.
+
+Without a skill name, checks every skill in the scope that declares config keys.
+Exits with code 4 if a required key is missing or a key has the wrong type.
+
+Examples:
+  jd config check quant-data
+  jd config check --global
+  jd config check quant-data --json`,
+	Args:              cobra.MaximumNArgs(1),
+	RunE:              runConfigCheck,
+	ValidArgsFunction: skillNameCompletion,
+}
+
+func init() {
+	configCmd.AddCommand(configCheckCmd)
+	configCheckCmd.Flags().BoolVarP(&configCheckGlobal, "global", "g", false, "Check skills in global ~/.claude/skills/")
+	configCheckCmd.Flags().BoolVarP(&configCheckLocal, "local", "l", false, "Check skills in local .claude/skills/")
+	configCheckCmd.Flags().BoolVar(&configCheckJSON, "json", false, "Output in JSON format")
+}
+
+// Statuses of a checked configuration key
+const (
+	configStatusOK        = "ok"
+	configStatusDefault   = "default"
+	configStatusMissing   = "missing"
+	configStatusUnset     = "unset"
+	configStatusWrongType = "wrong type"
+)
+
+// configCheckKey is a configuration key a skill declares, and its value
+type configCheckKey struct {
+	Key         string `json:"key"`
+	Type        string `json:"type"`
+	Value       string `json:"value,omitempty"` // Masked for secrets
+	Source      string `json:"source,omitempty"`
+	Status      string `json:"status"`
+	Required    bool   `json:"required"`
+	Secret      bool   `json:"secret,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// configCheckSkill is the configuration a skill declares
+type configCheckSkill struct {
+	Skill string           `json:"skill"`
+	Path  string           `json:"path"`
+	Keys  []configCheckKey `json:"keys"`
+}
+
+func runConfigCheck(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	scope, err := ResolveScope(configCheckGlobal, configCheckLocal)
+	if err != nil {
+		return err
+	}
+	store := skill.NewStore(GetPathByScope(scope, "skills"))
+
+	var skills []*skill.Skill
+	if len(args) == 1 {
+		s, err := store.Get(args[0])
+		if err != nil {
+			if os.IsNotExist(err) {
+				return notFoundErrorf("skill not found in %s: %s", ScopeDescription(scope), args[0])
+			}
+			return fmt.Errorf("failed to get skill: %w", err)
+		}
+		skills = append(skills, s)
+	} else {
+		all, err := store.List()
+		if err != nil {
+			return fmt.Errorf("failed to list skills: %w", err)
+		}
+		for _, s := range all {
+			if len(s.Config) > 0 {
+				skills = append(skills, s)
+			}
+		}
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	results := []configCheckSkill{}
+	problems := 0
+	for _, s := range skills {
+		result := configCheckSkill{Skill: filepath.Base(filepath.Dir(s.Path)), Path: s.Path, Keys: []configCheckKey{}}
+		for _, k := range s.Config {
+			if k.Name == "" {
+				continue
+			}
+			key := checkSkillConfigKey(cfg, k)
+			if key.Status == configStatusMissing || key.Status == configStatusWrongType {
+				problems++
+			}
+			result.Keys = append(result.Keys, key)
+		}
+		results = append(results, result)
+	}
+
+	if configCheckJSON {
+		output, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(output))
+	} else {
+		printConfigCheck(results, len(args) == 1, scope)
+	}
+
+	if problems > 0 {
+		return validationErrorf("%d configuration key(s) missing or of the wrong type", problems)
+	}
+	return nil
+}
+
+// checkSkillConfigKey resolves a declared key the way a skill reads it: its
+// vendor environment variable, then ITDA_<KEY>, then the config files, then
+// the declared default
+func checkSkillConfigKey(cfg *config.Config, k skill.ConfigKey) configCheckKey {
+	entry := configCheckKey{
+		Key:         k.Name,
+		Type:        k.Type,
+		Required:    k.IsRequired(),
+		Secret:      k.Secret,
+		Description: k.Description,
+	}
+	if entry.Type == "" {
+		entry.Type = config.TypeString
+	}
+
+	var value any
+	switch {
+	case k.Env != "" && os.Getenv(k.Env) != "":
+		value, entry.Source = config.ParseValue(os.Getenv(k.Env)), "env ("+k.Env+")"
+	case os.Getenv(config.EnvKey(k.Name)) != "":
+		value, entry.Source = config.ParseValue(os.Getenv(config.EnvKey(k.Name))), "env ("+config.EnvKey(k.Name)+")"
+	default:
+		v, err := cfg.Get(k.Name)
+		if err != nil {
+			break
+		}
+		value, entry.Source = v, "global file"
+		if cfg.IsLocal(k.Name) {
+			entry.Source = "local file"
+		}
+	}
+
+	switch {
+	case value == nil && k.Default != nil:
+		value, entry.Source, entry.Status = k.Default, "default", configStatusDefault
+	case value == nil && entry.Required:
+		entry.Status = configStatusMissing
+		return entry
+	case value == nil:
+		entry.Status = configStatusUnset
+		return entry
+	case !config.HasType(value, entry.Type):
+		entry.Status = configStatusWrongType
+	default:
+		entry.Status = configStatusOK
+	}
+
+	entry.Value = formatConfigValue(k.Name, value)
+	if k.Secret {
+		entry.Value = maskSecret(fmt.Sprint(value))
+	}
+	return entry
+}
+
+// printConfigCheck prints the declared keys of each skill as a table, and how
+// to set the missing ones
+func printConfigCheck(results []configCheckSkill, named bool, scope PathScope) {
+	if len(results) == 0 {
+		fmt.Printf("No skills in %s declare configuration keys.\n", ScopeDescription(scope))
+		return
+	}
+
+	var missing []configCheckKey
+	for i, result := range results {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("%s (%s)\n", result.Skill, result.Path)
+		if len(result.Keys) == 0 {
+			fmt.Println("  No configuration keys declared.")
+			continue
+		}
+
+		t := newTable(
+			table.Column{Header: "KEY", Fixed: true},
+			table.Column{Header: "TYPE"},
+			table.Column{Header: "VALUE", Max: 30},
+			table.Column{Header: "SOURCE", Wrap: true},
+			table.Column{Header: "STATUS"},
+		)
+		for _, k := range result.Keys {
+			source := k.Source
+			if source == "" {
+				source = "-"
+			}
+			t.AddRow(k.Key, k.Type, displayConfigValue(k.Value), source, k.Status)
+			if k.Status == configStatusMissing || k.Status == configStatusWrongType {
+				missing = append(missing, k)
+			}
+		}
+		t.Render(os.Stdout)
+	}
+
+	if len(missing) == 0 {
+		if named {
+			fmt.Println("\n✓ Required configuration is set.")
+		} else {
+			fmt.Printf("\n✓ Required configuration of %d skill(s) is set.\n", len(results))
+		}
+		return
+	}
+	fmt.Println("\nSet them with:")
+	for _, k := range missing {
+		fmt.Printf("  jd config set %s <%s>", k.Key, k.Type)
+		if k.Description != "" {
+			fmt.Printf("   # %s", k.Description)
+		}
+		fmt.Println()
+	}
+}
@@ -0,0 +1,434 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/favorite"
+	"github.com/itda-skills/jindo/internal/pkg/pkgmgr"
+	"github.com/itda-skills/jindo/internal/settings"
+	"github.com/itda-skills/jindo/internal/table"
+	"github.com/itda-skills/jindo/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+var configDoctorJSON bool
+
+var configDoctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Show where each configuration value comes from and check the config files",
+	Long: `Report the effective value of every configuration key jd knows, and where it
+comes from:
+  default       Not set anywhere; jd's built-in default applies
+  env           Set by an environment variable, which takes precedence
+  local file    Set in the project's .claude/jindo.toml, which overrides config.toml
+  global file   Set in config.toml
+
+Keys jd does not read itself (e.g., skill settings under common or skills) are
+listed too. Skills read them with an ITDA_<KEY> environment override, so the
+same precedence is shown for them. API keys and other secrets are masked.
+
+doctor also checks, and reports with line numbers:
+- Malformed TOML in config.toml or the project's .claude/jindo.toml
+- Sections defined more than once
+- Values of the wrong type, which jd ignores in favor of the default
+  (e.g., history.auto = "yes" instead of true)
+- Keys read only from config.toml (paths, named scopes, post-update commands,
+  adapt sandbox settings, the update webhook) set in the project file
+
+Exits with code 4 if there are problems.
+
+Examples:
+  jd config doctor
+  jd config doctor --json`,
+	Args: cobra.NoArgs,
+	RunE: runConfigDoctor,
+}
+
+func init() {
+	configCmd.AddCommand(configDoctorCmd)
+	configDoctorCmd.Flags().BoolVar(&configDoctorJSON, "json", false, "Output in JSON format")
+}
+
+// Kinds of values a known configuration key holds
+const (
+	kindString = "string"
+	kindBool   = "boolean"
+	kindInt    = "integer"
+	kindNumber = "number" // An integer or a float
+	kindList   = "list"
+	kindTable  = "table"
+)
+
+// knownConfigKey is a configuration key jd reads, with its built-in default and
+// the environment variable that overrides it, if any
+type knownConfigKey struct {
+	key         string
+	kind        string
+	def         string
+	env         string
+	description string
+	globalOnly  bool // Read from config.toml only: it is needed to find the project, or a cloned project could use it to run commands or send data
+}
+
+// knownConfigKeys are the configuration keys jd reads
+var knownConfigKeys = []knownConfigKey{
+	{basedir.DataDirKey, kindString, basedir.DefaultDataDir, basedir.DataDirEnv, "jd data directory (repos, installed.json)", true},
+	{basedir.ClaudeDirKey, kindString, basedir.DefaultClaudeDir, basedir.ClaudeDirEnv, "Global Claude Code directory", true},
+	{defaultScopeKey, kindString, "", "", "Scope used without --global/--local: local or global (default: local if .claude exists)", false},
+	{historyAutoKey, kindBool, "false", "", "Snapshot resources before jd overwrites them", false},
+	{gcRepoUnusedDaysKey, kindInt, strconv.Itoa(defaultGCRepoUnusedDays), "", "Days before jd gc removes an unused repository clone", false},
+	{gcGuideMaxAgeKey, kindInt, strconv.Itoa(defaultGCGuideMaxAge), "", "Days before jd gc removes a saved guide", false},
+	{gcHistoryKeepKey, kindInt, strconv.Itoa(defaultGCHistoryKeep), "", "Versions per resource kept by jd gc and history prune", false},
+	{favorite.ConfigKey, kindList, "", "", "Favorite skills, commands, agents, and packages", false},
+	{scopesConfigKey, kindTable, "", "", "Named scopes for --scope-path", true},
+	{tuiKeysKey, kindTable, "", "", "Browse TUI key bindings", false},
+	{postUpdateCommandsKey, kindList, "", "", "Shell commands run after jd pkg repo update pulls new commits", true},
+	{postUpdateReposKey, kindList, "", "", "Repositories whose updates run the post-update commands (\"*\" for all)", true},
+	{indexEnabledKey, kindBool, "true", "", "Keep a full-text index of installed and repository packages", false},
+	{pkgNamingSeparatorKey, kindString, pkgmgr.DefaultNamespaceSep, "", "Separator between the namespace and name of installed packages, made of -, _, or .", false},
+	{pkgNamingFlatKey, kindBool, "false", "", "Install packages under their original name when it is free", false},
+	{pkgNamingFullNamesKey, kindBool, "false", "", "List installed packages under their namespaced name instead of a short name", false},
+	{adaptAllowedToolsKey, kindList, "", "", "Tools Claude may use without asking in adapt sessions (default: Edit, Read, Write, and Glob, Grep or Bash)", true},
+	{adaptAddDirsKey, kindList, "", "", "Other directories Claude may access in adapt sessions", true},
+	{adaptPermissionModeKey, kindString, "", "", "Permission mode of adapt sessions: default, acceptEdits, plan, or bypassPermissions", true},
+	{tokensClaudemdWarnKey, kindInt, strconv.Itoa(defaultTokensClaudemdWarn), "", "Estimated tokens above which a CLAUDE.md is reported (0 turns it off)", false},
+	{tokensSkillWarnKey, kindInt, strconv.Itoa(defaultTokensSkillWarn), "", "Estimated tokens above which a SKILL.md is reported (0 turns it off)", false},
+	{settings.KeepKey, kindInt, strconv.Itoa(settings.DefaultKeep), "", "Automatic backups of settings.json kept per file (0 turns them off)", false},
+	{notifyWebhookURLKey, kindString, "", notifyWebhookURLEnv, "Webhook told about new package versions found by jd pkg update", true},
+	{notifyFormatKey, kindString, "", "", "Payload of the webhook: slack, discord, or json (default: from the URL)", true},
+	{notifyTemplateKey, kindString, "", "", "Go text/template of the update message", true},
+	{aiPriceInputKey, kindNumber, "3", "", "USD per million Claude input tokens, for cost estimates", false},
+	{aiPriceOutputKey, kindNumber, "15", "", "USD per million Claude output tokens, for cost estimates", false},
+	{aiConfirmAboveKey, kindNumber, "0", "", "Ask before an AI-powered command estimated above this many USD (0 never asks)", false},
+	{aiMonthlyBudgetKey, kindNumber, "0", "", "Ask before an AI-powered command that would take the month past this many USD (0 never asks)", false},
+}
+
+// configDoctorFile is a configuration file doctor checked
+type configDoctorFile struct {
+	Scope  string `json:"scope"` // "global" or "local"
+	Path   string `json:"path"`
+	Exists bool   `json:"exists"`
+}
+
+// configDoctorKey is the effective value of a configuration key
+type configDoctorKey struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Source      string `json:"source"` // "default", "env", "local file", or "global file"
+	Env         string `json:"env,omitempty"`
+	Known       bool   `json:"known"`
+	Description string `json:"description,omitempty"`
+}
+
+// configDoctorReport is the output of jd config doctor
+type configDoctorReport struct {
+	Files    []configDoctorFile `json:"files"`
+	Keys     []configDoctorKey  `json:"keys"`
+	Problems []string           `json:"problems"`
+}
+
+func runConfigDoctor(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+
+	report, err := buildConfigDoctorReport()
+	if err != nil {
+		return err
+	}
+
+	if configDoctorJSON {
+		output, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(output))
+	} else {
+		printConfigDoctor(report)
+	}
+
+	if len(report.Problems) > 0 {
+		return validationErrorf("config doctor found %d problem(s)", len(report.Problems))
+	}
+	return nil
+}
+
+// buildConfigDoctorReport checks the config files and resolves every key in them
+// or known to jd
+func buildConfigDoctorReport() (configDoctorReport, error) {
+	report := configDoctorReport{Problems: []string{}}
+
+	globalPath, err := config.GetConfigPath()
+	if err != nil {
+		return report, fmt.Errorf("failed to get config path: %w", err)
+	}
+	layers := configDoctorLayers{global: config.New(), local: config.New()}
+	if checkConfigFile(&report, "global", globalPath) {
+		if loaded, err := config.LoadFromPath(globalPath); err == nil {
+			layers.global = loaded
+		}
+	}
+	if localPath := config.LocalPath(); localPath != "" {
+		if checkConfigFile(&report, "local", localPath) {
+			if loaded, err := config.LoadFromPath(localPath); err == nil {
+				layers.local = loaded
+			}
+		}
+	}
+
+	known := make(map[string]bool)
+	for _, k := range knownConfigKeys {
+		known[k.key] = true
+		report.Keys = append(report.Keys, effectiveKnownKey(&report, layers, k))
+	}
+	for _, key := range otherConfigKeys(config.MergeTables(layers.global.ToMap(), layers.local.ToMap()), "", known) {
+		value, source := layers.get(key, false)
+		entry := configDoctorKey{Key: key, Value: formatConfigValue(key, value), Source: source, Env: config.EnvKey(key)}
+		if env := os.Getenv(entry.Env); env != "" {
+			entry.Value, entry.Source = formatConfigValue(key, env), "env"
+		}
+		report.Keys = append(report.Keys, entry)
+	}
+	return report, nil
+}
+
+// checkConfigFile records a config file and reports malformed TOML and duplicate
+// sections in it. It returns whether the file exists and parses.
+func checkConfigFile(report *configDoctorReport, scope, path string) bool {
+	file := configDoctorFile{Scope: scope, Path: path}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			report.Problems = append(report.Problems, fmt.Sprintf("%s: %v", path, err))
+		}
+		report.Files = append(report.Files, file)
+		return false
+	}
+	file.Exists = true
+	report.Files = append(report.Files, file)
+
+	// The parser also rejects duplicates, but without saying where the first one is
+	dups := config.DuplicateSections(content)
+	for _, d := range dups {
+		lines := make([]string, len(d.Lines))
+		for i, n := range d.Lines {
+			lines[i] = strconv.Itoa(n)
+		}
+		report.Problems = append(report.Problems, fmt.Sprintf("%s: section [%s] is defined on lines %s (values in the file are ignored)",
+			path, d.Section, strings.Join(lines, ", ")))
+	}
+	if len(dups) > 0 {
+		return false
+	}
+	if err := config.ParseError(content); err != nil {
+		report.Problems = append(report.Problems, fmt.Sprintf("%s: malformed TOML: %v (values in the file are ignored)", path, err))
+		return false
+	}
+	return true
+}
+
+// configDoctorLayers are the config files doctor read, each loaded on its own
+type configDoctorLayers struct {
+	global *config.Config
+	local  *config.Config
+}
+
+// get returns the value of key as config.Load resolves it, and the file it comes
+// from. Tables set in both files are merged. globalOnly skips the project file.
+func (l configDoctorLayers) get(key string, globalOnly bool) (any, string) {
+	globalValue, globalErr := l.global.Get(key)
+	if globalOnly {
+		if globalErr != nil {
+			return nil, ""
+		}
+		return globalValue, "global file"
+	}
+
+	localValue, localErr := l.local.Get(key)
+	switch {
+	case localErr != nil && globalErr != nil:
+		return nil, ""
+	case localErr != nil:
+		return globalValue, "global file"
+	case globalErr != nil:
+		return localValue, "local file"
+	}
+	if globalTable, ok := globalValue.(map[string]any); ok {
+		if localTable, ok := localValue.(map[string]any); ok {
+			return config.MergeTables(globalTable, localTable), "local file + global file"
+		}
+	}
+	return localValue, "local file"
+}
+
+// effectiveKnownKey resolves a known key the way jd reads it: its environment
+// variable, then the project file, then the global file, then the default. A file
+// value of the wrong type is reported, since jd ignores it, and so is a global-only
+// key set in the project file.
+func effectiveKnownKey(report *configDoctorReport, layers configDoctorLayers, k knownConfigKey) configDoctorKey {
+	entry := configDoctorKey{Key: k.key, Value: k.def, Source: "default", Env: k.env, Known: true, Description: k.description}
+	if _, err := layers.local.Get(k.key); err == nil && k.globalOnly {
+		report.Problems = append(report.Problems, fmt.Sprintf("%s is set in %s, but is read from the global config only", k.key, config.LocalPath()))
+	}
+	if k.env != "" {
+		if env := os.Getenv(k.env); env != "" {
+			entry.Value, entry.Source = env, "env"
+			return entry
+		}
+	}
+
+	value, source := layers.get(k.key, k.globalOnly)
+	if source == "" {
+		return entry
+	}
+	if kind := configValueKind(value); kind != k.kind && !(k.kind == kindNumber && kind == kindInt) {
+		report.Problems = append(report.Problems, fmt.Sprintf("%s in the %s is a %s; expected a %s, so the default is used", k.key, source, kind, k.kind))
+		return entry
+	}
+	entry.Value, entry.Source = formatConfigValue(k.key, value), source
+	return entry
+}
+
+// configValueKind returns the kind of a decoded TOML value
+func configValueKind(value any) string {
+	switch value.(type) {
+	case string:
+		return kindString
+	case bool:
+		return kindBool
+	case int64, int:
+		return kindInt
+	case float64:
+		return kindNumber
+	case []any:
+		return kindList
+	case map[string]any:
+		return kindTable
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// otherConfigKeys returns the dot keys of the leaf values in data that are not
+// known keys or inside one, sorted
+func otherConfigKeys(data map[string]any, prefix string, known map[string]bool) []string {
+	var keys []string
+	for name, value := range data {
+		key := prefix + name
+		if known[key] {
+			continue
+		}
+		if table, ok := value.(map[string]any); ok {
+			keys = append(keys, otherConfigKeys(table, key+".", known)...)
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// formatConfigValue formats a value for display on one line, masking secrets
+func formatConfigValue(key string, value any) string {
+	switch v := value.(type) {
+	case map[string]any:
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		parts := make([]string, len(names))
+		for i, name := range names {
+			parts[i] = name + "=" + formatConfigValue(key+"."+name, v[name])
+		}
+		return strings.Join(parts, ", ")
+	case []any:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = fmt.Sprint(item)
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	}
+
+	s := fmt.Sprint(value)
+	if isSecretConfigKey(key) {
+		return maskSecret(s)
+	}
+	return s
+}
+
+// maskSecret hides a secret value but for its last four characters, if it is long enough
+func maskSecret(s string) string {
+	if s == "" {
+		return s
+	}
+	if len(s) <= 8 {
+		return "****"
+	}
+	return "****" + s[len(s)-4:]
+}
+
+// isSecretConfigKey reports whether a key holds a credential that should not be printed
+func isSecretConfigKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, marker := range []string{"api_key", "token", "secret", "password"} {
+		if strings.Contains(key, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// printConfigDoctor prints the doctor report as tables
+func printConfigDoctor(report configDoctorReport) {
+	fmt.Println("Files:")
+	for _, f := range report.Files {
+		status := "not found"
+		if f.Exists {
+			status = "found"
+		}
+		fmt.Printf("  %-6s  %s (%s)\n", f.Scope, f.Path, status)
+	}
+	fmt.Println()
+
+	t := newTable(
+		table.Column{Header: "KEY", Fixed: true},
+		table.Column{Header: "VALUE", Max: 40},
+		table.Column{Header: "SOURCE", Min: 11, Wrap: true},
+	)
+	for _, k := range report.Keys {
+		source := k.Source
+		switch {
+		case k.Source == "env":
+			source += " (" + k.Env + ")"
+		case k.Env != "":
+			source += " (override with " + k.Env + ")"
+		}
+		if !k.Known {
+			source += ", not read by jd"
+		}
+		t.AddRow(k.Key, displayConfigValue(k.Value), source)
+	}
+	t.Render(os.Stdout)
+
+	if len(report.Problems) == 0 {
+		fmt.Println("\n✓ No problems found.")
+		return
+	}
+	fmt.Printf("\nProblems (%d):\n", len(report.Problems))
+	for _, p := range report.Problems {
+		fmt.Printf("  ✗ %s\n", p)
+	}
+}
+
+// displayConfigValue shows unset values as (none)
+func displayConfigValue(value string) string {
+	if value == "" {
+		return "(none)"
+	}
+	return value
+}
@@ -53,13 +53,13 @@ func runConfigGet(cmd *cobra.Command, args []string) error {
 	if configGetEnv {
 		value, found = cfg.GetWithEnv(key)
 		if !found {
-			return fmt.Errorf("key not found: %s", key)
+			return notFoundErrorf("key not found: %s", key)
 		}
 	} else {
 		value, err = cfg.Get(key)
 		if err != nil {
 			if err == config.ErrKeyNotFound {
-				return fmt.Errorf("key not found: %s", key)
+				return notFoundErrorf("key not found: %s", key)
 			}
 			return fmt.Errorf("failed to get value: %w", err)
 		}
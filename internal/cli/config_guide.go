@@ -33,6 +33,11 @@ itda-skills는 OS별 설정 디렉토리에서 모든 skills의 설정을 통합
   - Windows:     %AppData%\itda-skills\config.toml
 각 skill은 jindo의 config 패키지를 import하여 설정을 읽고 쓸 수 있습니다.
 
+프로젝트의 .claude/jindo.toml에 있는 값은 해당 프로젝트에서 전역 설정보다 우선합니다.
+우선순위: 환경변수 > 프로젝트(.claude/jindo.toml) > 전역(config.toml)
+config.Load()가 현재 디렉토리에서 가장 가까운 .claude/jindo.toml을 자동으로 찾아 적용하며,
+cfg.Save()는 전역 설정 파일에만 저장합니다.
+
 ## 설정 파일 구조
 
 ` + "```toml" + `
@@ -45,7 +45,7 @@ func showRawConfig() error {
 	}
 
 	if !config.ConfigExists() {
-		return fmt.Errorf("config file not found: %s\nRun 'jd config init' to create it", path)
+		return notFoundErrorf("config file not found: %s\nRun 'jd config init' to create it", path)
 	}
 
 	content, err := readFileContent(path)
@@ -66,7 +66,7 @@ func showParsedConfig() error {
 	if cfg.IsEmpty() {
 		path, _ := config.GetConfigPath()
 		if !config.ConfigExists() {
-			return fmt.Errorf("config file not found: %s\nRun 'jd config init' to create it", path)
+			return notFoundErrorf("config file not found: %s\nRun 'jd config init' to create it", path)
 		}
 		fmt.Println("# Config is empty")
 		return nil
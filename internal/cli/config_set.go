@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/itda-skills/jindo/pkg/config"
 	"github.com/spf13/cobra"
@@ -17,17 +18,25 @@ The value type is automatically inferred:
   - numeric strings -> integer or float
   - other strings -> string
 
+Use --local to write to the project's .claude/jindo.toml instead of the global
+config. Project values override global ones for that project; environment
+variables (ITDA_<KEY>) override both.
+
 Examples:
   jd config set common.default_market kr
   jd config set common.api_keys.tiingo YOUR_API_KEY
   jd config set skills.quant-data.default_format table
-  jd config set skills.quant-data.sources.krx.delay 1000`,
+  jd config set skills.quant-data.sources.krx.delay 1000
+  jd config set --local common.default_market us`,
 	Args: cobra.ExactArgs(2),
 	RunE: runConfigSet,
 }
 
+var configSetLocal bool
+
 func init() {
 	configCmd.AddCommand(configSetCmd)
+	configSetCmd.Flags().BoolVarP(&configSetLocal, "local", "l", false, "Write to the project config (.claude/jindo.toml)")
 }
 
 func runConfigSet(cmd *cobra.Command, args []string) error {
@@ -36,6 +45,10 @@ func runConfigSet(cmd *cobra.Command, args []string) error {
 	key := args[0]
 	rawValue := args[1]
 
+	if configSetLocal {
+		return setLocalConfig(key, config.ParseValue(rawValue))
+	}
+
 	cfg, err := config.Load()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
@@ -53,5 +66,30 @@ func runConfigSet(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Printf("Set %s = %v\n", key, value)
+	if cfg.IsLocal(key) {
+		fmt.Fprintf(os.Stderr, "Note: %s is also set in %s, which overrides the global value in this project\n", key, config.LocalPath())
+	}
+	return nil
+}
+
+// setLocalConfig sets a value in the project config file
+func setLocalConfig(key string, value any) error {
+	path := config.LocalPath()
+	if path == "" {
+		return fmt.Errorf("failed to find project root")
+	}
+
+	cfg, err := config.LoadFromPath(path)
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", path, err)
+	}
+	if err := cfg.Set(key, value); err != nil {
+		return fmt.Errorf("failed to set value: %w", err)
+	}
+	if err := cfg.SaveToPath(path); err != nil {
+		return fmt.Errorf("failed to save %s: %w", path, err)
+	}
+
+	fmt.Printf("Set %s = %v (%s)\n", key, value, path)
 	return nil
 }
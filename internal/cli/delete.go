@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/pkg/pkgmgr"
+)
+
+// maxDeletePreviewFiles is how many files a deletion lists before summarizing the rest
+const maxDeletePreviewFiles = 15
+
+// resourceDeletion is a skill, agent, or command about to be deleted
+type resourceDeletion struct {
+	kind  string // "skill", "agent", or "command"
+	name  string
+	file  string // The definition file, as recorded by the package that installed it
+	path  string // What is removed: the file, or the skill's directory
+	scope PathScope
+}
+
+// deleteResource deletes a skill, agent, or command after showing what will be
+// removed and what still references it, and asking. Resources installed by a
+// package are left to 'jd pkg uninstall'. Unless force is set, the files are
+// moved to the trash; yes skips the confirmation, and force skips it too and
+// deletes package resources and files outright.
+func deleteResource(d resourceDeletion, force, yes bool) error {
+	if !force {
+		pkg, err := packageForResource(d.kind, d.name, d.file)
+		if err != nil {
+			return fmt.Errorf("failed to check the package of the %s: %w", d.kind, err)
+		}
+		if pkg != nil {
+			return validationErrorf("%s %s was installed by package %s; uninstall it with: jd pkg uninstall %s (or use --force to delete its files anyway)", d.kind, d.name, pkg.Name, pkg.Name)
+		}
+	}
+
+	manager := pkgmgr.NewManager(basedir.DataDir())
+	refs := resourceReferences(manager, d)
+
+	switch {
+	case force:
+	case yes:
+		for _, r := range refs {
+			fmt.Fprintf(os.Stderr, "Warning: %s %s is still referenced by %s\n", d.kind, d.name, r.Label())
+		}
+	default:
+		if !confirmDeletion(manager, d, refs) {
+			fmt.Println("Cancelled.")
+			return errCancelled
+		}
+	}
+
+	if force {
+		if err := os.RemoveAll(d.path); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", d.kind, err)
+		}
+		fmt.Printf("Deleted %s: %s\n", d.kind, d.name)
+		return nil
+	}
+
+	dest, err := moveResourceToTrash(manager, d.kind, d.name, d.path)
+	if err != nil {
+		return fmt.Errorf("failed to move %s to the trash: %w", d.kind, err)
+	}
+	fmt.Printf("Deleted %s: %s (moved to %s)\n", d.kind, d.name, dest)
+	return nil
+}
+
+// moveResourceToTrash moves the files of a resource to the trash, in a directory
+// named after its kind and name, and returns their new path
+func moveResourceToTrash(manager *pkgmgr.Manager, kind, name, path string) (string, error) {
+	label := strings.NewReplacer(":", "-", "/", "-").Replace(kind + "-" + name)
+	return manager.MoveToTrash(label, path)
+}
+
+// resourceReferences returns the files, outside the resource itself, that mention
+// it by name: other skills, commands, and agents, installed or the user's own
+func resourceReferences(manager *pkgmgr.Manager, d resourceDeletion) []pkgmgr.Reference {
+	var dirs []string
+	if LocalClaudeDirExists() {
+		if root, err := ProjectRoot(); err == nil {
+			dirs = append(dirs, filepath.Join(root, localClaudeDir))
+		}
+	}
+	refs, err := manager.References([]string{d.name}, dirs...)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to find references: %v\n", err)
+		return nil
+	}
+
+	var result []pkgmgr.Reference
+	for _, r := range refs[d.name] {
+		if r.Path == d.path || strings.HasPrefix(r.Path, d.path+string(filepath.Separator)) {
+			continue
+		}
+		result = append(result, r)
+	}
+	return result
+}
+
+// confirmDeletion lists the files of a resource and what references it, and asks
+// whether to delete it
+func confirmDeletion(manager *pkgmgr.Manager, d resourceDeletion, refs []pkgmgr.Reference) bool {
+	fmt.Printf("Delete %s '%s' from %s?\n", d.kind, d.name, ScopeDescription(d.scope))
+
+	files := deletionFiles(d.path)
+	fmt.Printf("  Files (%d):\n", len(files))
+	for i, f := range files {
+		if i == maxDeletePreviewFiles {
+			fmt.Printf("    ... and %d more\n", len(files)-i)
+			break
+		}
+		fmt.Printf("    %s\n", f)
+	}
+	if len(refs) > 0 {
+		fmt.Println("  Still referenced by:")
+		for _, r := range refs {
+			fmt.Printf("    %s\n", r.Label())
+		}
+	}
+	if trashDir, err := manager.TrashDir(); err == nil {
+		fmt.Printf("The files are moved to the trash (%s); use --force to delete them outright.\n", trashDir)
+	}
+	fmt.Print("Delete? (y/N): ")
+
+	reader := bufio.NewReader(os.Stdin)
+	response, _ := reader.ReadString('\n')
+	response = strings.TrimSpace(strings.ToLower(response))
+	return response == "y" || response == "yes"
+}
+
+// deletionFiles returns the files under path, or path itself if it is a file
+func deletionFiles(path string) []string {
+	var files []string
+	_ = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			files = append(files, p)
+		}
+		return nil
+	})
+	return files
+}
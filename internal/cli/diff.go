@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// diffContextLines is the number of unchanged lines shown around each change
+const diffContextLines = 3
+
+// ANSI colors for diff output
+const (
+	diffColorReset = "\x1b[0m"
+	diffColorRed   = "\x1b[31m"
+	diffColorGreen = "\x1b[32m"
+	diffColorCyan  = "\x1b[36m"
+	diffColorBold  = "\x1b[1m"
+)
+
+// diffLine is a single line of a line-based diff
+type diffLine struct {
+	Op   diffmatchpatch.Operation
+	Text string
+}
+
+// lineDiff computes a line-based diff between two texts
+func lineDiff(oldContent, newContent string) []diffLine {
+	dmp := diffmatchpatch.New()
+	a, b, lines := dmp.DiffLinesToChars(oldContent, newContent)
+	diffs := dmp.DiffCharsToLines(dmp.DiffMain(a, b, false), lines)
+
+	var result []diffLine
+	for _, d := range diffs {
+		text := strings.TrimSuffix(d.Text, "\n")
+		for _, line := range strings.Split(text, "\n") {
+			result = append(result, diffLine{Op: d.Type, Text: line})
+		}
+	}
+	return result
+}
+
+// unifiedDiff renders a unified diff between two texts, colored unless NO_COLOR is set.
+// Returns an empty string if the texts are identical.
+func unifiedDiff(oldName, newName, oldContent, newContent string) string {
+	return formatUnifiedDiff(oldName, newName, oldContent, newContent, os.Getenv("NO_COLOR") == "")
+}
+
+// formatUnifiedDiff renders a unified diff between two texts, optionally with ANSI colors
+func formatUnifiedDiff(oldName, newName, oldContent, newContent string, color bool) string {
+	if oldContent == newContent {
+		return ""
+	}
+
+	paint := func(c, s string) string {
+		if !color {
+			return s
+		}
+		return c + s + diffColorReset
+	}
+
+	lines := lineDiff(oldContent, newContent)
+
+	var b strings.Builder
+	b.WriteString(paint(diffColorBold, "--- "+oldName) + "\n")
+	b.WriteString(paint(diffColorBold, "+++ "+newName) + "\n")
+
+	// Group changed lines into hunks with surrounding context
+	i := 0
+	for i < len(lines) {
+		if lines[i].Op == diffmatchpatch.DiffEqual {
+			i++
+			continue
+		}
+
+		start := max(i-diffContextLines, 0)
+		end := i
+		for end < len(lines) {
+			if lines[end].Op != diffmatchpatch.DiffEqual {
+				end++
+				continue
+			}
+			// Stop when the run of equal lines is long enough to split hunks
+			run := 0
+			for end+run < len(lines) && lines[end+run].Op == diffmatchpatch.DiffEqual {
+				run++
+			}
+			if end+run == len(lines) || run > 2*diffContextLines {
+				end = min(end+diffContextLines, len(lines))
+				break
+			}
+			end += run
+		}
+
+		oldStart, newStart := 1, 1
+		for _, l := range lines[:start] {
+			if l.Op != diffmatchpatch.DiffInsert {
+				oldStart++
+			}
+			if l.Op != diffmatchpatch.DiffDelete {
+				newStart++
+			}
+		}
+		oldCount, newCount := 0, 0
+		for _, l := range lines[start:end] {
+			if l.Op != diffmatchpatch.DiffInsert {
+				oldCount++
+			}
+			if l.Op != diffmatchpatch.DiffDelete {
+				newCount++
+			}
+		}
+
+		b.WriteString(paint(diffColorCyan, fmt.Sprintf("@@ -%d,%d +%d,%d @@", oldStart, oldCount, newStart, newCount)) + "\n")
+		for _, l := range lines[start:end] {
+			switch l.Op {
+			case diffmatchpatch.DiffDelete:
+				b.WriteString(paint(diffColorRed, "-"+l.Text) + "\n")
+			case diffmatchpatch.DiffInsert:
+				b.WriteString(paint(diffColorGreen, "+"+l.Text) + "\n")
+			default:
+				b.WriteString(" " + l.Text + "\n")
+			}
+		}
+
+		i = end
+	}
+
+	return b.String()
+}
@@ -0,0 +1,338 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/guide"
+	"github.com/itda-skills/jindo/internal/pkg/pkgmgr"
+	"github.com/spf13/cobra"
+)
+
+var (
+	duSummary bool
+	duTop     int
+)
+
+var duCmd = &cobra.Command{
+	Use:   "du",
+	Short: "Show disk usage of repository clones, packages, history, caches, and backups",
+	Long: `Summarize the disk space used by jd.
+
+Categories:
+  Repository clones   Clones of registered repositories (~/.itda-skills/repos)
+  Installed packages  Files installed by 'jd pkg install'
+  History versions    Saved versions of skills, agents, and hooks (global and local)
+  Guide caches        Cached guides and their HTML renderings
+  Backups             CLAUDE.md backups written by 'jd claudemd tidy', modified
+                      files kept by 'jd pkg uninstall', and resources deleted with
+                      'jd skills delete' and the like (~/.itda-skills/trash)
+
+Items in each category are sorted by size. Use 'jd gc' to reclaim space.`,
+	Example: `  # Per-item breakdown
+  jd du
+
+  # Category totals only
+  jd du --summary
+
+  # Largest 5 items per category
+  jd du --top 5`,
+	Args: cobra.NoArgs,
+	RunE: runDU,
+}
+
+func init() {
+	rootCmd.AddCommand(duCmd)
+	duCmd.Flags().BoolVarP(&duSummary, "summary", "s", false, "Show category totals only")
+	duCmd.Flags().IntVarP(&duTop, "top", "t", 0, "Show only the largest N items per category (0 for all)")
+}
+
+// duItem is a single entry in the disk usage report
+type duItem struct {
+	Label string
+	Size  int64
+}
+
+// duCategory groups disk usage entries of one kind
+type duCategory struct {
+	Name  string
+	Hint  string
+	Items []duItem
+}
+
+// size returns the total size of the category
+func (c *duCategory) size() int64 {
+	var total int64
+	for _, item := range c.Items {
+		total += item.Size
+	}
+	return total
+}
+
+func runDU(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+
+	repoCat, err := collectDURepos()
+	if err != nil {
+		return fmt.Errorf("failed to collect repositories: %w", err)
+	}
+	pkgCat, err := collectDUPackages()
+	if err != nil {
+		return fmt.Errorf("failed to collect packages: %w", err)
+	}
+	guideCat, err := collectDUGuides()
+	if err != nil {
+		return fmt.Errorf("failed to collect guide caches: %w", err)
+	}
+
+	backupCat, err := collectDUBackups()
+	if err != nil {
+		return fmt.Errorf("failed to collect backups: %w", err)
+	}
+
+	categories := []*duCategory{repoCat, pkgCat, collectDUHistory(), guideCat, backupCat}
+
+	var total int64
+	for _, c := range categories {
+		sort.SliceStable(c.Items, func(i, j int) bool {
+			return c.Items[i].Size > c.Items[j].Size
+		})
+
+		fmt.Printf("%-20s %10s  (%d item(s))\n", c.Name, formatBytes(c.size()), len(c.Items))
+		total += c.size()
+
+		if duSummary {
+			continue
+		}
+		items := c.Items
+		if duTop > 0 && len(items) > duTop {
+			items = items[:duTop]
+		}
+		for _, item := range items {
+			fmt.Printf("  %10s  %s\n", formatBytes(item.Size), item.Label)
+		}
+		if len(items) < len(c.Items) {
+			fmt.Printf("  ... and %d more\n", len(c.Items)-len(items))
+		}
+	}
+
+	fmt.Printf("\n%-20s %10s\n", "Total", formatBytes(total))
+
+	var hints []string
+	for _, c := range categories {
+		if c.Hint != "" && len(c.Items) > 0 {
+			hints = append(hints, c.Hint)
+		}
+	}
+	if len(hints) > 0 {
+		fmt.Println()
+		for _, h := range hints {
+			fmt.Printf("Hint: %s\n", h)
+		}
+	}
+
+	return nil
+}
+
+// collectDURepos reports registered repository clones and orphaned clone directories
+func collectDURepos() (*duCategory, error) {
+	cat := &duCategory{
+		Name: "Repository clones",
+		Hint: "run 'jd gc --dry-run' to find clones of repositories with no installed packages",
+	}
+
+	store := pkgmgr.NewManager(basedir.DataDir()).RepoStore()
+
+	repos, err := store.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range repos {
+		path, err := store.RepoLocalPath(r.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		label := r.Namespace
+		if r.Link {
+			label += " (linked)"
+		}
+		cat.Items = append(cat.Items, duItem{Label: label, Size: dirSize(path)})
+	}
+
+	orphans, err := store.OrphanedClones()
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range orphans {
+		cat.Items = append(cat.Items, duItem{
+			Label: fmt.Sprintf("%s (unregistered)", filepath.Base(path)),
+			Size:  dirSize(path),
+		})
+	}
+
+	return cat, nil
+}
+
+// collectDUPackages reports the installed files of each package
+func collectDUPackages() (*duCategory, error) {
+	cat := &duCategory{Name: "Installed packages"}
+
+	packages, err := pkgmgr.NewManager(basedir.DataDir()).List()
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range packages {
+		var size int64
+		for _, f := range p.Files {
+			if info, err := os.Stat(f.Target); err == nil && info.Mode().IsRegular() {
+				size += info.Size()
+			}
+		}
+		cat.Items = append(cat.Items, duItem{Label: fmt.Sprintf("%s (%s)", p.Name, p.Type), Size: size})
+	}
+
+	return cat, nil
+}
+
+// collectDUHistory reports history directories of skills, agents, and hooks in the
+// global and local scopes, including history left behind by deleted agents and hooks
+func collectDUHistory() *duCategory {
+	cat := &duCategory{
+		Name: "History versions",
+		Hint: "run 'jd gc' to prune old history versions (config " + gcHistoryKeepKey + ")",
+	}
+
+	scopes := []PathScope{ScopeGlobal}
+	if LocalClaudeDirExists() {
+		scopes = append(scopes, ScopeLocal)
+	}
+
+	for _, scope := range scopes {
+		skillsDir, err := expandScopeDir(GetPathByScope(scope, "skills"))
+		if err != nil {
+			continue
+		}
+		if entries, err := os.ReadDir(skillsDir); err == nil {
+			for _, e := range entries {
+				historyPath := filepath.Join(skillsDir, e.Name(), ".history")
+				if _, err := os.Stat(historyPath); err != nil {
+					continue
+				}
+				cat.Items = append(cat.Items, duItem{
+					Label: fmt.Sprintf("skill %s (%s)", e.Name(), scope),
+					Size:  dirSize(historyPath),
+				})
+			}
+		}
+
+		agentsDir, err := expandScopeDir(GetPathByScope(scope, "agents"))
+		if err != nil {
+			continue
+		}
+		cat.Items = append(cat.Items, duSubdirs(filepath.Join(agentsDir, ".history"), "agent", scope)...)
+
+		claudeDir, err := expandScopeDir(GetPathByScope(scope, ""))
+		if err != nil {
+			continue
+		}
+		cat.Items = append(cat.Items, duSubdirs(filepath.Join(claudeDir, ".history", "hooks"), "hook", scope)...)
+	}
+
+	return cat
+}
+
+// duSubdirs returns an entry for each subdirectory of dir
+func duSubdirs(dir, kind string, scope PathScope) []duItem {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	var items []duItem
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		items = append(items, duItem{
+			Label: fmt.Sprintf("%s %s (%s)", kind, e.Name(), scope),
+			Size:  dirSize(filepath.Join(dir, e.Name())),
+		})
+	}
+	return items
+}
+
+// collectDUGuides reports cached guides per guide type
+func collectDUGuides() (*duCategory, error) {
+	cat := &duCategory{
+		Name: "Guide caches",
+		Hint: "run 'jd gc' to remove cached guides older than config " + gcGuideMaxAgeKey,
+	}
+
+	dirs, err := guide.CacheDirs()
+	if err != nil {
+		return nil, err
+	}
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			cat.Items = append(cat.Items, duItem{
+				Label: fmt.Sprintf("%s/%s", filepath.Base(dir), e.Name()),
+				Size:  dirSize(filepath.Join(dir, e.Name())),
+			})
+		}
+	}
+
+	return cat, nil
+}
+
+// collectDUBackups reports CLAUDE.md backups in the global and local scopes, and
+// modified package files kept in the trash by uninstall
+func collectDUBackups() (*duCategory, error) {
+	cat := &duCategory{
+		Name: "Backups",
+		Hint: "backups are not removed by 'jd gc'; delete old files in .claude/backups and the trash manually",
+	}
+
+	trashDir, err := pkgmgr.NewManager(basedir.DataDir()).TrashDir()
+	if err != nil {
+		return nil, err
+	}
+	if entries, err := os.ReadDir(trashDir); err == nil {
+		for _, e := range entries {
+			cat.Items = append(cat.Items, duItem{
+				Label: fmt.Sprintf("%s (trash)", e.Name()),
+				Size:  dirSize(filepath.Join(trashDir, e.Name())),
+			})
+		}
+	}
+
+	scopes := []PathScope{ScopeGlobal}
+	if LocalClaudeDirExists() {
+		scopes = append(scopes, ScopeLocal)
+	}
+
+	for _, scope := range scopes {
+		dir, err := expandScopeDir(GetPathByScope(scope, "backups"))
+		if err != nil {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			cat.Items = append(cat.Items, duItem{
+				Label: fmt.Sprintf("%s (%s)", e.Name(), scope),
+				Size:  dirSize(filepath.Join(dir, e.Name())),
+			})
+		}
+	}
+
+	return cat, nil
+}
@@ -0,0 +1,384 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/itda-skills/jindo/internal/agent"
+	"github.com/itda-skills/jindo/internal/command"
+	"github.com/itda-skills/jindo/internal/prompt"
+	"github.com/itda-skills/jindo/internal/skill"
+	"github.com/spf13/cobra"
+)
+
+// editMaxChoices is the maximum number of matches shown in the picker
+const editMaxChoices = 20
+
+var (
+	editGlobal bool
+	editLocal  bool
+)
+
+var editCmd = &cobra.Command{
+	Use:   "edit [query]",
+	Short: "Find a skill, command, agent, prompt, or CLAUDE.md and open it in your editor",
+	Long: `Fuzzy-find a resource and open it in your editor ($EDITOR or $VISUAL).
+
+The query is matched against the names of skills, commands, agents, prompts,
+and CLAUDE.md. A single match is opened directly; otherwise pick one from the list.
+Without a query, all resources are listed.
+
+After the editor exits with changes:
+- Skills and agents get a history version (see 'jd skills history')
+- CLAUDE.md is backed up to .claude/backups/
+- Skills, commands, and agents are validated and new warnings are reported
+
+Default scope is local if a .claude directory exists, otherwise global.
+Use --global or --local to override. Prompts are always global.`,
+	Example: `  # Pick from all resources
+  jd edit
+
+  # Fuzzy match ("cmt" matches "git-commit")
+  jd edit cmt
+
+  # Edit the global CLAUDE.md
+  jd edit claude -g`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runEdit,
+}
+
+func init() {
+	rootCmd.AddCommand(editCmd)
+	editCmd.Flags().BoolVarP(&editGlobal, "global", "g", false, "Search global ~/.claude resources")
+	editCmd.Flags().BoolVarP(&editLocal, "local", "l", false, "Search local .claude resources")
+}
+
+// editCandidate is a file that can be opened with jd edit
+type editCandidate struct {
+	Kind  string // "skill", "command", "agent", "prompt", "claudemd"
+	Name  string
+	Path  string
+	Scope PathScope
+	score int
+}
+
+// label returns the display label of the candidate
+func (c *editCandidate) label() string {
+	return fmt.Sprintf("%-8s %s", c.Kind, c.Name)
+}
+
+func runEdit(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	scope, err := ResolveScope(editGlobal, editLocal)
+	if err != nil {
+		return err
+	}
+
+	query := ""
+	if len(args) > 0 {
+		query = args[0]
+	}
+
+	matches := matchEditCandidates(collectEditCandidates(scope), query)
+	if len(matches) == 0 {
+		if query == "" {
+			return notFoundErrorf("no resources found in %s", ScopeDescription(scope))
+		}
+		return notFoundErrorf("no resources matching '%s' in %s", query, ScopeDescription(scope))
+	}
+
+	c, err := pickEditCandidate(matches)
+	if err != nil {
+		return err
+	}
+	if c == nil {
+		fmt.Println("Cancelled.")
+		return errCancelled
+	}
+
+	return editResource(c)
+}
+
+// collectEditCandidates lists the editable resources in scope
+func collectEditCandidates(scope PathScope) []*editCandidate {
+	var candidates []*editCandidate
+
+	if skills, err := skill.NewStore(GetPathByScope(scope, "skills")).List(); err == nil {
+		for _, s := range skills {
+			candidates = append(candidates, &editCandidate{
+				Kind:  "skill",
+				Name:  filepath.Base(filepath.Dir(s.Path)),
+				Path:  s.Path,
+				Scope: scope,
+			})
+		}
+	}
+
+	if commands, err := command.NewStore(GetPathByScope(scope, "commands")).List(); err == nil {
+		for _, c := range commands {
+			candidates = append(candidates, &editCandidate{Kind: "command", Name: c.Name, Path: c.Path, Scope: scope})
+		}
+	}
+
+	if agents, err := agent.NewStore(GetPathByScope(scope, "agents")).List(); err == nil {
+		for _, a := range agents {
+			candidates = append(candidates, &editCandidate{
+				Kind:  "agent",
+				Name:  strings.TrimSuffix(filepath.Base(a.Path), ".md"),
+				Path:  a.Path,
+				Scope: scope,
+			})
+		}
+	}
+
+	if prompts, err := prompt.List(); err == nil {
+		for _, p := range prompts {
+			path, err := prompt.GetOverridePath(p.Name)
+			if err != nil {
+				continue
+			}
+			candidates = append(candidates, &editCandidate{Kind: "prompt", Name: p.Name, Path: path, Scope: ScopeGlobal})
+		}
+	}
+
+	if claudemdPath, err := expandScopeDir(GetPathByScope(scope, "CLAUDE.md")); err == nil {
+		if _, err := os.Stat(claudemdPath); err == nil {
+			candidates = append(candidates, &editCandidate{Kind: "claudemd", Name: "CLAUDE.md", Path: claudemdPath, Scope: scope})
+		}
+	}
+
+	return candidates
+}
+
+// matchEditCandidates returns the candidates matching query, best match first
+func matchEditCandidates(candidates []*editCandidate, query string) []*editCandidate {
+	var matches []*editCandidate
+	for _, c := range candidates {
+		score, ok := fuzzyScore(query, c.Name)
+		if !ok {
+			continue
+		}
+		c.score = score
+		matches = append(matches, c)
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		return matches[i].label() < matches[j].label()
+	})
+	return matches
+}
+
+// fuzzyScore reports whether the characters of query appear in order in target
+// (case-insensitive) and scores the match. Exact, prefix, and substring matches
+// rank above scattered ones; consecutive characters score higher.
+func fuzzyScore(query, target string) (int, bool) {
+	q := strings.ToLower(query)
+	t := strings.ToLower(target)
+
+	switch {
+	case q == "":
+		return 0, true
+	case q == t:
+		return 1000, true
+	case strings.HasPrefix(t, q):
+		return 900 - len(t), true
+	case strings.Contains(t, q):
+		return 800 - len(t), true
+	}
+
+	runes := []rune(t)
+	score := 0
+	run := 0
+	ti := 0
+	for _, qc := range q {
+		found := false
+		for ti < len(runes) {
+			tc := runes[ti]
+			ti++
+			if tc == qc {
+				found = true
+				break
+			}
+			run = 0
+		}
+		if !found {
+			return 0, false
+		}
+		run++
+		score += run
+	}
+	return score, true
+}
+
+// pickEditCandidate returns the only match or asks the user to choose one.
+// Returns nil if the user cancels.
+func pickEditCandidate(matches []*editCandidate) (*editCandidate, error) {
+	if len(matches) == 1 {
+		return matches[0], nil
+	}
+
+	shown := matches
+	if len(shown) > editMaxChoices {
+		shown = shown[:editMaxChoices]
+	}
+
+	for i, c := range shown {
+		fmt.Printf("  %2d) %s\n", i+1, c.label())
+	}
+	if len(shown) < len(matches) {
+		fmt.Printf("  ... and %d more (refine the query)\n", len(matches)-len(shown))
+	}
+	fmt.Printf("Select (1-%d, empty to cancel): ", len(shown))
+
+	reader := bufio.NewReader(os.Stdin)
+	input, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input: %w", err)
+	}
+
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil, nil
+	}
+
+	n, err := strconv.Atoi(input)
+	if err != nil || n < 1 || n > len(shown) {
+		return nil, fmt.Errorf("invalid selection: %s", input)
+	}
+	return shown[n-1], nil
+}
+
+// editResource opens a candidate in the editor, then records history and
+// re-validates the resource if it changed
+func editResource(c *editCandidate) error {
+	// Prompts are edited through an override created from the embedded version
+	if c.Kind == "prompt" && !prompt.HasOverride(c.Name) {
+		content, err := prompt.GetEmbedded(c.Name)
+		if err != nil {
+			return fmt.Errorf("failed to get embedded prompt: %w", err)
+		}
+		if err := prompt.SaveOverride(c.Name, content); err != nil {
+			return fmt.Errorf("failed to create override: %w", err)
+		}
+		fmt.Printf("Created override from embedded: %s\n", c.Path)
+	}
+
+	before, err := os.ReadFile(c.Path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", c.Path, err)
+	}
+	warningsBefore := validateEditCandidate(c)
+
+	if err := openEditor(c.Path); err != nil {
+		return fmt.Errorf("failed to run editor: %w", err)
+	}
+
+	after, err := os.ReadFile(c.Path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", c.Path, err)
+	}
+	if string(after) == string(before) {
+		fmt.Println("📝 No changes made")
+		return nil
+	}
+
+	fmt.Printf("✅ Updated %s: %s\n", c.Kind, c.Path)
+
+	if err := recordEditHistory(c, string(before), string(after)); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to record history: %v\n", err)
+	}
+
+	var newWarnings []ValidationError
+	seen := make(map[string]bool)
+	for _, w := range warningsBefore {
+		seen[w.Message] = true
+	}
+	for _, w := range validateEditCandidate(c) {
+		if !seen[w.Message] {
+			newWarnings = append(newWarnings, w)
+		}
+	}
+
+	if len(newWarnings) > 0 {
+		fmt.Println("\nNew warnings:")
+		for _, w := range newWarnings {
+			fmt.Printf("  [WARN] %s '%s': %s\n", w.Type, w.Name, w.Message)
+		}
+	}
+
+	return nil
+}
+
+// recordEditHistory saves the content before and after an edit as history versions.
+// CLAUDE.md has no version history, so the previous content is backed up instead.
+func recordEditHistory(c *editCandidate, before, after string) error {
+	switch c.Kind {
+	case "skill":
+		historyMgr := skill.NewHistoryManager(filepath.Dir(c.Path))
+		previous, err := saveSkillVersionIfChanged(historyMgr, before)
+		if err != nil {
+			return err
+		}
+		version, err := historyMgr.SaveVersion(after)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("📦 Saved %s (revert with: jd skills revert %s %d)\n",
+			skill.FormatVersionName(version), c.Name, previous.Number)
+	case "agent":
+		historyMgr := agent.NewHistoryManager(filepath.Dir(c.Path), c.Name)
+		previous, err := saveAgentVersionIfChanged(historyMgr, before)
+		if err != nil {
+			return err
+		}
+		version, err := historyMgr.SaveVersion(after)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("📦 Saved %s (revert with: jd agents revert %s %d)\n",
+			agent.FormatVersionName(version), c.Name, previous.Number)
+	case "claudemd":
+		backupPath, err := writeCLAUDEmdBackup(c.Path, before)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("📦 Backed up previous content to %s\n", backupPath)
+	}
+	return nil
+}
+
+// validateEditCandidate validates a skill, command, or agent and returns its
+// errors and warnings. Other resource kinds have no validation.
+func validateEditCandidate(c *editCandidate) []ValidationError {
+	result := &ValidationResult{}
+
+	switch c.Kind {
+	case "skill":
+		skillsDir := filepath.Dir(filepath.Dir(c.Path))
+		validateSkill(result, skill.NewStore(skillsDir), skillsDir, c.Name)
+	case "command":
+		cmd, err := command.ParseCommandFile(c.Path)
+		if err != nil {
+			return []ValidationError{{Type: c.Kind, Name: c.Name, Path: c.Path, Message: fmt.Sprintf("failed to parse: %v", err)}}
+		}
+		cmd.Name = c.Name
+		validateCommand(result, cmd)
+	case "agent":
+		a, err := agent.ParseAgentFile(c.Path)
+		if err != nil {
+			return []ValidationError{{Type: c.Kind, Name: c.Name, Path: c.Path, Message: fmt.Sprintf("failed to parse: %v", err)}}
+		}
+		validateAgent(result, a)
+	}
+
+	return append(result.Errors, result.Warnings...)
+}
@@ -0,0 +1,149 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+
+	"github.com/itda-skills/jindo/internal/pkg/git"
+	"github.com/itda-skills/jindo/internal/pkg/pkgmgr"
+	"github.com/itda-skills/jindo/internal/pkg/repo"
+	"github.com/spf13/cobra"
+)
+
+// Exit codes returned by jd. They are part of the CLI contract: scripts can
+// branch on them, so existing values must never change meaning.
+const (
+	ExitOK         = 0   // Success, or a declined confirmation prompt
+	ExitFailure    = 1   // Any error not covered below
+	ExitUsage      = 2   // Invalid arguments, flags, or unknown command
+	ExitNotFound   = 3   // Skill, command, agent, hook, package, repository, or key not found
+	ExitValidation = 4   // Validation failed (jd validate, jd settings validate)
+	ExitNetwork    = 5   // A remote or download could not be reached
+	ExitCancelled  = 130 // Interrupted with Ctrl-C, as for SIGINT
+)
+
+// ExitError is an error that makes jd exit with a specific code
+type ExitError struct {
+	Code int
+	Err  error
+
+	usage *cobra.Command // Command whose usage is printed after the error
+}
+
+func (e *ExitError) Error() string { return e.Err.Error() }
+
+func (e *ExitError) Unwrap() error { return e.Err }
+
+// errCancelled is returned when the user declines a confirmation prompt.
+// The command has already said so, so it is not printed as an error, and
+// as the user chose to stop, jd exits with ExitOK.
+var errCancelled = &ExitError{Code: ExitOK, Err: errors.New("cancelled")}
+
+// notFoundErrorf returns a not-found error with a formatted message
+func notFoundErrorf(format string, args ...any) error {
+	return &ExitError{Code: ExitNotFound, Err: fmt.Errorf(format, args...)}
+}
+
+// validationErrorf returns a validation error with a formatted message
+func validationErrorf(format string, args ...any) error {
+	return &ExitError{Code: ExitValidation, Err: fmt.Errorf(format, args...)}
+}
+
+// usageError marks err as a command-line usage error of cmd (nil to not print its usage)
+func usageError(cmd *cobra.Command, err error) error {
+	return &ExitError{Code: ExitUsage, Err: err, usage: cmd}
+}
+
+// ExitCode returns the process exit code for an error returned by Execute
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+
+	// Pass through an interrupted child process (e.g., the claude CLI)
+	var procErr *exec.ExitError
+	if errors.As(err, &procErr) {
+		if status, ok := procErr.Sys().(syscall.WaitStatus); ok && status.Signaled() && status.Signal() == syscall.SIGINT {
+			return ExitCancelled
+		}
+		if procErr.ExitCode() == ExitCancelled {
+			return ExitCancelled
+		}
+	}
+
+	var remoteErr *git.RemoteError
+	var urlErr *url.Error
+	var netErr net.Error
+	switch {
+	case errors.As(err, &remoteErr), errors.As(err, &urlErr), errors.As(err, &netErr):
+		return ExitNetwork
+	case errors.Is(err, os.ErrNotExist),
+		errors.Is(err, pkgmgr.ErrPackageNotFound),
+		errors.Is(err, repo.ErrRepoNotFound),
+		errors.Is(err, repo.ErrPackageNotFound):
+		return ExitNotFound
+	}
+	return ExitFailure
+}
+
+// wrapArgsErrors makes the argument validators of cmd and its subcommands return usage errors
+func wrapArgsErrors(cmd *cobra.Command) {
+	if validate := cmd.Args; validate != nil {
+		cmd.Args = func(cmd *cobra.Command, args []string) error {
+			if err := validate(cmd, args); err != nil {
+				return usageError(cmd, err)
+			}
+			return nil
+		}
+	}
+	for _, sub := range cmd.Commands() {
+		wrapArgsErrors(sub)
+	}
+}
+
+// classifyCobraError marks the errors cobra reports before running a command as usage errors
+func classifyCobraError(err error) error {
+	if strings.HasPrefix(err.Error(), "unknown command ") {
+		return usageError(nil, err)
+	}
+	return err
+}
+
+var exitCodesCmd = &cobra.Command{
+	Use:   "exit-codes",
+	Short: "Exit codes returned by jd",
+	Long: `jd exits with a code describing the kind of failure, so scripts and
+wrappers can branch on it:
+
+  0    Success, or a confirmation prompt declined
+  1    Any other error
+  2    Invalid arguments, flags, or unknown command
+  3    Not found: skill, command, agent, hook, package, repository, or config key
+  4    Validation failed (jd validate, jd settings validate)
+  5    Network failure: a remote could not be reached (clone, pull, fetch),
+       or a download failed; not a pull that cannot fast-forward
+  130  Interrupted with Ctrl-C (also passed through when the claude CLI
+       is interrupted)
+
+Example:
+  jd pkg update affa-ever--web-fetch --apply
+  case $? in
+    3) echo "not installed" ;;
+    5) echo "offline, try again later" ;;
+  esac`,
+}
+
+func init() {
+	rootCmd.AddCommand(exitCodesCmd)
+}
@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"syscall"
+	"testing"
+
+	"github.com/itda-skills/jindo/internal/pkg/git"
+	"github.com/itda-skills/jindo/internal/pkg/pkgmgr"
+)
+
+func TestExitCode(t *testing.T) {
+	interrupted := exec.Command("sh", "-c", "exit 130").Run()
+	failed := exec.Command("sh", "-c", "exit 3").Run()
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"success", nil, ExitOK},
+		{"declined prompt", errCancelled, ExitOK},
+		{"declined prompt, wrapped", fmt.Errorf("delete: %w", errCancelled), ExitOK},
+		{"usage", usageError(nil, errors.New("bad flag")), ExitUsage},
+		{"not found", notFoundErrorf("skill not found: %s", "x"), ExitNotFound},
+		{"validation", validationErrorf("invalid"), ExitValidation},
+		{"missing file", fmt.Errorf("read: %w", os.ErrNotExist), ExitNotFound},
+		{"package not installed", fmt.Errorf("uninstall: %w", pkgmgr.ErrPackageNotFound), ExitNotFound},
+		{"remote unreachable", fmt.Errorf("update: %w", &git.RemoteError{Op: "pull", Err: errors.New("exit status 1")}), ExitNetwork},
+		{"download failed", &url.Error{Op: "Get", URL: "https://example.com", Err: syscall.ECONNREFUSED}, ExitNetwork},
+		{"claude interrupted", fmt.Errorf("claude: %w", interrupted), ExitCancelled},
+		{"child failed", fmt.Errorf("claude: %w", failed), ExitFailure},
+		{"other", errors.New("boom"), ExitFailure},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("ExitCode(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
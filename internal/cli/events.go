@@ -0,0 +1,191 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/events"
+	"github.com/spf13/cobra"
+)
+
+var (
+	eventsFollow bool
+	eventsTypes  []string
+	eventsLimit  int
+	eventsSince  time.Duration
+	eventsJSON   bool
+)
+
+// eventsPollInterval is how often --follow checks the log for new events
+const eventsPollInterval = 500 * time.Millisecond
+
+var eventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "Show the log of changes jd made, or follow it",
+	Long: `Show the log of changes jd made, for CI bots, dashboards, and editor
+extensions to read or tail. Event types:
+  install       A package was installed, imported from a plugin, or adopted
+  uninstall     A package was uninstalled
+  update        A package was updated (data has the previous version)
+  hook-change   A hook rule was added, edited, deleted, disabled, or enabled
+  validate      'jd validate' ran (data has the error and warning counts)
+  setup         A package's one-time setup step ran (data has the exit code and log)
+
+Events are kept as newline-delimited JSON in events.jsonl in the jd data
+directory (~/.itda-skills by default), which tools can also tail directly. Each
+line has the time, type, subject (the package, hook, or validated scope), and
+data. The log is rotated to events.jsonl.1 when it passes 5 MB.
+
+Use --follow to print new events as they are recorded until interrupted, and
+--json to print each event as a line of JSON, as in the log.
+
+Examples:
+  jd events
+  jd events -t install -t update --since 24h
+  jd events --follow --json -t validate`,
+	Args: cobra.NoArgs,
+	RunE: runEvents,
+}
+
+func init() {
+	rootCmd.AddCommand(eventsCmd)
+	eventsCmd.Flags().BoolVarP(&eventsFollow, "follow", "f", false, "Keep printing events as they are recorded")
+	eventsCmd.Flags().StringSliceVarP(&eventsTypes, "type", "t", nil, "Show only events of this type (repeatable)")
+	eventsCmd.Flags().IntVarP(&eventsLimit, "limit", "n", 20, "Number of recorded events to show (0 for all)")
+	eventsCmd.Flags().DurationVar(&eventsSince, "since", 0, "Show only events recorded within this long (e.g., 24h)")
+	eventsCmd.Flags().BoolVar(&eventsJSON, "json", false, "Print each event as a line of JSON")
+	_ = eventsCmd.RegisterFlagCompletionFunc("type", cobra.FixedCompletions(eventTypeNames(), cobra.ShellCompDirectiveNoFileComp))
+}
+
+// eventTypeNames returns the names of the event types, for --type
+func eventTypeNames() []string {
+	names := make([]string, len(events.Types))
+	for i, t := range events.Types {
+		names[i] = string(t)
+	}
+	return names
+}
+
+func runEvents(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+
+	for _, t := range eventsTypes {
+		if !slices.Contains(events.Types, events.Type(t)) {
+			return validationErrorf("invalid event type: %s (use: %s)", t, strings.Join(eventTypeNames(), ", "))
+		}
+	}
+	if eventsLimit < 0 {
+		return validationErrorf("--limit must not be negative")
+	}
+
+	dataDir, err := basedir.Expand(basedir.DataDir())
+	if err != nil {
+		return fmt.Errorf("failed to find the data directory: %w", err)
+	}
+	log := events.NewLog(dataDir)
+
+	recorded, offset, err := log.Read()
+	if err != nil {
+		return fmt.Errorf("failed to read events: %w", err)
+	}
+	recorded = slices.DeleteFunc(recorded, func(e events.Event) bool { return !showEvent(e) })
+	if eventsLimit > 0 && len(recorded) > eventsLimit {
+		recorded = recorded[len(recorded)-eventsLimit:]
+	}
+
+	if len(recorded) == 0 && !eventsFollow && !eventsJSON {
+		fmt.Println("No events recorded.")
+		return nil
+	}
+	for _, e := range recorded {
+		if err := printEvent(e); err != nil {
+			return err
+		}
+	}
+	if !eventsFollow {
+		return nil
+	}
+
+	if !eventsJSON {
+		fmt.Fprintf(os.Stderr, "Following %s (Ctrl+C to stop)\n", log.Path())
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	var printErr error
+	err = log.Follow(ctx, offset, eventsPollInterval, func(e events.Event) {
+		if printErr == nil && showEvent(e) {
+			printErr = printEvent(e)
+			if printErr != nil {
+				stop()
+			}
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to follow events: %w", err)
+	}
+	return printErr
+}
+
+// showEvent reports whether an event passes --type and --since
+func showEvent(e events.Event) bool {
+	if len(eventsTypes) > 0 && !slices.Contains(eventsTypes, string(e.Type)) {
+		return false
+	}
+	return eventsSince == 0 || time.Since(e.Time) <= eventsSince
+}
+
+// printEvent prints an event as a line of JSON with --json, or as its time,
+// type, subject, and data
+func printEvent(e events.Event) error {
+	if eventsJSON {
+		line, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(line))
+		return nil
+	}
+
+	color := isTerminal(os.Stdout) && os.Getenv("NO_COLOR") == ""
+	kind := fmt.Sprintf("%-11s", e.Type)
+	if color {
+		kind = diffColorCyan + kind + diffColorReset
+	}
+	fmt.Printf("%s  %s  %s%s\n", e.Time.Local().Format("2006-01-02 15:04:05"), kind, e.Subject, eventDetails(e.Data))
+	return nil
+}
+
+// eventDetails formats the data of an event as key=value pairs sorted by key,
+// leaving out lists and objects, which --json shows
+func eventDetails(data map[string]any) string {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		switch v := data[k].(type) {
+		case string, bool, float64, int:
+			s := fmt.Sprint(v)
+			if len(s) == 40 && strings.HasSuffix(k, "version") {
+				s = s[:7] // Commit SHA
+			}
+			if s != "" {
+				fmt.Fprintf(&b, " %s=%s", k, s)
+			}
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,252 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/itda-skills/jindo/internal/agent"
+	"github.com/itda-skills/jindo/internal/command"
+	"github.com/itda-skills/jindo/internal/explain"
+	"github.com/itda-skills/jindo/internal/hook"
+	"github.com/itda-skills/jindo/internal/skill"
+	"github.com/spf13/cobra"
+)
+
+var (
+	explainJSON   bool
+	explainGlobal bool
+	explainLocal  bool
+)
+
+// explainTypes are the resource types jd explain accepts, with their plurals
+var explainTypes = map[string]string{
+	"skill": "skill", "skills": "skill",
+	"command": "command", "commands": "command",
+	"agent": "agent", "agents": "agent",
+	"hook": "hook", "hooks": "hook",
+}
+
+var explainCmd = &cobra.Command{
+	Use:   "explain <type> <name>",
+	Short: "Summarize what a skill, command, agent, or hook does, without AI",
+	Long: `Print a structured summary of a skill, command, agent, or hook: what it is,
+when it triggers, what it runs, and which tools it uses.
+
+The summary is extracted from the resource itself, without calling Claude, so
+the same file always gives the same summary. It works offline and in CI:
+  What it is     The description (or the first paragraph) and the model
+  When           How it is invoked, and sentences of the description saying when
+  Runs           Commands in shell code blocks, !` + "`cmd`" + ` lines of commands, and hook commands
+  Tools          Tools declared in the frontmatter and tools named in the body
+  Outline        The headings
+
+<type> is skill, command, agent, or hook. <name> is looked up like 'jd <type>s show'
+does, or is a path to a file (a skill's directory, or a hook script), which
+explains packages before they are installed.
+
+Default scope is local if a .claude directory exists, otherwise global.
+Use --global or --local to override.`,
+	Example: `  jd explain skill web-fetch
+  jd explain command git:commit --json
+  jd explain hook PostToolUse-Edit-Write-0 -g
+  jd explain skill ./skills/web-fetch`,
+	Args:              cobra.ExactArgs(2),
+	RunE:              runExplain,
+	ValidArgsFunction: explainCompletion,
+}
+
+func init() {
+	rootCmd.AddCommand(explainCmd)
+	explainCmd.Flags().BoolVar(&explainJSON, "json", false, "Output in JSON format")
+	explainCmd.Flags().BoolVarP(&explainGlobal, "global", "g", false, "Explain a global ~/.claude resource")
+	explainCmd.Flags().BoolVarP(&explainLocal, "local", "l", false, "Explain a local .claude resource")
+}
+
+func runExplain(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+	resourceType, ok := explainTypes[strings.ToLower(args[0])]
+	if !ok {
+		return validationErrorf("invalid type: %s (use: skill, command, agent, hook)", args[0])
+	}
+	name := args[1]
+
+	var summary *explain.Summary
+	var err error
+	if isExplainPath(name) {
+		summary, err = explainFile(resourceType, name)
+	} else {
+		summary, err = explainInstalled(resourceType, name)
+	}
+	if err != nil {
+		return err
+	}
+
+	if explainJSON {
+		output, err := json.MarshalIndent(summary, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(output))
+		return nil
+	}
+	printExplanation(summary)
+	return nil
+}
+
+// isExplainPath reports whether name is a path to a file or directory rather than a resource name
+func isExplainPath(name string) bool {
+	if !strings.ContainsAny(name, `/\`) && filepath.Ext(name) == "" {
+		return false
+	}
+	_, err := os.Stat(name)
+	return err == nil
+}
+
+// explainFile explains the resource in a file, or the SKILL.md of a skill directory
+func explainFile(resourceType, path string) (*explain.Summary, error) {
+	name := strings.TrimSuffix(filepath.Base(path), ".md")
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		name = filepath.Base(path)
+		for _, file := range []string{"SKILL.md", "skill.md"} {
+			if _, err := os.Stat(filepath.Join(path, file)); err == nil {
+				path = filepath.Join(path, file)
+				break
+			}
+		}
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var summary *explain.Summary
+	if resourceType == "hook" {
+		event, matcher := "", ""
+		if meta, err := hook.ParseScriptMeta(path); err == nil && meta != nil {
+			event, matcher = string(meta.EventType), meta.Matcher
+		}
+		summary = explain.Script(filepath.Base(path), event, matcher, string(content))
+	} else {
+		summary = explain.Markdown(resourceType, name, string(content))
+	}
+	summary.Path = path
+	return summary, nil
+}
+
+// explainInstalled explains a resource by name in the resolved scope
+func explainInstalled(resourceType, name string) (*explain.Summary, error) {
+	scope, err := ResolveScope(explainGlobal, explainLocal)
+	if err != nil {
+		return nil, err
+	}
+
+	var path string
+	switch resourceType {
+	case "skill":
+		var s *skill.Skill
+		if s, err = skill.NewStore(GetPathByScope(scope, "skills")).Get(name); err == nil {
+			path = s.Path
+		}
+	case "command":
+		var c *command.Command
+		if c, err = command.NewStore(GetPathByScope(scope, "commands")).Get(name); err == nil {
+			path = c.Path
+		}
+	case "agent":
+		var a *agent.Agent
+		if a, err = agent.NewStore(GetPathByScope(scope, "agents")).Get(name); err == nil {
+			path = a.Path
+		}
+	case "hook":
+		var h *hook.Hook
+		if h, err = hook.NewStore(GetSettingsPathByScope(scope)).Get(name); err == nil {
+			return explain.Hook(h.Name, string(h.EventType), h.Matcher, h.Commands), nil
+		}
+	}
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, notFoundErrorf("%s not found in %s: %s", resourceType, ScopeDescription(scope), name)
+		}
+		return nil, fmt.Errorf("failed to get %s: %w", resourceType, err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", resourceType, err)
+	}
+	summary := explain.Markdown(resourceType, name, string(content))
+	summary.Path = path
+	return summary, nil
+}
+
+// printExplanation prints a summary as labeled sections
+func printExplanation(s *explain.Summary) {
+	fmt.Printf("%s %s\n", s.Type, s.Name)
+	if s.Path != "" {
+		fmt.Printf("  Path: %s\n", s.Path)
+	}
+
+	fmt.Println("\nWhat it is:")
+	if s.Description != "" {
+		fmt.Printf("  %s\n", s.Description)
+	} else {
+		fmt.Println("  (no description)")
+	}
+	if s.Model != "" {
+		fmt.Printf("  Model: %s\n", s.Model)
+	}
+
+	fmt.Println("\nWhen it triggers:")
+	for _, t := range s.Triggers {
+		fmt.Printf("  - %s\n", t)
+	}
+
+	fmt.Println("\nWhat it runs:")
+	if len(s.Runs) == 0 {
+		fmt.Println("  (no shell commands)")
+	}
+	for _, r := range s.Runs {
+		fmt.Printf("  $ %s\n", r)
+	}
+
+	if len(s.AllowedTools) > 0 || len(s.ReferencedTools) > 0 {
+		fmt.Println("\nTools:")
+		if len(s.AllowedTools) > 0 {
+			fmt.Printf("  Allowed:    %s\n", strings.Join(s.AllowedTools, ", "))
+		}
+		if len(s.ReferencedTools) > 0 {
+			fmt.Printf("  Referenced: %s\n", strings.Join(s.ReferencedTools, ", "))
+		}
+	}
+
+	if len(s.Headings) > 0 {
+		fmt.Println("\nOutline:")
+		for _, h := range s.Headings {
+			fmt.Printf("  %s\n", h)
+		}
+	}
+}
+
+// explainCompletion completes the type, then the names of resources of that type
+func explainCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	switch len(args) {
+	case 0:
+		return []string{"skill", "command", "agent", "hook"}, cobra.ShellCompDirectiveNoFileComp
+	case 1:
+		switch explainTypes[strings.ToLower(args[0])] {
+		case "skill":
+			return skillNameCompletion(cmd, nil, toComplete)
+		case "command":
+			return commandNameCompletion(cmd, nil, toComplete)
+		case "agent":
+			return agentNameCompletion(cmd, nil, toComplete)
+		case "hook":
+			return hookNameCompletion(cmd, nil, toComplete)
+		}
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,14 @@
+package cli
+
+import "github.com/spf13/cobra"
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export resources to other formats",
+	Long: `Export skills, commands, agents, and hooks managed by jd to formats
+other tools install natively.`,
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+}
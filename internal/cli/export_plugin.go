@@ -0,0 +1,255 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/itda-skills/jindo/internal/agent"
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/command"
+	"github.com/itda-skills/jindo/internal/hook"
+	"github.com/itda-skills/jindo/internal/plugin"
+	"github.com/itda-skills/jindo/internal/skill"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportPluginOutput      string
+	exportPluginName        string
+	exportPluginDescription string
+	exportPluginVersion     string
+	exportPluginAuthor      string
+	exportPluginSkills      []string
+	exportPluginCommands    []string
+	exportPluginAgents      []string
+	exportPluginHooks       []string
+	exportPluginAll         bool
+	exportPluginForce       bool
+	exportPluginGlobal      bool
+	exportPluginLocal       bool
+)
+
+var exportPluginCmd = &cobra.Command{
+	Use:   "plugin --output <dir>",
+	Short: "Export resources as a Claude Code plugin",
+	Long: `Write selected skills, commands, agents, and hooks as a Claude Code plugin:
+a directory with a .claude-plugin/plugin.json manifest that users can install
+natively, add to a plugin marketplace, or try with 'claude --plugin-dir <dir>'.
+
+Select resources with --skill, --command, --agent, and --hook (repeatable, or
+comma-separated), or export everything in the scope with --all. Hooks are
+written to hooks/hooks.json. Scripts in the scope's hooks directory that they
+run are copied to scripts/ and run from ${CLAUDE_PLUGIN_ROOT}.
+
+The plugin name defaults to the name of the output directory and must be
+kebab-case. The output directory must be empty unless --force is set.
+
+Default scope is local if a .claude directory exists in the current or a parent directory (the nearest one is used), otherwise global.
+Use --global or --local to override.`,
+	Example: `  jd export plugin --output ./team-tools --skill web-fetch --command team:deploy
+  jd export plugin -o ./my-setup --all --version 1.0.0 --author "Jane Doe"
+  jd export plugin -o ./format-hooks --hook format-on-save --description "Format files after edits"`,
+	Args: cobra.NoArgs,
+	RunE: runExportPlugin,
+}
+
+func init() {
+	exportCmd.AddCommand(exportPluginCmd)
+	exportPluginCmd.Flags().StringVarP(&exportPluginOutput, "output", "o", "", "Directory to write the plugin to (required)")
+	exportPluginCmd.Flags().StringVar(&exportPluginName, "name", "", "Plugin name (default: name of the output directory)")
+	exportPluginCmd.Flags().StringVar(&exportPluginDescription, "description", "", "Plugin description")
+	exportPluginCmd.Flags().StringVar(&exportPluginVersion, "version", "", "Plugin version (e.g., 1.0.0)")
+	exportPluginCmd.Flags().StringVar(&exportPluginAuthor, "author", "", "Plugin author")
+	exportPluginCmd.Flags().StringSliceVar(&exportPluginSkills, "skill", nil, "Skill to export (repeatable)")
+	exportPluginCmd.Flags().StringSliceVar(&exportPluginCommands, "command", nil, "Command to export (repeatable)")
+	exportPluginCmd.Flags().StringSliceVar(&exportPluginAgents, "agent", nil, "Agent to export (repeatable)")
+	exportPluginCmd.Flags().StringSliceVar(&exportPluginHooks, "hook", nil, "Hook to export (repeatable)")
+	exportPluginCmd.Flags().BoolVar(&exportPluginAll, "all", false, "Export all skills, commands, agents, and hooks in the scope")
+	exportPluginCmd.Flags().BoolVarP(&exportPluginForce, "force", "f", false, "Write into a non-empty output directory, overwriting files")
+	exportPluginCmd.Flags().BoolVarP(&exportPluginGlobal, "global", "g", false, "Export from global ~/.claude/")
+	exportPluginCmd.Flags().BoolVarP(&exportPluginLocal, "local", "l", false, "Export from local .claude/")
+	_ = exportPluginCmd.MarkFlagDirname("output")
+	_ = exportPluginCmd.RegisterFlagCompletionFunc("skill", skillNameCompletion)
+	_ = exportPluginCmd.RegisterFlagCompletionFunc("command", commandNameCompletion)
+	_ = exportPluginCmd.RegisterFlagCompletionFunc("agent", agentNameCompletion)
+	_ = exportPluginCmd.RegisterFlagCompletionFunc("hook", hookNameCompletion)
+}
+
+func runExportPlugin(cmd *cobra.Command, _ []string) error {
+	if exportPluginOutput == "" {
+		return usageError(cmd, errors.New("--output is required"))
+	}
+	if !exportPluginAll && len(exportPluginSkills)+len(exportPluginCommands)+len(exportPluginAgents)+len(exportPluginHooks) == 0 {
+		return usageError(cmd, errors.New("select resources with --skill, --command, --agent, or --hook, or use --all"))
+	}
+	cmd.SilenceUsage = true
+
+	scope, err := ResolveScope(exportPluginGlobal, exportPluginLocal)
+	if err != nil {
+		return err
+	}
+
+	output, err := filepath.Abs(exportPluginOutput)
+	if err != nil {
+		return fmt.Errorf("failed to resolve output directory: %w", err)
+	}
+	name := exportPluginName
+	if name == "" {
+		name = filepath.Base(output)
+	}
+	if err := plugin.ValidateName(name); err != nil {
+		return validationErrorf("%v (set one with --name)", err)
+	}
+
+	bundle := &plugin.Bundle{
+		Manifest: plugin.Manifest{
+			Name:        name,
+			Version:     exportPluginVersion,
+			Description: exportPluginDescription,
+		},
+	}
+	if bundle.HooksDir, err = basedir.Expand(GetPathByScope(scope, "hooks")); err != nil {
+		return fmt.Errorf("failed to resolve hooks directory: %w", err)
+	}
+	if exportPluginAuthor != "" {
+		bundle.Manifest.Author = &plugin.Author{Name: exportPluginAuthor}
+	}
+	if root, err := ProjectRoot(); err == nil {
+		bundle.ProjectDir = root
+	}
+
+	if err := collectExportResources(bundle, scope); err != nil {
+		return err
+	}
+	if len(bundle.Skills)+len(bundle.Commands)+len(bundle.Agents)+len(bundle.Hooks) == 0 {
+		return notFoundErrorf("no skills, commands, agents, or hooks to export in %s", ScopeDescription(scope))
+	}
+
+	result, err := bundle.Write(output, exportPluginForce)
+	if err != nil {
+		if errors.Is(err, plugin.ErrNotEmpty) {
+			return validationErrorf("%v (use --force to overwrite)", err)
+		}
+		return fmt.Errorf("failed to export plugin: %w", err)
+	}
+
+	fmt.Printf("✓ Exported plugin %s to %s\n", name, output)
+	printExportedNames("Skills", bundle.Skills)
+	printExportedNames("Commands", bundle.Commands)
+	printExportedNames("Agents", bundle.Agents)
+	if len(bundle.Hooks) > 0 {
+		fmt.Printf("  Hooks:    %d rule(s) in %s\n", len(bundle.Hooks), plugin.HooksFile)
+	}
+	if len(result.Scripts) > 0 {
+		fmt.Printf("  Scripts:  %s\n", strings.Join(result.Scripts, ", "))
+	}
+	fmt.Printf("\nTry it with: claude --plugin-dir %s\n", output)
+	return nil
+}
+
+// collectExportResources adds the selected resources of a scope to the bundle,
+// or all of them with --all
+func collectExportResources(bundle *plugin.Bundle, scope PathScope) error {
+	skillStore := skill.NewStore(GetPathByScope(scope, "skills"))
+	if exportPluginAll {
+		skills, err := skillStore.List()
+		if err != nil {
+			return fmt.Errorf("failed to list skills: %w", err)
+		}
+		for _, s := range skills {
+			dir := filepath.Dir(s.Path)
+			bundle.Skills = append(bundle.Skills, plugin.Resource{Name: filepath.Base(dir), Path: dir})
+		}
+	}
+	for _, name := range exportPluginSkills {
+		s, err := skillStore.Get(name)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return notFoundErrorf("skill not found in %s: %s", ScopeDescription(scope), name)
+			}
+			return fmt.Errorf("failed to get skill: %w", err)
+		}
+		bundle.Skills = append(bundle.Skills, plugin.Resource{Name: name, Path: filepath.Dir(s.Path)})
+	}
+
+	commandStore := command.NewStore(GetPathByScope(scope, "commands"))
+	if exportPluginAll {
+		commands, err := commandStore.List()
+		if err != nil {
+			return fmt.Errorf("failed to list commands: %w", err)
+		}
+		for _, c := range commands {
+			bundle.Commands = append(bundle.Commands, plugin.Resource{Name: c.Name, Path: c.Path})
+		}
+	}
+	for _, name := range exportPluginCommands {
+		c, err := commandStore.Get(name)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return notFoundErrorf("command not found in %s: %s", ScopeDescription(scope), name)
+			}
+			return fmt.Errorf("failed to get command: %w", err)
+		}
+		bundle.Commands = append(bundle.Commands, plugin.Resource{Name: c.Name, Path: c.Path})
+	}
+
+	agentStore := agent.NewStore(GetPathByScope(scope, "agents"))
+	if exportPluginAll {
+		agents, err := agentStore.List()
+		if err != nil {
+			return fmt.Errorf("failed to list agents: %w", err)
+		}
+		for _, a := range agents {
+			bundle.Agents = append(bundle.Agents, plugin.Resource{Name: a.Name, Path: a.Path})
+		}
+	}
+	for _, name := range exportPluginAgents {
+		a, err := agentStore.Get(name)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return notFoundErrorf("agent not found in %s: %s", ScopeDescription(scope), name)
+			}
+			return fmt.Errorf("failed to get agent: %w", err)
+		}
+		bundle.Agents = append(bundle.Agents, plugin.Resource{Name: a.Name, Path: a.Path})
+	}
+
+	hookStore := hook.NewStore(GetSettingsPathByScope(scope))
+	var hooks []*hook.Hook
+	if exportPluginAll {
+		all, err := hookStore.List()
+		if err != nil {
+			return fmt.Errorf("failed to list hooks: %w", err)
+		}
+		hooks = append(hooks, all...)
+	}
+	for _, name := range exportPluginHooks {
+		h, err := hookStore.Get(name)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return notFoundErrorf("hook not found in %s: %s", ScopeDescription(scope), name)
+			}
+			return fmt.Errorf("failed to get hook: %w", err)
+		}
+		hooks = append(hooks, h)
+	}
+	for _, h := range hooks {
+		bundle.Hooks = append(bundle.Hooks, plugin.Hook{EventType: string(h.EventType), Matcher: h.Matcher, Commands: h.Commands})
+	}
+	return nil
+}
+
+// printExportedNames prints the names of exported resources of a kind
+func printExportedNames(label string, resources []plugin.Resource) {
+	if len(resources) == 0 {
+		return
+	}
+	names := make([]string, len(resources))
+	for i, r := range resources {
+		names[i] = r.Name
+	}
+	fmt.Printf("  %-9s %s\n", label+":", strings.Join(names, ", "))
+}
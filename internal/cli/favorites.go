@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/itda-skills/jindo/internal/favorite"
+	"github.com/spf13/cobra"
+)
+
+var favoritesCmd = &cobra.Command{
+	Use:     "favorites",
+	Aliases: []string{"fav", "favs"},
+	Short:   "Manage favorite resources and packages",
+	Long: `Mark skills, commands, agents, and packages as favorites.
+
+Favorites are stored in the config file under jindo.favorites.
+They are listed first with a ★ marker in 'jd list' and in the
+'jd pkg browse' TUI, where 'f' toggles a favorites-only view.
+
+Use the resource name as shown in list output:
+  - skill ID (e.g., web-fetch)
+  - command or agent name (e.g., game:init)
+  - namespaced package name (e.g., affa-ever--web-fetch)`,
+}
+
+func init() {
+	rootCmd.AddCommand(favoritesCmd)
+}
+
+var (
+	favoritesOnce sync.Once
+	favoritesSet  *favorite.Set
+)
+
+// isFavorite reports whether name is marked as a favorite.
+// Favorites are loaded once per process; load errors are treated as no favorites.
+func isFavorite(name string) bool {
+	favoritesOnce.Do(func() {
+		favoritesSet, _ = favorite.Load()
+	})
+	return favoritesSet.Contains(name)
+}
+
+// favoriteMarker returns the name column prefix for table output
+func favoriteMarker(name string) string {
+	if isFavorite(name) {
+		return "★ "
+	}
+	return "  "
+}
+
+// sortFavoritesFirst stably moves favorites to the front of a list
+func sortFavoritesFirst[T any](items []T, nameOf func(T) string) {
+	sort.SliceStable(items, func(i, j int) bool {
+		return isFavorite(nameOf(items[i])) && !isFavorite(nameOf(items[j]))
+	})
+}
+
+// anyFavorite reports whether any of the names is a favorite
+func anyFavorite(names ...string) bool {
+	for _, name := range names {
+		if isFavorite(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// favoriteLabel prefixes name with the favorite marker column when mark is set
+func favoriteLabel(name string, mark bool) string {
+	if !mark {
+		return name
+	}
+	return favoriteMarker(name) + name
+}
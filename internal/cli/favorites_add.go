@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/itda-skills/jindo/internal/favorite"
+	"github.com/spf13/cobra"
+)
+
+var favoritesAddCmd = &cobra.Command{
+	Use:     "add <name>...",
+	Aliases: []string{"a"},
+	Short:   "Mark resources or packages as favorites",
+	Example: `  jd favorites add web-fetch
+  jd fav add affa-ever--web-fetch game:init`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runFavoritesAdd,
+}
+
+func init() {
+	favoritesCmd.AddCommand(favoritesAddCmd)
+}
+
+func runFavoritesAdd(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	added, err := favorite.Add(args...)
+	if err != nil {
+		return fmt.Errorf("failed to update favorites: %w", err)
+	}
+
+	if len(added) == 0 {
+		fmt.Println("Already in favorites.")
+		return nil
+	}
+
+	for _, name := range added {
+		fmt.Printf("★ Added to favorites: %s\n", name)
+	}
+	return nil
+}
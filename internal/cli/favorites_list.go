@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/itda-skills/jindo/internal/favorite"
+	"github.com/spf13/cobra"
+)
+
+var favoritesListJSON bool
+
+var favoritesListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"l", "ls"},
+	Short:   "List favorites",
+	RunE:    runFavoritesList,
+}
+
+func init() {
+	favoritesCmd.AddCommand(favoritesListCmd)
+	favoritesListCmd.Flags().BoolVar(&favoritesListJSON, "json", false, "Output in JSON format")
+	addOutputFlags(favoritesListCmd)
+}
+
+func runFavoritesList(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+
+	favs, err := favorite.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load favorites: %w", err)
+	}
+
+	names := favs.Names()
+
+	if favoritesListJSON || formattedOutput() {
+		return printJSON(names)
+	}
+
+	if len(names) == 0 {
+		fmt.Println("No favorites.")
+		fmt.Println()
+		fmt.Println("Add one with:")
+		fmt.Println("  jd favorites add <name>")
+		return nil
+	}
+
+	for _, name := range names {
+		fmt.Printf("★ %s\n", name)
+	}
+	fmt.Printf("\nTotal: %d favorites\n", len(names))
+	return nil
+}
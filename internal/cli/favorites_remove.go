@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/itda-skills/jindo/internal/favorite"
+	"github.com/spf13/cobra"
+)
+
+var favoritesRemoveCmd = &cobra.Command{
+	Use:               "remove <name>...",
+	Aliases:           []string{"rm", "delete", "d"},
+	Short:             "Remove resources or packages from favorites",
+	Args:              cobra.MinimumNArgs(1),
+	RunE:              runFavoritesRemove,
+	ValidArgsFunction: favoriteNameCompletion,
+}
+
+func init() {
+	favoritesCmd.AddCommand(favoritesRemoveCmd)
+}
+
+func runFavoritesRemove(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	removed, err := favorite.Remove(args...)
+	if err != nil {
+		return fmt.Errorf("failed to update favorites: %w", err)
+	}
+
+	if len(removed) == 0 {
+		fmt.Println("No matching favorites.")
+		return nil
+	}
+
+	for _, name := range removed {
+		fmt.Printf("Removed from favorites: %s\n", name)
+	}
+	return nil
+}
+
+// favoriteNameCompletion provides completion for favorite names
+func favoriteNameCompletion(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	favs, err := favorite.Load()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return favs.Names(), cobra.ShellCompDirectiveNoFileComp
+}
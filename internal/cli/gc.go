@@ -0,0 +1,377 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/itda-skills/jindo/internal/agent"
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/guide"
+	"github.com/itda-skills/jindo/internal/hook"
+	"github.com/itda-skills/jindo/internal/pkg/pkgmgr"
+	"github.com/itda-skills/jindo/internal/skill"
+	"github.com/itda-skills/jindo/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+// Retention policy config keys and their defaults (0 disables the category)
+const (
+	gcRepoUnusedDaysKey = "gc.repos.unused_days"
+	gcGuideMaxAgeKey    = "gc.guides.max_age_days"
+	gcHistoryKeepKey    = "gc.history.keep"
+
+	defaultGCRepoUnusedDays = 30
+	defaultGCGuideMaxAge    = 30
+	defaultGCHistoryKeep    = 10
+)
+
+var (
+	gcDryRun bool
+	gcForce  bool
+)
+
+var gcCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Remove unused repository clones, old guide caches, and stale history",
+	Long: `Reclaim disk space used by jd.
+
+Three categories are collected:
+  Repository clones  Repositories with no installed packages (registration and clone)
+                     and clone directories with no registered repository
+  Guide caches       Cached guides older than the retention period
+  History versions   Old versions beyond the number kept per skill, agent, and hook
+
+Retention policies are read from config (0 disables a category):
+  ` + gcRepoUnusedDaysKey + `   Days since a repository was added before it counts as unused (default 30)
+  ` + gcGuideMaxAgeKey + ` Maximum age of cached guides in days (default 30)
+  ` + gcHistoryKeepKey + `        Versions kept per resource (default 10)
+
+History is collected for the global scope and, if present, the local scope.
+Reclaimable space is reported per category, then deleted after confirmation.`,
+	Example: `  # Show what would be removed
+  jd gc --dry-run
+
+  # Remove without confirmation
+  jd gc --force
+
+  # Keep only the last 3 versions of each resource
+  jd config set gc.history.keep 3`,
+	Args: cobra.NoArgs,
+	RunE: runGC,
+}
+
+func init() {
+	rootCmd.AddCommand(gcCmd)
+	gcCmd.Flags().BoolVarP(&gcDryRun, "dry-run", "n", false, "Report reclaimable space without deleting")
+	gcCmd.Flags().BoolVarP(&gcForce, "force", "f", false, "Skip confirmation prompt")
+}
+
+// gcItem is a single reclaimable entry
+type gcItem struct {
+	Label  string
+	Size   int64
+	remove func() error
+}
+
+// gcCategory groups reclaimable entries of one kind
+type gcCategory struct {
+	Name  string
+	Items []gcItem
+}
+
+// size returns the total reclaimable size of the category
+func (c *gcCategory) size() int64 {
+	var total int64
+	for _, item := range c.Items {
+		total += item.Size
+	}
+	return total
+}
+
+func runGC(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	repoCat, err := collectGCRepos(cfg.GetInt(gcRepoUnusedDaysKey, defaultGCRepoUnusedDays))
+	if err != nil {
+		return fmt.Errorf("failed to collect repositories: %w", err)
+	}
+	guideCat, err := collectGCGuides(cfg.GetInt(gcGuideMaxAgeKey, defaultGCGuideMaxAge))
+	if err != nil {
+		return fmt.Errorf("failed to collect guide caches: %w", err)
+	}
+	historyCat := collectGCHistory(cfg.GetInt(gcHistoryKeepKey, defaultGCHistoryKeep))
+
+	return runGCCategories([]*gcCategory{repoCat, guideCat, historyCat}, gcDryRun, gcForce)
+}
+
+// runGCCategories reports reclaimable space per category and, unless dryRun,
+// deletes the items after confirmation (skipped with force)
+func runGCCategories(categories []*gcCategory, dryRun, force bool) error {
+	var total int64
+	count := 0
+	for _, c := range categories {
+		fmt.Printf("%s: %d item(s), %s\n", c.Name, len(c.Items), formatBytes(c.size()))
+		for _, item := range c.Items {
+			fmt.Printf("  %s  %s\n", item.Label, formatBytes(item.Size))
+		}
+		total += c.size()
+		count += len(c.Items)
+	}
+	fmt.Printf("\nTotal reclaimable: %s\n", formatBytes(total))
+
+	if count == 0 {
+		fmt.Println("Nothing to clean up.")
+		return nil
+	}
+	if dryRun {
+		fmt.Println("Dry run: nothing was deleted.")
+		return nil
+	}
+
+	if !force {
+		fmt.Printf("\nDelete %d item(s)? (y/N): ", count)
+
+		reader := bufio.NewReader(os.Stdin)
+		response, err := reader.ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("failed to read input: %w", err)
+		}
+
+		response = strings.TrimSpace(strings.ToLower(response))
+		if response != "y" && response != "yes" {
+			fmt.Println("Cancelled.")
+			return errCancelled
+		}
+	}
+
+	var reclaimed int64
+	failed := 0
+	for _, c := range categories {
+		for _, item := range c.Items {
+			if err := item.remove(); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to remove %s: %v\n", item.Label, err)
+				failed++
+				continue
+			}
+			reclaimed += item.Size
+		}
+	}
+
+	fmt.Printf("Reclaimed %s\n", formatBytes(reclaimed))
+	if failed > 0 {
+		return fmt.Errorf("%d item(s) could not be removed", failed)
+	}
+	return nil
+}
+
+// collectGCRepos finds repositories without installed packages and orphaned clone directories
+func collectGCRepos(unusedDays int) (*gcCategory, error) {
+	cat := &gcCategory{Name: "Repository clones"}
+
+	manager := pkgmgr.NewManager(basedir.DataDir())
+	store := manager.RepoStore()
+
+	orphans, err := store.OrphanedClones()
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range orphans {
+		cat.Items = append(cat.Items, gcItem{
+			Label:  fmt.Sprintf("%s (unregistered)", path),
+			Size:   dirSize(path),
+			remove: func() error { return os.RemoveAll(path) },
+		})
+	}
+
+	if unusedDays <= 0 {
+		return cat, nil
+	}
+
+	packages, err := manager.List()
+	if err != nil {
+		return nil, err
+	}
+	used := make(map[string]bool)
+	for _, p := range packages {
+		used[p.Namespace] = true
+	}
+
+	repos, err := store.List()
+	if err != nil {
+		return nil, err
+	}
+	cutoff := time.Now().AddDate(0, 0, -unusedDays)
+	for _, r := range repos {
+		// Linked repositories point at a live checkout and take no space
+		if used[r.Namespace] || r.Link || r.AddedAt.After(cutoff) {
+			continue
+		}
+		path, err := store.RepoLocalPath(r.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		namespace := r.Namespace
+		cat.Items = append(cat.Items, gcItem{
+			Label:  fmt.Sprintf("%s (no installed packages)", namespace),
+			Size:   dirSize(path),
+			remove: func() error { return store.Remove(namespace) },
+		})
+	}
+
+	return cat, nil
+}
+
+// collectGCGuides finds cached guide files older than maxAgeDays
+func collectGCGuides(maxAgeDays int) (*gcCategory, error) {
+	cat := &gcCategory{Name: "Guide caches"}
+	if maxAgeDays <= 0 {
+		return cat, nil
+	}
+
+	dirs, err := guide.CacheDirs()
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := time.Now().AddDate(0, 0, -maxAgeDays)
+	for _, dir := range dirs {
+		_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil || info.ModTime().After(cutoff) {
+				return nil
+			}
+			cat.Items = append(cat.Items, gcItem{
+				Label:  path,
+				Size:   info.Size(),
+				remove: func() error { return os.Remove(path) },
+			})
+			return nil
+		})
+	}
+
+	return cat, nil
+}
+
+// collectGCHistory finds history versions beyond the newest keep versions of each
+// skill, agent, and hook in the global and local scopes
+func collectGCHistory(keep int) *gcCategory {
+	cat := &gcCategory{Name: "History versions"}
+	if keep <= 0 {
+		return cat
+	}
+
+	scopes := []PathScope{ScopeGlobal}
+	if LocalClaudeDirExists() {
+		scopes = append(scopes, ScopeLocal)
+	}
+
+	for _, scope := range scopes {
+		if skills, err := skill.NewStore(GetPathByScope(scope, "skills")).List(); err == nil {
+			for _, s := range skills {
+				mgr := skill.NewHistoryManager(filepath.Dir(s.Path))
+				versions, _ := mgr.ListVersions()
+				for i := keep; i < len(versions); i++ {
+					v := versions[i]
+					cat.Items = append(cat.Items, historyGCItem(
+						fmt.Sprintf("skill %s %s", s.Name, skill.FormatVersionName(&v)),
+						mgr.VersionPath(&v),
+						func() error { return mgr.DeleteVersion(v.Number) },
+					))
+				}
+			}
+		}
+
+		agentsDir, err := expandScopeDir(GetPathByScope(scope, "agents"))
+		if err != nil {
+			continue
+		}
+		if agents, err := agent.NewStore(agentsDir).List(); err == nil {
+			for _, a := range agents {
+				mgr := agent.NewHistoryManager(agentsDir, a.Name)
+				versions, _ := mgr.ListVersions()
+				for i := keep; i < len(versions); i++ {
+					v := versions[i]
+					cat.Items = append(cat.Items, historyGCItem(
+						fmt.Sprintf("agent %s %s", a.Name, agent.FormatVersionName(&v)),
+						mgr.VersionPath(&v),
+						func() error { return mgr.DeleteVersion(v.Number) },
+					))
+				}
+			}
+		}
+
+		settingsPath, err := expandScopeDir(GetSettingsPathByScope(scope))
+		if err != nil {
+			continue
+		}
+		if hooks, err := hook.NewStore(settingsPath).List(); err == nil {
+			for _, h := range hooks {
+				mgr := hook.NewHistoryManager(filepath.Dir(settingsPath), h.Name)
+				versions, _ := mgr.ListVersions()
+				for i := keep; i < len(versions); i++ {
+					v := versions[i]
+					cat.Items = append(cat.Items, historyGCItem(
+						fmt.Sprintf("hook %s %s", h.Name, hook.FormatVersionName(&v)),
+						mgr.VersionPath(&v),
+						func() error { return mgr.DeleteVersion(v.Number) },
+					))
+				}
+			}
+		}
+	}
+
+	return cat
+}
+
+// historyGCItem builds a reclaimable entry for a history version file
+func historyGCItem(label, path string, remove func() error) gcItem {
+	var size int64
+	if info, err := os.Stat(path); err == nil {
+		size = info.Size()
+	}
+	return gcItem{Label: label, Size: size, remove: remove}
+}
+
+// dirSize returns the total size of regular files under path (symlinks are not followed)
+func dirSize(path string) int64 {
+	var total int64
+	_ = filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.Type().IsRegular() {
+			if info, err := d.Info(); err == nil {
+				total += info.Size()
+			}
+		}
+		return nil
+	})
+	return total
+}
+
+// formatBytes formats a byte count for display (e.g., 1.5 MB)
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
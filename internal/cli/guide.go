@@ -1,6 +1,9 @@
 package cli
 
 import (
+	"fmt"
+
+	"github.com/itda-skills/jindo/internal/guide"
 	"github.com/spf13/cobra"
 )
 
@@ -16,6 +19,10 @@ The guide command analyzes your skills, hooks, agents, or commands and provides:
 - Practical examples
 - Customization suggestions and improvements
 
+Guides are cached with a hash of the content they explain. When the skill,
+hook, agent, or command changes, its guide is regenerated; if that fails, the
+cached guide is shown with a warning.
+
 By default, it provides a one-shot explanation. Use -i for interactive mode
 where AI asks about your context and provides personalized guidance.`,
 	Example: `  # Get usage guide for a skill
@@ -31,3 +38,56 @@ where AI asks about your context and provides personalized guidance.`,
 func init() {
 	rootCmd.AddCommand(guideCmd)
 }
+
+// guideUserPrompts are the requests for a guide to each resource type, formatted with its ID
+var guideUserPrompts = map[guide.GuideType]string{
+	guide.TypeSkill:   "'%s' 스킬에 대한 사용법 가이드를 작성해주세요.",
+	guide.TypeCommand: "'%s' 명령에 대한 사용법 가이드를 작성해주세요.",
+	guide.TypeAgent:   "'%s' 에이전트에 대한 사용법 가이드를 작성해주세요.",
+	guide.TypeHook:    "'%s' 훅에 대한 사용법 가이드를 작성해주세요.",
+}
+
+// cachedGuide returns the cached guide for a resource whose content hashes to sourceHash.
+// A guide generated from other content is returned as stale instead, to be regenerated
+// and shown only if that fails. Both are nil with refresh or if nothing is cached.
+func cachedGuide(store *guide.Store, guideType guide.GuideType, id, sourceHash string, refresh bool) (cached, stale *guide.Guide) {
+	if refresh || !store.Exists(guideType, id) {
+		return nil, nil
+	}
+	g, err := store.Get(guideType, id)
+	if err != nil {
+		return nil, nil
+	}
+	if g.SourceHash != "" && g.SourceHash != sourceHash {
+		fmt.Println("🔄 내용이 변경되어 가이드를 다시 생성합니다.")
+		return nil, g
+	}
+	return g, nil
+}
+
+// showCachedGuide prints or opens a cached guide, warning if it may not match the
+// resource content hashing to sourceHash
+func showCachedGuide(g *guide.Guide, title, format, sourceHash string) error {
+	if g.Outdated(sourceHash) {
+		fmt.Println("⚠️  가이드가 현재 내용과 다를 수 있습니다. 재생성: --refresh (-r)")
+	}
+	if format == "html" {
+		return guide.OpenHTMLGuide(g.Type, g.ID, g.Content, g.CreatedAt)
+	}
+	guide.PrintGuide(title, g.Content, g.CreatedAt, true)
+	return nil
+}
+
+// runInteractiveGuide runs an interactive guide conversation, after estimating
+// its first reply
+func runInteractiveGuide(command, id, systemPrompt string) error {
+	run := newAIRun(command, id, firstReplyOutputTokens, systemPrompt)
+	if err := confirmAICost(run.estimate, aiFirstReplyNote, false); err != nil {
+		return err
+	}
+	if err := guide.RunInteractiveGuide(id, systemPrompt); err != nil {
+		return err
+	}
+	recordAIRun(run, "")
+	return nil
+}
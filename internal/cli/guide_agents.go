@@ -32,7 +32,8 @@ The guide explains:
 - How to use it effectively
 - Customization suggestions
 
-Guides are cached for future use. Use --refresh to regenerate.
+Guides are cached for future use and regenerated when the agent changes.
+Use --refresh to regenerate.
 Use -i for interactive mode where AI asks about your context.
 Use --format html to generate HTML and open in browser.`,
 	Example: `  # Get usage guide for an agent (uses cache if available)
@@ -80,7 +81,7 @@ func runGuideAgents(cmd *cobra.Command, args []string) error {
 	a, err := store.Get(agentID)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return fmt.Errorf("agent not found in %s: %s", ScopeDescription(scope), agentID)
+			return notFoundErrorf("agent not found in %s: %s", ScopeDescription(scope), agentID)
 		}
 		return fmt.Errorf("failed to get agent: %w", err)
 	}
@@ -99,7 +100,7 @@ func runGuideAgents(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return err
 		}
-		return guide.RunInteractiveGuide(agentID, systemPrompt)
+		return runInteractiveGuide("guide agents", agentID, systemPrompt)
 	}
 
 	guideStore, err := guide.NewStore()
@@ -107,16 +108,11 @@ func runGuideAgents(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to initialize guide store: %w", err)
 	}
 
-	// Use cache if available
-	if !guideAgentsRefresh && guideStore.Exists(guide.TypeAgent, agentID) {
-		cached, err := guideStore.Get(guide.TypeAgent, agentID)
-		if err == nil {
-			if guideAgentsFormat == "html" {
-				return guide.OpenHTMLGuide(guide.TypeAgent, agentID, cached.Content, cached.CreatedAt)
-			}
-			guide.PrintGuide(fmt.Sprintf("Agent Guide: %s", agentID), cached.Content, cached.CreatedAt, true)
-			return nil
-		}
+	// Use cache if available, unless the agent changed since the guide was generated
+	sourceHash := guide.HashSource(content)
+	cached, stale := cachedGuide(guideStore, guide.TypeAgent, agentID, sourceHash, guideAgentsRefresh)
+	if cached != nil {
+		return showCachedGuide(cached, fmt.Sprintf("Agent Guide: %s", agentID), guideAgentsFormat, sourceHash)
 	}
 
 	// Generate new guide
@@ -125,15 +121,24 @@ func runGuideAgents(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	userPrompt := fmt.Sprintf("'%s' 에이전트에 대한 사용법 가이드를 작성해주세요.", agentID)
+	userPrompt := fmt.Sprintf(guideUserPrompts[guide.TypeAgent], agentID)
+	run := newAIRun("guide agents", agentID, guideOutputTokens, systemPrompt, userPrompt)
+	if err := confirmAICost(run.estimate, "", false); err != nil {
+		return err
+	}
 
 	generatedContent, err := guide.RunClaudeWithSpinner(systemPrompt, userPrompt)
 	if err != nil {
+		if stale != nil {
+			fmt.Printf("⚠️  가이드 재생성 실패: %v\n", err)
+			return showCachedGuide(stale, fmt.Sprintf("Agent Guide: %s", agentID), guideAgentsFormat, sourceHash)
+		}
 		return fmt.Errorf("failed to generate guide: %w", err)
 	}
 
 	if generatedContent != "" {
-		savedGuide, err := guideStore.Save(guide.TypeAgent, agentID, generatedContent)
+		recordAIRun(run, generatedContent)
+		savedGuide, err := guideStore.Save(guide.TypeAgent, agentID, generatedContent, sourceHash)
 		if err != nil {
 			fmt.Printf("⚠️  가이드 저장 실패: %v\n", err)
 		}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/itda-skills/jindo/internal/command"
 	"github.com/itda-skills/jindo/internal/guide"
+	"github.com/itda-skills/jindo/internal/pkg/repo"
 	"github.com/itda-skills/jindo/internal/prompt"
 	"github.com/spf13/cobra"
 )
@@ -32,7 +33,8 @@ The guide explains:
 - Practical examples
 - Customization suggestions
 
-Guides are cached for future use. Use --refresh to regenerate.
+Guides are cached for future use and regenerated when the command changes.
+Use --refresh to regenerate.
 Use -i for interactive mode where AI asks about your context.
 Use --format html to generate HTML and open in browser.`,
 	Example: `  # Get usage guide for a command (uses cache if available)
@@ -80,7 +82,7 @@ func runGuideCommands(cmd *cobra.Command, args []string) error {
 	c, err := store.Get(commandName)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return fmt.Errorf("command not found in %s: %s", ScopeDescription(scope), commandName)
+			return notFoundErrorf("command not found in %s: %s", ScopeDescription(scope), commandName)
 		}
 		return fmt.Errorf("failed to get command: %w", err)
 	}
@@ -99,7 +101,7 @@ func runGuideCommands(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return err
 		}
-		return guide.RunInteractiveGuide(commandName, systemPrompt)
+		return runInteractiveGuide("guide commands", commandName, systemPrompt)
 	}
 
 	guideStore, err := guide.NewStore()
@@ -107,16 +109,11 @@ func runGuideCommands(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to initialize guide store: %w", err)
 	}
 
-	// Use cache if available
-	if !guideCommandsRefresh && guideStore.Exists(guide.TypeCommand, commandName) {
-		cached, err := guideStore.Get(guide.TypeCommand, commandName)
-		if err == nil {
-			if guideCommandsFormat == "html" {
-				return guide.OpenHTMLGuide(guide.TypeCommand, commandName, cached.Content, cached.CreatedAt)
-			}
-			guide.PrintGuide(fmt.Sprintf("Command Guide: %s", commandName), cached.Content, cached.CreatedAt, true)
-			return nil
-		}
+	// Use cache if available, unless the command changed since the guide was generated
+	sourceHash := guide.HashSource(content)
+	cached, stale := cachedGuide(guideStore, guide.TypeCommand, commandName, sourceHash, guideCommandsRefresh)
+	if cached != nil {
+		return showCachedGuide(cached, fmt.Sprintf("Command Guide: %s", commandName), guideCommandsFormat, sourceHash)
 	}
 
 	// Generate new guide
@@ -125,15 +122,24 @@ func runGuideCommands(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	userPrompt := fmt.Sprintf("'%s' 명령에 대한 사용법 가이드를 작성해주세요.", commandName)
+	userPrompt := fmt.Sprintf(guideUserPrompts[guide.TypeCommand], commandName)
+	run := newAIRun("guide commands", commandName, guideOutputTokens, systemPrompt, userPrompt)
+	if err := confirmAICost(run.estimate, "", false); err != nil {
+		return err
+	}
 
 	generatedContent, err := guide.RunClaudeWithSpinner(systemPrompt, userPrompt)
 	if err != nil {
+		if stale != nil {
+			fmt.Printf("⚠️  가이드 재생성 실패: %v\n", err)
+			return showCachedGuide(stale, fmt.Sprintf("Command Guide: %s", commandName), guideCommandsFormat, sourceHash)
+		}
 		return fmt.Errorf("failed to generate guide: %w", err)
 	}
 
 	if generatedContent != "" {
-		savedGuide, err := guideStore.Save(guide.TypeCommand, commandName, generatedContent)
+		recordAIRun(run, generatedContent)
+		savedGuide, err := guideStore.Save(guide.TypeCommand, commandName, generatedContent, sourceHash)
 		if err != nil {
 			fmt.Printf("⚠️  가이드 저장 실패: %v\n", err)
 		}
@@ -191,10 +197,16 @@ func commandNameCompletion(cmd *cobra.Command, args []string, toComplete string)
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
 
+	listed := make([]string, len(commands))
+	for i, c := range commands {
+		listed[i] = c.Name
+	}
+	short := loadShortNames(map[repo.PackageType][]string{repo.TypeCommand: listed})
+
 	var names []string
 	for _, c := range commands {
-		if c.Description != "" {
-			names = append(names, fmt.Sprintf("%s\t%s", c.Name, c.Description))
+		if description := short.describe(repo.TypeCommand, c.Name, c.Description); description != "" {
+			names = append(names, fmt.Sprintf("%s\t%s", c.Name, description))
 		} else {
 			names = append(names, c.Name)
 		}
@@ -33,7 +33,8 @@ The guide explains:
 - Practical examples
 - Customization suggestions
 
-Guides are cached for future use. Use --refresh to regenerate.
+Guides are cached for future use and regenerated when the hook changes.
+Use --refresh to regenerate.
 Use -i for interactive mode where AI asks about your context.
 Use --format html to generate HTML and open in browser.`,
 	Example: `  # Get usage guide for a hook (uses cache if available)
@@ -80,7 +81,7 @@ func runGuideHooks(cmd *cobra.Command, args []string) error {
 	h, err := store.Get(hookName)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return fmt.Errorf("hook not found in %s: %s", ScopeDescription(scope), hookName)
+			return notFoundErrorf("hook not found in %s: %s", ScopeDescription(scope), hookName)
 		}
 		return fmt.Errorf("failed to get hook: %w", err)
 	}
@@ -99,7 +100,7 @@ func runGuideHooks(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return err
 		}
-		return guide.RunInteractiveGuide(hookName, systemPrompt)
+		return runInteractiveGuide("guide hooks", hookName, systemPrompt)
 	}
 
 	guideStore, err := guide.NewStore()
@@ -107,16 +108,11 @@ func runGuideHooks(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to initialize guide store: %w", err)
 	}
 
-	// Use cache if available
-	if !guideHooksRefresh && guideStore.Exists(guide.TypeHook, hookName) {
-		cached, err := guideStore.Get(guide.TypeHook, hookName)
-		if err == nil {
-			if guideHooksFormat == "html" {
-				return guide.OpenHTMLGuide(guide.TypeHook, hookName, cached.Content, cached.CreatedAt)
-			}
-			guide.PrintGuide(fmt.Sprintf("Hook Guide: %s", hookName), cached.Content, cached.CreatedAt, true)
-			return nil
-		}
+	// Use cache if available, unless the hook changed since the guide was generated
+	sourceHash := guide.HashSource(string(content))
+	cached, stale := cachedGuide(guideStore, guide.TypeHook, hookName, sourceHash, guideHooksRefresh)
+	if cached != nil {
+		return showCachedGuide(cached, fmt.Sprintf("Hook Guide: %s", hookName), guideHooksFormat, sourceHash)
 	}
 
 	// Generate new guide
@@ -125,15 +121,24 @@ func runGuideHooks(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	userPrompt := fmt.Sprintf("'%s' 훅에 대한 사용법 가이드를 작성해주세요.", hookName)
+	userPrompt := fmt.Sprintf(guideUserPrompts[guide.TypeHook], hookName)
+	run := newAIRun("guide hooks", hookName, guideOutputTokens, systemPrompt, userPrompt)
+	if err := confirmAICost(run.estimate, "", false); err != nil {
+		return err
+	}
 
 	generatedContent, err := guide.RunClaudeWithSpinner(systemPrompt, userPrompt)
 	if err != nil {
+		if stale != nil {
+			fmt.Printf("⚠️  가이드 재생성 실패: %v\n", err)
+			return showCachedGuide(stale, fmt.Sprintf("Hook Guide: %s", hookName), guideHooksFormat, sourceHash)
+		}
 		return fmt.Errorf("failed to generate guide: %w", err)
 	}
 
 	if generatedContent != "" {
-		savedGuide, err := guideStore.Save(guide.TypeHook, hookName, generatedContent)
+		recordAIRun(run, generatedContent)
+		savedGuide, err := guideStore.Save(guide.TypeHook, hookName, generatedContent, sourceHash)
 		if err != nil {
 			fmt.Printf("⚠️  가이드 저장 실패: %v\n", err)
 		}
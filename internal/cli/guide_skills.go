@@ -32,7 +32,8 @@ The guide explains:
 - Practical examples
 - Customization suggestions
 
-Guides are cached for future use. Use --refresh to regenerate.
+Guides are cached for future use and regenerated when the skill changes.
+Use --refresh to regenerate.
 Use -i for interactive mode where AI asks about your context.
 Use --format html to generate HTML and open in browser.`,
 	Example: `  # Get usage guide for a skill (uses cache if available)
@@ -80,7 +81,7 @@ func runGuideSkills(cmd *cobra.Command, args []string) error {
 	s, err := store.Get(skillID)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return fmt.Errorf("skill not found in %s: %s", ScopeDescription(scope), skillID)
+			return notFoundErrorf("skill not found in %s: %s", ScopeDescription(scope), skillID)
 		}
 		return fmt.Errorf("failed to get skill: %w", err)
 	}
@@ -99,7 +100,7 @@ func runGuideSkills(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return err
 		}
-		return guide.RunInteractiveGuide(skillID, systemPrompt)
+		return runInteractiveGuide("guide skills", skillID, systemPrompt)
 	}
 
 	guideStore, err := guide.NewStore()
@@ -107,16 +108,11 @@ func runGuideSkills(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to initialize guide store: %w", err)
 	}
 
-	// Use cache if available
-	if !guideSkillsRefresh && guideStore.Exists(guide.TypeSkill, skillID) {
-		cached, err := guideStore.Get(guide.TypeSkill, skillID)
-		if err == nil {
-			if guideSkillsFormat == "html" {
-				return guide.OpenHTMLGuide(guide.TypeSkill, skillID, cached.Content, cached.CreatedAt)
-			}
-			guide.PrintGuide(fmt.Sprintf("Skill Guide: %s", skillID), cached.Content, cached.CreatedAt, true)
-			return nil
-		}
+	// Use cache if available, unless the skill changed since the guide was generated
+	sourceHash := guide.HashSource(content)
+	cached, stale := cachedGuide(guideStore, guide.TypeSkill, skillID, sourceHash, guideSkillsRefresh)
+	if cached != nil {
+		return showCachedGuide(cached, fmt.Sprintf("Skill Guide: %s", skillID), guideSkillsFormat, sourceHash)
 	}
 
 	// Generate new guide
@@ -125,15 +121,24 @@ func runGuideSkills(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	userPrompt := fmt.Sprintf("'%s' 스킬에 대한 사용법 가이드를 작성해주세요.", skillID)
+	userPrompt := fmt.Sprintf(guideUserPrompts[guide.TypeSkill], skillID)
+	run := newAIRun("guide skills", skillID, guideOutputTokens, systemPrompt, userPrompt)
+	if err := confirmAICost(run.estimate, "", false); err != nil {
+		return err
+	}
 
 	generatedContent, err := guide.RunClaudeWithSpinner(systemPrompt, userPrompt)
 	if err != nil {
+		if stale != nil {
+			fmt.Printf("⚠️  가이드 재생성 실패: %v\n", err)
+			return showCachedGuide(stale, fmt.Sprintf("Skill Guide: %s", skillID), guideSkillsFormat, sourceHash)
+		}
 		return fmt.Errorf("failed to generate guide: %w", err)
 	}
 
 	if generatedContent != "" {
-		savedGuide, err := guideStore.Save(guide.TypeSkill, skillID, generatedContent)
+		recordAIRun(run, generatedContent)
+		savedGuide, err := guideStore.Save(guide.TypeSkill, skillID, generatedContent, sourceHash)
 		if err != nil {
 			fmt.Printf("⚠️  가이드 저장 실패: %v\n", err)
 		}
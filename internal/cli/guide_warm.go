@@ -0,0 +1,322 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/itda-skills/jindo/internal/agent"
+	"github.com/itda-skills/jindo/internal/aicost"
+	"github.com/itda-skills/jindo/internal/command"
+	"github.com/itda-skills/jindo/internal/guide"
+	"github.com/itda-skills/jindo/internal/hook"
+	"github.com/itda-skills/jindo/internal/progress"
+	"github.com/itda-skills/jindo/internal/skill"
+	"github.com/spf13/cobra"
+)
+
+var (
+	guideWarmType    string
+	guideWarmJobs    int
+	guideWarmRefresh bool
+	guideWarmDryRun  bool
+	guideWarmGlobal  bool
+	guideWarmLocal   bool
+	guideWarmYes     bool
+)
+
+// guideWarmTypes are the values of --type, with the guide types each selects
+var guideWarmTypes = map[string][]guide.GuideType{
+	"skills":   {guide.TypeSkill},
+	"commands": {guide.TypeCommand},
+	"agents":   {guide.TypeAgent},
+	"hooks":    {guide.TypeHook},
+	"all":      {guide.TypeSkill, guide.TypeCommand, guide.TypeAgent, guide.TypeHook},
+}
+
+var guideWarmCmd = &cobra.Command{
+	Use:   "warm",
+	Short: "Pre-generate the guides of all installed resources",
+	Long: `Generate and cache the guides of every skill, command, agent, and hook in the
+scope, so 'jd guide <type> <name>' shows them right away. Run it once, e.g.
+overnight, instead of waiting for each guide the first time it is asked for.
+
+Resources whose cached guide was generated from their current content are
+skipped; --refresh regenerates those too. Guides are generated with Claude,
+--jobs at a time, with a progress bar (or a line at every quarter when not on a
+terminal). Failures are listed at the end and do not stop the others. The
+estimated Claude usage of all the guides is printed first; see 'jd stats ai'
+for the budget settings.
+
+Default scope is local if a .claude directory exists, otherwise global.
+Use --global or --local to override.`,
+	Example: `  # Guides for every resource
+  jd guide warm
+
+  # Only skills, 8 at a time
+  jd guide warm --type skills --jobs 8
+
+  # List what would be generated
+  jd guide warm --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: runGuideWarm,
+}
+
+func init() {
+	guideCmd.AddCommand(guideWarmCmd)
+	guideWarmCmd.Flags().StringVarP(&guideWarmType, "type", "t", "all", "Resources to generate guides for: skills, commands, agents, hooks, all")
+	guideWarmCmd.Flags().IntVarP(&guideWarmJobs, "jobs", "j", 4, "Number of guides generated at a time")
+	guideWarmCmd.Flags().BoolVarP(&guideWarmRefresh, "refresh", "r", false, "Regenerate guides that are already up to date")
+	guideWarmCmd.Flags().BoolVar(&guideWarmDryRun, "dry-run", false, "List the guides that would be generated without generating them")
+	guideWarmCmd.Flags().BoolVarP(&guideWarmGlobal, "global", "g", false, "Guide resources in global ~/.claude")
+	guideWarmCmd.Flags().BoolVarP(&guideWarmLocal, "local", "l", false, "Guide resources in local .claude")
+	guideWarmCmd.Flags().BoolVarP(&guideWarmYes, "yes", "y", false, "Generate even if the estimated cost is over budget")
+	_ = guideWarmCmd.RegisterFlagCompletionFunc("type", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return []string{"skills", "commands", "agents", "hooks", "all"}, cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+// guideJob is a guide to generate for a resource
+type guideJob struct {
+	guideType    guide.GuideType
+	id           string
+	sourceHash   string
+	systemPrompt string
+	run          *aiRun
+	err          error
+}
+
+func runGuideWarm(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+
+	types, ok := guideWarmTypes[guideWarmType]
+	if !ok {
+		return validationErrorf("invalid type: %s (use: skills, commands, agents, hooks, all)", guideWarmType)
+	}
+	if guideWarmJobs < 1 {
+		return validationErrorf("--jobs must be at least 1")
+	}
+
+	scope, err := ResolveScope(guideWarmGlobal, guideWarmLocal)
+	if err != nil {
+		return err
+	}
+
+	guideStore, err := guide.NewStore()
+	if err != nil {
+		return fmt.Errorf("failed to initialize guide store: %w", err)
+	}
+
+	var jobs []*guideJob
+	upToDate := 0
+	for _, t := range types {
+		found, err := guideJobs(t, scope)
+		if err != nil {
+			return err
+		}
+		for _, job := range found {
+			if !guideWarmRefresh && guideUpToDate(guideStore, job) {
+				upToDate++
+				continue
+			}
+			jobs = append(jobs, job)
+		}
+	}
+
+	if len(jobs) == 0 {
+		fmt.Printf("All %d guide(s) in %s are up to date.\n", upToDate, ScopeDescription(scope))
+		return nil
+	}
+
+	var estimate aicost.Estimate
+	for _, job := range jobs {
+		name := fmt.Sprintf("%s/%s", job.guideType, job.id)
+		job.run = newAIRun("guide warm", name, guideOutputTokens, job.systemPrompt, fmt.Sprintf(guideUserPrompts[job.guideType], job.id))
+		estimate = estimate.Add(job.run.estimate)
+	}
+
+	if guideWarmDryRun {
+		fmt.Printf("Would generate %d guide(s) (%d up to date):\n", len(jobs), upToDate)
+		for _, job := range jobs {
+			fmt.Printf("  %s/%s\n", job.guideType, job.id)
+		}
+		// Only print the estimate
+		return confirmAICost(estimate, "", true)
+	}
+
+	if _, err := exec.LookPath("claude"); err != nil {
+		return fmt.Errorf("claude CLI not found. Install: npm install -g @anthropic-ai/claude-cli")
+	}
+
+	if err := confirmAICost(estimate, "", guideWarmYes); err != nil {
+		return err
+	}
+
+	fmt.Printf("Generating %d guide(s) in %s, %d at a time (%d up to date)\n", len(jobs), ScopeDescription(scope), guideWarmJobs, upToDate)
+
+	reporter := progress.New(os.Stderr)
+	reporter.Start("Generating guides", int64(len(jobs)), progress.Items)
+	var mu sync.Mutex
+	done := 0
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, guideWarmJobs)
+	for _, job := range jobs {
+		wg.Add(1)
+		go func(job *guideJob) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			content, err := generateGuide(cmd, guideStore, job)
+			job.err = err
+
+			mu.Lock()
+			if err == nil {
+				recordAIRun(job.run, content)
+			}
+			done++
+			reporter.Update(int64(done))
+			mu.Unlock()
+		}(job)
+	}
+	wg.Wait()
+	reporter.Finish()
+
+	var failed []*guideJob
+	for _, job := range jobs {
+		if job.err != nil {
+			failed = append(failed, job)
+		}
+	}
+
+	fmt.Printf("✅ Generated %d guide(s)", len(jobs)-len(failed))
+	if upToDate > 0 {
+		fmt.Printf(", %d already up to date", upToDate)
+	}
+	fmt.Println()
+	if len(failed) == 0 {
+		return nil
+	}
+
+	fmt.Printf("\n✗ Failed (%d):\n", len(failed))
+	for _, job := range failed {
+		fmt.Printf("  %s/%s: %v\n", job.guideType, job.id, job.err)
+	}
+	return fmt.Errorf("failed to generate %d of %d guide(s)", len(failed), len(jobs))
+}
+
+// guideJobs returns a guide job for each resource of a type in the scope, with
+// the same content hash and prompt as 'jd guide <type> <name>' uses
+func guideJobs(t guide.GuideType, scope PathScope) ([]*guideJob, error) {
+	var jobs []*guideJob
+	add := func(id, content string, build func() (string, error)) error {
+		systemPrompt, err := build()
+		if err != nil {
+			return err
+		}
+		jobs = append(jobs, &guideJob{guideType: t, id: id, sourceHash: guide.HashSource(content), systemPrompt: systemPrompt})
+		return nil
+	}
+
+	switch t {
+	case guide.TypeSkill:
+		store := skill.NewStore(GetPathByScope(scope, "skills"))
+		skills, err := store.List()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list skills: %w", err)
+		}
+		for _, s := range skills {
+			id := filepath.Base(filepath.Dir(s.Path))
+			content, err := store.GetContent(id)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read skill content: %w", err)
+			}
+			if err := add(id, content, func() (string, error) { return buildSkillSystemPrompt(id, s.Path, content) }); err != nil {
+				return nil, err
+			}
+		}
+	case guide.TypeCommand:
+		store := command.NewStore(GetPathByScope(scope, "commands"))
+		commands, err := store.List()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list commands: %w", err)
+		}
+		for _, c := range commands {
+			content, err := store.GetContent(c.Name)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read command content: %w", err)
+			}
+			if err := add(c.Name, content, func() (string, error) { return buildCommandSystemPrompt(c.Name, c.Path, content) }); err != nil {
+				return nil, err
+			}
+		}
+	case guide.TypeAgent:
+		store := agent.NewStore(GetPathByScope(scope, "agents"))
+		agents, err := store.List()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list agents: %w", err)
+		}
+		for _, a := range agents {
+			id := strings.TrimSuffix(filepath.Base(a.Path), ".md")
+			content, err := store.GetContent(id)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read agent content: %w", err)
+			}
+			if err := add(id, content, func() (string, error) { return buildAgentSystemPrompt(id, a.Path, content) }); err != nil {
+				return nil, err
+			}
+		}
+	case guide.TypeHook:
+		settingsPath := GetSettingsPathByScope(scope)
+		hooks, err := hook.NewStore(settingsPath).List()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list hooks: %w", err)
+		}
+		for _, h := range hooks {
+			content, err := json.MarshalIndent(h, "", "  ")
+			if err != nil {
+				return nil, fmt.Errorf("failed to serialize hook: %w", err)
+			}
+			if err := add(h.Name, string(content), func() (string, error) {
+				return buildHookSystemPrompt(h.Name, settingsPath, string(h.EventType), string(content))
+			}); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return jobs, nil
+}
+
+// guideUpToDate reports whether a resource's guide is cached and was generated
+// from its current content; cachedGuide would show it without regenerating
+func guideUpToDate(store *guide.Store, job *guideJob) bool {
+	if !store.Exists(job.guideType, job.id) {
+		return false
+	}
+	g, err := store.Get(job.guideType, job.id)
+	if err != nil {
+		return false
+	}
+	return g.SourceHash == "" || g.SourceHash == job.sourceHash
+}
+
+// generateGuide generates a guide with Claude and caches it. Returns the guide.
+func generateGuide(cmd *cobra.Command, store *guide.Store, job *guideJob) (string, error) {
+	content, err := guide.RunClaude(cmd.Context(), job.systemPrompt, fmt.Sprintf(guideUserPrompts[job.guideType], job.id))
+	if err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(content) == "" {
+		return "", errors.New("claude returned an empty guide")
+	}
+	if _, err := store.Save(job.guideType, job.id, content, job.sourceHash); err != nil {
+		return "", fmt.Errorf("save guide: %w", err)
+	}
+	return content, nil
+}
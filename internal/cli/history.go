@@ -0,0 +1,23 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Manage version history of skills, agents, and hooks",
+	Long: `Manage the version history saved for skills, agents, and hooks.
+
+History versions are written by adapt, revert, and 'jd edit'.
+Set history.auto to also snapshot the prior content whenever jd overwrites a
+resource (skills/agents/hooks edit, pkg install, pkg update):
+  jd config set history.auto true
+
+Use 'jd history prune' to limit how many versions are kept.
+Per-resource history is under 'jd skills history', 'jd agents history', and 'jd hooks history'.`,
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+}
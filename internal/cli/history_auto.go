@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/itda-skills/jindo/internal/agent"
+	"github.com/itda-skills/jindo/internal/hook"
+	"github.com/itda-skills/jindo/internal/pkg/pkgmgr"
+	"github.com/itda-skills/jindo/internal/skill"
+	"github.com/itda-skills/jindo/pkg/config"
+)
+
+// historyAutoKey enables snapshotting prior content before jd overwrites a skill,
+// agent, or hook (edit, pkg install/update)
+const historyAutoKey = "history.auto"
+
+// historyAutoEnabled reports whether history.auto is set in config
+func historyAutoEnabled() bool {
+	cfg, err := config.Load()
+	if err != nil {
+		return false
+	}
+	return cfg.GetBool(historyAutoKey, false)
+}
+
+// autoSnapshotSkill saves the current SKILL.md content as a history version
+// when history.auto is enabled
+func autoSnapshotSkill(skillPath string) {
+	if !historyAutoEnabled() {
+		return
+	}
+	snapshotSkillFile(skillPath)
+}
+
+// autoSnapshotAgent saves the current agent file content as a history version
+// when history.auto is enabled
+func autoSnapshotAgent(agentPath string) {
+	if !historyAutoEnabled() {
+		return
+	}
+	snapshotAgentFile(agentPath)
+}
+
+// autoSnapshotHook saves the current hook configuration as a history version
+// when history.auto is enabled
+func autoSnapshotHook(settingsPath string, h *hook.Hook) {
+	if !historyAutoEnabled() {
+		return
+	}
+
+	claudeDir, err := expandScopeDir(filepath.Dir(settingsPath))
+	if err != nil {
+		return
+	}
+	if _, err := saveHookVersionIfChanged(hook.NewHistoryManager(claudeDir, h.Name), h); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to snapshot hook %s: %v\n", h.Name, err)
+	}
+}
+
+// enableAutoSnapshot makes the package manager snapshot installed skills and
+// agents before replacing them when history.auto is enabled
+func enableAutoSnapshot(manager *pkgmgr.Manager) {
+	if !historyAutoEnabled() {
+		return
+	}
+	manager.SetBeforeOverwrite(func(path string) {
+		switch {
+		case strings.EqualFold(filepath.Base(path), "SKILL.md"):
+			snapshotSkillFile(path)
+		case filepath.Base(filepath.Dir(path)) == "agents" && filepath.Ext(path) == ".md":
+			snapshotAgentFile(path)
+		}
+	})
+}
+
+// snapshotSkillFile saves the content of a SKILL.md file to its skill's history
+func snapshotSkillFile(skillPath string) {
+	content, err := os.ReadFile(skillPath)
+	if err != nil {
+		return
+	}
+	historyMgr := skill.NewHistoryManager(filepath.Dir(skillPath))
+	if _, err := saveSkillVersionIfChanged(historyMgr, string(content)); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to snapshot %s: %v\n", skillPath, err)
+	}
+}
+
+// snapshotAgentFile saves the content of an agent file to its history
+func snapshotAgentFile(agentPath string) {
+	content, err := os.ReadFile(agentPath)
+	if err != nil {
+		return
+	}
+	agentsDir, err := expandScopeDir(filepath.Dir(agentPath))
+	if err != nil {
+		return
+	}
+	agentID := strings.TrimSuffix(filepath.Base(agentPath), ".md")
+	historyMgr := agent.NewHistoryManager(agentsDir, agentID)
+	if _, err := saveAgentVersionIfChanged(historyMgr, string(content)); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to snapshot %s: %v\n", agentPath, err)
+	}
+}
+
+// saveSkillVersionIfChanged saves content as a new version unless it matches the latest one
+func saveSkillVersionIfChanged(historyMgr *skill.HistoryManager, content string) (*skill.Version, error) {
+	if latest, err := historyMgr.GetLatestVersion(); err == nil {
+		if latestContent, _, err := historyMgr.GetVersion(latest.Number); err == nil && latestContent == content {
+			return latest, nil
+		}
+	}
+	return historyMgr.SaveVersion(content)
+}
+
+// saveAgentVersionIfChanged saves content as a new version unless it matches the latest one
+func saveAgentVersionIfChanged(historyMgr *agent.HistoryManager, content string) (*agent.Version, error) {
+	if latest, err := historyMgr.GetLatestVersion(); err == nil {
+		if latestContent, _, err := historyMgr.GetVersion(latest.Number); err == nil && latestContent == content {
+			return latest, nil
+		}
+	}
+	return historyMgr.SaveVersion(content)
+}
+
+// saveHookVersionIfChanged saves the hook as a new version unless it matches the latest one
+func saveHookVersionIfChanged(historyMgr *hook.HistoryManager, h *hook.Hook) (*hook.Version, error) {
+	if latest, err := historyMgr.GetLatestVersion(); err == nil {
+		if snapshot, _, err := historyMgr.GetVersion(latest.Number); err == nil &&
+			snapshot.Matcher == h.Matcher && equalStringSlices(snapshot.Commands, h.Commands) {
+			return latest, nil
+		}
+	}
+	return historyMgr.SaveVersion(h)
+}
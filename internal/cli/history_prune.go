@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/itda-skills/jindo/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	historyPruneKeep   int
+	historyPruneDryRun bool
+	historyPruneForce  bool
+)
+
+var historyPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove old history versions",
+	Long: `Remove history versions beyond the newest N of each skill, agent, and hook.
+
+History is pruned in the global scope and, if present, the local scope.
+The number of versions kept defaults to config ` + gcHistoryKeepKey + ` (10 if unset).`,
+	Example: `  # Show what would be removed
+  jd history prune --dry-run
+
+  # Keep the last 3 versions of each resource
+  jd history prune --keep 3`,
+	Args: cobra.NoArgs,
+	RunE: runHistoryPrune,
+}
+
+func init() {
+	historyCmd.AddCommand(historyPruneCmd)
+	historyPruneCmd.Flags().IntVarP(&historyPruneKeep, "keep", "k", 0, "Versions to keep per resource (default: config "+gcHistoryKeepKey+")")
+	historyPruneCmd.Flags().BoolVarP(&historyPruneDryRun, "dry-run", "n", false, "Report versions to remove without deleting")
+	historyPruneCmd.Flags().BoolVarP(&historyPruneForce, "force", "f", false, "Skip confirmation prompt")
+}
+
+func runHistoryPrune(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+
+	keep := historyPruneKeep
+	if !cmd.Flags().Changed("keep") {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		keep = cfg.GetInt(gcHistoryKeepKey, defaultGCHistoryKeep)
+	}
+	if keep < 1 {
+		return errors.New("--keep must be at least 1")
+	}
+
+	return runGCCategories([]*gcCategory{collectGCHistory(keep)}, historyPruneDryRun, historyPruneForce)
+}
@@ -31,13 +31,21 @@ This command:
 3. Provides guidance on modifying the hook
 4. Helps you update the hook configuration
 
-Default scope is local if a .claude directory exists in the current working directory, otherwise global.
-Use --global or --local to override.`,
+Default scope is local if a .claude directory exists in the current or a parent directory (the nearest one is used), otherwise global.
+Use --global or --local to override.
+
+Claude may edit, read, and write files and run Bash without asking.
+--allowed-tools, --add-dir, and --permission-mode are passed to claude to
+change that; adapt.allowed_tools, adapt.add_dirs, and adapt.permission_mode in
+the config set their defaults.`,
 	Example: `  # Adapt a global hook
   jd hooks adapt PreToolUse-Bash-0
 
   # Adapt a local hook
-  jd hooks adapt PreToolUse-Bash-0 --local`,
+  jd hooks adapt PreToolUse-Bash-0 --local
+
+  # Approve every command Claude runs
+  jd hooks adapt PreToolUse-Bash-0 --allowed-tools Edit,Read,Write`,
 	Args:              cobra.ExactArgs(1),
 	RunE:              runHooksAdapt,
 	ValidArgsFunction: hookNameCompletion,
@@ -47,6 +55,7 @@ func init() {
 	hooksCmd.AddCommand(hooksAdaptCmd)
 	hooksAdaptCmd.Flags().BoolVarP(&hooksAdaptGlobal, "global", "g", false, "Adapt from global ~/.claude/settings.json")
 	hooksAdaptCmd.Flags().BoolVarP(&hooksAdaptLocal, "local", "l", false, "Adapt from local .claude/settings.json")
+	addAdaptSandboxFlags(hooksAdaptCmd)
 }
 
 func runHooksAdapt(cmd *cobra.Command, args []string) error {
@@ -58,6 +67,10 @@ func runHooksAdapt(cmd *cobra.Command, args []string) error {
 	}
 
 	hookName := args[0]
+	sandbox, err := adaptSandboxArgs(cmd, adaptHookDefaultTools)
+	if err != nil {
+		return err
+	}
 
 	settingsPath := GetSettingsPathByScope(scope)
 	store := hook.NewStore(settingsPath)
@@ -66,7 +79,7 @@ func runHooksAdapt(cmd *cobra.Command, args []string) error {
 	h, err := store.Get(hookName)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return fmt.Errorf("hook not found in %s: %s", ScopeDescription(scope), hookName)
+			return notFoundErrorf("hook not found in %s: %s", ScopeDescription(scope), hookName)
 		}
 		return fmt.Errorf("failed to get hook: %w", err)
 	}
@@ -136,14 +149,17 @@ func runHooksAdapt(cmd *cobra.Command, args []string) error {
 
 	// Initial prompt to make Claude start the conversation (passed as positional argument for interactive mode)
 	initialPrompt := fmt.Sprintf("I want to customize the '%s' hook. Please start by asking me about my specific needs and how I'd like to adapt this hook to my workflow.", hookName)
+	run := newAIRun("hooks adapt", hookName, firstReplyOutputTokens, systemPrompt.String(), initialPrompt)
+	if err := confirmAICost(run.estimate, aiFirstReplyNote, false); err != nil {
+		// Nothing was adapted: drop the backup
+		_ = historyMgr.DeleteVersion(version.Number)
+		return err
+	}
 
 	// Run claude command with the system prompt and initial message
 	// Note: positional argument (not -p) keeps interactive mode
-	claudeCmd := exec.Command("claude",
-		"--system-prompt", systemPrompt.String(),
-		"--allowedTools", "Edit,Read,Write,Bash",
-		initialPrompt,
-	)
+	claudeArgs := append([]string{"--system-prompt", systemPrompt.String()}, sandbox...)
+	claudeCmd := exec.Command("claude", append(claudeArgs, initialPrompt)...)
 	claudeCmd.Stdin = os.Stdin
 	claudeCmd.Stdout = os.Stdout
 	claudeCmd.Stderr = os.Stderr
@@ -152,12 +168,14 @@ func runHooksAdapt(cmd *cobra.Command, args []string) error {
 		// Check if it's just a user exit
 		if exitErr, ok := err.(*exec.ExitError); ok {
 			if exitErr.ExitCode() == 130 { // Ctrl+C
+				recordAIRun(run, "")
 				fmt.Println("\n⚠️  Adaptation cancelled")
 				return nil
 			}
 		}
 		return fmt.Errorf("claude command failed: %w", err)
 	}
+	recordAIRun(run, "")
 
 	// Read the potentially updated hook
 	newHook, err := store.Get(hookName)
@@ -0,0 +1,318 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/itda-skills/jindo/internal/hook"
+	"github.com/spf13/cobra"
+)
+
+var (
+	hooksCoverageJSON   bool
+	hooksCoverageGlobal bool
+	hooksCoverageLocal  bool
+)
+
+var hooksCoverageCmd = &cobra.Command{
+	Use:     "coverage",
+	Aliases: []string{"cov"},
+	Short:   "Show which events and tools are covered by hooks",
+	Long: `Show a matrix of tool events (PreToolUse, PostToolUse) × tools with the
+number of hook rules that fire for each combination, per scope, followed by
+the other events (Notification, Stop, SubagentStop).
+
+Gaps list the tools a tool event has no hook for, when the event has hooks for
+other tools (e.g., a PostToolUse hook for Edit but none for Write). Overlaps
+list rules that fire together for the same event and tool, across scopes,
+since Claude Code runs the hooks of both settings.json files.
+
+Matchers are matched as Claude Code does: "*" or an empty matcher selects every
+tool, otherwise the matcher is a regular expression for the whole tool name.
+Disabled hooks are not counted.
+
+By default both the global and, if present, the local settings.json are shown.
+
+Examples:
+  jd hooks coverage
+  jd hooks coverage --local
+  jd hooks coverage --json`,
+	RunE: runHooksCoverage,
+}
+
+func init() {
+	hooksCmd.AddCommand(hooksCoverageCmd)
+	hooksCoverageCmd.Flags().BoolVar(&hooksCoverageJSON, "json", false, "Output in JSON format")
+	hooksCoverageCmd.Flags().BoolVarP(&hooksCoverageGlobal, "global", "g", false, "Show only global ~/.claude/settings.json")
+	hooksCoverageCmd.Flags().BoolVarP(&hooksCoverageLocal, "local", "l", false, "Show only local .claude/settings.json")
+	hooksCoverageCmd.MarkFlagsMutuallyExclusive("global", "local")
+}
+
+// hooksCoverageScope is the coverage of the hooks in one settings.json
+type hooksCoverageScope struct {
+	Scope string `json:"scope"`
+	Path  string `json:"path"`
+	// Tools maps tool event -> tool -> names of the hooks that fire
+	Tools map[hook.EventType]map[string][]string `json:"tools"`
+	// Events maps the other events to the names of their hooks
+	Events map[hook.EventType][]string `json:"events"`
+	// Gaps maps tool events with hooks to the tools they have no hook for
+	Gaps map[hook.EventType][]string `json:"gaps,omitempty"`
+
+	hooks int
+}
+
+// hooksCoverageOverlap is a set of rules that fire together for an event
+type hooksCoverageOverlap struct {
+	Event hook.EventType `json:"event"`
+	Tools []string       `json:"tools,omitempty"`
+	Hooks []string       `json:"hooks"` // "<name> (<scope>)"
+}
+
+type hooksCoverageOutput struct {
+	ToolList []string               `json:"tool_list"`
+	Scopes   []*hooksCoverageScope  `json:"scopes"`
+	Overlaps []hooksCoverageOverlap `json:"overlaps,omitempty"`
+}
+
+func runHooksCoverage(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+
+	type scopeHooks struct {
+		scope PathScope
+		path  string
+		hooks []*hook.Hook
+	}
+
+	var scopes []PathScope
+	switch {
+	case hooksCoverageGlobal:
+		scopes = []PathScope{ScopeGlobal}
+	case hooksCoverageLocal:
+		scopes = []PathScope{ScopeLocal}
+	default:
+		scopes = []PathScope{ScopeGlobal}
+		if GetLocalSettingsPath() != "" {
+			scopes = append(scopes, ScopeLocal)
+		}
+	}
+
+	var loaded []scopeHooks
+	for _, scope := range scopes {
+		path := GetSettingsPathByScope(scope)
+		hooks, err := hook.NewStore(path).List()
+		if err != nil {
+			return fmt.Errorf("failed to list hooks in %s: %w", ScopeDescription(scope), err)
+		}
+		loaded = append(loaded, scopeHooks{scope: scope, path: path, hooks: hooks})
+	}
+
+	// Known tools, plus any tool a matcher names
+	toolSet := make(map[string]bool)
+	for tool := range validTools {
+		toolSet[tool] = true
+	}
+	for _, l := range loaded {
+		for _, h := range l.hooks {
+			if hook.IsToolEvent(h.EventType) {
+				for _, tool := range hook.MatcherTools(h.Matcher) {
+					toolSet[tool] = true
+				}
+			}
+		}
+	}
+	tools := make([]string, 0, len(toolSet))
+	for tool := range toolSet {
+		tools = append(tools, tool)
+	}
+	sort.Strings(tools)
+
+	output := hooksCoverageOutput{ToolList: tools}
+	for _, l := range loaded {
+		output.Scopes = append(output.Scopes, buildHooksCoverage(string(l.scope), l.path, l.hooks, tools))
+	}
+	output.Overlaps = findHookOverlaps(output.Scopes, tools)
+
+	if hooksCoverageJSON {
+		data, err := json.MarshalIndent(output, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	for i, c := range output.Scopes {
+		if i > 0 {
+			fmt.Println()
+		}
+		printHooksCoverage(c, tools)
+	}
+
+	if len(output.Overlaps) > 0 {
+		fmt.Println()
+		fmt.Println("Overlaps (rules that fire together):")
+		for _, o := range output.Overlaps {
+			target := string(o.Event)
+			if len(o.Tools) > 0 {
+				target += " × " + strings.Join(o.Tools, ", ")
+			}
+			fmt.Printf("  %s\n    %s\n", target, strings.Join(o.Hooks, ", "))
+		}
+	}
+	return nil
+}
+
+// buildHooksCoverage computes the coverage of the hooks of one scope
+func buildHooksCoverage(scope, path string, hooks []*hook.Hook, tools []string) *hooksCoverageScope {
+	c := &hooksCoverageScope{
+		Scope:  scope,
+		Path:   path,
+		Tools:  make(map[hook.EventType]map[string][]string),
+		Events: make(map[hook.EventType][]string),
+		hooks:  len(hooks),
+	}
+	for _, et := range hook.AllEventTypes() {
+		if hook.IsToolEvent(et) {
+			c.Tools[et] = make(map[string][]string)
+		} else {
+			c.Events[et] = []string{}
+		}
+	}
+
+	sorted := append([]*hook.Hook(nil), hooks...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	for _, h := range sorted {
+		if !hook.IsToolEvent(h.EventType) {
+			c.Events[h.EventType] = append(c.Events[h.EventType], h.Name)
+			continue
+		}
+		for _, tool := range tools {
+			if hook.MatchesTool(h.Matcher, tool) {
+				c.Tools[h.EventType][tool] = append(c.Tools[h.EventType][tool], h.Name)
+			}
+		}
+	}
+
+	for et, byTool := range c.Tools {
+		if len(byTool) == 0 {
+			continue
+		}
+		for _, tool := range tools {
+			if len(byTool[tool]) == 0 {
+				if c.Gaps == nil {
+					c.Gaps = make(map[hook.EventType][]string)
+				}
+				c.Gaps[et] = append(c.Gaps[et], tool)
+			}
+		}
+	}
+	return c
+}
+
+// findHookOverlaps returns the rules that fire together for the same event (and tool)
+// across scopes. Tools with the same set of rules are grouped.
+func findHookOverlaps(scopes []*hooksCoverageScope, tools []string) []hooksCoverageOverlap {
+	var overlaps []hooksCoverageOverlap
+	for _, et := range hook.AllEventTypes() {
+		if !hook.IsToolEvent(et) {
+			var names []string
+			for _, c := range scopes {
+				for _, name := range c.Events[et] {
+					names = append(names, fmt.Sprintf("%s (%s)", name, c.Scope))
+				}
+			}
+			if len(names) > 1 {
+				overlaps = append(overlaps, hooksCoverageOverlap{Event: et, Hooks: names})
+			}
+			continue
+		}
+
+		index := make(map[string]int) // joined hook names -> position in overlaps
+		for _, tool := range tools {
+			var names []string
+			for _, c := range scopes {
+				for _, name := range c.Tools[et][tool] {
+					names = append(names, fmt.Sprintf("%s (%s)", name, c.Scope))
+				}
+			}
+			if len(names) < 2 {
+				continue
+			}
+			key := strings.Join(names, "\x00")
+			if i, ok := index[key]; ok {
+				overlaps[i].Tools = append(overlaps[i].Tools, tool)
+				continue
+			}
+			index[key] = len(overlaps)
+			overlaps = append(overlaps, hooksCoverageOverlap{Event: et, Tools: []string{tool}, Hooks: names})
+		}
+	}
+	return overlaps
+}
+
+// printHooksCoverage prints the coverage matrix of one scope
+func printHooksCoverage(c *hooksCoverageScope, tools []string) {
+	fmt.Printf("=== %s (%s) ===\n", strings.ToUpper(c.Scope[:1])+c.Scope[1:], c.Path)
+	if c.hooks == 0 {
+		fmt.Println("No hooks found.")
+		return
+	}
+
+	var toolEvents, otherEvents []hook.EventType
+	for _, et := range hook.AllEventTypes() {
+		if hook.IsToolEvent(et) {
+			toolEvents = append(toolEvents, et)
+		} else {
+			otherEvents = append(otherEvents, et)
+		}
+	}
+
+	toolWidth := len("TOOL")
+	for _, tool := range tools {
+		if len(tool) > toolWidth {
+			toolWidth = len(tool)
+		}
+	}
+
+	cell := func(n int) string {
+		if n == 0 {
+			return "-"
+		}
+		return fmt.Sprintf("%d", n)
+	}
+
+	fmt.Printf("%-*s", toolWidth, "TOOL")
+	for _, et := range toolEvents {
+		fmt.Printf("  %-*s", len(et), et)
+	}
+	fmt.Println()
+	fmt.Print(strings.Repeat("-", toolWidth))
+	for _, et := range toolEvents {
+		fmt.Print("  " + strings.Repeat("-", len(et)))
+	}
+	fmt.Println()
+	for _, tool := range tools {
+		fmt.Printf("%-*s", toolWidth, tool)
+		for _, et := range toolEvents {
+			fmt.Printf("  %-*s", len(et), cell(len(c.Tools[et][tool])))
+		}
+		fmt.Println()
+	}
+
+	fmt.Println()
+	var others []string
+	for _, et := range otherEvents {
+		others = append(others, fmt.Sprintf("%s %s", et, cell(len(c.Events[et]))))
+	}
+	fmt.Printf("Other events: %s\n", strings.Join(others, ", "))
+
+	for _, et := range toolEvents {
+		if gaps := c.Gaps[et]; len(gaps) > 0 {
+			fmt.Printf("Gaps: no %s hook for %s\n", et, strings.Join(gaps, ", "))
+		}
+	}
+}
@@ -6,12 +6,14 @@ import (
 	"os"
 	"strings"
 
+	"github.com/itda-skills/jindo/internal/basedir"
 	"github.com/itda-skills/jindo/internal/hook"
 	"github.com/spf13/cobra"
 )
 
 var (
 	hooksDeleteForce  bool
+	hooksDeleteYes    bool
 	hooksDeleteGlobal bool
 	hooksDeleteLocal  bool
 )
@@ -22,12 +24,16 @@ var hooksDeleteCmd = &cobra.Command{
 	Short:   "Delete a hook",
 	Long: `Delete a hook from ~/.claude/settings.json (global) or .claude/settings.json (local).
 
-Default scope is local if a .claude directory exists in the current working directory, otherwise global.
+A hook registered by a package is not deleted; uninstall the package with
+'jd pkg uninstall' instead. Use --yes to skip the confirmation in scripts, or
+--force to skip it and delete a package's hook anyway.
+
+Default scope is local if a .claude directory exists in the current or a parent directory (the nearest one is used), otherwise global.
 Use --global or --local to override.
 
 Examples:
   jd hooks delete PreToolUse-Bash-0
-  jd hooks delete PreToolUse-Bash-0 -f
+  jd hooks delete PreToolUse-Bash-0 --yes
   jd hooks delete --local PreToolUse-Bash-0`,
 	Args:              cobra.ExactArgs(1),
 	RunE:              runHooksDelete,
@@ -36,7 +42,8 @@ Examples:
 
 func init() {
 	hooksCmd.AddCommand(hooksDeleteCmd)
-	hooksDeleteCmd.Flags().BoolVarP(&hooksDeleteForce, "force", "f", false, "Skip confirmation")
+	hooksDeleteCmd.Flags().BoolVarP(&hooksDeleteForce, "force", "f", false, "Skip confirmation and delete even if registered by a package")
+	hooksDeleteCmd.Flags().BoolVarP(&hooksDeleteYes, "yes", "y", false, "Skip confirmation")
 	hooksDeleteCmd.Flags().BoolVarP(&hooksDeleteGlobal, "global", "g", false, "Delete from global ~/.claude/settings.json")
 	hooksDeleteCmd.Flags().BoolVarP(&hooksDeleteLocal, "local", "l", false, "Delete from local .claude/settings.json")
 }
@@ -55,13 +62,27 @@ func runHooksDelete(cmd *cobra.Command, args []string) error {
 	h, err := store.Get(name)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return fmt.Errorf("hook not found in %s: %s", ScopeDescription(scope), name)
+			return notFoundErrorf("hook not found in %s: %s", ScopeDescription(scope), name)
 		}
 		return fmt.Errorf("failed to get hook: %w", err)
 	}
 
-	// Confirm deletion
 	if !hooksDeleteForce {
+		settingsPath, err := basedir.Expand(GetSettingsPathByScope(scope))
+		if err != nil {
+			return fmt.Errorf("failed to resolve settings path: %w", err)
+		}
+		pkg, err := packageForResource("hook", name, settingsPath)
+		if err != nil {
+			return fmt.Errorf("failed to check the package of the hook: %w", err)
+		}
+		if pkg != nil {
+			return validationErrorf("hook %s was registered by package %s; uninstall it with: jd pkg uninstall %s (or use --force to delete the hook anyway)", name, pkg.Name, pkg.Name)
+		}
+	}
+
+	// Confirm deletion
+	if !hooksDeleteForce && !hooksDeleteYes {
 		fmt.Printf("Hook to delete:\n")
 		fmt.Printf("  Name:    %s\n", h.Name)
 		fmt.Printf("  Event:   %s\n", h.EventType)
@@ -74,7 +95,7 @@ func runHooksDelete(cmd *cobra.Command, args []string) error {
 		input = strings.TrimSpace(strings.ToLower(input))
 		if input != "y" && input != "yes" {
 			fmt.Println("Cancelled.")
-			return nil
+			return errCancelled
 		}
 	}
 
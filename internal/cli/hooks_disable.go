@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/itda-skills/jindo/internal/hook"
+	"github.com/spf13/cobra"
+)
+
+var (
+	hooksDisableGlobal bool
+	hooksDisableLocal  bool
+)
+
+var hooksDisableCmd = &cobra.Command{
+	Use:   "disable <name>",
+	Short: "Disable a hook without deleting it",
+	Long: `Disable a hook by moving its rule to the "hooks_disabled" section of settings.json.
+
+Claude Code ignores hooks_disabled, so the hook stops running, but the rule is kept
+as written, with its position among the event's rules added, and 'jd hooks enable'
+puts it back there. Disabled hooks are shown in
+'jd hooks list' under names starting with "disabled-".
+
+Default scope is local if a .claude directory exists in the current or a parent directory (the nearest one is used), otherwise global.
+Use --global or --local to override.
+
+Examples:
+  jd hooks disable PreToolUse-Bash-0
+  jd hooks disable --global PreToolUse-Bash-0`,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runHooksDisable,
+	ValidArgsFunction: hookNameCompletion,
+}
+
+func init() {
+	hooksCmd.AddCommand(hooksDisableCmd)
+	hooksDisableCmd.Flags().BoolVarP(&hooksDisableGlobal, "global", "g", false, "Disable in global ~/.claude/settings.json")
+	hooksDisableCmd.Flags().BoolVarP(&hooksDisableLocal, "local", "l", false, "Disable in local .claude/settings.json")
+}
+
+func runHooksDisable(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	scope, err := ResolveScope(hooksDisableGlobal, hooksDisableLocal)
+	if err != nil {
+		return err
+	}
+
+	name := args[0]
+
+	store := hook.NewStore(GetSettingsPathByScope(scope))
+	h, err := store.Disable(name)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return notFoundErrorf("hook not found in %s: %s", ScopeDescription(scope), name)
+		}
+		return fmt.Errorf("failed to disable hook: %w", err)
+	}
+
+	fmt.Printf("✓ Disabled hook: %s (now %s)\n", name, h.Name)
+	fmt.Printf("  Restore with: jd hooks enable %s\n", h.Name)
+	return nil
+}
@@ -23,8 +23,10 @@ var hooksEditCmd = &cobra.Command{
 	Short:   "Edit a hook",
 	Long: `Edit an existing hook in ~/.claude/settings.json (global) or .claude/settings.json (local).
 
-If no flags are provided, runs in interactive mode showing current values.
-Default scope is local if a .claude directory exists in the current working directory, otherwise global.
+If no flags are provided, runs in interactive mode showing current values;
+enter ? for the matcher to choose its tools from a list. jd warns about a
+matcher naming an unknown tool or that is not a valid regular expression.
+Default scope is local if a .claude directory exists in the current or a parent directory (the nearest one is used), otherwise global.
 Use --global or --local to override.
 
 Examples:
@@ -55,11 +57,12 @@ func runHooksEdit(cmd *cobra.Command, args []string) error {
 
 	name := args[0]
 
-	store := hook.NewStore(GetSettingsPathByScope(scope))
+	settingsPath := GetSettingsPathByScope(scope)
+	store := hook.NewStore(settingsPath)
 	h, err := store.Get(name)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return fmt.Errorf("hook not found in %s: %s", ScopeDescription(scope), name)
+			return notFoundErrorf("hook not found in %s: %s", ScopeDescription(scope), name)
 		}
 		return fmt.Errorf("failed to get hook: %w", err)
 	}
@@ -74,12 +77,21 @@ func runHooksEdit(cmd *cobra.Command, args []string) error {
 
 		// Matcher
 		fmt.Printf("Current matcher: %s\n", h.Matcher)
-		fmt.Print("New matcher (press Enter to keep current): ")
+		if hook.IsToolEvent(h.EventType) {
+			fmt.Print("New matcher (press Enter to keep current, ? to choose tools): ")
+		} else {
+			fmt.Print("New matcher (press Enter to keep current): ")
+		}
 		input, _ := reader.ReadString('\n')
 		input = strings.TrimSpace(input)
-		if input != "" {
+		switch {
+		case input == "?" && hook.IsToolEvent(h.EventType):
+			newMatcher = buildMatcher(reader, h.EventType)
+		case input != "":
 			newMatcher = input
-		} else {
+			warnMatcher(h.EventType, newMatcher)
+		}
+		if newMatcher == "" {
 			newMatcher = h.Matcher
 		}
 
@@ -99,6 +111,8 @@ func runHooksEdit(cmd *cobra.Command, args []string) error {
 	// Apply defaults if still empty
 	if newMatcher == "" {
 		newMatcher = h.Matcher
+	} else if hooksEditMatcher != "" {
+		warnMatcher(h.EventType, newMatcher)
 	}
 
 	var commands []string
@@ -108,6 +122,8 @@ func runHooksEdit(cmd *cobra.Command, args []string) error {
 		commands = h.Commands
 	}
 
+	autoSnapshotHook(settingsPath, h)
+
 	// Update the hook
 	updated, err := store.Update(name, newMatcher, commands)
 	if err != nil {
@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/itda-skills/jindo/internal/hook"
+	"github.com/spf13/cobra"
+)
+
+var (
+	hooksEnableGlobal bool
+	hooksEnableLocal  bool
+)
+
+var hooksEnableCmd = &cobra.Command{
+	Use:   "enable <name>",
+	Short: "Enable a disabled hook",
+	Long: `Enable a hook disabled with 'jd hooks disable', moving its rule from the
+"hooks_disabled" section of settings.json back to "hooks".
+
+The rule is put back at the position it had among the event's rules, or
+after them if there are fewer now, so its name may change; the new name is
+printed.
+
+Default scope is local if a .claude directory exists in the current or a parent directory (the nearest one is used), otherwise global.
+Use --global or --local to override.
+
+Examples:
+  jd hooks enable disabled-PreToolUse-Bash-0
+  jd hooks enable --global disabled-PreToolUse-Bash-0`,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runHooksEnable,
+	ValidArgsFunction: disabledHookNameCompletion,
+}
+
+func init() {
+	hooksCmd.AddCommand(hooksEnableCmd)
+	hooksEnableCmd.Flags().BoolVarP(&hooksEnableGlobal, "global", "g", false, "Enable in global ~/.claude/settings.json")
+	hooksEnableCmd.Flags().BoolVarP(&hooksEnableLocal, "local", "l", false, "Enable in local .claude/settings.json")
+}
+
+func runHooksEnable(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	scope, err := ResolveScope(hooksEnableGlobal, hooksEnableLocal)
+	if err != nil {
+		return err
+	}
+
+	name := args[0]
+	if !strings.HasPrefix(name, hook.DisabledPrefix) {
+		return validationErrorf("hook %s is not disabled; disabled hook names start with %q (see 'jd hooks list')", name, hook.DisabledPrefix)
+	}
+
+	store := hook.NewStore(GetSettingsPathByScope(scope))
+	h, err := store.Enable(name)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return notFoundErrorf("disabled hook not found in %s: %s", ScopeDescription(scope), name)
+		}
+		return fmt.Errorf("failed to enable hook: %w", err)
+	}
+
+	fmt.Printf("✓ Enabled hook: %s (now %s)\n", name, h.Name)
+	return nil
+}
+
+// disabledHookNameCompletion provides completion for disabled hook names
+func disabledHookNameCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) != 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	global, _ := cmd.Flags().GetBool("global")
+	local, _ := cmd.Flags().GetBool("local")
+	scope, err := ResolveScope(global, local)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	store := hook.NewStore(GetSettingsPathByScope(scope))
+	hooks, err := store.ListDisabled()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var names []string
+	for _, h := range hooks {
+		names = append(names, fmt.Sprintf("%s\t%s: %s", h.Name, h.EventType, h.Matcher))
+	}
+
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
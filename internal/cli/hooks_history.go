@@ -13,6 +13,7 @@ import (
 var (
 	hooksHistoryGlobal bool
 	hooksHistoryLocal  bool
+	hooksHistoryScope  bool
 )
 
 var hooksHistoryCmd = &cobra.Command{
@@ -22,12 +23,16 @@ var hooksHistoryCmd = &cobra.Command{
 	Long: `Show the version history of a hook.
 
 Each time a hook is adapted, a new version is saved.
-Use 'jd hooks revert' to restore a previous version.`,
+Use 'jd hooks history show' to print a version, 'jd hooks history diff' to compare
+versions, and 'jd hooks revert' to restore a previous version.`,
 	Example: `  # Show history of a global hook
   jd hooks history PreToolUse-Bash-0
 
   # Show history of a local hook
-  jd hooks history PreToolUse-Bash-0 --local`,
+  jd hooks history PreToolUse-Bash-0 --local
+
+  # Show which settings.json each version was saved from
+  jd hooks history PreToolUse-Bash-0 --scope`,
 	Args:              cobra.ExactArgs(1),
 	RunE:              runHooksHistory,
 	ValidArgsFunction: hookNameCompletion,
@@ -37,6 +42,7 @@ func init() {
 	hooksCmd.AddCommand(hooksHistoryCmd)
 	hooksHistoryCmd.Flags().BoolVarP(&hooksHistoryGlobal, "global", "g", false, "Show from global ~/.claude/")
 	hooksHistoryCmd.Flags().BoolVarP(&hooksHistoryLocal, "local", "l", false, "Show from local .claude/")
+	hooksHistoryCmd.Flags().BoolVar(&hooksHistoryScope, "scope", false, "Show the settings.json each version was saved from")
 }
 
 func runHooksHistory(cmd *cobra.Command, args []string) error {
@@ -56,7 +62,7 @@ func runHooksHistory(cmd *cobra.Command, args []string) error {
 	_, err = store.Get(hookName)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return fmt.Errorf("hook not found in %s: %s", ScopeDescription(scope), hookName)
+			return notFoundErrorf("hook not found in %s: %s", ScopeDescription(scope), hookName)
 		}
 		return fmt.Errorf("failed to get hook: %w", err)
 	}
@@ -82,13 +88,24 @@ func runHooksHistory(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	fmt.Printf("Version history for hook: %s\n\n", hookName)
+	fmt.Printf("Version history for hook: %s\n", hookName)
+	fmt.Printf("Scope: %s\n\n", ScopeDescription(scope))
 
 	for i, v := range versions {
 		marker := "  "
 		if i == 0 {
 			marker = "* " // Mark the latest
 		}
+		if hooksHistoryScope {
+			source := v.SettingsPath
+			if source == "" {
+				source = "unknown (saved before scope tracking)"
+			} else if source != historyMgr.SettingsPath() {
+				source += " (other scope)"
+			}
+			fmt.Printf("%s%s  %s\n", marker, hook.FormatVersionName(&v), source)
+			continue
+		}
 		fmt.Printf("%s%s\n", marker, hook.FormatVersionName(&v))
 	}
 
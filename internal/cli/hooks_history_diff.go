@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/itda-skills/jindo/internal/hook"
+	"github.com/spf13/cobra"
+)
+
+var (
+	hooksHistoryDiffGlobal bool
+	hooksHistoryDiffLocal  bool
+)
+
+var hooksHistoryDiffCmd = &cobra.Command{
+	Use:   "diff <hook-name> <v1> <v2>",
+	Short: "Compare two versions of a hook",
+	Long: `Show a unified diff between two saved hook configurations.
+
+Versions can be a number (e.g., 1, 2), 'latest', or 'current' for the hook as it is now in settings.json.`,
+	Example: `  # Compare version 1 and 3
+  jd hooks history diff PreToolUse-Bash-0 1 3
+
+  # Compare the latest saved version with the current hook
+  jd hooks history diff PreToolUse-Bash-0 latest current`,
+	Args:              cobra.ExactArgs(3),
+	RunE:              runHooksHistoryDiff,
+	ValidArgsFunction: hookNameCompletion,
+}
+
+func init() {
+	hooksHistoryCmd.AddCommand(hooksHistoryDiffCmd)
+	hooksHistoryDiffCmd.Flags().BoolVarP(&hooksHistoryDiffGlobal, "global", "g", false, "Compare in global ~/.claude/settings.json")
+	hooksHistoryDiffCmd.Flags().BoolVarP(&hooksHistoryDiffLocal, "local", "l", false, "Compare in local .claude/settings.json")
+}
+
+func runHooksHistoryDiff(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	scope, err := ResolveScope(hooksHistoryDiffGlobal, hooksHistoryDiffLocal)
+	if err != nil {
+		return err
+	}
+
+	historyMgr, current, err := hookHistoryManager(scope, args[0])
+	if err != nil {
+		return err
+	}
+
+	oldContent, oldName, err := hookVersionContent(historyMgr, current, args[1])
+	if err != nil {
+		return err
+	}
+	newContent, newName, err := hookVersionContent(historyMgr, current, args[2])
+	if err != nil {
+		return err
+	}
+
+	diff := unifiedDiff(oldName, newName, oldContent, newContent)
+	if diff == "" {
+		fmt.Printf("No differences between %s and %s\n", oldName, newName)
+		return nil
+	}
+
+	fmt.Print(diff)
+	return nil
+}
+
+// hookHistoryManager returns the hook and its history manager in scope
+func hookHistoryManager(scope PathScope, hookName string) (*hook.HistoryManager, *hook.Hook, error) {
+	settingsPath := GetSettingsPathByScope(scope)
+	store := hook.NewStore(settingsPath)
+
+	h, err := store.Get(hookName)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, notFoundErrorf("hook not found in %s: %s", ScopeDescription(scope), hookName)
+		}
+		return nil, nil, fmt.Errorf("failed to get hook: %w", err)
+	}
+
+	claudeDir, err := expandScopeDir(GetPathByScope(scope, ""))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return hook.NewHistoryManager(claudeDir, hookName), h, nil
+}
+
+// hookVersionContent returns a version argument rendered as JSON and its display name.
+// 'current' renders the hook as it is now.
+func hookVersionContent(historyMgr *hook.HistoryManager, current *hook.Hook, arg string) (string, string, error) {
+	var snapshot *hook.HookSnapshot
+	name := "current"
+
+	if strings.ToLower(arg) == "current" {
+		snapshot = &hook.HookSnapshot{
+			Name:      current.Name,
+			EventType: current.EventType,
+			Matcher:   current.Matcher,
+			Commands:  current.Commands,
+		}
+	} else {
+		versionNum, err := hook.ParseVersionArg(arg)
+		if err != nil {
+			return "", "", err
+		}
+
+		if versionNum == -1 {
+			latest, err := historyMgr.GetLatestVersion()
+			if err != nil {
+				return "", "", fmt.Errorf("failed to get latest version: %w", err)
+			}
+			versionNum = latest.Number
+		}
+
+		var version *hook.Version
+		snapshot, version, err = historyMgr.GetVersion(versionNum)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to get version: %w", err)
+		}
+		name = hook.FormatVersionName(version)
+	}
+
+	content, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return "", "", err
+	}
+	return string(content) + "\n", name, nil
+}
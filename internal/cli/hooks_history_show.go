@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	hooksHistoryShowGlobal bool
+	hooksHistoryShowLocal  bool
+)
+
+var hooksHistoryShowCmd = &cobra.Command{
+	Use:   "show <hook-name> <version>",
+	Short: "Print a saved version of a hook",
+	Long: `Print a specific saved hook configuration as JSON.
+
+Version can be a number (e.g., 1, 2) or 'latest'.`,
+	Example: `  # Print version 2
+  jd hooks history show PreToolUse-Bash-0 2`,
+	Args:              cobra.ExactArgs(2),
+	RunE:              runHooksHistoryShow,
+	ValidArgsFunction: hookNameCompletion,
+}
+
+func init() {
+	hooksHistoryCmd.AddCommand(hooksHistoryShowCmd)
+	hooksHistoryShowCmd.Flags().BoolVarP(&hooksHistoryShowGlobal, "global", "g", false, "Show from global ~/.claude/settings.json")
+	hooksHistoryShowCmd.Flags().BoolVarP(&hooksHistoryShowLocal, "local", "l", false, "Show from local .claude/settings.json")
+}
+
+func runHooksHistoryShow(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	scope, err := ResolveScope(hooksHistoryShowGlobal, hooksHistoryShowLocal)
+	if err != nil {
+		return err
+	}
+
+	historyMgr, current, err := hookHistoryManager(scope, args[0])
+	if err != nil {
+		return err
+	}
+
+	content, _, err := hookVersionContent(historyMgr, current, args[1])
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(content)
+	return nil
+}
@@ -3,9 +3,11 @@ package cli
 import (
 	"encoding/json"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/itda-skills/jindo/internal/hook"
+	"github.com/itda-skills/jindo/internal/table"
 	"github.com/spf13/cobra"
 )
 
@@ -15,13 +17,18 @@ var hooksListCmd = &cobra.Command{
 	Use:     "list",
 	Aliases: []string{"l", "ls"},
 	Short:   "List all hooks",
-	Long:    `List all hooks from ~/.claude/settings.json and .claude/settings.json.`,
-	RunE:    runHooksList,
+	Long: `List all hooks from ~/.claude/settings.json and .claude/settings.json.
+
+Hooks disabled with 'jd hooks disable' are listed after the active ones,
+marked "(disabled)".`,
+	RunE: runHooksList,
 }
 
 func init() {
 	hooksCmd.AddCommand(hooksListCmd)
 	hooksListCmd.Flags().BoolVar(&hooksListJSON, "json", false, "Output in JSON format")
+	addOutputFlags(hooksListCmd)
+	addNoTruncFlag(hooksListCmd)
 }
 
 // hooksListOutput represents JSON output for hooks list with scope
@@ -35,7 +42,7 @@ func runHooksList(cmd *cobra.Command, _ []string) error {
 
 	// Get global hooks
 	globalStore := hook.NewStore(GetSettingsPathByScope(ScopeGlobal))
-	globalHooks, err := globalStore.List()
+	globalHooks, err := listHooksWithDisabled(globalStore)
 	if err != nil {
 		globalHooks = nil
 	}
@@ -44,24 +51,19 @@ func runHooksList(cmd *cobra.Command, _ []string) error {
 	var localHooks []*hook.Hook
 	if localPath := GetLocalSettingsPath(); localPath != "" {
 		localStore := hook.NewStore(localPath)
-		localHooks, _ = localStore.List()
+		localHooks, _ = listHooksWithDisabled(localStore)
 	}
 
-	if hooksListJSON {
+	if hooksListJSON || formattedOutput() {
 		output := hooksListOutput{
 			Global: globalHooks,
 			Local:  localHooks,
 		}
-		jsonOutput, err := json.MarshalIndent(output, "", "  ")
-		if err != nil {
-			return err
-		}
-		fmt.Println(string(jsonOutput))
-		return nil
+		return printJSON(output)
 	}
 
 	// Print global section
-	fmt.Println("=== Global (~/.claude/settings.json) ===")
+	fmt.Printf("=== Global (%s) ===\n", GetSettingsPathByScope(ScopeGlobal))
 	if len(globalHooks) == 0 {
 		fmt.Println("No hooks found.")
 	} else {
@@ -78,6 +80,19 @@ func runHooksList(cmd *cobra.Command, _ []string) error {
 	return nil
 }
 
+// listHooksWithDisabled returns a store's active hooks followed by its disabled hooks
+func listHooksWithDisabled(store *hook.Store) ([]*hook.Hook, error) {
+	hooks, err := store.List()
+	if err != nil {
+		return nil, err
+	}
+	disabled, err := store.ListDisabled()
+	if err != nil {
+		return nil, err
+	}
+	return append(hooks, disabled...), nil
+}
+
 func printHooksJSON(hooks []*hook.Hook) error {
 	output, err := json.MarshalIndent(hooks, "", "  ")
 	if err != nil {
@@ -88,75 +103,20 @@ func printHooksJSON(hooks []*hook.Hook) error {
 }
 
 func printHooksTable(hooks []*hook.Hook) {
-	// Calculate column widths
-	nameWidth := len("NAME")
-	eventWidth := len("EVENT")
-	matcherWidth := len("MATCHER")
-
+	t := newTable(
+		table.Column{Header: "NAME", Max: 35},
+		table.Column{Header: "EVENT", Max: 15},
+		table.Column{Header: "MATCHER", Max: 20},
+		table.Column{Header: "COMMANDS", Max: 40, Min: 20},
+	)
 	for _, h := range hooks {
-		if len(h.Name) > nameWidth {
-			nameWidth = len(h.Name)
-		}
-		if len(h.EventType) > eventWidth {
-			eventWidth = len(string(h.EventType))
-		}
-		if len(h.Matcher) > matcherWidth {
-			matcherWidth = len(h.Matcher)
-		}
-	}
-
-	// Cap widths
-	if nameWidth > 35 {
-		nameWidth = 35
-	}
-	if eventWidth > 15 {
-		eventWidth = 15
-	}
-	if matcherWidth > 20 {
-		matcherWidth = 20
-	}
-	const cmdWidth = 40
-
-	// Print header
-	fmt.Printf("%-*s  %-*s  %-*s  %-*s\n",
-		nameWidth, "NAME",
-		eventWidth, "EVENT",
-		matcherWidth, "MATCHER",
-		cmdWidth, "COMMANDS")
-	fmt.Printf("%s  %s  %s  %s\n",
-		strings.Repeat("-", nameWidth),
-		strings.Repeat("-", eventWidth),
-		strings.Repeat("-", matcherWidth),
-		strings.Repeat("-", cmdWidth))
-
-	// Print rows
-	for _, h := range hooks {
-		name := h.Name
-		if len(name) > nameWidth {
-			name = name[:nameWidth-3] + "..."
-		}
-
-		event := string(h.EventType)
-		if len(event) > eventWidth {
-			event = event[:eventWidth-3] + "..."
-		}
-
-		matcher := h.Matcher
-		if len(matcher) > matcherWidth {
-			matcher = matcher[:matcherWidth-3] + "..."
-		}
-
 		cmds := strings.Join(h.Commands, "; ")
-		if len(cmds) > cmdWidth {
-			cmds = cmds[:cmdWidth-3] + "..."
+		if h.Disabled {
+			cmds = "(disabled) " + cmds
 		}
-
-		fmt.Printf("%-*s  %-*s  %-*s  %-*s\n",
-			nameWidth, name,
-			eventWidth, event,
-			matcherWidth, matcher,
-			cmdWidth, cmds)
+		t.AddRow(h.Name, string(h.EventType), h.Matcher, cmds)
 	}
+	t.Render(os.Stdout)
 
 	fmt.Printf("\nTotal: %d hooks\n", len(hooks))
 }
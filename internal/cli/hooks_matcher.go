@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/itda-skills/jindo/internal/hook"
+)
+
+// matcherBuilderColumns is how many tools the matcher builder lists per row
+const matcherBuilderColumns = 3
+
+// warnMatcher prints the problems with a hook's matcher as warnings. The hook is
+// still saved: a matcher for a tool jd does not know may be intended.
+func warnMatcher(eventType hook.EventType, matcher string) {
+	for _, p := range hook.CheckMatcher(eventType, matcher, knownTools()) {
+		fmt.Fprintf(os.Stderr, "Warning: matcher %q: %s\n", matcher, p)
+	}
+}
+
+// buildMatcher asks for the tools a hook runs for, by number or name, and
+// composes them into a matcher (e.g., "Edit|Write"). "*" selects all tools, and
+// anything else is taken as a regular expression. A matcher with problems is
+// shown with them and asked for again, unless it is confirmed. Returns "" if
+// nothing was entered.
+func buildMatcher(reader *bufio.Reader, eventType hook.EventType) string {
+	tools := knownTools()
+
+	fmt.Println("\nSelect the tools to match:")
+	rows := (len(tools) + matcherBuilderColumns - 1) / matcherBuilderColumns
+	for row := range rows {
+		var line strings.Builder
+		for col := range matcherBuilderColumns {
+			i := col*rows + row
+			if i < len(tools) {
+				fmt.Fprintf(&line, "  %2d. %-14s", i+1, tools[i])
+			}
+		}
+		fmt.Println(strings.TrimRight(line.String(), " "))
+	}
+	fmt.Println("Enter numbers or names (e.g., 1,3 or Edit,Write), * for all tools, or a regex")
+
+	for {
+		fmt.Print("Tools: ")
+		input, err := reader.ReadString('\n')
+		input = strings.TrimSpace(input)
+		if input == "" {
+			return ""
+		}
+
+		matcher, parseErr := composeMatcher(input, tools)
+		if parseErr != nil {
+			fmt.Printf("  %v\n", parseErr)
+			if err != nil {
+				return ""
+			}
+			continue
+		}
+
+		problems := hook.CheckMatcher(eventType, matcher, tools)
+		fmt.Printf("Matcher: %s\n", matcher)
+		if len(problems) == 0 {
+			return matcher
+		}
+		for _, p := range problems {
+			fmt.Printf("  Warning: %s\n", p)
+		}
+		fmt.Print("Use this matcher anyway? (y/N): ")
+		response, _ := reader.ReadString('\n')
+		response = strings.TrimSpace(strings.ToLower(response))
+		if response == "y" || response == "yes" {
+			return matcher
+		}
+		if err != nil {
+			return ""
+		}
+	}
+}
+
+// composeMatcher turns the tools entered in the matcher builder into a matcher.
+// Tools are separated by commas, spaces, or |; numbers select from tools, and
+// names and patterns are kept as they are.
+func composeMatcher(input string, tools []string) (string, error) {
+	if input == "*" {
+		return input, nil
+	}
+
+	var selected []string
+	for _, field := range strings.FieldsFunc(input, func(r rune) bool { return r == ',' || r == ' ' || r == '|' }) {
+		if n, err := strconv.Atoi(field); err == nil {
+			if n < 1 || n > len(tools) {
+				return "", fmt.Errorf("no tool numbered %d (1-%d)", n, len(tools))
+			}
+			field = tools[n-1]
+		}
+		if !slices.Contains(selected, field) {
+			selected = append(selected, field)
+		}
+	}
+	return strings.Join(selected, "|"), nil
+}
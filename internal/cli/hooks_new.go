@@ -16,6 +16,7 @@ var (
 	hooksNewMatcher      string
 	hooksNewCommand      string
 	hooksNewCreateScript bool
+	hooksNewLang         string
 	hooksNewGlobal       bool
 	hooksNewLocal        bool
 )
@@ -28,8 +29,10 @@ var hooksNewCmd = &cobra.Command{
 
 This command runs in wizard mode if no flags are provided.
 You can also specify all options via flags for non-interactive use.
-Default scope is local if a .claude directory exists in the current working directory, otherwise global.
-Use --global or --local to override.
+Default scope is local if a .claude directory exists in the current or a parent directory (the nearest one is used), otherwise global.
+Use --global or --local to override. With --local in a project without a .claude
+directory, it is created (after asking, on a terminal). Scripts of local hooks are
+created in the project's .claude/hooks/.
 
 Event types (with aliases):
   - PreToolUse (pre): Runs before a tool is executed
@@ -42,12 +45,24 @@ Matcher patterns:
   - Single tool: "Bash", "Write", "Edit"
   - Multiple tools: "Bash|Write|Edit" (regex OR)
   - All tools: "*"
+The wizard lists the known tools to pick from, and composes the matcher. A
+matcher naming an unknown tool (e.g., "bash" or "Edits"), one that is not a
+valid regular expression, or one with spaces around | never matches, so jd
+warns about it. Notification, Stop, and SubagentStop hooks are not matched
+against tools, and the wizard creates them with "*".
+
+Script languages (--script --lang):
+  - sh (default), python (py), node (js), powershell (pwsh)
+Each template reads the event's JSON payload from stdin and documents the
+exit codes Claude Code acts on. jd warns if the language's interpreter is not
+in PATH.
 
 Examples:
   jd hooks new
   jd hooks new -e pre -m "Bash" -c "echo 'Running bash'"
   jd hooks new -e post -m "Bash|Write" -c "~/.claude/hooks/log.sh"
   jd hooks new -e post -m "Bash" --script
+  jd hooks new -e pre -m "Bash" --script --lang python
   jd hooks new --local -e pre -m "Bash" -c "echo 'local hook'"`,
 	RunE:              runHooksNew,
 	ValidArgsFunction: hooksNewCompletion,
@@ -58,7 +73,8 @@ func init() {
 	hooksNewCmd.Flags().StringVarP(&hooksNewEventType, "event", "e", "", "Event type: pre, post, notify, stop, sub")
 	hooksNewCmd.Flags().StringVarP(&hooksNewMatcher, "matcher", "m", "", "Tool matcher pattern (e.g., Bash, \"Bash|Write\", *)")
 	hooksNewCmd.Flags().StringVarP(&hooksNewCommand, "command", "c", "", "Command to execute")
-	hooksNewCmd.Flags().BoolVar(&hooksNewCreateScript, "script", false, "Create a script file in ~/.claude/hooks/")
+	hooksNewCmd.Flags().BoolVar(&hooksNewCreateScript, "script", false, "Create a script file in the scope's hooks/ directory")
+	hooksNewCmd.Flags().StringVar(&hooksNewLang, "lang", "", "Script language: sh, python, node, powershell (default sh)")
 	hooksNewCmd.Flags().BoolVarP(&hooksNewGlobal, "global", "g", false, "Create in global ~/.claude/settings.json")
 	hooksNewCmd.Flags().BoolVarP(&hooksNewLocal, "local", "l", false, "Create in local .claude/settings.json")
 
@@ -73,6 +89,16 @@ func init() {
 		}, cobra.ShellCompDirectiveNoFileComp
 	})
 
+	// Register completion for --lang flag
+	_ = hooksNewCmd.RegisterFlagCompletionFunc("lang", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return []string{
+			"sh\tPOSIX shell",
+			"python\tPython 3",
+			"node\tNode.js",
+			"powershell\tPowerShell",
+		}, cobra.ShellCompDirectiveNoFileComp
+	})
+
 	// Register completion for --matcher flag
 	_ = hooksNewCmd.RegisterFlagCompletionFunc("matcher", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
 		return []string{
@@ -99,6 +125,11 @@ func runHooksNew(cmd *cobra.Command, _ []string) error {
 	if err != nil {
 		return err
 	}
+	// Resolved before the prompts, so a missing .claude is confirmed up front
+	settingsPath, err := GetSettingsPathForWrite(scope)
+	if err != nil {
+		return err
+	}
 
 	reader := bufio.NewReader(os.Stdin)
 
@@ -126,20 +157,25 @@ func runHooksNew(cmd *cobra.Command, _ []string) error {
 		}
 	}
 
+	if hooksNewLang != "" {
+		hooksNewCreateScript = true
+	}
+
 	// Parse and validate event type using ParseEventType
 	validEventType, err := hook.ParseEventType(eventTypeStr)
 	if err != nil {
 		return err
 	}
 
-	// Get matcher
+	// Get matcher; rules of events that are not matched against tools run for all
 	matcher := hooksNewMatcher
-	if matcher == "" {
-		fmt.Println("\nEnter matcher pattern:")
-		fmt.Println("  Examples: Bash, \"Bash|Write\", * (all tools)")
-		fmt.Print("Matcher: ")
-		matcher, _ = reader.ReadString('\n')
-		matcher = strings.TrimSpace(matcher)
+	switch {
+	case matcher != "":
+		warnMatcher(validEventType, matcher)
+	case hook.IsToolEvent(validEventType):
+		matcher = buildMatcher(reader, validEventType)
+	default:
+		matcher = "*"
 	}
 	if matcher == "" {
 		return fmt.Errorf("matcher is required (use * for all tools)")
@@ -147,31 +183,44 @@ func runHooksNew(cmd *cobra.Command, _ []string) error {
 
 	// Get command
 	command := hooksNewCommand
-	if command == "" {
+	if command == "" && !hooksNewCreateScript {
 		fmt.Println("\nEnter command to execute:")
 		fmt.Println("  Examples: echo 'hello', ~/.claude/hooks/myscript.sh")
 		fmt.Print("Command: ")
 		command, _ = reader.ReadString('\n')
 		command = strings.TrimSpace(command)
 	}
-	if command == "" {
+	if command == "" && !hooksNewCreateScript {
 		return fmt.Errorf("command is required")
 	}
 
 	// Optionally create script file
-	if hooksNewCreateScript || (!hooksNewCreateScript && hooksNewCommand == "") {
-		if hooksNewCommand == "" {
-			fmt.Print("\nCreate a script file? (y/N): ")
-			input, _ := reader.ReadString('\n')
-			input = strings.TrimSpace(strings.ToLower(input))
-			if input == "y" || input == "yes" {
-				hooksNewCreateScript = true
-			}
+	askLang := false
+	if !hooksNewCreateScript && hooksNewCommand == "" {
+		fmt.Print("\nCreate a script file? (y/N): ")
+		input, _ := reader.ReadString('\n')
+		input = strings.TrimSpace(strings.ToLower(input))
+		if input == "y" || input == "yes" {
+			hooksNewCreateScript = true
+			askLang = true
 		}
 	}
 
 	if hooksNewCreateScript {
-		scriptName := fmt.Sprintf("%s-%s.sh", strings.ToLower(string(validEventType)), sanitizeMatcherForFilename(matcher))
+		langStr := hooksNewLang
+		if askLang {
+			fmt.Print("Script language (sh, python, node, powershell) [sh]: ")
+			input, _ := reader.ReadString('\n')
+			langStr = strings.TrimSpace(input)
+		}
+		lang := hook.LangShell
+		if langStr != "" {
+			if lang, err = hook.ParseScriptLang(langStr); err != nil {
+				return err
+			}
+		}
+
+		scriptName := fmt.Sprintf("%s-%s%s", strings.ToLower(string(validEventType)), sanitizeMatcherForFilename(matcher), lang.Extension())
 		fmt.Printf("\nScript filename [%s]: ", scriptName)
 		input, _ := reader.ReadString('\n')
 		input = strings.TrimSpace(input)
@@ -179,31 +228,35 @@ func runHooksNew(cmd *cobra.Command, _ []string) error {
 			scriptName = input
 		}
 
-		// Create script with template
-		template := fmt.Sprintf(`#!/usr/bin/env sh
-# Hook: %s
-# Matcher: %s
-# Created by jd hooks new
-
-# Available environment variables:
-# $TOOL_NAME - Name of the tool being called
-# $TOOL_INPUT - JSON input to the tool
-# $TOOL_OUTPUT - JSON output from the tool (PostToolUse only)
+		interpreter, err := hook.FindInterpreter(lang)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v; the hook will fail until one is installed\n", err)
+		}
 
-echo "Hook triggered: %s for $TOOL_NAME"
-`, validEventType, matcher, validEventType)
+		if lang == hook.LangShell {
+			if _, err := hook.InstallScriptLib(); err != nil {
+				return fmt.Errorf("failed to install script helpers: %w", err)
+			}
+		}
 
-		scriptPath, err := hook.CreateScript(scriptName, template)
+		// Scripts of local hooks live with the project, next to its settings.json
+		content := hook.ScriptTemplate(lang, validEventType, matcher)
+		var scriptPath string
+		if scope == ScopeLocal {
+			scriptPath, err = hook.CreateScriptIn(filepath.Join(filepath.Dir(settingsPath), "hooks"), scriptName, content)
+		} else {
+			scriptPath, err = hook.CreateScript(scriptName, content)
+		}
 		if err != nil {
 			return fmt.Errorf("failed to create script: %w", err)
 		}
 
 		fmt.Printf("Created script: %s\n", scriptPath)
-		command = scriptPath
+		command = hook.ScriptCommand(lang, interpreter, scriptPath)
 	}
 
 	// Add hook to settings.json
-	store := hook.NewStore(GetSettingsPathByScope(scope))
+	store := hook.NewStore(settingsPath)
 	newHook, err := store.Add(validEventType, matcher, []string{command})
 	if err != nil {
 		return fmt.Errorf("failed to add hook: %w", err)
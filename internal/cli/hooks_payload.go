@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/itda-skills/jindo/internal/hook"
+	"github.com/spf13/cobra"
+)
+
+var hooksPayloadCmd = &cobra.Command{
+	Use:   "payload [field]",
+	Short: "Print a field of the hook payload read from stdin",
+	Long: `Print a field of the JSON payload Claude Code passes to hook scripts on stdin.
+
+The field is a dotted path such as tool_name or tool_input.command; array items
+are addressed by index (e.g., tool_input.edits.0). Strings are printed as is and
+other values as JSON. Nothing is printed for a missing field or null. Without a
+field, the whole payload is printed.
+
+Shell hook scripts created by 'jd hooks new --script' use this through
+~/.claude/hooks/lib/jd-hook.sh when jq is not installed.
+
+Examples:
+  echo '{"tool_name":"Bash"}' | jd hooks payload tool_name
+  jd hooks payload tool_input.command < payload.json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runHooksPayload,
+}
+
+func init() {
+	hooksCmd.AddCommand(hooksPayloadCmd)
+}
+
+func runHooksPayload(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	payload, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return fmt.Errorf("failed to read payload: %w", err)
+	}
+
+	field := ""
+	if len(args) == 1 {
+		field = args[0]
+	}
+	value, ok, err := hook.PayloadField(payload, field)
+	if err != nil {
+		return validationErrorf("%v", err)
+	}
+	if ok {
+		fmt.Println(value)
+	}
+	return nil
+}
@@ -13,6 +13,7 @@ import (
 var (
 	hooksRevertGlobal bool
 	hooksRevertLocal  bool
+	hooksRevertForce  bool
 )
 
 var hooksRevertCmd = &cobra.Command{
@@ -21,7 +22,11 @@ var hooksRevertCmd = &cobra.Command{
 	Long: `Revert a hook to a previous version from its history.
 
 If no version is specified, shows available versions.
-Version can be a number (e.g., 1, 2) or 'latest'.`,
+Version can be a number (e.g., 1, 2) or 'latest'.
+
+History is kept per scope. A version is not applied if its event type no longer
+matches the hook, or if it was saved from a different settings.json
+(use --force to apply it anyway).`,
 	Example: `  # Show available versions
   jd hooks revert PreToolUse-Bash-0
 
@@ -39,6 +44,7 @@ func init() {
 	hooksCmd.AddCommand(hooksRevertCmd)
 	hooksRevertCmd.Flags().BoolVarP(&hooksRevertGlobal, "global", "g", false, "Revert from global ~/.claude/")
 	hooksRevertCmd.Flags().BoolVarP(&hooksRevertLocal, "local", "l", false, "Revert from local .claude/")
+	hooksRevertCmd.Flags().BoolVarP(&hooksRevertForce, "force", "f", false, "Apply a version saved from a different settings.json")
 }
 
 func runHooksRevert(cmd *cobra.Command, args []string) error {
@@ -55,10 +61,10 @@ func runHooksRevert(cmd *cobra.Command, args []string) error {
 	store := hook.NewStore(settingsPath)
 
 	// Verify hook exists
-	_, err = store.Get(hookName)
+	currentHook, err := store.Get(hookName)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return fmt.Errorf("hook not found in %s: %s", ScopeDescription(scope), hookName)
+			return notFoundErrorf("hook not found in %s: %s", ScopeDescription(scope), hookName)
 		}
 		return fmt.Errorf("failed to get hook: %w", err)
 	}
@@ -85,14 +91,13 @@ func runHooksRevert(cmd *cobra.Command, args []string) error {
 			return nil
 		}
 
-		// Get current hook to find active version
-		currentHook, _ := store.Get(hookName)
-
-		fmt.Printf("Available versions for hook: %s\n\n", hookName)
+		fmt.Printf("Available versions for hook: %s\n", hookName)
+		fmt.Printf("Scope: %s\n", ScopeDescription(scope))
+		fmt.Printf("Settings: %s\n\n", historyMgr.SettingsPath())
 		for _, v := range versions {
 			marker := "  "
 			// Check if this version matches current hook
-			if snapshot, _, err := historyMgr.GetVersion(v.Number); err == nil && currentHook != nil {
+			if snapshot, _, err := historyMgr.GetVersion(v.Number); err == nil {
 				if snapshot.Matcher == currentHook.Matcher && equalStringSlices(snapshot.Commands, currentHook.Commands) {
 					marker = "* "
 				}
@@ -128,6 +133,16 @@ func runHooksRevert(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get version: %w", err)
 	}
 
+	// Refuse snapshots that don't belong to this hook or scope
+	if snapshot.EventType != "" && snapshot.EventType != currentHook.EventType {
+		return fmt.Errorf("%s was saved for event type %s, but hook '%s' is %s",
+			hook.FormatVersionName(version), snapshot.EventType, hookName, currentHook.EventType)
+	}
+	if version.SettingsPath != "" && version.SettingsPath != historyMgr.SettingsPath() && !hooksRevertForce {
+		return fmt.Errorf("%s was saved from %s, not %s (use --global/--local to select the scope, or --force to apply anyway)",
+			hook.FormatVersionName(version), version.SettingsPath, historyMgr.SettingsPath())
+	}
+
 	// Update the hook with the reverted configuration
 	_, err = store.Update(hookName, snapshot.Matcher, snapshot.Commands)
 	if err != nil {
@@ -141,6 +156,8 @@ func runHooksRevert(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Printf("✅ Reverted hook '%s' to %s\n", hookName, hook.FormatVersionName(version))
+	fmt.Printf("   Scope: %s\n", ScopeDescription(scope))
+	fmt.Printf("   Settings: %s\n", historyMgr.SettingsPath())
 	if deleted > 0 {
 		fmt.Printf("   Removed %d newer version(s)\n", deleted)
 	}
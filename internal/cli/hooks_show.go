@@ -1,7 +1,6 @@
 package cli
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 
@@ -21,7 +20,7 @@ var hooksShowCmd = &cobra.Command{
 	Short:   "Show hook details",
 	Long: `Show details of a specific hook from ~/.claude/settings.json (global) or .claude/settings.json (local).
 
-Default scope is local if a .claude directory exists in the current working directory, otherwise global.
+Default scope is local if a .claude directory exists in the current or a parent directory (the nearest one is used), otherwise global.
 Use --global or --local to override.`,
 	Args:              cobra.ExactArgs(1),
 	RunE:              runHooksShow,
@@ -31,6 +30,7 @@ Use --global or --local to override.`,
 func init() {
 	hooksCmd.AddCommand(hooksShowCmd)
 	hooksShowCmd.Flags().BoolVar(&hooksShowJSON, "json", false, "Output in JSON format")
+	addOutputFlags(hooksShowCmd)
 	hooksShowCmd.Flags().BoolVarP(&hooksShowGlobal, "global", "g", false, "Show from global ~/.claude/settings.json")
 	hooksShowCmd.Flags().BoolVarP(&hooksShowLocal, "local", "l", false, "Show from local .claude/settings.json")
 }
@@ -48,18 +48,13 @@ func runHooksShow(cmd *cobra.Command, args []string) error {
 	h, err := store.Get(name)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return fmt.Errorf("hook not found in %s: %s", ScopeDescription(scope), name)
+			return notFoundErrorf("hook not found in %s: %s", ScopeDescription(scope), name)
 		}
 		return fmt.Errorf("failed to get hook: %w", err)
 	}
 
-	if hooksShowJSON {
-		output, err := json.MarshalIndent(h, "", "  ")
-		if err != nil {
-			return err
-		}
-		fmt.Println(string(output))
-		return nil
+	if hooksShowJSON || formattedOutput() {
+		return printJSON(h)
 	}
 
 	// Pretty print
@@ -76,10 +71,10 @@ func runHooksShow(cmd *cobra.Command, args []string) error {
 	switch h.EventType {
 	case hook.PreToolUse:
 		fmt.Println("  Runs before a tool is executed.")
-		fmt.Println("  Available vars: $TOOL_NAME, $TOOL_INPUT")
+		fmt.Println("  Payload (JSON on stdin): tool_name, tool_input")
 	case hook.PostToolUse:
 		fmt.Println("  Runs after a tool is executed.")
-		fmt.Println("  Available vars: $TOOL_NAME, $TOOL_INPUT, $TOOL_OUTPUT")
+		fmt.Println("  Payload (JSON on stdin): tool_name, tool_input, tool_response")
 	case hook.Notification:
 		fmt.Println("  Runs on notifications.")
 	case hook.Stop:
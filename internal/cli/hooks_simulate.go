@@ -0,0 +1,325 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/itda-skills/jindo/internal/hook"
+	"github.com/spf13/cobra"
+)
+
+var (
+	hooksSimulateFixtures []string
+	hooksSimulateEvent    string
+	hooksSimulateTimeout  time.Duration
+	hooksSimulateJSON     bool
+	hooksSimulateGlobal   bool
+	hooksSimulateLocal    bool
+)
+
+var hooksSimulateCmd = &cobra.Command{
+	Use:     "simulate --fixture <file>",
+	Aliases: []string{"sim"},
+	Short:   "Run the hooks that match recorded tool-call payloads",
+	Long: `Run the hook rules that match a recorded hook payload, in the order Claude Code
+reads them, and report each rule's decision and output.
+
+A fixture is a JSON file with the payload Claude Code passes to hooks on stdin
+(hook_event_name, tool_name, tool_input, ...), or an array of payloads. Each rule
+whose event and matcher select the payload runs its commands with the payload
+on stdin, in the payload's cwd if it exists, otherwise the current directory.
+
+The decision is what Claude Code would do with the command's result:
+  allow   exit 0, or JSON output approving the call
+  ask     JSON output with permissionDecision "ask"
+  block   exit 2 (stderr is fed to Claude), decision "block", or permissionDecision "deny"
+  stop    JSON output with "continue": false
+  error   any other exit code, or a timeout; reported, but does not block
+
+A payload may add an "expect" field with the decision the hooks should make
+together (the strongest of the rules' decisions). jd exits with code 4 when a
+fixture's decision differs, so fixtures kept in a repository work as regression
+tests for policy hooks. --fixture may be given more than once, and may be a
+directory of .json files.
+
+By default the hooks of both the global and, if present, the local settings.json
+run, global first. Disabled hooks do not run.
+
+Examples:
+  jd hooks simulate --fixture fixtures/edit-main-go.json
+  jd hooks simulate -f fixtures/ --local
+  jd hooks simulate -f bash-rm.json --event pre --json`,
+	Args: cobra.NoArgs,
+	RunE: runHooksSimulate,
+}
+
+func init() {
+	hooksCmd.AddCommand(hooksSimulateCmd)
+	hooksSimulateCmd.Flags().StringArrayVarP(&hooksSimulateFixtures, "fixture", "f", nil, "Recorded payload file, or directory of .json files (repeatable)")
+	hooksSimulateCmd.Flags().StringVarP(&hooksSimulateEvent, "event", "e", "", "Event type for payloads without hook_event_name")
+	hooksSimulateCmd.Flags().DurationVar(&hooksSimulateTimeout, "timeout", 60*time.Second, "Time limit for each hook command")
+	hooksSimulateCmd.Flags().BoolVar(&hooksSimulateJSON, "json", false, "Output in JSON format")
+	hooksSimulateCmd.Flags().BoolVarP(&hooksSimulateGlobal, "global", "g", false, "Run only hooks in global ~/.claude/settings.json")
+	hooksSimulateCmd.Flags().BoolVarP(&hooksSimulateLocal, "local", "l", false, "Run only hooks in local .claude/settings.json")
+	hooksSimulateCmd.MarkFlagsMutuallyExclusive("global", "local")
+	_ = hooksSimulateCmd.MarkFlagFilename("fixture", "json")
+	_ = hooksSimulateCmd.RegisterFlagCompletionFunc("event", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return hook.EventTypeNames(), cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+// simulatedRule is the outcome of one hook rule on a payload
+type simulatedRule struct {
+	Hook     string               `json:"hook"`
+	Scope    PathScope            `json:"scope"`
+	Matcher  string               `json:"matcher"`
+	Decision hook.Decision        `json:"decision"`
+	Commands []hook.CommandResult `json:"commands"`
+}
+
+// simulatedFixture is the outcome of the hooks on one recorded payload
+type simulatedFixture struct {
+	Fixture  string          `json:"fixture"` // File, with the payload's position for arrays
+	Event    hook.EventType  `json:"event"`
+	Tool     string          `json:"tool,omitempty"`
+	Rules    []simulatedRule `json:"rules"`
+	Decision hook.Decision   `json:"decision"`
+	Expect   hook.Decision   `json:"expect,omitempty"`
+	Passed   bool            `json:"passed"`
+}
+
+// scopedHook is a hook with the scope of the settings.json it is in
+type scopedHook struct {
+	scope PathScope
+	hook  *hook.Hook
+}
+
+func runHooksSimulate(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+	if len(hooksSimulateFixtures) == 0 {
+		return usageError(cmd, errors.New("--fixture is required"))
+	}
+
+	var defaultEvent hook.EventType
+	if hooksSimulateEvent != "" {
+		et, err := hook.ParseEventType(hooksSimulateEvent)
+		if err != nil {
+			return validationErrorf("%v", err)
+		}
+		defaultEvent = et
+	}
+
+	files, err := fixtureFiles(hooksSimulateFixtures)
+	if err != nil {
+		return err
+	}
+
+	var scopes []PathScope
+	switch {
+	case hooksSimulateGlobal:
+		scopes = []PathScope{ScopeGlobal}
+	case hooksSimulateLocal:
+		scopes = []PathScope{ScopeLocal}
+	default:
+		scopes = []PathScope{ScopeGlobal}
+		if GetLocalSettingsPath() != "" {
+			scopes = append(scopes, ScopeLocal)
+		}
+	}
+	hooksByScope := make(map[PathScope][]*hook.Hook)
+	for _, scope := range scopes {
+		hooks, err := hook.NewStore(GetSettingsPathByScope(scope)).List()
+		if err != nil {
+			return fmt.Errorf("failed to list hooks in %s: %w", ScopeDescription(scope), err)
+		}
+		hooksByScope[scope] = hooks
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	var results []simulatedFixture
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read fixture: %w", err)
+		}
+		fixtures, err := hook.ParseFixtures(data)
+		if err != nil {
+			return validationErrorf("%s: %v", file, err)
+		}
+
+		for i, f := range fixtures {
+			name := file
+			if len(fixtures) > 1 {
+				name = fmt.Sprintf("%s[%d]", file, i)
+			}
+			if f.EventType == "" {
+				f.EventType = defaultEvent
+			}
+			if f.EventType == "" {
+				return validationErrorf("%s: payload has no hook_event_name (use --event)", name)
+			}
+			if hook.IsToolEvent(f.EventType) && f.ToolName == "" {
+				return validationErrorf("%s: %s payload has no tool_name", name, f.EventType)
+			}
+
+			var matched []scopedHook
+			for _, scope := range scopes {
+				for _, h := range hook.MatchingHooks(hooksByScope[scope], f.EventType, f.ToolName) {
+					matched = append(matched, scopedHook{scope: scope, hook: h})
+				}
+			}
+
+			dir := wd
+			if info, err := os.Stat(f.Cwd); f.Cwd != "" && err == nil && info.IsDir() {
+				dir = f.Cwd
+			}
+			results = append(results, simulateFixture(cmd, name, f, matched, dir))
+		}
+	}
+
+	if hooksSimulateJSON {
+		output, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(output))
+	} else {
+		printSimulation(results)
+	}
+
+	failed := 0
+	for _, r := range results {
+		if !r.Passed {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return validationErrorf("%d of %d fixture(s) did not produce the expected decision", failed, len(results))
+	}
+	return nil
+}
+
+// fixtureFiles expands the --fixture arguments, replacing directories with the
+// .json files in them
+func fixtureFiles(args []string) ([]string, error) {
+	var files []string
+	for _, arg := range args {
+		info, err := os.Stat(arg)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil, notFoundErrorf("fixture not found: %s", arg)
+			}
+			return nil, fmt.Errorf("failed to read fixture: %w", err)
+		}
+		if !info.IsDir() {
+			files = append(files, arg)
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(arg, "*.json"))
+		if err != nil {
+			return nil, err
+		}
+		if len(matches) == 0 {
+			return nil, notFoundErrorf("no .json fixtures in %s", arg)
+		}
+		sort.Strings(matches)
+		files = append(files, matches...)
+	}
+	return files, nil
+}
+
+// simulateFixture runs the commands of the matched hooks on a payload, in order
+func simulateFixture(cmd *cobra.Command, name string, f hook.Fixture, matched []scopedHook, dir string) simulatedFixture {
+	result := simulatedFixture{
+		Fixture:  name,
+		Event:    f.EventType,
+		Tool:     f.ToolName,
+		Rules:    []simulatedRule{},
+		Decision: hook.DecisionAllow,
+		Expect:   f.Expect,
+	}
+	for _, m := range matched {
+		rule := simulatedRule{Hook: m.hook.Name, Scope: m.scope, Matcher: m.hook.Matcher, Decision: hook.DecisionAllow}
+		for _, command := range m.hook.Commands {
+			run := hook.RunCommand(cmd.Context(), command, f.Payload, dir, hooksSimulateTimeout)
+			rule.Commands = append(rule.Commands, run)
+			rule.Decision = hook.Stronger(rule.Decision, run.Decision)
+		}
+		result.Rules = append(result.Rules, rule)
+		result.Decision = hook.Stronger(result.Decision, rule.Decision)
+	}
+	result.Passed = f.Expect == "" || f.Expect == result.Decision
+	return result
+}
+
+// printSimulation prints each fixture's rules with their commands and output
+func printSimulation(results []simulatedFixture) {
+	for i, r := range results {
+		if i > 0 {
+			fmt.Println()
+		}
+		target := string(r.Event)
+		if r.Tool != "" {
+			target += " " + r.Tool
+		}
+		fmt.Printf("%s: %s\n", r.Fixture, target)
+
+		if len(r.Rules) == 0 {
+			fmt.Println("  No hooks match")
+		}
+		for n, rule := range r.Rules {
+			fmt.Printf("  %d. %s (%s)  %s\n", n+1, rule.Hook, rule.Scope, rule.Decision)
+			for _, c := range rule.Commands {
+				fmt.Printf("     $ %s\n", c.Command)
+				status := fmt.Sprintf("exit %d", c.ExitCode)
+				if c.ExitCode < 0 {
+					status = "failed"
+				}
+				fmt.Printf("     %s, %s, %s\n", status, c.Decision, c.Duration.Round(time.Millisecond))
+				if c.Reason != "" {
+					fmt.Printf("     reason: %s\n", indentOutput(c.Reason))
+				}
+				if c.Stdout != "" {
+					fmt.Printf("     stdout: %s\n", indentOutput(c.Stdout))
+				}
+				if c.Stderr != "" && c.Stderr != c.Reason {
+					fmt.Printf("     stderr: %s\n", indentOutput(c.Stderr))
+				}
+			}
+		}
+
+		switch {
+		case r.Expect == "":
+			fmt.Printf("Decision: %s\n", r.Decision)
+		case r.Passed:
+			fmt.Printf("Decision: %s (expected %s) ✓\n", r.Decision, r.Expect)
+		default:
+			fmt.Printf("Decision: %s (expected %s) ✗\n", r.Decision, r.Expect)
+		}
+	}
+
+	if len(results) > 1 {
+		passed := 0
+		for _, r := range results {
+			if r.Passed {
+				passed++
+			}
+		}
+		fmt.Printf("\n%d fixtures, %d passed, %d failed\n", len(results), passed, len(results)-passed)
+	}
+}
+
+// indentOutput aligns the continuation lines of command output under its label
+func indentOutput(s string) string {
+	return strings.ReplaceAll(s, "\n", "\n             ")
+}
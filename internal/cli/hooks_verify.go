@@ -0,0 +1,191 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/hook"
+	"github.com/itda-skills/jindo/internal/table"
+	"github.com/spf13/cobra"
+)
+
+var (
+	hooksVerifyGlobal bool
+	hooksVerifyLocal  bool
+	hooksVerifyAccept bool
+	hooksVerifyJSON   bool
+)
+
+var hooksVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Check that the scripts hooks run have not changed since their rules were created",
+	Long: `Check the scripts that hook commands run against the checksums pinned when
+their rules were created.
+
+When jd creates a hook rule ('jd hooks new', 'jd pkg install', 'jd project init',
+and so on), it records the SHA-256 of each script the command runs, such as
+~/.claude/hooks/fmt.sh or $CLAUDE_PROJECT_DIR/.claude/hooks/lint.py, in the
+"hooks_checksums" section of settings.json. Claude Code ignores that section.
+A script that changes afterwards, by hand, by another tool, or by
+'jd pkg update', runs new code without its rule changing; verify reports it:
+  ok        Unchanged since it was pinned
+  changed   Different from the pinned checksum
+  missing   Pinned, but the file is gone
+  unpinned  Run by a rule jd did not create, so there is nothing to compare
+
+Programs in $PATH directories (interpreters such as python3) are not pinned.
+
+Review the changed scripts, then run with --accept to pin their current
+content (and pin unpinned scripts, and forget missing ones).
+
+Without --global or --local, both the global and the project settings are
+checked. Exits with code 4 if a script changed or is missing.
+
+Examples:
+  jd hooks verify
+  jd hooks verify --global --accept
+  jd hooks verify --json`,
+	Args: cobra.NoArgs,
+	RunE: runHooksVerify,
+}
+
+func init() {
+	hooksCmd.AddCommand(hooksVerifyCmd)
+	hooksVerifyCmd.Flags().BoolVarP(&hooksVerifyGlobal, "global", "g", false, "Verify global ~/.claude/settings.json")
+	hooksVerifyCmd.Flags().BoolVarP(&hooksVerifyLocal, "local", "l", false, "Verify local .claude/settings.json")
+	hooksVerifyCmd.Flags().BoolVar(&hooksVerifyAccept, "accept", false, "Pin the current checksums of changed and unpinned scripts")
+	hooksVerifyCmd.Flags().BoolVar(&hooksVerifyJSON, "json", false, "Output in JSON format")
+	addNoTruncFlag(hooksVerifyCmd)
+}
+
+// hooksVerifyOutput represents JSON output for hooks verify with scope
+type hooksVerifyOutput struct {
+	Global []hook.ScriptCheck `json:"global,omitempty"`
+	Local  []hook.ScriptCheck `json:"local,omitempty"`
+}
+
+func runHooksVerify(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+
+	if err := ValidateScopeFlags(hooksVerifyGlobal, hooksVerifyLocal); err != nil {
+		return err
+	}
+
+	type scopeSettings struct {
+		label string
+		scope PathScope
+		path  string
+	}
+	var targets []scopeSettings
+	if !hooksVerifyLocal {
+		targets = append(targets, scopeSettings{"Global", ScopeGlobal, GetSettingsPathByScope(ScopeGlobal)})
+	}
+	switch {
+	case hooksVerifyLocal:
+		targets = append(targets, scopeSettings{"Local", ScopeLocal, GetSettingsPathByScope(ScopeLocal)})
+	case !hooksVerifyGlobal:
+		// The project may be the home directory, whose settings are the global ones
+		global, _ := basedir.Expand(GetSettingsPathByScope(ScopeGlobal))
+		if path := GetLocalSettingsPath(); path != "" && filepath.Clean(path) != filepath.Clean(global) {
+			targets = append(targets, scopeSettings{"Local", ScopeLocal, path})
+		}
+	}
+
+	var output hooksVerifyOutput
+	problems := 0
+	for _, target := range targets {
+		store := hook.NewStore(target.path)
+		checks, err := store.Verify()
+		if err != nil {
+			return fmt.Errorf("failed to verify hooks in %s: %w", target.path, err)
+		}
+
+		if hooksVerifyAccept {
+			var scripts []string
+			for _, c := range checks {
+				if c.Status != hook.PinOK {
+					scripts = append(scripts, c.Script)
+				}
+			}
+			if len(scripts) > 0 {
+				if err := store.Accept(scripts); err != nil {
+					return fmt.Errorf("failed to pin hook scripts in %s: %w", target.path, err)
+				}
+				if !hooksVerifyJSON {
+					fmt.Printf("✓ Pinned %d script(s) in %s\n", len(scripts), target.path)
+				}
+				if checks, err = store.Verify(); err != nil {
+					return fmt.Errorf("failed to verify hooks in %s: %w", target.path, err)
+				}
+			}
+		}
+
+		for _, c := range checks {
+			if c.Status == hook.PinChanged || c.Status == hook.PinMissing {
+				problems++
+			}
+		}
+		if target.scope == ScopeGlobal {
+			output.Global = checks
+		} else {
+			output.Local = checks
+		}
+		if !hooksVerifyJSON {
+			fmt.Printf("=== %s (%s) ===\n", target.label, target.path)
+			printScriptChecks(checks)
+			fmt.Println()
+		}
+	}
+
+	if hooksVerifyJSON {
+		data, err := json.MarshalIndent(output, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+	}
+
+	if problems > 0 {
+		if !hooksVerifyJSON {
+			fmt.Println("Review the changed scripts, then pin them with: jd hooks verify --accept")
+		}
+		return validationErrorf("%d hook script(s) changed or missing since they were pinned", problems)
+	}
+	if !hooksVerifyJSON {
+		fmt.Println("✓ No hook script changed since it was pinned.")
+	}
+	return nil
+}
+
+// printScriptChecks prints the scripts hooks run and how they compare with their pins
+func printScriptChecks(checks []hook.ScriptCheck) {
+	if len(checks) == 0 {
+		fmt.Println("No hook scripts found.")
+		return
+	}
+
+	t := newTable(
+		table.Column{Header: "STATUS"},
+		table.Column{Header: "SCRIPT", Max: 50},
+		table.Column{Header: "HOOKS", Max: 40, Wrap: true},
+	)
+	unpinned := 0
+	for _, c := range checks {
+		hooks := strings.Join(c.Hooks, ", ")
+		if c.Disabled {
+			hooks += " (disabled)"
+		}
+		t.AddRow(c.Status, c.Script, hooks)
+		if c.Status == hook.PinUnpinned {
+			unpinned++
+		}
+	}
+	t.Render(os.Stdout)
+	if unpinned > 0 {
+		fmt.Printf("\n%d script(s) have no checksum; pin them with: jd hooks verify --accept\n", unpinned)
+	}
+}
@@ -0,0 +1,25 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var indexCmd = &cobra.Command{
+	Use:   "index",
+	Short: "Manage the full-text index of installed and repository packages",
+	Long: `Manage the full-text index of installed skills, commands, and agents and of
+the packages in registered repositories.
+
+The index is kept in the jd data directory (` + packageIndexFile + `) and powers
+'jd search', 'jd pkg search', completion of 'jd pkg install', and the filter of
+'jd pkg browse' (press /). It is updated incrementally: pkg install, update, and
+uninstall and repo add, update, and remove reindex only the installed resources
+or repositories that changed.
+
+Use 'jd index rebuild' if the index gets out of date or corrupt. To disable it:
+  jd config set ` + indexEnabledKey + ` false`,
+}
+
+func init() {
+	rootCmd.AddCommand(indexCmd)
+}
@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/itda-skills/jindo/internal/index"
+	"github.com/spf13/cobra"
+)
+
+var indexRebuildCmd = &cobra.Command{
+	Use:   "rebuild",
+	Short: "Rebuild the full-text index from scratch",
+	Long: `Discard the full-text index and reindex the installed skills, commands, and
+agents and every registered repository.
+
+Examples:
+  jd index rebuild`,
+	Args: cobra.NoArgs,
+	RunE: runIndexRebuild,
+}
+
+func init() {
+	indexCmd.AddCommand(indexRebuildCmd)
+}
+
+func runIndexRebuild(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+	if !indexEnabled() {
+		return validationErrorf("indexing is disabled (set %s to true to enable it)", indexEnabledKey)
+	}
+
+	path, err := packageIndexPath()
+	if err != nil {
+		return fmt.Errorf("failed to locate index: %w", err)
+	}
+	ix := index.New(path)
+	refreshIndex(ix)
+	if err := ix.Save(); err != nil {
+		return fmt.Errorf("failed to save index: %w", err)
+	}
+
+	fmt.Printf("Indexed %d documents from %d sources (%s)\n", len(ix.Docs()), len(ix.Sources()), path)
+	return nil
+}
@@ -0,0 +1,232 @@
+package cli
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/index"
+	"github.com/itda-skills/jindo/internal/pkg/repo"
+	"github.com/itda-skills/jindo/internal/search"
+	"github.com/itda-skills/jindo/pkg/config"
+)
+
+// indexEnabledKey disables the package index when set to false; search,
+// completions, and the browse filter then scan files as they are used
+const indexEnabledKey = "index.enabled"
+
+// packageIndexFile is the package index, in the jd data directory
+const packageIndexFile = "cache/index.json"
+
+// maxIndexedFileSize is the largest repository file whose content is indexed
+const maxIndexedFileSize = 1 << 20
+
+// indexEnabled reports whether index.enabled is not set to false
+func indexEnabled() bool {
+	cfg, err := config.Load()
+	if err != nil {
+		return true
+	}
+	return cfg.GetBool(indexEnabledKey, true)
+}
+
+// packageIndexPath returns the path of the package index
+func packageIndexPath() (string, error) {
+	dataDir, err := basedir.Expand(basedir.DataDir())
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dataDir, packageIndexFile), nil
+}
+
+// openIndex opens the package index. A corrupt index is started over, and its
+// sources are reindexed by the next refresh.
+func openIndex() (*index.Index, error) {
+	path, err := packageIndexPath()
+	if err != nil {
+		return nil, err
+	}
+	ix, err := index.Open(path)
+	if errors.Is(err, index.ErrCorrupt) {
+		fmt.Fprintln(os.Stderr, "Warning: package index is corrupt; rebuilding it")
+		return ix, nil
+	}
+	return ix, err
+}
+
+// loadIndex opens the package index and brings it up to date, or returns nil if
+// indexing is disabled or the index cannot be used
+func loadIndex() *index.Index {
+	if !indexEnabled() {
+		return nil
+	}
+	ix, err := openIndex()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to open package index: %v\n", err)
+		return nil
+	}
+	if refreshIndex(ix) {
+		if err := ix.Save(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to save package index: %v\n", err)
+		}
+	}
+	return ix
+}
+
+// syncIndex updates the package index after packages or repositories change.
+// Failures only warn, since the index can always be rebuilt.
+func syncIndex() {
+	loadIndex()
+}
+
+// refreshIndex reindexes the installed resources and registered repositories whose
+// fingerprint changed, drops repositories no longer registered, and reports
+// whether the index changed
+func refreshIndex(ix *index.Index) bool {
+	changed := false
+
+	if docs, err := installedDocuments(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to index installed resources: %v\n", err)
+	} else if fp := installedFingerprint(docs); !indexed(ix, index.SourceInstalled, fp) {
+		ix.Set(index.SourceInstalled, fp, installedEntries(docs))
+		changed = true
+	}
+
+	store := repo.NewStore(basedir.DataDir())
+	repos, err := store.List()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to index repositories: %v\n", err)
+		return changed
+	}
+	registered := make(map[string]bool)
+	for _, r := range repos {
+		source := index.RepoSource(r.Namespace)
+		registered[source] = true
+		fp := repoFingerprint(store, r)
+		if indexed(ix, source, fp) {
+			continue
+		}
+		entries, err := repoEntries(store, r.Namespace)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to index repository '%s': %v\n", r.Namespace, err)
+			continue
+		}
+		ix.Set(source, fp, entries)
+		changed = true
+	}
+	for source := range ix.Sources() {
+		if index.RepoNamespace(source) != "" && !registered[source] {
+			ix.Remove(source)
+			changed = true
+		}
+	}
+	return changed
+}
+
+// indexed reports whether a source is indexed with fingerprint; an empty
+// fingerprint is never up to date
+func indexed(ix *index.Index, source, fingerprint string) bool {
+	fp, ok := ix.Fingerprint(source)
+	return ok && fp != "" && fp == fingerprint
+}
+
+// installedDocuments returns the global skills, commands, and agents
+func installedDocuments() ([]search.Document, error) {
+	var docs []search.Document
+	for _, list := range []func() ([]search.Document, error){skillDocuments, commandDocuments, agentDocuments} {
+		listed, err := list()
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, listed...)
+	}
+	return docs, nil
+}
+
+// installedFingerprint hashes the paths, sizes, and modification times of the
+// installed resources' files
+func installedFingerprint(docs []search.Document) string {
+	lines := make([]string, 0, len(docs))
+	for _, d := range docs {
+		line := d.Type + " " + d.Path
+		if info, err := os.Stat(d.Path); err == nil {
+			line += fmt.Sprintf(" %d %d", info.Size(), info.ModTime().UnixNano())
+		}
+		lines = append(lines, line)
+	}
+	sort.Strings(lines)
+	h := sha256.New()
+	for _, line := range lines {
+		fmt.Fprintln(h, line)
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// installedEntries returns the index entries of the installed resources
+func installedEntries(docs []search.Document) []index.Entry {
+	entries := make([]index.Entry, 0, len(docs))
+	for _, d := range docs {
+		content, _ := os.ReadFile(d.Path)
+		entries = append(entries, index.NewEntry(index.Doc{Type: d.Type, Name: d.Name, Path: d.Path, Description: d.Description}, string(content)))
+	}
+	return entries
+}
+
+// repoFingerprint identifies the packages a repository's clone has: its commit,
+// root, and layout. Linked checkouts change without commits, so they have none.
+func repoFingerprint(store *repo.Store, r repo.RepoConfig) string {
+	if r.Link {
+		return ""
+	}
+	head, err := store.Head(r.Namespace)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%s %s %v", head, r.Root, r.Layout)
+}
+
+// repoEntries returns the index entries of a repository's packages
+func repoEntries(store *repo.Store, namespace string) ([]index.Entry, error) {
+	items, err := store.Browse(namespace, "")
+	if err != nil {
+		return nil, err
+	}
+	root, err := store.PackageRoot(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]index.Entry, 0, len(items))
+	for _, item := range items {
+		doc := index.Doc{Namespace: namespace, Type: string(item.Type), Name: item.Name, Path: item.Path, Description: item.Description}
+		entries = append(entries, index.NewEntry(doc, packageContent(filepath.Join(root, filepath.FromSlash(item.Path)), item.Type)))
+	}
+	return entries, nil
+}
+
+// packageContent returns the text of a package's main file (SKILL.md for skills),
+// or "" for large and binary files
+func packageContent(path string, pkgType repo.PackageType) string {
+	if pkgType == repo.TypeSkill {
+		for _, name := range []string{"SKILL.md", "skill.md"} {
+			if _, err := os.Stat(filepath.Join(path, name)); err == nil {
+				path = filepath.Join(path, name)
+				break
+			}
+		}
+	}
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() || info.Size() > maxIndexedFileSize {
+		return ""
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || bytes.IndexByte(data, 0) >= 0 {
+		return ""
+	}
+	return string(data)
+}
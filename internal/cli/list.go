@@ -1,34 +1,126 @@
 package cli
 
 import (
-	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/itda-skills/jindo/internal/agent"
+	"github.com/itda-skills/jindo/internal/basedir"
 	"github.com/itda-skills/jindo/internal/command"
 	"github.com/itda-skills/jindo/internal/hook"
+	"github.com/itda-skills/jindo/internal/pkg/pkgmgr"
+	"github.com/itda-skills/jindo/internal/pkg/repo"
 	"github.com/itda-skills/jindo/internal/skill"
+	"github.com/itda-skills/jindo/internal/sorting"
+	"github.com/itda-skills/jindo/internal/table"
+	"github.com/mattn/go-runewidth"
 	"github.com/spf13/cobra"
 )
 
-var listJSON bool
+var (
+	listJSON      bool
+	listTag       string
+	listAllScopes bool
+	listTypes     []string
+	listScope     string
+	listSearch    string
+	listWide      bool
+	listSort      string
+)
 
 var listCmd = &cobra.Command{
 	Use:     "list",
 	Aliases: []string{"l", "ls"},
 	Short:   "List all skills, agents, commands, and hooks",
-	Long:    `List all configured skills, agents, commands, and hooks from ~/.claude/ and .claude/ directories.`,
-	RunE:    runList,
+	Long: `List all configured skills, agents, commands, and hooks from ~/.claude/ and .claude/ directories.
+
+Each scope is listed as one line per resource, with a colored type badge
+(colors are left out when the output is not a terminal or NO_COLOR is set).
+Use --wide for a full table per resource type instead.
+
+Favorites (see 'jd favorites') are listed first with a ★ marker.
+
+Installed packages are listed under their original name, with the namespace
+after it: web-fetch (affa-ever) for affa-ever--web-fetch. Where that would be
+ambiguous, because another package or one of your resources of the same type
+has the name, the full name is listed instead. --wide, --json, and the
+per-type lists show the full names commands take; set pkg.naming.full_names
+to list those here too.
+
+Filters, which can be combined:
+  --type skill,hook     Only these types (skill, agent, command, hook)
+  --scope local         Only this scope: global, local, or a named scope
+  --tag deploy          Only skills, agents, and commands with this tag
+  --search review       Only resources whose name, description, tags, or
+                        hook commands contain this text (case-insensitive)
+
+Resources are grouped by type, and listed by name within each type. Use --sort
+to list them across types instead:
+  name       By name
+  type       By type, then name
+  installed  Most recently installed first (resources that are not from a
+             package by when their file was written)
+  updated    Most recently changed first
+Names are compared ignoring case, with numbers by value, in the collation of
+your locale (LC_ALL, LC_COLLATE, or LANG). --json output, which is always
+grouped by type, is in the same order within each type.
+
+Use --all-scopes to also list every named scope declared in config:
+  jd config set jindo.scopes.app-a ~/work/monorepo/packages/app-a
+  jd list --all-scopes`,
+	Example: `  jd list
+  jd list --type skill --search go
+  jd list --scope local --wide
+  jd list --sort updated`,
+	Args: cobra.NoArgs,
+	RunE: runList,
 }
 
 func init() {
 	rootCmd.AddCommand(listCmd)
 	listCmd.Flags().BoolVar(&listJSON, "json", false, "Output in JSON format")
+	addOutputFlags(listCmd)
+	addNoTruncFlag(listCmd)
+	listCmd.Flags().StringVar(&listTag, "tag", "", "Show only skills, agents, and commands with this tag")
+	listCmd.Flags().BoolVar(&listAllScopes, "all-scopes", false, "Also list resources from named scopes in config ("+scopesConfigKey+")")
+	listCmd.Flags().StringSliceVarP(&listTypes, "type", "t", nil, "Show only these types: skill, agent, command, hook (repeatable)")
+	listCmd.Flags().StringVar(&listScope, "scope", "", "Show only this scope: global, local, or a named scope")
+	listCmd.Flags().StringVarP(&listSearch, "search", "s", "", "Show only resources containing this text")
+	listCmd.Flags().BoolVarP(&listWide, "wide", "w", false, "Show a full table per resource type")
+	listCmd.Flags().StringVar(&listSort, "sort", "", "Sort by name, type, installed, or updated across types (default: grouped by type)")
+	_ = listCmd.RegisterFlagCompletionFunc("type", cobra.FixedCompletions(listTypeNames, cobra.ShellCompDirectiveNoFileComp))
+	_ = listCmd.RegisterFlagCompletionFunc("sort", cobra.FixedCompletions(sorting.Orders, cobra.ShellCompDirectiveNoFileComp))
+	_ = listCmd.RegisterFlagCompletionFunc("scope", listScopeCompletion)
+}
+
+// Resource types jd list shows, in the order they are grouped
+const (
+	listTypeSkill   = "skill"
+	listTypeAgent   = "agent"
+	listTypeCommand = "command"
+	listTypeHook    = "hook"
+)
+
+var listTypeNames = []string{listTypeSkill, listTypeAgent, listTypeCommand, listTypeHook}
+
+// ANSI colors of the type badges
+var listTypeColors = map[string]string{
+	listTypeSkill:   "\x1b[32m", // Green
+	listTypeAgent:   "\x1b[35m", // Magenta
+	listTypeCommand: "\x1b[36m", // Cyan
+	listTypeHook:    "\x1b[33m", // Yellow
 }
 
 type listItem struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Tags        []string `json:"tags,omitempty"`
+	Favorite    bool     `json:"favorite,omitempty"`
 }
 
 type scopedListOutput struct {
@@ -39,157 +131,629 @@ type scopedListOutput struct {
 }
 
 type listOutput struct {
-	Global scopedListOutput `json:"global"`
-	Local  scopedListOutput `json:"local,omitempty"`
+	Global *scopedListOutput           `json:"global,omitempty"`
+	Local  *scopedListOutput           `json:"local,omitempty"`
+	Scopes map[string]scopedListOutput `json:"scopes,omitempty"`
+}
+
+// scopeItems holds the resources found in one .claude directory
+type scopeItems struct {
+	skills       []*skill.Skill
+	agents       []*agent.Agent
+	commands     []*command.Command
+	hooks        []*hook.Hook
+	settingsPath string // Where the hooks are, whose changes date them
+}
+
+// loadScopeItems loads all resources from a .claude directory
+func loadScopeItems(claudeDir string) scopeItems {
+	var items scopeItems
+	items.skills, _ = skill.NewStore(filepath.Join(claudeDir, "skills")).List()
+	items.agents, _ = agent.NewStore(filepath.Join(claudeDir, "agents")).List()
+	items.commands, _ = command.NewStore(filepath.Join(claudeDir, "commands")).List()
+	items.settingsPath = filepath.Join(claudeDir, "settings.json")
+	items.hooks, _ = hook.NewStore(items.settingsPath).List()
+	return items
+}
+
+// filterByTag keeps only tagged resources; hooks have no tags, so they are excluded
+func (s *scopeItems) filterByTag(tag string) {
+	s.skills = filterSkillsByTag(s.skills, tag)
+	s.agents = filterAgentsByTag(s.agents, tag)
+	s.commands = filterCommandsByTag(s.commands, tag)
+	s.hooks = nil
+}
+
+// filterByType keeps only the resource types in types
+func (s *scopeItems) filterByType(types map[string]bool) {
+	if !types[listTypeSkill] {
+		s.skills = nil
+	}
+	if !types[listTypeAgent] {
+		s.agents = nil
+	}
+	if !types[listTypeCommand] {
+		s.commands = nil
+	}
+	if !types[listTypeHook] {
+		s.hooks = nil
+	}
+}
+
+// filterBySearch keeps only resources with text in their name, description,
+// tags, or, for hooks, event, matcher, and commands
+func (s *scopeItems) filterBySearch(text string) {
+	s.skills = filterList(s.skills, func(sk *skill.Skill) bool {
+		return containsFold(text, skillID(sk), sk.Name, sk.Description, strings.Join(sk.Tags, " "))
+	})
+	s.agents = filterList(s.agents, func(a *agent.Agent) bool {
+		return containsFold(text, a.Name, a.Description, strings.Join(a.Tags, " "))
+	})
+	s.commands = filterList(s.commands, func(c *command.Command) bool {
+		return containsFold(text, c.Name, c.Description, strings.Join(c.Tags, " "))
+	})
+	s.hooks = filterList(s.hooks, func(h *hook.Hook) bool {
+		return containsFold(text, h.Name, string(h.EventType), h.Matcher, strings.Join(h.Commands, " "))
+	})
+}
+
+// filterList returns the items keep reports true for
+func filterList[T any](items []T, keep func(T) bool) []T {
+	var filtered []T
+	for _, item := range items {
+		if keep(item) {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered
+}
+
+// containsFold reports whether any of the fields contains text, ignoring case
+func containsFold(text string, fields ...string) bool {
+	text = strings.ToLower(text)
+	for _, f := range fields {
+		if strings.Contains(strings.ToLower(f), text) {
+			return true
+		}
+	}
+	return false
+}
+
+// skillID returns the ID of a skill: its directory name, which other commands take
+func skillID(s *skill.Skill) string {
+	return filepath.Base(filepath.Dir(s.Path))
+}
+
+// sortFavoritesFirst moves favorites to the front of each resource list
+func (s *scopeItems) sortFavoritesFirst() {
+	sortFavoritesFirst(s.skills, skillID)
+	sortFavoritesFirst(s.agents, func(a *agent.Agent) string { return a.Name })
+	sortFavoritesFirst(s.commands, func(c *command.Command) string { return c.Name })
+}
+
+// order sorts each resource list in a sort order (see sorting.Orders), or, for
+// the default order "", by name with favorites first
+func (s *scopeItems) order(order string) {
+	if order == "" {
+		s.sortBy(sorting.Name)
+		s.sortFavoritesFirst()
+		return
+	}
+	s.sortBy(order)
+}
+
+// sortBy sorts each resource list in a sort order; within a list, type is by name
+func (s *scopeItems) sortBy(order string) {
+	hooksChanged := modTime(s.settingsPath)
+	sortList(s.skills, order, skillID, func(sk *skill.Skill) time.Time { return modTime(sk.Path) }, func(sk *skill.Skill) time.Time {
+		return resourceInstalledAt(listTypeSkill, skillID(sk), sk.Path)
+	})
+	sortList(s.agents, order, func(a *agent.Agent) string { return a.Name }, func(a *agent.Agent) time.Time { return modTime(a.Path) }, func(a *agent.Agent) time.Time {
+		return resourceInstalledAt(listTypeAgent, a.Name, a.Path)
+	})
+	sortList(s.commands, order, func(c *command.Command) string { return c.Name }, func(c *command.Command) time.Time { return modTime(c.Path) }, func(c *command.Command) time.Time {
+		return resourceInstalledAt(listTypeCommand, c.Name, c.Path)
+	})
+	sortList(s.hooks, order, func(h *hook.Hook) string { return h.Name }, func(*hook.Hook) time.Time { return hooksChanged }, func(*hook.Hook) time.Time { return hooksChanged })
+}
+
+// sortList sorts items by name, last change, or install time (newest first),
+// with ties by name
+func sortList[T any](items []T, order string, nameOf func(T) string, changed, installed func(T) time.Time) {
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		switch order {
+		case sorting.Updated:
+			if ta, tb := changed(a), changed(b); !ta.Equal(tb) {
+				return ta.After(tb)
+			}
+		case sorting.Installed:
+			if ta, tb := installed(a), installed(b); !ta.Equal(tb) {
+				return ta.After(tb)
+			}
+		}
+		return sorting.Less(nameOf(a), nameOf(b))
+	})
+}
+
+var (
+	packageInstallTimesOnce sync.Once
+	packageInstallTimes     map[string]time.Time // By list type and installed name
+)
+
+// resourceInstalledAt returns when a resource was installed, if it is from a
+// package, or else when its file was last written
+func resourceInstalledAt(kind, name, path string) time.Time {
+	packageInstallTimesOnce.Do(func() {
+		packageInstallTimes = make(map[string]time.Time)
+		packages, _ := pkgmgr.NewManager(basedir.DataDir()).List()
+		for _, pkg := range packages {
+			packageInstallTimes[string(pkg.Type)+"\x00"+pkg.Name] = pkg.InstalledAt
+		}
+	})
+	if t, ok := packageInstallTimes[kind+"\x00"+name]; ok {
+		return t
+	}
+	return modTime(path)
+}
+
+// modTime returns when a file was last changed, or the zero time if unknown
+func modTime(path string) time.Time {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
+
+// isEmpty reports whether the scope has no resources
+func (s *scopeItems) isEmpty() bool {
+	return len(s.skills) == 0 && len(s.agents) == 0 && len(s.commands) == 0 && len(s.hooks) == 0
+}
+
+// listSection is a scope jd list shows
+type listSection struct {
+	name  string // "global", "local", or a named scope
+	title string
+	items scopeItems
+	local bool // A project scope, listed only if it has resources
 }
 
 func runList(cmd *cobra.Command, _ []string) error {
 	cmd.SilenceUsage = true
 
-	// Get global items
-	globalSkillStore := skill.NewStore(GetGlobalPath("skills"))
-	globalAgentStore := agent.NewStore(GetGlobalPath("agents"))
-	globalCommandStore := command.NewStore(GetGlobalPath("commands"))
-	globalHookStore := hook.NewStore(GetSettingsPathByScope(ScopeGlobal))
+	types, err := listTypeFilter()
+	if err != nil {
+		return usageError(cmd, err)
+	}
+	if listSort, err = sorting.ParseOrder(listSort); err != nil {
+		return usageError(cmd, err)
+	}
 
-	globalSkills, _ := globalSkillStore.List()
-	globalAgents, _ := globalAgentStore.List()
-	globalCommands, _ := globalCommandStore.List()
-	globalHooks, _ := globalHookStore.List()
+	sections, err := loadListSections()
+	if err != nil {
+		return err
+	}
+	// Short names are decided before filtering, so they do not change with the filters
+	var names shortNames
+	if !listJSON && !formattedOutput() && !listWide {
+		names = listShortNames(sections)
+	}
 
-	// Get local items (if .claude exists)
-	var localSkills []*skill.Skill
-	var localAgents []*agent.Agent
-	var localCommands []*command.Command
-	var localHooks []*hook.Hook
+	for i := range sections {
+		items := &sections[i].items
+		if listTag != "" {
+			items.filterByTag(listTag)
+		}
+		if types != nil {
+			items.filterByType(types)
+		}
+		if listSearch != "" {
+			items.filterBySearch(listSearch)
+		}
+		items.order(listSort)
+	}
 
-	if localPath := GetLocalPath("skills"); localPath != "" {
-		localSkillStore := skill.NewStore(localPath)
-		localSkills, _ = localSkillStore.List()
+	if listJSON || formattedOutput() {
+		return printListJSON(sections)
 	}
-	if localPath := GetLocalPath("agents"); localPath != "" {
-		localAgentStore := agent.NewStore(localPath)
-		localAgents, _ = localAgentStore.List()
+
+	printed := 0
+	for _, s := range sections {
+		if s.local && s.items.isEmpty() {
+			continue
+		}
+		if printed > 0 {
+			fmt.Println()
+		}
+		printed++
+		if listWide {
+			printScopeSection(s.title, s.items, !s.local)
+		} else {
+			printCompactSection(s.title, s.items, names)
+		}
 	}
-	if localPath := GetLocalPath("commands"); localPath != "" {
-		localCommandStore := command.NewStore(localPath)
-		localCommands, _ = localCommandStore.List()
+	if printed == 0 {
+		fmt.Println("No resources found.")
 	}
-	if localSettingsPath := GetLocalSettingsPath(); localSettingsPath != "" {
-		localHookStore := hook.NewStore(localSettingsPath)
-		localHooks, _ = localHookStore.List()
+	return nil
+}
+
+// listTypeFilter parses --type into the set of types to show, or nil for all
+func listTypeFilter() (map[string]bool, error) {
+	return parseListTypes(listTypes)
+}
+
+// parseListTypes parses resource types (skill or skills, agent, command, hook)
+// into a set, or nil for all
+func parseListTypes(values []string) (map[string]bool, error) {
+	if len(values) == 0 {
+		return nil, nil
 	}
+	types := make(map[string]bool)
+	for _, t := range values {
+		t = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(t)), "s")
+		switch t {
+		case listTypeSkill, listTypeAgent, listTypeCommand, listTypeHook:
+			types[t] = true
+		default:
+			return nil, fmt.Errorf("invalid type: %s (use: skill, agent, command, hook)", t)
+		}
+	}
+	return types, nil
+}
 
-	hasLocal := len(localSkills) > 0 || len(localAgents) > 0 || len(localCommands) > 0 || len(localHooks) > 0
+// loadListSections loads the scopes selected by --scope and --all-scopes
+func loadListSections() ([]listSection, error) {
+	return loadScopeSections(listScope, listAllScopes)
+}
 
-	if listJSON {
-		return printListJSON(globalSkills, globalAgents, globalCommands, globalHooks, localSkills, localAgents, localCommands, localHooks)
+// loadScopeSections loads the global and local scopes, only the scope named
+// scope if it is set, and with allScopes, the named scopes from the config
+func loadScopeSections(scope string, allScopes bool) ([]listSection, error) {
+	var sections []listSection
+	if scope == "" || scope == string(ScopeGlobal) {
+		sections = append(sections, listSection{
+			name:  string(ScopeGlobal),
+			title: fmt.Sprintf("=== Global (%s/) ===", basedir.ClaudeDir()),
+			items: loadScopeItems(basedir.ClaudeDir()),
+		})
 	}
 
-	// Print Global section
-	fmt.Println("=== Global (~/.claude/) ===")
-	fmt.Println()
+	var localRoot string
+	if LocalClaudeDirExists() {
+		localRoot, _ = ProjectRoot()
+	}
+	if scope == string(ScopeLocal) && localRoot == "" {
+		return nil, notFoundErrorf("no project .claude directory found")
+	}
+	if localRoot != "" && (scope == "" || scope == string(ScopeLocal)) {
+		title := "=== Local (.claude/) ==="
+		if projectRootSelected() {
+			title = fmt.Sprintf("=== Local (%s/) ===", filepath.Join(localRoot, localClaudeDir))
+		}
+		sections = append(sections, listSection{
+			name:  string(ScopeLocal),
+			title: title,
+			items: loadScopeItems(filepath.Join(localRoot, localClaudeDir)),
+			local: true,
+		})
+	}
 
-	fmt.Println("Skills:")
-	if len(globalSkills) == 0 {
-		fmt.Println("  No skills found.")
-	} else {
-		printSkillsTable(globalSkills)
+	// Named scopes from config: all with --all-scopes, or the one --scope names
+	if !allScopes && (scope == "" || scope == string(ScopeGlobal) || scope == string(ScopeLocal)) {
+		return sections, nil
 	}
-	fmt.Println()
+	found := false
+	for _, s := range configuredScopes() {
+		if scope != "" && scope != string(ScopeGlobal) && scope != string(ScopeLocal) {
+			if s.Name != scope {
+				continue
+			}
+			found = true
+		} else if s.Root == localRoot {
+			continue
+		}
+		claudeDir := filepath.Join(s.Root, localClaudeDir)
+		if info, err := os.Stat(claudeDir); err != nil || !info.IsDir() {
+			continue
+		}
+		sections = append(sections, listSection{
+			name:  s.Name,
+			title: fmt.Sprintf("=== Scope %s (%s/) ===", s.Name, claudeDir),
+			items: loadScopeItems(claudeDir),
+			local: true,
+		})
+	}
+	if scope != "" && scope != string(ScopeGlobal) && scope != string(ScopeLocal) && !found {
+		return nil, notFoundErrorf("scope not found: %s (use global, local, or a scope in %s)", scope, scopesConfigKey)
+	}
+	return sections, nil
+}
 
-	fmt.Println("Agents:")
-	if len(globalAgents) == 0 {
-		fmt.Println("  No agents found.")
-	} else {
-		printAgentsTable(globalAgents)
+// listScopeCompletion completes --scope with global, local, and the named scopes
+func listScopeCompletion(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	names := []string{string(ScopeGlobal), string(ScopeLocal)}
+	for _, s := range configuredScopes() {
+		names = append(names, s.Name)
 	}
-	fmt.Println()
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
 
-	fmt.Println("Commands:")
-	if len(globalCommands) == 0 {
-		fmt.Println("  No commands found.")
-	} else {
-		printCommandsTable(globalCommands)
+// listShortNames returns the short names of the installed packages among the
+// resources of all sections
+func listShortNames(sections []listSection) shortNames {
+	listed := make(map[repo.PackageType][]string)
+	for _, s := range sections {
+		for _, sk := range s.items.skills {
+			listed[repo.TypeSkill] = append(listed[repo.TypeSkill], skillID(sk))
+		}
+		for _, a := range s.items.agents {
+			listed[repo.TypeAgent] = append(listed[repo.TypeAgent], a.Name)
+		}
+		for _, c := range s.items.commands {
+			listed[repo.TypeCommand] = append(listed[repo.TypeCommand], c.Name)
+		}
 	}
+	return loadShortNames(listed)
+}
 
-	fmt.Println()
+// listEntry is a resource on one line of the compact listing
+type listEntry struct {
+	kind        string
+	name        string
+	namespace   string // Shown after the name when it is a package's short name
+	description string
+	favorite    bool
+}
 
-	fmt.Println("Hooks:")
-	if len(globalHooks) == 0 {
-		fmt.Println("  No hooks found.")
-	} else {
-		printHooksTable(globalHooks)
+// entries returns the resources of a scope as listed, grouped by type unless
+// sorted with --sort name, installed, or updated, in which case types are
+// merged in that order. Installed packages are listed under their short names,
+// if they have one in names.
+func (s *scopeItems) entries(names shortNames) []listEntry {
+	var entries []listEntry
+	var changed, installed []time.Time
+	add := func(kind, name, description string, favorite bool, path string, modified time.Time) {
+		entry := listEntry{kind: kind, name: name, description: description, favorite: favorite}
+		if short, ok := names.get(repo.PackageType(kind), name); ok {
+			entry.name, entry.namespace = short.Name, short.Namespace
+		}
+		entries = append(entries, entry)
+		changed = append(changed, modified)
+		if listSort == sorting.Installed {
+			installed = append(installed, resourceInstalledAt(kind, name, path))
+		}
+	}
+	for _, sk := range s.skills {
+		add(listTypeSkill, skillID(sk), sk.Description, isFavorite(skillID(sk)), sk.Path, modTime(sk.Path))
+	}
+	for _, a := range s.agents {
+		add(listTypeAgent, a.Name, a.Description, isFavorite(a.Name), a.Path, modTime(a.Path))
+	}
+	for _, c := range s.commands {
+		add(listTypeCommand, c.Name, c.Description, isFavorite(c.Name), c.Path, modTime(c.Path))
+	}
+	hooksChanged := modTime(s.settingsPath)
+	for _, h := range s.hooks {
+		add(listTypeHook, h.Name, fmt.Sprintf("%s %s: %s", h.EventType, h.Matcher, strings.Join(h.Commands, "; ")), false, s.settingsPath, hooksChanged)
 	}
 
-	// Print Local section only if has items
-	if hasLocal {
-		fmt.Println()
-		fmt.Println("=== Local (.claude/) ===")
-		fmt.Println()
+	// Grouped by type, each type is already in order
+	if listSort == "" || listSort == sorting.Type {
+		return entries
+	}
+	order := make([]int, len(entries))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := order[i], order[j]
+		switch listSort {
+		case sorting.Updated:
+			if !changed[a].Equal(changed[b]) {
+				return changed[a].After(changed[b])
+			}
+		case sorting.Installed:
+			if !installed[a].Equal(installed[b]) {
+				return installed[a].After(installed[b])
+			}
+		}
+		if c := sorting.Compare(entries[a].name, entries[b].name); c != 0 {
+			return c < 0
+		}
+		return sorting.Less(entries[a].namespace, entries[b].namespace)
+	})
+	sorted := make([]listEntry, len(entries))
+	for i, idx := range order {
+		sorted[i] = entries[idx]
+	}
+	return sorted
+}
 
-		if len(localSkills) > 0 {
-			fmt.Println("Skills:")
-			printSkillsTable(localSkills)
-			fmt.Println()
+// label returns the name an entry is listed under, with its namespace if it has one
+func (e listEntry) label() string {
+	if e.namespace == "" {
+		return e.name
+	}
+	return e.name + " (" + e.namespace + ")"
+}
+
+// printCompactSection prints a scope with one line per resource: a favorite
+// marker, a colored type badge, the name, and the description cut to the terminal
+func printCompactSection(title string, items scopeItems, names shortNames) {
+	fmt.Println(title)
+	entries := items.entries(names)
+	if len(entries) == 0 {
+		fmt.Println("  No resources found.")
+		return
+	}
+
+	color := isTerminal(os.Stdout) && os.Getenv("NO_COLOR") == ""
+	nameWidth := 0
+	for _, e := range entries {
+		nameWidth = max(nameWidth, runewidth.StringWidth(e.label()))
+	}
+	const badgeWidth = len(listTypeCommand)
+	width := table.TerminalWidth(os.Stdout)
+
+	for _, e := range entries {
+		marker := "  "
+		if e.favorite {
+			marker = "★ "
+		}
+		badge := fmt.Sprintf("%-*s", badgeWidth, e.kind)
+		if color {
+			badge = listTypeColors[e.kind] + badge + diffColorReset
+		}
+		name := runewidth.FillRight(e.label(), nameWidth)
+		if color && e.namespace != "" {
+			name = e.name + " " + listColorDim + "(" + e.namespace + ")" + diffColorReset + name[len(e.label()):]
 		}
+		line := fmt.Sprintf("%s%s  %s", marker, badge, name)
 
-		if len(localAgents) > 0 {
-			fmt.Println("Agents:")
-			printAgentsTable(localAgents)
-			fmt.Println()
+		description := strings.Join(strings.Fields(e.description), " ")
+		if description != "" {
+			used := runewidth.StringWidth(marker) + badgeWidth + 2 + nameWidth + 2
+			if width > 0 && !tableNoTrunc {
+				description = table.Truncate(description, max(width-used, 10))
+			}
+			line += "  " + description
 		}
+		fmt.Println(strings.TrimRight(line, " "))
+	}
 
-		if len(localCommands) > 0 {
-			fmt.Println("Commands:")
-			printCommandsTable(localCommands)
-			fmt.Println()
+	counts := []struct {
+		n    int
+		name string
+	}{
+		{len(items.skills), "skill"}, {len(items.agents), "agent"},
+		{len(items.commands), "command"}, {len(items.hooks), "hook"},
+	}
+	var parts []string
+	for _, c := range counts {
+		if c.n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s%s", c.n, c.name, plural(c.n)))
 		}
+	}
+	fmt.Printf("\n%s\n", strings.Join(parts, ", "))
+}
+
+// plural returns "s" unless n is 1
+func plural(n int) string {
+	if n == 1 {
+		return ""
+	}
+	return "s"
+}
 
-		if len(localHooks) > 0 {
-			fmt.Println("Hooks:")
-			printHooksTable(localHooks)
+// printScopeSection prints a scope as a table per resource type. A global
+// scope lists every type it was not filtered to leave out, even if empty.
+func printScopeSection(title string, items scopeItems, showEmpty bool) {
+	fmt.Println(title)
+	fmt.Println()
+
+	types, _ := listTypeFilter()
+	show := func(kind string, n int) bool {
+		if n > 0 {
+			return true
 		}
+		return showEmpty && (types == nil || types[kind]) && (kind != listTypeHook || listTag == "")
 	}
 
-	return nil
+	printed := false
+	section := func(header string) {
+		if printed {
+			fmt.Println()
+		}
+		printed = true
+		fmt.Println(header)
+	}
+
+	if show(listTypeSkill, len(items.skills)) {
+		section("Skills:")
+		if len(items.skills) == 0 {
+			fmt.Println("  No skills found.")
+		} else {
+			printSkillsTable(items.skills)
+		}
+	}
+	if show(listTypeAgent, len(items.agents)) {
+		section("Agents:")
+		if len(items.agents) == 0 {
+			fmt.Println("  No agents found.")
+		} else {
+			printAgentsTable(items.agents)
+		}
+	}
+	if show(listTypeCommand, len(items.commands)) {
+		section("Commands:")
+		if len(items.commands) == 0 {
+			fmt.Println("  No commands found.")
+		} else {
+			printCommandsTable(items.commands)
+		}
+	}
+	if show(listTypeHook, len(items.hooks)) {
+		section("Hooks:")
+		if len(items.hooks) == 0 {
+			fmt.Println("  No hooks found.")
+		} else {
+			printHooksTable(items.hooks)
+		}
+	}
+	if !printed {
+		fmt.Println("No resources found.")
+	}
 }
 
-func printListJSON(globalSkills []*skill.Skill, globalAgents []*agent.Agent, globalCommands []*command.Command, globalHooks []*hook.Hook,
-	localSkills []*skill.Skill, localAgents []*agent.Agent, localCommands []*command.Command, localHooks []*hook.Hook) error {
+func printListJSON(sections []listSection) error {
+	return printJSON(listJSONOutput(sections, listScope))
+}
 
-	toListItems := func(skills []*skill.Skill, agents []*agent.Agent, commands []*command.Command, hooks []*hook.Hook) scopedListOutput {
+// listJSONOutput returns the JSON output of jd list for sections, loaded for
+// scope: an empty local scope is included when all scopes were listed
+func listJSONOutput(sections []listSection, scope string) listOutput {
+	toListItems := func(items scopeItems) scopedListOutput {
 		output := scopedListOutput{
-			Skills:   make([]listItem, 0, len(skills)),
-			Agents:   make([]listItem, 0, len(agents)),
-			Commands: make([]listItem, 0, len(commands)),
-			Hooks:    make([]listItem, 0, len(hooks)),
+			Skills:   make([]listItem, 0, len(items.skills)),
+			Agents:   make([]listItem, 0, len(items.agents)),
+			Commands: make([]listItem, 0, len(items.commands)),
+			Hooks:    make([]listItem, 0, len(items.hooks)),
 		}
-		for _, s := range skills {
-			output.Skills = append(output.Skills, listItem{Name: s.Name, Description: s.Description})
+		for _, s := range items.skills {
+			output.Skills = append(output.Skills, listItem{Name: s.Name, Description: s.Description, Tags: s.Tags, Favorite: isFavorite(skillID(s))})
 		}
-		for _, a := range agents {
-			output.Agents = append(output.Agents, listItem{Name: a.Name, Description: a.Description})
+		for _, a := range items.agents {
+			output.Agents = append(output.Agents, listItem{Name: a.Name, Description: a.Description, Tags: a.Tags, Favorite: isFavorite(a.Name)})
 		}
-		for _, c := range commands {
-			output.Commands = append(output.Commands, listItem{Name: c.Name, Description: c.Description})
+		for _, c := range items.commands {
+			output.Commands = append(output.Commands, listItem{Name: c.Name, Description: c.Description, Tags: c.Tags, Favorite: isFavorite(c.Name)})
 		}
-		for _, h := range hooks {
+		for _, h := range items.hooks {
 			desc := fmt.Sprintf("%s: %s", h.EventType, h.Matcher)
 			output.Hooks = append(output.Hooks, listItem{Name: h.Name, Description: desc})
 		}
 		return output
 	}
 
-	output := listOutput{
-		Global: toListItems(globalSkills, globalAgents, globalCommands, globalHooks),
-		Local:  toListItems(localSkills, localAgents, localCommands, localHooks),
+	var output listOutput
+	for _, s := range sections {
+		items := toListItems(s.items)
+		switch {
+		case s.name == string(ScopeGlobal):
+			output.Global = &items
+		case s.name == string(ScopeLocal):
+			output.Local = &items
+		default:
+			if output.Scopes == nil {
+				output.Scopes = make(map[string]scopedListOutput)
+			}
+			output.Scopes[s.name] = items
+		}
 	}
-
-	jsonOutput, err := json.MarshalIndent(output, "", "  ")
-	if err != nil {
-		return err
+	if scope == "" && output.Local == nil {
+		output.Local = &scopedListOutput{Skills: []listItem{}, Agents: []listItem{}, Commands: []listItem{}, Hooks: []listItem{}}
 	}
-	fmt.Println(string(jsonOutput))
-	return nil
+	return output
 }
@@ -0,0 +1,166 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/pkg/repo"
+	"github.com/spf13/cobra"
+)
+
+// findTemplate looks up a repository template given as namespace:name
+func findTemplate(spec string, t repo.PackageType) (*repo.Template, error) {
+	namespace, name, ok := strings.Cut(spec, ":")
+	if !ok || namespace == "" || name == "" {
+		return nil, validationErrorf("invalid template: %s (use namespace:name)", spec)
+	}
+
+	store := repo.NewStore(basedir.DataDir())
+	tmpl, err := store.FindTemplate(namespace, t, name)
+	switch {
+	case errors.Is(err, repo.ErrRepoNotFound):
+		return nil, notFoundErrorf("repository not found: %s", namespace)
+	case errors.Is(err, repo.ErrTemplateNotFound):
+		templates, _ := store.Templates(namespace, t)
+		if len(templates) == 0 {
+			return nil, notFoundErrorf("repository %s has no %s templates (in %s/%ss/)", namespace, t, repo.TemplatesDir, t)
+		}
+		var names []string
+		for _, tm := range templates {
+			names = append(names, tm.Name)
+		}
+		return nil, notFoundErrorf("%s template not found in %s: %s (available: %s)", t, namespace, name, strings.Join(names, ", "))
+	case err != nil:
+		return nil, fmt.Errorf("failed to read templates of %s: %w", namespace, err)
+	}
+	return tmpl, nil
+}
+
+// templateValues returns the placeholder values for a new skill or agent:
+// name, title, description, date, and the type's extra fields
+func templateValues(name, description string, extra map[string]string) map[string]string {
+	if description == "" {
+		description = "Description of " + name
+	}
+	values := map[string]string{
+		"name":        name,
+		"title":       toTitle(name),
+		"description": description,
+		"date":        time.Now().Format("2006-01-02"),
+	}
+	for k, v := range extra {
+		values[k] = v
+	}
+	return values
+}
+
+// instantiateSkillTemplate copies a skill template directory to skillDir,
+// rendering the placeholders of its text files and keeping file modes. Returns
+// the placeholders that had no value.
+func instantiateSkillTemplate(tmpl *repo.Template, skillDir string, values map[string]string) ([]string, error) {
+	unknown := make(map[string]bool)
+	err := filepath.WalkDir(tmpl.Dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(tmpl.Dir, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(skillDir, rel)
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return os.MkdirAll(target, 0755)
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		// Binary files are copied as they are
+		if !bytes.Contains(content, []byte{0}) {
+			rendered, missing := repo.RenderTemplate(string(content), values)
+			if rel == "SKILL.md" {
+				rendered = setFrontmatterName(rendered, values["name"])
+			}
+			content = []byte(rendered)
+			for _, key := range missing {
+				unknown[key] = true
+			}
+		}
+		return os.WriteFile(target, content, info.Mode().Perm())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	for key := range unknown {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// renderAgentTemplate renders an agent template file for a new agent. Returns the
+// content and the placeholders that had no value.
+func renderAgentTemplate(tmpl *repo.Template, values map[string]string) (string, []string, error) {
+	content, err := os.ReadFile(tmpl.Dir)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read template: %w", err)
+	}
+	rendered, unknown := repo.RenderTemplate(string(content), values)
+	return setFrontmatterName(rendered, values["name"]), unknown, nil
+}
+
+// warnUnknownPlaceholders notes template placeholders jd has no value for
+func warnUnknownPlaceholders(unknown []string) {
+	if len(unknown) == 0 {
+		return
+	}
+	for i, key := range unknown {
+		unknown[i] = "{{" + key + "}}"
+	}
+	fmt.Fprintf(os.Stderr, "⚠️  Left unfilled placeholders for you to complete: %s\n", strings.Join(unknown, ", "))
+}
+
+// templateCompletion returns tab completion for the templates of a type across
+// registered repositories, as namespace:name
+func templateCompletion(t repo.PackageType) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		store := repo.NewStore(basedir.DataDir())
+		repos, err := store.List()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		var completions []string
+		for _, r := range repos {
+			templates, err := store.Templates(r.Namespace, t)
+			if err != nil {
+				continue
+			}
+			for _, tmpl := range templates {
+				completions = append(completions, fmt.Sprintf("%s:%s\t%s", r.Namespace, tmpl.Name, tmpl.Path))
+			}
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+}
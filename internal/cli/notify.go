@@ -0,0 +1,198 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/notify"
+	"github.com/itda-skills/jindo/internal/pkg/pkgmgr"
+	"github.com/itda-skills/jindo/internal/pkg/repo"
+	"github.com/itda-skills/jindo/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+// Config keys of the webhook that is told about available package updates
+const (
+	notifyWebhookURLKey = "notify.webhook_url"
+	notifyFormatKey     = "notify.format"
+	notifyTemplateKey   = "notify.template"
+)
+
+// notifyWebhookURLEnv overrides notify.webhook_url (see config.GetWithEnv), so
+// CI can keep the URL in a secret
+const notifyWebhookURLEnv = "ITDA_NOTIFY_WEBHOOK_URL"
+
+var notifyCmd = &cobra.Command{
+	Use:   "notify",
+	Short: "Tell a team's chat webhook about available package updates",
+	Long: `Post a message to a Slack, Discord, or other webhook when 'jd pkg update'
+finds new versions of installed packages. Each new version is announced once,
+however often the check runs; the versions announced are kept in
+` + notify.SentFile + ` in the jd data directory.
+
+Configuration (in the global config.toml; a project's .claude/jindo.toml
+cannot set it):
+  ` + notifyWebhookURLKey + `  Webhook URL (or the ` + notifyWebhookURLEnv + ` environment variable)
+  ` + notifyFormatKey + `       slack, discord, or json (default: from the URL; json for other hosts)
+  ` + notifyTemplateKey + `     Go text/template of the message (default: one line per update)
+
+The template is given .Host and .Updates, each with .Name, .Namespace, .Type,
+.Current, .Latest, .ChangedFiles, .Pinned, and .ChangelogURL (a GitHub compare
+link, or the repository page for other hosts). The json format posts the
+message as "text" along with "event", "host", and "updates" for endpoints that
+process them.
+
+To poll, run the check on a schedule, such as from cron:
+  jd pkg repo update && jd pkg update
+
+Subcommands:
+  ping  Post a sample message to check the webhook and template`,
+	Example: `  jd config set notify.webhook_url https://hooks.slack.com/services/T000/B000/XXXX
+  jd notify ping
+  jd pkg update`,
+}
+
+func init() {
+	rootCmd.AddCommand(notifyCmd)
+}
+
+// notifyConfig is the configured webhook; url is "" if none is
+type notifyConfig struct {
+	url      string
+	format   notify.Format
+	template string
+}
+
+// loadNotifyConfig reads the webhook configuration from the global config only,
+// so that a cloned project cannot send the updates to its own endpoint
+func loadNotifyConfig() (notifyConfig, error) {
+	var nc notifyConfig
+	cfg, err := config.LoadGlobal()
+	if err != nil {
+		return nc, fmt.Errorf("failed to load config: %w", err)
+	}
+	if value, ok := cfg.GetWithEnv(notifyWebhookURLKey); ok {
+		nc.url, _ = value.(string)
+	}
+	nc.template = configString(cfg, notifyTemplateKey)
+	nc.format = notify.DetectFormat(nc.url)
+	if format := configString(cfg, notifyFormatKey); format != "" {
+		if nc.format, err = notify.ParseFormat(format); err != nil {
+			return nc, validationErrorf("%s: %v", notifyFormatKey, err)
+		}
+	}
+	return nc, nil
+}
+
+// configString returns a string config value, or "" if it is not set or not a string
+func configString(cfg *config.Config, key string) string {
+	value, err := cfg.Get(key)
+	if err != nil {
+		return ""
+	}
+	s, _ := value.(string)
+	return s
+}
+
+// payload renders a message into the request body of the webhook
+func (nc notifyConfig) payload(msg notify.Message) ([]byte, error) {
+	text, err := notify.Render(nc.template, msg)
+	if err != nil {
+		return nil, validationErrorf("%s: %v", notifyTemplateKey, err)
+	}
+	return notify.Payload(nc.format, text, msg)
+}
+
+// post renders a message and posts it to the webhook
+func (nc notifyConfig) post(msg notify.Message) error {
+	payload, err := nc.payload(msg)
+	if err != nil {
+		return err
+	}
+	return notify.Send(nc.url, payload)
+}
+
+// notifyUpdates posts the updates not announced before to the configured
+// webhook, if any, and records them as announced. Failures are warnings: the
+// check itself succeeded, and the next one retries.
+func notifyUpdates(updates []pkgmgr.UpdateInfo) {
+	nc, err := loadNotifyConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: not notifying: %v\n", err)
+		return
+	}
+	if nc.url == "" {
+		return
+	}
+
+	dataDir, err := basedir.Expand(basedir.DataDir())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: not notifying: %v\n", err)
+		return
+	}
+	sentPath := filepath.Join(dataDir, notify.SentFile)
+	sent, err := notify.LoadSent(sentPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: not notifying: %v\n", err)
+		return
+	}
+
+	msg := notifyMessage(nil)
+	for _, u := range updates {
+		if u.HasUpdate && sent[u.Package.Name] != u.LatestSHA {
+			msg.Updates = append(msg.Updates, notifyUpdate(u))
+		}
+	}
+	if len(msg.Updates) == 0 {
+		return
+	}
+	if err := nc.post(msg); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to notify %s: %v\n", nc.format, err)
+		return
+	}
+	for _, u := range updates {
+		if u.HasUpdate {
+			sent[u.Package.Name] = u.LatestSHA
+		}
+	}
+	if err := sent.Save(sentPath); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to record the updates announced: %v\n", err)
+	}
+	fmt.Printf("📣 Announced %d new update%s to the %s webhook\n", len(msg.Updates), plural(len(msg.Updates)), nc.format)
+}
+
+// notifyMessage returns a message about updates from this machine
+func notifyMessage(updates []notify.Update) notify.Message {
+	host, _ := os.Hostname()
+	return notify.Message{Host: host, Updates: updates}
+}
+
+// notifyUpdate describes an available update in a message, with a link to its
+// changes when its source has a web page
+func notifyUpdate(u pkgmgr.UpdateInfo) notify.Update {
+	pkg := u.Package
+	update := notify.Update{
+		Name:         pkg.Name,
+		Namespace:    pkg.Namespace,
+		Type:         string(pkg.Type),
+		Current:      shortSHA(u.CurrentSHA),
+		Latest:       shortSHA(u.LatestSHA),
+		ChangedFiles: len(u.ChangedFiles),
+		Pinned:       pkg.Pinned(),
+	}
+	if u.LatestRef != "" {
+		update.Current, update.Latest = pkg.Version.Ref, u.LatestRef
+	}
+	if pkg.Source != "" {
+		// Archive checksums have no changes to link to
+		return update
+	}
+	config, err := repo.NewStore(basedir.DataDir()).Get(pkg.Namespace)
+	if err != nil {
+		config = &repo.RepoConfig{URL: pkg.RepoURL}
+	}
+	update.ChangelogURL = config.CompareURL(u.CurrentSHA, u.LatestSHA)
+	return update
+}
@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/itda-skills/jindo/internal/notify"
+	"github.com/spf13/cobra"
+)
+
+var notifyPingDryRun bool
+
+var notifyPingCmd = &cobra.Command{
+	Use:   "ping",
+	Short: "Post a sample update message to the webhook",
+	Long: `Post a message about a sample package update to the configured webhook, in
+its format and with its template, to check both. Nothing is recorded as
+announced.
+
+With --dry-run, the request body is printed instead of posted.`,
+	Example: `  jd notify ping
+  jd notify ping --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: runNotifyPing,
+}
+
+func init() {
+	notifyCmd.AddCommand(notifyPingCmd)
+	notifyPingCmd.Flags().BoolVarP(&notifyPingDryRun, "dry-run", "n", false, "Print the request body instead of posting it")
+}
+
+func runNotifyPing(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+
+	nc, err := loadNotifyConfig()
+	if err != nil {
+		return err
+	}
+	if nc.url == "" && !notifyPingDryRun {
+		return validationErrorf("no webhook configured; set %s (or %s)", notifyWebhookURLKey, notifyWebhookURLEnv)
+	}
+
+	msg := notifyMessage([]notify.Update{{
+		Name:         "example--web-fetch",
+		Namespace:    "example",
+		Type:         "skill",
+		Current:      "1a2b3c4d",
+		Latest:       "5e6f7a8b",
+		ChangedFiles: 2,
+		ChangelogURL: "https://github.com/example/skills/compare/1a2b3c4d...5e6f7a8b",
+	}})
+
+	if notifyPingDryRun {
+		payload, err := nc.payload(msg)
+		if err != nil {
+			return err
+		}
+		url := nc.url
+		if url == "" {
+			url = "(no webhook configured)"
+		}
+		fmt.Printf("POST %s (%s)\n%s\n", url, nc.format, payload)
+		return nil
+	}
+
+	if err := nc.post(msg); err != nil {
+		return fmt.Errorf("failed to notify: %w", err)
+	}
+	fmt.Printf("✅ Posted a sample message to the %s webhook\n", nc.format)
+	return nil
+}
@@ -0,0 +1,349 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/itda-skills/jindo/internal/agent"
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/command"
+	"github.com/itda-skills/jindo/internal/guide"
+	"github.com/itda-skills/jindo/internal/hook"
+	"github.com/itda-skills/jindo/internal/pkg/pkgmgr"
+	"github.com/itda-skills/jindo/internal/pkg/repo"
+	"github.com/itda-skills/jindo/internal/prompt"
+	"github.com/itda-skills/jindo/internal/skill"
+	"github.com/spf13/cobra"
+)
+
+var (
+	openDir    bool
+	openWeb    bool
+	openPrint  bool
+	openGlobal bool
+	openLocal  bool
+)
+
+// openTypes are the resource types jd open accepts, with their plurals and short forms
+var openTypes = map[string]string{
+	"skill": "skill", "skills": "skill",
+	"command": "command", "commands": "command",
+	"agent": "agent", "agents": "agent",
+	"hook": "hook", "hooks": "hook",
+	"prompt": "prompt", "prompts": "prompt",
+	"package": "package", "packages": "package", "pkg": "package",
+	"repo": "repo", "repos": "repo",
+}
+
+var openCmd = &cobra.Command{
+	Use:   "open <type> <name>",
+	Short: "Open a resource's file, directory, or source repository",
+	Long: `Open where a resource lives with the platform opener (open on macOS,
+xdg-open on Linux, start on Windows).
+
+<type> is skill, command, agent, hook, prompt, package, or repo:
+  skill, command, agent   The resource's file
+  hook                    The settings.json the hook is defined in
+  prompt                  The prompt's override file (built-in prompts have none)
+  package                 An installed package's main file
+  repo                    A registered repository's local checkout
+
+--dir opens the directory containing the file instead. --web opens the source
+in the browser: the repository's page for the exact commit a package was
+installed at (for resources installed from a repository), or a registered
+repository's page. --print prints the path or URL instead of opening it, e.g.
+cd "$(jd open skill web-fetch --dir --print)".
+
+Default scope is local if a .claude directory exists, otherwise global.
+Use --global or --local to override.`,
+	Example: `  jd open skill web-fetch
+  jd open agent code-reviewer --dir
+  jd open skill affa-ever--web-fetch --web
+  jd open hook PostToolUse-Edit-Write-0 -g
+  jd open repo affa-ever --web`,
+	Args:              cobra.ExactArgs(2),
+	RunE:              runOpen,
+	ValidArgsFunction: openCompletion,
+}
+
+func init() {
+	rootCmd.AddCommand(openCmd)
+	openCmd.Flags().BoolVarP(&openDir, "dir", "d", false, "Open the containing directory")
+	openCmd.Flags().BoolVarP(&openWeb, "web", "w", false, "Open the source repository in the browser")
+	openCmd.Flags().BoolVarP(&openPrint, "print", "p", false, "Print the path or URL instead of opening it")
+	openCmd.Flags().BoolVarP(&openGlobal, "global", "g", false, "Open a global ~/.claude resource")
+	openCmd.Flags().BoolVarP(&openLocal, "local", "l", false, "Open a local .claude resource")
+	openCmd.MarkFlagsMutuallyExclusive("dir", "web")
+}
+
+func runOpen(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+	resourceType, ok := openTypes[strings.ToLower(args[0])]
+	if !ok {
+		return validationErrorf("invalid type: %s (use: skill, command, agent, hook, prompt, package, repo)", args[0])
+	}
+	name := args[1]
+
+	var target string
+	var err error
+	if openWeb {
+		target, err = openWebURL(resourceType, name)
+	} else {
+		target, err = openPath(resourceType, name)
+		if err == nil && openDir {
+			if info, statErr := os.Stat(target); statErr == nil && !info.IsDir() {
+				target = filepath.Dir(target)
+			}
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if openPrint {
+		fmt.Println(target)
+		return nil
+	}
+	fmt.Printf("Opening %s\n", target)
+	if err := guide.OpenInBrowser(target); err != nil {
+		return fmt.Errorf("failed to open %s: %w", target, err)
+	}
+	return nil
+}
+
+// openPath returns the file or directory of a resource
+func openPath(resourceType, name string) (string, error) {
+	switch resourceType {
+	case "prompt":
+		if !prompt.Exists(name) {
+			return "", notFoundErrorf("prompt not found: %s", name)
+		}
+		if !prompt.HasOverride(name) {
+			return "", validationErrorf("prompt %s is built in and has no file; create an override with 'jd prompts edit %s'", name, name)
+		}
+		path, err := prompt.GetOverridePath(name)
+		if err != nil {
+			return "", fmt.Errorf("failed to get prompt path: %w", err)
+		}
+		return path, nil
+	case "package":
+		pkg, err := openPackage(name)
+		if err != nil {
+			return "", err
+		}
+		return packageMainFile(pkg), nil
+	case "repo":
+		root, err := repo.NewStore(basedir.DataDir()).PackageRoot(name)
+		if errors.Is(err, repo.ErrRepoNotFound) {
+			return "", notFoundErrorf("repository not found: %s", name)
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to locate repository %s: %w", name, err)
+		}
+		return root, nil
+	}
+
+	scope, err := ResolveScope(openGlobal, openLocal)
+	if err != nil {
+		return "", err
+	}
+
+	var path string
+	switch resourceType {
+	case "skill":
+		var s *skill.Skill
+		if s, err = skill.NewStore(GetPathByScope(scope, "skills")).Get(name); err == nil {
+			path = s.Path
+		}
+	case "command":
+		var c *command.Command
+		if c, err = command.NewStore(GetPathByScope(scope, "commands")).Get(name); err == nil {
+			path = c.Path
+		}
+	case "agent":
+		var a *agent.Agent
+		if a, err = agent.NewStore(GetPathByScope(scope, "agents")).Get(name); err == nil {
+			path = a.Path
+		}
+	case "hook":
+		if _, err = hook.NewStore(GetSettingsPathByScope(scope)).Get(name); err == nil {
+			path, err = basedir.Expand(GetSettingsPathByScope(scope))
+		}
+	}
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", notFoundErrorf("%s not found in %s: %s", resourceType, ScopeDescription(scope), name)
+		}
+		return "", fmt.Errorf("failed to get %s: %w", resourceType, err)
+	}
+	return path, nil
+}
+
+// openWebURL returns the web page of a resource's source: the repository of the
+// package it was installed from, or a registered repository
+func openWebURL(resourceType, name string) (string, error) {
+	store := repo.NewStore(basedir.DataDir())
+
+	if resourceType == "repo" {
+		config, err := store.Get(name)
+		if errors.Is(err, repo.ErrRepoNotFound) {
+			return "", notFoundErrorf("repository not found: %s", name)
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to get repository %s: %w", name, err)
+		}
+		if url := config.WebURL("", ""); url != "" {
+			return url, nil
+		}
+		return "", validationErrorf("repository %s is local and has no web page (%s)", name, config.URL)
+	}
+
+	var pkg *pkgmgr.InstalledPackage
+	switch resourceType {
+	case "package":
+		p, err := openPackage(name)
+		if err != nil {
+			return "", err
+		}
+		pkg = p
+	case "prompt":
+		return "", validationErrorf("prompts are not installed from repositories")
+	default:
+		path, err := openPath(resourceType, name)
+		if err != nil {
+			return "", err
+		}
+		pkg, err = packageForResource(resourceType, name, path)
+		if err != nil {
+			return "", err
+		}
+		if pkg == nil {
+			return "", notFoundErrorf("%s %s was not installed from a repository", resourceType, name)
+		}
+	}
+
+	if pkg.Source != "" {
+		return "", validationErrorf("%s was installed from an archive (%s), not a repository", pkg.Name, pkg.Source)
+	}
+	config, err := store.Get(pkg.Namespace)
+	if err != nil {
+		// The repository was removed since: fall back to the URL recorded at install time
+		config = &repo.RepoConfig{URL: pkg.RepoURL}
+	}
+	url := config.WebURL(pkg.Version.SHA, pkg.SourcePath)
+	if url == "" {
+		return "", validationErrorf("%s comes from a local repository with no web page (%s)", pkg.Name, config.URL)
+	}
+	return url, nil
+}
+
+// openPackage returns an installed package by name
+func openPackage(name string) (*pkgmgr.InstalledPackage, error) {
+	pkg, err := pkgmgr.NewManager(basedir.DataDir()).Get(name)
+	if errors.Is(err, pkgmgr.ErrPackageNotFound) {
+		return nil, notFoundErrorf("package '%s' not found. Use 'jd pkg list' to see installed packages", name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get package: %w", err)
+	}
+	return pkg, nil
+}
+
+// packageMainFile returns the file of an installed package to open: a skill's
+// SKILL.md, otherwise its first file
+func packageMainFile(pkg *pkgmgr.InstalledPackage) string {
+	for _, f := range pkg.Files {
+		if filepath.Base(f.Target) == "SKILL.md" {
+			return f.Target
+		}
+	}
+	if len(pkg.Files) > 0 {
+		return pkg.Files[0].Target
+	}
+	return ""
+}
+
+// packageForResource returns the installed package that wrote a resource's file,
+// or nil if the resource was not installed from a package. Hooks are matched by
+// the settings.json rule their package registered.
+func packageForResource(resourceType, name, path string) (*pkgmgr.InstalledPackage, error) {
+	packages, err := pkgmgr.NewManager(basedir.DataDir()).List()
+	if err != nil {
+		return nil, fmt.Errorf("list packages: %w", err)
+	}
+
+	var commands []string
+	if resourceType == "hook" {
+		h, err := hook.NewStore(path).Get(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get hook: %w", err)
+		}
+		commands = h.Commands
+	}
+
+	for i, pkg := range packages {
+		if resourceType == "hook" {
+			if pkg.Hook == nil || !slices.Contains(commands, pkg.Hook.Command) {
+				continue
+			}
+			if settingsPath, err := basedir.Expand(pkg.Hook.SettingsPath); err == nil && settingsPath == path {
+				return &packages[i], nil
+			}
+			continue
+		}
+		for _, f := range pkg.Files {
+			if f.Target == path {
+				return &packages[i], nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// openCompletion completes the type, then the names of resources of that type
+func openCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	switch len(args) {
+	case 0:
+		return []string{"skill", "command", "agent", "hook", "prompt", "package", "repo"}, cobra.ShellCompDirectiveNoFileComp
+	case 1:
+		switch openTypes[strings.ToLower(args[0])] {
+		case "skill":
+			return skillNameCompletion(cmd, nil, toComplete)
+		case "command":
+			return commandNameCompletion(cmd, nil, toComplete)
+		case "agent":
+			return agentNameCompletion(cmd, nil, toComplete)
+		case "hook":
+			return hookNameCompletion(cmd, nil, toComplete)
+		case "prompt":
+			return promptNameCompletion(cmd, nil, toComplete)
+		case "package":
+			return installedPackageCompletion(cmd, nil, toComplete)
+		case "repo":
+			return pkgBrowseCompletion(cmd, nil, toComplete)
+		}
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
+// installedPackageCompletion provides completion for installed package names
+func installedPackageCompletion(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) != 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	packages, err := pkgmgr.NewManager(basedir.DataDir()).List()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var names []string
+	for _, pkg := range packages {
+		names = append(names, fmt.Sprintf("%s\t%s from %s", pkg.Name, pkg.Type, pkg.Namespace))
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
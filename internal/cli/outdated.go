@@ -0,0 +1,202 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/pkg/pkgmgr"
+	"github.com/itda-skills/jindo/internal/pkg/repo"
+	"github.com/itda-skills/jindo/internal/table"
+	"github.com/spf13/cobra"
+)
+
+// outdatedSchemaVersion is bumped whenever a field of the JSON report changes meaning or is removed
+const outdatedSchemaVersion = 1
+
+var outdatedJSON bool
+
+var outdatedCmd = &cobra.Command{
+	Use:   "outdated [name...]",
+	Short: "Report installed packages with available updates",
+	Long: `Check installed packages against their sources without applying anything.
+
+Every installed package is reported, including up-to-date packages and
+packages that could not be checked (e.g., a removed repository). Use
+'jd pkg update --apply' to install the updates.
+
+With --json, the report uses a stable, versioned schema for CI and dashboards:
+
+  {
+    "schema_version": 1,
+    "checked_at": "2026-01-02T15:04:05Z",
+    "summary": {"total": 3, "outdated": 1, "errors": 0},
+    "packages": [
+      {
+        "name": "affa-ever--web-fetch",
+        "type": "skill",
+        "namespace": "affa-ever",
+        "source_path": "skills/web-fetch",
+        "source": "",
+        "ref": "main",
+        "current_sha": "…",
+        "latest_sha": "…",
+        "outdated": true,
+        "changed_files": 2,
+        "pinned": false,
+        "error": ""
+      }
+    ]
+  }
+
+"source" is set for packages installed from an archive, whose SHAs are
+SHA-256 checksums. "pinned" packages are installed at a fixed tag.
+
+Examples:
+  jd outdated
+  jd outdated --json
+  jd outdated affa-ever--web-fetch --json`,
+	RunE: runOutdated,
+}
+
+func init() {
+	rootCmd.AddCommand(outdatedCmd)
+	outdatedCmd.Flags().BoolVar(&outdatedJSON, "json", false, "Output in JSON format")
+	addOutputFlags(outdatedCmd)
+	addNoTruncFlag(outdatedCmd)
+}
+
+type outdatedPackage struct {
+	Name         string           `json:"name"`
+	Type         repo.PackageType `json:"type"`
+	Namespace    string           `json:"namespace"`
+	SourcePath   string           `json:"source_path"`
+	Source       string           `json:"source"`
+	Ref          string           `json:"ref"`
+	CurrentSHA   string           `json:"current_sha"`
+	LatestSHA    string           `json:"latest_sha"`
+	Channel      string           `json:"channel,omitempty"`
+	LatestRef    string           `json:"latest_ref,omitempty"` // Release tag, on the stable channel
+	Outdated     bool             `json:"outdated"`
+	ChangedFiles int              `json:"changed_files"`
+	Pinned       bool             `json:"pinned"`
+	Error        string           `json:"error"`
+}
+
+type outdatedSummary struct {
+	Total    int `json:"total"`
+	Outdated int `json:"outdated"`
+	Errors   int `json:"errors"`
+}
+
+type outdatedReport struct {
+	SchemaVersion int               `json:"schema_version"`
+	CheckedAt     time.Time         `json:"checked_at"`
+	Summary       outdatedSummary   `json:"summary"`
+	Packages      []outdatedPackage `json:"packages"`
+}
+
+func runOutdated(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+	manager := pkgmgr.NewManager(basedir.DataDir())
+
+	infos, err := manager.CheckAll(args...)
+	if err != nil {
+		return fmt.Errorf("failed to check updates: %w", err)
+	}
+
+	report := outdatedReport{
+		SchemaVersion: outdatedSchemaVersion,
+		CheckedAt:     time.Now().UTC().Truncate(time.Second),
+		Packages:      []outdatedPackage{},
+	}
+	for _, info := range infos {
+		p := outdatedPackage{
+			Name:         info.Package.Name,
+			Type:         info.Package.Type,
+			Namespace:    info.Package.Namespace,
+			SourcePath:   info.Package.SourcePath,
+			Source:       info.Package.Source,
+			Ref:          info.Package.Version.Ref,
+			CurrentSHA:   info.CurrentSHA,
+			LatestSHA:    info.LatestSHA,
+			Channel:      info.Package.Channel,
+			LatestRef:    info.LatestRef,
+			Outdated:     info.HasUpdate,
+			ChangedFiles: len(info.ChangedFiles),
+			Pinned:       info.Package.Pinned(),
+		}
+		if info.Err != nil {
+			p.Error = info.Err.Error()
+			report.Summary.Errors++
+		}
+		if p.Outdated {
+			report.Summary.Outdated++
+		}
+		report.Packages = append(report.Packages, p)
+	}
+	report.Summary.Total = len(report.Packages)
+
+	if outdatedJSON || formattedOutput() {
+		return printJSON(report)
+	}
+
+	if report.Summary.Total == 0 {
+		fmt.Println("No packages installed.")
+		return nil
+	}
+
+	t := newTable(
+		table.Column{Header: "NAME", Max: 35},
+		table.Column{Header: "CURRENT"},
+		table.Column{Header: "LATEST"},
+		table.Column{Header: "CHANGES"},
+		table.Column{Header: "STATUS", Min: 10, Wrap: true},
+	)
+	for _, p := range report.Packages {
+		status := "up to date"
+		switch {
+		case p.Error != "":
+			status = "error: " + p.Error
+		case p.Outdated:
+			status = "outdated"
+		}
+		if p.Pinned {
+			status += " (pinned)"
+		}
+
+		changes := "-"
+		if p.Outdated {
+			changes = fmt.Sprintf("%d", p.ChangedFiles)
+		}
+
+		current, latest := shortSHA(p.CurrentSHA), shortSHA(p.LatestSHA)
+		if p.LatestRef != "" {
+			current, latest = p.Ref, p.LatestRef
+		}
+		t.AddRow(p.Name, current, latest, changes, status)
+	}
+	t.Render(os.Stdout)
+
+	fmt.Printf("\n%d of %d package(s) outdated", report.Summary.Outdated, report.Summary.Total)
+	if report.Summary.Errors > 0 {
+		fmt.Printf(", %d could not be checked", report.Summary.Errors)
+	}
+	fmt.Println()
+	if report.Summary.Outdated > 0 {
+		fmt.Println("\nApply updates with: jd pkg update --apply")
+	}
+	return nil
+}
+
+// shortSHA abbreviates a commit SHA or checksum for display
+func shortSHA(sha string) string {
+	if sha == "" {
+		return "-"
+	}
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+	return sha
+}
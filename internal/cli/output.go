@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"github.com/itda-skills/jindo/internal/jsonpath"
+	"github.com/spf13/cobra"
+)
+
+var (
+	outputFormat   string
+	outputJSONPath string
+)
+
+// addOutputFlags adds --format and --jsonpath to a command with --json output
+func addOutputFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&outputFormat, "format", "", "Print each item with a Go template (e.g., '{{.Name}}')")
+	cmd.Flags().StringVar(&outputJSONPath, "jsonpath", "", "Print the JSON output through a JSONPath template (e.g., '{.skills[*].name}')")
+	cmd.MarkFlagsMutuallyExclusive("json", "format", "jsonpath")
+}
+
+// formattedOutput reports whether --format or --jsonpath was given, which, like
+// --json, replace the human-readable output
+func formattedOutput() bool {
+	return outputFormat != "" || outputJSONPath != ""
+}
+
+// printJSON prints a command's JSON output: indented, or through the --format
+// Go template or the --jsonpath template if one was given
+func printJSON(v any) error {
+	switch {
+	case outputFormat != "":
+		return printTemplate(v)
+	case outputJSONPath != "":
+		return printJSONPath(v)
+	}
+	output, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(output))
+	return nil
+}
+
+// outputTemplateFuncs are the functions --format templates can call
+var outputTemplateFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+// printTemplate executes the --format template on each item of a list, each on
+// its own line, or once on anything else. Fields are the Go names of the JSON
+// output (e.g., {{.Name}} {{.Version.SHA}}).
+func printTemplate(v any) error {
+	tmpl, err := template.New("format").Funcs(outputTemplateFuncs).Option("missingkey=zero").Parse(outputFormat)
+	if err != nil {
+		return validationErrorf("invalid --format template: %v", err)
+	}
+
+	items := []any{v}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		items = items[:0]
+		for i := range rv.Len() {
+			items = append(items, rv.Index(i).Interface())
+		}
+	}
+
+	var b bytes.Buffer
+	for _, item := range items {
+		if err := tmpl.Execute(&b, item); err != nil {
+			return validationErrorf("failed to execute --format template: %v", err)
+		}
+		if b.Len() > 0 && b.Bytes()[b.Len()-1] != '\n' {
+			b.WriteByte('\n')
+		}
+	}
+	_, err = os.Stdout.Write(b.Bytes())
+	return err
+}
+
+// printJSONPath prints the --jsonpath template evaluated on the JSON output
+func printJSONPath(v any) error {
+	tmpl, err := jsonpath.Parse(outputJSONPath)
+	if err != nil {
+		return validationErrorf("invalid --jsonpath template: %v", err)
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var decoded any
+	if err := decoder.Decode(&decoded); err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, decoded); err != nil {
+		return validationErrorf("failed to execute --jsonpath template: %v", err)
+	}
+	out := b.String()
+	if out != "" && !strings.HasSuffix(out, "\n") {
+		out += "\n"
+	}
+	fmt.Print(out)
+	return nil
+}
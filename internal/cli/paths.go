@@ -1,9 +1,15 @@
 package cli
 
 import (
+	"bufio"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/pkg/config"
 )
 
 // ErrMutuallyExclusiveFlags is returned when both --global and --local flags are specified
@@ -17,9 +23,20 @@ func ValidateScopeFlags(global, local bool) error {
 	return nil
 }
 
+// defaultScopeKey is the config key that fixes the default scope to "local" or "global"
+const defaultScopeKey = "jindo.default_scope"
+
 // DefaultScope returns the default scope.
-// If a local .claude directory exists in the current working directory, local scope is preferred.
+// The jindo.default_scope config value is used if set; otherwise, if a local .claude
+// directory exists in the project root, local scope is preferred.
 func DefaultScope() PathScope {
+	if cfg, err := config.Load(); err == nil {
+		if value, err := cfg.Get(defaultScopeKey); err == nil {
+			if scope, _ := value.(string); scope == string(ScopeLocal) || scope == string(ScopeGlobal) {
+				return PathScope(scope)
+			}
+		}
+	}
 	if LocalClaudeDirExists() {
 		return ScopeLocal
 	}
@@ -27,7 +44,7 @@ func DefaultScope() PathScope {
 }
 
 // ResolveScope determines the effective scope given optional --global/--local flags.
-// Default is local if .claude exists, otherwise global.
+// Default is jindo.default_scope if set, else local if .claude exists, otherwise global.
 func ResolveScope(globalFlag, localFlag bool) (PathScope, error) {
 	if err := ValidateScopeFlags(globalFlag, localFlag); err != nil {
 		return "", err
@@ -45,16 +62,18 @@ func ResolveScope(globalFlag, localFlag bool) (PathScope, error) {
 func ScopeDescription(scope PathScope) string {
 	switch scope {
 	case ScopeLocal:
+		if projectRootSelected() {
+			if root, err := ProjectRoot(); err == nil {
+				return fmt.Sprintf("local (%s)", filepath.Join(root, localClaudeDir))
+			}
+		}
 		return "local (.claude)"
 	default:
-		return "global (~/.claude)"
+		return fmt.Sprintf("global (%s)", basedir.ClaudeDir())
 	}
 }
 
-const (
-	globalClaudeDir = "~/.claude"
-	localClaudeDir  = ".claude"
-)
+const localClaudeDir = ".claude"
 
 // PathScope represents the scope of a path (global or local)
 type PathScope string
@@ -64,20 +83,67 @@ const (
 	ScopeLocal  PathScope = "local"
 )
 
-// GetGlobalPath returns the global ~/.claude path
+// projectRootOverride is set by the --project-root flag
+var projectRootOverride string
+
+// ProjectRoot returns the directory whose .claude is used for local scope.
+// The --project-root and --scope-path flags take precedence; otherwise the nearest directory
+// containing .claude is found by walking up from CWD (like git does for .git).
+// Falls back to CWD when no .claude directory is found.
+func ProjectRoot() (string, error) {
+	if projectRootOverride != "" {
+		return filepath.Abs(projectRootOverride)
+	}
+	if scopePathFlag != "" {
+		return resolveScopePath(scopePathFlag)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+
+	if root, ok := findProjectRoot(cwd); ok {
+		return root, nil
+	}
+	return cwd, nil
+}
+
+// findProjectRoot walks up from dir looking for a .claude directory.
+// The home directory and the global Claude directory are skipped, since they are the global scope.
+func findProjectRoot(dir string) (string, bool) {
+	home, _ := os.UserHomeDir()
+	global, _ := basedir.ClaudePath()
+
+	for {
+		if dir != home && filepath.Join(dir, localClaudeDir) != global {
+			if info, err := os.Stat(filepath.Join(dir, localClaudeDir)); err == nil && info.IsDir() {
+				return dir, true
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// GetGlobalPath returns the global Claude path (~/.claude unless overridden, see basedir)
 func GetGlobalPath(subdir string) string {
-	return filepath.Join(globalClaudeDir, subdir)
+	return filepath.Join(basedir.ClaudeDir(), subdir)
 }
 
-// GetLocalPath returns the local .claude path (CWD-based)
+// GetLocalPath returns the local .claude path (project root based)
 // Returns empty string if local .claude directory doesn't exist
 func GetLocalPath(subdir string) string {
-	cwd, err := os.Getwd()
+	root, err := ProjectRoot()
 	if err != nil {
 		return ""
 	}
 
-	localDir := filepath.Join(cwd, localClaudeDir)
+	localDir := filepath.Join(root, localClaudeDir)
 	if _, err := os.Stat(localDir); os.IsNotExist(err) {
 		return ""
 	}
@@ -86,14 +152,14 @@ func GetLocalPath(subdir string) string {
 }
 
 // GetLocalPathForWrite returns the local .claude path for writing
-// Creates the directory if it doesn't exist
+// Creates the directory if it doesn't exist (see EnsureLocalClaudeDir)
 func GetLocalPathForWrite(subdir string) (string, error) {
-	cwd, err := os.Getwd()
+	claudeDir, err := EnsureLocalClaudeDir()
 	if err != nil {
 		return "", err
 	}
 
-	localDir := filepath.Join(cwd, localClaudeDir, subdir)
+	localDir := filepath.Join(claudeDir, subdir)
 	if err := os.MkdirAll(localDir, 0755); err != nil {
 		return "", err
 	}
@@ -101,14 +167,46 @@ func GetLocalPathForWrite(subdir string) (string, error) {
 	return localDir, nil
 }
 
-// LocalClaudeDirExists checks if .claude directory exists in CWD
+// EnsureLocalClaudeDir returns the project's .claude directory for writing,
+// creating it if it doesn't exist. Without a .claude directory the project root
+// falls back to the current directory, so on a terminal the directory is
+// confirmed before it is created; declining returns errCancelled.
+func EnsureLocalClaudeDir() (string, error) {
+	root, err := ProjectRoot()
+	if err != nil {
+		return "", err
+	}
+
+	claudeDir := filepath.Join(root, localClaudeDir)
+	if info, err := os.Stat(claudeDir); err == nil && info.IsDir() {
+		return claudeDir, nil
+	}
+
+	if isTerminal(os.Stdin) {
+		fmt.Printf("No %s directory in %s. Create it? (Y/n): ", localClaudeDir, root)
+		reader := bufio.NewReader(os.Stdin)
+		response, _ := reader.ReadString('\n')
+		response = strings.TrimSpace(strings.ToLower(response))
+		if response == "n" || response == "no" {
+			fmt.Println("Cancelled. Use --global, or --project-root to pick the project.")
+			return "", errCancelled
+		}
+	}
+	if err := os.MkdirAll(claudeDir, 0755); err != nil {
+		return "", fmt.Errorf("create %s: %w", claudeDir, err)
+	}
+	fmt.Fprintf(os.Stderr, "Created %s\n", claudeDir)
+	return claudeDir, nil
+}
+
+// LocalClaudeDirExists checks if .claude directory exists in the project root
 func LocalClaudeDirExists() bool {
-	cwd, err := os.Getwd()
+	root, err := ProjectRoot()
 	if err != nil {
 		return false
 	}
 
-	localDir := filepath.Join(cwd, localClaudeDir)
+	localDir := filepath.Join(root, localClaudeDir)
 	info, err := os.Stat(localDir)
 	if err != nil {
 		return false
@@ -120,11 +218,11 @@ func LocalClaudeDirExists() bool {
 func GetPathByScope(scope PathScope, subdir string) string {
 	switch scope {
 	case ScopeLocal:
-		cwd, err := os.Getwd()
+		root, err := ProjectRoot()
 		if err != nil {
 			return GetGlobalPath(subdir) // fallback to global
 		}
-		return filepath.Join(cwd, localClaudeDir, subdir)
+		return filepath.Join(root, localClaudeDir, subdir)
 	default:
 		return GetGlobalPath(subdir)
 	}
@@ -134,25 +232,38 @@ func GetPathByScope(scope PathScope, subdir string) string {
 func GetSettingsPathByScope(scope PathScope) string {
 	switch scope {
 	case ScopeLocal:
-		cwd, err := os.Getwd()
+		root, err := ProjectRoot()
 		if err != nil {
-			return filepath.Join(globalClaudeDir, "settings.json") // fallback to global
+			return filepath.Join(basedir.ClaudeDir(), "settings.json") // fallback to global
 		}
-		return filepath.Join(cwd, localClaudeDir, "settings.json")
+		return filepath.Join(root, localClaudeDir, "settings.json")
 	default:
-		return filepath.Join(globalClaudeDir, "settings.json")
+		return filepath.Join(basedir.ClaudeDir(), "settings.json")
 	}
 }
 
+// GetSettingsPathForWrite returns the settings.json path of a scope for writing,
+// creating the local .claude directory if needed (see EnsureLocalClaudeDir)
+func GetSettingsPathForWrite(scope PathScope) (string, error) {
+	if scope != ScopeLocal {
+		return GetSettingsPathByScope(scope), nil
+	}
+	claudeDir, err := EnsureLocalClaudeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(claudeDir, "settings.json"), nil
+}
+
 // GetLocalSettingsPath returns the local settings.json path if exists
 // Returns empty string if local .claude/settings.json doesn't exist
 func GetLocalSettingsPath() string {
-	cwd, err := os.Getwd()
+	root, err := ProjectRoot()
 	if err != nil {
 		return ""
 	}
 
-	settingsPath := filepath.Join(cwd, localClaudeDir, "settings.json")
+	settingsPath := filepath.Join(root, localClaudeDir, "settings.json")
 	if _, err := os.Stat(settingsPath); os.IsNotExist(err) {
 		return ""
 	}
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"github.com/itda-skills/jindo/internal/pkg/pkgmgr"
 	"github.com/spf13/cobra"
 )
 
@@ -18,4 +19,6 @@ This command allows you to:
 
 func init() {
 	rootCmd.AddCommand(pkgCmd)
+	// Recorded in the receipts of installed packages
+	pkgmgr.InstallerVersion = Version
 }
@@ -0,0 +1,156 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/pkg/pkgmgr"
+	"github.com/itda-skills/jindo/internal/pkg/repo"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pkgAdoptSpec   string
+	pkgAdoptForce  bool
+	pkgAdoptGlobal bool
+	pkgAdoptLocal  bool
+)
+
+var pkgAdoptCmd = &cobra.Command{
+	Use:   "adopt <path-or-name> --spec namespace:path",
+	Short: "Manage a resource copied by hand from a registered repository",
+	Long: `Record a skill, command, agent, or hook that was copied by hand from a
+registered repository as an installed package, so 'jd outdated' and
+'jd pkg update' manage it from then on. Nothing is copied or renamed.
+
+The resource is given by path, or by name in the scope's skills, commands,
+agents, or hooks directory. Its files are compared with every version of the
+package in the repository's clone, and it is recorded at the newest version
+they match, so an update brings in the changes made since it was copied.
+
+If no version matches, e.g. because the copy was edited, adopt stops. With
+--force it records the current version instead, and the differences show as
+local modifications, such as in 'jd pkg update --interactive'.
+
+Default scope is local if a .claude directory exists, otherwise global.
+Use --global or --local to override.
+
+Example:
+  jd pkg adopt web-fetch --spec affa-ever:skills/web-fetch
+  jd pkg adopt ~/.claude/commands/deploy.md --spec team:commands/deploy.md
+  jd pkg adopt web-fetch --spec affa-ever:skills/web-fetch --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPkgAdopt,
+}
+
+func init() {
+	pkgCmd.AddCommand(pkgAdoptCmd)
+	pkgAdoptCmd.Flags().StringVarP(&pkgAdoptSpec, "spec", "s", "", "Package the resource was copied from (namespace:path)")
+	pkgAdoptCmd.Flags().BoolVarP(&pkgAdoptForce, "force", "f", false, "Adopt at the current version even if the content matches no version")
+	pkgAdoptCmd.Flags().BoolVarP(&pkgAdoptGlobal, "global", "g", false, "Look up the name in global ~/.claude")
+	pkgAdoptCmd.Flags().BoolVarP(&pkgAdoptLocal, "local", "l", false, "Look up the name in local .claude")
+	_ = pkgAdoptCmd.RegisterFlagCompletionFunc("spec", pkgSpecCompletion)
+}
+
+func runPkgAdopt(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+	defer syncIndex()
+
+	if pkgAdoptSpec == "" {
+		return usageError(cmd, errors.New("--spec is required"))
+	}
+	spec, err := pkgmgr.ParseSpec(pkgAdoptSpec)
+	if err != nil {
+		return fmt.Errorf("invalid specification. Format: namespace:path")
+	}
+
+	manager := pkgmgr.NewManager(basedir.DataDir())
+	if _, err := manager.RepoStore().Get(spec.Namespace); err != nil {
+		return notFoundErrorf("repository '%s' not found. Register with: jd pkg repo add gh:owner/repo", spec.Namespace)
+	}
+
+	path := args[0]
+	if _, err := os.Stat(path); err != nil {
+		scope, err := ResolveScope(pkgAdoptGlobal, pkgAdoptLocal)
+		if err != nil {
+			return err
+		}
+		pkgType, err := manager.SpecType(pkgAdoptSpec)
+		if err != nil {
+			return fmt.Errorf("resolve package: %w", err)
+		}
+		path, err = adoptResourcePath(scope, pkgType, args[0])
+		if err != nil {
+			return err
+		}
+		if _, err := os.Stat(path); err != nil {
+			return notFoundErrorf("%s '%s' not found in %s", pkgType, args[0], ScopeDescription(scope))
+		}
+	}
+	if path, err = filepath.Abs(path); err != nil {
+		return fmt.Errorf("resolve path: %w", err)
+	}
+
+	adoption, err := manager.Adopt(pkgAdoptSpec, path, pkgAdoptForce)
+	if err != nil {
+		switch {
+		case errors.Is(err, pkgmgr.ErrContentMismatch):
+			return validationErrorf("%s does not match any version of %s\nUse --force to adopt it at the current version, with the differences as local modifications", path, pkgAdoptSpec)
+		case errors.Is(err, pkgmgr.ErrPackageAlreadyInstalled):
+			return fmt.Errorf("package already installed. Use 'jd pkg update %s' to update", pkgAdoptSpec)
+		case errors.Is(err, pkgmgr.ErrNameCollision):
+			return validationErrorf("%v", err)
+		}
+		return fmt.Errorf("adopt: %w", err)
+	}
+
+	pkg := adoption.Package
+	fmt.Printf("Adopted successfully!\n")
+	fmt.Printf("  Name:      %s\n", pkg.Name)
+	fmt.Printf("  Type:      %s\n", pkg.Type)
+	fmt.Printf("  Source:    %s:%s\n", pkg.Namespace, pkg.SourcePath)
+	fmt.Printf("  Version:   %s (%s)\n", pkg.Version.Ref, pkg.Version.SHA[:8])
+	fmt.Printf("  Files:     %d\n", len(pkg.Files))
+
+	switch {
+	case !adoption.Matched:
+		fmt.Printf("\n⚠️  The content matches no version; the differences from the current version show as local modifications.\n")
+	case adoption.Behind > 0:
+		fmt.Printf("\n%d change(s) behind the repository. Run 'jd pkg update %s' to update.\n", adoption.Behind, pkg.Name)
+	}
+	return nil
+}
+
+// adoptResourcePath returns the path of the resource of a type named name in a scope
+func adoptResourcePath(scope PathScope, pkgType repo.PackageType, name string) (string, error) {
+	var subdir string
+	switch pkgType {
+	case repo.TypeSkill:
+		subdir = "skills"
+	case repo.TypeCommand:
+		subdir = "commands"
+	case repo.TypeAgent:
+		subdir = "agents"
+	case repo.TypeHook:
+		subdir = "hooks"
+	default:
+		return "", validationErrorf("cannot adopt %s packages", pkgType)
+	}
+	dir, err := basedir.Expand(GetPathByScope(scope, subdir))
+	if err != nil {
+		return "", fmt.Errorf("resolve %s directory: %w", subdir, err)
+	}
+
+	switch pkgType {
+	case repo.TypeCommand, repo.TypeAgent:
+		// Nested names map to subdirectories, as installed commands do
+		name = strings.TrimSuffix(name, ".md")
+		return filepath.Join(dir, filepath.Join(strings.Split(name, ":")...)+".md"), nil
+	default:
+		return filepath.Join(dir, name), nil
+	}
+}
@@ -4,16 +4,27 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/index"
 	"github.com/itda-skills/jindo/internal/pkg/pkgmgr"
 	"github.com/itda-skills/jindo/internal/pkg/repo"
+	"github.com/itda-skills/jindo/internal/sorting"
 	"github.com/itda-skills/jindo/internal/tui"
+	"github.com/itda-skills/jindo/pkg/config"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 var (
-	pkgBrowseType string
-	pkgBrowseJSON bool
+	pkgBrowseType  string
+	pkgBrowseJSON  bool
+	pkgBrowsePlain bool
+	pkgBrowseSort  string
 )
 
 var pkgBrowseCmd = &cobra.Command{
@@ -27,10 +38,40 @@ With a namespace argument, opens TUI filtered to that specific repository.
 
 Use --type to select the initial tab (TUI) or filter output (--json).
 Use --json for machine-readable output.
+Packages are listed by repository, then name, case-insensitively in the
+collation of your locale. Use --sort to list them by name, by type, most
+recently installed first, by the stars of their repository, or most recently
+updated first; --sort applies to --plain and --json output and to the TUI.
+Use --plain for a simple table of packages instead of the TUI. This is also
+what browse prints when stdin or stdout is not a terminal (e.g., in CI or when
+piped), since the TUI cannot run there.
+
+In the TUI, press ? for all key bindings. Besides the arrow keys and j/k,
+gg/G jump to the first/last package and ctrl+d/ctrl+u (or pgdown/pgup) page.
+The preview shows a skill, command, or agent's frontmatter (description,
+model, allowed tools) above its body; press v to switch to the raw file.
+Changes to installed.json and settings.json made elsewhere, such as by jd in
+another terminal, are reloaded while the TUI is open.
+
+Packages from GitHub repositories show their repository's stars and the date
+and author of their last change: in the preview header, as UPDATED and AUTHOR
+columns, and under "meta" in JSON. It is fetched through the GitHub API
+(GITHUB_TOKEN is used if set) and cached for a few hours in the jd data
+directory; set ` + pkgMetadataKey + ` = false to browse without it.
+
+Any action can be rebound in the config with tui.keys.<action>, set to a key
+or a list of keys; a two-key sequence is written with a space ("g g"):
+  jd config set tui.keys.install i
+  jd config edit    # [tui.keys] quit = ["q", "ctrl+c"]
+Actions: up, down, left, right, tab, top, bottom, page_up, page_down, select,
+select_all, install, uninstall, favorites, view, help, quit.
 
 Examples:
   jd pkg browse                     # Interactive TUI
   jd pkg browse affa-ever           # TUI filtered to affa-ever
+  jd pkg browse --plain             # Table of all packages
+  jd pkg browse | grep web          # Not a terminal: table output
+  jd pkg browse --sort updated      # Recently changed packages first
   jd pkg browse --json              # JSON output of all packages
   jd pkg browse affa-ever --json    # JSON output of affa-ever packages`,
 	Args:              cobra.MaximumNArgs(1),
@@ -42,6 +83,10 @@ func init() {
 	pkgCmd.AddCommand(pkgBrowseCmd)
 	pkgBrowseCmd.Flags().StringVarP(&pkgBrowseType, "type", "t", "", "Filter by type (skills, commands, agents, hooks)")
 	pkgBrowseCmd.Flags().BoolVar(&pkgBrowseJSON, "json", false, "Output in JSON format")
+	addNoTruncFlag(pkgBrowseCmd)
+	pkgBrowseCmd.Flags().BoolVar(&pkgBrowsePlain, "plain", false, "List packages as a table instead of opening the TUI")
+	pkgBrowseCmd.Flags().StringVar(&pkgBrowseSort, "sort", "", "Sort packages by name, type, installed, updated, or stars (default: by repository, then name)")
+	_ = pkgBrowseCmd.RegisterFlagCompletionFunc("sort", cobra.FixedCompletions(append(append([]string{}, sorting.Orders...), string(tui.SortStars)), cobra.ShellCompDirectiveNoFileComp))
 }
 
 func runPkgBrowse(cmd *cobra.Command, args []string) error {
@@ -51,12 +96,21 @@ func runPkgBrowse(cmd *cobra.Command, args []string) error {
 	if len(args) > 0 {
 		namespace = args[0]
 	}
+	sortOrder, err := pkgBrowseSortOrder()
+	if err != nil {
+		return err
+	}
 
 	// If JSON output is requested, use CLI mode
 	if pkgBrowseJSON {
 		return runPkgBrowseCLI(namespace)
 	}
 
+	// The TUI needs a terminal for both input and output
+	if pkgBrowsePlain || !isTerminal(os.Stdin) || !isTerminal(os.Stdout) {
+		return runPkgBrowsePlain(namespace)
+	}
+
 	// Validate/parse type for TUI starting tab
 	var startTab tui.Tab
 	switch pkgBrowseType {
@@ -73,37 +127,218 @@ func runPkgBrowse(cmd *cobra.Command, args []string) error {
 	}
 
 	// Launch TUI (with optional namespace filter)
-	manager := pkgmgr.NewManager("~/.itda-skills")
+	manager := pkgmgr.NewManager(basedir.DataDir())
+	enableAutoSnapshot(manager)
+	if err := applyPackageNaming(manager); err != nil {
+		return err
+	}
 
 	// Validate namespace exists if provided
+	store := repo.NewStore(basedir.DataDir())
+	repos, err := store.List()
+	if err != nil {
+		return fmt.Errorf("list repositories: %w", err)
+	}
 	if namespace != "" {
-		store := repo.NewStore("~/.itda-skills")
-		if _, err := store.Get(namespace); err != nil {
-			return fmt.Errorf("repository '%s' not found", namespace)
+		r, err := store.Get(namespace)
+		if err != nil {
+			return notFoundErrorf("repository '%s' not found", namespace)
 		}
+		repos = []repo.RepoConfig{*r}
 	}
 
-	return tui.Run(manager, namespace, startTab)
+	// The TUI leaves out repositories with broken clones, so offer to repair them first
+	if broken := brokenClones(store, repos); len(broken) > 0 {
+		if err := repairClones(store, broken, false); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
+
+	keyOverrides, err := tuiKeyOverrides()
+	if err != nil {
+		return err
+	}
+
+	// Packages installed or uninstalled in the TUI are reindexed when it exits
+	defer syncIndex()
+	return tui.Run(manager, tui.Options{
+		Namespace:    namespace,
+		StartTab:     startTab,
+		KeyOverrides: keyOverrides,
+		Filter:       browseFilter(),
+		Sort:         sortOrder,
+		Metadata:     packageMetadataEnabled(),
+	})
 }
 
-func runPkgBrowseCLI(namespace string) error {
-	store := repo.NewStore("~/.itda-skills")
+// browseFilter matches the browse filter query against the names, descriptions,
+// and contents of repository packages in the package index, or returns nil to
+// match names only when indexing is disabled
+func browseFilter() tui.FilterFunc {
+	ix := loadIndex()
+	if ix == nil {
+		return nil
+	}
+	var sources []string
+	for source := range ix.Sources() {
+		if index.RepoNamespace(source) != "" {
+			sources = append(sources, source)
+		}
+	}
+	return func(query string) map[string]bool {
+		if len(index.Tokenize(query)) == 0 {
+			return nil
+		}
+		matches := make(map[string]bool)
+		for _, hit := range ix.Search(query, sources...) {
+			matches[hit.Namespace+":"+hit.Path] = true
+		}
+		return matches
+	}
+}
+
+// tuiKeysKey is the config table that rebinds browse TUI actions (tui.keys.<action>)
+const tuiKeysKey = "tui.keys"
 
-	// Validate type filter
-	var typeFilter repo.PackageType
+// tuiKeyOverrides reads the browse TUI key bindings set in config. Each action is
+// set to a key or a list of keys.
+func tuiKeyOverrides() (map[string][]string, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	value, err := cfg.Get(tuiKeysKey)
+	if err != nil {
+		return nil, nil
+	}
+	table, ok := value.(map[string]any)
+	if !ok {
+		return nil, validationErrorf("%s must be a table of actions to keys", tuiKeysKey)
+	}
+
+	actions := make(map[string]bool)
+	for _, a := range tui.KeyActions() {
+		actions[a] = true
+	}
+
+	overrides := make(map[string][]string, len(table))
+	for action, v := range table {
+		if !actions[action] {
+			return nil, validationErrorf("unknown action %s.%s (valid: %s)", tuiKeysKey, action, strings.Join(tui.KeyActions(), ", "))
+		}
+		switch v := v.(type) {
+		case string:
+			overrides[action] = []string{v}
+		case int64:
+			// Digit keys are stored as numbers by 'jd config set'
+			overrides[action] = []string{strconv.FormatInt(v, 10)}
+		case []any:
+			overrides[action] = cfg.GetStringSlice(tuiKeysKey + "." + action)
+		default:
+			return nil, validationErrorf("%s.%s must be a key or a list of keys", tuiKeysKey, action)
+		}
+	}
+	return overrides, nil
+}
+
+// isTerminal reports whether f is a terminal
+func isTerminal(f *os.File) bool {
+	return term.IsTerminal(int(f.Fd()))
+}
+
+// pkgBrowseTypeFilter parses --type for the non-TUI listings
+func pkgBrowseTypeFilter() (repo.PackageType, error) {
 	switch pkgBrowseType {
 	case "":
-		// No filter
+		return "", nil
 	case "skills", "skill":
-		typeFilter = repo.TypeSkill
+		return repo.TypeSkill, nil
 	case "commands", "command":
-		typeFilter = repo.TypeCommand
+		return repo.TypeCommand, nil
 	case "agents", "agent":
-		typeFilter = repo.TypeAgent
+		return repo.TypeAgent, nil
 	case "hooks", "hook":
-		typeFilter = repo.TypeHook
+		return repo.TypeHook, nil
 	default:
-		return fmt.Errorf("invalid type: %s (use: skills, commands, agents, hooks)", pkgBrowseType)
+		return "", fmt.Errorf("invalid type: %s (use: skills, commands, agents, hooks)", pkgBrowseType)
+	}
+}
+
+// runPkgBrowsePlain lists packages as tables, one per repository, in place of the TUI
+func runPkgBrowsePlain(namespace string) error {
+	store := repo.NewStore(basedir.DataDir())
+
+	typeFilter, err := pkgBrowseTypeFilter()
+	if err != nil {
+		return err
+	}
+	sortOrder, err := pkgBrowseSortOrder()
+	if err != nil {
+		return err
+	}
+
+	var repos []repo.RepoConfig
+	if namespace != "" {
+		r, err := store.Get(namespace)
+		if err != nil {
+			return notFoundErrorf("repository '%s' not found", namespace)
+		}
+		repos = []repo.RepoConfig{*r}
+	} else {
+		repos, err = store.List()
+		if err != nil {
+			return fmt.Errorf("list repositories: %w", err)
+		}
+	}
+
+	installed := installTimes()
+	results := make(map[string][]repo.BrowseItem)
+	var namespaces []string
+	for _, r := range repos {
+		items, err := store.Browse(r.Namespace, typeFilter)
+		if err != nil {
+			// A single repository is reported; when listing all, skip it as the TUI does
+			if namespace != "" {
+				return fmt.Errorf("browse repository: %w", brokenCloneError(namespace, err))
+			}
+			continue
+		}
+		if len(items) > 0 {
+			addBrowseMeta(store, r.Namespace, items)
+			sortBrowseItems(items, sortOrder, installed)
+			results[r.Namespace] = items
+			namespaces = append(namespaces, r.Namespace)
+		}
+	}
+
+	if len(results) == 0 {
+		fmt.Println("No packages found.")
+		return nil
+	}
+
+	// Repositories are listed by name, or by stars
+	sort.SliceStable(namespaces, func(i, j int) bool { return sorting.Less(namespaces[i], namespaces[j]) })
+	if sortOrder == tui.SortStars {
+		sort.SliceStable(namespaces, func(i, j int) bool {
+			return metaStars(results[namespaces[i]][0].Meta) > metaStars(results[namespaces[j]][0].Meta)
+		})
+	}
+	total := printPackageTables(results, namespaces)
+	fmt.Printf("Total: %d packages in %d repositories\n", total, len(results))
+	return nil
+}
+
+func runPkgBrowseCLI(namespace string) error {
+	store := repo.NewStore(basedir.DataDir())
+
+	typeFilter, err := pkgBrowseTypeFilter()
+	if err != nil {
+		return err
+	}
+	sortOrder, err := pkgBrowseSortOrder()
+	if err != nil {
+		return err
 	}
 
 	// If no namespace, browse all repositories
@@ -119,8 +354,10 @@ func runPkgBrowseCLI(namespace string) error {
 			if err != nil {
 				continue
 			}
+			addBrowseMeta(store, r.Namespace, items)
 			allItems = append(allItems, items...)
 		}
+		sortBrowseItems(allItems, sortOrder, installTimes())
 
 		output, err := json.MarshalIndent(allItems, "", "  ")
 		if err != nil {
@@ -133,20 +370,22 @@ func runPkgBrowseCLI(namespace string) error {
 	// Get repository info
 	config, err := store.Get(namespace)
 	if err != nil {
-		return fmt.Errorf("repository '%s' not found", namespace)
+		return notFoundErrorf("repository '%s' not found", namespace)
 	}
 
 	fmt.Fprintf(os.Stderr, "Browsing %s (%s)...\n\n", namespace, config.URL)
 
 	items, err := store.Browse(namespace, typeFilter)
 	if err != nil {
-		return fmt.Errorf("browse repository: %w", err)
+		return fmt.Errorf("browse repository: %w", brokenCloneError(namespace, err))
 	}
 
 	if len(items) == 0 {
 		fmt.Println("[]")
 		return nil
 	}
+	addBrowseMeta(store, namespace, items)
+	sortBrowseItems(items, sortOrder, installTimes())
 
 	output, err := json.MarshalIndent(items, "", "  ")
 	if err != nil {
@@ -157,6 +396,118 @@ func runPkgBrowseCLI(namespace string) error {
 	return nil
 }
 
+// pkgMetadataKey disables fetching the stars and last changes of packages from
+// GitHub when set to false
+const pkgMetadataKey = "pkg.metadata"
+
+// packageMetadataEnabled reports whether pkg.metadata is not set to false
+func packageMetadataEnabled() bool {
+	cfg, err := config.Load()
+	if err != nil {
+		return true
+	}
+	return cfg.GetBool(pkgMetadataKey, true)
+}
+
+// pkgBrowseSortOrder parses --sort
+func pkgBrowseSortOrder() (tui.SortOrder, error) {
+	value, err := sorting.ParseOrder(pkgBrowseSort, string(tui.SortStars))
+	if err != nil {
+		return "", err
+	}
+	order := tui.SortOrder(value)
+	if (order == tui.SortStars || order == tui.SortUpdated) && !packageMetadataEnabled() {
+		return "", validationErrorf("--sort %s needs package metadata, which %s = false turns off", order, pkgMetadataKey)
+	}
+	return order, nil
+}
+
+// addBrowseMeta fetches the stars and last changes of a repository's packages
+// unless pkg.metadata is false, warning if they cannot all be fetched
+func addBrowseMeta(store *repo.Store, namespace string, items []repo.BrowseItem) {
+	if !packageMetadataEnabled() {
+		return
+	}
+	if err := store.AddMeta(namespace, items); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: package metadata of %s: %v\n", namespace, err)
+	}
+}
+
+// sortBrowseItems sorts packages in a sort order, as the TUI does: ties are
+// ordered by repository and name (by name and repository for --sort name).
+// installed holds the install times of packages by namespace:path.
+func sortBrowseItems(items []repo.BrowseItem, order tui.SortOrder, installed map[string]time.Time) {
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if order == tui.SortName {
+			if c := sorting.Compare(a.Name, b.Name); c != 0 {
+				return c < 0
+			}
+			return sorting.Less(a.Namespace, b.Namespace)
+		}
+		if c := sorting.Compare(a.Namespace, b.Namespace); c != 0 {
+			return c < 0
+		}
+		return sorting.Less(a.Name, b.Name)
+	})
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		switch order {
+		case tui.SortType:
+			return packageTypeRank(a.Type) < packageTypeRank(b.Type)
+		case tui.SortInstalled:
+			return installed[a.Namespace+":"+a.Path].After(installed[b.Namespace+":"+b.Path])
+		case tui.SortStars:
+			return metaStars(a.Meta) > metaStars(b.Meta)
+		case tui.SortUpdated:
+			return a.Meta != nil && (b.Meta == nil || a.Meta.UpdatedAt.After(b.Meta.UpdatedAt))
+		default:
+			return false
+		}
+	})
+}
+
+// installTimes returns when installed packages were installed, by
+// namespace:path of their source
+func installTimes() map[string]time.Time {
+	times := make(map[string]time.Time)
+	packages, err := pkgmgr.NewManager(basedir.DataDir()).List()
+	if err != nil {
+		return times
+	}
+	for _, pkg := range packages {
+		key := pkg.Namespace + ":" + pkg.SourcePath
+		if pkg.InstalledAt.After(times[key]) {
+			times[key] = pkg.InstalledAt
+		}
+	}
+	return times
+}
+
+// metaStars returns the stars of fetched metadata, or 0 if none was fetched
+func metaStars(meta *repo.PackageMeta) int {
+	if meta == nil {
+		return 0
+	}
+	return meta.Stars
+}
+
+// formatMetaDate returns the date of a package's last change, or "-" if unknown
+func formatMetaDate(meta *repo.PackageMeta) string {
+	if meta == nil || meta.UpdatedAt.IsZero() {
+		return "-"
+	}
+	return meta.UpdatedAt.Local().Format("2006-01-02")
+}
+
+// metaAuthor returns the author of a package's last change, or "-" if unknown
+func metaAuthor(meta *repo.PackageMeta) string {
+	if meta == nil || meta.Author == "" {
+		return "-"
+	}
+	return meta.Author
+}
+
 // pkgBrowseCompletion provides tab completion for repository namespaces
 func pkgBrowseCompletion(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
 	// Only complete first argument
@@ -164,7 +515,7 @@ func pkgBrowseCompletion(_ *cobra.Command, args []string, _ string) ([]string, c
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
 
-	store := repo.NewStore("~/.itda-skills")
+	store := repo.NewStore(basedir.DataDir())
 	repos, err := store.List()
 	if err != nil {
 		return nil, cobra.ShellCompDirectiveNoFileComp
@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/pkg/pkgmgr"
+	"github.com/itda-skills/jindo/internal/progress"
+	"github.com/spf13/cobra"
+)
+
+var pkgChannelCmd = &cobra.Command{
+	Use:   "channel <name> [stable|edge]",
+	Short: "Show or switch the update channel of a package",
+	Long: `Show the update channel of an installed package, or move it to another one.
+
+  stable  the repository's latest release tag (such as v1.2.0); 'jd pkg update'
+          moves the package from release to release only
+  edge    the head of the repository's tracked branch (the default)
+
+Switching reinstalls the package from the other channel right away, under the
+same name and into the same directory. A pin is dropped. Packages installed
+from an archive or plugin have no channels.
+
+Examples:
+  jd pkg channel affa-ever--web-fetch
+  jd pkg channel affa-ever--web-fetch stable
+  jd pkg channel affa-ever--web-fetch edge`,
+	Args: cobra.RangeArgs(1, 2),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 1 {
+			return pkgmgr.Channels(), cobra.ShellCompDirectiveNoFileComp
+		}
+		return installedPackageCompletion(cmd, args, toComplete)
+	},
+	RunE: runPkgChannel,
+}
+
+func init() {
+	pkgCmd.AddCommand(pkgChannelCmd)
+}
+
+func runPkgChannel(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+	name := args[0]
+
+	manager := pkgmgr.NewManager(basedir.DataDir())
+	pkg, err := manager.Get(name)
+	if err != nil {
+		if errors.Is(err, pkgmgr.ErrPackageNotFound) {
+			return notFoundErrorf("package '%s' not found. Use 'jd pkg list' to see installed packages", name)
+		}
+		return fmt.Errorf("get package: %w", err)
+	}
+
+	current := pkg.Channel
+	if current == "" {
+		current = pkgmgr.ChannelEdge
+	}
+	if len(args) == 1 {
+		fmt.Printf("%s: %s (%s)\n", pkg.Name, current, packageVersion(pkg))
+		return nil
+	}
+
+	channel := args[1]
+	if !pkgmgr.ValidChannel(channel) {
+		return validationErrorf("invalid channel: %s (use: %s)", channel, strings.Join(pkgmgr.Channels(), ", "))
+	}
+	if channel == current {
+		fmt.Printf("%s is already on the %s channel (%s).\n", pkg.Name, channel, packageVersion(pkg))
+		return nil
+	}
+
+	defer syncIndex()
+	manager.SetProgress(progress.New(os.Stderr))
+	enableAutoSnapshot(manager)
+
+	fmt.Printf("Switching %s from %s to %s...\n", pkg.Name, current, channel)
+	updated, err := manager.SwitchChannel(pkg.Name, channel)
+	if err != nil {
+		if errors.Is(err, pkgmgr.ErrNoReleases) {
+			return validationErrorf("%s: %v", pkg.Namespace, err)
+		}
+		return fmt.Errorf("failed to switch channel: %w", err)
+	}
+	if updated.Name != pkg.Name {
+		fmt.Printf("Renamed upstream to %s; installed as %s\n", updated.SourcePath, updated.Name)
+		syncPackageSkillReference(pkg, false)
+	}
+	syncPackageSkillReference(updated, true)
+	fmt.Printf("✅ %s is on the %s channel (%s)\n", updated.Name, channel, packageVersion(updated))
+	return nil
+}
+
+// packageVersion returns the release tag or short commit a package is installed at
+func packageVersion(pkg *pkgmgr.InstalledPackage) string {
+	if pkg.Version.Type == "tag" {
+		return pkg.Version.Ref
+	}
+	return shortSHA(pkg.Version.SHA)
+}
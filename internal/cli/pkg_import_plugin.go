@@ -0,0 +1,178 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/pkg/pkgmgr"
+	"github.com/itda-skills/jindo/internal/pkg/repo"
+	"github.com/itda-skills/jindo/internal/plugin"
+	"github.com/itda-skills/jindo/internal/progress"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pkgImportPluginLocal      bool
+	pkgImportPluginNamespace  string
+	pkgImportPluginPlugins    []string
+	pkgImportPluginNoRegister bool
+	pkgImportPluginYes        bool
+)
+
+var pkgImportPluginCmd = &cobra.Command{
+	Use:   "import-plugin <path-or-url>",
+	Short: "Import a Claude Code plugin or marketplace as packages",
+	Long: `Import the skills, commands, agents, and hooks of a Claude Code plugin as
+jd packages, to manage them with jd instead of a plugin marketplace.
+
+The source is one of:
+  - a plugin directory (with .claude-plugin/plugin.json)
+  - a marketplace directory (with .claude-plugin/marketplace.json), whose
+    plugins are all imported unless --plugin selects some
+  - a .tar.gz/.tgz/.zip archive of either, as a path or URL
+  - a git repository of either: gh:owner/repo, or a clone URL
+
+Each skill, command, and agent becomes a package in the plugin's namespace
+(--namespace to change it), such as team-tools--deploy; a plugin whose name is
+not a valid namespace is skipped unless --namespace is given. A hook that runs a
+script of the plugin (${CLAUDE_PLUGIN_ROOT}/...) installs the script as a hook
+package and registers the plugin's rule for it in settings.json, after
+confirmation (--yes to register without asking, --no-register to only install
+the scripts). Hooks running no script of the plugin are listed as skipped.
+
+Imported packages record the source and plugin they came from ('jd pkg info'),
+so 'jd pkg update' reimports changed ones from it and 'jd pkg uninstall'
+removes them.`,
+	Example: `  jd pkg import-plugin ./team-tools
+  jd pkg import-plugin gh:acme/claude-plugins --plugin formatter --plugin reviewer
+  jd pkg import-plugin https://example.com/team-tools.zip --namespace team
+  jd pkg import-plugin ./team-tools --local --yes`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPkgImportPlugin,
+}
+
+func init() {
+	pkgCmd.AddCommand(pkgImportPluginCmd)
+	pkgImportPluginCmd.Flags().BoolVarP(&pkgImportPluginLocal, "local", "l", false, "Import into the project's .claude directory")
+	pkgImportPluginCmd.Flags().StringVarP(&pkgImportPluginNamespace, "namespace", "n", "", "Namespace of the imported packages (default: plugin name)")
+	pkgImportPluginCmd.Flags().StringSliceVar(&pkgImportPluginPlugins, "plugin", nil, "Plugin of a marketplace to import (repeatable; default: all)")
+	pkgImportPluginCmd.Flags().BoolVar(&pkgImportPluginNoRegister, "no-register", false, "Install hook scripts without registering them in settings.json")
+	pkgImportPluginCmd.Flags().BoolVarP(&pkgImportPluginYes, "yes", "y", false, "Import and register hooks without confirmation")
+}
+
+func runPkgImportPlugin(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+	defer syncIndex()
+	source := args[0]
+
+	if pkgImportPluginNamespace != "" {
+		if issue := repo.NamespaceIssue(pkgImportPluginNamespace); issue != "" {
+			return validationErrorf("invalid namespace %q: %s", pkgImportPluginNamespace, issue)
+		}
+	}
+
+	manager := pkgmgr.NewManager(basedir.DataDir())
+	manager.SetProgress(progress.New(os.Stderr))
+	enableAutoSnapshot(manager)
+	if err := applyPackageNaming(manager); err != nil {
+		return err
+	}
+
+	scope := ScopeGlobal
+	if pkgImportPluginLocal || projectRootSelected() {
+		scope = ScopeLocal
+		root, err := ProjectRoot()
+		if err != nil {
+			return fmt.Errorf("resolve project root: %w", err)
+		}
+		manager.SetClaudeDir(filepath.Join(root, localClaudeDir))
+	}
+
+	opts := pkgmgr.PluginImportOptions{
+		Namespace: pkgImportPluginNamespace,
+		Plugins:   pkgImportPluginPlugins,
+	}
+	if !pkgImportPluginNoRegister {
+		opts.SettingsPath = GetSettingsPathByScope(scope)
+	}
+	if !pkgImportPluginYes {
+		opts.ConfirmHooks = confirmPluginHooks
+	}
+
+	fmt.Printf("Importing %s into %s...\n", source, ScopeDescription(scope))
+	results, err := manager.ImportPlugin(source, opts)
+	printPluginImports(results, opts.SettingsPath)
+	if err != nil {
+		switch {
+		case errors.Is(err, pkgmgr.ErrPluginNotFound):
+			return notFoundErrorf("%v", err)
+		case errors.Is(err, plugin.ErrNoPlugin):
+			return validationErrorf("%s: %v", source, err)
+		case os.IsNotExist(err):
+			return notFoundErrorf("source not found: %s", source)
+		}
+		return fmt.Errorf("import plugin: %w", err)
+	}
+
+	for _, result := range results {
+		for _, pkg := range result.Packages {
+			syncPackageSkillReference(pkg, true)
+		}
+	}
+	return nil
+}
+
+// confirmPluginHooks shows the hooks of a plugin and asks whether to import them
+func confirmPluginHooks(name string, commands []string) bool {
+	fmt.Printf("\nPlugin %s has hooks, which run shell commands automatically on Claude Code events:\n", name)
+	for _, c := range commands {
+		fmt.Printf("  %s\n", c)
+	}
+	fmt.Print("Import and register them? (y/N): ")
+
+	reader := bufio.NewReader(os.Stdin)
+	response, _ := reader.ReadString('\n')
+	response = strings.TrimSpace(strings.ToLower(response))
+	return response == "y" || response == "yes"
+}
+
+// printPluginImports prints the packages each plugin was imported as, and what was skipped
+func printPluginImports(results []pkgmgr.PluginImport, settingsPath string) {
+	total, imported := 0, 0
+	for _, result := range results {
+		title := result.Plugin
+		if result.Version != "" {
+			title += " " + result.Version
+		}
+		fmt.Printf("\n%s\n", title)
+
+		for _, pkg := range result.Packages {
+			fmt.Printf("  ✓ %-8s %s\n", pkg.Type, pkg.Name)
+			if pkg.Hook != nil {
+				matcher := pkg.Hook.Matcher
+				if matcher == "" {
+					matcher = "*"
+				}
+				fmt.Printf("             registered for %s (matcher: %s) in %s\n", pkg.Hook.EventType, matcher, settingsPath)
+			}
+		}
+		for _, note := range result.Skipped {
+			fmt.Printf("  - skipped  %s\n", note)
+		}
+		if len(result.Packages) == 0 && len(result.Skipped) == 0 {
+			fmt.Println("  (no skills, commands, agents, or hooks)")
+		}
+		total += len(result.Packages)
+		if len(result.Packages) > 0 {
+			imported++
+		}
+	}
+	if len(results) > 0 {
+		fmt.Printf("\nImported %d package(s) from %d plugin(s).\n", total, imported)
+	}
+}
@@ -1,10 +1,11 @@
 package cli
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 
+	"github.com/itda-skills/jindo/internal/basedir"
 	"github.com/itda-skills/jindo/internal/pkg/pkgmgr"
 	"github.com/spf13/cobra"
 )
@@ -28,29 +29,25 @@ Example:
 func init() {
 	pkgCmd.AddCommand(pkgInfoCmd)
 	pkgInfoCmd.Flags().BoolVar(&pkgInfoJSON, "json", false, "Output in JSON format")
+	addOutputFlags(pkgInfoCmd)
 }
 
 func runPkgInfo(cmd *cobra.Command, args []string) error {
 	cmd.SilenceUsage = true
 	name := args[0]
 
-	manager := pkgmgr.NewManager("~/.itda-skills")
+	manager := pkgmgr.NewManager(basedir.DataDir())
 
 	pkg, err := manager.Get(name)
 	if err != nil {
 		if errors.Is(err, pkgmgr.ErrPackageNotFound) {
-			return fmt.Errorf("package '%s' not found. Use 'jd pkg list' to see installed packages", name)
+			return notFoundErrorf("package '%s' not found. Use 'jd pkg list' to see installed packages", name)
 		}
 		return fmt.Errorf("get package: %w", err)
 	}
 
-	if pkgInfoJSON {
-		output, err := json.MarshalIndent(pkg, "", "  ")
-		if err != nil {
-			return err
-		}
-		fmt.Println(string(output))
-		return nil
+	if pkgInfoJSON || formattedOutput() {
+		return printJSON(pkg)
 	}
 
 	fmt.Printf("Name:          %s\n", pkg.Name)
@@ -61,8 +58,35 @@ func runPkgInfo(cmd *cobra.Command, args []string) error {
 	fmt.Printf("Version Type:  %s\n", pkg.Version.Type)
 	fmt.Printf("Version SHA:   %s\n", pkg.Version.SHA)
 	fmt.Printf("Version Ref:   %s\n", pkg.Version.Ref)
+	if pkg.Channel != "" {
+		fmt.Printf("Channel:       %s\n", pkg.Channel)
+	}
+	if pkg.Pin != nil {
+		fmt.Printf("Pinned At:     %s\n", pkg.Pin.Ref)
+	}
+	if pkg.Target != "" {
+		fmt.Printf("Target:        %s (%s)\n", pkgmgr.TargetName(pkg.Target), pkgmgr.TargetRulesDir(pkg.Target, pkg.ProjectRoot))
+	}
+	if pkg.Scope != "" {
+		fmt.Printf("Scope:         %s\n", pkg.Scope)
+	}
+	if pkg.RepoURL != "" {
+		fmt.Printf("Repository:    %s\n", pkg.RepoURL)
+	}
+	if pkg.Source != "" {
+		fmt.Printf("Source:        %s\n", pkg.Source)
+	}
+	if pkg.Bundle != "" {
+		fmt.Printf("Bundle:        %s\n", pkg.Bundle)
+	}
 	fmt.Printf("Installed At:  %s\n", pkg.InstalledAt.Format("2006-01-02 15:04:05"))
 	fmt.Printf("Updated At:    %s\n", pkg.UpdatedAt.Format("2006-01-02 15:04:05"))
+	if pkg.Hook != nil {
+		fmt.Printf("Hook:          %s (matcher: %s) in %s\n", pkg.Hook.EventType, pkg.Hook.Matcher, pkg.Hook.SettingsPath)
+	}
+	if len(pkg.Only) > 0 {
+		fmt.Printf("Only:          %s\n", strings.Join(pkg.Only, ", "))
+	}
 	fmt.Printf("Files:         %d\n", len(pkg.Files))
 
 	if len(pkg.Files) > 0 {
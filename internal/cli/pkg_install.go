@@ -1,18 +1,45 @@
 package cli
 
 import (
+	"bufio"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/index"
 	"github.com/itda-skills/jindo/internal/pkg/pkgmgr"
+	"github.com/itda-skills/jindo/internal/pkg/repo"
+	"github.com/itda-skills/jindo/internal/progress"
+	"github.com/itda-skills/jindo/pkg/config"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var (
+	pkgInstallLocal      bool
+	pkgInstallFromURL    string
+	pkgInstallNamespace  string
+	pkgInstallRegister   bool
+	pkgInstallNoRegister bool
+	pkgInstallSkipSetup  bool
+	pkgInstallRunSetup   bool
+	pkgInstallYes        bool
+	pkgInstallTarget     string
+	pkgInstallTypes      []string
+	pkgInstallOnly       []string
+	pkgInstallChannel    string
 )
 
 var pkgInstallCmd = &cobra.Command{
 	Use:     "install <namespace:path[@version]>",
 	Aliases: []string{"i"},
-	Short:   "Install a package from a registered repository",
-	Long: `Install a package from a registered repository.
+	Short:   "Install a package from a registered repository or archive",
+	Long: `Install a package from a registered repository, or from a
+.tar.gz/.tgz/.zip archive with --from-url.
 
 The specification format is: namespace:path[@version]
 - namespace: The repository namespace (from 'jd pkg repo list')
@@ -24,22 +51,163 @@ Examples:
   jd pkg install affa-ever:commands/commit.md
   jd pkg install affa-ever:skills/web-fetch@v1.2.0
 
+The path 'all' installs every package of the repository, or those of the
+types given with --type. Packages already installed are left as they are, one
+that fails does not stop the others, and a table of the results is printed at
+the end. Hooks and commands from an untrusted repository are confirmed once
+for all of them; declining skips them. Setup is not asked for package by
+package: hook rules are registered after one confirmation (or with --register),
+and the configuration keys and setup steps packages need are listed at the
+end.
+  jd pkg install affa-ever:all
+  jd pkg install affa-ever:all --type skills,agents
+
 Installed packages are placed in ~/.itda-skills/ with namespace prefixes:
   ~/.itda-skills/skills/affa-ever--web-fetch/
-  ~/.itda-skills/commands/affa-ever--commit.md`,
-	Args: cobra.ExactArgs(1),
-	RunE: runPkgInstall,
+  ~/.itda-skills/commands/affa-ever--commit.md
+
+Use --local (or --scope-path / --project-root) to install into a project's
+.claude directory instead of ~/.claude:
+  jd --scope-path packages/app-a pkg install affa-ever:skills/web-fetch
+
+Use --from-url to install a single package from an archive URL or local
+archive path, outside registered repositories. The archive must contain a
+skill (SKILL.md) or exactly one package in skills/, commands/, agents/, or
+hooks/. The source and archive SHA-256 are recorded in installed.json:
+  jd pkg install --from-url https://example.com/skill.tar.gz
+  jd pkg install --from-url ./my-skill.zip --namespace myteam
+
+Hook scripts can declare how they are wired in their header comments, in the
+format 'jd hooks new' writes:
+  #!/usr/bin/env sh
+  # Hook: PostToolUse
+  # Matcher: Edit|Write
+When a hook package declares an event, install offers to add the matching
+rule to settings.json (--register to add it without asking, --no-register to
+skip). Uninstalling the package removes the rule again.
+
+Hooks and commands can run shell commands on your machine. Installing one from
+a repository that is not trusted prints a warning and asks for confirmation
+(--yes to install without asking). Trust a repository with
+'jd pkg repo trust <namespace>'.
+
+Use --target to install a skill, command, or agent as a rule for another AI
+editor in the current project (or --project-root). The package's markdown is
+written with the frontmatter that editor expects:
+  cursor     .cursor/rules/<name>.mdc
+  windsurf   .windsurf/rules/<name>.md
+  copilot    .github/instructions/<name>.instructions.md
+The target is recorded in installed.json, so 'jd pkg update' and
+'jd pkg uninstall' work on the rule, and 'jd pkg list' lists it separately:
+  jd pkg install affa-ever:skills/go-style --target cursor
+
+Use --channel stable to install a package as it is at the repository's latest
+release tag (such as v1.2.0; pre-releases such as v1.3.0-rc1 are left out)
+instead of the head of its tracked branch. The channel is recorded, so
+'jd pkg update' moves the package from release to release only; the default,
+edge, follows the branch. 'jd pkg channel' switches an installed package:
+  jd pkg install affa-ever:skills/web-fetch --channel stable
+
+Use --only to install some of a skill's files, such as when it bundles
+reference docs you do not need. Patterns are globs relative to the skill
+directory; one that matches a directory selects everything in it, and the
+skill's SKILL.md is always installed. The selection is recorded, so
+'jd pkg update' installs the same files and ignores changes to the others:
+  jd pkg install affa-ever:skills/docs --only 'SKILL.md,templates/*'
+
+Skill packages can include a POST_INSTALL.md that is shown after installation.
+Its frontmatter may list jd config keys the skill needs; install asks for any
+that are not set yet (--skip-setup to only list them):
+  ---
+  requires_config:
+    - key: common.api_keys.tiingo
+      description: Tiingo API key
+      env: TIINGO_API_KEY
+  ---
+A skill's SKILL.md can also declare the keys it reads under config: (see
+'jd config check'); install asks for the required ones in the same way,
+checking values against the declared type and not echoing secrets.
+
+Packages can declare commands to run once after installation, such as
+installing a linter or downloading a model: under setup: in POST_INSTALL.md
+frontmatter, or in "# Setup:" lines of a hook script's header. Install shows
+them and asks before running them (--run-setup to run them without asking,
+--skip-setup to run them later with 'jd pkg setup'). Steps that ran are
+recorded and never run again unless their command changes; see
+'jd pkg setup --help' for how they run.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if pkgInstallFromURL != "" {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
+	ValidArgsFunction: pkgSpecCompletion,
+	RunE:              runPkgInstall,
 }
 
 func init() {
 	pkgCmd.AddCommand(pkgInstallCmd)
+	pkgInstallCmd.Flags().BoolVarP(&pkgInstallLocal, "local", "l", false, "Install into the project's .claude directory")
+	pkgInstallCmd.Flags().StringVar(&pkgInstallFromURL, "from-url", "", "Install from an archive URL or local .tar.gz/.tgz/.zip path")
+	pkgInstallCmd.Flags().StringVarP(&pkgInstallNamespace, "namespace", "n", "", "Namespace for --from-url packages (default: "+pkgmgr.ArchiveNamespace+")")
+	pkgInstallCmd.Flags().BoolVar(&pkgInstallRegister, "register", false, "Register a hook package in settings.json without asking")
+	pkgInstallCmd.Flags().BoolVar(&pkgInstallNoRegister, "no-register", false, "Do not register a hook package in settings.json")
+	pkgInstallCmd.MarkFlagsMutuallyExclusive("register", "no-register")
+	pkgInstallCmd.Flags().BoolVar(&pkgInstallSkipSetup, "skip-setup", false, "Do not prompt for configuration or run setup steps the package requires")
+	pkgInstallCmd.Flags().BoolVar(&pkgInstallRunSetup, "run-setup", false, "Run the package's setup steps without confirmation")
+	pkgInstallCmd.MarkFlagsMutuallyExclusive("skip-setup", "run-setup")
+	pkgInstallCmd.Flags().BoolVarP(&pkgInstallYes, "yes", "y", false, "Install hooks and commands from untrusted repositories without confirmation")
+	pkgInstallCmd.Flags().StringVar(&pkgInstallTarget, "target", pkgmgr.TargetClaude, "Assistant to install for ("+pkgmgr.TargetClaude+", "+strings.Join(pkgmgr.Targets(), ", ")+")")
+	pkgInstallCmd.MarkFlagsMutuallyExclusive("target", "from-url")
+	pkgInstallCmd.Flags().StringSliceVarP(&pkgInstallTypes, "type", "t", nil, "Types of packages to install with <namespace>:all (skills, commands, agents, hooks)")
+	pkgInstallCmd.Flags().StringSliceVar(&pkgInstallOnly, "only", nil, "Install only the files of a skill matching these glob patterns (SKILL.md is always installed)")
+	pkgInstallCmd.MarkFlagsMutuallyExclusive("only", "from-url")
+	pkgInstallCmd.MarkFlagsMutuallyExclusive("only", "target")
+	pkgInstallCmd.Flags().StringVar(&pkgInstallChannel, "channel", "", "Update channel: "+pkgmgr.ChannelStable+" (release tags) or "+pkgmgr.ChannelEdge+" (the tracked branch, the default)")
+	pkgInstallCmd.MarkFlagsMutuallyExclusive("channel", "from-url")
+	_ = pkgInstallCmd.RegisterFlagCompletionFunc("channel", cobra.FixedCompletions(pkgmgr.Channels(), cobra.ShellCompDirectiveNoFileComp))
+	_ = pkgInstallCmd.RegisterFlagCompletionFunc("type", cobra.FixedCompletions([]string{"skills", "commands", "agents", "hooks"}, cobra.ShellCompDirectiveNoFileComp))
 }
 
 func runPkgInstall(cmd *cobra.Command, args []string) error {
 	cmd.SilenceUsage = true
-	spec := args[0]
+	defer syncIndex()
+
+	manager := pkgmgr.NewManager(basedir.DataDir())
+	manager.SetProgress(progress.New(os.Stderr))
+	enableAutoSnapshot(manager)
+	if err := applyPackageNaming(manager); err != nil {
+		return err
+	}
+
+	if pkgInstallTarget != pkgmgr.TargetClaude {
+		return installForTarget(manager, args[0])
+	}
+
+	scope, err := setInstallScope(manager, pkgInstallLocal)
+	if err != nil {
+		return err
+	}
+
+	if pkgInstallFromURL != "" {
+		fmt.Printf("Installing %s into %s...\n", pkgInstallFromURL, ScopeDescription(scope))
+
+		pkg, err := manager.InstallArchive(pkgInstallFromURL, pkgInstallNamespace)
+		if err != nil {
+			if errors.Is(err, pkgmgr.ErrPackageAlreadyInstalled) {
+				return errors.New("package already installed. Uninstall it first with 'jd pkg uninstall'")
+			}
+			if errors.Is(err, pkgmgr.ErrNameCollision) {
+				return nameCollisionError(err)
+			}
+			return fmt.Errorf("install: %w", err)
+		}
 
-	manager := pkgmgr.NewManager("~/.itda-skills")
+		printInstalledPackage(pkg)
+		return finishInstall(manager, pkg, scope)
+	}
+
+	spec := args[0]
 
 	// Validate spec format
 	parsedSpec, err := pkgmgr.ParseSpec(spec)
@@ -47,26 +215,473 @@ func runPkgInstall(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid specification. Format: namespace:path[@version]")
 	}
 
+	if pkgInstallChannel != "" {
+		if !pkgmgr.ValidChannel(pkgInstallChannel) {
+			return validationErrorf("invalid channel: %s (use: %s)", pkgInstallChannel, strings.Join(pkgmgr.Channels(), ", "))
+		}
+		if parsedSpec.Version != "" {
+			return validationErrorf("--channel picks the version; remove @%s", parsedSpec.Version)
+		}
+		manager.SetChannel(pkgInstallChannel)
+	}
+
+	if parsedSpec.Path == installAllPath {
+		types, err := parsePackageTypes(pkgInstallTypes)
+		if err != nil {
+			return err
+		}
+		if len(pkgInstallOnly) > 0 {
+			return usageError(cmd, fmt.Errorf("--only selects the files of a single skill, not of <namespace>:%s", installAllPath))
+		}
+		return installAll(manager, parsedSpec.Namespace, parsedSpec.Version, types, scope, pkgInstallYes)
+	}
+	if len(pkgInstallTypes) > 0 {
+		return usageError(cmd, fmt.Errorf("--type selects packages of <namespace>:%s", installAllPath))
+	}
+	if len(pkgInstallOnly) > 0 {
+		if err := setInstallOnly(manager, spec, parsedSpec.Namespace, pkgInstallOnly); err != nil {
+			return err
+		}
+	}
+
+	return installFromRepo(manager, spec, parsedSpec.Namespace, scope, pkgInstallYes)
+}
+
+// setInstallScope points the manager at the project's .claude directory if
+// local is set or a project root was selected, and returns the scope installed into
+func setInstallScope(manager *pkgmgr.Manager, local bool) (PathScope, error) {
+	if !local && !projectRootSelected() {
+		return ScopeGlobal, nil
+	}
+	root, err := ProjectRoot()
+	if err != nil {
+		return ScopeLocal, fmt.Errorf("resolve project root: %w", err)
+	}
+	manager.SetClaudeDir(filepath.Join(root, localClaudeDir))
+	return ScopeLocal, nil
+}
+
+// setInstallOnly makes the manager install the files of the skill spec that
+// patterns select, refusing patterns that select none of them
+func setInstallOnly(manager *pkgmgr.Manager, spec, namespace string, patterns []string) error {
+	if err := pkgmgr.ValidateOnly(patterns); err != nil {
+		return validationErrorf("--only: %v", err)
+	}
+	if _, err := manager.RepoStore().Get(namespace); err != nil {
+		return notFoundErrorf("repository '%s' not found. Register with: jd pkg repo add gh:owner/repo", namespace)
+	}
+	files, err := manager.SkillFiles(spec)
+	if errors.Is(err, pkgmgr.ErrOnlySkills) {
+		return validationErrorf("--only: %v", err)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to list the files of %s: %w", spec, err)
+	}
+	if unmatched := pkgmgr.UnmatchedOnly(patterns, files); len(unmatched) > 0 {
+		return validationErrorf("--only: no file of %s matches %s", spec, strings.Join(unmatched, ", "))
+	}
+	manager.SetOnly(patterns)
+	return nil
+}
+
+// pkgSpecCompletion completes namespace:path specs of repository packages from
+// the package index, or from the repositories' clones when indexing is disabled
+func pkgSpecCompletion(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) != 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var docs []index.Doc
+	if indexEnabled() {
+		// Completion runs on every key press, so a built index is used as is
+		ix, err := openIndex()
+		if err != nil || len(ix.Sources()) == 0 {
+			ix = loadIndex()
+		}
+		if ix != nil {
+			docs = ix.Docs()
+		}
+	} else {
+		store := repo.NewStore(basedir.DataDir())
+		repos, _ := store.List()
+		for _, r := range repos {
+			items, _ := store.Browse(r.Namespace, "")
+			for _, item := range items {
+				docs = append(docs, index.Doc{Namespace: r.Namespace, Path: item.Path})
+			}
+		}
+	}
+
+	var specs []string
+	for _, d := range docs {
+		spec := d.Namespace + ":" + d.Path
+		if d.Namespace == "" || !strings.HasPrefix(spec, toComplete) {
+			continue
+		}
+		if d.Description != "" {
+			spec += "\t" + d.Description
+		}
+		specs = append(specs, spec)
+	}
+	sort.Strings(specs)
+	return specs, cobra.ShellCompDirectiveNoFileComp
+}
+
+// installForTarget installs a package as a rule for the assistant given with --target,
+// in the project's rules directory
+func installForTarget(manager *pkgmgr.Manager, spec string) error {
+	if !pkgmgr.ValidTarget(pkgInstallTarget) {
+		return validationErrorf("invalid target: %s (use: %s, %s)", pkgInstallTarget, pkgmgr.TargetClaude, strings.Join(pkgmgr.Targets(), ", "))
+	}
+	parsedSpec, err := pkgmgr.ParseSpec(spec)
+	if err != nil {
+		return fmt.Errorf("invalid specification. Format: namespace:path[@version]")
+	}
+	if _, err := manager.RepoStore().Get(parsedSpec.Namespace); err != nil {
+		return notFoundErrorf("repository '%s' not found. Register with: jd pkg repo add gh:owner/repo", parsedSpec.Namespace)
+	}
+
+	root, err := ProjectRoot()
+	if err != nil {
+		return fmt.Errorf("resolve project root: %w", err)
+	}
+	manager.SetTarget(pkgInstallTarget, root)
+
+	// Rules are instructions for the editor, so unlike hooks and commands they run nothing
+	fmt.Printf("Installing %s as a %s rule into %s...\n", spec, pkgmgr.TargetName(pkgInstallTarget), pkgmgr.TargetRulesDir(pkgInstallTarget, root))
+
+	pkg, err := manager.Install(spec)
+	if err != nil {
+		if errors.Is(err, pkgmgr.ErrPackageAlreadyInstalled) {
+			return fmt.Errorf("package already installed. Use 'jd pkg update %s' to update", spec)
+		}
+		if errors.Is(err, pkgmgr.ErrTargetUnsupported) {
+			return validationErrorf("%v", err)
+		}
+		if errors.Is(err, pkgmgr.ErrNameCollision) {
+			return nameCollisionError(err)
+		}
+		return fmt.Errorf("install: %w", err)
+	}
+
+	printInstalledPackage(pkg)
+	return nil
+}
+
+// installFromRepo installs a package from a registered repository, confirming hooks and
+// commands from untrusted repositories unless yes is set, and runs its setup steps
+func installFromRepo(manager *pkgmgr.Manager, spec, namespace string, scope PathScope, yes bool) error {
 	// Check if repository exists
-	_, err = manager.RepoStore().Get(parsedSpec.Namespace)
+	repoConfig, err := manager.RepoStore().Get(namespace)
 	if err != nil {
-		return fmt.Errorf("repository '%s' not found. Register with: jd pkg repo add gh:owner/repo", parsedSpec.Namespace)
+		return notFoundErrorf("repository '%s' not found. Register with: jd pkg repo add gh:owner/repo", namespace)
+	}
+	if err := manager.RepoStore().Check(namespace, false); err != nil {
+		return brokenCloneError(namespace, err)
+	}
+
+	if !repoConfig.Trusted && !yes {
+		if err := confirmUntrustedInstall(manager, spec, repoConfig.Namespace); err != nil {
+			return err
+		}
 	}
 
-	fmt.Printf("Installing %s...\n", spec)
+	fmt.Printf("Installing %s into %s...\n", spec, ScopeDescription(scope))
 
 	pkg, err := manager.Install(spec)
 	if err != nil {
 		if errors.Is(err, pkgmgr.ErrPackageAlreadyInstalled) {
 			return fmt.Errorf("package already installed. Use 'jd pkg update %s' to update", spec)
 		}
+		if errors.Is(err, pkgmgr.ErrNameCollision) {
+			return nameCollisionError(err)
+		}
+		if errors.Is(err, pkgmgr.ErrNoReleases) {
+			return validationErrorf("%s: %v; install from the branch without --channel %s", namespace, err, pkgmgr.ChannelStable)
+		}
 		return fmt.Errorf("install: %w", err)
 	}
 
+	printInstalledPackage(pkg)
+	return finishInstall(manager, pkg, scope)
+}
+
+// Configuration keys of the names packages are installed under
+const (
+	pkgNamingSeparatorKey = "pkg.naming.separator"
+	pkgNamingFlatKey      = "pkg.naming.flat"
+)
+
+// applyPackageNaming sets the names the manager installs packages under from
+// pkg.naming.separator and pkg.naming.flat
+func applyPackageNaming(manager *pkgmgr.Manager) error {
+	naming := pkgmgr.DefaultNaming
+	cfg, err := config.Load()
+	if err != nil {
+		manager.SetNaming(naming)
+		return nil
+	}
+	if value, err := cfg.Get(pkgNamingSeparatorKey); err == nil {
+		sep, _ := value.(string)
+		if err := pkgmgr.ValidateSeparator(sep); err != nil {
+			return validationErrorf("invalid %s: %v", pkgNamingSeparatorKey, err)
+		}
+		naming.Separator = sep
+	}
+	naming.Flat = cfg.GetBool(pkgNamingFlatKey, false)
+	manager.SetNaming(naming)
+	return nil
+}
+
+// nameCollisionError explains that a package's install name is taken
+func nameCollisionError(err error) error {
+	return validationErrorf("%v\nUninstall the other package, or install under another name by changing %s", err, pkgNamingSeparatorKey)
+}
+
+// confirmUntrustedInstall warns that a hook or command package comes from an untrusted
+// repository and asks whether to install it anyway. Other package types pass silently.
+func confirmUntrustedInstall(manager *pkgmgr.Manager, spec, namespace string) error {
+	pkgType, err := manager.SpecType(spec)
+	if err != nil || !pkgType.RunsCommands() {
+		// Install reports unresolvable specs
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "\n⚠️  WARNING: '%s' is not a trusted repository.\n", namespace)
+	if pkgType == repo.TypeHook {
+		fmt.Fprintln(os.Stderr, "   Hooks run shell commands automatically on Claude Code events.")
+	} else {
+		fmt.Fprintln(os.Stderr, "   Commands can run shell commands when invoked.")
+	}
+	fmt.Fprintf(os.Stderr, "   Review %s before installing it.\n", spec)
+	fmt.Fprintf(os.Stderr, "   To stop asking for this repository: jd pkg repo trust %s\n\n", namespace)
+	fmt.Printf("Install %s %s anyway? (y/N): ", pkgType, spec)
+
+	reader := bufio.NewReader(os.Stdin)
+	response, _ := reader.ReadString('\n')
+	response = strings.TrimSpace(strings.ToLower(response))
+	if response != "y" && response != "yes" {
+		fmt.Println("Cancelled.")
+		return errCancelled
+	}
+	return nil
+}
+
+// finishInstall runs the setup steps a newly installed package declares
+func finishInstall(manager *pkgmgr.Manager, pkg *pkgmgr.InstalledPackage, scope PathScope) error {
+	if err := offerHookRegistration(manager, pkg, scope); err != nil {
+		return err
+	}
+	syncPackageSkillReference(pkg, true)
+	if err := showPostInstall(pkg); err != nil {
+		return err
+	}
+	return offerSetup(manager, pkg)
+}
+
+// showPostInstall prints a package's POST_INSTALL.md notes and asks for
+// required configuration keys, from those notes or the skill's SKILL.md, that
+// are not set yet
+func showPostInstall(pkg *pkgmgr.InstalledPackage) error {
+	info, err := pkgmgr.PostInstallInfo(pkg)
+	if err != nil {
+		return fmt.Errorf("failed to read post-install notes: %w", err)
+	}
+	if info != nil && info.Notes != "" {
+		fmt.Printf("\n📋 Post-install notes for %s:\n\n%s\n", pkg.Name, info.Notes)
+	}
+
+	cfg, reqs, missing, err := missingConfig(pkg, info)
+	if err != nil {
+		return err
+	}
+	if len(reqs) == 0 {
+		return nil
+	}
+	if len(missing) == 0 {
+		fmt.Println("\n✓ Required configuration is set.")
+		return nil
+	}
+
+	fmt.Printf("\n%s needs %d configuration value(s):\n", pkg.Name, len(missing))
+	if pkgInstallSkipSetup {
+		printConfigCommands(missing)
+		return nil
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	changed := false
+	for _, r := range missing {
+		value, ok := askConfigValue(reader, r)
+		if !ok {
+			fmt.Printf("    Skipped. Set it later with: jd config set %s <value>\n", r.Key)
+			continue
+		}
+		if err := cfg.Set(r.Key, value); err != nil {
+			return fmt.Errorf("failed to set %s: %w", r.Key, err)
+		}
+		changed = true
+	}
+
+	if changed {
+		if err := cfg.Save(); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+		fmt.Println("\n✓ Configuration saved.")
+	}
+	return nil
+}
+
+// missingConfig returns the configuration keys a package requires, from its
+// POST_INSTALL.md notes (info, if it has any) or its skill's SKILL.md, and
+// those of them that are not set yet, with the configuration they were looked
+// up in
+func missingConfig(pkg *pkgmgr.InstalledPackage, info *pkgmgr.PostInstall) (cfg *config.Config, reqs, missing []pkgmgr.ConfigRequirement, err error) {
+	if info != nil {
+		reqs = info.RequiresConfig
+	}
+	skillReqs, err := pkgmgr.SkillConfigRequirements(pkg)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to read skill config: %w", err)
+	}
+	for _, r := range skillReqs {
+		if !hasConfigRequirement(reqs, r.Key) {
+			reqs = append(reqs, r)
+		}
+	}
+	if len(reqs) == 0 {
+		return nil, nil, nil, nil
+	}
+
+	cfg, err = config.Load()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	for _, r := range reqs {
+		if r.Env != "" {
+			if _, ok := cfg.GetWithVendorEnv(r.Key, r.Env); ok {
+				continue
+			}
+		} else if _, ok := cfg.GetWithEnv(r.Key); ok {
+			continue
+		}
+		missing = append(missing, r)
+	}
+	return cfg, reqs, missing, nil
+}
+
+// printConfigCommands prints the jd config set commands that set missing keys
+func printConfigCommands(missing []pkgmgr.ConfigRequirement) {
+	for _, r := range missing {
+		fmt.Printf("  jd config set %s <value>", r.Key)
+		if r.Description != "" {
+			fmt.Printf("   # %s", r.Description)
+		}
+		fmt.Println()
+	}
+}
+
+// hasConfigRequirement reports whether reqs has a requirement for key
+func hasConfigRequirement(reqs []pkgmgr.ConfigRequirement, key string) bool {
+	for _, r := range reqs {
+		if r.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// askConfigValue asks for the value of a required configuration key until it
+// is of the declared type. Secrets are not echoed on a terminal. Returns false
+// if the key was skipped with an empty answer.
+func askConfigValue(reader *bufio.Reader, r pkgmgr.ConfigRequirement) (any, bool) {
+	label := r.Key
+	if r.Type != "" && r.Type != config.TypeString {
+		label += " <" + r.Type + ">"
+	}
+	if r.Description != "" {
+		label = fmt.Sprintf("%s (%s)", label, r.Description)
+	}
+
+	for {
+		fmt.Printf("  %s [Enter to skip]: ", label)
+		var input string
+		if r.Secret && isTerminal(os.Stdin) {
+			data, _ := term.ReadPassword(int(os.Stdin.Fd()))
+			fmt.Println()
+			input = string(data)
+		} else {
+			input, _ = reader.ReadString('\n')
+		}
+		input = strings.TrimSpace(input)
+		if input == "" {
+			return nil, false
+		}
+
+		if r.Type == "" {
+			return config.ParseValue(input), true
+		}
+		value, err := config.ParseTyped(input, r.Type)
+		if err == nil {
+			return value, true
+		}
+		fmt.Printf("    %v\n", err)
+	}
+}
+
+// offerHookRegistration adds the settings.json rule declared by an installed hook
+// package, asking first unless --register or --no-register was given
+func offerHookRegistration(manager *pkgmgr.Manager, pkg *pkgmgr.InstalledPackage, scope PathScope) error {
+	if pkgInstallNoRegister {
+		return nil
+	}
+
+	meta, err := manager.HookMeta(pkg.Name)
+	if err != nil {
+		return fmt.Errorf("failed to read hook metadata: %w", err)
+	}
+	if meta == nil {
+		if pkgInstallRegister && pkg.Type == repo.TypeHook {
+			return pkgmgr.ErrNoHookMetadata
+		}
+		return nil
+	}
+
+	settingsPath := GetSettingsPathByScope(scope)
+	if !pkgInstallRegister {
+		fmt.Printf("\nRegister hook in %s?\n", settingsPath)
+		fmt.Printf("  Event:   %s\n", meta.EventType)
+		fmt.Printf("  Matcher: %s\n", meta.Matcher)
+		fmt.Print("(y/N): ")
+
+		reader := bufio.NewReader(os.Stdin)
+		response, _ := reader.ReadString('\n')
+		response = strings.TrimSpace(strings.ToLower(response))
+		if response != "y" && response != "yes" {
+			fmt.Println("Skipped. The hook script is installed but not registered.")
+			return nil
+		}
+	}
+
+	if _, err := manager.RegisterHook(pkg.Name, settingsPath); err != nil {
+		return fmt.Errorf("failed to register hook: %w", err)
+	}
+	fmt.Printf("\n✓ Registered %s hook (matcher: %s) in %s\n", meta.EventType, meta.Matcher, settingsPath)
+	return nil
+}
+
+// printInstalledPackage prints a summary of a newly installed package
+func printInstalledPackage(pkg *pkgmgr.InstalledPackage) {
 	fmt.Printf("Installed successfully!\n")
 	fmt.Printf("  Name:      %s\n", pkg.Name)
 	fmt.Printf("  Type:      %s\n", pkg.Type)
 	fmt.Printf("  Version:   %s (%s)\n", pkg.Version.Ref, pkg.Version.SHA[:8])
+	if pkg.Source != "" {
+		fmt.Printf("  Source:    %s\n", pkg.Source)
+	}
+	if len(pkg.Only) > 0 {
+		fmt.Printf("  Only:      %s\n", strings.Join(pkg.Only, ", "))
+	}
 	fmt.Printf("  Files:     %d\n", len(pkg.Files))
 
 	if len(pkg.Files) > 0 {
@@ -75,6 +690,4 @@ func runPkgInstall(cmd *cobra.Command, args []string) error {
 			fmt.Printf("  %s\n", f.Target)
 		}
 	}
-
-	return nil
 }
@@ -0,0 +1,261 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/itda-skills/jindo/internal/pkg/pkgmgr"
+	"github.com/itda-skills/jindo/internal/pkg/repo"
+	"github.com/itda-skills/jindo/internal/table"
+)
+
+// installAllPath is the spec path that installs every package of a repository (e.g., affa-ever:all)
+const installAllPath = "all"
+
+// Results of installing one package of a repository with installAll
+const (
+	bulkInstalled        = "installed"
+	bulkAlreadyInstalled = "already installed"
+	bulkSkipped          = "skipped"
+	bulkFailed           = "failed"
+)
+
+// bulkInstall is the result of installing one package of a repository
+type bulkInstall struct {
+	item   repo.BrowseItem
+	status string
+	detail string // The name installed under, or why the package was skipped or failed
+	pkg    *pkgmgr.InstalledPackage
+}
+
+// parsePackageTypes parses --type values (skills or skill, commands, agents,
+// hooks). No values selects every type.
+func parsePackageTypes(values []string) ([]repo.PackageType, error) {
+	var types []repo.PackageType
+	for _, v := range values {
+		t := repo.PackageType(strings.TrimSuffix(strings.ToLower(strings.TrimSpace(v)), "s"))
+		if !slices.Contains(repo.PackageTypes, t) {
+			return nil, validationErrorf("invalid type: %s (use: skills, commands, agents, hooks)", v)
+		}
+		if !slices.Contains(types, t) {
+			types = append(types, t)
+		}
+	}
+	return types, nil
+}
+
+// installAll installs every package of a repository, or those of types, at
+// version if it is set. A package that fails does not stop the others; the
+// results are printed as a table. Hooks and commands from an untrusted
+// repository are confirmed together unless yes is set, and skipped if declined.
+func installAll(manager *pkgmgr.Manager, namespace, version string, types []repo.PackageType, scope PathScope, yes bool) error {
+	repoConfig, err := manager.RepoStore().Get(namespace)
+	if err != nil {
+		return notFoundErrorf("repository '%s' not found. Register with: jd pkg repo add gh:owner/repo", namespace)
+	}
+	if err := manager.RepoStore().Check(namespace, false); err != nil {
+		return brokenCloneError(namespace, err)
+	}
+
+	items, err := manager.RepoStore().Browse(namespace, "")
+	if err != nil {
+		return fmt.Errorf("failed to list packages of %s: %w", namespace, err)
+	}
+	if len(types) > 0 {
+		items = slices.DeleteFunc(items, func(item repo.BrowseItem) bool {
+			return !slices.Contains(types, item.Type)
+		})
+	}
+	if len(items) == 0 {
+		fmt.Printf("No packages to install in %s.\n", namespace)
+		return nil
+	}
+
+	runsCommands := !repoConfig.Trusted && !yes && slices.ContainsFunc(items, func(item repo.BrowseItem) bool {
+		return item.Type.RunsCommands()
+	})
+	skipCommands := runsCommands && !confirmUntrustedInstallAll(namespace, items)
+
+	fmt.Printf("Installing %d package(s) from %s into %s...\n", len(items), namespace, ScopeDescription(scope))
+
+	results := make([]bulkInstall, 0, len(items))
+	for _, item := range items {
+		result := bulkInstall{item: item}
+		if skipCommands && item.Type.RunsCommands() {
+			result.status, result.detail = bulkSkipped, "untrusted repository"
+			results = append(results, result)
+			continue
+		}
+
+		spec := namespace + ":" + item.Path
+		if version != "" {
+			spec += "@" + version
+		}
+		switch pkg, err := manager.Install(spec); {
+		case err == nil:
+			result.status, result.detail, result.pkg = bulkInstalled, pkg.Name, pkg
+			syncPackageSkillReference(pkg, true)
+		case errors.Is(err, pkgmgr.ErrPackageAlreadyInstalled):
+			result.status = bulkAlreadyInstalled
+		default:
+			result.status, result.detail = bulkFailed, err.Error()
+		}
+		results = append(results, result)
+	}
+
+	fmt.Println()
+	printBulkInstall(results)
+
+	if err := registerBulkHooks(manager, results, scope); err != nil {
+		return err
+	}
+	printBulkConfig(manager, results)
+
+	if failed := countBulk(results, bulkFailed); failed > 0 {
+		return fmt.Errorf("%d of %d package(s) failed to install", failed, len(results))
+	}
+	return nil
+}
+
+// confirmUntrustedInstallAll warns that the hooks and commands among items come
+// from an untrusted repository, and asks whether to install them too
+func confirmUntrustedInstallAll(namespace string, items []repo.BrowseItem) bool {
+	var hooks, commands int
+	for _, item := range items {
+		switch item.Type {
+		case repo.TypeHook:
+			hooks++
+		case repo.TypeCommand:
+			commands++
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "\n⚠️  WARNING: '%s' is not a trusted repository.\n", namespace)
+	fmt.Fprintf(os.Stderr, "   It has %d hook(s), which run shell commands automatically on Claude Code events,\n", hooks)
+	fmt.Fprintf(os.Stderr, "   and %d command(s), which can run shell commands when invoked.\n", commands)
+	fmt.Fprintf(os.Stderr, "   Review them with: jd pkg browse %s\n", namespace)
+	fmt.Fprintf(os.Stderr, "   To stop asking for this repository: jd pkg repo trust %s\n\n", namespace)
+	fmt.Print("Install its hooks and commands too? Otherwise they are skipped (y/N): ")
+
+	reader := bufio.NewReader(os.Stdin)
+	response, _ := reader.ReadString('\n')
+	response = strings.TrimSpace(strings.ToLower(response))
+	return response == "y" || response == "yes"
+}
+
+// printBulkInstall prints the results of installAll as a table, with a summary line
+func printBulkInstall(results []bulkInstall) {
+	t := newTable(
+		table.Column{Header: "TYPE"},
+		table.Column{Header: "PACKAGE", Max: 40},
+		table.Column{Header: "STATUS"},
+		table.Column{Header: "DETAIL", Max: 60},
+	)
+	for _, r := range results {
+		t.AddRow(string(r.item.Type), r.item.Path, r.status, r.detail)
+	}
+	t.Render(os.Stdout)
+
+	fmt.Printf("\n%d installed, %d already installed, %d skipped, %d failed\n",
+		countBulk(results, bulkInstalled), countBulk(results, bulkAlreadyInstalled),
+		countBulk(results, bulkSkipped), countBulk(results, bulkFailed))
+}
+
+// countBulk counts the results of installAll with a status
+func countBulk(results []bulkInstall, status string) int {
+	n := 0
+	for _, r := range results {
+		if r.status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// registerBulkHooks adds the settings.json rules the newly installed hook
+// packages declare, after one confirmation unless --register or --no-register
+// was given
+func registerBulkHooks(manager *pkgmgr.Manager, results []bulkInstall, scope PathScope) error {
+	if pkgInstallNoRegister {
+		return nil
+	}
+
+	type declared struct {
+		name, event, matcher string
+	}
+	var hooks []declared
+	for _, r := range results {
+		if r.pkg == nil || r.pkg.Type != repo.TypeHook {
+			continue
+		}
+		meta, err := manager.HookMeta(r.pkg.Name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to read hook metadata of %s: %v\n", r.pkg.Name, err)
+			continue
+		}
+		if meta != nil {
+			hooks = append(hooks, declared{r.pkg.Name, string(meta.EventType), meta.Matcher})
+		}
+	}
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	settingsPath := GetSettingsPathByScope(scope)
+	if !pkgInstallRegister {
+		fmt.Printf("\nRegister %d hook(s) in %s?\n", len(hooks), settingsPath)
+		for _, h := range hooks {
+			fmt.Printf("  %s (%s, matcher: %s)\n", h.name, h.event, h.matcher)
+		}
+		fmt.Print("(y/N): ")
+
+		reader := bufio.NewReader(os.Stdin)
+		response, _ := reader.ReadString('\n')
+		response = strings.TrimSpace(strings.ToLower(response))
+		if response != "y" && response != "yes" {
+			fmt.Println("Skipped. The hook scripts are installed but not registered.")
+			return nil
+		}
+	}
+
+	for _, h := range hooks {
+		if _, err := manager.RegisterHook(h.name, settingsPath); err != nil {
+			return fmt.Errorf("failed to register hook %s: %w", h.name, err)
+		}
+	}
+	fmt.Printf("\n✓ Registered %d hook(s) in %s\n", len(hooks), settingsPath)
+	return nil
+}
+
+// printBulkConfig lists the configuration keys the newly installed packages
+// need that are not set yet, and the setup steps they have not run
+func printBulkConfig(manager *pkgmgr.Manager, results []bulkInstall) {
+	for _, r := range results {
+		if r.pkg == nil {
+			continue
+		}
+		info, err := pkgmgr.PostInstallInfo(r.pkg)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to read post-install notes of %s: %v\n", r.pkg.Name, err)
+			continue
+		}
+		_, _, missing, err := missingConfig(r.pkg, info)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %s: %v\n", r.pkg.Name, err)
+			continue
+		}
+		if len(missing) > 0 {
+			fmt.Printf("\n%s needs %d configuration value(s):\n", r.pkg.Name, len(missing))
+			printConfigCommands(missing)
+		}
+		if steps, err := manager.PendingSetup(r.pkg); err == nil && len(steps) > 0 {
+			fmt.Printf("\n%s has %d setup step(s) to run once:\n", r.pkg.Name, len(steps))
+			printSetupSteps(steps)
+			fmt.Printf("Run them with: jd pkg setup %s\n", r.pkg.Name)
+		}
+	}
+}
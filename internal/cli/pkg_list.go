@@ -1,39 +1,71 @@
 package cli
 
 import (
-	"encoding/json"
 	"fmt"
-	"strings"
+	"os"
+	"slices"
+	"sort"
 
+	"github.com/itda-skills/jindo/internal/basedir"
 	"github.com/itda-skills/jindo/internal/pkg/pkgmgr"
+	"github.com/itda-skills/jindo/internal/pkg/repo"
+	"github.com/itda-skills/jindo/internal/sorting"
+	"github.com/itda-skills/jindo/internal/table"
 	"github.com/spf13/cobra"
 )
 
-var pkgListJSON bool
+var (
+	pkgListJSON bool
+	pkgListSort string
+)
 
 var pkgListCmd = &cobra.Command{
 	Use:     "list",
 	Aliases: []string{"l", "ls"},
 	Short:   "List installed packages",
-	Long:    `List all installed packages from registered repositories.`,
-	RunE:    runPkgList,
+	Long: `List all installed packages from registered repositories.
+
+Packages are grouped by namespace, and listed by name within each. Use --sort
+to list them across namespaces instead:
+  name       By name
+  type       By type (skill, command, agent, hook), then name
+  installed  Most recently installed first
+  updated    Most recently updated first
+Names are compared ignoring case, with numbers by value, in the collation of
+your locale (LC_ALL, LC_COLLATE, or LANG). --json output is in the same order.
+
+Examples:
+  jd pkg list
+  jd pkg list --sort updated
+  jd pkg list --sort installed --json`,
+	Args: cobra.NoArgs,
+	RunE: runPkgList,
 }
 
 func init() {
 	pkgCmd.AddCommand(pkgListCmd)
 	pkgListCmd.Flags().BoolVar(&pkgListJSON, "json", false, "Output in JSON format")
+	addOutputFlags(pkgListCmd)
+	addNoTruncFlag(pkgListCmd)
+	pkgListCmd.Flags().StringVar(&pkgListSort, "sort", "", "Sort by name, type, installed, or updated (default: by namespace, then name)")
+	_ = pkgListCmd.RegisterFlagCompletionFunc("sort", cobra.FixedCompletions(sorting.Orders, cobra.ShellCompDirectiveNoFileComp))
 }
 
 func runPkgList(cmd *cobra.Command, _ []string) error {
 	cmd.SilenceUsage = true
-	manager := pkgmgr.NewManager("~/.itda-skills")
+	order, err := sorting.ParseOrder(pkgListSort)
+	if err != nil {
+		return usageError(cmd, err)
+	}
+	manager := pkgmgr.NewManager(basedir.DataDir())
 
 	packages, err := manager.List()
 	if err != nil {
 		return fmt.Errorf("list packages: %w", err)
 	}
+	sortInstalledPackages(packages, order)
 
-	if len(packages) == 0 {
+	if len(packages) == 0 && !formattedOutput() {
 		fmt.Println("No packages installed.")
 		fmt.Println()
 		fmt.Println("Install a package with:")
@@ -41,96 +73,93 @@ func runPkgList(cmd *cobra.Command, _ []string) error {
 		return nil
 	}
 
-	if pkgListJSON {
-		output, err := json.MarshalIndent(packages, "", "  ")
-		if err != nil {
-			return err
-		}
-		fmt.Println(string(output))
-		return nil
+	if pkgListJSON || formattedOutput() {
+		return printJSON(packages)
 	}
 
-	// Calculate column widths
-	nameWidth := len("NAME")
-	typeWidth := len("TYPE")
-	nsWidth := len("NAMESPACE")
-	versionWidth := len("VERSION")
-
+	// Packages installed for other assistants are listed after Claude Code's, by target
+	var claude []pkgmgr.InstalledPackage
+	byTarget := make(map[string][]pkgmgr.InstalledPackage)
 	for _, pkg := range packages {
-		if len(pkg.Name) > nameWidth {
-			nameWidth = len(pkg.Name)
-		}
-		typeStr := string(pkg.Type)
-		if len(typeStr) > typeWidth {
-			typeWidth = len(typeStr)
-		}
-		if len(pkg.Namespace) > nsWidth {
-			nsWidth = len(pkg.Namespace)
-		}
-		version := pkg.Version.SHA
-		if len(version) > 8 {
-			version = version[:8]
-		}
-		if len(version) > versionWidth {
-			versionWidth = len(version)
+		if pkg.Target == "" {
+			claude = append(claude, pkg)
+		} else {
+			byTarget[pkg.Target] = append(byTarget[pkg.Target], pkg)
 		}
 	}
 
-	// Cap widths
-	if nameWidth > 35 {
-		nameWidth = 35
+	printed := len(claude) > 0
+	if printed {
+		printInstalledTable(claude)
 	}
-	if typeWidth > 10 {
-		typeWidth = 10
-	}
-	if nsWidth > 15 {
-		nsWidth = 15
-	}
-	if versionWidth > 12 {
-		versionWidth = 12
-	}
-
-	// Print header
-	fmt.Printf("%-*s  %-*s  %-*s  %-*s\n",
-		nameWidth, "NAME",
-		typeWidth, "TYPE",
-		nsWidth, "NAMESPACE",
-		versionWidth, "VERSION")
-	fmt.Printf("%s  %s  %s  %s\n",
-		strings.Repeat("-", nameWidth),
-		strings.Repeat("-", typeWidth),
-		strings.Repeat("-", nsWidth),
-		strings.Repeat("-", versionWidth))
-
-	// Print rows
-	for _, pkg := range packages {
-		name := pkg.Name
-		if len(name) > nameWidth {
-			name = name[:nameWidth-3] + "..."
+	for _, target := range pkgmgr.Targets() {
+		if len(byTarget[target]) == 0 {
+			continue
 		}
-
-		typeStr := string(pkg.Type)
-		if len(typeStr) > typeWidth {
-			typeStr = typeStr[:typeWidth-3] + "..."
+		if printed {
+			fmt.Println()
 		}
+		fmt.Printf("%s rules:\n", pkgmgr.TargetName(target))
+		printInstalledTable(byTarget[target])
+		printed = true
+	}
 
-		ns := pkg.Namespace
-		if len(ns) > nsWidth {
-			ns = ns[:nsWidth-3] + "..."
-		}
+	fmt.Printf("\nTotal: %d packages\n", len(packages))
+	return nil
+}
 
+// printInstalledTable prints installed packages as a NAME/TYPE/NAMESPACE/VERSION table
+func printInstalledTable(packages []pkgmgr.InstalledPackage) {
+	t := newTable(
+		table.Column{Header: "NAME", Max: 35},
+		table.Column{Header: "TYPE", Max: 10},
+		table.Column{Header: "NAMESPACE", Max: 15},
+		table.Column{Header: "VERSION", Max: 12},
+	)
+	for _, pkg := range packages {
 		version := pkg.Version.SHA
 		if len(version) > 8 {
 			version = version[:8]
 		}
-
-		fmt.Printf("%-*s  %-*s  %-*s  %-*s\n",
-			nameWidth, name,
-			typeWidth, typeStr,
-			nsWidth, ns,
-			versionWidth, version)
+		t.AddRow(pkg.Name, string(pkg.Type), pkg.Namespace, version)
 	}
+	t.Render(os.Stdout)
+}
 
-	fmt.Printf("\nTotal: %d packages\n", len(packages))
-	return nil
+// sortInstalledPackages sorts installed packages in a sort order (see
+// sorting.Orders), or by namespace and then name for the default order ""
+func sortInstalledPackages(packages []pkgmgr.InstalledPackage, order string) {
+	sort.SliceStable(packages, func(i, j int) bool {
+		a, b := packages[i], packages[j]
+		switch order {
+		case sorting.Type:
+			if a.Type != b.Type {
+				return packageTypeRank(a.Type) < packageTypeRank(b.Type)
+			}
+		case sorting.Installed:
+			if !a.InstalledAt.Equal(b.InstalledAt) {
+				return a.InstalledAt.After(b.InstalledAt)
+			}
+		case sorting.Updated:
+			if !a.UpdatedAt.Equal(b.UpdatedAt) {
+				return a.UpdatedAt.After(b.UpdatedAt)
+			}
+		case "":
+			if c := sorting.Compare(a.Namespace, b.Namespace); c != 0 {
+				return c < 0
+			}
+		}
+		if c := sorting.Compare(a.OriginalName, b.OriginalName); c != 0 {
+			return c < 0
+		}
+		return sorting.Less(a.Name, b.Name)
+	})
+}
+
+// packageTypeRank returns the position of a package type in repo.PackageTypes
+func packageTypeRank(t repo.PackageType) int {
+	if i := slices.Index(repo.PackageTypes, t); i >= 0 {
+		return i
+	}
+	return len(repo.PackageTypes)
 }
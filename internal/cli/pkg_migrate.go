@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/pkg/pkgmgr"
+	"github.com/spf13/cobra"
+)
+
+var pkgMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Upgrade installed.json to the current schema",
+	Long: `Upgrade installed.json, the record of installed packages, to the current
+schema version and fill in metadata missing from its packages.
+
+Schema version 2 records each package's install scope, source repository URL,
+pin, and bundle, and a hash of every installed file. Older files are migrated
+automatically the first time jd reads them; run this to migrate explicitly, or
+to fill in metadata that was unavailable then (e.g., the URL of a repository
+registered later). Missing file hashes are taken from the files as they are now.
+
+installed.json is backed up next to itself before it is changed.
+
+Example:
+  jd pkg migrate`,
+	Args: cobra.NoArgs,
+	RunE: runPkgMigrate,
+}
+
+func init() {
+	pkgCmd.AddCommand(pkgMigrateCmd)
+}
+
+func runPkgMigrate(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+
+	manager := pkgmgr.NewManager(basedir.DataDir())
+	result, err := manager.Migrate()
+	if err != nil {
+		return fmt.Errorf("failed to migrate installed.json: %w", err)
+	}
+
+	switch {
+	case result.From == 0:
+		fmt.Println("No packages installed.")
+	case result.Backup == "":
+		fmt.Printf("installed.json is up to date (schema version %d).\n", result.To)
+	default:
+		if result.From != result.To {
+			fmt.Printf("Migrated installed.json from schema version %d to %d.\n", result.From, result.To)
+		}
+		fmt.Printf("Filled in metadata for %d package(s).\n", result.Backfill)
+		fmt.Printf("Backup: %s\n", result.Backup)
+	}
+	return nil
+}
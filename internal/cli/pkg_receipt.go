@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/pkg/pkgmgr"
+	"github.com/spf13/cobra"
+)
+
+var pkgReceiptJSON bool
+
+var pkgReceiptCmd = &cobra.Command{
+	Use:   "receipt <name>",
+	Short: "Show the install receipt of a package",
+	Long: `Show the install receipt of a package: the repository URL and commit it was
+installed from, the jd version that installed it, when it was installed and
+last updated, and every file it wrote with its SHA-256 hash.
+
+Receipts are recorded in installed.json at install time, so they can be used
+to audit where installed files came from. Packages installed by a jd version
+that did not record it show the installer as "unknown".
+
+Examples:
+  jd pkg receipt affa-ever--web-fetch
+  jd pkg receipt affa-ever--web-fetch --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPkgReceipt,
+}
+
+func init() {
+	pkgCmd.AddCommand(pkgReceiptCmd)
+	pkgReceiptCmd.Flags().BoolVar(&pkgReceiptJSON, "json", false, "Output in JSON format")
+}
+
+func runPkgReceipt(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+	name := args[0]
+
+	manager := pkgmgr.NewManager(basedir.DataDir())
+	pkg, err := manager.Get(name)
+	if err != nil {
+		if errors.Is(err, pkgmgr.ErrPackageNotFound) {
+			return notFoundErrorf("package '%s' not found. Use 'jd pkg list' to see installed packages", name)
+		}
+		return fmt.Errorf("get package: %w", err)
+	}
+	receipt := pkg.Receipt()
+
+	if pkgReceiptJSON {
+		output, err := json.MarshalIndent(receipt, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(output))
+		return nil
+	}
+
+	fmt.Printf("Package:      %s (%s)\n", receipt.Name, receipt.Type)
+	if receipt.Archive != "" {
+		fmt.Printf("Archive:      %s\n", receipt.Archive)
+	} else if receipt.RepoURL != "" {
+		fmt.Printf("Repository:   %s\n", receipt.RepoURL)
+	} else {
+		fmt.Printf("Repository:   %s (URL not recorded)\n", receipt.Namespace)
+	}
+	fmt.Printf("Source Path:  %s\n", receipt.SourcePath)
+	fmt.Printf("Commit:       %s\n", receipt.Commit)
+	if receipt.Ref != "" {
+		ref := receipt.Ref
+		if receipt.Pinned {
+			ref += " (pinned)"
+		}
+		fmt.Printf("Ref:          %s\n", ref)
+	}
+	fmt.Printf("Installer:    jd %s\n", receipt.Installer)
+	fmt.Printf("Installed At: %s\n", receipt.InstalledAt.Format("2006-01-02 15:04:05 MST"))
+	fmt.Printf("Updated At:   %s\n", receipt.UpdatedAt.Format("2006-01-02 15:04:05 MST"))
+
+	fmt.Printf("\nFiles (%d):\n", len(receipt.Files))
+	for _, f := range receipt.Files {
+		sha := f.SHA256
+		if sha == "" {
+			sha = "(no hash recorded)"
+		}
+		fmt.Printf("  %s  %s\n", sha, f.Path)
+	}
+	return nil
+}
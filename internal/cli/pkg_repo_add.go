@@ -5,29 +5,68 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/pkg/pkgmgr"
 	"github.com/itda-skills/jindo/internal/pkg/repo"
+	"github.com/itda-skills/jindo/internal/progress"
 	"github.com/spf13/cobra"
 )
 
-var pkgRepoAddNamespace string
+var (
+	pkgRepoAddNamespace string
+	pkgRepoAddLink      bool
+	pkgRepoAddBranch    string
+	pkgRepoAddRoot      string
+	pkgRepoAddYes       bool
+	pkgRepoAddInstall   bool
+)
 
 var pkgRepoAddCmd = &cobra.Command{
-	Use:     "add <gh:owner/repo>",
+	Use:     "add <gh:owner/repo|file:///path>",
 	Aliases: []string{"a"},
-	Short:   "Register a GitHub repository",
-	Long: `Register a GitHub repository containing Claude Code packages.
+	Short:   "Register a GitHub or local repository",
+	Long: `Register a GitHub or local repository containing Claude Code packages.
+
+The repository URL must be in the format: gh:owner/repo or file:///path
+
+A local git repository is cloned like a GitHub one. With --link, the local
+directory is symlinked instead, so installs always use the live checkout and
+'jd pkg repo update' leaves it untouched.
 
-The repository URL must be in the format: gh:owner/repo
+The repository's default branch is tracked unless --branch selects another.
+Browse, install, and update checks all use the tracked branch; change it later
+with 'jd pkg repo set-branch'.
+
+For monorepos, --root limits package discovery to a sub-path: only
+<root>/skills, <root>/commands, <root>/agents, and <root>/hooks (and their
+.claude/ equivalents) are scanned, and package paths are relative to the root.
 
 A namespace will be automatically generated from the owner and repo names
 (first 4 characters of each, joined by a hyphen). You can override this
 with the --namespace flag.
 
+If the namespace is already registered, or the generated one is malformed
+(e.g., contains "--"), the registered namespaces and free suggestions (full
+repo name, owner-repo, numbered suffix) are shown; enter a number or type a
+new namespace. --yes takes the first suggestion without asking.
+
 Examples:
   jd pkg repo add gh:affaan-m/everything-claude-code
-  jd pkg repo add gh:user/claude-skills --namespace mysk`,
+  jd pkg repo add gh:user/claude-skills --namespace mysk
+  jd pkg repo add gh:user/claude-skills --yes
+  jd pkg repo add gh:user/claude-skills --branch develop
+  jd pkg repo add gh:org/monorepo --root tools/claude
+  jd pkg repo add file:///home/me/skills --namespace myteam
+  jd pkg repo add file:///home/me/skills --namespace myteam --link
+
+--install-all installs every package of the repository once it is registered,
+as 'jd pkg install <namespace>:all' does; use that command with --type to
+install only some types:
+  jd pkg repo add gh:user/claude-skills --install-all`,
 	Args: cobra.ExactArgs(1),
 	RunE: runPkgRepoAdd,
 }
@@ -35,59 +74,62 @@ Examples:
 func init() {
 	pkgRepoCmd.AddCommand(pkgRepoAddCmd)
 	pkgRepoAddCmd.Flags().StringVarP(&pkgRepoAddNamespace, "namespace", "n", "", "Custom namespace for the repository")
+	pkgRepoAddCmd.Flags().BoolVar(&pkgRepoAddLink, "link", false, "Symlink a local repository instead of cloning it")
+	pkgRepoAddCmd.Flags().StringVarP(&pkgRepoAddBranch, "branch", "b", "", "Branch to track (default: the repository's default branch)")
+	pkgRepoAddCmd.Flags().StringVar(&pkgRepoAddRoot, "root", "", "Sub-path to discover packages under (for monorepos)")
+	pkgRepoAddCmd.Flags().BoolVarP(&pkgRepoAddYes, "yes", "y", false, "Take the first suggested namespace without asking if the generated one is taken")
+	pkgRepoAddCmd.Flags().BoolVar(&pkgRepoAddInstall, "install-all", false, "Install every package of the repository after registering it")
 }
 
 func runPkgRepoAdd(cmd *cobra.Command, args []string) error {
 	cmd.SilenceUsage = true
+	defer syncIndex()
 	url := args[0]
 
-	// Parse URL to generate namespace if not provided
-	owner, repoName, err := repo.ParseURL(url)
-	if err != nil {
-		return fmt.Errorf("invalid URL format. Use: gh:owner/repo")
+	local := repo.IsLocalURL(url)
+	if pkgRepoAddLink && !local {
+		return errors.New("--link requires a local repository (file:///path)")
 	}
-
-	store := repo.NewStore("~/.itda-skills")
-
-	namespace := pkgRepoAddNamespace
-	if namespace == "" {
-		namespace = repo.GenerateNamespace(owner, repoName)
-	}
-
-	// Check if namespace exists
-	exists, err := store.NamespaceExists(namespace)
-	if err != nil {
-		return fmt.Errorf("check namespace: %w", err)
+	if pkgRepoAddLink && pkgRepoAddBranch != "" {
+		return errors.New("--branch cannot be used with --link (the live checkout's branch is used)")
 	}
 
-	if exists {
-		fmt.Printf("Namespace '%s' already exists.\n", namespace)
-		fmt.Print("Enter alternative namespace: ")
-
-		reader := bufio.NewReader(os.Stdin)
-		input, err := reader.ReadString('\n')
+	// Parse URL to generate namespace candidates if not provided
+	var candidates []string
+	if local {
+		path, err := repo.ParseLocalURL(url)
 		if err != nil {
-			return fmt.Errorf("read input: %w", err)
+			return fmt.Errorf("invalid URL format. Use: file:///path/to/repo")
 		}
-
-		namespace = strings.TrimSpace(input)
-		if namespace == "" {
-			return errors.New("namespace cannot be empty")
+		candidates = []string{
+			repo.GenerateLocalNamespace(path),
+			filepath.Base(filepath.Dir(path)) + "-" + filepath.Base(path),
 		}
-
-		// Check again
-		exists, err = store.NamespaceExists(namespace)
+	} else {
+		owner, repoName, err := repo.ParseURL(url)
 		if err != nil {
-			return fmt.Errorf("check namespace: %w", err)
-		}
-		if exists {
-			return fmt.Errorf("namespace '%s' already exists", namespace)
+			return fmt.Errorf("invalid URL format. Use: gh:owner/repo or file:///path")
 		}
+		candidates = []string{repo.GenerateNamespace(owner, repoName), repoName, owner + "-" + repoName}
+	}
+
+	store := repo.NewStore(basedir.DataDir())
+	store.SetProgress(progress.New(os.Stderr))
+
+	namespace, err := chooseRepoNamespace(store, pkgRepoAddNamespace, candidates)
+	if err != nil {
+		return err
 	}
 
 	fmt.Printf("Registering %s...\n", url)
 
-	config, err := store.Add(url, namespace)
+	var config *repo.RepoConfig
+	opts := repo.AddOptions{Branch: pkgRepoAddBranch, Root: pkgRepoAddRoot}
+	if local {
+		config, err = store.AddLocal(url, namespace, pkgRepoAddLink, opts)
+	} else {
+		config, err = store.Add(url, namespace, opts)
+	}
 	if err != nil {
 		if errors.Is(err, repo.ErrNamespaceExists) {
 			return fmt.Errorf("namespace '%s' already exists", namespace)
@@ -99,8 +141,128 @@ func runPkgRepoAdd(cmd *cobra.Command, args []string) error {
 	fmt.Printf("  Namespace:      %s\n", config.Namespace)
 	fmt.Printf("  URL:            %s\n", config.URL)
 	fmt.Printf("  Default Branch: %s\n", config.DefaultBranch)
+	if config.Branch != "" {
+		fmt.Printf("  Branch:         %s\n", config.Branch)
+	}
+	if config.Root != "" {
+		fmt.Printf("  Root:           %s\n", config.Root)
+	}
+	if config.Link {
+		fmt.Printf("  Linked:         yes (updates are live)\n")
+	}
 	fmt.Println()
+
+	if pkgRepoAddInstall {
+		manager := pkgmgr.NewManager(basedir.DataDir())
+		manager.SetProgress(progress.New(os.Stderr))
+		enableAutoSnapshot(manager)
+		if err := applyPackageNaming(manager); err != nil {
+			return err
+		}
+		scope, err := setInstallScope(manager, false)
+		if err != nil {
+			return err
+		}
+		return installAll(manager, config.Namespace, "", nil, scope, false)
+	}
+
 	fmt.Printf("Browse packages: jd pkg browse %s\n", config.Namespace)
 
 	return nil
 }
+
+// chooseRepoNamespace returns the namespace to register a repository under. The --namespace value
+// or generated namespace is used as is unless it is taken (or, if generated, malformed); then the
+// free suggestions are offered and a number or a new namespace is read from stdin. With --yes, the
+// first suggestion is taken instead of asking.
+func chooseRepoNamespace(store *repo.Store, explicit string, candidates []string) (string, error) {
+	namespace := explicit
+	if namespace == "" {
+		namespace = candidates[0]
+	}
+
+	exists, err := store.NamespaceExists(namespace)
+	if err != nil {
+		return "", fmt.Errorf("check namespace: %w", err)
+	}
+	var problem string
+	switch {
+	case exists:
+		problem = fmt.Sprintf("Namespace '%s' already exists.", namespace)
+	case explicit == "":
+		if issue := repo.NamespaceIssue(namespace); issue != "" {
+			problem = fmt.Sprintf("Generated namespace '%s' %s.", namespace, issue)
+		}
+	}
+	if problem == "" {
+		return namespace, nil
+	}
+
+	if explicit != "" {
+		candidates = append([]string{explicit}, candidates...)
+	}
+	suggestions, err := store.SuggestNamespaces(candidates...)
+	if err != nil {
+		return "", fmt.Errorf("suggest namespaces: %w", err)
+	}
+
+	if pkgRepoAddYes {
+		if explicit != "" || len(suggestions) == 0 {
+			return "", fmt.Errorf("namespace '%s' already exists (free: %s)", namespace, strings.Join(suggestions, ", "))
+		}
+		fmt.Printf("%s Using '%s'.\n", problem, suggestions[0])
+		return suggestions[0], nil
+	}
+
+	fmt.Println(problem)
+	if repos, err := store.List(); err == nil && len(repos) > 0 {
+		registered := make([]string, len(repos))
+		for i, r := range repos {
+			registered[i] = r.Namespace
+		}
+		fmt.Printf("Registered namespaces: %s\n", strings.Join(registered, ", "))
+	}
+	fmt.Println("Choose a namespace:")
+	for i, ns := range suggestions {
+		fmt.Printf("  %d) %s\n", i+1, ns)
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		if len(suggestions) > 0 {
+			fmt.Print("Enter a number or a new namespace [1]: ")
+		} else {
+			fmt.Print("Enter a new namespace: ")
+		}
+		input, readErr := reader.ReadString('\n')
+		input = strings.TrimSpace(input)
+		if readErr != nil && input == "" {
+			fmt.Println()
+			return "", errors.New("no namespace chosen. Use --namespace or --yes")
+		}
+
+		if input == "" && len(suggestions) > 0 {
+			return suggestions[0], nil
+		}
+		if n, err := strconv.Atoi(input); err == nil {
+			if n >= 1 && n <= len(suggestions) {
+				return suggestions[n-1], nil
+			}
+			fmt.Printf("Enter a number from 1 to %d.\n", len(suggestions))
+			continue
+		}
+
+		if issue := repo.NamespaceIssue(input); issue != "" {
+			fmt.Printf("Namespace '%s' %s.\n", input, issue)
+		} else if exists, err := store.NamespaceExists(input); err != nil {
+			return "", fmt.Errorf("check namespace: %w", err)
+		} else if exists {
+			fmt.Printf("Namespace '%s' already exists.\n", input)
+		} else {
+			return input, nil
+		}
+		if readErr != nil {
+			return "", errors.New("no namespace chosen. Use --namespace or --yes")
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/pkg/repo"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pkgRepoConfigureSkills   []string
+	pkgRepoConfigureCommands []string
+	pkgRepoConfigureAgents   []string
+	pkgRepoConfigureHooks    []string
+	pkgRepoConfigureReset    bool
+)
+
+var pkgRepoConfigureCmd = &cobra.Command{
+	Use:   "configure <namespace>",
+	Short: "Configure where a repository's packages are scanned",
+	Long: `Configure the directories scanned for each package type in a repository.
+
+By default jd scans skills/, commands/, agents/, and hooks/ (and their .claude/
+equivalents). Repositories with a different layout can map each package type
+to its own directories. Directories are relative to the package root (see
+'jd pkg repo add --root') and may contain glob patterns such as
+plugins/*/skills.
+
+Each flag replaces the directories for its type; types without a flag keep
+their current directories, and an empty value (--skills '') restores a type's
+default directories. Without flags, the current layout is shown.
+
+Examples:
+  jd pkg repo configure mysk                              # Show layout
+  jd pkg repo configure mysk --skills ai/skills
+  jd pkg repo configure mysk --commands prompts,ai/commands
+  jd pkg repo configure mysk --skills 'plugins/*/skills'
+  jd pkg repo configure mysk --reset                      # Default layout`,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runPkgRepoConfigure,
+	ValidArgsFunction: pkgBrowseCompletion,
+}
+
+func init() {
+	pkgRepoCmd.AddCommand(pkgRepoConfigureCmd)
+	pkgRepoConfigureCmd.Flags().StringSliceVar(&pkgRepoConfigureSkills, "skills", nil, "Directories to scan for skills")
+	pkgRepoConfigureCmd.Flags().StringSliceVar(&pkgRepoConfigureCommands, "commands", nil, "Directories to scan for commands")
+	pkgRepoConfigureCmd.Flags().StringSliceVar(&pkgRepoConfigureAgents, "agents", nil, "Directories to scan for agents")
+	pkgRepoConfigureCmd.Flags().StringSliceVar(&pkgRepoConfigureHooks, "hooks", nil, "Directories to scan for hooks")
+	pkgRepoConfigureCmd.Flags().BoolVar(&pkgRepoConfigureReset, "reset", false, "Restore the default layout")
+}
+
+func runPkgRepoConfigure(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+	defer syncIndex()
+	namespace := args[0]
+
+	store := repo.NewStore(basedir.DataDir())
+
+	config, err := store.Get(namespace)
+	if err != nil {
+		if errors.Is(err, repo.ErrRepoNotFound) {
+			return notFoundErrorf("repository '%s' not found", namespace)
+		}
+		return fmt.Errorf("get repository: %w", err)
+	}
+
+	flags := map[repo.PackageType]string{
+		repo.TypeSkill:   "skills",
+		repo.TypeCommand: "commands",
+		repo.TypeAgent:   "agents",
+		repo.TypeHook:    "hooks",
+	}
+	values := map[repo.PackageType][]string{
+		repo.TypeSkill:   pkgRepoConfigureSkills,
+		repo.TypeCommand: pkgRepoConfigureCommands,
+		repo.TypeAgent:   pkgRepoConfigureAgents,
+		repo.TypeHook:    pkgRepoConfigureHooks,
+	}
+
+	changed := false
+	for _, t := range repo.PackageTypes {
+		if cmd.Flags().Changed(flags[t]) {
+			changed = true
+		}
+	}
+	if pkgRepoConfigureReset && changed {
+		return errors.New("--reset cannot be combined with directory flags")
+	}
+
+	if pkgRepoConfigureReset || changed {
+		layout := make(repo.Layout)
+		if !pkgRepoConfigureReset {
+			for t, dirs := range config.Layout {
+				layout[t] = dirs
+			}
+			for _, t := range repo.PackageTypes {
+				if cmd.Flags().Changed(flags[t]) {
+					layout[t] = values[t]
+				}
+			}
+		}
+
+		config, err = store.SetLayout(namespace, layout)
+		if err != nil {
+			return fmt.Errorf("set layout: %w", err)
+		}
+		fmt.Printf("✅ Updated scan layout for %s\n\n", config.Namespace)
+	}
+
+	fmt.Printf("Scan layout for %s:\n", config.Namespace)
+	for _, t := range repo.PackageTypes {
+		source := "default"
+		if _, ok := config.Layout[t]; ok {
+			source = "custom"
+		}
+		fmt.Printf("  %-9s %s (%s)\n", flags[t]+":", strings.Join(config.Layout.Dirs(t), ", "), source)
+	}
+	if config.Root != "" {
+		fmt.Printf("\nDirectories are relative to root: %s\n", config.Root)
+	}
+
+	return nil
+}
@@ -1,11 +1,12 @@
 package cli
 
 import (
-	"encoding/json"
 	"fmt"
-	"strings"
+	"os"
 
+	"github.com/itda-skills/jindo/internal/basedir"
 	"github.com/itda-skills/jindo/internal/pkg/repo"
+	"github.com/itda-skills/jindo/internal/table"
 	"github.com/spf13/cobra"
 )
 
@@ -22,18 +23,20 @@ var pkgRepoListCmd = &cobra.Command{
 func init() {
 	pkgRepoCmd.AddCommand(pkgRepoListCmd)
 	pkgRepoListCmd.Flags().BoolVar(&pkgRepoListJSON, "json", false, "Output in JSON format")
+	addOutputFlags(pkgRepoListCmd)
+	addNoTruncFlag(pkgRepoListCmd)
 }
 
 func runPkgRepoList(cmd *cobra.Command, _ []string) error {
 	cmd.SilenceUsage = true
-	store := repo.NewStore("~/.itda-skills")
+	store := repo.NewStore(basedir.DataDir())
 
 	repos, err := store.List()
 	if err != nil {
 		return fmt.Errorf("list repositories: %w", err)
 	}
 
-	if len(repos) == 0 {
+	if len(repos) == 0 && !formattedOutput() {
 		fmt.Println("No repositories registered.")
 		fmt.Println()
 		fmt.Println("Add a repository with:")
@@ -41,75 +44,24 @@ func runPkgRepoList(cmd *cobra.Command, _ []string) error {
 		return nil
 	}
 
-	if pkgRepoListJSON {
-		output, err := json.MarshalIndent(repos, "", "  ")
-		if err != nil {
-			return err
-		}
-		fmt.Println(string(output))
-		return nil
+	if pkgRepoListJSON || formattedOutput() {
+		return printJSON(repos)
 	}
 
-	// Calculate column widths
-	nsWidth := len("NAMESPACE")
-	urlWidth := len("URL")
-	branchWidth := len("BRANCH")
-
+	t := newTable(
+		table.Column{Header: "NAMESPACE", Max: 20},
+		table.Column{Header: "URL", Max: 50, Min: 20},
+		table.Column{Header: "BRANCH", Max: 15},
+		table.Column{Header: "TRUSTED"},
+	)
 	for _, r := range repos {
-		if len(r.Namespace) > nsWidth {
-			nsWidth = len(r.Namespace)
-		}
-		if len(r.URL) > urlWidth {
-			urlWidth = len(r.URL)
-		}
-		if len(r.DefaultBranch) > branchWidth {
-			branchWidth = len(r.DefaultBranch)
+		trusted := "no"
+		if r.Trusted {
+			trusted = "yes"
 		}
+		t.AddRow(r.Namespace, r.URL, r.TrackedBranch(), trusted)
 	}
-
-	// Cap widths
-	if nsWidth > 20 {
-		nsWidth = 20
-	}
-	if urlWidth > 50 {
-		urlWidth = 50
-	}
-	if branchWidth > 15 {
-		branchWidth = 15
-	}
-
-	// Print header
-	fmt.Printf("%-*s  %-*s  %-*s\n",
-		nsWidth, "NAMESPACE",
-		urlWidth, "URL",
-		branchWidth, "BRANCH")
-	fmt.Printf("%s  %s  %s\n",
-		strings.Repeat("-", nsWidth),
-		strings.Repeat("-", urlWidth),
-		strings.Repeat("-", branchWidth))
-
-	// Print rows
-	for _, r := range repos {
-		ns := r.Namespace
-		if len(ns) > nsWidth {
-			ns = ns[:nsWidth-3] + "..."
-		}
-
-		url := r.URL
-		if len(url) > urlWidth {
-			url = url[:urlWidth-3] + "..."
-		}
-
-		branch := r.DefaultBranch
-		if len(branch) > branchWidth {
-			branch = branch[:branchWidth-3] + "..."
-		}
-
-		fmt.Printf("%-*s  %-*s  %-*s\n",
-			nsWidth, ns,
-			urlWidth, url,
-			branchWidth, branch)
-	}
+	t.Render(os.Stdout)
 
 	fmt.Printf("\nTotal: %d repositories\n", len(repos))
 	return nil
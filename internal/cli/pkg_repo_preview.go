@@ -0,0 +1,183 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/itda-skills/jindo/internal/pkg/repo"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pkgRepoPreviewBranch string
+	pkgRepoPreviewRoot   string
+	pkgRepoPreviewType   string
+	pkgRepoPreviewJSON   bool
+)
+
+var pkgRepoPreviewCmd = &cobra.Command{
+	Use:     "preview <gh:owner/repo> [package-path]",
+	Aliases: []string{"peek"},
+	Short:   "Inspect a GitHub repository without cloning it",
+	Long: `List the skills, commands, agents, and hooks in a GitHub repository using the
+GitHub API, without cloning it or registering a namespace.
+
+With a package path from the listing, print the package's file instead: a
+skill's SKILL.md, or a command, agent, or hook file.
+
+Packages are found in the standard layout (skills/, commands/, agents/, hooks/,
+and the same under .claude/). Set GITHUB_TOKEN for private repositories or to
+raise the API rate limit.
+
+Examples:
+  jd pkg repo preview gh:affaan-m/everything-claude-code
+  jd pkg repo preview gh:affaan-m/everything-claude-code skills/web-fetch
+  jd pkg repo preview gh:user/repo --branch develop --root tools/claude
+  jd pkg repo preview gh:user/repo --type skills --json`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runPkgRepoPreview,
+}
+
+func init() {
+	pkgRepoCmd.AddCommand(pkgRepoPreviewCmd)
+	pkgRepoPreviewCmd.Flags().StringVarP(&pkgRepoPreviewBranch, "branch", "b", "", "Branch to inspect (default: the repository's default branch)")
+	pkgRepoPreviewCmd.Flags().StringVar(&pkgRepoPreviewRoot, "root", "", "Only look for packages under this sub-path")
+	pkgRepoPreviewCmd.Flags().StringVarP(&pkgRepoPreviewType, "type", "t", "", "Filter by type (skills, commands, agents, hooks)")
+	pkgRepoPreviewCmd.Flags().BoolVar(&pkgRepoPreviewJSON, "json", false, "Output in JSON format")
+}
+
+func runPkgRepoPreview(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	owner, name, err := repo.ParseURL(args[0])
+	if err != nil {
+		return validationErrorf("invalid repository %q. Use: gh:owner/repo", args[0])
+	}
+
+	var typeFilter repo.PackageType
+	switch pkgRepoPreviewType {
+	case "":
+		// No filter
+	case "skills", "skill":
+		typeFilter = repo.TypeSkill
+	case "commands", "command":
+		typeFilter = repo.TypeCommand
+	case "agents", "agent":
+		typeFilter = repo.TypeAgent
+	case "hooks", "hook":
+		typeFilter = repo.TypeHook
+	default:
+		return fmt.Errorf("invalid type: %s (use: skills, commands, agents, hooks)", pkgRepoPreviewType)
+	}
+
+	fmt.Fprintf(os.Stderr, "Fetching %s/%s from GitHub...\n", owner, name)
+	tree, err := repo.FetchRemoteTree(owner, name, pkgRepoPreviewBranch)
+	if err != nil {
+		return fmt.Errorf("failed to list repository: %w", err)
+	}
+	if tree.Truncated {
+		fmt.Fprintln(os.Stderr, "Warning: the repository is too large to list completely; some packages may be missing. Use --root to narrow it down.")
+	}
+
+	items := tree.Packages(pkgRepoPreviewRoot, nil, typeFilter)
+
+	if len(args) == 2 {
+		return printPreviewPackage(tree, items, args[1])
+	}
+
+	if pkgRepoPreviewJSON {
+		if items == nil {
+			items = []repo.BrowseItem{}
+		}
+		output, err := json.MarshalIndent(items, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(output))
+		return nil
+	}
+
+	fmt.Printf("Repository: %s/%s (branch %s)\n", owner, name, tree.Branch)
+	if tree.Description != "" {
+		fmt.Printf("Description: %s\n", tree.Description)
+	}
+
+	if len(items) == 0 {
+		fmt.Println("\nNo packages found.")
+		return nil
+	}
+
+	for _, pkgType := range repo.PackageTypes {
+		var ofType []repo.BrowseItem
+		nameWidth := 0
+		for _, item := range items {
+			if item.Type == pkgType {
+				ofType = append(ofType, item)
+				nameWidth = max(nameWidth, len(item.Name))
+			}
+		}
+		if len(ofType) == 0 {
+			continue
+		}
+		fmt.Printf("\n%ss (%d):\n", strings.ToUpper(string(pkgType[:1]))+string(pkgType[1:]), len(ofType))
+		for _, item := range ofType {
+			fmt.Printf("  %-*s  %s\n", nameWidth, item.Name, item.Path)
+		}
+	}
+
+	addCmd := "jd pkg repo add " + args[0]
+	if pkgRepoPreviewBranch != "" {
+		addCmd += " --branch " + pkgRepoPreviewBranch
+	}
+	if pkgRepoPreviewRoot != "" {
+		addCmd += " --root " + pkgRepoPreviewRoot
+	}
+	fmt.Printf("\nPreview a package: jd pkg repo preview %s <path>\n", args[0])
+	fmt.Printf("Register it:       %s\n", addCmd)
+	return nil
+}
+
+// printPreviewPackage prints the file of the package at pkgPath (or named pkgPath)
+func printPreviewPackage(tree *repo.RemoteTree, items []repo.BrowseItem, pkgPath string) error {
+	pkgPath = strings.Trim(pkgPath, "/")
+
+	var item *repo.BrowseItem
+	for i := range items {
+		if items[i].Path == pkgPath || items[i].Name == pkgPath {
+			item = &items[i]
+			break
+		}
+	}
+	if item == nil {
+		return notFoundErrorf("package '%s' not found in %s/%s; run without a path to list packages", pkgPath, tree.Owner, tree.Repo)
+	}
+
+	filePath := item.Path
+	if root := strings.Trim(pkgRepoPreviewRoot, "/"); root != "" {
+		filePath = root + "/" + filePath
+	}
+	if item.Type == repo.TypeSkill {
+		skillFile := path.Join(filePath, "SKILL.md")
+		for _, f := range tree.Files {
+			if f == path.Join(filePath, "skill.md") {
+				skillFile = f
+			}
+		}
+		filePath = skillFile
+	}
+
+	content, err := tree.FetchFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", filePath, err)
+	}
+
+	fmt.Fprintf(os.Stderr, "=== %s (%s) ===\n", filePath, item.Type)
+	fmt.Print(string(content))
+	if len(content) > 0 && content[len(content)-1] != '\n' {
+		fmt.Println()
+	}
+	return nil
+}
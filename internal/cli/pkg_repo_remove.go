@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/pkg/pkgmgr"
 	"github.com/itda-skills/jindo/internal/pkg/repo"
 	"github.com/spf13/cobra"
 )
@@ -14,38 +16,72 @@ var pkgRepoRemoveCmd = &cobra.Command{
 	Short:   "Remove a registered repository",
 	Long: `Remove a registered repository by its namespace.
 
-Note: This only removes the repository registration.
-Installed packages from this repository will remain installed.
+If packages from the repository are installed, they are listed and you are
+asked whether to uninstall them as well; declining aborts. Use --cascade to
+uninstall them without asking, or --keep-installed to remove only the
+registration and leave them installed (they can no longer be updated).
 
 Example:
-  jd pkg repo remove affa-ever`,
+  jd pkg repo remove affa-ever
+  jd pkg repo remove affa-ever --cascade
+  jd pkg repo remove affa-ever --keep-installed`,
 	Args: cobra.ExactArgs(1),
 	RunE: runPkgRepoRemove,
 }
 
+var (
+	pkgRepoRemoveCascade       bool
+	pkgRepoRemoveKeepInstalled bool
+)
+
 func init() {
 	pkgRepoCmd.AddCommand(pkgRepoRemoveCmd)
+	pkgRepoRemoveCmd.Flags().BoolVar(&pkgRepoRemoveCascade, "cascade", false, "Uninstall the repository's installed packages without asking")
+	pkgRepoRemoveCmd.Flags().BoolVar(&pkgRepoRemoveKeepInstalled, "keep-installed", false, "Leave the repository's installed packages installed")
+	pkgRepoRemoveCmd.MarkFlagsMutuallyExclusive("cascade", "keep-installed")
 }
 
 func runPkgRepoRemove(cmd *cobra.Command, args []string) error {
 	cmd.SilenceUsage = true
+	defer syncIndex()
 	namespace := args[0]
 
-	store := repo.NewStore("~/.itda-skills")
+	manager := pkgmgr.NewManager(basedir.DataDir())
+	store := manager.RepoStore()
 
 	// Check if exists
 	config, err := store.Get(namespace)
 	if err != nil {
 		if errors.Is(err, repo.ErrRepoNotFound) {
-			return fmt.Errorf("repository '%s' not found", namespace)
+			return notFoundErrorf("repository '%s' not found", namespace)
 		}
 		return fmt.Errorf("get repository: %w", err)
 	}
 
+	installed, err := manager.ListNamespace(namespace)
+	if err != nil {
+		return fmt.Errorf("list packages: %w", err)
+	}
+	if len(installed) > 0 && !pkgRepoRemoveKeepInstalled {
+		if !pkgRepoRemoveCascade {
+			fmt.Printf("%d package(s) from '%s' are installed:\n", len(installed), namespace)
+			if !confirmUninstall(manager, installed) {
+				fmt.Println("Cancelled. Use --keep-installed to remove only the repository.")
+				return errCancelled
+			}
+		}
+		if err := uninstallPackages(manager, installed, false, false); err != nil {
+			return err
+		}
+	}
+
 	if err := store.Remove(namespace); err != nil {
 		return fmt.Errorf("remove repository: %w", err)
 	}
 
 	fmt.Printf("Removed repository: %s (%s)\n", namespace, config.URL)
+	if len(installed) > 0 && pkgRepoRemoveKeepInstalled {
+		fmt.Printf("%d package(s) from '%s' remain installed and can no longer be updated.\n", len(installed), namespace)
+	}
 	return nil
 }
@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/pkg/repo"
+	"github.com/spf13/cobra"
+)
+
+var pkgRepoSetBranchCmd = &cobra.Command{
+	Use:   "set-branch <namespace> <branch>",
+	Short: "Change the branch a repository tracks",
+	Long: `Re-point a repository clone at another branch.
+
+Browse, install, and update checks use the tracked branch. Installed packages
+keep their files until updated; 'jd pkg update' then compares them against the
+new branch. Setting the default branch clears the override.
+
+Examples:
+  jd pkg repo set-branch affa-ever develop
+  jd pkg repo set-branch affa-ever main     # Back to the default branch`,
+	Args:              cobra.ExactArgs(2),
+	RunE:              runPkgRepoSetBranch,
+	ValidArgsFunction: pkgBrowseCompletion,
+}
+
+func init() {
+	pkgRepoCmd.AddCommand(pkgRepoSetBranchCmd)
+}
+
+func runPkgRepoSetBranch(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+	defer syncIndex()
+	namespace, branch := args[0], args[1]
+
+	store := repo.NewStore(basedir.DataDir())
+
+	fmt.Printf("Switching %s to %s...\n", namespace, branch)
+	config, err := store.SetBranch(namespace, branch)
+	if err != nil {
+		if errors.Is(err, repo.ErrRepoNotFound) {
+			return notFoundErrorf("repository '%s' not found", namespace)
+		}
+		return fmt.Errorf("set branch: %w", err)
+	}
+
+	fmt.Printf("✅ %s now tracks %s\n", config.Namespace, config.TrackedBranch())
+	if config.Branch == "" {
+		fmt.Println("   (default branch)")
+	}
+	fmt.Printf("\nCheck installed packages: jd pkg update\n")
+	return nil
+}
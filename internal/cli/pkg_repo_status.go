@@ -0,0 +1,157 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/pkg/repo"
+	"github.com/itda-skills/jindo/internal/table"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pkgRepoStatusRepair bool
+	pkgRepoStatusQuick  bool
+)
+
+var pkgRepoStatusCmd = &cobra.Command{
+	Use:   "status [namespace...]",
+	Short: "Check the local clones of registered repositories",
+	Long: `Check that the local clone of each registered repository is intact.
+
+An interrupted clone or pull can leave a clone without a usable HEAD or with
+missing objects; browsing and installing from it then fail in confusing ways.
+status lists every repository with its branch, commit, and whether its clone
+is ok, checking every object of the history with git fsck (--quick only checks
+that HEAD resolves, as browse and install do).
+
+Broken clones can be replaced with a fresh clone of the same URL and branch,
+under the same namespace and settings. On a terminal, status offers to do it;
+--repair does it without asking. Linked repositories are your own checkout and
+are not checked.
+
+Examples:
+  jd pkg repo status               # Check all
+  jd pkg repo status affa-ever     # Check one
+  jd pkg repo status --repair      # Re-clone broken repositories`,
+	RunE:              runPkgRepoStatus,
+	ValidArgsFunction: pkgBrowseCompletion,
+}
+
+func init() {
+	pkgRepoCmd.AddCommand(pkgRepoStatusCmd)
+	pkgRepoStatusCmd.Flags().BoolVar(&pkgRepoStatusRepair, "repair", false, "Re-clone broken repositories without asking")
+	pkgRepoStatusCmd.Flags().BoolVar(&pkgRepoStatusQuick, "quick", false, "Only check that HEAD resolves, skipping git fsck")
+	addNoTruncFlag(pkgRepoStatusCmd)
+}
+
+func runPkgRepoStatus(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+	store := repo.NewStore(basedir.DataDir())
+
+	var repos []repo.RepoConfig
+	if len(args) == 0 {
+		var err error
+		if repos, err = store.List(); err != nil {
+			return fmt.Errorf("list repositories: %w", err)
+		}
+	} else {
+		for _, namespace := range args {
+			r, err := store.Get(namespace)
+			if err != nil {
+				return notFoundErrorf("repository '%s' not found", namespace)
+			}
+			repos = append(repos, *r)
+		}
+	}
+	if len(repos) == 0 {
+		fmt.Println("No repositories registered.")
+		return nil
+	}
+
+	t := newTable(
+		table.Column{Header: "NAMESPACE", Max: 20},
+		table.Column{Header: "BRANCH", Max: 15},
+		table.Column{Header: "COMMIT"},
+		table.Column{Header: "STATUS", Max: 60, Min: 20},
+	)
+	var broken []string
+	for _, r := range repos {
+		status, commit := "ok", "-"
+		err := store.Check(r.Namespace, !pkgRepoStatusQuick)
+		switch {
+		case r.Link:
+			status = "linked"
+		case err != nil:
+			status = strings.TrimPrefix(err.Error(), repo.ErrCloneBroken.Error()+": ")
+			broken = append(broken, r.Namespace)
+		}
+		if head, headErr := store.Head(r.Namespace); err == nil && headErr == nil && len(head) >= 8 {
+			commit = head[:8]
+		}
+		t.AddRow(r.Namespace, r.TrackedBranch(), commit, status)
+	}
+	t.Render(os.Stdout)
+
+	if len(broken) == 0 {
+		fmt.Printf("\nAll %d clone(s) are ok.\n", len(repos))
+		return nil
+	}
+	fmt.Printf("\n%d broken clone(s): %s\n", len(broken), strings.Join(broken, ", "))
+	return repairClones(store, broken, pkgRepoStatusRepair)
+}
+
+// repairClones offers to re-clone broken repositories, and re-clones them without
+// asking with yes. Returns an error naming those left broken.
+func repairClones(store *repo.Store, broken []string, yes bool) error {
+	if !yes {
+		if !isTerminal(os.Stdin) {
+			return fmt.Errorf("%d repository clone(s) are broken. Re-clone them with: jd pkg repo status --repair", len(broken))
+		}
+		fmt.Printf("Re-clone %s? (y/N): ", strings.Join(broken, ", "))
+		reader := bufio.NewReader(os.Stdin)
+		response, _ := reader.ReadString('\n')
+		response = strings.TrimSpace(strings.ToLower(response))
+		if response != "y" && response != "yes" {
+			return fmt.Errorf("%d repository clone(s) are broken. Re-clone them with: jd pkg repo status --repair", len(broken))
+		}
+	}
+
+	var failed []string
+	for _, namespace := range broken {
+		if err := store.Reclone(namespace); err != nil {
+			fmt.Printf("  ✗ %s: %v\n", namespace, err)
+			failed = append(failed, namespace)
+			continue
+		}
+		fmt.Printf("  ✓ Re-cloned %s\n", namespace)
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to re-clone %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// brokenCloneError adds how to repair a broken clone to an error about it
+func brokenCloneError(namespace string, err error) error {
+	if !errors.Is(err, repo.ErrCloneBroken) {
+		return err
+	}
+	return fmt.Errorf("%s: %w\nRe-clone it with: jd pkg repo status --repair %s", namespace, err, namespace)
+}
+
+// brokenClones returns the repositories whose clone is broken, checking HEAD only
+func brokenClones(store *repo.Store, repos []repo.RepoConfig) []string {
+	var broken []string
+	for _, r := range repos {
+		if err := store.Check(r.Namespace, false); errors.Is(err, repo.ErrCloneBroken) {
+			fmt.Fprintf(os.Stderr, "Warning: %s: %v\n", r.Namespace, err)
+			broken = append(broken, r.Namespace)
+		}
+	}
+	return broken
+}
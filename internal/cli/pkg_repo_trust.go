@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/pkg/repo"
+	"github.com/spf13/cobra"
+)
+
+var pkgRepoTrustRevoke bool
+
+var pkgRepoTrustCmd = &cobra.Command{
+	Use:   "trust <namespace>",
+	Short: "Trust a repository's hooks and commands",
+	Long: `Mark a repository as trusted.
+
+Hooks run shell commands on every matching Claude Code event, and commands can
+run shell commands when invoked. Installing either from an untrusted repository
+prints a warning and asks for confirmation; trusting the repository suppresses
+it. Skills and agents install without a warning either way.
+
+Trust is recorded per namespace in repos.json and shown by 'jd pkg repo list'.
+It is a lightweight guard, not a signature check: review a repository before
+trusting it.
+
+Examples:
+  jd pkg repo trust affa-ever
+  jd pkg repo trust affa-ever --revoke`,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runPkgRepoTrust,
+	ValidArgsFunction: pkgBrowseCompletion,
+}
+
+func init() {
+	pkgRepoCmd.AddCommand(pkgRepoTrustCmd)
+	pkgRepoTrustCmd.Flags().BoolVar(&pkgRepoTrustRevoke, "revoke", false, "Stop trusting the repository")
+}
+
+func runPkgRepoTrust(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+	namespace := args[0]
+
+	store := repo.NewStore(basedir.DataDir())
+
+	config, err := store.SetTrusted(namespace, !pkgRepoTrustRevoke)
+	if err != nil {
+		if errors.Is(err, repo.ErrRepoNotFound) {
+			return notFoundErrorf("repository '%s' not found", namespace)
+		}
+		return fmt.Errorf("set trust: %w", err)
+	}
+
+	if config.Trusted {
+		fmt.Printf("✅ %s is trusted\n", config.Namespace)
+		fmt.Println("   Hooks and commands from it install without a warning.")
+	} else {
+		fmt.Printf("✅ %s is no longer trusted\n", config.Namespace)
+		fmt.Println("   Installing hooks or commands from it asks for confirmation.")
+	}
+	return nil
+}
@@ -1,12 +1,30 @@
 package cli
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"slices"
+	"strings"
 
+	"github.com/itda-skills/jindo/internal/basedir"
 	"github.com/itda-skills/jindo/internal/pkg/repo"
+	"github.com/itda-skills/jindo/internal/shell"
+	"github.com/itda-skills/jindo/pkg/config"
 	"github.com/spf13/cobra"
 )
 
+// Config keys for actions run after repositories are updated
+const (
+	postUpdateCommandsKey = "repos.post_update.commands"
+	postUpdateReposKey    = "repos.post_update.repos"
+)
+
+// postUpdateReposEnv lists the updated repositories for post-update actions
+const postUpdateReposEnv = "JD_UPDATED_REPOS"
+
+var pkgRepoUpdateNoHooks bool
+
 var pkgRepoUpdateCmd = &cobra.Command{
 	Use:     "update [namespace...]",
 	Aliases: []string{"u", "up"},
@@ -16,35 +34,112 @@ var pkgRepoUpdateCmd = &cobra.Command{
 Without arguments, updates all registered repositories.
 With arguments, updates only the specified repositories.
 
+Post-update actions are shell commands run once after the pulls, when a
+repository that opted in received new commits. The updated repositories are
+passed in ` + postUpdateReposEnv + ` (comma-separated). Each repository opts in by
+being listed in ` + postUpdateReposKey + ` ("*" for all). Use --no-hooks to skip them.
+Both keys are read from the global config.toml only; a project's
+.claude/jindo.toml cannot set them.
+
+  jd config edit
+  [repos.post_update]
+  commands = ["jd pkg update --apply", "jd validate"]
+  repos = ["affa-ever"]
+
 Examples:
   jd pkg repo update              # Update all
-  jd pkg repo update affa-ever    # Update specific repo`,
+  jd pkg repo update affa-ever    # Update specific repo
+  jd pkg repo update --no-hooks   # Do not run post-update actions`,
 	RunE: runPkgRepoUpdate,
 }
 
 func init() {
 	pkgRepoCmd.AddCommand(pkgRepoUpdateCmd)
+	pkgRepoUpdateCmd.Flags().BoolVar(&pkgRepoUpdateNoHooks, "no-hooks", false, "Do not run post-update actions")
 }
 
 func runPkgRepoUpdate(cmd *cobra.Command, args []string) error {
 	cmd.SilenceUsage = true
-	store := repo.NewStore("~/.itda-skills")
+	store := repo.NewStore(basedir.DataDir())
 
+	var updated []string
 	if len(args) == 0 {
 		// Update all
 		fmt.Println("Updating all repositories...")
-		return store.UpdateAll()
+		var err error
+		if updated, err = store.UpdateAll(); err != nil {
+			return err
+		}
+	} else {
+		// Update specific repos
+		for _, namespace := range args {
+			fmt.Printf("Updating %s...\n", namespace)
+			before, _ := store.Head(namespace)
+			if err := store.Update(namespace); err != nil {
+				fmt.Printf("  Error: %v\n", err)
+				continue
+			}
+			if after, _ := store.Head(namespace); after != before {
+				updated = append(updated, namespace)
+			}
+			fmt.Println("  Done")
+		}
 	}
 
-	// Update specific repos
-	for _, namespace := range args {
-		fmt.Printf("Updating %s...\n", namespace)
-		if err := store.Update(namespace); err != nil {
-			fmt.Printf("  Error: %v\n", err)
-			continue
+	// Post-update actions see the updated packages in the index
+	syncIndex()
+
+	if pkgRepoUpdateNoHooks {
+		return nil
+	}
+	return runPostUpdateActions(updated)
+}
+
+// runPostUpdateActions runs the configured post-update actions once if any of the
+// updated repositories opted in to them. They are read from the global config
+// only, so that a cloned project cannot run commands with its .claude/jindo.toml.
+func runPostUpdateActions(updated []string) error {
+	cfg, err := config.LoadGlobal()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	commands := cfg.GetStringSlice(postUpdateCommandsKey)
+	optedIn := cfg.GetStringSlice(postUpdateReposKey)
+	if len(commands) == 0 {
+		return nil
+	}
+
+	var triggered []string
+	for _, namespace := range updated {
+		if slices.Contains(optedIn, "*") || slices.Contains(optedIn, namespace) {
+			triggered = append(triggered, namespace)
 		}
-		fmt.Println("  Done")
+	}
+	if len(triggered) == 0 {
+		return nil
 	}
 
+	failed := 0
+	for _, command := range commands {
+		fmt.Printf("Running post-update action: %s\n", command)
+		if err := runShellCommand(command, postUpdateReposEnv+"="+strings.Join(triggered, ",")); err != nil {
+			fmt.Fprintf(os.Stderr, "  Warning: %s: %v\n", command, err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d post-update action(s) failed", failed)
+	}
 	return nil
 }
+
+// runShellCommand runs a command line with the platform shell, attached to the
+// terminal, with extra environment variables
+func runShellCommand(command string, env ...string) error {
+	return shell.Run(context.Background(), command, shell.Options{
+		Env:    append(os.Environ(), env...),
+		Stdin:  os.Stdin,
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+	})
+}
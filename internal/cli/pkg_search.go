@@ -1,12 +1,15 @@
 package cli
 
 import (
-	"encoding/json"
 	"fmt"
+	"os"
 	"sort"
-	"strings"
 
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/index"
 	"github.com/itda-skills/jindo/internal/pkg/repo"
+	"github.com/itda-skills/jindo/internal/sorting"
+	"github.com/itda-skills/jindo/internal/table"
 	"github.com/spf13/cobra"
 )
 
@@ -16,13 +19,19 @@ var pkgSearchCmd = &cobra.Command{
 	Use:     "search <query>",
 	Aliases: []string{"se"},
 	Short:   "Search for packages across all registered repositories",
-	Long: `Search for packages by name across all registered repositories.
+	Long: `Search for packages across all registered repositories.
 
-The search is case-insensitive and matches package names containing the query.
+The search uses the full-text index (see 'jd index'): every word of the query
+must start a word in a package's name, path, description, or content. Packages
+matching in their name are listed first, then those matching in their description.
+
+With indexing disabled (` + indexEnabledKey + ` = false), the search is case-insensitive
+and matches package names containing the query.
 
 Examples:
   jd pkg search web
-  jd pkg search commit`,
+  jd pkg search commit
+  jd pkg search "pull request"`,
 	Args: cobra.ExactArgs(1),
 	RunE: runPkgSearch,
 }
@@ -30,111 +39,100 @@ Examples:
 func init() {
 	pkgCmd.AddCommand(pkgSearchCmd)
 	pkgSearchCmd.Flags().BoolVar(&pkgSearchJSON, "json", false, "Output in JSON format")
+	addOutputFlags(pkgSearchCmd)
+	addNoTruncFlag(pkgSearchCmd)
 }
 
 func runPkgSearch(cmd *cobra.Command, args []string) error {
 	cmd.SilenceUsage = true
 	query := args[0]
 
-	store := repo.NewStore("~/.itda-skills")
-
-	results, err := store.Search(query)
-	if err != nil {
-		return fmt.Errorf("search: %w", err)
+	var results map[string][]repo.BrowseItem
+	if ix := loadIndex(); ix != nil {
+		results = indexedPackageSearch(ix, query)
+	} else {
+		var err error
+		results, err = repo.NewStore(basedir.DataDir()).Search(query)
+		if err != nil {
+			return fmt.Errorf("search: %w", err)
+		}
 	}
 
-	if len(results) == 0 {
+	if len(results) == 0 && !formattedOutput() {
 		fmt.Printf("No packages found matching '%s'.\n", query)
 		return nil
 	}
 
-	if pkgSearchJSON {
-		output, err := json.MarshalIndent(results, "", "  ")
-		if err != nil {
-			return err
+	if pkgSearchJSON || formattedOutput() {
+		return printJSON(results)
+	}
+
+	totalCount := printPackageTables(results, nil)
+	fmt.Printf("Total: %d packages in %d repositories\n", totalCount, len(results))
+	return nil
+}
+
+// indexedPackageSearch returns the repository packages in the index matching
+// query, by namespace, best matches first
+func indexedPackageSearch(ix *index.Index, query string) map[string][]repo.BrowseItem {
+	results := make(map[string][]repo.BrowseItem)
+	for _, hit := range ix.Search(query) {
+		if hit.Namespace == "" {
+			continue // Installed resource
 		}
-		fmt.Println(string(output))
-		return nil
+		results[hit.Namespace] = append(results[hit.Namespace], repo.BrowseItem{
+			Name:        hit.Name,
+			Path:        hit.Path,
+			Type:        repo.PackageType(hit.Type),
+			Description: hit.Description,
+		})
 	}
+	return results
+}
 
-	// Sort namespaces for consistent output
-	namespaces := make([]string, 0, len(results))
-	for ns := range results {
-		namespaces = append(namespaces, ns)
+// printPackageTables prints each repository's packages as a NAME/TYPE/PATH table,
+// with the last change of each package when its metadata was fetched, and
+// returns the number of packages printed. Repositories are printed in the order
+// of namespaces, or alphabetically if it is nil.
+func printPackageTables(results map[string][]repo.BrowseItem, namespaces []string) int {
+	if namespaces == nil {
+		for ns := range results {
+			namespaces = append(namespaces, ns)
+		}
+		sort.Slice(namespaces, func(i, j int) bool { return sorting.Less(namespaces[i], namespaces[j]) })
 	}
-	sort.Strings(namespaces)
 
-	totalCount := 0
+	total := 0
 	for _, ns := range namespaces {
 		items := results[ns]
-		totalCount += len(items)
-
-		fmt.Printf("%s:\n", ns)
+		total += len(items)
 
-		// Calculate column widths
-		nameWidth := len("NAME")
-		typeWidth := len("TYPE")
-		pathWidth := len("PATH")
-
-		for _, item := range items {
-			if len(item.Name) > nameWidth {
-				nameWidth = len(item.Name)
-			}
-			typeStr := string(item.Type)
-			if len(typeStr) > typeWidth {
-				typeWidth = len(typeStr)
-			}
-			if len(item.Path) > pathWidth {
-				pathWidth = len(item.Path)
-			}
-		}
-
-		// Cap widths
-		if nameWidth > 25 {
-			nameWidth = 25
+		withMeta := len(items) > 0 && items[0].Meta != nil
+		if withMeta {
+			fmt.Printf("%s (★ %d):\n", ns, items[0].Meta.Stars)
+		} else {
+			fmt.Printf("%s:\n", ns)
 		}
-		if typeWidth > 10 {
-			typeWidth = 10
+		columns := []table.Column{
+			{Header: "NAME", Max: 25},
+			{Header: "TYPE", Max: 10},
+			{Header: "PATH", Max: 45},
 		}
-		if pathWidth > 45 {
-			pathWidth = 45
+		if withMeta {
+			columns = append(columns, table.Column{Header: "UPDATED"}, table.Column{Header: "AUTHOR", Max: 20})
 		}
-
-		// Print header
-		fmt.Printf("  %-*s  %-*s  %-*s\n",
-			nameWidth, "NAME",
-			typeWidth, "TYPE",
-			pathWidth, "PATH")
-		fmt.Printf("  %s  %s  %s\n",
-			strings.Repeat("-", nameWidth),
-			strings.Repeat("-", typeWidth),
-			strings.Repeat("-", pathWidth))
-
-		// Print rows
+		t := newTable(columns...)
+		t.Indent = "  "
 		for _, item := range items {
-			name := item.Name
-			if len(name) > nameWidth {
-				name = name[:nameWidth-3] + "..."
+			row := []string{item.Name, string(item.Type), item.Path}
+			if withMeta {
+				row = append(row, formatMetaDate(item.Meta), metaAuthor(item.Meta))
 			}
-
-			typeStr := string(item.Type)
-			if len(typeStr) > typeWidth {
-				typeStr = typeStr[:typeWidth-3] + "..."
-			}
-
-			path := item.Path
-			if len(path) > pathWidth {
-				path = path[:pathWidth-3] + "..."
-			}
-
-			fmt.Printf("  %-*s  %-*s  %-*s\n",
-				nameWidth, name,
-				typeWidth, typeStr,
-				pathWidth, path)
+			t.AddRow(row...)
 		}
+		t.Render(os.Stdout)
 		fmt.Println()
 	}
 
-	fmt.Printf("Total: %d packages in %d repositories\n", totalCount, len(results))
-	return nil
+	return total
 }
@@ -0,0 +1,197 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/pkg/pkgmgr"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pkgSetupList  bool
+	pkgSetupRerun bool
+	pkgSetupYes   bool
+)
+
+var pkgSetupCmd = &cobra.Command{
+	Use:   "setup <name>",
+	Short: "Run the one-time setup steps of an installed package",
+	Long: `Run the one-time setup steps an installed package declares, such as installing
+a linter or downloading a model, that have not run yet. The commands are shown
+and confirmed before they run (--yes to run them without asking).
+
+Skill packages declare setup steps under setup: in the frontmatter of their
+POST_INSTALL.md; hook scripts in "# Setup:" header lines:
+  ---
+  setup:
+    - pip install --user ruff
+    - name: model
+      run: ./download-model.sh
+      description: Download the embedding model (400 MB)
+      timeout: 30m
+  ---
+
+'jd pkg install' offers to run them right after installation. Each step that
+succeeds is recorded in setup.json in the data directory and does not run
+again, also when the package is reinstalled or updated, unless its command
+changes (--rerun to run recorded steps again).
+
+Steps run with sh (cmd on Windows) in the skill's directory, or for other
+packages in setup/<name> in the data directory, with no input, a timeout
+(10 minutes unless the step declares one), and only basic environment
+variables (PATH, HOME, LANG, ...) plus JD_PACKAGE, JD_PACKAGE_DIR, and
+JD_SETUP_DIR, so tokens and credentials in your environment are not passed
+on. They still run as you: approve only commands you trust. The output of each
+run is logged in setup-logs/ in the data directory, and a setup event is
+recorded (see 'jd events').
+
+Examples:
+  jd pkg setup affa-ever--embeddings
+  jd pkg setup affa-ever--embeddings --list
+  jd pkg setup affa-ever--embeddings --rerun --yes`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPkgSetup,
+}
+
+func init() {
+	pkgCmd.AddCommand(pkgSetupCmd)
+	pkgSetupCmd.Flags().BoolVar(&pkgSetupList, "list", false, "List the setup steps and whether they have run, without running them")
+	pkgSetupCmd.Flags().BoolVar(&pkgSetupRerun, "rerun", false, "Also run the steps that have already run")
+	pkgSetupCmd.Flags().BoolVarP(&pkgSetupYes, "yes", "y", false, "Run the steps without confirmation")
+	pkgSetupCmd.MarkFlagsMutuallyExclusive("list", "rerun")
+	pkgSetupCmd.MarkFlagsMutuallyExclusive("list", "yes")
+}
+
+func runPkgSetup(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+	name := args[0]
+
+	manager := pkgmgr.NewManager(basedir.DataDir())
+	pkg, err := manager.Get(name)
+	if err != nil {
+		if errors.Is(err, pkgmgr.ErrPackageNotFound) {
+			return notFoundErrorf("package '%s' not found. Use 'jd pkg list' to see installed packages", name)
+		}
+		return fmt.Errorf("get package: %w", err)
+	}
+
+	steps, err := pkgmgr.SetupSteps(pkg)
+	if err != nil {
+		return fmt.Errorf("failed to read setup steps: %w", err)
+	}
+	if len(steps) == 0 {
+		fmt.Printf("%s declares no setup steps.\n", pkg.Name)
+		return nil
+	}
+
+	if pkgSetupList {
+		for _, step := range steps {
+			run, err := manager.SetupRunOf(pkg, step)
+			if err != nil {
+				return fmt.Errorf("failed to read setup runs: %w", err)
+			}
+			if run == nil {
+				fmt.Printf("pending             %s\n", step.Label())
+				continue
+			}
+			fmt.Printf("ran %s  %s\n", run.RanAt.Local().Format("2006-01-02 15:04"), step.Label())
+			fmt.Printf("                    log: %s\n", run.Log)
+		}
+		return nil
+	}
+
+	pending := steps
+	if !pkgSetupRerun {
+		if pending, err = manager.PendingSetup(pkg); err != nil {
+			return fmt.Errorf("failed to read setup runs: %w", err)
+		}
+	}
+	if len(pending) == 0 {
+		fmt.Printf("The setup steps of %s have already run (--rerun to run them again).\n", pkg.Name)
+		return nil
+	}
+	return runSetupSteps(manager, pkg, pending, pkgSetupYes)
+}
+
+// offerSetup offers to run the setup steps of a newly installed package that
+// have not run yet: with --run-setup without asking, with --skip-setup not at
+// all, only listing them
+func offerSetup(manager *pkgmgr.Manager, pkg *pkgmgr.InstalledPackage) error {
+	pending, err := manager.PendingSetup(pkg)
+	if err != nil {
+		return fmt.Errorf("failed to read setup steps: %w", err)
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	if pkgInstallSkipSetup {
+		fmt.Printf("\n%s has %d setup step(s) to run once:\n", pkg.Name, len(pending))
+		printSetupSteps(pending)
+		fmt.Printf("Run them with: jd pkg setup %s\n", pkg.Name)
+		return nil
+	}
+	return runSetupSteps(manager, pkg, pending, pkgInstallRunSetup)
+}
+
+// runSetupSteps shows the setup steps of a package, asks whether to run them
+// unless yes is set, and runs them in order, stopping at the first that fails
+func runSetupSteps(manager *pkgmgr.Manager, pkg *pkgmgr.InstalledPackage, steps []pkgmgr.SetupStep, yes bool) error {
+	fmt.Printf("\n%s has %d setup step(s) to run once:\n", pkg.Name, len(steps))
+	printSetupSteps(steps)
+	dir, err := manager.SetupDir(pkg)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("They run in %s, without your tokens and credentials in the environment.\n", dir)
+
+	if !yes {
+		if repoConfig, err := manager.RepoStore().Get(pkg.Namespace); err != nil || !repoConfig.Trusted {
+			fmt.Fprintf(os.Stderr, "\n⚠️  WARNING: '%s' is not a trusted repository. These commands run as you.\n", pkg.Namespace)
+		}
+		fmt.Print("Run them now? (y/N): ")
+		reader := bufio.NewReader(os.Stdin)
+		response, _ := reader.ReadString('\n')
+		response = strings.TrimSpace(strings.ToLower(response))
+		if response != "y" && response != "yes" {
+			fmt.Printf("Skipped. Run them later with: jd pkg setup %s\n", pkg.Name)
+			return nil
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	for i, step := range steps {
+		fmt.Printf("\n[%d/%d] %s\n", i+1, len(steps), step.Label())
+		run, err := manager.RunSetup(ctx, pkg, step, os.Stdout)
+		if err != nil {
+			if ctx.Err() != nil {
+				fmt.Println("Cancelled.")
+				return errCancelled
+			}
+			return fmt.Errorf("%w\nRun the remaining steps with: jd pkg setup %s", err, pkg.Name)
+		}
+		fmt.Printf("✓ %s (log: %s)\n", step.Label(), run.Log)
+	}
+	return nil
+}
+
+// printSetupSteps lists setup steps with their commands
+func printSetupSteps(steps []pkgmgr.SetupStep) {
+	for _, step := range steps {
+		if step.Name != "" {
+			fmt.Printf("  %s: %s\n", step.Name, step.Run)
+		} else {
+			fmt.Printf("  %s\n", step.Run)
+		}
+		if step.Description != "" {
+			fmt.Printf("      %s\n", step.Description)
+		}
+	}
+}
@@ -1,50 +1,294 @@
 package cli
 
 import (
+	"bufio"
 	"errors"
 	"fmt"
+	"os"
+	"strings"
 
+	"github.com/itda-skills/jindo/internal/basedir"
 	"github.com/itda-skills/jindo/internal/pkg/pkgmgr"
+	"github.com/itda-skills/jindo/internal/tui"
 	"github.com/spf13/cobra"
 )
 
+var (
+	pkgUninstallForce       bool
+	pkgUninstallNamespace   string
+	pkgUninstallAll         bool
+	pkgUninstallInteractive bool
+	pkgUninstallYes         bool
+)
+
 var pkgUninstallCmd = &cobra.Command{
-	Use:     "uninstall <name>",
+	Use:     "uninstall [name]",
 	Aliases: []string{"un", "rm", "remove"},
 	Short:   "Uninstall an installed package",
 	Long: `Uninstall a package by its installed name.
 
 Use 'jd pkg list' to see installed package names.
 
+With --namespace, every package installed from that namespace is uninstalled;
+with --all, every installed package. The packages are listed and confirmed
+once before anything is removed.
+
+With --interactive, or with no name on a terminal, pick the packages from a
+checklist (limited to --namespace if given). The packages and files that
+mention each package by name, such as a command that runs a skill, are shown
+in the checklist and in the confirmation, since they may stop working.
+
+Use --yes to skip the confirmation in scripts; modified files are then kept in
+the trash without asking.
+
+Installed files are compared with the hashes recorded at install time. If any
+were edited since, uninstall asks before removing them: keep a copy in the
+trash (trash/ in the jd data directory, ~/.itda-skills by default) and
+uninstall, or abort. Use --force to skip all prompts and remove modified files
+without keeping a copy.
+
 Example:
-  jd pkg uninstall affa-ever--web-fetch`,
-	Args: cobra.ExactArgs(1),
+  jd pkg uninstall affa-ever--web-fetch
+  jd pkg uninstall affa-ever--web-fetch --force
+  jd pkg uninstall --namespace affa-ever
+  jd pkg uninstall --namespace affa-ever --yes
+  jd pkg uninstall --all
+  jd pkg uninstall -i`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if pkgUninstallNamespace != "" || pkgUninstallAll || pkgUninstallInteractive {
+			return cobra.NoArgs(cmd, args)
+		}
+		if len(args) == 0 && isTerminal(os.Stdin) && isTerminal(os.Stdout) {
+			return nil // Interactive
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
 	RunE: runPkgUninstall,
 }
 
 func init() {
 	pkgCmd.AddCommand(pkgUninstallCmd)
+	pkgUninstallCmd.Flags().BoolVarP(&pkgUninstallForce, "force", "f", false, "Skip confirmation and remove locally modified files without keeping a copy")
+	pkgUninstallCmd.Flags().StringVarP(&pkgUninstallNamespace, "namespace", "n", "", "Uninstall every package installed from this namespace")
+	pkgUninstallCmd.Flags().BoolVar(&pkgUninstallAll, "all", false, "Uninstall every installed package")
+	pkgUninstallCmd.Flags().BoolVarP(&pkgUninstallInteractive, "interactive", "i", false, "Pick packages to uninstall from a checklist")
+	pkgUninstallCmd.Flags().BoolVarP(&pkgUninstallYes, "yes", "y", false, "Skip confirmation and keep copies of modified files")
+	pkgUninstallCmd.MarkFlagsMutuallyExclusive("namespace", "all")
+	pkgUninstallCmd.MarkFlagsMutuallyExclusive("interactive", "all")
 }
 
 func runPkgUninstall(cmd *cobra.Command, args []string) error {
 	cmd.SilenceUsage = true
-	name := args[0]
+	defer syncIndex()
+
+	manager := pkgmgr.NewManager(basedir.DataDir())
+
+	if pkgUninstallNamespace != "" || pkgUninstallAll || len(args) == 0 {
+		var packages []pkgmgr.InstalledPackage
+		var err error
+		if pkgUninstallNamespace != "" {
+			packages, err = manager.ListNamespace(pkgUninstallNamespace)
+		} else {
+			packages, err = manager.List()
+		}
+		if err != nil {
+			return fmt.Errorf("list packages: %w", err)
+		}
+		if len(packages) == 0 {
+			if pkgUninstallNamespace == "" {
+				fmt.Println("No packages installed.")
+			} else {
+				fmt.Printf("No packages installed from '%s'.\n", pkgUninstallNamespace)
+			}
+			return nil
+		}
+
+		if pkgUninstallInteractive || (pkgUninstallNamespace == "" && !pkgUninstallAll) {
+			if !isTerminal(os.Stdin) || !isTerminal(os.Stdout) {
+				return validationErrorf("--interactive requires a terminal")
+			}
+			packages, err = pickPackagesToUninstall(manager, packages)
+			if err != nil {
+				return err
+			}
+		}
+
+		if !pkgUninstallForce && !pkgUninstallYes && !confirmUninstall(manager, packages) {
+			fmt.Println("Cancelled.")
+			return errCancelled
+		}
+		return uninstallPackages(manager, packages, pkgUninstallForce, pkgUninstallYes)
+	}
 
-	manager := pkgmgr.NewManager("~/.itda-skills")
+	name := args[0]
 
 	// Get package info first for display
 	pkg, err := manager.Get(name)
 	if err != nil {
 		if errors.Is(err, pkgmgr.ErrPackageNotFound) {
-			return fmt.Errorf("package '%s' not found. Use 'jd pkg list' to see installed packages", name)
+			return notFoundErrorf("package '%s' not found. Use 'jd pkg list' to see installed packages", name)
 		}
 		return fmt.Errorf("get package: %w", err)
 	}
 
-	if err := manager.Uninstall(name); err != nil {
-		return fmt.Errorf("uninstall: %w", err)
+	// A single package is removed without confirmation, but say what may break
+	packages := []pkgmgr.InstalledPackage{*pkg}
+	if refs, err := packageDependents(manager, packages, nil); err == nil {
+		for _, r := range refs[pkg.Name] {
+			fmt.Fprintf(os.Stderr, "Warning: %s is referenced by %s\n", pkg.Name, r.Label())
+		}
+	}
+	return uninstallPackages(manager, packages, pkgUninstallForce, pkgUninstallYes)
+}
+
+// pickPackagesToUninstall shows a checklist of packages, with the packages and
+// files that reference each, and returns the selected ones
+func pickPackagesToUninstall(manager *pkgmgr.Manager, packages []pkgmgr.InstalledPackage) ([]pkgmgr.InstalledPackage, error) {
+	refs, err := packageDependents(manager, packages, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]tui.UninstallItem, len(packages))
+	for i, p := range packages {
+		items[i] = tui.UninstallItem{Name: p.Name, Type: string(p.Type)}
+		for _, r := range refs[p.Name] {
+			items[i].Dependents = append(items[i].Dependents, r.Label())
+		}
+	}
+
+	names, err := tui.RunUninstall(items)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run checklist: %w", err)
+	}
+	if len(names) == 0 {
+		fmt.Println("Cancelled.")
+		return nil, errCancelled
+	}
+
+	selected := make(map[string]bool, len(names))
+	for _, name := range names {
+		selected[name] = true
+	}
+	var result []pkgmgr.InstalledPackage
+	for _, p := range packages {
+		if selected[p.Name] {
+			result = append(result, p)
+		}
+	}
+	return result, nil
+}
+
+// packageDependents returns the references to each package, leaving out the
+// files of packages in removing, since they are removed too
+func packageDependents(manager *pkgmgr.Manager, packages []pkgmgr.InstalledPackage, removing map[string]bool) (map[string][]pkgmgr.Reference, error) {
+	names := make([]string, len(packages))
+	for i, p := range packages {
+		names[i] = p.Name
+	}
+	refs, err := manager.Dependents(names)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find references: %w", err)
+	}
+	for name, list := range refs {
+		var kept []pkgmgr.Reference
+		for _, r := range list {
+			if !removing[r.Package] {
+				kept = append(kept, r)
+			}
+		}
+		refs[name] = kept
+	}
+	return refs, nil
+}
+
+// confirmUninstall lists packages, with the packages and files that reference
+// them, and asks whether to uninstall them
+func confirmUninstall(manager *pkgmgr.Manager, packages []pkgmgr.InstalledPackage) bool {
+	removing := make(map[string]bool, len(packages))
+	for _, p := range packages {
+		removing[p.Name] = true
+	}
+	refs, err := packageDependents(manager, packages, removing)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	referenced := 0
+	for _, p := range packages {
+		fmt.Printf("  %s (%s)\n", p.Name, p.Type)
+		for _, r := range refs[p.Name] {
+			fmt.Printf("      referenced by %s\n", r.Label())
+		}
+		if len(refs[p.Name]) > 0 {
+			referenced++
+		}
+	}
+	if referenced > 0 {
+		fmt.Printf("%d package(s) are still referenced and may break what references them.\n", referenced)
+	}
+	fmt.Printf("Uninstall %d package(s)? (y/N): ", len(packages))
+
+	reader := bufio.NewReader(os.Stdin)
+	response, _ := reader.ReadString('\n')
+	response = strings.TrimSpace(strings.ToLower(response))
+	return response == "y" || response == "yes"
+}
+
+// uninstallPackages uninstalls packages in order. Unless force is set, modified
+// files are kept in the trash, after asking unless yes is set; declining stops
+// before the package is removed.
+func uninstallPackages(manager *pkgmgr.Manager, packages []pkgmgr.InstalledPackage, force, yes bool) error {
+	manager.SetDiscardModified(force)
+	for i := range packages {
+		pkg := &packages[i]
+		if !force && !yes {
+			if err := confirmModifiedFiles(manager, pkg); err != nil {
+				return err
+			}
+		}
+
+		kept, err := manager.UninstallKeepingModified(pkg.Name)
+		if kept != "" {
+			fmt.Printf("Kept modified files in %s\n", kept)
+		}
+		if err != nil {
+			return fmt.Errorf("uninstall %s: %w", pkg.Name, err)
+		}
+
+		fmt.Printf("Uninstalled: %s (%s)\n", pkg.Name, pkg.Type)
+		if pkg.Hook != nil {
+			fmt.Printf("Removed %s hook from %s\n", pkg.Hook.EventType, pkg.Hook.SettingsPath)
+		}
+		syncPackageSkillReference(pkg, false)
+	}
+	return nil
+}
+
+// confirmModifiedFiles lists the package files edited since installation, if
+// any, and asks whether to uninstall keeping a copy of them in the trash, or to
+// abort the uninstall.
+func confirmModifiedFiles(manager *pkgmgr.Manager, pkg *pkgmgr.InstalledPackage) error {
+	modified, err := manager.ModifiedFiles(pkg)
+	if err != nil {
+		return fmt.Errorf("failed to check for modified files: %w", err)
 	}
+	if len(modified) == 0 {
+		return nil
+	}
+
+	fmt.Printf("%d file(s) of %s were modified since installation:\n", len(modified), pkg.Name)
+	for _, f := range modified {
+		fmt.Printf("  %s\n", f)
+	}
+	fmt.Print("Keep a copy in the trash and uninstall? (Y/n, n aborts): ")
 
-	fmt.Printf("Uninstalled: %s (%s)\n", pkg.Name, pkg.Type)
+	reader := bufio.NewReader(os.Stdin)
+	response, _ := reader.ReadString('\n')
+	response = strings.TrimSpace(strings.ToLower(response))
+	if response != "" && response != "y" && response != "yes" {
+		fmt.Println("Cancelled.")
+		return errCancelled
+	}
 	return nil
 }
@@ -1,14 +1,27 @@
 package cli
 
 import (
+	"bufio"
 	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"slices"
 	"strings"
 
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/guide"
 	"github.com/itda-skills/jindo/internal/pkg/pkgmgr"
+	"github.com/itda-skills/jindo/internal/progress"
+	"github.com/itda-skills/jindo/internal/table"
 	"github.com/spf13/cobra"
 )
 
-var pkgUpdateApply bool
+var (
+	pkgUpdateApply       bool
+	pkgUpdateInteractive bool
+	pkgUpdateNoNotify    bool
+)
 
 var pkgUpdateCmd = &cobra.Command{
 	Use:     "update [name...]",
@@ -17,23 +30,57 @@ var pkgUpdateCmd = &cobra.Command{
 	Long: `Check for updates to installed packages.
 
 Without --apply, shows available updates.
-With --apply, downloads and installs updates.
+With --apply, downloads and installs updates. Pinned packages are skipped
+unless named; updating a pinned package by name drops the pin.
+
+Packages installed with --channel stable are compared with the repository's
+latest release tag instead of its branch, and move from release to release.
+
+With --interactive, each available update is shown in turn with its changed
+files and any of them you edited locally, and you decide one at a time:
+  y  update the package
+  s  skip it this time (the default)
+  p  pin it at its current version, so --apply skips it from now on
+  d  show the diff of the update
+  a  summarize the update with Claude (prints the estimated cost first)
+  q  stop reviewing; the updates approved so far are applied
+
+A package renamed or moved in its repository is followed: the old files are
+removed and it is installed under the new name (a hook script renamed from
+format.sh to format.py is installed as <namespace>--format.py). A package removed
+from its repository is kept as it is. Files next to an updated package that no
+package owns, such as a file added to a skill by hand, are listed after the
+updates.
+
+If a webhook is configured (see 'jd notify'), new versions found are announced
+to it, each once. Use --no-notify to skip it.
 
 Examples:
   jd pkg update                    # Check all packages
   jd pkg update affa-ever--web-fetch  # Check specific package
-  jd pkg update --apply            # Apply all updates`,
+  jd pkg update --apply            # Apply all updates
+  jd pkg update -i                 # Review and approve each update`,
 	RunE: runPkgUpdate,
 }
 
 func init() {
 	pkgCmd.AddCommand(pkgUpdateCmd)
 	pkgUpdateCmd.Flags().BoolVar(&pkgUpdateApply, "apply", false, "Apply available updates")
+	pkgUpdateCmd.Flags().BoolVarP(&pkgUpdateInteractive, "interactive", "i", false, "Review each update and approve, skip, or pin it")
+	pkgUpdateCmd.Flags().BoolVar(&pkgUpdateNoNotify, "no-notify", false, "Do not announce new versions to the configured webhook")
+	pkgUpdateCmd.MarkFlagsMutuallyExclusive("apply", "interactive")
+	addNoTruncFlag(pkgUpdateCmd)
 }
 
 func runPkgUpdate(cmd *cobra.Command, args []string) error {
 	cmd.SilenceUsage = true
-	manager := pkgmgr.NewManager("~/.itda-skills")
+	if pkgUpdateInteractive && (!isTerminal(os.Stdin) || !isTerminal(os.Stdout)) {
+		return validationErrorf("--interactive requires a terminal")
+	}
+	defer syncIndex()
+	manager := pkgmgr.NewManager(basedir.DataDir())
+	manager.SetProgress(progress.New(os.Stderr))
+	enableAutoSnapshot(manager)
 
 	fmt.Println("Checking for updates...")
 
@@ -60,98 +107,71 @@ func runPkgUpdate(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	// Calculate column widths
-	nameWidth := len("NAME")
-	currentWidth := len("CURRENT")
-	latestWidth := len("LATEST")
-	changesWidth := len("CHANGES")
+	fmt.Printf("\n%d package(s) have updates available:\n\n", updateCount)
 
+	t := newTable(
+		table.Column{Header: "NAME", Max: 35},
+		table.Column{Header: "CURRENT", Max: 12},
+		table.Column{Header: "LATEST", Max: 12},
+		table.Column{Header: "CHANGES", Max: 20},
+	)
 	for _, u := range updates {
 		if !u.HasUpdate {
 			continue
 		}
-		if len(u.Package.Name) > nameWidth {
-			nameWidth = len(u.Package.Name)
-		}
+
 		current := u.CurrentSHA
 		if len(current) > 8 {
 			current = current[:8]
 		}
-		if len(current) > currentWidth {
-			currentWidth = len(current)
-		}
+
 		latest := u.LatestSHA
 		if len(latest) > 8 {
 			latest = latest[:8]
 		}
-		if len(latest) > latestWidth {
-			latestWidth = len(latest)
+		// Packages on the stable channel move between release tags
+		if u.LatestRef != "" {
+			current, latest = u.Package.Version.Ref, u.LatestRef
 		}
+
 		changes := fmt.Sprintf("%d files", len(u.ChangedFiles))
-		if len(changes) > changesWidth {
-			changesWidth = len(changes)
+		if u.Package.Pinned() {
+			changes += " (pinned)"
 		}
+		t.AddRow(u.Package.Name, current, latest, changes)
 	}
-
-	// Cap widths
-	if nameWidth > 35 {
-		nameWidth = 35
-	}
-	if currentWidth > 12 {
-		currentWidth = 12
-	}
-	if latestWidth > 12 {
-		latestWidth = 12
-	}
-	if changesWidth > 15 {
-		changesWidth = 15
+	t.Render(os.Stdout)
+	if !pkgUpdateNoNotify {
+		notifyUpdates(updates)
 	}
 
-	fmt.Printf("\n%d package(s) have updates available:\n\n", updateCount)
-
-	// Print header
-	fmt.Printf("%-*s  %-*s  %-*s  %-*s\n",
-		nameWidth, "NAME",
-		currentWidth, "CURRENT",
-		latestWidth, "LATEST",
-		changesWidth, "CHANGES")
-	fmt.Printf("%s  %s  %s  %s\n",
-		strings.Repeat("-", nameWidth),
-		strings.Repeat("-", currentWidth),
-		strings.Repeat("-", latestWidth),
-		strings.Repeat("-", changesWidth))
-
-	// Print rows
-	for _, u := range updates {
-		if !u.HasUpdate {
-			continue
+	var pending []pkgmgr.UpdateInfo
+	switch {
+	case pkgUpdateInteractive:
+		if pending, err = reviewUpdates(cmd, manager, updates); err != nil {
+			return err
 		}
-
-		name := u.Package.Name
-		if len(name) > nameWidth {
-			name = name[:nameWidth-3] + "..."
+		if len(pending) == 0 {
+			fmt.Println("\nNo updates approved.")
+			return nil
 		}
-
-		current := u.CurrentSHA
-		if len(current) > 8 {
-			current = current[:8]
+	case pkgUpdateApply:
+		for _, u := range updates {
+			if !u.HasUpdate {
+				continue
+			}
+			// Pinned packages only move when named
+			if u.Package.Pinned() && !slices.Contains(args, u.Package.Name) {
+				fmt.Printf("Skipping %s (pinned at %s; update it by name to move it)\n", u.Package.Name, pinRef(u.Package))
+				continue
+			}
+			pending = append(pending, u)
 		}
-
-		latest := u.LatestSHA
-		if len(latest) > 8 {
-			latest = latest[:8]
+		if len(pending) == 0 {
+			fmt.Println("\nNo updates to apply.")
+			return nil
 		}
-
-		changes := fmt.Sprintf("%d files", len(u.ChangedFiles))
-
-		fmt.Printf("%-*s  %-*s  %-*s  %-*s\n",
-			nameWidth, name,
-			currentWidth, current,
-			latestWidth, latest,
-			changesWidth, changes)
-	}
-
-	if !pkgUpdateApply {
+	default:
 		fmt.Println()
 		fmt.Println("Run with --apply to install updates:")
 		fmt.Println("  jd pkg update --apply")
@@ -163,21 +183,191 @@ func runPkgUpdate(cmd *cobra.Command, args []string) error {
 	fmt.Println("Applying updates...")
 
 	successCount := 0
-	for _, u := range updates {
-		if !u.HasUpdate {
-			continue
-		}
-
+	var hooks, orphaned []string
+	for _, u := range pending {
 		fmt.Printf("  Updating %s... ", u.Package.Name)
-		_, err := manager.Update(u.Package.Name)
+		updated, err := manager.Update(u.Package.Name)
 		if err != nil {
 			fmt.Printf("FAILED: %v\n", err)
 			continue
 		}
 		fmt.Println("OK")
+		if updated.Name != u.Package.Name {
+			fmt.Printf("    Renamed upstream to %s; installed as %s\n", updated.SourcePath, updated.Name)
+			syncPackageSkillReference(u.Package, false)
+		}
+		syncPackageSkillReference(updated, true)
 		successCount++
+		if updated.Hook != nil {
+			hooks = append(hooks, updated.Name)
+		}
+		// A renamed package may leave files behind where it was installed before
+		for _, pkg := range []*pkgmgr.InstalledPackage{u.Package, updated} {
+			if orphans, err := manager.Orphans(pkg); err == nil {
+				orphaned = append(orphaned, orphans...)
+			}
+		}
+	}
+
+	fmt.Printf("\nUpdated %d of %d packages.\n", successCount, len(pending))
+	if len(hooks) > 0 {
+		fmt.Printf("\nRegistered hooks now run the updated scripts of %s.\n", strings.Join(hooks, ", "))
+		fmt.Println("Review them, then pin them with: jd hooks verify --accept")
 	}
+	slices.Sort(orphaned)
+	orphaned = slices.Compact(orphaned)
+	if len(orphaned) > 0 {
+		fmt.Printf("\n⚠️  %d file(s) next to the updated packages belong to no package:\n", len(orphaned))
+		for _, f := range orphaned {
+			fmt.Printf("  %s\n", f)
+		}
+		fmt.Println("They were left by an earlier version or added by hand; remove them if they are not needed.")
+	}
+	return nil
+}
+
+// pinRef returns the short ref a pinned package is held at
+func pinRef(pkg *pkgmgr.InstalledPackage) string {
+	switch {
+	case pkg.Version.Type == "tag":
+		return pkg.Version.Ref
+	case pkg.Pin != nil:
+		return shortSHA(pkg.Pin.Ref)
+	}
+	return shortSHA(pkg.Version.SHA)
+}
+
+// reviewUpdates walks through the available updates one at a time and returns
+// those approved. Packages are pinned as they are reviewed.
+func reviewUpdates(cmd *cobra.Command, manager *pkgmgr.Manager, updates []pkgmgr.UpdateInfo) ([]pkgmgr.UpdateInfo, error) {
+	var available []pkgmgr.UpdateInfo
+	for _, u := range updates {
+		if u.HasUpdate {
+			available = append(available, u)
+		}
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	var approved []pkgmgr.UpdateInfo
+	for i, u := range available {
+		pkg := u.Package
+		from, to := shortSHA(u.CurrentSHA), shortSHA(u.LatestSHA)
+		if u.LatestRef != "" {
+			from, to = pkg.Version.Ref, u.LatestRef
+		}
+		fmt.Printf("\n[%d/%d] %s (%s from %s)  %s → %s\n", i+1, len(available), pkg.Name, pkg.Type, packageOrigin(pkg), from, to)
+		if pkg.Pinned() {
+			fmt.Printf("  Pinned at %s; updating drops the pin\n", pinRef(pkg))
+		}
+		if len(u.ChangedFiles) > 0 {
+			fmt.Printf("  Changed files (%d):\n", len(u.ChangedFiles))
+			for _, f := range u.ChangedFiles {
+				fmt.Printf("    %s\n", f)
+			}
+		}
+		if modified, err := manager.ModifiedFiles(pkg); err == nil && len(modified) > 0 {
+			fmt.Printf("  ⚠️  Edited locally, overwritten by the update (%d):\n", len(modified))
+			for _, f := range modified {
+				fmt.Printf("    %s\n", f)
+			}
+		}
+
+	prompt:
+		for {
+			fmt.Print("Update? [y]es, [s]kip, [p]in, [d]iff, [a]i summary, [q]uit (default s): ")
+			response, err := reader.ReadString('\n')
+			if err != nil && err != io.EOF {
+				return nil, err
+			}
+			if err == io.EOF && response == "" {
+				fmt.Println()
+				return approved, nil
+			}
 
-	fmt.Printf("\nUpdated %d of %d packages.\n", successCount, updateCount)
+			switch strings.TrimSpace(strings.ToLower(response)) {
+			case "y", "yes":
+				approved = append(approved, u)
+				break prompt
+			case "", "s", "skip":
+				break prompt
+			case "p", "pin":
+				if _, err := manager.Pin(pkg.Name); err != nil {
+					fmt.Printf("  Failed to pin: %v\n", err)
+					continue
+				}
+				fmt.Printf("  📌 Pinned %s at %s\n", pkg.Name, shortSHA(u.CurrentSHA))
+				break prompt
+			case "d", "diff":
+				diff, err := manager.UpdateDiff(&u)
+				switch {
+				case err != nil:
+					fmt.Printf("  No diff: %v\n", err)
+				case strings.TrimSpace(diff) == "":
+					fmt.Println("  The update does not change the package's files.")
+				default:
+					fmt.Print(diff)
+				}
+			case "a", "ai":
+				if err := summarizeUpdate(cmd, manager, &u); err != nil {
+					fmt.Printf("  No summary: %v\n", err)
+				}
+			case "q", "quit":
+				return approved, nil
+			default:
+				fmt.Println("  Please answer y, s, p, d, a, or q.")
+			}
+		}
+	}
+	return approved, nil
+}
+
+// packageOrigin returns where a package was installed from, for display
+func packageOrigin(pkg *pkgmgr.InstalledPackage) string {
+	if pkg.Source != "" {
+		return pkg.Source
+	}
+	return pkg.Namespace + ":" + pkg.SourcePath
+}
+
+// maxSummaryDiffChars limits the diff sent to Claude for an update summary
+const maxSummaryDiffChars = 60000
+
+// updateSummaryOutputTokens is the expected length of an update summary
+const updateSummaryOutputTokens = 500
+
+// updateSummaryPrompt asks Claude to summarize a package update for review
+const updateSummaryPrompt = `You review updates to Claude Code packages (skills, commands, agents, and hooks) for a user deciding whether to install them.
+Summarize the diff you are given in at most five short bullet points: what changes in behavior, and anything that deserves a closer look, such as new shell commands, tools, permissions, network access, or removed features.
+Reply with the bullet points only.`
+
+// summarizeUpdate prints a summary of an update's diff written by Claude
+func summarizeUpdate(cmd *cobra.Command, manager *pkgmgr.Manager, u *pkgmgr.UpdateInfo) error {
+	if _, err := exec.LookPath("claude"); err != nil {
+		return fmt.Errorf("claude CLI not found. Install: npm install -g @anthropic-ai/claude-cli")
+	}
+	diff, err := manager.UpdateDiff(u)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(diff) == "" {
+		return fmt.Errorf("the update does not change the package's files")
+	}
+	if len(diff) > maxSummaryDiffChars {
+		diff = diff[:maxSummaryDiffChars] + "\n[diff truncated]"
+	}
+
+	userPrompt := fmt.Sprintf("Update of the %s %s:\n\n%s", u.Package.Type, u.Package.Name, diff)
+	run := newAIRun("pkg update", u.Package.Name, updateSummaryOutputTokens, updateSummaryPrompt, userPrompt)
+	if err := confirmAICost(run.estimate, "", false); err != nil {
+		return err
+	}
+
+	fmt.Println("  Summarizing with Claude...")
+	summary, err := guide.RunClaude(cmd.Context(), updateSummaryPrompt, userPrompt)
+	if err != nil {
+		return err
+	}
+	recordAIRun(run, summary)
+	fmt.Println(strings.TrimRight(summary, "\n"))
 	return nil
 }
@@ -0,0 +1,17 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var projectCmd = &cobra.Command{
+	Use:     "project",
+	Aliases: []string{"proj"},
+	Short:   "Set up Claude Code for a project",
+	Long: `Set up Claude Code configuration for a project: the .claude directory,
+settings.json, and CLAUDE.md.`,
+}
+
+func init() {
+	rootCmd.AddCommand(projectCmd)
+}
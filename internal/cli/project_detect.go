@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/itda-skills/jindo/internal/detect"
+	"github.com/spf13/cobra"
+)
+
+var projectDetectJSON bool
+
+var projectDetectCmd = &cobra.Command{
+	Use:   "detect",
+	Short: "Show the detected project stack",
+	Long: `Show the stack detected in the current directory (or --project-root):
+languages with their build, test, and lint commands and formatter, container
+setup (Dockerfile, compose files), and CI providers (GitHub Actions, GitLab CI,
+CircleCI, Jenkins, Azure Pipelines, Bitbucket Pipelines, Travis CI).
+
+The same profile tailors 'jd project init' and 'jd claudemd guide --analyze'.
+
+Examples:
+  jd project detect
+  jd project detect --json`,
+	Args: cobra.NoArgs,
+	RunE: runProjectDetect,
+}
+
+func init() {
+	projectCmd.AddCommand(projectDetectCmd)
+	projectDetectCmd.Flags().BoolVar(&projectDetectJSON, "json", false, "Output in JSON format")
+}
+
+func runProjectDetect(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+
+	root, err := os.Getwd()
+	if projectRootSelected() {
+		root, err = ProjectRoot()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to resolve project root: %w", err)
+	}
+
+	profile := detect.Detect(root)
+
+	if projectDetectJSON {
+		output, err := json.MarshalIndent(profile, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(output))
+		return nil
+	}
+
+	fmt.Printf("Project: %s\n", root)
+	printStack(profile)
+
+	for _, l := range profile.Languages {
+		var details []string
+		for _, d := range [][2]string{{"build", l.Build}, {"test", l.Test}, {"lint", l.Lint}, {"format", l.Formatter}} {
+			if d[1] != "" {
+				details = append(details, fmt.Sprintf("%s: %s", d[0], d[1]))
+			}
+		}
+		if len(details) > 0 {
+			fmt.Printf("\n%s\n", l.Name)
+			for _, d := range details {
+				fmt.Printf("  %s\n", d)
+			}
+		}
+	}
+	return nil
+}
+
+// printStack prints the detected stack, one item per line
+func printStack(profile *detect.Profile) {
+	if profile.Empty() {
+		fmt.Println("Detected stack: none")
+		return
+	}
+	fmt.Println("Detected stack:")
+	for _, line := range profile.Lines() {
+		fmt.Printf("  %s\n", line)
+	}
+}
@@ -0,0 +1,182 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/itda-skills/jindo/internal/hook"
+	"github.com/itda-skills/jindo/internal/project"
+	"github.com/itda-skills/jindo/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	projectInitForce    bool
+	projectInitNoHooks  bool
+	projectInitManifest bool
+)
+
+var projectInitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Bootstrap Claude Code configuration for the current project",
+	Long: `Set up a project for Claude Code in one command.
+
+In the current directory (or --project-root), init:
+  - creates .claude/settings.json, if it does not exist
+  - writes a starter .claude/CLAUDE.md from the README's title and
+    introduction and the detected stack, with its build, test, and lint
+    commands (a CLAUDE.md already at the project root is used instead)
+  - adds recommended hooks for the detected stack to .claude/settings.json:
+    formatting each file Claude edits with the project's formatter (gofmt,
+    prettier, ruff, black, rustfmt). The hooks read the edited file with
+    'jd hooks payload', so jd must be on PATH when they run.
+  - with --manifest, writes a .claude/jindo.toml listing the settings jd
+    reads from a project, commented out with their defaults
+
+Existing CLAUDE.md and jindo.toml files are kept unless --force is given.
+Hooks already in settings.json are not added twice, so init can be re-run.
+
+Examples:
+  jd project init
+  jd project init --manifest
+  jd project init --no-hooks
+  jd --project-root ~/src/app project init --force`,
+	Args: cobra.NoArgs,
+	RunE: runProjectInit,
+}
+
+func init() {
+	projectCmd.AddCommand(projectInitCmd)
+	projectInitCmd.Flags().BoolVarP(&projectInitForce, "force", "f", false, "Overwrite an existing CLAUDE.md and jindo.toml")
+	projectInitCmd.Flags().BoolVar(&projectInitNoHooks, "no-hooks", false, "Do not add recommended hooks")
+	projectInitCmd.Flags().BoolVar(&projectInitManifest, "manifest", false, "Also write a starter .claude/"+config.LocalConfigName+" with jd's project settings")
+}
+
+func runProjectInit(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+
+	// A new project has no .claude yet, so init does not walk up to a parent project
+	root, err := os.Getwd()
+	if projectRootSelected() {
+		root, err = ProjectRoot()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to resolve project root: %w", err)
+	}
+
+	profile := project.Detect(root)
+	fmt.Printf("Initializing Claude Code for %s (%s)\n", profile.Name, root)
+	printStack(profile.Stack)
+	fmt.Println()
+
+	claudeDir := filepath.Join(root, localClaudeDir)
+	if err := os.MkdirAll(claudeDir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", claudeDir, err)
+	}
+
+	settingsPath := filepath.Join(claudeDir, "settings.json")
+	if _, err := os.Stat(settingsPath); os.IsNotExist(err) {
+		if err := os.WriteFile(settingsPath, []byte("{}\n"), 0644); err != nil {
+			return fmt.Errorf("failed to create settings.json: %w", err)
+		}
+		fmt.Printf("✅ Created %s\n", settingsPath)
+	} else {
+		fmt.Printf("   Kept %s\n", settingsPath)
+	}
+
+	// .claude/CLAUDE.md is where the jd claudemd commands look, but a CLAUDE.md the
+	// project already keeps at its root is used instead of adding a second one
+	claudemdPath := filepath.Join(claudeDir, "CLAUDE.md")
+	if _, err := os.Stat(claudemdPath); os.IsNotExist(err) {
+		if _, err := os.Stat(filepath.Join(root, "CLAUDE.md")); err == nil {
+			claudemdPath = filepath.Join(root, "CLAUDE.md")
+		}
+	}
+	if err := writeProjectFile(claudemdPath, project.ClaudeMD(profile)); err != nil {
+		return err
+	}
+
+	if !projectInitNoHooks {
+		if err := addRecommendedHooks(settingsPath, project.RecommendedHooks(profile)); err != nil {
+			return err
+		}
+	}
+
+	if projectInitManifest {
+		if err := writeProjectFile(filepath.Join(claudeDir, config.LocalConfigName), projectManifest()); err != nil {
+			return err
+		}
+	}
+
+	fmt.Println("\nNext steps:")
+	fmt.Println("  Review CLAUDE.md and describe the project's conventions")
+	fmt.Println("  jd claudemd guide --analyze --local    # Suggestions for improving CLAUDE.md")
+	return nil
+}
+
+// projectManifest returns a starter .claude/jindo.toml: the settings jd reads
+// from a project's config, commented out with their defaults
+func projectManifest() string {
+	var b strings.Builder
+	b.WriteString("# jd settings for this project, over the global config.toml.\n")
+	b.WriteString("# Uncomment a setting to change it here; 'jd config list' shows the values in effect.\n")
+	for _, k := range knownConfigKeys {
+		if k.globalOnly || k.kind == kindTable {
+			continue
+		}
+		value := k.def
+		switch k.kind {
+		case kindString:
+			value = strconv.Quote(value)
+		case kindList:
+			value = "[]"
+		}
+		fmt.Fprintf(&b, "\n# %s\n# %s = %s\n", k.description, k.key, value)
+	}
+	return b.String()
+}
+
+// writeProjectFile writes a generated file, keeping an existing one unless --force is set
+func writeProjectFile(path, content string) error {
+	if _, err := os.Stat(path); err == nil && !projectInitForce {
+		fmt.Printf("   Kept %s (--force to overwrite)\n", path)
+		return nil
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	fmt.Printf("✅ Wrote %s\n", path)
+	return nil
+}
+
+// addRecommendedHooks adds hooks to settings.json, skipping those whose command is already present
+func addRecommendedHooks(settingsPath string, recommended []project.RecommendedHook) error {
+	store := hook.NewStore(settingsPath)
+	existing, err := store.List()
+	if err != nil {
+		return fmt.Errorf("failed to list hooks: %w", err)
+	}
+
+	present := make(map[string]bool)
+	for _, h := range existing {
+		for _, c := range h.Commands {
+			present[c] = true
+		}
+	}
+
+	for _, r := range recommended {
+		if present[r.Command] {
+			fmt.Printf("   Hook already present: %s\n", r.Description)
+			continue
+		}
+		h, err := store.Add(r.Event, r.Matcher, []string{r.Command})
+		if err != nil {
+			return fmt.Errorf("failed to add hook: %w", err)
+		}
+		fmt.Printf("✅ Added hook %s: %s\n", h.Name, r.Description)
+	}
+	return nil
+}
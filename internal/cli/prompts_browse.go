@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"github.com/itda-skills/jindo/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+var promptsBrowseCmd = &cobra.Command{
+	Use:     "browse",
+	Aliases: []string{"b"},
+	Short:   "Browse prompts interactively",
+	Long: `Open an interactive TUI to browse embedded, overridden, and custom prompts.
+
+The preview highlights template variables such as {{.Content}}.
+For overridden prompts, press tab to show the diff against the embedded version.
+
+Keys:
+  e  Edit the prompt (creates an override from the embedded version if needed)
+  r  Reset an override to the embedded default, or delete a custom prompt
+  c  Duplicate the prompt as a new custom prompt`,
+	Args: cobra.NoArgs,
+	RunE: runPromptsBrowse,
+}
+
+func init() {
+	promptsCmd.AddCommand(promptsBrowseCmd)
+}
+
+func runPromptsBrowse(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+
+	return tui.RunPrompts(tui.PromptOptions{
+		Diff: func(oldName, newName, oldContent, newContent string) string {
+			return formatUnifiedDiff(oldName, newName, oldContent, newContent, false)
+		},
+		Editor: editorCommand,
+	})
+}
@@ -30,10 +30,9 @@ func runPromptsEdit(cmd *cobra.Command, args []string) error {
 
 	name := args[0]
 
-	// Check if prompt exists (in embedded)
-	_, err := prompt.GetEmbedded(name)
-	if err != nil {
-		return fmt.Errorf("prompt not found: %s", name)
+	// Check if prompt exists (embedded or custom)
+	if !prompt.Exists(name) {
+		return notFoundErrorf("prompt not found: %s", name)
 	}
 
 	// Get override path
@@ -13,7 +13,8 @@ var promptsListCmd = &cobra.Command{
 	Short:   "List available prompts",
 	Long: `List all available prompts.
 
-Prompts marked with [override] have custom versions in ~/.claude/jindo/prompts/.`,
+Prompts marked with [override] have custom versions in ~/.claude/jindo/prompts/.
+Prompts marked with [custom] exist only there (e.g., duplicates made in 'jd prompts browse').`,
 	RunE: runPromptsList,
 }
 
@@ -39,7 +40,9 @@ func runPromptsList(cmd *cobra.Command, args []string) error {
 
 	for _, p := range prompts {
 		status := ""
-		if p.IsOverride {
+		if p.IsCustom {
+			status = " [custom]"
+		} else if p.IsOverride {
 			status = " [override]"
 		}
 		fmt.Printf("  %s%s\n", p.Name, status)
@@ -48,6 +51,7 @@ func runPromptsList(cmd *cobra.Command, args []string) error {
 	fmt.Println()
 	fmt.Println("Use 'jd prompts show <name>' to view a prompt.")
 	fmt.Println("Use 'jd prompts edit <name>' to customize a prompt.")
+	fmt.Println("Use 'jd prompts browse' to preview, compare, and duplicate prompts.")
 
 	return nil
 }
@@ -0,0 +1,168 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/itda-skills/jindo/internal/prompt"
+	"github.com/spf13/cobra"
+)
+
+var promptsTestVars []string
+
+var promptsTestCmd = &cobra.Command{
+	Use:     "test <name>",
+	Aliases: []string{"render", "lint"},
+	Short:   "Render a prompt with test values and check its variables",
+	Long: `Render a prompt template with supplied values and print the result.
+
+Values are given with --var Name=value. Prefix the value with @ to read it
+from a file (e.g., --var Content=@SKILL.md). Repeat --var for list variables
+such as Commands in adapt-hook.
+
+Reports:
+  - variables the template uses that jd does not provide when it renders the
+    prompt (these render as "<no value>" inside adapt/guide/tidy)
+  - variables the template uses that no --var supplied
+  - --var values the template never uses
+
+The rendered prompt is printed to stdout and the report to stderr.
+Exits with an error if the template fails to parse or render, or uses a variable
+jd does not provide.`,
+	Example: `  # Render the tidy prompt with a CLAUDE.md
+  jd prompts test tidy-claudemd --var Content=@CLAUDE.md --var Style=minimal
+
+  # Check an overridden prompt's variables without values
+  jd prompts test adapt-skill > /dev/null`,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runPromptsTest,
+	ValidArgsFunction: promptNameCompletion,
+}
+
+func init() {
+	promptsCmd.AddCommand(promptsTestCmd)
+	promptsTestCmd.Flags().StringArrayVar(&promptsTestVars, "var", nil, "Template variable as Name=value or Name=@file (repeatable)")
+}
+
+func runPromptsTest(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	name := args[0]
+
+	content, info, err := prompt.LoadInfo(name)
+	if err != nil {
+		return notFoundErrorf("prompt not found: %s", name)
+	}
+
+	referenced, err := prompt.ReferencedVariables(name, content)
+	if err != nil {
+		return fmt.Errorf("failed to parse prompt template: %w", err)
+	}
+
+	runtimeVars, known := prompt.Variables(name)
+	isList := make(map[string]bool)
+	provided := make(map[string]bool)
+	for _, v := range runtimeVars {
+		isList[v.Name] = v.List
+		provided[v.Name] = true
+	}
+
+	data, err := parsePromptVars(promptsTestVars, isList)
+	if err != nil {
+		return err
+	}
+
+	source := "embedded"
+	if info.IsOverride {
+		source = info.Path
+	}
+	fmt.Fprintf(os.Stderr, "Prompt: %s (%s)\n", name, source)
+
+	// Check variables used by the template
+	used := make(map[string]bool)
+	unavailable := 0
+	for _, v := range referenced {
+		used[v] = true
+		_, supplied := data[v]
+		switch {
+		case known && !provided[v]:
+			fmt.Fprintf(os.Stderr, "  [ERROR] .%s is not provided by jd when rendering %s\n", v, name)
+			unavailable++
+		case !supplied:
+			fmt.Fprintf(os.Stderr, "  [WARN] .%s is undefined (pass --var %s=...)\n", v, v)
+		default:
+			fmt.Fprintf(os.Stderr, "  [OK] .%s\n", v)
+		}
+	}
+
+	// Check supplied values the template never uses
+	names := make([]string, 0, len(data))
+	for v := range data {
+		names = append(names, v)
+	}
+	sort.Strings(names)
+	for _, v := range names {
+		if !used[v] {
+			fmt.Fprintf(os.Stderr, "  [WARN] --var %s is not used by the template\n", v)
+		}
+	}
+
+	if known {
+		for _, v := range runtimeVars {
+			if !used[v.Name] {
+				fmt.Fprintf(os.Stderr, "  [INFO] .%s is available but not used\n", v.Name)
+			}
+		}
+	}
+	fmt.Fprintln(os.Stderr)
+
+	tmpl, err := template.New(name).Parse(content)
+	if err != nil {
+		return fmt.Errorf("failed to parse prompt template: %w", err)
+	}
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		return fmt.Errorf("failed to render prompt: %w", err)
+	}
+	fmt.Print(rendered.String())
+
+	if unavailable > 0 {
+		return fmt.Errorf("%d variable(s) used by %s are not provided by jd", unavailable, name)
+	}
+	return nil
+}
+
+// parsePromptVars parses Name=value flags into template data. Values starting with @
+// are read from a file; names in isList collect repeated values into a list.
+func parsePromptVars(vars []string, isList map[string]bool) (map[string]any, error) {
+	data := make(map[string]any)
+	for _, kv := range vars {
+		name, value, ok := strings.Cut(kv, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid --var %q (expected Name=value)", kv)
+		}
+
+		if path, isFile := strings.CutPrefix(value, "@"); isFile {
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read value for %s: %w", name, err)
+			}
+			value = string(content)
+		}
+
+		if isList[name] {
+			list, _ := data[name].([]string)
+			data[name] = append(list, value)
+			continue
+		}
+		if _, exists := data[name]; exists {
+			return nil, fmt.Errorf("--var %s given more than once", name)
+		}
+		data[name] = value
+	}
+	return data, nil
+}
@@ -32,7 +32,10 @@ func runPromptsReset(cmd *cobra.Command, args []string) error {
 	// Check if prompt exists (in embedded)
 	_, err := prompt.GetEmbedded(name)
 	if err != nil {
-		return fmt.Errorf("prompt not found: %s", name)
+		if prompt.HasOverride(name) {
+			return fmt.Errorf("%s is a custom prompt with no embedded default; delete it in 'jd prompts browse'", name)
+		}
+		return notFoundErrorf("prompt not found: %s", name)
 	}
 
 	if !prompt.HasOverride(name) {
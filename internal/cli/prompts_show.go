@@ -42,14 +42,14 @@ func runPromptsShow(cmd *cobra.Command, args []string) error {
 	if promptsShowEmbedded {
 		content, err = prompt.GetEmbedded(name)
 		if err != nil {
-			return fmt.Errorf("embedded prompt not found: %s", name)
+			return notFoundErrorf("embedded prompt not found: %s", name)
 		}
 		fmt.Printf("# Embedded prompt: %s\n\n", name)
 	} else {
 		var info *prompt.PromptInfo
 		content, info, err = prompt.LoadInfo(name)
 		if err != nil {
-			return fmt.Errorf("prompt not found: %s", name)
+			return notFoundErrorf("prompt not found: %s", name)
 		}
 
 		if info.IsOverride {
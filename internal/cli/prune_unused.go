@@ -0,0 +1,408 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/hook"
+	"github.com/itda-skills/jindo/internal/pkg/pkgmgr"
+	"github.com/itda-skills/jindo/internal/sessionlog"
+	"github.com/itda-skills/jindo/internal/table"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pruneUnusedGlobal   bool
+	pruneUnusedLocal    bool
+	pruneUnusedSessions bool
+	pruneUnusedSince    time.Duration
+	pruneUnusedDryRun   bool
+	pruneUnusedYes      bool
+	pruneUnusedJSON     bool
+)
+
+var pruneUnusedCmd = &cobra.Command{
+	Use:   "prune-unused",
+	Short: "Find skills, agents, commands, and hook scripts nothing refers to, and move them to the trash",
+	Long: `Find the resources that appear unused, and move the ones you pick to the trash.
+
+A skill, agent, or command appears unused when no CLAUDE.md (global, project,
+or nested in the project), other skill, agent, or command, or installed package
+mentions it by name. A hook script in hooks/ appears unused when no hook
+command in settings.json or settings.local.json, active or disabled, and no
+other script refers to it.
+
+Mentions miss the resources you use directly, such as a command you type or a
+skill Claude picks by its description. With --sessions, the session
+transcripts Claude Code keeps in ~/.claude/projects are read too, and a
+resource used in any of them is kept; --since limits them to recent sessions.
+
+Resources installed by a package are not listed; uninstall the package with
+'jd pkg uninstall' instead.
+
+You are asked about each resource: y moves it to the trash, n keeps it, a moves
+it and all the rest, and q stops. The trash is trash/ in the jd data directory
+(~/.itda-skills by default), from where files can be restored by hand.
+
+Both the global scope and, if present, the local scope are checked; use
+--global or --local for one.`,
+	Example: `  jd prune-unused --dry-run
+  jd prune-unused --sessions --since 720h
+  jd prune-unused --local --yes
+  jd prune-unused --sessions --json`,
+	Args: cobra.NoArgs,
+	RunE: runPruneUnused,
+}
+
+func init() {
+	rootCmd.AddCommand(pruneUnusedCmd)
+	pruneUnusedCmd.Flags().BoolVarP(&pruneUnusedGlobal, "global", "g", false, "Check only global ~/.claude/")
+	pruneUnusedCmd.Flags().BoolVarP(&pruneUnusedLocal, "local", "l", false, "Check only local .claude/")
+	pruneUnusedCmd.Flags().BoolVar(&pruneUnusedSessions, "sessions", false, "Keep resources used in Claude Code session transcripts")
+	pruneUnusedCmd.Flags().DurationVar(&pruneUnusedSince, "since", 0, "With --sessions, read only sessions within this long (e.g., 720h)")
+	pruneUnusedCmd.Flags().BoolVarP(&pruneUnusedDryRun, "dry-run", "n", false, "List the unused resources without moving any")
+	pruneUnusedCmd.Flags().BoolVarP(&pruneUnusedYes, "yes", "y", false, "Move every unused resource to the trash without asking")
+	pruneUnusedCmd.Flags().BoolVar(&pruneUnusedJSON, "json", false, "List the unused resources in JSON format without moving any")
+	pruneUnusedCmd.MarkFlagsMutuallyExclusive("global", "local")
+	pruneUnusedCmd.MarkFlagsMutuallyExclusive("dry-run", "yes")
+}
+
+// unusedResource is a resource that nothing appears to refer to
+type unusedResource struct {
+	Kind  string `json:"kind"` // skill, agent, command, or hook-script
+	Scope string `json:"scope"`
+	Name  string `json:"name"`
+	Path  string `json:"path"` // What is moved: the file, or the skill's directory
+}
+
+// pruneCandidate is a resource checked for references, by the names it may be
+// mentioned or used by
+type pruneCandidate struct {
+	unusedResource
+	names []string
+}
+
+func runPruneUnused(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+
+	if pruneUnusedSince != 0 && !pruneUnusedSessions {
+		return validationErrorf("--since limits the sessions read by --sessions; add --sessions")
+	}
+
+	type scopeDir struct {
+		scope PathScope
+		dir   string
+	}
+	globalDir, err := basedir.ClaudePath()
+	if err != nil {
+		return fmt.Errorf("failed to find the Claude directory: %w", err)
+	}
+	var scopes []scopeDir
+	if !pruneUnusedLocal {
+		scopes = append(scopes, scopeDir{ScopeGlobal, globalDir})
+	}
+	if !pruneUnusedGlobal {
+		if dir := pruneLocalDir(); dir != "" {
+			scopes = append(scopes, scopeDir{ScopeLocal, dir})
+		} else if pruneUnusedLocal {
+			return notFoundErrorf("no local .claude directory in %s", ScopeDescription(ScopeLocal))
+		}
+	}
+
+	manager := pkgmgr.NewManager(basedir.DataDir())
+	packages, err := manager.List()
+	if err != nil {
+		return fmt.Errorf("failed to list packages: %w", err)
+	}
+	owned := make(map[string]bool)
+	for _, pkg := range packages {
+		for _, f := range pkg.Files {
+			owned[f.Target] = true
+		}
+	}
+
+	var candidates []pruneCandidate
+	var scripts []unusedResource
+	for _, sc := range scopes {
+		items := loadScopeItems(sc.dir)
+		scope := string(sc.scope)
+		for _, s := range items.skills {
+			if owned[s.Path] {
+				continue
+			}
+			c := pruneCandidate{unusedResource{"skill", scope, skillID(s), filepath.Dir(s.Path)}, []string{skillID(s)}}
+			if s.Name != "" && s.Name != skillID(s) {
+				c.names = append(c.names, s.Name)
+			}
+			candidates = append(candidates, c)
+		}
+		for _, a := range items.agents {
+			if !owned[a.Path] {
+				candidates = append(candidates, pruneCandidate{unusedResource{"agent", scope, a.Name, a.Path}, []string{a.Name}})
+			}
+		}
+		for _, c := range items.commands {
+			if !owned[c.Path] {
+				candidates = append(candidates, pruneCandidate{unusedResource{"command", scope, c.Name, c.Path}, []string{c.Name}})
+			}
+		}
+		for _, path := range hookScripts(sc.dir) {
+			if !owned[path] {
+				scripts = append(scripts, unusedResource{"hook-script", scope, filepath.Base(path), path})
+			}
+		}
+	}
+
+	candidates, err = unreferenced(manager, candidates)
+	if err != nil {
+		return err
+	}
+
+	var usage *sessionlog.Usage
+	if pruneUnusedSessions {
+		var since time.Time
+		if pruneUnusedSince > 0 {
+			since = time.Now().Add(-pruneUnusedSince)
+		}
+		projectsDir, _ := basedir.ClaudePath("projects")
+		if usage, err = sessionlog.Scan(projectsDir, since); err != nil {
+			return fmt.Errorf("failed to read session transcripts: %w", err)
+		}
+		candidates = unusedInSessions(candidates, usage)
+	}
+
+	unused := []unusedResource{}
+	for _, c := range candidates {
+		unused = append(unused, c.unusedResource)
+	}
+	// A local hook may run a global script, so the hooks of both scopes are searched
+	hookText := hookCommandText(globalDir)
+	if dir := pruneLocalDir(); dir != "" {
+		hookText += hookCommandText(dir)
+	}
+	for _, s := range scripts {
+		if !scriptReferenced(s.Path, hookText) {
+			unused = append(unused, s)
+		}
+	}
+
+	if pruneUnusedJSON {
+		return printJSON(unused)
+	}
+
+	if usage != nil {
+		if usage.Sessions == 0 {
+			fmt.Println("No session transcripts found; only mentions were checked.")
+		} else {
+			fmt.Printf("Checked %d session%s since %s.\n", usage.Sessions, plural(usage.Sessions), usage.Oldest.Format("2006-01-02"))
+		}
+	}
+	if len(unused) == 0 {
+		fmt.Println("No unused resources found.")
+		return nil
+	}
+
+	fmt.Printf("Unused resources (%d):\n\n", len(unused))
+	t := newTable(table.Column{Header: "TYPE"}, table.Column{Header: "SCOPE"}, table.Column{Header: "NAME", Fixed: true}, table.Column{Header: "PATH", Max: 60})
+	for _, r := range unused {
+		t.AddRow(r.Kind, r.Scope, r.Name, r.Path)
+	}
+	t.Render(os.Stdout)
+	if !pruneUnusedSessions {
+		fmt.Println("\nResources you use directly may not be mentioned anywhere; add --sessions to also check Claude Code sessions.")
+	}
+	if pruneUnusedDryRun {
+		return nil
+	}
+	fmt.Println()
+	return pruneResources(manager, unused, pruneUnusedYes)
+}
+
+// pruneLocalDir returns the project's .claude directory, or "" if there is
+// none or it is the global one, as when run in the home directory
+func pruneLocalDir() string {
+	dir := GetLocalPath("")
+	if global, err := basedir.ClaudePath(); dir == "" || (err == nil && filepath.Clean(dir) == global) {
+		return ""
+	}
+	return dir
+}
+
+// unreferenced returns the candidates no CLAUDE.md, other resource, or
+// installed package mentions
+func unreferenced(manager *pkgmgr.Manager, candidates []pruneCandidate) ([]pruneCandidate, error) {
+	var names []string
+	for _, c := range candidates {
+		names = append(names, c.names...)
+	}
+	var dirs []string
+	if dir := pruneLocalDir(); dir != "" {
+		dirs = append(dirs, dir)
+	}
+	refs, err := manager.References(names, dirs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find references: %w", err)
+	}
+
+	home, _ := os.UserHomeDir()
+	root, _ := ProjectRoot()
+	inProject := root != "" && filepath.Clean(root) != filepath.Clean(home)
+	_, claudemdFiles, _, err := collectClaudemdFiles(inProject)
+	if err != nil {
+		return nil, err
+	}
+	var claudemdText strings.Builder
+	for _, f := range claudemdFiles {
+		if data, err := os.ReadFile(f.Path); err == nil {
+			claudemdText.Write(data)
+			claudemdText.WriteString("\n")
+		}
+	}
+
+	var unused []pruneCandidate
+	for _, c := range candidates {
+		if !resourceMentioned(c, refs, claudemdText.String()) {
+			unused = append(unused, c)
+		}
+	}
+	return unused, nil
+}
+
+// resourceMentioned reports whether a CLAUDE.md or a file outside the resource
+// itself mentions it by one of its names
+func resourceMentioned(c pruneCandidate, refs map[string][]pkgmgr.Reference, claudemdText string) bool {
+	for _, name := range c.names {
+		if pkgmgr.MentionPattern(name).MatchString(claudemdText) {
+			return true
+		}
+		for _, r := range refs[name] {
+			if r.Path != c.Path && !strings.HasPrefix(r.Path, c.Path+string(filepath.Separator)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// unusedInSessions drops the candidates used, by any of their names, in the sessions read
+func unusedInSessions(candidates []pruneCandidate, usage *sessionlog.Usage) []pruneCandidate {
+	var unused []pruneCandidate
+	for _, c := range candidates {
+		uses := map[string]map[string]int{"skill": usage.Skills, "agent": usage.Agents, "command": usage.Commands}[c.Kind]
+		if !slices.ContainsFunc(c.names, func(name string) bool { return uses[name] > 0 }) {
+			unused = append(unused, c)
+		}
+	}
+	return unused
+}
+
+// hookScripts returns the scripts directly in the hooks directory of a Claude
+// directory; helper libraries in its subdirectories are sourced by them
+func hookScripts(claudeDir string) []string {
+	entries, err := os.ReadDir(filepath.Join(claudeDir, "hooks"))
+	if err != nil {
+		return nil
+	}
+	var scripts []string
+	for _, e := range entries {
+		if !e.IsDir() && !strings.HasPrefix(e.Name(), ".") {
+			scripts = append(scripts, filepath.Join(claudeDir, "hooks", e.Name()))
+		}
+	}
+	return scripts
+}
+
+// hookCommandText joins the commands of the hooks, active and disabled, in the
+// settings files of a Claude directory, and the hook scripts in it
+func hookCommandText(claudeDir string) string {
+	var b strings.Builder
+	for _, name := range []string{"settings.json", "settings.local.json"} {
+		store := hook.NewStore(filepath.Join(claudeDir, name))
+		active, _ := store.List()
+		disabled, _ := store.ListDisabled()
+		for _, h := range append(active, disabled...) {
+			for _, c := range h.Commands {
+				b.WriteString(c)
+				b.WriteString("\n")
+			}
+		}
+	}
+	return b.String()
+}
+
+// scriptReferenced reports whether a hook command or another hook script refers
+// to a script by its file name
+func scriptReferenced(path, hookText string) bool {
+	name := filepath.Base(path)
+	if strings.Contains(hookText, name) {
+		return true
+	}
+	for _, other := range hookScripts(filepath.Dir(filepath.Dir(path))) {
+		if other == path {
+			continue
+		}
+		if content, ok := readScript(other); ok && strings.Contains(content, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// readScript reads a hook script, skipping large and binary files
+func readScript(path string) (string, bool) {
+	info, err := os.Stat(path)
+	if err != nil || info.Size() > 1<<20 {
+		return "", false
+	}
+	content, err := os.ReadFile(path)
+	if err != nil || strings.ContainsRune(string(content[:min(len(content), 512)]), 0) {
+		return "", false
+	}
+	return string(content), true
+}
+
+// pruneResources moves unused resources to the trash, asking about each unless yes is set
+func pruneResources(manager *pkgmgr.Manager, unused []unusedResource, yes bool) error {
+	reader := bufio.NewReader(os.Stdin)
+	moved := 0
+	for _, r := range unused {
+		if !yes {
+			fmt.Printf("Move %s '%s' (%s) to the trash? (y/N/a/q): ", r.Kind, r.Name, r.Scope)
+			response, _ := reader.ReadString('\n')
+			switch strings.TrimSpace(strings.ToLower(response)) {
+			case "y", "yes":
+			case "a", "all":
+				yes = true
+			case "q", "quit":
+				fmt.Println("Stopped.")
+				return pruneSummary(manager, moved)
+			default:
+				continue
+			}
+		}
+		dest, err := moveResourceToTrash(manager, r.Kind, r.Name, r.Path)
+		if err != nil {
+			return fmt.Errorf("failed to move %s to the trash: %w", r.Kind, err)
+		}
+		fmt.Printf("🗑  Moved %s %s to %s\n", r.Kind, r.Name, dest)
+		moved++
+	}
+	return pruneSummary(manager, moved)
+}
+
+// pruneSummary reports how many resources were moved to the trash
+func pruneSummary(manager *pkgmgr.Manager, moved int) error {
+	if moved == 0 {
+		fmt.Println("Nothing was moved.")
+		return nil
+	}
+	trashDir, _ := manager.TrashDir()
+	fmt.Printf("\n✅ Moved %d resource%s to the trash (%s)\n", moved, plural(moved), trashDir)
+	return nil
+}
@@ -0,0 +1,176 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/detect"
+	"github.com/itda-skills/jindo/internal/pkg/pkgmgr"
+	"github.com/itda-skills/jindo/internal/pkg/repo"
+	"github.com/itda-skills/jindo/internal/progress"
+	"github.com/itda-skills/jindo/internal/recommend"
+	"github.com/itda-skills/jindo/internal/table"
+	"github.com/spf13/cobra"
+)
+
+var (
+	recommendLimit int
+	recommendType  string
+	recommendJSON  bool
+	recommendLocal bool
+)
+
+var recommendCmd = &cobra.Command{
+	Use:     "recommend",
+	Aliases: []string{"rec"},
+	Short:   "Suggest packages for the current project",
+	Long: `Suggest packages from registered repositories that fit the project's stack.
+
+The stack is detected as in 'jd project detect' (languages, formatters,
+Docker, CI providers). Each package that is not installed yet is scored by
+where the stack shows up: its name or tags count most, then its description
+(or, for hooks, the script itself). Hooks for the formatter the project is set
+up for, such as prettier, rank higher. Each suggestion says why it matched.
+
+After the list, enter the numbers of the packages to install (e.g., "1 3"),
+or press Enter to skip. Use --local to install into the project's .claude.
+
+Examples:
+  jd recommend
+  jd recommend --type hooks
+  jd recommend --limit 20 --local
+  jd recommend --json`,
+	Args: cobra.NoArgs,
+	RunE: runRecommend,
+}
+
+func init() {
+	rootCmd.AddCommand(recommendCmd)
+	recommendCmd.Flags().IntVarP(&recommendLimit, "limit", "n", 10, "Maximum number of suggestions (0 for all)")
+	recommendCmd.Flags().StringVarP(&recommendType, "type", "t", "", "Filter by type (skills, commands, agents, hooks)")
+	recommendCmd.Flags().BoolVar(&recommendJSON, "json", false, "Output in JSON format (does not offer to install)")
+	recommendCmd.Flags().BoolVarP(&recommendLocal, "local", "l", false, "Install into the project's .claude directory")
+}
+
+func runRecommend(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+
+	var typeFilter repo.PackageType
+	switch recommendType {
+	case "":
+		// No filter
+	case "skills", "skill":
+		typeFilter = repo.TypeSkill
+	case "commands", "command":
+		typeFilter = repo.TypeCommand
+	case "agents", "agent":
+		typeFilter = repo.TypeAgent
+	case "hooks", "hook":
+		typeFilter = repo.TypeHook
+	default:
+		return fmt.Errorf("invalid type: %s (use: skills, commands, agents, hooks)", recommendType)
+	}
+
+	root, err := ProjectRoot()
+	if err != nil {
+		return fmt.Errorf("failed to resolve project root: %w", err)
+	}
+	profile := detect.Detect(root)
+
+	manager := pkgmgr.NewManager(basedir.DataDir())
+	installed, err := manager.List()
+	if err != nil {
+		return fmt.Errorf("failed to list installed packages: %w", err)
+	}
+
+	recs, err := recommend.Recommend(manager.RepoStore(), installed, profile, typeFilter)
+	if err != nil {
+		return fmt.Errorf("failed to rank packages: %w", err)
+	}
+	if recommendLimit > 0 && len(recs) > recommendLimit {
+		recs = recs[:recommendLimit]
+	}
+
+	if recommendJSON {
+		output, err := json.MarshalIndent(recs, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(output))
+		return nil
+	}
+
+	if profile.Empty() {
+		fmt.Printf("No stack detected in %s.\n", root)
+		fmt.Println("Recommendations are based on go.mod, package.json, pyproject.toml, Cargo.toml, Dockerfile, and CI configs.")
+		return nil
+	}
+	fmt.Printf("Stack: %s\n\n", strings.Join(profile.Tags(), ", "))
+
+	if len(recs) == 0 {
+		fmt.Println("No matching packages in registered repositories.")
+		fmt.Println("Register more with: jd pkg repo add gh:owner/repo")
+		return nil
+	}
+
+	for i, r := range recs {
+		fmt.Printf("%2d. %s (%s)\n", i+1, r.Spec(), r.Type)
+		if desc := strings.Join(strings.Fields(r.Description), " "); desc != "" {
+			fmt.Printf("    %s\n", table.Truncate(desc, 76))
+		}
+		fmt.Printf("    Why: %s\n", strings.Join(r.Reasons, "; "))
+	}
+
+	selected, err := askRecommendations(len(recs))
+	if err != nil || len(selected) == 0 {
+		return err
+	}
+
+	manager.SetProgress(progress.New(os.Stderr))
+	enableAutoSnapshot(manager)
+	if err := applyPackageNaming(manager); err != nil {
+		return err
+	}
+	scope := ScopeGlobal
+	if recommendLocal || projectRootSelected() {
+		scope = ScopeLocal
+		manager.SetClaudeDir(filepath.Join(root, localClaudeDir))
+	}
+
+	for _, i := range selected {
+		r := recs[i]
+		fmt.Println()
+		if err := installFromRepo(manager, r.Spec(), r.Namespace, scope, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// askRecommendations asks which of n numbered suggestions to install and returns
+// their indexes. An empty answer selects nothing.
+func askRecommendations(n int) ([]int, error) {
+	fmt.Print("\nInstall (numbers, e.g. 1 3; Enter to skip): ")
+	reader := bufio.NewReader(os.Stdin)
+	response, _ := reader.ReadString('\n')
+
+	var selected []int
+	seen := make(map[int]bool)
+	for _, field := range strings.Fields(strings.ReplaceAll(response, ",", " ")) {
+		num, err := strconv.Atoi(field)
+		if err != nil || num < 1 || num > n {
+			return nil, validationErrorf("invalid selection: %s (use 1-%d)", field, n)
+		}
+		if !seen[num] {
+			seen[num] = true
+			selected = append(selected, num-1)
+		}
+	}
+	return selected, nil
+}
@@ -1,25 +1,99 @@
 package cli
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/itda-skills/jindo/pkg/config"
 	"github.com/spf13/cobra"
 )
 
+var rootVerbose bool
+
 var rootCmd = &cobra.Command{
-	Use:   "jd",
-	Short: "Claude Code configuration manager",
+	Use:     "jd",
+	Short:   "Claude Code configuration manager",
 	Version: Version,
 	Long: `jd is a CLI tool for managing Claude Code configurations
 including skills, commands, agents, and hooks.
 
 Default scope: local (.claude) if present, otherwise global (~/.claude).
+The local .claude is searched upward from the current directory, like git.
+Use --project-root to select the project directory explicitly, or
+--scope-path to select a sub-project directory or a named scope from config.
+Settings in the project's .claude/jindo.toml override the global config.
 
 Subcommand aliases: skills(s), commands(c), agents(a), hooks(h), pkg(p), list(l)
 Common subcommand aliases: list(l,ls), new(n,add,create), show(s,get,view), edit(e,update,modify), delete(d,rm,remove)
 
 Use 'jd --help' for all available commands.`,
+	PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+		if projectRootOverride != "" && scopePathFlag != "" {
+			cmd.SilenceUsage = true
+			return errors.New("--project-root and --scope-path flags are mutually exclusive")
+		}
+		if scopePathFlag != "" {
+			if _, err := resolveScopePath(scopePathFlag); err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+		}
+		if root, err := ProjectRoot(); err == nil {
+			config.SetLocalPath(filepath.Join(root, localClaudeDir, config.LocalConfigName))
+		}
+		if rootVerbose {
+			printProjectRoot()
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&projectRootOverride, "project-root", "", "Project directory containing .claude (default: nearest .claude in parent directories)")
+	rootCmd.PersistentFlags().StringVar(&scopePathFlag, "scope-path", "", "Sub-project directory or named scope (config "+scopesConfigKey+") to use as local scope")
+	rootCmd.PersistentFlags().BoolVar(&rootVerbose, "verbose", false, "Show verbose output, including the selected project root")
 }
 
-// Execute runs the root command
+// printProjectRoot prints the project root used for local scope to stderr
+func printProjectRoot() {
+	root, err := ProjectRoot()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Project root: unknown (%v)\n", err)
+		return
+	}
+	if LocalClaudeDirExists() {
+		fmt.Fprintf(os.Stderr, "Project root: %s\n", root)
+	} else {
+		fmt.Fprintf(os.Stderr, "Project root: %s (no .claude found)\n", root)
+	}
+}
+
+// Execute runs the root command and prints any error.
+// Use ExitCode to turn the returned error into the process exit code.
 func Execute() error {
-	return rootCmd.Execute()
+	// Errors and usage are printed here, so that usage errors can be told apart
+	rootCmd.SilenceErrors = true
+	rootCmd.SilenceUsage = true
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return usageError(cmd, err)
+	})
+	wrapArgsErrors(rootCmd)
+
+	err := rootCmd.Execute()
+	if err == nil {
+		return nil
+	}
+	err = classifyCobraError(err)
+	if errors.Is(err, errCancelled) {
+		return err
+	}
+
+	fmt.Fprintln(os.Stderr, "Error:", err)
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) && exitErr.usage != nil {
+		fmt.Fprintln(os.Stderr, exitErr.usage.UsageString())
+	}
+	return err
 }
@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/itda-skills/jindo/pkg/config"
+)
+
+// scopesConfigKey is the config table of named local scopes (name = project directory)
+//
+//	[jindo.scopes]
+//	app-a = "~/work/monorepo/packages/app-a"
+const scopesConfigKey = "jindo.scopes"
+
+// scopePathFlag is set by the --scope-path flag
+var scopePathFlag string
+
+// namedScope is a project directory declared in config
+type namedScope struct {
+	Name string
+	Root string
+}
+
+// configuredScopes returns the named scopes declared in config, sorted by name
+func configuredScopes() []namedScope {
+	// Named scopes select the project, so they come from the global config only
+	cfg, err := config.LoadGlobal()
+	if err != nil {
+		return nil
+	}
+
+	var scopes []namedScope
+	for name, dir := range cfg.GetStringMap(scopesConfigKey) {
+		root, err := expandScopeDir(dir)
+		if err != nil {
+			continue
+		}
+		scopes = append(scopes, namedScope{Name: name, Root: root})
+	}
+
+	sort.Slice(scopes, func(i, j int) bool {
+		return scopes[i].Name < scopes[j].Name
+	})
+	return scopes
+}
+
+// resolveScopePath resolves a --scope-path value to a project directory.
+// The value is either a named scope from config or a directory path.
+func resolveScopePath(value string) (string, error) {
+	for _, s := range configuredScopes() {
+		if s.Name == value {
+			return s.Root, nil
+		}
+	}
+
+	root, err := expandScopeDir(value)
+	if err != nil {
+		return "", err
+	}
+
+	info, err := os.Stat(root)
+	if err != nil || !info.IsDir() {
+		return "", notFoundErrorf("scope path not found: %s (not a directory or named scope in %s)", value, scopesConfigKey)
+	}
+	return root, nil
+}
+
+// expandScopeDir expands ~ and makes dir absolute
+func expandScopeDir(dir string) (string, error) {
+	if strings.HasPrefix(dir, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, dir[2:])
+	}
+	return filepath.Abs(dir)
+}
+
+// projectRootSelected reports whether the project root was chosen explicitly
+// with --project-root or --scope-path
+func projectRootSelected() bool {
+	return projectRootOverride != "" || scopePathFlag != ""
+}
@@ -1,13 +1,21 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/itda-skills/jindo/internal/agent"
+	"github.com/itda-skills/jindo/internal/basedir"
 	"github.com/itda-skills/jindo/internal/command"
+	"github.com/itda-skills/jindo/internal/index"
+	"github.com/itda-skills/jindo/internal/pkg/repo"
+	"github.com/itda-skills/jindo/internal/search"
 	"github.com/itda-skills/jindo/internal/skill"
+	"github.com/itda-skills/jindo/internal/table"
 	"github.com/spf13/cobra"
 )
 
@@ -16,6 +24,8 @@ var (
 	searchCommandsOnly bool
 	searchAgentsOnly   bool
 	searchNameOnly     bool
+	searchLimit        int
+	searchStream       bool
 )
 
 var searchCmd = &cobra.Command{
@@ -23,8 +33,22 @@ var searchCmd = &cobra.Command{
 	Short: "Search across skills, commands, and agents",
 	Long: `Search for a keyword across all skills, commands, and agents.
 
-Searches in name, description, and content by default.
-Results are grouped by resource type.`,
+Searches in name, tags, description, and content by default.
+Results are grouped by resource type.
+
+File contents are scanned concurrently, and only for resources whose name, tags,
+and description do not match. The full-text index (see 'jd index') narrows the
+scan to files containing every word of the query. Lowercased contents are cached
+in the jd data directory (cache/search-index.json) and reread only when a file's
+modification time or size changes, so repeated searches in large setups are fast.
+
+Use --stream to print results as they are found instead of grouped at the end,
+and --limit to stop after a number of results.
+
+Examples:
+  jd search fetch
+  jd search git --commands
+  jd search review --stream --limit 5`,
 	Args: cobra.ExactArgs(1),
 	RunE: runSearch,
 }
@@ -35,206 +59,186 @@ func init() {
 	searchCmd.Flags().BoolVarP(&searchCommandsOnly, "commands", "c", false, "Search only in commands")
 	searchCmd.Flags().BoolVarP(&searchAgentsOnly, "agents", "a", false, "Search only in agents")
 	searchCmd.Flags().BoolVarP(&searchNameOnly, "name", "n", false, "Search only in names")
+	searchCmd.Flags().IntVar(&searchLimit, "limit", 0, "Stop after this many results (0: no limit)")
+	searchCmd.Flags().BoolVar(&searchStream, "stream", false, "Print results as they are found")
 }
 
 // SearchResult represents a single search result
 type SearchResult struct {
-	Type        string // "skill", "command", "agent"
-	Name        string
-	Description string
-	Path        string
-	MatchIn     string // where the match was found: "name", "description", "content"
+	Type        string `json:"type"` // "skill", "command", "agent"
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Path        string `json:"path"`
+	MatchIn     string `json:"match_in"` // where the match was found: "name", "tag", "description", "content"
+	order       int    // position in the searched resources, for listing results in a stable order
 }
 
+// searchIndexFile is the cache of searched file contents, in the jd data directory
+const searchIndexFile = "cache/search-index.json"
+
 func runSearch(cmd *cobra.Command, args []string) error {
 	cmd.SilenceUsage = true
-	query := strings.ToLower(args[0])
 
 	var results []SearchResult
-
-	// Determine which resources to search
-	searchAll := !searchSkillsOnly && !searchCommandsOnly && !searchAgentsOnly
-
-	// Search skills
-	if searchAll || searchSkillsOnly {
-		skillResults, err := searchSkills(query)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to search skills: %v\n", err)
-		}
-		results = append(results, skillResults...)
+	q := searchQuery{
+		text:       strings.ToLower(args[0]),
+		skills:     searchSkillsOnly,
+		commands:   searchCommandsOnly,
+		agents:     searchAgentsOnly,
+		nameOnly:   searchNameOnly,
+		limit:      searchLimit,
+		shortNames: true,
 	}
-
-	// Search commands
-	if searchAll || searchCommandsOnly {
-		cmdResults, err := searchCommands(query)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to search commands: %v\n", err)
-		}
-		results = append(results, cmdResults...)
-	}
-
-	// Search agents
-	if searchAll || searchAgentsOnly {
-		agentResults, err := searchAgents(query)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to search agents: %v\n", err)
+	err := searchResources(cmd.Context(), q, func(result SearchResult) {
+		if searchStream {
+			fmt.Printf("%-8s ", result.Type)
+			printResult(result)
 		}
-		results = append(results, agentResults...)
+		results = append(results, result)
+	})
+	if err != nil {
+		return err
 	}
 
 	if len(results) == 0 {
 		fmt.Println("No results found.")
 		return nil
 	}
+	if searchStream {
+		fmt.Printf("\nTotal: %d results\n", len(results))
+		return nil
+	}
 
-	// Print results grouped by type
+	// Results arrive as they are found; list them in resource order
+	sort.Slice(results, func(i, j int) bool { return results[i].order < results[j].order })
 	printGroupedResults(results)
 
 	return nil
 }
 
-func searchSkills(query string) ([]SearchResult, error) {
-	store := skill.NewStore("~/.claude/skills")
-	skills, err := store.List()
-	if err != nil {
-		return nil, err
-	}
-
-	var results []SearchResult
-	for _, s := range skills {
-		matchIn := matchSkill(s, query, store)
-		if matchIn != "" {
-			results = append(results, SearchResult{
-				Type:        "skill",
-				Name:        s.Name,
-				Description: s.Description,
-				Path:        s.Path,
-				MatchIn:     matchIn,
-			})
-		}
-	}
-
-	return results, nil
+// searchQuery is a search of the global skills, commands, and agents
+type searchQuery struct {
+	text                     string // Lowercased
+	skills, commands, agents bool   // Kinds of resources to search; none selects all
+	nameOnly                 bool
+	limit                    int  // Results to stop after; 0 for no limit
+	shortNames               bool // Name installed packages by their short names
 }
 
-func matchSkill(s *skill.Skill, query string, store *skill.Store) string {
-	// Check name
-	if strings.Contains(strings.ToLower(s.Name), query) {
-		return "name"
-	}
+// searchResources runs a search, calling found with each result as it is found
+func searchResources(ctx context.Context, q searchQuery, found func(SearchResult)) error {
+	all := !q.skills && !q.commands && !q.agents
 
-	if searchNameOnly {
-		return ""
+	var docs []search.Document
+	if all || q.skills {
+		skillDocs, err := skillDocuments()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to search skills: %v\n", err)
+		}
+		docs = append(docs, skillDocs...)
 	}
-
-	// Check description
-	if strings.Contains(strings.ToLower(s.Description), query) {
-		return "description"
+	if all || q.commands {
+		cmdDocs, err := commandDocuments()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to search commands: %v\n", err)
+		}
+		docs = append(docs, cmdDocs...)
 	}
-
-	// Check content
-	content, err := store.GetContent(s.Name)
-	if err == nil && strings.Contains(strings.ToLower(content), query) {
-		return "content"
+	if all || q.agents {
+		agentDocs, err := agentDocuments()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to search agents: %v\n", err)
+		}
+		docs = append(docs, agentDocs...)
 	}
 
-	return ""
-}
-
-func searchCommands(query string) ([]SearchResult, error) {
-	store := command.NewStore("~/.claude/commands")
-	commands, err := store.List()
-	if err != nil {
-		return nil, err
+	var cache *search.Index
+	if dataDir, err := basedir.Expand(basedir.DataDir()); err == nil {
+		cache = search.LoadIndex(filepath.Join(dataDir, searchIndexFile))
 	}
 
-	var results []SearchResult
-	for _, cmd := range commands {
-		matchIn := matchCommand(cmd, query, store)
-		if matchIn != "" {
-			results = append(results, SearchResult{
-				Type:        "command",
-				Name:        cmd.Name,
-				Description: cmd.Description,
-				Path:        cmd.Path,
-				MatchIn:     matchIn,
-			})
+	var names shortNames
+	if q.shortNames {
+		listed := make(map[repo.PackageType][]string)
+		for _, d := range docs {
+			listed[repo.PackageType(d.Type)] = append(listed[repo.PackageType(d.Type)], documentID(d.Type, d.Name, d.Path))
 		}
+		names = loadShortNames(listed)
 	}
 
-	return results, nil
-}
-
-func matchCommand(cmd *command.Command, query string, store *command.Store) string {
-	// Check name
-	if strings.Contains(strings.ToLower(cmd.Name), query) {
-		return "name"
+	opts := search.Options{NameOnly: q.nameOnly, Limit: q.limit, Index: cache}
+	if !q.nameOnly {
+		if ix := loadIndex(); ix != nil {
+			opts.Candidates = ix.Candidates(q.text, index.SourceInstalled)
+		}
 	}
-
-	if searchNameOnly {
-		return ""
+	err := search.Scan(ctx, docs, q.text, opts, func(r search.Result) {
+		found(SearchResult{Type: r.Type, Name: resultName(names, r), Description: r.Description, Path: r.Path, MatchIn: r.MatchIn, order: r.Order})
+	})
+	if cache != nil {
+		if err := cache.Save(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to save search index: %v\n", err)
+		}
 	}
+	return err
+}
 
-	// Check description
-	if strings.Contains(strings.ToLower(cmd.Description), query) {
-		return "description"
+// documentID returns the ID of a searched resource: its name, or for a skill,
+// its directory name
+func documentID(kind, name, path string) string {
+	if kind == "skill" {
+		return filepath.Base(filepath.Dir(path))
 	}
+	return name
+}
 
-	// Check content
-	content, err := store.GetContent(cmd.Name)
-	if err == nil && strings.Contains(strings.ToLower(content), query) {
-		return "content"
+// resultName returns the name a result is listed under: the short name of an
+// installed package, with its namespace, or the resource's name
+func resultName(names shortNames, r search.Result) string {
+	kind, id := repo.PackageType(r.Type), documentID(r.Type, r.Name, r.Path)
+	if _, ok := names.get(kind, id); ok {
+		return names.label(kind, id)
 	}
-
-	return ""
+	return r.Name
 }
 
-func searchAgents(query string) ([]SearchResult, error) {
-	store := agent.NewStore("~/.claude/agents")
-	agents, err := store.List()
+// skillDocuments returns the global skills to search
+func skillDocuments() ([]search.Document, error) {
+	skills, err := skill.NewStore(GetGlobalPath("skills")).List()
 	if err != nil {
 		return nil, err
 	}
-
-	var results []SearchResult
-	for _, a := range agents {
-		matchIn := matchAgent(a, query, store)
-		if matchIn != "" {
-			results = append(results, SearchResult{
-				Type:        "agent",
-				Name:        a.Name,
-				Description: a.Description,
-				Path:        a.Path,
-				MatchIn:     matchIn,
-			})
-		}
+	docs := make([]search.Document, len(skills))
+	for i, s := range skills {
+		docs[i] = search.Document{Type: "skill", Name: s.Name, Description: s.Description, Tags: s.Tags, Path: s.Path}
 	}
-
-	return results, nil
+	return docs, nil
 }
 
-func matchAgent(a *agent.Agent, query string, store *agent.Store) string {
-	// Check name
-	if strings.Contains(strings.ToLower(a.Name), query) {
-		return "name"
+// commandDocuments returns the global commands to search
+func commandDocuments() ([]search.Document, error) {
+	commands, err := command.NewStore(GetGlobalPath("commands")).List()
+	if err != nil {
+		return nil, err
 	}
-
-	if searchNameOnly {
-		return ""
+	docs := make([]search.Document, len(commands))
+	for i, c := range commands {
+		docs[i] = search.Document{Type: "command", Name: c.Name, Description: c.Description, Tags: c.Tags, Path: c.Path}
 	}
+	return docs, nil
+}
 
-	// Check description
-	if strings.Contains(strings.ToLower(a.Description), query) {
-		return "description"
+// agentDocuments returns the global agents to search
+func agentDocuments() ([]search.Document, error) {
+	agents, err := agent.NewStore(GetGlobalPath("agents")).List()
+	if err != nil {
+		return nil, err
 	}
-
-	// Check content
-	content, err := store.GetContent(a.Name)
-	if err == nil && strings.Contains(strings.ToLower(content), query) {
-		return "content"
+	docs := make([]search.Document, len(agents))
+	for i, a := range agents {
+		docs[i] = search.Document{Type: "agent", Name: a.Name, Description: a.Description, Tags: a.Tags, Path: a.Path}
 	}
-
-	return ""
+	return docs, nil
 }
 
 func printGroupedResults(results []SearchResult) {
@@ -283,9 +287,5 @@ func filterByType(results []SearchResult, typ string) []SearchResult {
 }
 
 func printResult(r SearchResult) {
-	desc := r.Description
-	if len(desc) > 50 {
-		desc = desc[:47] + "..."
-	}
-	fmt.Printf("  %-20s  %s  (match in %s)\n", r.Name, desc, r.MatchIn)
+	fmt.Printf("  %-20s  %s  (match in %s)\n", r.Name, table.Truncate(r.Description, 50), r.MatchIn)
 }
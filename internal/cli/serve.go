@@ -0,0 +1,281 @@
+package cli
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/spf13/cobra"
+)
+
+var (
+	serveAddr   string
+	serveSocket string
+	serveWrite  bool
+	serveToken  string
+)
+
+// Defaults of jd serve
+const (
+	serveDefaultAddr = "127.0.0.1:7437"
+	serveTokenEnv    = "JINDO_SERVE_TOKEN"
+	serveTokenFile   = "serve-token" // In the jd data directory, while the server runs
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve a local HTTP API for editor and IDE integrations",
+	Long: `Serve a local HTTP API, so editor extensions such as a VS Code extension can
+list resources, search, browse repositories, and install packages without
+running jd for every call. Responses are JSON; errors are {"error": "..."}.
+
+Read-only endpoints:
+  GET  /v1/health                         Version, and whether writes are enabled
+  GET  /v1/resources                      Resources by scope, as 'jd list --json'
+       ?scope=global|local|<name>&type=skill,agent&tag=<tag>&q=<text>
+       &sort=name|type|installed|updated
+  GET  /v1/search?q=<query>               Search, as 'jd search'
+       &type=skill|command|agent&name_only=true&limit=<n>
+  GET  /v1/packages                       Installed packages, as 'jd pkg list --json'
+       ?sort=name|type|installed|updated
+  GET  /v1/repos                          Registered repositories
+  GET  /v1/repos/{namespace}/packages     Packages of a repository (?type=skill)
+  GET  /v1/events?type=<type>&limit=<n>   Recent events (see 'jd events')
+
+With --write, endpoints that change packages are enabled:
+  POST   /v1/packages                     Install {"spec": "ns:path[@version]",
+                                          "scope": "global|local", "register_hook": true}
+  DELETE /v1/packages/{name}              Uninstall (modified files are kept in the trash)
+
+Write requests need the header 'Authorization: Bearer <token>'. The token is
+--token, or $JINDO_SERVE_TOKEN, or else generated at startup and written to
+serve-token in the jd data directory, readable only by you, for the duration
+of the server. Hooks and commands from untrusted repositories are refused.
+
+Every endpoint takes ?project=<dir> to use that project's .claude as local
+scope (default: the project jd serve was started in).
+
+The server listens on localhost only (--addr), or on a unix socket (--socket).
+Requests whose Host header is not localhost are refused, so web pages cannot
+reach it through DNS rebinding.
+
+Examples:
+  jd serve
+  jd serve --addr 127.0.0.1:9000 --write
+  jd serve --socket ~/.itda-skills/jd.sock --write
+  curl -s localhost:7437/v1/search?q=fetch`,
+	Args: cobra.NoArgs,
+	RunE: runServe,
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+	serveCmd.Flags().StringVar(&serveAddr, "addr", serveDefaultAddr, "Localhost address to listen on")
+	serveCmd.Flags().StringVar(&serveSocket, "socket", "", "Unix socket to listen on instead of --addr")
+	serveCmd.Flags().BoolVar(&serveWrite, "write", false, "Enable the endpoints that install and uninstall packages")
+	serveCmd.Flags().StringVar(&serveToken, "token", "", "Token write requests must send (default: $"+serveTokenEnv+" or generated)")
+	serveCmd.MarkFlagsMutuallyExclusive("addr", "socket")
+}
+
+func runServe(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+
+	listener, where, err := serveListen()
+	if err != nil {
+		return err
+	}
+
+	s := &apiServer{write: serveWrite, tcp: serveSocket == ""}
+	if s.write {
+		tokenPath, err := s.setupToken()
+		if err != nil {
+			listener.Close()
+			return err
+		}
+		if tokenPath != "" {
+			defer os.Remove(tokenPath)
+		}
+	}
+
+	server := &http.Server{Handler: s.routes(), ReadHeaderTimeout: 10 * time.Second}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	mode := "read-only"
+	if s.write {
+		mode = "read-write"
+	}
+	fmt.Fprintf(os.Stderr, "Serving the jd API (%s) on %s (Ctrl+C to stop)\n", mode, where)
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve: %w", err)
+	}
+	return nil
+}
+
+// serveListen listens on --socket, or on --addr, which must be a loopback
+// address. Returns the listener and where it listens, for display.
+func serveListen() (net.Listener, string, error) {
+	if serveSocket != "" {
+		path, err := basedir.Expand(serveSocket)
+		if err != nil {
+			return nil, "", err
+		}
+		// A socket left by a server that did not shut down is replaced
+		if info, err := os.Stat(path); err == nil && info.Mode()&os.ModeSocket != 0 {
+			_ = os.Remove(path)
+		}
+		listener, err := net.Listen("unix", path)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to listen on %s: %w", path, err)
+		}
+		if err := os.Chmod(path, 0600); err != nil {
+			listener.Close()
+			return nil, "", fmt.Errorf("failed to restrict %s: %w", path, err)
+		}
+		return listener, path, nil
+	}
+
+	host, _, err := net.SplitHostPort(serveAddr)
+	if err != nil {
+		return nil, "", validationErrorf("invalid --addr %q: %v", serveAddr, err)
+	}
+	if !loopbackHost(host) {
+		return nil, "", validationErrorf("--addr must be a localhost address, not %s: the API is for this machine only", host)
+	}
+	listener, err := net.Listen("tcp", serveAddr)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to listen on %s: %w", serveAddr, err)
+	}
+	return listener, "http://" + listener.Addr().String(), nil
+}
+
+// loopbackHost reports whether host names this machine: localhost or a loopback IP
+func loopbackHost(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// apiServer serves the jd API. Requests are handled one at a time, as the
+// commands they share code with keep state in package variables.
+type apiServer struct {
+	mu    sync.Mutex
+	write bool   // Install and uninstall are enabled
+	token string // Token write requests must send
+	tcp   bool   // Listening on TCP, where the Host header is checked
+}
+
+// setupToken sets the token write requests must send: --token, the
+// environment, or a generated one written to the data directory. Returns the
+// file written, if any.
+func (s *apiServer) setupToken() (string, error) {
+	s.token = serveToken
+	if s.token == "" {
+		s.token = os.Getenv(serveTokenEnv)
+	}
+	if s.token != "" {
+		return "", nil
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate a token: %w", err)
+	}
+	s.token = hex.EncodeToString(b)
+
+	dataDir, err := basedir.Expand(basedir.DataDir())
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create data directory: %w", err)
+	}
+	path := filepath.Join(dataDir, serveTokenFile)
+	if err := os.WriteFile(path, []byte(s.token+"\n"), 0600); err != nil {
+		return "", fmt.Errorf("failed to write the token: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Write requests need the token in %s\n", path)
+	return path, nil
+}
+
+// routes returns the handler of the API
+func (s *apiServer) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/health", s.handleHealth)
+	mux.HandleFunc("GET /v1/resources", s.handleResources)
+	mux.HandleFunc("GET /v1/search", s.handleSearch)
+	mux.HandleFunc("GET /v1/packages", s.handlePackages)
+	mux.HandleFunc("GET /v1/repos", s.handleRepos)
+	mux.HandleFunc("GET /v1/repos/{namespace}/packages", s.handleRepoPackages)
+	mux.HandleFunc("GET /v1/events", s.handleEvents)
+	mux.HandleFunc("POST /v1/packages", s.writes(s.handleInstall))
+	mux.HandleFunc("DELETE /v1/packages/{name}", s.writes(s.handleUninstall))
+	mux.HandleFunc("/", func(w http.ResponseWriter, _ *http.Request) {
+		writeAPIError(w, http.StatusNotFound, errors.New("no such endpoint (see 'jd serve --help')"))
+	})
+	return s.guard(mux)
+}
+
+// guard refuses requests to the TCP listener whose Host is not localhost, and
+// handles requests one at a time in the project they name
+func (s *apiServer) guard(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.tcp {
+			host, _, err := net.SplitHostPort(r.Host)
+			if err != nil {
+				host = r.Host
+			}
+			if !loopbackHost(strings.Trim(host, "[]")) {
+				writeAPIError(w, http.StatusForbidden, fmt.Errorf("host %s is not allowed", r.Host))
+				return
+			}
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		restore, err := useProject(r.URL.Query().Get("project"))
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, err)
+			return
+		}
+		defer restore()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// writes guards an endpoint that changes packages: it needs --write and the token
+func (s *apiServer) writes(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.write {
+			writeAPIError(w, http.StatusForbidden, errors.New("writes are disabled; start the server with 'jd serve --write'"))
+			return
+		}
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			writeAPIError(w, http.StatusUnauthorized, errors.New("missing or wrong token (Authorization: Bearer <token>)"))
+			return
+		}
+		next(w, r)
+	}
+}
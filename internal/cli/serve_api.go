@@ -0,0 +1,380 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/events"
+	"github.com/itda-skills/jindo/internal/pkg/pkgmgr"
+	"github.com/itda-skills/jindo/internal/pkg/repo"
+	"github.com/itda-skills/jindo/internal/sorting"
+	"github.com/itda-skills/jindo/pkg/config"
+)
+
+// serveDefaultEventLimit is how many events /v1/events returns by default
+const serveDefaultEventLimit = 100
+
+// installRequest is the body of POST /v1/packages
+type installRequest struct {
+	Spec         string `json:"spec"`                    // namespace:path[@version]
+	Scope        string `json:"scope,omitempty"`         // global (default) or local
+	RegisterHook bool   `json:"register_hook,omitempty"` // Add the rule a hook package declares to settings.json
+}
+
+// useProject makes project the project whose .claude is the local scope, until
+// the returned function restores the previous one. An empty project keeps it.
+func useProject(project string) (func(), error) {
+	if project == "" {
+		return func() {}, nil
+	}
+	root, err := basedir.Expand(project)
+	if err != nil {
+		return nil, err
+	}
+	if !filepath.IsAbs(root) {
+		return nil, fmt.Errorf("project must be an absolute path: %s", project)
+	}
+
+	prevRoot, prevConfig := projectRootOverride, config.LocalPath()
+	projectRootOverride = root
+	config.SetLocalPath(filepath.Join(root, localClaudeDir, config.LocalConfigName))
+	return func() {
+		projectRootOverride = prevRoot
+		config.SetLocalPath(prevConfig)
+	}, nil
+}
+
+func (s *apiServer) handleHealth(w http.ResponseWriter, _ *http.Request) {
+	writeAPIJSON(w, http.StatusOK, map[string]any{"version": Version, "write": s.write})
+}
+
+func (s *apiServer) handleResources(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	types, err := parseListTypes(splitQuery(q.Get("type")))
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+	order, err := sorting.ParseOrder(q.Get("sort"))
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+	scope := q.Get("scope")
+	sections, err := loadScopeSections(scope, false)
+	if err != nil {
+		writeAPIErrorFor(w, err)
+		return
+	}
+	for i := range sections {
+		items := &sections[i].items
+		if tag := q.Get("tag"); tag != "" {
+			items.filterByTag(tag)
+		}
+		if types != nil {
+			items.filterByType(types)
+		}
+		if text := q.Get("q"); text != "" {
+			items.filterBySearch(text)
+		}
+		items.order(order)
+	}
+	writeAPIJSON(w, http.StatusOK, listJSONOutput(sections, scope))
+}
+
+func (s *apiServer) handleSearch(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	query := strings.ToLower(strings.TrimSpace(q.Get("q")))
+	if query == "" {
+		writeAPIError(w, http.StatusBadRequest, errors.New("missing query: ?q=<query>"))
+		return
+	}
+
+	sq := searchQuery{text: query, nameOnly: q.Get("name_only") == "true"}
+	for _, t := range splitQuery(q.Get("type")) {
+		switch strings.TrimSuffix(strings.ToLower(t), "s") {
+		case "skill":
+			sq.skills = true
+		case "command":
+			sq.commands = true
+		case "agent":
+			sq.agents = true
+		default:
+			writeAPIError(w, http.StatusBadRequest, fmt.Errorf("invalid type: %s (use: skill, command, agent)", t))
+			return
+		}
+	}
+	limit, err := queryInt(q.Get("limit"), 0)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+	sq.limit = limit
+
+	// Results keep the names other endpoints and commands take
+	results := []SearchResult{}
+	err = searchResources(r.Context(), sq, func(result SearchResult) {
+		results = append(results, result)
+	})
+	if err != nil {
+		writeAPIErrorFor(w, err)
+		return
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].order < results[j].order })
+	writeAPIJSON(w, http.StatusOK, results)
+}
+
+func (s *apiServer) handlePackages(w http.ResponseWriter, r *http.Request) {
+	order, err := sorting.ParseOrder(r.URL.Query().Get("sort"))
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+	packages, err := pkgmgr.NewManager(basedir.DataDir()).List()
+	if err != nil {
+		writeAPIErrorFor(w, fmt.Errorf("failed to list packages: %w", err))
+		return
+	}
+	if packages == nil {
+		packages = []pkgmgr.InstalledPackage{}
+	}
+	sortInstalledPackages(packages, order)
+	writeAPIJSON(w, http.StatusOK, packages)
+}
+
+func (s *apiServer) handleRepos(w http.ResponseWriter, _ *http.Request) {
+	repos, err := repo.NewStore(basedir.DataDir()).List()
+	if err != nil {
+		writeAPIErrorFor(w, fmt.Errorf("failed to list repositories: %w", err))
+		return
+	}
+	if repos == nil {
+		repos = []repo.RepoConfig{}
+	}
+	writeAPIJSON(w, http.StatusOK, repos)
+}
+
+func (s *apiServer) handleRepoPackages(w http.ResponseWriter, r *http.Request) {
+	namespace := r.PathValue("namespace")
+	typeFilter := repo.PackageType(strings.TrimSuffix(strings.ToLower(r.URL.Query().Get("type")), "s"))
+	if typeFilter != "" && !slices.Contains(repo.PackageTypes, typeFilter) {
+		writeAPIError(w, http.StatusBadRequest, fmt.Errorf("invalid type: %s (use: skill, command, agent, hook)", typeFilter))
+		return
+	}
+
+	store := repo.NewStore(basedir.DataDir())
+	if _, err := store.Get(namespace); err != nil {
+		writeAPIError(w, http.StatusNotFound, fmt.Errorf("repository '%s' not found", namespace))
+		return
+	}
+	items, err := store.Browse(namespace, typeFilter)
+	if err != nil {
+		writeAPIErrorFor(w, fmt.Errorf("failed to list packages of %s: %w", namespace, err))
+		return
+	}
+	if items == nil {
+		items = []repo.BrowseItem{}
+	}
+	writeAPIJSON(w, http.StatusOK, items)
+}
+
+func (s *apiServer) handleEvents(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	types := splitQuery(q.Get("type"))
+	for _, t := range types {
+		if !slices.Contains(events.Types, events.Type(t)) {
+			writeAPIError(w, http.StatusBadRequest, fmt.Errorf("invalid event type: %s (use: %s)", t, strings.Join(eventTypeNames(), ", ")))
+			return
+		}
+	}
+	limit, err := queryInt(q.Get("limit"), serveDefaultEventLimit)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	dataDir, err := basedir.Expand(basedir.DataDir())
+	if err != nil {
+		writeAPIErrorFor(w, err)
+		return
+	}
+	recorded, _, err := events.NewLog(dataDir).Read()
+	if err != nil {
+		writeAPIErrorFor(w, fmt.Errorf("failed to read events: %w", err))
+		return
+	}
+	recorded = slices.DeleteFunc(recorded, func(e events.Event) bool {
+		return len(types) > 0 && !slices.Contains(types, string(e.Type))
+	})
+	if limit > 0 && len(recorded) > limit {
+		recorded = recorded[len(recorded)-limit:]
+	}
+	if recorded == nil {
+		recorded = []events.Event{}
+	}
+	writeAPIJSON(w, http.StatusOK, recorded)
+}
+
+func (s *apiServer) handleInstall(w http.ResponseWriter, r *http.Request) {
+	var req installRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeAPIError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	spec, err := pkgmgr.ParseSpec(req.Spec)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, errors.New("invalid spec. Format: namespace:path[@version]"))
+		return
+	}
+	if spec.Path == installAllPath {
+		writeAPIError(w, http.StatusBadRequest, fmt.Errorf("install the packages of a repository one at a time, or with 'jd pkg install %s:%s'", spec.Namespace, installAllPath))
+		return
+	}
+	if req.Scope != "" && req.Scope != string(ScopeGlobal) && req.Scope != string(ScopeLocal) {
+		writeAPIError(w, http.StatusBadRequest, fmt.Errorf("invalid scope: %s (use: global, local)", req.Scope))
+		return
+	}
+
+	manager := pkgmgr.NewManager(basedir.DataDir())
+	enableAutoSnapshot(manager)
+	if err := applyPackageNaming(manager); err != nil {
+		writeAPIErrorFor(w, err)
+		return
+	}
+	// Unlike jd pkg install, a selected project does not make the install local
+	scope := ScopeGlobal
+	if req.Scope == string(ScopeLocal) {
+		if scope, err = setInstallScope(manager, true); err != nil {
+			writeAPIErrorFor(w, err)
+			return
+		}
+	}
+
+	repoConfig, err := manager.RepoStore().Get(spec.Namespace)
+	if err != nil {
+		writeAPIError(w, http.StatusNotFound, fmt.Errorf("repository '%s' not found. Register with: jd pkg repo add gh:owner/repo", spec.Namespace))
+		return
+	}
+	if err := manager.RepoStore().Check(spec.Namespace, false); err != nil {
+		writeAPIErrorFor(w, brokenCloneError(spec.Namespace, err))
+		return
+	}
+	if !repoConfig.Trusted {
+		pkgType, err := manager.SpecType(req.Spec)
+		if err != nil {
+			writeAPIErrorFor(w, err)
+			return
+		}
+		if pkgType.RunsCommands() {
+			writeAPIError(w, http.StatusForbidden, fmt.Errorf("'%s' is not a trusted repository, and a %s can run shell commands. Install it with 'jd pkg install %s', or trust the repository with 'jd pkg repo trust %s'", spec.Namespace, pkgType, req.Spec, spec.Namespace))
+			return
+		}
+	}
+
+	pkg, err := manager.Install(req.Spec)
+	switch {
+	case errors.Is(err, pkgmgr.ErrPackageAlreadyInstalled):
+		writeAPIError(w, http.StatusConflict, fmt.Errorf("package already installed. Use 'jd pkg update %s' to update", req.Spec))
+		return
+	case errors.Is(err, pkgmgr.ErrNameCollision):
+		writeAPIError(w, http.StatusConflict, err)
+		return
+	case err != nil:
+		writeAPIErrorFor(w, fmt.Errorf("install: %w", err))
+		return
+	}
+	syncPackageSkillReference(pkg, true)
+	syncIndex()
+
+	if req.RegisterHook && pkg.Type == repo.TypeHook {
+		registered, err := manager.RegisterHook(pkg.Name, GetSettingsPathByScope(scope))
+		if errors.Is(err, pkgmgr.ErrNoHookMetadata) {
+			writeAPIError(w, http.StatusUnprocessableEntity, fmt.Errorf("installed %s, but it declares no hook rule to register", pkg.Name))
+			return
+		}
+		if err != nil {
+			writeAPIErrorFor(w, fmt.Errorf("installed %s, but failed to register its hook: %w", pkg.Name, err))
+			return
+		}
+		if registered != nil {
+			pkg = registered
+		}
+	}
+	writeAPIJSON(w, http.StatusCreated, pkg)
+}
+
+func (s *apiServer) handleUninstall(w http.ResponseWriter, r *http.Request) {
+	manager := pkgmgr.NewManager(basedir.DataDir())
+	pkg, err := manager.Get(r.PathValue("name"))
+	if err != nil {
+		writeAPIError(w, http.StatusNotFound, fmt.Errorf("package '%s' not found", r.PathValue("name")))
+		return
+	}
+	if err := uninstallPackages(manager, []pkgmgr.InstalledPackage{*pkg}, false, true); err != nil {
+		writeAPIErrorFor(w, err)
+		return
+	}
+	syncIndex()
+	writeAPIJSON(w, http.StatusOK, pkg)
+}
+
+// splitQuery splits a comma-separated query parameter, dropping empty values
+func splitQuery(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// queryInt parses a non-negative integer query parameter, or returns def if it is empty
+func queryInt(value string, def int) (int, error) {
+	if value == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid number: %s", value)
+	}
+	return n, nil
+}
+
+// writeAPIJSON writes a JSON response
+func writeAPIJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
+
+// writeAPIError writes an error response: {"error": "..."}
+func writeAPIError(w http.ResponseWriter, status int, err error) {
+	writeAPIJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+// writeAPIErrorFor writes an error response with the status matching the exit
+// code jd would exit with: 404 for not found, 400 for invalid input, 502 for
+// network failures, and 500 otherwise
+func writeAPIErrorFor(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch ExitCode(err) {
+	case ExitNotFound:
+		status = http.StatusNotFound
+	case ExitUsage, ExitValidation:
+		status = http.StatusBadRequest
+	case ExitNetwork:
+		status = http.StatusBadGateway
+	}
+	writeAPIError(w, status, err)
+}
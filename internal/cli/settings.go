@@ -0,0 +1,16 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var settingsCmd = &cobra.Command{
+	Use:   "settings",
+	Short: "Inspect Claude Code settings.json files and restore backups",
+	Long: `Inspect Claude Code settings in ~/.claude/settings.json (global)
+and .claude/settings.json (local), and restore the backups jd keeps of them.`,
+}
+
+func init() {
+	rootCmd.AddCommand(settingsCmd)
+}
@@ -0,0 +1,156 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/settings"
+	"github.com/itda-skills/jindo/internal/table"
+	"github.com/spf13/cobra"
+)
+
+var (
+	settingsRestoreGlobal bool
+	settingsRestoreLocal  bool
+	settingsRestoreList   bool
+	settingsRestoreYes    bool
+)
+
+var settingsRestoreCmd = &cobra.Command{
+	Use:   "restore [backup]",
+	Short: "Roll settings.json back to an automatic backup",
+	Long: `Restore settings.json from one of the backups jd writes before it changes the
+file (adding, editing, or removing hooks, installing hook packages): the newest
+one, or the one named (as listed by --list). The current content is backed up
+first, so a restore can be undone by restoring that backup.
+
+Backups are kept in backups/settings/ next to settings.json. The newest
+` + settings.KeepKey + ` (default 10; 0 turns backups off) are kept per file.
+jd writes settings.json to a temporary file and renames it into place, so a
+crash never leaves a partly written file.
+
+Default scope is local if a .claude directory exists in the current or a parent directory (the nearest one is used), otherwise global.
+Use --global or --local to override.`,
+	Example: `  jd settings restore --list
+  jd settings restore
+  jd settings restore settings.json.20260122-153045.123456.bak
+  jd settings restore --global -y`,
+	Args:              cobra.MaximumNArgs(1),
+	RunE:              runSettingsRestore,
+	ValidArgsFunction: settingsBackupCompletion,
+}
+
+func init() {
+	settingsCmd.AddCommand(settingsRestoreCmd)
+	settingsRestoreCmd.Flags().BoolVarP(&settingsRestoreGlobal, "global", "g", false, "Restore global ~/.claude/settings.json")
+	settingsRestoreCmd.Flags().BoolVarP(&settingsRestoreLocal, "local", "l", false, "Restore local .claude/settings.json")
+	settingsRestoreCmd.Flags().BoolVar(&settingsRestoreList, "list", false, "List the backups instead of restoring one")
+	settingsRestoreCmd.Flags().BoolVarP(&settingsRestoreYes, "yes", "y", false, "Restore without asking")
+}
+
+func runSettingsRestore(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	scope, err := ResolveScope(settingsRestoreGlobal, settingsRestoreLocal)
+	if err != nil {
+		return err
+	}
+	path, err := basedir.Expand(GetSettingsPathByScope(scope))
+	if err != nil {
+		return fmt.Errorf("failed to find settings.json: %w", err)
+	}
+	backups, err := settings.Backups(path)
+	if err != nil {
+		return fmt.Errorf("failed to list backups: %w", err)
+	}
+	if len(backups) == 0 {
+		return notFoundErrorf("no backups of %s in %s", path, settings.BackupDir(path))
+	}
+
+	if settingsRestoreList {
+		t := newTable(table.Column{Header: "BACKUP"}, table.Column{Header: "WRITTEN"}, table.Column{Header: "SIZE"})
+		for _, b := range backups {
+			info, err := os.Stat(b.Path)
+			if err != nil {
+				continue
+			}
+			t.AddRow(filepath.Base(b.Path), b.Written.Format("2006-01-02 15:04:05"), formatBytes(info.Size()))
+		}
+		fmt.Printf("Backups of %s (newest first):\n\n", path)
+		t.Render(os.Stdout)
+		return nil
+	}
+
+	backup := backups[0].Path
+	if len(args) > 0 {
+		backup = ""
+		for _, b := range backups {
+			if args[0] == filepath.Base(b.Path) || filepath.Clean(args[0]) == b.Path {
+				backup = b.Path
+			}
+		}
+		if backup == "" {
+			return notFoundErrorf("backup not found: %s (see 'jd settings restore --list')", args[0])
+		}
+	}
+
+	content, err := os.ReadFile(backup)
+	if err != nil {
+		return fmt.Errorf("failed to read backup: %w", err)
+	}
+	current, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read settings.json: %w", err)
+	}
+	if err == nil && string(current) == string(content) {
+		fmt.Printf("%s already matches %s.\n", path, filepath.Base(backup))
+		return nil
+	}
+	if !json.Valid(content) {
+		fmt.Fprintf(os.Stderr, "Warning: %s is not valid JSON; check it with 'jd settings validate' after restoring\n", filepath.Base(backup))
+	}
+
+	if !settingsRestoreYes {
+		fmt.Printf("Replace %s with %s? (y/N): ", path, filepath.Base(backup))
+		reader := bufio.NewReader(os.Stdin)
+		response, _ := reader.ReadString('\n')
+		response = strings.TrimSpace(strings.ToLower(response))
+		if response != "y" && response != "yes" {
+			fmt.Println("Cancelled.")
+			return errCancelled
+		}
+	}
+
+	// settings.Write backs up the content being replaced, so the restore can be undone
+	if err := settings.Write(path, content); err != nil {
+		return fmt.Errorf("failed to restore settings.json: %w", err)
+	}
+	fmt.Printf("✅ Restored %s from %s\n", path, filepath.Base(backup))
+	return nil
+}
+
+// settingsBackupCompletion completes the backups of the settings.json picked by the flags given so far
+func settingsBackupCompletion(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	scope, err := ResolveScope(settingsRestoreGlobal, settingsRestoreLocal)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	path, err := basedir.Expand(GetSettingsPathByScope(scope))
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	backups, _ := settings.Backups(path)
+	names := make([]string, 0, len(backups))
+	for _, b := range backups {
+		names = append(names, filepath.Base(b.Path))
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
+}
@@ -0,0 +1,168 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/itda-skills/jindo/internal/settings"
+	"github.com/spf13/cobra"
+)
+
+var (
+	settingsValidateGlobal bool
+	settingsValidateLocal  bool
+	settingsValidateJSON   bool
+)
+
+var settingsValidateCmd = &cobra.Command{
+	Use:   "validate [file...]",
+	Short: "Check settings.json against the known Claude Code settings",
+	Long: `Validate ~/.claude/settings.json and .claude/settings.json against a bundled
+JSON Schema of the known Claude Code settings.
+
+Reports, with the JSON path of each problem:
+- Malformed JSON (with line and column)
+- Type errors and invalid values (e.g., "permissions.defaultMode")
+- Malformed hook structures (event names, matchers, hook commands)
+- Unknown keys, as warnings, since Claude Code may add settings jd does not know yet
+
+Both scopes are checked by default; use --global or --local to check one,
+or pass settings files explicitly.`,
+	Example: `  jd settings validate
+  jd settings validate -l
+  jd settings validate .claude/settings.local.json
+  jd settings validate --json`,
+	RunE: runSettingsValidate,
+}
+
+func init() {
+	settingsCmd.AddCommand(settingsValidateCmd)
+	settingsValidateCmd.Flags().BoolVarP(&settingsValidateGlobal, "global", "g", false, "Validate only ~/.claude/settings.json")
+	settingsValidateCmd.Flags().BoolVarP(&settingsValidateLocal, "local", "l", false, "Validate only .claude/settings.json")
+	settingsValidateCmd.Flags().BoolVar(&settingsValidateJSON, "json", false, "Output in JSON format")
+	settingsValidateCmd.MarkFlagsMutuallyExclusive("global", "local")
+}
+
+// settingsFileResult is the validation result of one settings file
+type settingsFileResult struct {
+	Path   string           `json:"path"`
+	Issues []settings.Issue `json:"issues"`
+}
+
+func runSettingsValidate(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	paths, err := settingsFilesToValidate(args)
+	if err != nil {
+		return err
+	}
+
+	var results []settingsFileResult
+	errorCount, warningCount := 0, 0
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) && len(args) == 0 {
+				continue
+			}
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		issues, err := settings.Validate(content)
+		if err != nil {
+			return fmt.Errorf("failed to validate %s: %w", path, err)
+		}
+		if issues == nil {
+			issues = []settings.Issue{}
+		}
+		for _, issue := range issues {
+			if issue.Severity == settings.SeverityError {
+				errorCount++
+			} else {
+				warningCount++
+			}
+		}
+		results = append(results, settingsFileResult{Path: path, Issues: issues})
+	}
+
+	if settingsValidateJSON {
+		if results == nil {
+			results = []settingsFileResult{}
+		}
+		output, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(output))
+	} else {
+		printSettingsResults(results, errorCount, warningCount)
+	}
+
+	if errorCount > 0 {
+		return validationErrorf("validation failed with %d error(s)", errorCount)
+	}
+	return nil
+}
+
+// settingsFilesToValidate returns the explicit files, or the settings.json of the selected scopes
+func settingsFilesToValidate(args []string) ([]string, error) {
+	if len(args) > 0 {
+		return args, nil
+	}
+
+	var scopes []PathScope
+	switch {
+	case settingsValidateGlobal:
+		scopes = []PathScope{ScopeGlobal}
+	case settingsValidateLocal:
+		if !LocalClaudeDirExists() {
+			return nil, fmt.Errorf("no local .claude directory found")
+		}
+		scopes = []PathScope{ScopeLocal}
+	default:
+		scopes = []PathScope{ScopeGlobal}
+		if LocalClaudeDirExists() {
+			scopes = append(scopes, ScopeLocal)
+		}
+	}
+
+	var paths []string
+	seen := make(map[string]bool)
+	for _, scope := range scopes {
+		path, err := expandScopeDir(GetSettingsPathByScope(scope))
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve settings path: %w", err)
+		}
+		// Inside the home directory both scopes may point at ~/.claude
+		if !seen[path] {
+			seen[path] = true
+			paths = append(paths, path)
+		}
+	}
+	return paths, nil
+}
+
+func printSettingsResults(results []settingsFileResult, errorCount, warningCount int) {
+	if len(results) == 0 {
+		fmt.Println("No settings.json files found.")
+		return
+	}
+
+	for _, r := range results {
+		fmt.Println(r.Path)
+		if len(r.Issues) == 0 {
+			fmt.Println("  ✓ valid")
+		}
+		for _, issue := range r.Issues {
+			label := "[ERROR]"
+			if issue.Severity == settings.SeverityWarning {
+				label = "[WARN] "
+			}
+			fmt.Printf("  %s %s: %s\n", label, issue.Path, issue.Message)
+		}
+		fmt.Println()
+	}
+
+	fmt.Printf("Checked %d file(s): %d error(s), %d warning(s)\n", len(results), errorCount, warningCount)
+}
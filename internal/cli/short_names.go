@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/pkg/pkgmgr"
+	"github.com/itda-skills/jindo/internal/pkg/repo"
+	"github.com/itda-skills/jindo/pkg/config"
+)
+
+// pkgNamingFullNamesKey turns off short names, listing installed packages
+// under the namespaced names they are installed under
+const pkgNamingFullNamesKey = "pkg.naming.full_names"
+
+// listColorDim shows the namespace after a short name
+const listColorDim = "\x1b[2m"
+
+// shortNames are the short names listings show installed packages under: the
+// original name with the namespace after it (web-fetch (affa-ever) instead of
+// affa-ever--web-fetch). A nil shortNames lists every resource by its name.
+type shortNames map[repo.PackageType]map[string]pkgmgr.ShortName
+
+// loadShortNames returns the short names of installed packages for a listing
+// of the resources in listed (names by type), or nil if pkg.naming.full_names
+// is set or the packages cannot be read
+func loadShortNames(listed map[repo.PackageType][]string) shortNames {
+	if cfg, err := config.Load(); err == nil && cfg.GetBool(pkgNamingFullNamesKey, false) {
+		return nil
+	}
+	packages, err := pkgmgr.NewManager(basedir.DataDir()).List()
+	if err != nil {
+		return nil
+	}
+	return pkgmgr.ShortNames(packages, listed)
+}
+
+// get returns the short name of a resource, if it is an installed package that has one
+func (n shortNames) get(kind repo.PackageType, name string) (pkgmgr.ShortName, bool) {
+	short, ok := n[kind][name]
+	return short, ok
+}
+
+// label returns the name a resource is listed under: its short name with the
+// namespace in parentheses, or its name
+func (n shortNames) label(kind repo.PackageType, name string) string {
+	if short, ok := n.get(kind, name); ok {
+		return short.Name + " (" + short.Namespace + ")"
+	}
+	return name
+}
+
+// describe returns the description of a resource in shell completion, after its
+// short name if it is an installed package that has one. Completion inserts the
+// name commands take, so the short name is shown only as a description.
+func (n shortNames) describe(kind repo.PackageType, name, description string) string {
+	if _, ok := n.get(kind, name); !ok {
+		return description
+	}
+	if description == "" {
+		return n.label(kind, name)
+	}
+	return n.label(kind, name) + ": " + description
+}
@@ -29,13 +29,21 @@ This command:
 3. Modifies the skill based on the conversation
 4. Saves changes and updates version history
 
-Default scope is local if a .claude directory exists in the current working directory, otherwise global.
-Use --global or --local to override.`,
+Default scope is local if a .claude directory exists in the current or a parent directory (the nearest one is used), otherwise global.
+Use --global or --local to override.
+
+Claude may edit, read, and write files and search with Glob and Grep without
+asking. --allowed-tools, --add-dir, and --permission-mode are passed to claude
+to change that; adapt.allowed_tools, adapt.add_dirs, and adapt.permission_mode
+in the config set their defaults.`,
 	Example: `  # Adapt a global skill
   jd skills adapt my-skill
 
   # Adapt a local skill
-  jd skills adapt my-skill --local`,
+  jd skills adapt my-skill --local
+
+  # Approve every edit, and let Claude read a reference directory
+  jd skills adapt my-skill --allowed-tools Read,Glob,Grep --add-dir ~/notes`,
 	Args:              cobra.ExactArgs(1),
 	RunE:              runSkillsAdapt,
 	ValidArgsFunction: skillNameCompletion,
@@ -45,6 +53,7 @@ func init() {
 	skillsCmd.AddCommand(skillsAdaptCmd)
 	skillsAdaptCmd.Flags().BoolVarP(&skillsAdaptGlobal, "global", "g", false, "Adapt from global ~/.claude/skills/")
 	skillsAdaptCmd.Flags().BoolVarP(&skillsAdaptLocal, "local", "l", false, "Adapt from local .claude/skills/")
+	addAdaptSandboxFlags(skillsAdaptCmd)
 }
 
 func runSkillsAdapt(cmd *cobra.Command, args []string) error {
@@ -56,6 +65,10 @@ func runSkillsAdapt(cmd *cobra.Command, args []string) error {
 	}
 
 	skillID := args[0]
+	sandbox, err := adaptSandboxArgs(cmd, adaptDefaultTools)
+	if err != nil {
+		return err
+	}
 
 	skillsDir := GetPathByScope(scope, "skills")
 	store := skill.NewStore(skillsDir)
@@ -64,7 +77,7 @@ func runSkillsAdapt(cmd *cobra.Command, args []string) error {
 	s, err := store.Get(skillID)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return fmt.Errorf("skill not found in %s: %s", ScopeDescription(scope), skillID)
+			return notFoundErrorf("skill not found in %s: %s", ScopeDescription(scope), skillID)
 		}
 		return fmt.Errorf("failed to get skill: %w", err)
 	}
@@ -118,14 +131,17 @@ func runSkillsAdapt(cmd *cobra.Command, args []string) error {
 
 	// Initial prompt to make Claude start the conversation (passed as positional argument for interactive mode)
 	initialPrompt := fmt.Sprintf("I want to customize the '%s' skill. Please start by asking me about my specific needs and how I'd like to adapt this skill to my workflow.", skillID)
+	run := newAIRun("skills adapt", skillID, firstReplyOutputTokens, systemPrompt.String(), initialPrompt)
+	if err := confirmAICost(run.estimate, aiFirstReplyNote, false); err != nil {
+		// Nothing was adapted: drop the backup
+		_ = historyMgr.DeleteVersion(version.Number)
+		return err
+	}
 
 	// Run claude command with the system prompt and initial message
 	// Note: positional argument (not -p) keeps interactive mode
-	claudeCmd := exec.Command("claude",
-		"--system-prompt", systemPrompt.String(),
-		"--allowedTools", "Edit,Read,Write,Glob,Grep",
-		initialPrompt,
-	)
+	claudeArgs := append([]string{"--system-prompt", systemPrompt.String()}, sandbox...)
+	claudeCmd := exec.Command("claude", append(claudeArgs, initialPrompt)...)
 	claudeCmd.Stdin = os.Stdin
 	claudeCmd.Stdout = os.Stdout
 	claudeCmd.Stderr = os.Stderr
@@ -134,12 +150,14 @@ func runSkillsAdapt(cmd *cobra.Command, args []string) error {
 		// Check if it's just a user exit
 		if exitErr, ok := err.(*exec.ExitError); ok {
 			if exitErr.ExitCode() == 130 { // Ctrl+C
+				recordAIRun(run, "")
 				fmt.Println("\n⚠️  Adaptation cancelled")
 				return nil
 			}
 		}
 		return fmt.Errorf("claude command failed: %w", err)
 	}
+	recordAIRun(run, "")
 
 	// Read the potentially updated content
 	newContent, err := store.GetContent(skillID)
@@ -1,11 +1,9 @@
 package cli
 
 import (
-	"bufio"
 	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
 
 	"github.com/itda-skills/jindo/internal/skill"
 	"github.com/spf13/cobra"
@@ -13,6 +11,7 @@ import (
 
 var (
 	skillsDeleteForce  bool
+	skillsDeleteYes    bool
 	skillsDeleteGlobal bool
 	skillsDeleteLocal  bool
 )
@@ -23,9 +22,19 @@ var skillsDeleteCmd = &cobra.Command{
 	Short:   "Delete a skill",
 	Long: `Delete a skill from ~/.claude/skills/ (global) or .claude/skills/ (local) directory.
 
-This will delete the entire skill folder including all files.
-Use --force to skip the confirmation prompt.
-Default scope is local if a .claude directory exists in the current working directory, otherwise global.
+This removes the skill folder with all its files, after listing the files, and the skills,
+commands, and agents that still mention it, and asking. The files are moved to the trash
+(trash/ in the jd data directory, ~/.itda-skills by default), from where they
+can be restored by hand.
+
+A skill installed by a package is not deleted; uninstall the package with
+'jd pkg uninstall' instead, which also removes its record.
+
+Use --yes to skip the confirmation in scripts. Use --force to skip it, delete
+the files outright instead of moving them to the trash, and delete a skill
+installed by a package anyway.
+
+Default scope is local if a .claude directory exists in the current or a parent directory (the nearest one is used), otherwise global.
 Use --global or --local to override.`,
 	Args:              cobra.ExactArgs(1),
 	RunE:              runSkillsDelete,
@@ -34,7 +43,8 @@ Use --global or --local to override.`,
 
 func init() {
 	skillsCmd.AddCommand(skillsDeleteCmd)
-	skillsDeleteCmd.Flags().BoolVarP(&skillsDeleteForce, "force", "f", false, "Skip confirmation prompt")
+	skillsDeleteCmd.Flags().BoolVarP(&skillsDeleteForce, "force", "f", false, "Skip confirmation and delete outright, even if installed by a package")
+	skillsDeleteCmd.Flags().BoolVarP(&skillsDeleteYes, "yes", "y", false, "Skip confirmation and move the files to the trash")
 	skillsDeleteCmd.Flags().BoolVarP(&skillsDeleteGlobal, "global", "g", false, "Delete from global ~/.claude/skills/")
 	skillsDeleteCmd.Flags().BoolVarP(&skillsDeleteLocal, "local", "l", false, "Delete from local .claude/skills/")
 }
@@ -55,38 +65,16 @@ func runSkillsDelete(cmd *cobra.Command, args []string) error {
 	s, err := store.Get(name)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return fmt.Errorf("skill not found in %s: %s", ScopeDescription(scope), name)
+			return notFoundErrorf("skill not found in %s: %s", ScopeDescription(scope), name)
 		}
 		return fmt.Errorf("failed to get skill: %w", err)
 	}
 
-	// Get the skill directory (parent of SKILL.md)
-	skillDir := filepath.Dir(s.Path)
-
-	// Confirm deletion unless --force
-	if !skillsDeleteForce {
-		fmt.Printf("Delete skill '%s'?\n", name)
-		fmt.Printf("  Path: %s\n", skillDir)
-		fmt.Print("Type 'yes' to confirm: ")
-
-		reader := bufio.NewReader(os.Stdin)
-		response, err := reader.ReadString('\n')
-		if err != nil {
-			return fmt.Errorf("failed to read input: %w", err)
-		}
-
-		response = strings.TrimSpace(strings.ToLower(response))
-		if response != "yes" {
-			fmt.Println("Cancelled.")
-			return nil
-		}
-	}
-
-	// Delete the skill directory
-	if err := os.RemoveAll(skillDir); err != nil {
-		return fmt.Errorf("failed to delete skill: %w", err)
-	}
-
-	fmt.Printf("Deleted skill: %s\n", name)
-	return nil
+	return deleteResource(resourceDeletion{
+		kind:  "skill",
+		name:  name,
+		file:  s.Path,
+		path:  filepath.Dir(s.Path),
+		scope: scope,
+	}, skillsDeleteForce, skillsDeleteYes)
 }
@@ -23,7 +23,7 @@ var skillsEditCmd = &cobra.Command{
 
 By default, uses Claude CLI to interactively edit the skill content.
 Use --editor to open the skill file directly in your editor.
-Default scope is local if a .claude directory exists in the current working directory, otherwise global.
+Default scope is local if a .claude directory exists in the current or a parent directory (the nearest one is used), otherwise global.
 Use --global or --local to override.`,
 	Args:              cobra.ExactArgs(1),
 	RunE:              runSkillsEdit,
@@ -53,11 +53,14 @@ func runSkillsEdit(cmd *cobra.Command, args []string) error {
 	s, err := store.Get(name)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return fmt.Errorf("skill not found in %s: %s", ScopeDescription(scope), name)
+			return notFoundErrorf("skill not found in %s: %s", ScopeDescription(scope), name)
 		}
 		return fmt.Errorf("failed to get skill: %w", err)
 	}
 
+	// Snapshot prior content before it is overwritten (history.auto)
+	autoSnapshotSkill(s.Path)
+
 	// If --editor flag, just open in editor
 	if skillsEditEditor {
 		return openEditor(s.Path)
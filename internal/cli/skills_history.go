@@ -21,7 +21,8 @@ var skillsHistoryCmd = &cobra.Command{
 	Long: `Show the version history of a skill.
 
 Each time a skill is adapted, a new version is saved to .history/.
-Use 'jd skills revert' to restore a previous version.`,
+Use 'jd skills history show' to print a version, 'jd skills history diff' to compare
+versions, and 'jd skills revert' to restore a previous version.`,
 	Example: `  # Show history of a global skill
   jd skills history my-skill
 
@@ -55,7 +56,7 @@ func runSkillsHistory(cmd *cobra.Command, args []string) error {
 	s, err := store.Get(skillID)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return fmt.Errorf("skill not found in %s: %s", ScopeDescription(scope), skillID)
+			return notFoundErrorf("skill not found in %s: %s", ScopeDescription(scope), skillID)
 		}
 		return fmt.Errorf("failed to get skill: %w", err)
 	}
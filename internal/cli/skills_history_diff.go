@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/itda-skills/jindo/internal/skill"
+	"github.com/spf13/cobra"
+)
+
+var (
+	skillsHistoryDiffGlobal bool
+	skillsHistoryDiffLocal  bool
+)
+
+var skillsHistoryDiffCmd = &cobra.Command{
+	Use:   "diff <skill-id> <v1> <v2>",
+	Short: "Compare two versions of a skill",
+	Long: `Show a unified diff between two versions of a skill.
+
+Versions can be a number (e.g., 1, 2), 'latest', or 'current' for the skill file as it is now.`,
+	Example: `  # Compare version 1 and 3
+  jd skills history diff my-skill 1 3
+
+  # Compare the latest saved version with the current file
+  jd skills history diff my-skill latest current`,
+	Args:              cobra.ExactArgs(3),
+	RunE:              runSkillsHistoryDiff,
+	ValidArgsFunction: skillNameCompletion,
+}
+
+func init() {
+	skillsHistoryCmd.AddCommand(skillsHistoryDiffCmd)
+	skillsHistoryDiffCmd.Flags().BoolVarP(&skillsHistoryDiffGlobal, "global", "g", false, "Compare in global ~/.claude/skills/")
+	skillsHistoryDiffCmd.Flags().BoolVarP(&skillsHistoryDiffLocal, "local", "l", false, "Compare in local .claude/skills/")
+}
+
+func runSkillsHistoryDiff(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	skillID := args[0]
+
+	scope, err := ResolveScope(skillsHistoryDiffGlobal, skillsHistoryDiffLocal)
+	if err != nil {
+		return err
+	}
+
+	store := skill.NewStore(GetPathByScope(scope, "skills"))
+
+	s, err := store.Get(skillID)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return notFoundErrorf("skill not found in %s: %s", ScopeDescription(scope), skillID)
+		}
+		return fmt.Errorf("failed to get skill: %w", err)
+	}
+
+	historyMgr := skill.NewHistoryManager(filepath.Dir(s.Path))
+
+	oldContent, oldName, err := skillVersionContent(historyMgr, s.Path, args[1])
+	if err != nil {
+		return err
+	}
+	newContent, newName, err := skillVersionContent(historyMgr, s.Path, args[2])
+	if err != nil {
+		return err
+	}
+
+	diff := unifiedDiff(oldName, newName, oldContent, newContent)
+	if diff == "" {
+		fmt.Printf("No differences between %s and %s\n", oldName, newName)
+		return nil
+	}
+
+	fmt.Print(diff)
+	return nil
+}
+
+// skillVersionContent returns the content and display name of a version argument.
+// 'current' reads the skill file itself.
+func skillVersionContent(historyMgr *skill.HistoryManager, skillPath, arg string) (string, string, error) {
+	if strings.ToLower(arg) == "current" {
+		content, err := os.ReadFile(skillPath)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to read skill: %w", err)
+		}
+		return string(content), "current", nil
+	}
+
+	versionNum, err := skill.ParseVersionArg(arg)
+	if err != nil {
+		return "", "", err
+	}
+
+	if versionNum == -1 {
+		latest, err := historyMgr.GetLatestVersion()
+		if err != nil {
+			return "", "", fmt.Errorf("failed to get latest version: %w", err)
+		}
+		versionNum = latest.Number
+	}
+
+	content, version, err := historyMgr.GetVersion(versionNum)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get version: %w", err)
+	}
+	return content, skill.FormatVersionName(version), nil
+}
@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/itda-skills/jindo/internal/skill"
+	"github.com/spf13/cobra"
+)
+
+var (
+	skillsHistoryShowGlobal bool
+	skillsHistoryShowLocal  bool
+)
+
+var skillsHistoryShowCmd = &cobra.Command{
+	Use:   "show <skill-id> <version>",
+	Short: "Print a saved version of a skill",
+	Long: `Print the content of a specific version from a skill's history.
+
+Version can be a number (e.g., 1, 2) or 'latest'.`,
+	Example: `  # Print version 2
+  jd skills history show my-skill 2`,
+	Args:              cobra.ExactArgs(2),
+	RunE:              runSkillsHistoryShow,
+	ValidArgsFunction: skillNameCompletion,
+}
+
+func init() {
+	skillsHistoryCmd.AddCommand(skillsHistoryShowCmd)
+	skillsHistoryShowCmd.Flags().BoolVarP(&skillsHistoryShowGlobal, "global", "g", false, "Show from global ~/.claude/skills/")
+	skillsHistoryShowCmd.Flags().BoolVarP(&skillsHistoryShowLocal, "local", "l", false, "Show from local .claude/skills/")
+}
+
+func runSkillsHistoryShow(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	skillID := args[0]
+
+	scope, err := ResolveScope(skillsHistoryShowGlobal, skillsHistoryShowLocal)
+	if err != nil {
+		return err
+	}
+
+	store := skill.NewStore(GetPathByScope(scope, "skills"))
+
+	s, err := store.Get(skillID)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return notFoundErrorf("skill not found in %s: %s", ScopeDescription(scope), skillID)
+		}
+		return fmt.Errorf("failed to get skill: %w", err)
+	}
+
+	historyMgr := skill.NewHistoryManager(filepath.Dir(s.Path))
+
+	content, _, err := skillVersionContent(historyMgr, s.Path, args[1])
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(content)
+	return nil
+}
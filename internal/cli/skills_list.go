@@ -3,10 +3,12 @@ package cli
 import (
 	"encoding/json"
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/itda-skills/jindo/internal/skill"
+	"github.com/itda-skills/jindo/internal/table"
 	"github.com/spf13/cobra"
 )
 
@@ -16,19 +18,41 @@ var skillsListCmd = &cobra.Command{
 	Use:     "list",
 	Aliases: []string{"l", "ls"},
 	Short:   "List all skills",
-	Long:    `List all skills from ~/.claude/skills/ and .claude/skills/ directories.`,
-	RunE:    runSkillsList,
+	Long: `List all skills from ~/.claude/skills/ and .claude/skills/ directories.
+
+TOKENS is the estimated size of each SKILL.md, which Claude loads when it uses
+the skill. Skills over ` + tokensSkillWarnKey + ` tokens (default 5000; 0 turns it off) are
+reported: move details into files the skill points to, which Claude reads only
+when needed.`,
+	RunE: runSkillsList,
 }
 
 func init() {
 	skillsCmd.AddCommand(skillsListCmd)
 	skillsListCmd.Flags().BoolVar(&skillsListJSON, "json", false, "Output in JSON format")
+	addOutputFlags(skillsListCmd)
+	addNoTruncFlag(skillsListCmd)
 }
 
 // skillsListOutput represents JSON output for skills list with scope
 type skillsListOutput struct {
-	Global []*skill.Skill `json:"global"`
-	Local  []*skill.Skill `json:"local,omitempty"`
+	Global []skillsListItem `json:"global"`
+	Local  []skillsListItem `json:"local,omitempty"`
+}
+
+// skillsListItem is a skill in the JSON output, with the estimated tokens of its SKILL.md
+type skillsListItem struct {
+	*skill.Skill
+	Tokens int `json:"tokens"`
+}
+
+// skillsListItems returns skills with their estimated tokens
+func skillsListItems(skills []*skill.Skill) []skillsListItem {
+	items := make([]skillsListItem, 0, len(skills))
+	for _, s := range skills {
+		items = append(items, skillsListItem{Skill: s, Tokens: fileTokens(s.Path)})
+	}
+	return items
 }
 
 func runSkillsList(cmd *cobra.Command, _ []string) error {
@@ -48,21 +72,16 @@ func runSkillsList(cmd *cobra.Command, _ []string) error {
 		localSkills, _ = localStore.List()
 	}
 
-	if skillsListJSON {
+	if skillsListJSON || formattedOutput() {
 		output := skillsListOutput{
-			Global: globalSkills,
-			Local:  localSkills,
+			Global: skillsListItems(globalSkills),
+			Local:  skillsListItems(localSkills),
 		}
-		jsonOutput, err := json.MarshalIndent(output, "", "  ")
-		if err != nil {
-			return err
-		}
-		fmt.Println(string(jsonOutput))
-		return nil
+		return printJSON(output)
 	}
 
 	// Print global section
-	fmt.Println("=== Global (~/.claude/skills/) ===")
+	fmt.Printf("=== Global (%s/) ===\n", GetGlobalPath("skills"))
 	if len(globalSkills) == 0 {
 		fmt.Println("No skills found.")
 	} else {
@@ -89,93 +108,44 @@ func printSkillsJSON(skills []*skill.Skill) error {
 }
 
 func printSkillsTable(skills []*skill.Skill) {
-	// Calculate column widths
-	idWidth := len("ID")
-	toolsWidth := len("ALLOWED-TOOLS")
-	descWidth := 50 // Fixed description width for wrapping
-
+	// Favorites get a ★ marker column when any skill in the table is a favorite
+	var ids []string
 	for _, s := range skills {
-		// Use directory name as skill ID (used in commands) - no truncation
-		skillID := filepath.Base(filepath.Dir(s.Path))
-		if len(skillID) > idWidth {
-			idWidth = len(skillID)
-		}
-		tools := strings.Join(s.AllowedTools, ", ")
-		if len(tools) > toolsWidth {
-			toolsWidth = len(tools)
-		}
-	}
-
-	// Cap tools width only
-	if toolsWidth > 30 {
-		toolsWidth = 30
+		ids = append(ids, filepath.Base(filepath.Dir(s.Path)))
 	}
+	markFavorites := anyFavorite(ids...)
 
-	// Print header
-	fmt.Printf("%-*s  %-*s  %-*s\n",
-		idWidth, "ID",
-		descWidth, "DESCRIPTION",
-		toolsWidth, "ALLOWED-TOOLS")
-	fmt.Printf("%s  %s  %s\n",
-		strings.Repeat("-", idWidth),
-		strings.Repeat("-", descWidth),
-		strings.Repeat("-", toolsWidth))
-
-	// Print rows
+	// Tags column is shown only when at least one skill has tags
+	showTags := false
 	for _, s := range skills {
-		// Use directory name as skill ID - full, no truncation
-		skillID := filepath.Base(filepath.Dir(s.Path))
-
-		tools := strings.Join(s.AllowedTools, ", ")
-		if len(tools) > toolsWidth {
-			tools = tools[:toolsWidth-3] + "..."
-		}
-
-		// Wrap description into multiple lines
-		descLines := wrapText(s.Description, descWidth)
-		if len(descLines) == 0 {
-			descLines = []string{""}
-		}
-
-		// Print first line with ID and tools
-		fmt.Printf("%-*s  %-*s  %-*s\n",
-			idWidth, skillID,
-			descWidth, descLines[0],
-			toolsWidth, tools)
-
-		// Print remaining description lines (if any)
-		for i := 1; i < len(descLines); i++ {
-			fmt.Printf("%-*s  %-*s\n",
-				idWidth, "",
-				descWidth, descLines[i])
+		if len(s.Tags) > 0 {
+			showTags = true
 		}
 	}
 
-	fmt.Printf("\nTotal: %d skills\n", len(skills))
-}
-
-// wrapText wraps text to specified width, breaking at word boundaries
-func wrapText(text string, width int) []string {
-	if text == "" {
-		return nil
+	// The ID is typed into other commands, so it is never truncated
+	columns := []table.Column{
+		{Header: favoriteLabel("ID", markFavorites), Fixed: true},
+		{Header: "DESCRIPTION", Max: 50, Min: 20, Wrap: true},
+		{Header: "ALLOWED-TOOLS", Max: 30},
+		{Header: "TOKENS"},
 	}
-
-	var lines []string
-	words := strings.Fields(text)
-	if len(words) == 0 {
-		return nil
+	if showTags {
+		columns = append(columns, table.Column{Header: "TAGS", Max: 25})
 	}
+	t := newTable(columns...)
+	tokens := make([]int, len(skills))
+	for i, s := range skills {
+		tokens[i] = fileTokens(s.Path)
+		t.AddRow(favoriteLabel(ids[i], markFavorites), s.Description, strings.Join(s.AllowedTools, ", "), formatTokens(tokens[i]), formatTags(s.Tags))
+	}
+	t.Render(os.Stdout)
 
-	currentLine := words[0]
-	for _, word := range words[1:] {
-		if len(currentLine)+1+len(word) <= width {
-			currentLine += " " + word
-		} else {
-			lines = append(lines, currentLine)
-			currentLine = word
+	fmt.Printf("\nTotal: %d skills\n", len(skills))
+	_, limit := tokenThresholds()
+	for i := range skills {
+		if overTokens(tokens[i], limit) {
+			printTokenWarning("skill "+ids[i], tokens[i], limit, tokensSkillWarnKey, "")
 		}
 	}
-	lines = append(lines, currentLine)
-
-	return lines
 }
@@ -1,13 +1,23 @@
 package cli
 
 import (
+	"bytes"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"text/template"
+	"time"
 
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/frontmatter"
+	"github.com/itda-skills/jindo/internal/pkg/repo"
+	"github.com/itda-skills/jindo/internal/prompt"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 )
 
 var (
@@ -17,6 +27,8 @@ var (
 	skillsNewTools  string
 	skillsNewGlobal bool
 	skillsNewLocal  bool
+	skillsNewFrom   string
+	skillsNewTmpl   string
 )
 
 var skillsNewCmd = &cobra.Command{
@@ -27,8 +39,25 @@ var skillsNewCmd = &cobra.Command{
 
 By default, uses Claude CLI to interactively generate the skill content.
 Use --no-ai to create a minimal template without AI assistance.
-Default scope is local if a .claude directory exists in the current working directory, otherwise global.
-Use --global or --local to override.`,
+Default scope is local if a .claude directory exists in the current or a parent directory (the nearest one is used), otherwise global.
+Use --global or --local to override.
+
+Use --from to start from an existing document (a file path or an http(s) URL).
+The document becomes the SKILL.md body and Claude writes the frontmatter,
+inferring the description and allowed-tools from it. With --no-ai, the
+frontmatter comes from --description/--tools, the document's own frontmatter,
+or its first heading.
+
+Use --template to start from a template contributed by a registered repository
+(namespace:name, from the repository's templates/skills/<name>/ directory). All
+of the template's files are copied, with the placeholders {{name}}, {{title}},
+{{description}}, {{tools}}, and {{date}} filled in from the skill name and
+--description/--tools. Other placeholders are left for you to complete.`,
+	Example: `  jd skills new deploy-checklist
+  jd skills new deploy-checklist --no-ai -d "Pre-deploy checks" -t "Bash, Read"
+  jd skills new release-notes --from ./docs/release-process.md
+  jd skills new api-style --from https://raw.githubusercontent.com/org/repo/main/STYLE.md
+  jd skills new payments-review --template acme:go-review -d "Review payment service changes"`,
 	Args: cobra.ExactArgs(1),
 	RunE: runSkillsNew,
 }
@@ -41,6 +70,10 @@ func init() {
 	skillsNewCmd.Flags().StringVarP(&skillsNewTools, "tools", "t", "", "Allowed tools, comma-separated (for --no-ai mode)")
 	skillsNewCmd.Flags().BoolVarP(&skillsNewGlobal, "global", "g", false, "Create in global ~/.claude/skills/")
 	skillsNewCmd.Flags().BoolVarP(&skillsNewLocal, "local", "l", false, "Create in local .claude/skills/")
+	skillsNewCmd.Flags().StringVar(&skillsNewFrom, "from", "", "Seed the skill body from a file path or http(s) URL")
+	skillsNewCmd.Flags().StringVar(&skillsNewTmpl, "template", "", "Start from a repository template (namespace:name)")
+	skillsNewCmd.MarkFlagsMutuallyExclusive("from", "template")
+	_ = skillsNewCmd.RegisterFlagCompletionFunc("template", templateCompletion(repo.TypeSkill))
 }
 
 func runSkillsNew(cmd *cobra.Command, args []string) error {
@@ -62,11 +95,11 @@ func runSkillsNew(cmd *cobra.Command, args []string) error {
 		}
 		skillDir = filepath.Join(localPath, name)
 	} else {
-		home, err := os.UserHomeDir()
+		dir, err := basedir.ClaudePath("skills", name)
 		if err != nil {
 			return fmt.Errorf("failed to get home directory: %w", err)
 		}
-		skillDir = filepath.Join(home, ".claude", "skills", name)
+		skillDir = dir
 	}
 	skillFile := filepath.Join(skillDir, "SKILL.md")
 
@@ -75,13 +108,51 @@ func runSkillsNew(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("skill already exists: %s", name)
 	}
 
+	// Find the template or read the seed document before creating anything
+	var tmpl *repo.Template
+	if skillsNewTmpl != "" {
+		tmpl, err = findTemplate(skillsNewTmpl, repo.TypeSkill)
+		if err != nil {
+			return err
+		}
+	}
+	var source string
+	if skillsNewFrom != "" {
+		source, err = readSkillSource(skillsNewFrom)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", skillsNewFrom, err)
+		}
+	}
+
 	// Create skill directory
 	if err := os.MkdirAll(skillDir, 0755); err != nil {
 		return fmt.Errorf("failed to create skill directory: %w", err)
 	}
 
+	if tmpl != nil {
+		tools := skillsNewTools
+		if tools == "" {
+			tools = "Bash, Read, Write, Edit, Glob, Grep"
+		}
+		values := templateValues(name, skillsNewDesc, map[string]string{"tools": tools})
+		unknown, err := instantiateSkillTemplate(tmpl, skillDir, values)
+		if err != nil {
+			_ = os.RemoveAll(skillDir)
+			return fmt.Errorf("failed to create skill from template: %w", err)
+		}
+		fmt.Printf("✅ Created skill: %s\n", skillFile)
+		fmt.Printf("   (from template %s)\n", skillsNewTmpl)
+		warnUnknownPlaceholders(unknown)
+		if skillsNewEdit {
+			return openEditor(skillFile)
+		}
+		return nil
+	}
+
 	var content string
-	if skillsNewNoAI {
+	if skillsNewFrom != "" {
+		content = seedSkill(name, skillsNewFrom, source)
+	} else if skillsNewNoAI {
 		content = generateSkillTemplate(name, skillsNewDesc, skillsNewTools)
 	} else {
 		// Use Claude CLI to generate skill content
@@ -118,12 +189,7 @@ func generateSkillTemplate(name, description, tools string) string {
 		tools = "Bash, Read, Write, Edit, Glob, Grep"
 	}
 
-	return fmt.Sprintf(`---
-name: %s
-description: %s
-allowed-tools: %s
----
-
+	return skillFrontmatter(name, description, tools) + fmt.Sprintf(`
 # %s
 
 ## Overview
@@ -137,7 +203,158 @@ Explain how to use this skill.
 ## Examples
 
 Provide usage examples.
-`, name, description, tools, toTitle(name))
+`, toTitle(name))
+}
+
+// skillFrontmatter returns the SKILL.md frontmatter block, quoting values only when YAML requires it
+func skillFrontmatter(name, description, tools string) string {
+	return fmt.Sprintf("---\nname: %s\ndescription: %s\nallowed-tools: %s\n---\n",
+		yamlScalar(name), yamlScalar(description), yamlScalar(tools))
+}
+
+// yamlScalar formats s as a single-line YAML scalar
+func yamlScalar(s string) string {
+	out, err := yaml.Marshal(s)
+	if err != nil {
+		return s
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// readSkillSource reads a seed document from a local path or an http(s) URL
+func readSkillSource(src string) (string, error) {
+	if !strings.HasPrefix(src, "http://") && !strings.HasPrefix(src, "https://") {
+		content, err := os.ReadFile(src)
+		if err != nil {
+			return "", err
+		}
+		return string(content), nil
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Get(src)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// skillSourceMeta holds the frontmatter fields reused from a seed document or Claude's output
+type skillSourceMeta struct {
+	Description  string           `yaml:"description"`
+	AllowedTools frontmatter.List `yaml:"allowed-tools"` // "Bash, Read" or [Bash, Read]
+}
+
+// tools returns allowed-tools as a comma-separated list
+func (m skillSourceMeta) tools() string {
+	return m.AllowedTools.String()
+}
+
+// splitSkillSource separates optional YAML frontmatter from a seed document's body
+func splitSkillSource(content string) (skillSourceMeta, string) {
+	var meta skillSourceMeta
+	doc, _ := frontmatter.Parse(content, &meta)
+	return meta, strings.TrimSpace(doc.Body)
+}
+
+// seedSkill builds SKILL.md from a seed document, generating its frontmatter
+func seedSkill(name, src, content string) string {
+	meta, body := splitSkillSource(content)
+
+	description, tools := skillsNewDesc, skillsNewTools
+	if !skillsNewNoAI && (description == "" || tools == "") {
+		fmt.Println("🤖 Generating frontmatter with Claude...")
+		generated, err := generateSkillFrontmatterWithClaude(name, src, body)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  Could not generate frontmatter with Claude (%v); using the document instead\n", err)
+		} else {
+			meta = generated
+		}
+	}
+
+	if description == "" {
+		description = meta.Description
+	}
+	if description == "" {
+		description = firstHeading(body)
+	}
+	if tools == "" {
+		tools = meta.tools()
+	}
+	if description == "" {
+		description = "Description of " + name
+	}
+	if tools == "" {
+		tools = "Bash, Read, Write, Edit, Glob, Grep"
+	}
+
+	return skillFrontmatter(name, description, tools) + "\n" + body + "\n"
+}
+
+// firstHeading returns the text of the first markdown heading in content
+func firstHeading(content string) string {
+	for _, line := range strings.Split(content, "\n") {
+		if heading, ok := strings.CutPrefix(strings.TrimSpace(line), "# "); ok {
+			return strings.TrimSpace(heading)
+		}
+	}
+	return ""
+}
+
+// generateSkillFrontmatterWithClaude asks Claude for a description and allowed-tools for a seed document
+func generateSkillFrontmatterWithClaude(name, src, body string) (skillSourceMeta, error) {
+	var meta skillSourceMeta
+
+	promptTemplate, err := prompt.Load("import-skill")
+	if err != nil {
+		return meta, fmt.Errorf("failed to load import prompt: %w", err)
+	}
+	tmpl, err := template.New("import-skill").Parse(promptTemplate)
+	if err != nil {
+		return meta, fmt.Errorf("failed to parse prompt template: %w", err)
+	}
+	var systemPrompt bytes.Buffer
+	err = tmpl.Execute(&systemPrompt, map[string]string{
+		"SkillName": name,
+		"Source":    src,
+		"Content":   body,
+	})
+	if err != nil {
+		return meta, fmt.Errorf("failed to render prompt: %w", err)
+	}
+
+	cmd := exec.Command("claude",
+		"--print",
+		"--system-prompt", systemPrompt.String(),
+		fmt.Sprintf("Write the SKILL.md frontmatter for the '%s' skill.", name),
+	)
+	cmd.Stderr = os.Stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		return meta, err
+	}
+
+	// Tolerate code fences or text around the frontmatter block
+	text := strings.TrimSpace(string(output))
+	if start := strings.Index(text, "---"); start >= 0 {
+		text = text[start:]
+	}
+	meta, _ = splitSkillSource(text)
+	if meta.Description == "" {
+		return meta, fmt.Errorf("no description in Claude's output")
+	}
+	return meta, nil
 }
 
 func generateSkillWithClaude(name string) (string, error) {
@@ -190,6 +407,16 @@ func toTitle(name string) string {
 }
 
 func openEditor(filePath string) error {
+	cmd := editorCommand(filePath)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	return cmd.Run()
+}
+
+// editorCommand returns the command that opens filePath in $EDITOR, $VISUAL, or vi
+func editorCommand(filePath string) *exec.Cmd {
 	editor := os.Getenv("EDITOR")
 	if editor == "" {
 		editor = os.Getenv("VISUAL")
@@ -198,10 +425,5 @@ func openEditor(filePath string) error {
 		editor = "vi"
 	}
 
-	cmd := exec.Command(editor, filePath)
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-
-	return cmd.Run()
+	return exec.Command(editor, filePath)
 }
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/itda-skills/jindo/internal/pkg/repo"
 	"github.com/itda-skills/jindo/internal/skill"
 	"github.com/spf13/cobra"
 )
@@ -22,7 +23,7 @@ var skillsShowCmd = &cobra.Command{
 	Short:   "Show skill details",
 	Long: `Show the full content of a specific skill from ~/.claude/skills/ (global) or .claude/skills/ (local) directory.
 
-Default scope is local if a .claude directory exists in the current working directory, otherwise global.
+Default scope is local if a .claude directory exists in the current or a parent directory (the nearest one is used), otherwise global.
 Use --global or --local to override.`,
 	Args:              cobra.ExactArgs(1),
 	RunE:              runSkillsShow,
@@ -58,7 +59,7 @@ func showSkillBrief(store *skill.Store, name string, scope PathScope) error {
 	s, err := store.Get(name)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return fmt.Errorf("skill not found in %s: %s", ScopeDescription(scope), name)
+			return notFoundErrorf("skill not found in %s: %s", ScopeDescription(scope), name)
 		}
 		return fmt.Errorf("failed to get skill: %w", err)
 	}
@@ -75,7 +76,7 @@ func showSkillFull(store *skill.Store, name string, scope PathScope) error {
 	content, err := store.GetContent(name)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return fmt.Errorf("skill not found in %s: %s", ScopeDescription(scope), name)
+			return notFoundErrorf("skill not found in %s: %s", ScopeDescription(scope), name)
 		}
 		return fmt.Errorf("failed to get skill content: %w", err)
 	}
@@ -103,14 +104,19 @@ func skillNameCompletion(cmd *cobra.Command, args []string, toComplete string) (
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
 
-	var names []string
-	for _, s := range skills {
+	ids := make([]string, len(skills))
+	for i, s := range skills {
 		// Use directory name (the actual ID used for lookup), not frontmatter name
-		dirName := filepath.Base(filepath.Dir(s.Path))
-		if s.Description != "" {
-			names = append(names, fmt.Sprintf("%s\t%s", dirName, s.Description))
+		ids[i] = filepath.Base(filepath.Dir(s.Path))
+	}
+	short := loadShortNames(map[repo.PackageType][]string{repo.TypeSkill: ids})
+
+	var names []string
+	for i, s := range skills {
+		if description := short.describe(repo.TypeSkill, ids[i], s.Description); description != "" {
+			names = append(names, fmt.Sprintf("%s\t%s", ids[i], description))
 		} else {
-			names = append(names, dirName)
+			names = append(names, ids[i])
 		}
 	}
 
@@ -0,0 +1,19 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var skillsTagCmd = &cobra.Command{
+	Use:     "tag",
+	Aliases: []string{"t", "tags"},
+	Short:   "Manage skill tags",
+	Long: `Manage the tags list in a skill's SKILL.md frontmatter.
+
+Tags are stored as 'tags: [deploy, ci]' and can be used to filter
+'jd list --tag <tag>' and are matched by 'jd search'.`,
+}
+
+func init() {
+	skillsCmd.AddCommand(skillsTagCmd)
+}
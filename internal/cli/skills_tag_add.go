@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/itda-skills/jindo/internal/frontmatter"
+	"github.com/itda-skills/jindo/internal/skill"
+	"github.com/spf13/cobra"
+)
+
+var (
+	skillsTagAddGlobal bool
+	skillsTagAddLocal  bool
+)
+
+var skillsTagAddCmd = &cobra.Command{
+	Use:     "add <skill-name> <tag>...",
+	Aliases: []string{"a"},
+	Short:   "Add tags to a skill",
+	Long: `Add one or more tags to a skill's frontmatter.
+
+Tags that are already present are left unchanged.
+Default scope is local if a .claude directory exists in the current or a parent directory (the nearest one is used), otherwise global.
+Use --global or --local to override.`,
+	Example:           `  jd skills tag add web-fetch network http`,
+	Args:              cobra.MinimumNArgs(2),
+	RunE:              runSkillsTagAdd,
+	ValidArgsFunction: skillNameCompletion,
+}
+
+func init() {
+	skillsTagCmd.AddCommand(skillsTagAddCmd)
+	skillsTagAddCmd.Flags().BoolVarP(&skillsTagAddGlobal, "global", "g", false, "Tag skill in global ~/.claude/skills/")
+	skillsTagAddCmd.Flags().BoolVarP(&skillsTagAddLocal, "local", "l", false, "Tag skill in local .claude/skills/")
+}
+
+func runSkillsTagAdd(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	scope, err := ResolveScope(skillsTagAddGlobal, skillsTagAddLocal)
+	if err != nil {
+		return err
+	}
+
+	name := args[0]
+	store := skill.NewStore(GetPathByScope(scope, "skills"))
+
+	s, err := store.Get(name)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return notFoundErrorf("skill not found in %s: %s", ScopeDescription(scope), name)
+		}
+		return fmt.Errorf("failed to get skill: %w", err)
+	}
+
+	tags := s.Tags
+	for _, tag := range args[1:] {
+		if !frontmatter.HasTag(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+
+	updated, err := store.SetTags(name, tags)
+	if err != nil {
+		return fmt.Errorf("failed to update tags: %w", err)
+	}
+
+	fmt.Printf("✅ Tags for %s: %s\n", name, formatTags(updated.Tags))
+	return nil
+}
@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/itda-skills/jindo/internal/frontmatter"
+	"github.com/itda-skills/jindo/internal/skill"
+	"github.com/spf13/cobra"
+)
+
+var (
+	skillsTagRemoveGlobal bool
+	skillsTagRemoveLocal  bool
+)
+
+var skillsTagRemoveCmd = &cobra.Command{
+	Use:     "remove <skill-name> <tag>...",
+	Aliases: []string{"rm", "delete", "d"},
+	Short:   "Remove tags from a skill",
+	Long: `Remove one or more tags from a skill's frontmatter.
+
+The tags entry is dropped from the frontmatter when no tags remain.
+Default scope is local if a .claude directory exists in the current or a parent directory (the nearest one is used), otherwise global.
+Use --global or --local to override.`,
+	Example:           `  jd skills tag remove web-fetch http`,
+	Args:              cobra.MinimumNArgs(2),
+	RunE:              runSkillsTagRemove,
+	ValidArgsFunction: skillNameCompletion,
+}
+
+func init() {
+	skillsTagCmd.AddCommand(skillsTagRemoveCmd)
+	skillsTagRemoveCmd.Flags().BoolVarP(&skillsTagRemoveGlobal, "global", "g", false, "Untag skill in global ~/.claude/skills/")
+	skillsTagRemoveCmd.Flags().BoolVarP(&skillsTagRemoveLocal, "local", "l", false, "Untag skill in local .claude/skills/")
+}
+
+func runSkillsTagRemove(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	scope, err := ResolveScope(skillsTagRemoveGlobal, skillsTagRemoveLocal)
+	if err != nil {
+		return err
+	}
+
+	name := args[0]
+	store := skill.NewStore(GetPathByScope(scope, "skills"))
+
+	s, err := store.Get(name)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return notFoundErrorf("skill not found in %s: %s", ScopeDescription(scope), name)
+		}
+		return fmt.Errorf("failed to get skill: %w", err)
+	}
+
+	var tags []string
+	for _, tag := range s.Tags {
+		if !frontmatter.HasTag(args[1:], tag) {
+			tags = append(tags, tag)
+		}
+	}
+
+	updated, err := store.SetTags(name, tags)
+	if err != nil {
+		return fmt.Errorf("failed to update tags: %w", err)
+	}
+
+	if len(updated.Tags) == 0 {
+		fmt.Printf("✅ Removed all tags from %s\n", name)
+		return nil
+	}
+	fmt.Printf("✅ Tags for %s: %s\n", name, formatTags(updated.Tags))
+	return nil
+}
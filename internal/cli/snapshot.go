@@ -0,0 +1,235 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/itda-skills/jindo/internal/agent"
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/command"
+	"github.com/itda-skills/jindo/internal/hook"
+	"github.com/itda-skills/jindo/internal/pkg/pkgmgr"
+	"github.com/itda-skills/jindo/internal/pkg/repo"
+	"github.com/itda-skills/jindo/internal/skill"
+	"github.com/itda-skills/jindo/internal/snapshot"
+	"github.com/spf13/cobra"
+)
+
+var snapshotCmd = &cobra.Command{
+	Use:     "snapshot",
+	Aliases: []string{"snap"},
+	Short:   "Record the resources of this setup and compare snapshots",
+	Long: `Record the resources of this Claude Code setup in a manifest, and compare two
+manifests, to find out why Claude behaves differently on two machines or
+since some point in time.
+
+A snapshot lists the skills, commands, agents, hooks, settings.json keys, and
+CLAUDE.md of the global scope and of the project's local scope, each with a
+hash of its content and, for installed packages, the version it came from.
+Contents are hashed with the home directory written as ~, so the same file on
+two machines compares equal. A snapshot holds no file contents.`,
+}
+
+func init() {
+	rootCmd.AddCommand(snapshotCmd)
+}
+
+// snapshotIgnoredSettings are settings.json keys left out of snapshots: hooks
+// are recorded as hook resources, and jd's own bookkeeping changes nothing in Claude
+var snapshotIgnoredSettings = map[string]bool{"hooks": true, "hooks_disabled": true, "hooks_checksums": true}
+
+// takeSnapshot records the resources of the global scope and, if there is
+// one, the project's local scope
+func takeSnapshot() (*snapshot.Manifest, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	host, _ := os.Hostname()
+	m := &snapshot.Manifest{
+		Format:    snapshot.FormatVersion,
+		Created:   time.Now().UTC().Truncate(time.Second),
+		Host:      host,
+		JDVersion: Version,
+		Resources: []snapshot.Resource{},
+	}
+
+	packages, err := pkgmgr.NewManager(basedir.DataDir()).List()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to read installed packages: %v\n", err)
+	}
+
+	globalDir, err := basedir.Expand(basedir.ClaudeDir())
+	if err != nil {
+		return nil, err
+	}
+	c := &snapshotCollector{manifest: m, hasher: snapshot.NewHasher(home), packages: packages}
+	c.collect(string(ScopeGlobal), globalDir, "")
+
+	// In the home directory, the local .claude is the global one
+	if LocalClaudeDirExists() {
+		if root, err := ProjectRoot(); err == nil && filepath.Clean(filepath.Join(root, localClaudeDir)) != filepath.Clean(globalDir) {
+			c.collect(string(ScopeLocal), filepath.Join(root, localClaudeDir), root)
+		}
+	}
+	return m, nil
+}
+
+// snapshotCollector adds the resources of scopes to a manifest
+type snapshotCollector struct {
+	manifest *snapshot.Manifest
+	hasher   *snapshot.Hasher
+	packages []pkgmgr.InstalledPackage
+}
+
+// collect adds the resources of the scope in claudeDir; projectRoot is set for
+// a local scope, whose project CLAUDE.md is recorded too
+func (c *snapshotCollector) collect(scope, claudeDir, projectRoot string) {
+	globalDir, _ := basedir.Expand(basedir.ClaudeDir())
+	installed := make(map[string]*pkgmgr.InstalledPackage)
+	for i, pkg := range c.packages {
+		dir := pkg.ClaudeDir
+		if dir == "" {
+			dir = globalDir
+		}
+		if pkg.Target == "" && filepath.Clean(dir) == filepath.Clean(claudeDir) {
+			installed[string(pkg.Type)+"\x00"+pkg.Name] = &c.packages[i]
+		}
+	}
+	add := func(kind, name, hash string, pkg *pkgmgr.InstalledPackage) {
+		r := snapshot.Resource{Scope: scope, Type: kind, Name: name, Hash: hash}
+		if pkg != nil {
+			r.Package = &snapshot.Package{Namespace: pkg.Namespace, SourcePath: pkg.SourcePath, Version: pkg.Version.SHA, Ref: pkg.Version.Ref}
+		}
+		c.manifest.Resources = append(c.manifest.Resources, r)
+	}
+	packageOf := func(kind repo.PackageType, name string) *pkgmgr.InstalledPackage {
+		return installed[string(kind)+"\x00"+name]
+	}
+	warn := func(what string, err error) {
+		fmt.Fprintf(os.Stderr, "Warning: failed to record %s of %s: %v\n", what, claudeDir, err)
+	}
+
+	skills, _ := skill.NewStore(filepath.Join(claudeDir, "skills")).List()
+	for _, s := range skills {
+		hash, err := c.hasher.Dir(filepath.Dir(s.Path))
+		if err != nil {
+			warn("skill "+s.Name, err)
+			continue
+		}
+		add(string(repo.TypeSkill), skillID(s), hash, packageOf(repo.TypeSkill, skillID(s)))
+	}
+	commands, _ := command.NewStore(filepath.Join(claudeDir, "commands")).List()
+	for _, cmd := range commands {
+		hash, err := c.hasher.File(cmd.Path)
+		if err != nil {
+			warn("command "+cmd.Name, err)
+			continue
+		}
+		add(string(repo.TypeCommand), cmd.Name, hash, packageOf(repo.TypeCommand, cmd.Name))
+	}
+	agents, _ := agent.NewStore(filepath.Join(claudeDir, "agents")).List()
+	for _, a := range agents {
+		hash, err := c.hasher.File(a.Path)
+		if err != nil {
+			warn("agent "+a.Name, err)
+			continue
+		}
+		add(string(repo.TypeAgent), a.Name, hash, packageOf(repo.TypeAgent, a.Name))
+	}
+
+	// Hook rules are named by event and matcher, as their list names number
+	// them by position
+	settingsPath := filepath.Join(claudeDir, "settings.json")
+	hooks, _ := hook.NewStore(settingsPath).List()
+	seen := make(map[string]int)
+	for _, h := range hooks {
+		name := string(h.EventType) + " " + h.Matcher
+		if seen[name]++; seen[name] > 1 {
+			name = fmt.Sprintf("%s #%d", name, seen[name])
+		}
+		add(string(repo.TypeHook), name, c.hasher.Bytes([]byte(strings.Join(h.Commands, "\n"))), nil)
+	}
+	for _, pkg := range installed {
+		if pkg.Type != repo.TypeHook {
+			continue
+		}
+		var files []string
+		for _, f := range pkg.Files {
+			files = append(files, f.Target)
+		}
+		hash, err := c.hashFiles(files)
+		if err != nil {
+			warn("hook script "+pkg.Name, err)
+			continue
+		}
+		add("hook-script", pkg.Name, hash, pkg)
+	}
+
+	for _, name := range []string{"settings.json", "settings.local.json"} {
+		if err := c.collectSettings(scope, filepath.Join(claudeDir, name), name); err != nil {
+			warn(name, err)
+		}
+	}
+
+	mdFiles := map[string]string{"CLAUDE.md": filepath.Join(claudeDir, "CLAUDE.md")}
+	if projectRoot != "" {
+		mdFiles = map[string]string{
+			localClaudeDir + "/CLAUDE.md": filepath.Join(claudeDir, "CLAUDE.md"),
+			"CLAUDE.md":                   filepath.Join(projectRoot, "CLAUDE.md"),
+			"CLAUDE.local.md":             filepath.Join(projectRoot, "CLAUDE.local.md"),
+		}
+	}
+	for name, path := range mdFiles {
+		if hash, err := c.hasher.File(path); err == nil {
+			add("claudemd", name, hash, nil)
+		}
+	}
+}
+
+// collectSettings adds each top-level key of a settings file as a setting
+// resource named file:key, so snapshots show which setting differs
+func (c *snapshotCollector) collectSettings(scope, path, file string) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var settings map[string]any
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return err
+	}
+	for key, value := range settings {
+		if snapshotIgnoredSettings[key] {
+			continue
+		}
+		// Encoded again, with sorted keys, so formatting does not count as a change
+		canonical, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		c.manifest.Resources = append(c.manifest.Resources, snapshot.Resource{
+			Scope: scope, Type: "setting", Name: file + ":" + key, Hash: c.hasher.Bytes(canonical),
+		})
+	}
+	return nil
+}
+
+// hashFiles hashes the contents of files, in order
+func (c *snapshotCollector) hashFiles(files []string) (string, error) {
+	var b strings.Builder
+	for _, f := range files {
+		sum, err := c.hasher.File(f)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&b, "%s %s\n", filepath.Base(f), sum)
+	}
+	return c.hasher.Bytes([]byte(b.String())), nil
+}
@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/spf13/cobra"
+)
+
+var snapshotCreateOutput string
+
+var snapshotCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Record the resources of this setup in a snapshot",
+	Long: `Record the skills, commands, agents, hooks, settings, and CLAUDE.md of the
+global scope and the project's local scope, with a hash of each and the package
+version it was installed from. The snapshot is printed, or written to --output.
+
+Examples:
+  jd snapshot create -o laptop.json
+  jd snapshot create > "snapshot-$(date +%F).json"`,
+	Args: cobra.NoArgs,
+	RunE: runSnapshotCreate,
+}
+
+func init() {
+	snapshotCmd.AddCommand(snapshotCreateCmd)
+	snapshotCreateCmd.Flags().StringVarP(&snapshotCreateOutput, "output", "o", "", "Write the snapshot to a file instead of stdout")
+}
+
+func runSnapshotCreate(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+
+	m, err := takeSnapshot()
+	if err != nil {
+		return fmt.Errorf("failed to take snapshot: %w", err)
+	}
+	if snapshotCreateOutput == "" {
+		return m.Write(os.Stdout)
+	}
+
+	path, err := basedir.Expand(snapshotCreateOutput)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := m.Write(&buf); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	fmt.Printf("Wrote snapshot of %d resources to %s\n", len(m.Resources), path)
+	return nil
+}
@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/itda-skills/jindo/internal/snapshot"
+	"github.com/itda-skills/jindo/internal/table"
+	"github.com/spf13/cobra"
+)
+
+var (
+	snapshotDiffJSON     bool
+	snapshotDiffExitCode bool
+)
+
+var snapshotDiffCmd = &cobra.Command{
+	Use:   "diff <a.json> [b.json]",
+	Short: "Show the resources added, removed, or changed between two snapshots",
+	Long: `Compare two snapshots and show the resources added, removed, or changed from
+the first to the second. With one snapshot, it is compared with this setup now.
+
+A changed resource shows the package versions it was installed from, or
+"content differs" when its content changed; "edited since installed" means the
+content differs while the package version is the same.
+
+Examples:
+  jd snapshot diff laptop.json desktop.json
+  jd snapshot diff yesterday.json
+  jd snapshot diff laptop.json desktop.json --json`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runSnapshotDiff,
+}
+
+func init() {
+	snapshotCmd.AddCommand(snapshotDiffCmd)
+	snapshotDiffCmd.Flags().BoolVar(&snapshotDiffJSON, "json", false, "Output in JSON format")
+	snapshotDiffCmd.Flags().BoolVar(&snapshotDiffExitCode, "exit-code", false, "Exit with 1 if the snapshots differ")
+	addOutputFlags(snapshotDiffCmd)
+}
+
+// snapshotDiffOutput is the JSON output of jd snapshot diff
+type snapshotDiffOutput struct {
+	From    snapshotInfo      `json:"from"`
+	To      snapshotInfo      `json:"to"`
+	Changes []snapshot.Change `json:"changes"`
+}
+
+// snapshotInfo describes a compared snapshot
+type snapshotInfo struct {
+	Path      string    `json:"path,omitempty"` // Empty for this setup now
+	Host      string    `json:"host"`
+	Created   time.Time `json:"created"`
+	JDVersion string    `json:"jd_version"`
+}
+
+func runSnapshotDiff(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	from, err := snapshot.Load(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	var to *snapshot.Manifest
+	toPath := ""
+	if len(args) == 2 {
+		toPath = args[1]
+		if to, err = snapshot.Load(toPath); err != nil {
+			return fmt.Errorf("failed to read snapshot: %w", err)
+		}
+	} else if to, err = takeSnapshot(); err != nil {
+		return fmt.Errorf("failed to take snapshot: %w", err)
+	}
+
+	changes := snapshot.Diff(from, to)
+	out := snapshotDiffOutput{
+		From:    describeSnapshot(args[0], from),
+		To:      describeSnapshot(toPath, to),
+		Changes: changes,
+	}
+
+	if snapshotDiffJSON || formattedOutput() {
+		if err := printJSON(out); err != nil {
+			return err
+		}
+	} else {
+		printSnapshotDiff(out)
+	}
+
+	if snapshotDiffExitCode && len(changes) > 0 {
+		return &ExitError{Code: ExitFailure, Err: errors.New("snapshots differ")}
+	}
+	return nil
+}
+
+// describeSnapshot returns the description of a snapshot read from path, or of
+// this setup now if path is empty
+func describeSnapshot(path string, m *snapshot.Manifest) snapshotInfo {
+	return snapshotInfo{Path: path, Host: m.Host, Created: m.Created, JDVersion: m.JDVersion}
+}
+
+// label returns how a compared snapshot is named in the output
+func (s snapshotInfo) label() string {
+	if s.Path == "" {
+		return "now (" + s.Host + ")"
+	}
+	return fmt.Sprintf("%s (%s, %s)", s.Path, s.Host, s.Created.Local().Format("2006-01-02 15:04"))
+}
+
+func printSnapshotDiff(out snapshotDiffOutput) {
+	fmt.Printf("From: %s\n", out.From.label())
+	fmt.Printf("To:   %s\n", out.To.label())
+	if out.From.JDVersion != out.To.JDVersion {
+		fmt.Printf("Note: the snapshots were taken with different jd versions (%s, %s)\n", out.From.JDVersion, out.To.JDVersion)
+	}
+	fmt.Println()
+
+	if len(out.Changes) == 0 {
+		fmt.Println("No differences.")
+		return
+	}
+
+	t := newTable(
+		table.Column{Header: "CHANGE"},
+		table.Column{Header: "SCOPE"},
+		table.Column{Header: "TYPE"},
+		table.Column{Header: "NAME", Max: 40},
+		table.Column{Header: "DETAIL", Min: 10, Wrap: true},
+	)
+	counts := make(map[string]int)
+	for _, c := range out.Changes {
+		counts[c.Kind]++
+		detail := c.Detail
+		if detail == "" {
+			detail = "-"
+		}
+		t.AddRow(c.Kind, c.Scope, c.Type, c.Name, detail)
+	}
+	t.Render(os.Stdout)
+
+	fmt.Printf("\n%d added, %d removed, %d changed\n", counts[snapshot.Added], counts[snapshot.Removed], counts[snapshot.Changed])
+}
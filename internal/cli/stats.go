@@ -0,0 +1,19 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show usage statistics",
+	Long: `Show statistics about how jd is used.
+
+Subcommands:
+  ai      Estimated Claude usage and cost of AI-powered commands, by month
+  tokens  Estimated tokens of CLAUDE.md files and skills, with bloat warnings`,
+}
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+}
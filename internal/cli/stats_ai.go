@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/itda-skills/jindo/internal/aicost"
+	"github.com/itda-skills/jindo/internal/table"
+	"github.com/spf13/cobra"
+)
+
+var (
+	statsAIMonth string
+	statsAIJSON  bool
+)
+
+var statsAICmd = &cobra.Command{
+	Use:   "ai",
+	Short: "Show the estimated Claude usage of AI-powered commands this month",
+	Long: `Show the estimated Claude usage and cost of the AI-powered commands run in a
+month: claudemd tidy, guide, and adapt.
+
+Before they call Claude, these commands estimate the tokens from the length of
+the prompt and content (about 4 characters of English or code, or 1 Hangul or
+CJK character, per token) and print the approximate cost. Finished runs are
+tallied locally in ~/.itda-skills/` + aicost.TallyFile + `. The numbers are estimates for
+budgeting; see your Anthropic console for actual billing. Interactive
+conversations (adapt, guide -i) are counted for their first reply only.
+
+Configuration:
+  ` + aiPriceInputKey + `   USD per million input tokens (default 3)
+  ` + aiPriceOutputKey + `  USD per million output tokens (default 15)
+  ` + aiConfirmAboveKey + `   Ask before a run estimated above this (USD; 0 never asks)
+  ` + aiMonthlyBudgetKey + `         Ask before a run that would take the month past this (USD; 0 never asks)`,
+	Example: `  # This month's usage
+  jd stats ai
+
+  # Another month, as JSON
+  jd stats ai --month 2026-09 --json
+
+  # Ask before runs over $0.50 or past $10 a month
+  jd config set ` + aiConfirmAboveKey + ` 0.5
+  jd config set ` + aiMonthlyBudgetKey + ` 10`,
+	Args: cobra.NoArgs,
+	RunE: runStatsAI,
+}
+
+func init() {
+	statsCmd.AddCommand(statsAICmd)
+	statsAICmd.Flags().StringVarP(&statsAIMonth, "month", "m", "", "Month to show, as YYYY-MM (default: this month)")
+	statsAICmd.Flags().BoolVar(&statsAIJSON, "json", false, "Output in JSON format")
+}
+
+// statsAIReport is the output of jd stats ai
+type statsAIReport struct {
+	Month  string  `json:"month"`
+	Budget float64 `json:"budget_usd,omitempty"`
+	aicost.Summary
+}
+
+func runStatsAI(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+
+	month := statsAIMonth
+	if month == "" {
+		month = time.Now().Format(aicost.MonthFormat)
+	} else if _, err := time.Parse(aicost.MonthFormat, month); err != nil {
+		return validationErrorf("invalid month: %s (use YYYY-MM)", month)
+	}
+
+	tally, err := aiTally()
+	if err != nil {
+		return fmt.Errorf("failed to locate usage tally: %w", err)
+	}
+	usages, err := tally.Month(month)
+	if err != nil {
+		return fmt.Errorf("failed to read usage tally: %w", err)
+	}
+	report := statsAIReport{Month: month, Budget: loadAIBudget().monthly, Summary: aicost.Summarize(usages)}
+
+	if statsAIJSON {
+		output, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(output))
+		return nil
+	}
+
+	if report.Runs == 0 {
+		fmt.Printf("No AI-powered commands recorded in %s.\n", month)
+		return nil
+	}
+
+	fmt.Printf("Estimated Claude usage in %s\n\n", month)
+	tbl := newTable(
+		table.Column{Header: "COMMAND", Fixed: true},
+		table.Column{Header: "RUNS", Fixed: true},
+		table.Column{Header: "INPUT TOKENS", Fixed: true},
+		table.Column{Header: "OUTPUT TOKENS", Fixed: true},
+		table.Column{Header: "COST", Fixed: true},
+	)
+	for _, c := range report.Commands {
+		tbl.AddRow(c.Command, strconv.Itoa(c.Runs), strconv.Itoa(c.InputTokens), strconv.Itoa(c.OutputTokens), aicost.FormatCost(c.Cost))
+	}
+	tbl.AddRow("Total", strconv.Itoa(report.Runs), strconv.Itoa(report.InputTokens), strconv.Itoa(report.OutputTokens), aicost.FormatCost(report.Cost))
+	tbl.Render(os.Stdout)
+
+	if report.Budget > 0 {
+		left := report.Budget - report.Cost
+		if left >= 0 {
+			fmt.Printf("\nMonthly budget: %s (%s left)\n", aicost.FormatCost(report.Budget), aicost.FormatCost(left))
+		} else {
+			fmt.Printf("\nMonthly budget: %s (over by %s)\n", aicost.FormatCost(report.Budget), aicost.FormatCost(-left))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/itda-skills/jindo/internal/aicost"
+	"github.com/itda-skills/jindo/internal/skill"
+	"github.com/itda-skills/jindo/internal/table"
+	"github.com/spf13/cobra"
+)
+
+var statsTokensJSON bool
+
+var statsTokensCmd = &cobra.Command{
+	Use:   "tokens",
+	Short: "Show the estimated tokens of CLAUDE.md files and skills",
+	Long: `Show how much of Claude's context CLAUDE.md files and skills take, as
+estimated tokens (about 4 characters of English or code, or 1 Hangul or CJK
+character, per token), largest first.
+
+Every session starts with the global and project CLAUDE.md and the name and
+description of every skill. A CLAUDE.md in a subdirectory is loaded when
+Claude works there, and a skill's SKILL.md when Claude uses it.
+
+Files over these thresholds are reported (0 turns a warning off):
+  ` + tokensClaudemdWarnKey + `  A CLAUDE.md (default 4000)
+  ` + tokensSkillWarnKey + `     A SKILL.md (default 5000)
+
+'jd claudemd tidy --target-tokens N' has Claude compress a CLAUDE.md to a budget.`,
+	Example: `  jd stats tokens
+  jd stats tokens --json
+  jd config set ` + tokensClaudemdWarnKey + ` 2000`,
+	Args: cobra.NoArgs,
+	RunE: runStatsTokens,
+}
+
+func init() {
+	statsCmd.AddCommand(statsTokensCmd)
+	statsTokensCmd.Flags().BoolVar(&statsTokensJSON, "json", false, "Output in JSON format")
+}
+
+// statsTokensFile is a CLAUDE.md or a skill in the output of jd stats tokens
+type statsTokensFile struct {
+	Kind       string `json:"kind"` // claudemd or skill
+	Scope      string `json:"scope"`
+	Name       string `json:"name"` // Path of a CLAUDE.md, ID of a skill
+	Path       string `json:"path"`
+	Tokens     int    `json:"tokens"`
+	Always     int    `json:"always"` // Tokens loaded in every session: all of a top-level CLAUDE.md, a skill's description
+	OverLimit  bool   `json:"over_limit"`
+	LoadedWhen string `json:"loaded_when"`
+}
+
+// statsTokensReport is the output of jd stats tokens
+type statsTokensReport struct {
+	Files        []statsTokensFile `json:"files"`
+	SessionStart int               `json:"session_start"` // Tokens every session starts with
+	ClaudemdWarn int               `json:"claudemd_warn"`
+	SkillWarn    int               `json:"skill_warn"`
+}
+
+func runStatsTokens(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+
+	claudemdWarn, skillWarn := tokenThresholds()
+	report := statsTokensReport{Files: []statsTokensFile{}, ClaudemdWarn: claudemdWarn, SkillWarn: skillWarn}
+
+	// Nested CLAUDE.md files are listed in a project; the home directory is not searched
+	home, _ := os.UserHomeDir()
+	root, _ := ProjectRoot()
+	inProject := root != "" && filepath.Clean(root) != filepath.Clean(home)
+	_, entries, _, err := collectClaudemdFiles(inProject)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		f := statsTokensFile{Kind: "claudemd", Scope: e.Scope, Name: e.Rel, Path: e.Path, Tokens: e.Tokens, LoadedWhen: "every session"}
+		if dir := filepath.Dir(e.Rel); e.Scope == string(ScopeLocal) && dir != "." && dir != localClaudeDir {
+			f.LoadedWhen = "working in " + dir + "/"
+		} else {
+			f.Always = e.Tokens
+		}
+		f.OverLimit = overTokens(f.Tokens, claudemdWarn)
+		report.Files = append(report.Files, f)
+	}
+
+	type skillsDir struct {
+		scope PathScope
+		dir   string
+	}
+	skillsDirs := []skillsDir{{ScopeGlobal, GetGlobalPath("skills")}}
+	if localPath := GetLocalPath("skills"); localPath != "" {
+		skillsDirs = append(skillsDirs, skillsDir{ScopeLocal, localPath})
+	}
+	for _, sc := range skillsDirs {
+		skills, _ := skill.NewStore(sc.dir).List()
+		for _, s := range skills {
+			tokens := fileTokens(s.Path)
+			report.Files = append(report.Files, statsTokensFile{
+				Kind: "skill", Scope: string(sc.scope), Name: skillID(s), Path: s.Path,
+				Tokens: tokens, Always: aicost.EstimateTokens(s.Name + ": " + s.Description),
+				OverLimit: overTokens(tokens, skillWarn), LoadedWhen: "when used",
+			})
+		}
+	}
+
+	sort.SliceStable(report.Files, func(i, j int) bool {
+		a, b := report.Files[i], report.Files[j]
+		if a.Kind != b.Kind {
+			return a.Kind == "claudemd"
+		}
+		return a.Tokens > b.Tokens
+	})
+	for _, f := range report.Files {
+		report.SessionStart += f.Always
+	}
+
+	if statsTokensJSON {
+		return printJSON(report)
+	}
+	printStatsTokens(report)
+	return nil
+}
+
+// printStatsTokens prints the CLAUDE.md files and skills of a report as tables
+func printStatsTokens(report statsTokensReport) {
+	var claudemdFiles, skills []statsTokensFile
+	claudemdTotal, skillAlways := 0, 0
+	for _, f := range report.Files {
+		if f.Kind == "claudemd" {
+			claudemdFiles = append(claudemdFiles, f)
+			claudemdTotal += f.Always
+		} else {
+			skills = append(skills, f)
+			skillAlways += f.Always
+		}
+	}
+
+	fmt.Println("CLAUDE.md:")
+	if len(claudemdFiles) == 0 {
+		fmt.Println("  No CLAUDE.md files found.")
+	} else {
+		t := newTable(table.Column{Header: "SCOPE"}, table.Column{Header: "PATH", Max: 50}, table.Column{Header: "TOKENS"}, table.Column{Header: "LOADED"})
+		for _, f := range claudemdFiles {
+			t.AddRow(f.Scope, f.Name, overMark(formatTokens(f.Tokens), f.OverLimit), f.LoadedWhen)
+		}
+		t.Render(os.Stdout)
+	}
+
+	fmt.Println("\nSkills:")
+	if len(skills) == 0 {
+		fmt.Println("  No skills found.")
+	} else {
+		t := newTable(table.Column{Header: "SCOPE"}, table.Column{Header: "ID", Fixed: true}, table.Column{Header: "SKILL.MD"}, table.Column{Header: "DESCRIPTION"})
+		for _, f := range skills {
+			t.AddRow(f.Scope, f.Name, overMark(formatTokens(f.Tokens), f.OverLimit), formatTokens(f.Always))
+		}
+		t.Render(os.Stdout)
+	}
+
+	fmt.Printf("\nEvery session starts with %s tokens: %s of CLAUDE.md and %s of skill descriptions.\n",
+		formatTokens(report.SessionStart), formatTokens(claudemdTotal), formatTokens(skillAlways))
+
+	var over []string
+	for _, f := range report.Files {
+		if f.OverLimit {
+			over = append(over, f.Name)
+		}
+	}
+	if len(over) > 0 {
+		fmt.Fprintf(os.Stderr, "\n⚠️  Over the warning thresholds (%s %d, %s %d, marked !): %s\n",
+			tokensClaudemdWarnKey, report.ClaudemdWarn, tokensSkillWarnKey, report.SkillWarn, strings.Join(over, ", "))
+		fmt.Fprintln(os.Stderr, "   Compress a CLAUDE.md with 'jd claudemd tidy --target-tokens N'; move a skill's details into files it points to.")
+	}
+}
+
+// overMark marks a token count over its threshold with !
+func overMark(tokens string, over bool) string {
+	if over {
+		return tokens + " !"
+	}
+	return tokens
+}
@@ -0,0 +1,24 @@
+package cli
+
+import (
+	"os"
+
+	"github.com/itda-skills/jindo/internal/table"
+	"github.com/spf13/cobra"
+)
+
+// tableNoTrunc shows full values in list tables instead of fitting them to the terminal
+var tableNoTrunc bool
+
+// addNoTruncFlag adds --no-trunc to a command that prints tables
+func addNoTruncFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&tableNoTrunc, "no-trunc", false, "Show full values instead of truncating columns to fit the terminal")
+}
+
+// newTable returns a table sized to the terminal stdout writes to
+func newTable(columns ...table.Column) *table.Table {
+	t := table.New(columns...)
+	t.Width = table.TerminalWidth(os.Stdout)
+	t.NoTrunc = tableNoTrunc
+	return t
+}
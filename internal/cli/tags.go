@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/itda-skills/jindo/internal/agent"
+	"github.com/itda-skills/jindo/internal/command"
+	"github.com/itda-skills/jindo/internal/frontmatter"
+	"github.com/itda-skills/jindo/internal/skill"
+)
+
+// formatTags formats tags for table output
+func formatTags(tags []string) string {
+	return strings.Join(tags, ", ")
+}
+
+// filterSkillsByTag returns skills that have the given tag
+func filterSkillsByTag(skills []*skill.Skill, tag string) []*skill.Skill {
+	var filtered []*skill.Skill
+	for _, s := range skills {
+		if frontmatter.HasTag(s.Tags, tag) {
+			filtered = append(filtered, s)
+		}
+	}
+	return filtered
+}
+
+// filterAgentsByTag returns agents that have the given tag
+func filterAgentsByTag(agents []*agent.Agent, tag string) []*agent.Agent {
+	var filtered []*agent.Agent
+	for _, a := range agents {
+		if frontmatter.HasTag(a.Tags, tag) {
+			filtered = append(filtered, a)
+		}
+	}
+	return filtered
+}
+
+// filterCommandsByTag returns commands that have the given tag
+func filterCommandsByTag(commands []*command.Command, tag string) []*command.Command {
+	var filtered []*command.Command
+	for _, c := range commands {
+		if frontmatter.HasTag(c.Tags, tag) {
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered
+}
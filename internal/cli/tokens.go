@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/itda-skills/jindo/internal/aicost"
+	"github.com/itda-skills/jindo/pkg/config"
+)
+
+// Configuration keys of the estimated token counts above which a CLAUDE.md or
+// a SKILL.md is reported as bloated (0 turns the warning off)
+const (
+	tokensClaudemdWarnKey = "tokens.claudemd_warn"
+	tokensSkillWarnKey    = "tokens.skill_warn"
+)
+
+// Default token warning thresholds. CLAUDE.md is loaded into every session, so
+// it gets the lower one; a skill's body is loaded only when it is used.
+const (
+	defaultTokensClaudemdWarn = 4000
+	defaultTokensSkillWarn    = 5000
+)
+
+// tokenThresholds returns the token counts above which a CLAUDE.md and a
+// SKILL.md are reported, 0 for no limit
+func tokenThresholds() (claudemdWarn, skillWarn int) {
+	cfg, err := config.Load()
+	if err != nil {
+		return defaultTokensClaudemdWarn, defaultTokensSkillWarn
+	}
+	return max(cfg.GetInt(tokensClaudemdWarnKey, defaultTokensClaudemdWarn), 0),
+		max(cfg.GetInt(tokensSkillWarnKey, defaultTokensSkillWarn), 0)
+}
+
+// fileTokens returns the estimated token count of a file, or 0 if it cannot be read
+func fileTokens(path string) int {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	return aicost.EstimateTokens(string(data))
+}
+
+// overTokens reports whether an estimated token count is over a threshold
+func overTokens(tokens, limit int) bool {
+	return limit > 0 && tokens > limit
+}
+
+// formatTokens formats an estimated token count with thousands separators: ~12,345
+func formatTokens(n int) string {
+	s := fmt.Sprint(n)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return "~" + s
+}
+
+// printTokenWarning warns on stderr that a file is over a token threshold,
+// with the command that shrinks it
+func printTokenWarning(name string, tokens, limit int, key, fix string) {
+	fmt.Fprintf(os.Stderr, "⚠️  %s is %s tokens, over %s (%d)", name, formatTokens(tokens), key, limit)
+	if fix != "" {
+		fmt.Fprintf(os.Stderr, "; try '%s'", fix)
+	}
+	fmt.Fprintln(os.Stderr)
+}
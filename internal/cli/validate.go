@@ -4,10 +4,12 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/itda-skills/jindo/internal/agent"
 	"github.com/itda-skills/jindo/internal/command"
+	"github.com/itda-skills/jindo/internal/hook"
 	"github.com/itda-skills/jindo/internal/skill"
 	"github.com/spf13/cobra"
 )
@@ -18,6 +20,7 @@ var validTools = map[string]bool{
 	"Read":         true,
 	"Write":        true,
 	"Edit":         true,
+	"MultiEdit":    true,
 	"Glob":         true,
 	"Grep":         true,
 	"LS":           true,
@@ -30,22 +33,52 @@ var validTools = map[string]bool{
 	"NotebookRead": true,
 }
 
+// knownTools returns the names of validTools, sorted
+func knownTools() []string {
+	tools := make([]string, 0, len(validTools))
+	for t := range validTools {
+		tools = append(tools, t)
+	}
+	sort.Strings(tools)
+	return tools
+}
+
 var (
 	validateSkillsOnly   bool
 	validateCommandsOnly bool
 	validateAgentsOnly   bool
+	validateHooksOnly    bool
 	validateVerbose      bool
+	validateGlobal       bool
+	validateLocal        bool
+	validateFix          bool
 )
 
 var validateCmd = &cobra.Command{
 	Use:   "validate",
-	Short: "Validate skills, commands, and agents",
-	Long: `Validate the format and content of all skills, commands, and agents.
+	Short: "Validate skills, commands, agents, and hook matchers",
+	Long: `Validate the format and content of all skills, commands, and agents, and the
+matchers of hooks.
 
 Checks:
 - YAML frontmatter parsing
 - Required fields (name, description)
-- Skill allowed-tools validity`,
+- Skill allowed-tools validity
+- Skill config declarations (name, type, default)
+- Hook matchers: tool names that are not known tools (e.g., "bash" or "Edits"),
+  invalid regular expressions, spaces around |, and matchers on events that
+  are not matched against tools
+
+--fix makes safe fixes before validating, and lists them in the report:
+- Adds a missing skill or agent name, from the directory or file name
+- Normalizes the casing of tool names in allowed-tools and tools (bash -> Bash)
+- Removes a byte order mark, trailing whitespace, and blank lines at the end
+- Fixes frontmatter delimiters: blank lines before the opening ---, ----
+  instead of ---, and a missing closing ---
+Without --fix, files that can be fixed are counted in the summary.
+
+Default scope: local (.claude) if present, otherwise global (~/.claude).
+Use --scope-path to validate a specific sub-project's .claude.`,
 	RunE: runValidate,
 }
 
@@ -54,12 +87,16 @@ func init() {
 	validateCmd.Flags().BoolVarP(&validateSkillsOnly, "skills", "s", false, "Validate only skills")
 	validateCmd.Flags().BoolVarP(&validateCommandsOnly, "commands", "c", false, "Validate only commands")
 	validateCmd.Flags().BoolVarP(&validateAgentsOnly, "agents", "a", false, "Validate only agents")
+	validateCmd.Flags().BoolVar(&validateHooksOnly, "hooks", false, "Validate only hook matchers")
 	validateCmd.Flags().BoolVarP(&validateVerbose, "verbose", "v", false, "Show all files, not just errors")
+	validateCmd.Flags().BoolVarP(&validateGlobal, "global", "g", false, "Validate global resources (~/.claude)")
+	validateCmd.Flags().BoolVarP(&validateLocal, "local", "l", false, "Validate local resources (.claude)")
+	validateCmd.Flags().BoolVar(&validateFix, "fix", false, "Apply safe fixes before validating")
 }
 
 // ValidationError represents a single validation error
 type ValidationError struct {
-	Type    string // "skill", "command", "agent"
+	Type    string // "skill", "command", "agent", "hook"
 	Name    string
 	Path    string
 	Message string
@@ -69,6 +106,7 @@ type ValidationError struct {
 type ValidationResult struct {
 	Errors   []ValidationError
 	Warnings []ValidationError
+	Fixes    []ValidationFix
 	Checked  int
 }
 
@@ -76,48 +114,71 @@ func runValidate(cmd *cobra.Command, _ []string) error {
 	cmd.SilenceUsage = true
 	result := &ValidationResult{}
 
+	scope, err := ResolveScope(validateGlobal, validateLocal)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Validating %s\n\n", ScopeDescription(scope))
+
 	// Determine which resources to validate
-	validateAll := !validateSkillsOnly && !validateCommandsOnly && !validateAgentsOnly
+	validateAll := !validateSkillsOnly && !validateCommandsOnly && !validateAgentsOnly && !validateHooksOnly
+
+	// Fix files first, so that they are validated as fixed
+	if err := fixResources(result, scope, validateAll || validateSkillsOnly, validateAll || validateCommandsOnly, validateAll || validateAgentsOnly); err != nil {
+		return err
+	}
 
 	// Validate skills
 	if validateAll || validateSkillsOnly {
-		if err := validateSkills(result); err != nil {
+		if err := validateSkills(result, GetPathByScope(scope, "skills")); err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: failed to validate skills: %v\n", err)
 		}
 	}
 
 	// Validate commands
 	if validateAll || validateCommandsOnly {
-		if err := validateCommands(result); err != nil {
+		if err := validateCommands(result, GetPathByScope(scope, "commands")); err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: failed to validate commands: %v\n", err)
 		}
+		validateCommandConflicts(result)
 	}
 
 	// Validate agents
 	if validateAll || validateAgentsOnly {
-		if err := validateAgents(result); err != nil {
+		if err := validateAgents(result, GetPathByScope(scope, "agents")); err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: failed to validate agents: %v\n", err)
 		}
 	}
 
+	// Validate hook matchers
+	if validateAll || validateHooksOnly {
+		if err := validateHooks(result, GetSettingsPathByScope(scope)); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to validate hooks: %v\n", err)
+		}
+	}
+
 	// Print results
 	printValidationResults(result)
+	emitValidation(scope, result)
 
 	// Return error if there are validation errors
 	if len(result.Errors) > 0 {
-		return fmt.Errorf("validation failed with %d error(s)", len(result.Errors))
+		return validationErrorf("validation failed with %d error(s)", len(result.Errors))
 	}
 
 	return nil
 }
 
-func validateSkills(result *ValidationResult) error {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return err
+func validateSkills(result *ValidationResult, dir string) error {
+	skillsDir := dir
+	if strings.HasPrefix(skillsDir, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return err
+		}
+		skillsDir = filepath.Join(home, skillsDir[2:])
 	}
 
-	skillsDir := filepath.Join(home, ".claude", "skills")
 	entries, err := os.ReadDir(skillsDir)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -126,7 +187,7 @@ func validateSkills(result *ValidationResult) error {
 		return err
 	}
 
-	store := skill.NewStore("~/.claude/skills")
+	store := skill.NewStore(dir)
 
 	for _, entry := range entries {
 		if !entry.IsDir() {
@@ -135,60 +196,80 @@ func validateSkills(result *ValidationResult) error {
 
 		name := entry.Name()
 		result.Checked++
-
-		s, err := store.Get(name)
-		if err != nil {
-			result.Errors = append(result.Errors, ValidationError{
-				Type:    "skill",
-				Name:    name,
-				Path:    filepath.Join(skillsDir, name),
-				Message: fmt.Sprintf("failed to parse: %v", err),
-			})
+		if !validateSkill(result, store, skillsDir, name) {
 			continue
 		}
 
-		// Check required fields
-		if s.Name == "" {
+		if validateVerbose {
+			fmt.Printf("  [OK] skill: %s\n", name)
+		}
+	}
+
+	return nil
+}
+
+// validateSkill checks a single skill and records its errors and warnings.
+// Returns false if the skill could not be parsed.
+func validateSkill(result *ValidationResult, store *skill.Store, skillsDir, name string) bool {
+	s, err := store.Get(name)
+	if err != nil {
+		result.Errors = append(result.Errors, ValidationError{
+			Type:    "skill",
+			Name:    name,
+			Path:    filepath.Join(skillsDir, name),
+			Message: fmt.Sprintf("failed to parse: %v", err),
+		})
+		return false
+	}
+
+	// Check required fields
+	if s.Name == "" {
+		result.Warnings = append(result.Warnings, ValidationError{
+			Type:    "skill",
+			Name:    name,
+			Path:    s.Path,
+			Message: "missing 'name' in frontmatter (using directory name)",
+		})
+	}
+
+	if s.Description == "" {
+		result.Warnings = append(result.Warnings, ValidationError{
+			Type:    "skill",
+			Name:    name,
+			Path:    s.Path,
+			Message: "missing 'description' in frontmatter",
+		})
+	}
+
+	// Check allowed-tools
+	for _, tool := range s.AllowedTools {
+		tool = strings.TrimSpace(tool)
+		if tool != "" && !validTools[tool] {
 			result.Warnings = append(result.Warnings, ValidationError{
 				Type:    "skill",
 				Name:    name,
 				Path:    s.Path,
-				Message: "missing 'name' in frontmatter (using directory name)",
+				Message: fmt.Sprintf("unknown tool in allowed-tools: %s", tool),
 			})
 		}
+	}
 
-		if s.Description == "" {
+	// Check config declarations
+	for _, key := range s.Config {
+		if issue := key.Issue(); issue != "" {
 			result.Warnings = append(result.Warnings, ValidationError{
 				Type:    "skill",
 				Name:    name,
 				Path:    s.Path,
-				Message: "missing 'description' in frontmatter",
+				Message: issue,
 			})
 		}
-
-		// Check allowed-tools
-		for _, tool := range s.AllowedTools {
-			tool = strings.TrimSpace(tool)
-			if tool != "" && !validTools[tool] {
-				result.Warnings = append(result.Warnings, ValidationError{
-					Type:    "skill",
-					Name:    name,
-					Path:    s.Path,
-					Message: fmt.Sprintf("unknown tool in allowed-tools: %s", tool),
-				})
-			}
-		}
-
-		if validateVerbose {
-			fmt.Printf("  [OK] skill: %s\n", name)
-		}
 	}
-
-	return nil
+	return true
 }
 
-func validateCommands(result *ValidationResult) error {
-	store := command.NewStore("~/.claude/commands")
+func validateCommands(result *ValidationResult, dir string) error {
+	store := command.NewStore(dir)
 	commands, err := store.List()
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -199,27 +280,78 @@ func validateCommands(result *ValidationResult) error {
 
 	for _, cmd := range commands {
 		result.Checked++
+		validateCommand(result, cmd)
 
-		// Check required fields
-		if cmd.Description == "" {
+		if validateVerbose {
+			fmt.Printf("  [OK] command: %s\n", cmd.Name)
+		}
+	}
+
+	return nil
+}
+
+// validateCommand checks a single command and records its warnings
+func validateCommand(result *ValidationResult, cmd *command.Command) {
+	// Check required fields
+	if cmd.Description == "" {
+		result.Warnings = append(result.Warnings, ValidationError{
+			Type:    "command",
+			Name:    cmd.Name,
+			Path:    cmd.Path,
+			Message: "missing 'description' in frontmatter",
+		})
+	}
+}
+
+// validateCommandConflicts warns about slash commands shadowed by a command
+// with the same name in the other scope
+func validateCommandConflicts(result *ValidationResult) {
+	for _, c := range findCommandConflicts() {
+		for _, d := range c.Shadowed {
 			result.Warnings = append(result.Warnings, ValidationError{
 				Type:    "command",
-				Name:    cmd.Name,
-				Path:    cmd.Path,
-				Message: "missing 'description' in frontmatter",
+				Name:    c.Name,
+				Path:    d.Path,
+				Message: fmt.Sprintf("/%s is shadowed by %s (%s; see 'jd commands conflicts')", c.Name, c.Winner.Path, d.Suggestion),
+			})
+		}
+	}
+}
+
+// validateHooks checks the matchers of the hooks in a settings.json, disabled
+// ones included, since enabling them again should not bring back a typo
+func validateHooks(result *ValidationResult, settingsPath string) error {
+	store := hook.NewStore(settingsPath)
+	hooks, err := store.List()
+	if err != nil {
+		return err
+	}
+	disabled, err := store.ListDisabled()
+	if err != nil {
+		return err
+	}
+
+	tools := knownTools()
+	for _, h := range append(hooks, disabled...) {
+		result.Checked++
+		for _, p := range hook.CheckMatcher(h.EventType, h.Matcher, tools) {
+			result.Warnings = append(result.Warnings, ValidationError{
+				Type:    "hook",
+				Name:    h.Name,
+				Path:    settingsPath,
+				Message: fmt.Sprintf("matcher %q: %s", h.Matcher, p),
 			})
 		}
 
 		if validateVerbose {
-			fmt.Printf("  [OK] command: %s\n", cmd.Name)
+			fmt.Printf("  [OK] hook: %s\n", h.Name)
 		}
 	}
-
 	return nil
 }
 
-func validateAgents(result *ValidationResult) error {
-	store := agent.NewStore("~/.claude/agents")
+func validateAgents(result *ValidationResult, dir string) error {
+	store := agent.NewStore(dir)
 	agents, err := store.List()
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -230,34 +362,7 @@ func validateAgents(result *ValidationResult) error {
 
 	for _, a := range agents {
 		result.Checked++
-
-		// Check required fields
-		if a.Name == "" {
-			result.Warnings = append(result.Warnings, ValidationError{
-				Type:    "agent",
-				Name:    filepath.Base(a.Path),
-				Path:    a.Path,
-				Message: "missing 'name' in frontmatter (using filename)",
-			})
-		}
-
-		if a.Description == "" {
-			result.Warnings = append(result.Warnings, ValidationError{
-				Type:    "agent",
-				Name:    a.Name,
-				Path:    a.Path,
-				Message: "missing 'description' in frontmatter",
-			})
-		}
-
-		if a.Model == "" {
-			result.Warnings = append(result.Warnings, ValidationError{
-				Type:    "agent",
-				Name:    a.Name,
-				Path:    a.Path,
-				Message: "missing 'model' in frontmatter",
-			})
-		}
+		validateAgent(result, a)
 
 		if validateVerbose {
 			fmt.Printf("  [OK] agent: %s\n", a.Name)
@@ -267,6 +372,37 @@ func validateAgents(result *ValidationResult) error {
 	return nil
 }
 
+// validateAgent checks a single agent and records its warnings
+func validateAgent(result *ValidationResult, a *agent.Agent) {
+	// Check required fields
+	if a.Name == "" {
+		result.Warnings = append(result.Warnings, ValidationError{
+			Type:    "agent",
+			Name:    filepath.Base(a.Path),
+			Path:    a.Path,
+			Message: "missing 'name' in frontmatter (using filename)",
+		})
+	}
+
+	if a.Description == "" {
+		result.Warnings = append(result.Warnings, ValidationError{
+			Type:    "agent",
+			Name:    a.Name,
+			Path:    a.Path,
+			Message: "missing 'description' in frontmatter",
+		})
+	}
+
+	if a.Model == "" {
+		result.Warnings = append(result.Warnings, ValidationError{
+			Type:    "agent",
+			Name:    a.Name,
+			Path:    a.Path,
+			Message: "missing 'model' in frontmatter",
+		})
+	}
+}
+
 func printValidationResults(result *ValidationResult) {
 	// Print errors
 	if len(result.Errors) > 0 {
@@ -287,9 +423,32 @@ func printValidationResults(result *ValidationResult) {
 		fmt.Println()
 	}
 
+	// Print fixes; fixes not applied are listed with --verbose
+	if len(result.Fixes) > 0 && (validateFix || validateVerbose) {
+		if validateFix {
+			fmt.Println("Fixed:")
+		} else {
+			fmt.Println("Fixable with --fix:")
+		}
+		for _, f := range result.Fixes {
+			fmt.Printf("  [FIX] %s '%s': %s\n", f.Type, f.Name, f.Path)
+			for _, c := range f.Changes {
+				fmt.Printf("          - %s\n", c)
+			}
+		}
+		fmt.Println()
+	}
+
 	// Print summary
 	fmt.Printf("Checked %d items: %d error(s), %d warning(s)\n",
 		result.Checked, len(result.Errors), len(result.Warnings))
+	if len(result.Fixes) > 0 {
+		if validateFix {
+			fmt.Printf("Fixed %d file(s)\n", len(result.Fixes))
+		} else {
+			fmt.Printf("%d file(s) can be fixed automatically; run 'jd validate --fix'\n", len(result.Fixes))
+		}
+	}
 
 	if len(result.Errors) == 0 && len(result.Warnings) == 0 {
 		fmt.Println("All validations passed!")
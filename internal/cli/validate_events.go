@@ -0,0 +1,36 @@
+package cli
+
+import "github.com/itda-skills/jindo/internal/events"
+
+// validationProblem is an error or warning of a validation, as recorded in the event log
+type validationProblem struct {
+	Severity string `json:"severity"` // "error" or "warning"
+	Type     string `json:"type"`
+	Name     string `json:"name"`
+	Message  string `json:"message"`
+}
+
+// emitValidation records the results of validating a scope in the event log,
+// so CI bots and dashboards can follow them
+func emitValidation(scope PathScope, result *ValidationResult) {
+	var problems []validationProblem
+	for _, e := range result.Errors {
+		problems = append(problems, validationProblem{"error", e.Type, e.Name, e.Message})
+	}
+	for _, w := range result.Warnings {
+		problems = append(problems, validationProblem{"warning", w.Type, w.Name, w.Message})
+	}
+
+	data := map[string]any{
+		"checked":  result.Checked,
+		"errors":   len(result.Errors),
+		"warnings": len(result.Warnings),
+		"fixed":    len(result.Fixes),
+		"passed":   len(result.Errors) == 0,
+		"path":     GetPathByScope(scope, ""),
+	}
+	if len(problems) > 0 {
+		data["problems"] = problems
+	}
+	events.Emit(events.Event{Type: events.Validate, Subject: string(scope), Data: data})
+}
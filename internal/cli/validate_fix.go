@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/itda-skills/jindo/internal/agent"
+	"github.com/itda-skills/jindo/internal/autofix"
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/command"
+)
+
+// ValidationFix records the fixes made to a file by validate --fix, or that
+// --fix would make
+type ValidationFix struct {
+	Type    string // "skill", "command", "agent"
+	Name    string
+	Path    string
+	Changes []string
+}
+
+// fixResources finds the fixes for the skills, commands, and agents of a scope,
+// and writes them if --fix is given
+func fixResources(result *ValidationResult, scope PathScope, skills, commands, agents bool) error {
+	tools := knownTools()
+
+	if skills {
+		dir, err := basedir.Expand(GetPathByScope(scope, "skills"))
+		if err != nil {
+			return err
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to read skills: %w", err)
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+			for _, file := range []string{"SKILL.md", "skill.md"} {
+				path := filepath.Join(dir, entry.Name(), file)
+				if _, err := os.Stat(path); err != nil {
+					continue
+				}
+				opts := autofix.Options{Name: entry.Name(), ToolFields: []string{"allowed-tools"}, Tools: tools}
+				if err := fixFile(result, "skill", entry.Name(), path, opts); err != nil {
+					return err
+				}
+				break
+			}
+		}
+	}
+
+	if commands {
+		list, err := command.NewStore(GetPathByScope(scope, "commands")).List()
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to list commands: %w", err)
+		}
+		for _, c := range list {
+			// A command's name is its path, so none is added
+			opts := autofix.Options{ToolFields: []string{"allowed-tools"}, Tools: tools}
+			if err := fixFile(result, "command", c.Name, c.Path, opts); err != nil {
+				return err
+			}
+		}
+	}
+
+	if agents {
+		list, err := agent.NewStore(GetPathByScope(scope, "agents")).List()
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to list agents: %w", err)
+		}
+		for _, a := range list {
+			name := strings.TrimSuffix(filepath.Base(a.Path), ".md")
+			opts := autofix.Options{Name: name, ToolFields: []string{"tools"}, Tools: tools}
+			if err := fixFile(result, "agent", name, a.Path, opts); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fixFile records the fixes for a file, writing them if --fix is given
+func fixFile(result *ValidationResult, resourceType, name, path string, opts autofix.Options) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	fixed, changes := autofix.Fix(string(content), opts)
+	if len(changes) == 0 {
+		return nil
+	}
+	if validateFix {
+		if err := os.WriteFile(path, []byte(fixed), info.Mode().Perm()); err != nil {
+			return fmt.Errorf("failed to fix %s: %w", path, err)
+		}
+	}
+	result.Fixes = append(result.Fixes, ValidationFix{Type: resourceType, Name: name, Path: path, Changes: changes})
+	return nil
+}
@@ -5,62 +5,21 @@ import (
 	"path/filepath"
 	"strings"
 
-	"gopkg.in/yaml.v3"
+	"github.com/itda-skills/jindo/internal/frontmatter"
 )
 
 // Command represents a Claude Code command
 type Command struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Path        string `json:"path"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Tags        []string `json:"tags,omitempty"`
+	Path        string   `json:"path"`
 }
 
 // commandFrontmatter represents the YAML frontmatter structure
 type commandFrontmatter struct {
-	Description string `yaml:"description"`
-}
-
-// extractFrontmatter extracts YAML frontmatter from markdown content
-func extractFrontmatter(content string) (string, bool) {
-	lines := strings.Split(content, "\n")
-	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
-		return "", false
-	}
-
-	var frontmatterLines []string
-	for i := 1; i < len(lines); i++ {
-		if strings.TrimSpace(lines[i]) == "---" {
-			return strings.Join(frontmatterLines, "\n"), true
-		}
-		frontmatterLines = append(frontmatterLines, lines[i])
-	}
-
-	return "", false
-}
-
-// parseSimpleFrontmatter parses frontmatter using simple line-based approach
-// This is a fallback for when YAML parsing fails due to special characters
-func parseSimpleFrontmatter(frontmatter string) map[string]string {
-	result := make(map[string]string)
-	lines := strings.Split(frontmatter, "\n")
-
-	for _, line := range lines {
-		// Find first colon
-		idx := strings.Index(line, ":")
-		if idx <= 0 {
-			continue
-		}
-
-		key := strings.TrimSpace(line[:idx])
-		value := strings.TrimSpace(line[idx+1:])
-
-		// Only capture simple keys we care about
-		if key == "description" {
-			result[key] = value
-		}
-	}
-
-	return result
+	Description string           `yaml:"description"`
+	Tags        frontmatter.List `yaml:"tags"`
 }
 
 // findFirstHeading finds the first H1 heading in markdown content
@@ -85,24 +44,16 @@ func ParseCommandFile(path string) (*Command, error) {
 		Path: path,
 	}
 
-	frontmatter, found := extractFrontmatter(string(content))
-	if found && frontmatter != "" {
-		var fm commandFrontmatter
-		if err := yaml.Unmarshal([]byte(frontmatter), &fm); err != nil {
-			// If YAML parsing fails, fall back to simple parsing
-			simple := parseSimpleFrontmatter(frontmatter)
-			cmd.Description = simple["description"]
-			if cmd.Description == "" {
-				cmd.Description = findFirstHeading(string(content))
-			}
-			return cmd, nil
-		}
+	var fm commandFrontmatter
+	doc, err := frontmatter.Parse(string(content), &fm)
+	if err == nil {
 		cmd.Description = fm.Description
+		cmd.Tags = frontmatter.NormalizeTags(fm.Tags)
 	}
 
 	// If no description from frontmatter, try first heading
 	if cmd.Description == "" {
-		cmd.Description = findFirstHeading(string(content))
+		cmd.Description = findFirstHeading(doc.Body)
 	}
 
 	return cmd, nil
@@ -212,6 +163,10 @@ func (s *Store) walkDir(dir, prefix string, commands *[]*Command) error {
 		fullPath := filepath.Join(dir, name)
 
 		if entry.IsDir() {
+			// Skip hidden directories, such as a .history of saved versions
+			if strings.HasPrefix(name, ".") {
+				continue
+			}
 			// Recurse into subdirectory with prefix
 			newPrefix := name
 			if prefix != "" {
@@ -0,0 +1,242 @@
+// Package detect inspects a project directory and reports its stack: languages and
+// their toolchains, container setup, and CI providers. The profile drives tailored
+// suggestions in project init, the CLAUDE.md guide, and package recommendations.
+package detect
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Language is a language or toolchain detected in a project
+type Language struct {
+	ID        string `json:"id"`                  // Stable identifier (e.g., "go", "node")
+	Name      string `json:"name"`                // Display name (e.g., "Go")
+	Marker    string `json:"marker"`              // File that identified it (e.g., "go.mod")
+	Build     string `json:"build,omitempty"`     // Build command, if known
+	Test      string `json:"test,omitempty"`      // Test command, if known
+	Lint      string `json:"lint,omitempty"`      // Lint command, if known
+	Formatter string `json:"formatter,omitempty"` // Formatter the project is set up for (e.g., "prettier")
+}
+
+// Tool is a container or CI setup detected in a project
+type Tool struct {
+	ID     string `json:"id"`     // Stable identifier (e.g., "docker", "github-actions")
+	Name   string `json:"name"`   // Display name (e.g., "GitHub Actions")
+	Marker string `json:"marker"` // File or directory that identified it
+}
+
+// Profile is the detected stack of a project
+type Profile struct {
+	Root       string     `json:"root"`
+	Languages  []Language `json:"languages"`
+	Containers []Tool     `json:"containers"`
+	CI         []Tool     `json:"ci"`
+}
+
+// containerMarkers identify container setups, in display order
+var containerMarkers = []struct {
+	Tool
+	patterns []string
+}{
+	{Tool{ID: "docker", Name: "Docker"}, []string{"Dockerfile", "Dockerfile.*", "*.Dockerfile"}},
+	{Tool{ID: "docker-compose", Name: "Docker Compose"}, []string{"compose.yaml", "compose.yml", "docker-compose.yaml", "docker-compose.yml"}},
+}
+
+// ciMarkers identify CI providers by their configuration files, in display order
+var ciMarkers = []struct {
+	Tool
+	patterns []string
+}{
+	{Tool{ID: "github-actions", Name: "GitHub Actions"}, []string{".github/workflows/*.yml", ".github/workflows/*.yaml"}},
+	{Tool{ID: "gitlab-ci", Name: "GitLab CI"}, []string{".gitlab-ci.yml"}},
+	{Tool{ID: "circleci", Name: "CircleCI"}, []string{".circleci/config.yml"}},
+	{Tool{ID: "jenkins", Name: "Jenkins"}, []string{"Jenkinsfile"}},
+	{Tool{ID: "azure-pipelines", Name: "Azure Pipelines"}, []string{"azure-pipelines.yml"}},
+	{Tool{ID: "bitbucket-pipelines", Name: "Bitbucket Pipelines"}, []string{"bitbucket-pipelines.yml"}},
+	{Tool{ID: "travis", Name: "Travis CI"}, []string{".travis.yml"}},
+}
+
+// Detect inspects root for language, container, and CI marker files
+func Detect(root string) *Profile {
+	p := &Profile{Root: root, Languages: []Language{}, Containers: []Tool{}, CI: []Tool{}}
+
+	if exists(root, "go.mod") {
+		p.Languages = append(p.Languages, Language{
+			ID: "go", Name: "Go", Marker: "go.mod",
+			Build: "go build ./...", Test: "go test ./...", Lint: "go vet ./...",
+			Formatter: "gofmt",
+		})
+	}
+	if exists(root, "package.json") {
+		p.Languages = append(p.Languages, detectNode(root))
+	}
+	if lang, ok := detectPython(root); ok {
+		p.Languages = append(p.Languages, lang)
+	}
+	if exists(root, "Cargo.toml") {
+		p.Languages = append(p.Languages, Language{
+			ID: "rust", Name: "Rust", Marker: "Cargo.toml",
+			Build: "cargo build", Test: "cargo test", Lint: "cargo clippy",
+			Formatter: "rustfmt",
+		})
+	}
+
+	for _, m := range containerMarkers {
+		if marker := firstMatch(root, m.patterns); marker != "" {
+			t := m.Tool
+			t.Marker = marker
+			p.Containers = append(p.Containers, t)
+		}
+	}
+	for _, m := range ciMarkers {
+		if marker := firstMatch(root, m.patterns); marker != "" {
+			t := m.Tool
+			t.Marker = marker
+			p.CI = append(p.CI, t)
+		}
+	}
+	return p
+}
+
+// Empty reports whether nothing was detected
+func (p *Profile) Empty() bool {
+	return len(p.Languages) == 0 && len(p.Containers) == 0 && len(p.CI) == 0
+}
+
+// Tags returns the identifiers of everything detected: languages, formatters,
+// containers, and CI providers (e.g., "go", "gofmt", "docker", "github-actions")
+func (p *Profile) Tags() []string {
+	var tags []string
+	for _, l := range p.Languages {
+		tags = append(tags, l.ID)
+		if l.Formatter != "" {
+			tags = append(tags, l.Formatter)
+		}
+	}
+	for _, t := range p.Containers {
+		tags = append(tags, t.ID)
+	}
+	for _, t := range p.CI {
+		tags = append(tags, t.ID)
+	}
+	return tags
+}
+
+// Has reports whether tag is one of the profile's tags
+func (p *Profile) Has(tag string) bool {
+	for _, t := range p.Tags() {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// Lines describes the profile as one line per detected item (e.g., "Go (go.mod)")
+func (p *Profile) Lines() []string {
+	var lines []string
+	for _, l := range p.Languages {
+		lines = append(lines, fmt.Sprintf("%s (%s)", l.Name, l.Marker))
+	}
+	for _, t := range p.Containers {
+		lines = append(lines, fmt.Sprintf("%s (%s)", t.Name, t.Marker))
+	}
+	for _, t := range p.CI {
+		lines = append(lines, fmt.Sprintf("%s (%s)", t.Name, t.Marker))
+	}
+	return lines
+}
+
+// detectNode reads package.json scripts and picks the package manager from the lockfile
+func detectNode(root string) Language {
+	lang := Language{ID: "node", Name: "JavaScript/TypeScript", Marker: "package.json"}
+	if exists(root, "tsconfig.json") {
+		lang.Name = "TypeScript"
+	}
+
+	run := "npm run"
+	switch {
+	case exists(root, "pnpm-lock.yaml"):
+		run = "pnpm"
+	case exists(root, "yarn.lock"):
+		run = "yarn"
+	case exists(root, "bun.lockb"), exists(root, "bun.lock"):
+		run = "bun run"
+	}
+
+	var pkg struct {
+		Scripts         map[string]string `json:"scripts"`
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+		Prettier        json.RawMessage   `json:"prettier"`
+	}
+	if data, err := os.ReadFile(filepath.Join(root, "package.json")); err == nil {
+		_ = json.Unmarshal(data, &pkg)
+	}
+	if _, ok := pkg.Scripts["build"]; ok {
+		lang.Build = run + " build"
+	}
+	if _, ok := pkg.Scripts["test"]; ok {
+		lang.Test = run + " test"
+	}
+	if _, ok := pkg.Scripts["lint"]; ok {
+		lang.Lint = run + " lint"
+	}
+
+	_, dep := pkg.DevDependencies["prettier"]
+	if _, ok := pkg.Dependencies["prettier"]; ok {
+		dep = true
+	}
+	if dep || len(pkg.Prettier) > 0 || firstMatch(root, []string{".prettierrc*", "prettier.config.*"}) != "" {
+		lang.Formatter = "prettier"
+	}
+	return lang
+}
+
+// detectPython recognizes pyproject.toml, setup.py, or requirements.txt projects
+func detectPython(root string) (Language, bool) {
+	lang := Language{ID: "python", Name: "Python"}
+	lang.Marker = firstMatch(root, []string{"pyproject.toml", "setup.py", "requirements.txt"})
+	if lang.Marker == "" {
+		return lang, false
+	}
+
+	pyproject, _ := os.ReadFile(filepath.Join(root, "pyproject.toml"))
+	switch {
+	case strings.Contains(string(pyproject), "[tool.ruff") || exists(root, "ruff.toml"):
+		lang.Formatter = "ruff"
+		lang.Lint = "ruff check ."
+	case strings.Contains(string(pyproject), "[tool.black"):
+		lang.Formatter = "black"
+	}
+	if strings.Contains(string(pyproject), "[tool.pytest") || exists(root, "pytest.ini") || exists(root, "tests") {
+		lang.Test = "pytest"
+	}
+	return lang, true
+}
+
+// exists reports whether name exists in dir
+func exists(dir, name string) bool {
+	_, err := os.Stat(filepath.Join(dir, name))
+	return err == nil
+}
+
+// firstMatch returns the first file under dir, relative to it, that matches one of
+// the glob patterns, in pattern order
+func firstMatch(dir string, patterns []string) string {
+	for _, pattern := range patterns {
+		matches, _ := filepath.Glob(filepath.Join(dir, pattern))
+		if len(matches) > 0 {
+			rel, err := filepath.Rel(dir, matches[0])
+			if err != nil {
+				return matches[0]
+			}
+			return filepath.ToSlash(rel)
+		}
+	}
+	return ""
+}
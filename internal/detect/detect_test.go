@@ -0,0 +1,52 @@
+package detect
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDetect(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"go.mod":                   "module widget\n",
+		"package.json":             `{"scripts": {"test": "vitest"}, "devDependencies": {"prettier": "^3"}}`,
+		"pnpm-lock.yaml":           "",
+		"pyproject.toml":           "[tool.ruff]\nline-length = 100\n",
+		"Dockerfile":               "FROM scratch\n",
+		".github/workflows/ci.yml": "on: push\n",
+		".gitlab-ci.yml":           "test:\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	p := Detect(root)
+	want := "go,gofmt,node,prettier,python,ruff,docker,github-actions,gitlab-ci"
+	if got := strings.Join(p.Tags(), ","); got != want {
+		t.Errorf("Tags() = %s, want %s", got, want)
+	}
+	if node := p.Languages[1]; node.Test != "pnpm test" || node.Build != "" {
+		t.Errorf("node commands = build %q, test %q", node.Build, node.Test)
+	}
+	if p.CI[0].Marker != ".github/workflows/ci.yml" {
+		t.Errorf("GitHub Actions marker = %q", p.CI[0].Marker)
+	}
+	if !p.Has("docker") || p.Has("rust") {
+		t.Errorf("Has() mismatch for %v", p.Tags())
+	}
+}
+
+func TestDetectEmpty(t *testing.T) {
+	p := Detect(t.TempDir())
+	if !p.Empty() || len(p.Lines()) != 0 {
+		t.Errorf("Detect() on an empty directory = %+v", p)
+	}
+}
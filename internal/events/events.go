@@ -0,0 +1,210 @@
+// Package events keeps a log of the changes jd makes (packages installed,
+// updated, and uninstalled, hook rules changed, validation results, package
+// setup steps run) as newline-delimited JSON, for other tools such as CI bots,
+// dashboards, and editor extensions to read or tail.
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/itda-skills/jindo/internal/basedir"
+)
+
+// File is the event log, in the jd data directory
+const File = "events.jsonl"
+
+// maxSize is the size past which the log is rotated to File.1, replacing the
+// previous rotation, so it does not grow without bound
+var maxSize int64 = 5 << 20
+
+// Type is the kind of change an event records
+type Type string
+
+const (
+	Install    Type = "install"     // A package was installed
+	Uninstall  Type = "uninstall"   // A package was uninstalled
+	Update     Type = "update"      // A package was updated
+	HookChange Type = "hook-change" // A hook rule was added, edited, deleted, disabled, or enabled
+	Validate   Type = "validate"    // A configuration was validated
+	Setup      Type = "setup"       // A package's one-time setup step ran
+)
+
+// Types are the kinds of events, in the order they are documented
+var Types = []Type{Install, Uninstall, Update, HookChange, Validate, Setup}
+
+// Event is a change jd made, as one line of the log
+type Event struct {
+	Time    time.Time      `json:"time"`
+	Type    Type           `json:"type"`
+	Subject string         `json:"subject"`        // The package, hook, or scope the event is about
+	Data    map[string]any `json:"data,omitempty"` // Details, which depend on the type
+}
+
+// Log is the event log, one JSON object per line
+type Log struct {
+	path string
+}
+
+// NewLog returns the log kept in dataDir
+func NewLog(dataDir string) *Log {
+	return &Log{path: filepath.Join(dataDir, File)}
+}
+
+// Path returns the file the log is kept in
+func (l *Log) Path() string {
+	return l.path
+}
+
+// Append adds an event to the log, rotating the log first if it is too large
+func (l *Log) Append(e Event) error {
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	line, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("encode event: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(l.path), 0755); err != nil {
+		return fmt.Errorf("create data directory: %w", err)
+	}
+	if info, err := os.Stat(l.path); err == nil && info.Size() >= maxSize {
+		if err := os.Rename(l.path, l.path+".1"); err != nil {
+			return fmt.Errorf("rotate event log: %w", err)
+		}
+	}
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("open event log: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("write event log: %w", err)
+	}
+	return nil
+}
+
+// Read returns the events in the log, oldest first, and the offset after the
+// last complete line, where Follow picks up. Lines that cannot be read, such
+// as one being written, are skipped.
+func (l *Log) Read() ([]Event, int64, error) {
+	data, err := os.ReadFile(l.path)
+	if os.IsNotExist(err) {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("read event log: %w", err)
+	}
+	complete := bytes.LastIndexByte(data, '\n') + 1
+	return decode(data[:complete]), int64(complete), nil
+}
+
+// Follow calls fn with each event appended to the log after offset, checking
+// every interval, until ctx is done. A log that is rotated is read to its end
+// before following the new one; one that is truncated is followed from its
+// start.
+func (l *Log) Follow(ctx context.Context, offset int64, interval time.Duration, fn func(Event)) error {
+	var f *os.File
+	defer func() {
+		if f != nil {
+			f.Close()
+		}
+	}()
+
+	var pending []byte
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if f == nil {
+			if opened, err := os.Open(l.path); err == nil {
+				f = opened
+			} else if !os.IsNotExist(err) {
+				return fmt.Errorf("open event log: %w", err)
+			}
+		}
+
+		if f != nil {
+			if info, err := f.Stat(); err == nil && info.Size() < offset {
+				offset, pending = 0, nil
+			}
+			data, err := readFrom(f, offset)
+			if err != nil {
+				return fmt.Errorf("read event log: %w", err)
+			}
+			offset += int64(len(data))
+			pending = append(pending, data...)
+			if complete := bytes.LastIndexByte(pending, '\n') + 1; complete > 0 {
+				for _, e := range decode(pending[:complete]) {
+					fn(e)
+				}
+				pending = append([]byte(nil), pending[complete:]...)
+			}
+
+			// Once drained, a rotated log is left for the one that replaced it
+			if rotated(f, l.path) {
+				f.Close()
+				f, offset, pending = nil, 0, nil
+				continue
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// readFrom returns the contents of f after offset
+func readFrom(f *os.File, offset int64) ([]byte, error) {
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return io.ReadAll(f)
+}
+
+// rotated reports whether the open log f has been replaced by a new file at path
+func rotated(f *os.File, path string) bool {
+	open, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	current, err := os.Stat(path)
+	return err == nil && !os.SameFile(open, current)
+}
+
+// decode decodes complete lines of the log, skipping lines that cannot be read
+func decode(data []byte) []Event {
+	var events []Event
+	for _, line := range bytes.Split(data, []byte{'\n'}) {
+		var e Event
+		if len(line) == 0 || json.Unmarshal(line, &e) != nil {
+			continue
+		}
+		events = append(events, e)
+	}
+	return events
+}
+
+// Emit appends an event to the log in the jd data directory. Recording events
+// is best effort: a log that cannot be written never fails the change itself.
+func Emit(e Event) {
+	EmitTo(basedir.DataDir(), e)
+}
+
+// EmitTo appends an event to the log in dataDir, which may start with ~/, on
+// a best-effort basis like Emit
+func EmitTo(dataDir string, e Event) {
+	dir, err := basedir.Expand(dataDir)
+	if err != nil {
+		return
+	}
+	_ = NewLog(dir).Append(e)
+}
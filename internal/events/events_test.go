@@ -0,0 +1,129 @@
+package events
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLogAppendRead(t *testing.T) {
+	log := NewLog(filepath.Join(t.TempDir(), "data"))
+
+	events, offset, err := log.Read()
+	if err != nil || len(events) != 0 || offset != 0 {
+		t.Fatalf("Read() of a missing log = %v, %d, %v", events, offset, err)
+	}
+
+	at := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	if err := log.Append(Event{Time: at, Type: Install, Subject: "affa-ever--web-fetch", Data: map[string]any{"type": "skill"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := log.Append(Event{Type: HookChange, Subject: "PreToolUse-Bash-0"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// A line being written is left for Follow
+	f, err := os.OpenFile(log.Path(), os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"type":"upd`)
+	f.Close()
+
+	events, offset, err = log.Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Read() = %d events, want 2", len(events))
+	}
+	if !events[0].Time.Equal(at) || events[0].Type != Install || events[0].Data["type"] != "skill" {
+		t.Errorf("events[0] = %+v", events[0])
+	}
+	if events[1].Time.IsZero() {
+		t.Error("Append() did not set the time")
+	}
+	info, _ := os.Stat(log.Path())
+	if want := info.Size() - int64(len(`{"type":"upd`)); offset != want {
+		t.Errorf("offset = %d, want %d", offset, want)
+	}
+}
+
+func TestLogRotate(t *testing.T) {
+	defer func(size int64) { maxSize = size }(maxSize)
+	maxSize = 100
+
+	log := NewLog(t.TempDir())
+	for range 4 {
+		if err := log.Append(Event{Type: Validate, Subject: "global settings"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := os.Stat(log.Path() + ".1"); err != nil {
+		t.Fatalf("log was not rotated: %v", err)
+	}
+	events, _, err := log.Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) == 0 || len(events) == 4 {
+		t.Errorf("Read() after rotation = %d events", len(events))
+	}
+}
+
+func TestLogFollow(t *testing.T) {
+	log := NewLog(t.TempDir())
+	if err := log.Append(Event{Type: Install, Subject: "old"}); err != nil {
+		t.Fatal(err)
+	}
+	_, offset, err := log.Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	got := make(chan string, 10)
+	done := make(chan error)
+	go func() {
+		done <- log.Follow(ctx, offset, 10*time.Millisecond, func(e Event) { got <- e.Subject })
+	}()
+
+	next := func() string {
+		select {
+		case s := <-got:
+			return s
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for an event")
+			return ""
+		}
+	}
+
+	log.Append(Event{Type: Install, Subject: "first"})
+	if s := next(); s != "first" {
+		t.Errorf("followed %q, want first", s)
+	}
+
+	// A rotated log is read to its end, then the new one is followed
+	log.Append(Event{Type: Update, Subject: "before rotation"})
+	if err := os.Rename(log.Path(), log.Path()+".1"); err != nil {
+		t.Fatal(err)
+	}
+	log.Append(Event{Type: Uninstall, Subject: "after rotation"})
+	if s := next(); s != "before rotation" {
+		t.Errorf("followed %q, want before rotation", s)
+	}
+	if s := next(); s != "after rotation" {
+		t.Errorf("followed %q, want after rotation", s)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Follow() = %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("followed %q twice or out of order", <-got)
+	}
+}
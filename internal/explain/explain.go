@@ -0,0 +1,333 @@
+// Package explain summarizes skills, commands, agents, and hooks without AI:
+// what they are, when they trigger, and what they run, from their frontmatter,
+// headings, code blocks, and tool references. The same input always yields the
+// same summary, so it can be used offline and in CI.
+package explain
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/itda-skills/jindo/internal/frontmatter"
+)
+
+// Summary is the structured explanation of a resource
+type Summary struct {
+	Type            string            `json:"type"` // "skill", "command", "agent", "hook"
+	Name            string            `json:"name"`
+	Path            string            `json:"path,omitempty"`
+	Description     string            `json:"description,omitempty"`
+	Triggers        []string          `json:"triggers"`                   // When it triggers
+	Runs            []string          `json:"runs"`                       // Shell commands it runs or instructs Claude to run
+	AllowedTools    []string          `json:"allowed_tools,omitempty"`    // Tools declared in the frontmatter
+	ReferencedTools []string          `json:"referenced_tools,omitempty"` // Tools named in the body
+	Model           string            `json:"model,omitempty"`
+	Headings        []string          `json:"headings,omitempty"` // Outline, indented two spaces per level below the top
+	Frontmatter     map[string]string `json:"frontmatter,omitempty"`
+}
+
+// knownTools are the Claude Code tools recognized in a resource's body
+var knownTools = []string{
+	"Bash", "BashOutput", "Edit", "Glob", "Grep", "KillShell", "LS", "MultiEdit",
+	"NotebookEdit", "Read", "SlashCommand", "Task", "TodoWrite", "WebFetch",
+	"WebSearch", "Write",
+}
+
+var (
+	toolRefPattern  = regexp.MustCompile(`\b(` + strings.Join(knownTools, "|") + `)\b|\bmcp__[A-Za-z0-9_-]+`)
+	bangCmdPattern  = regexp.MustCompile("!`([^`]+)`")
+	argumentPattern = regexp.MustCompile(`\$ARGUMENTS|\$[1-9]`)
+	headingPattern  = regexp.MustCompile(`^(#{1,6})\s+(.+?)\s*#*\s*$`)
+)
+
+// shellLanguages are the code block languages whose lines are shell commands
+var shellLanguages = map[string]bool{"bash": true, "sh": true, "shell": true, "zsh": true, "console": true}
+
+// Markdown explains a skill, command, or agent from the content of its file
+func Markdown(resourceType, name, content string) *Summary {
+	fields, body := splitFrontmatter(content)
+	s := &Summary{
+		Type:        resourceType,
+		Name:        name,
+		Description: fields["description"],
+		Model:       fields["model"],
+		Frontmatter: fields,
+	}
+	if s.Description == "" {
+		s.Description = firstParagraph(body)
+	}
+
+	for _, key := range []string{"allowed-tools", "tools"} {
+		s.AllowedTools = append(s.AllowedTools, splitList(fields[key])...)
+	}
+
+	s.Headings, s.Runs = scanBody(body)
+	if resourceType == "command" {
+		// Commands run !`cmd` lines before the prompt is sent
+		for _, m := range bangCmdPattern.FindAllStringSubmatch(body, -1) {
+			s.Runs = appendUnique(s.Runs, strings.TrimSpace(m[1]))
+		}
+	}
+	s.ReferencedTools = referencedTools(body)
+	s.Triggers = markdownTriggers(resourceType, name, fields, body)
+	if s.Runs == nil {
+		s.Runs = []string{}
+	}
+	return s
+}
+
+// Hook explains a hook rule: the event and matcher it triggers on and its commands
+func Hook(name, event, matcher string, commands []string) *Summary {
+	s := &Summary{
+		Type:        "hook",
+		Name:        name,
+		Description: eventDescriptions[event],
+		Runs:        append([]string{}, commands...),
+	}
+
+	switch {
+	case !toolEvents[event]:
+		s.Triggers = []string{"On " + event}
+	case matcher == "" || matcher == "*":
+		s.Triggers = []string{fmt.Sprintf("On %s of every tool", event)}
+	default:
+		s.Triggers = []string{fmt.Sprintf("On %s of tools matching %s", event, matcher)}
+		for _, tool := range strings.Split(matcher, "|") {
+			if tool = strings.TrimSpace(tool); toolRefPattern.MatchString(tool) {
+				s.ReferencedTools = appendUnique(s.ReferencedTools, tool)
+			}
+		}
+	}
+	for _, c := range commands {
+		s.ReferencedTools = mergeSorted(s.ReferencedTools, referencedTools(c))
+	}
+	sort.Strings(s.ReferencedTools)
+	return s
+}
+
+// Script explains a hook script from its content, given the event and matcher its
+// header declares (empty if none): the lines it runs besides comments and block keywords
+func Script(name, event, matcher, content string) *Summary {
+	var runs []string
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "//") || blockKeywords[line] {
+			continue
+		}
+		runs = append(runs, line)
+	}
+
+	if event == "" {
+		s := Hook(name, "", "", runs)
+		s.Triggers = []string{"When registered in settings.json (the script declares no event)"}
+		return s
+	}
+	return Hook(name, event, matcher, runs)
+}
+
+// blockKeywords are shell lines that only open or close a block
+var blockKeywords = map[string]bool{"then": true, "else": true, "fi": true, "do": true, "done": true, "esac": true, ";;": true, "{": true, "}": true}
+
+// toolEvents are the hook events whose matcher is matched against tool names
+var toolEvents = map[string]bool{"PreToolUse": true, "PostToolUse": true}
+
+// eventDescriptions describe what a hook does on each event
+var eventDescriptions = map[string]string{
+	"PreToolUse":   "Runs before a tool is executed and can block it",
+	"PostToolUse":  "Runs after a tool is executed",
+	"Notification": "Runs when Claude Code sends a notification",
+	"Stop":         "Runs when Claude finishes responding",
+	"SubagentStop": "Runs when a subagent finishes",
+}
+
+// markdownTriggers describes when a skill, command, or agent is used
+func markdownTriggers(resourceType, name string, fields map[string]string, body string) []string {
+	var triggers []string
+	switch resourceType {
+	case "skill":
+		if fields["disable-model-invocation"] == "true" {
+			triggers = append(triggers, "Only when invoked explicitly")
+		} else {
+			triggers = append(triggers, "When a request matches its description")
+		}
+	case "command":
+		usage := "/" + name
+		if hint := fields["argument-hint"]; hint != "" {
+			usage += " " + hint
+		}
+		triggers = append(triggers, "When typed as "+usage)
+		if argumentPattern.MatchString(body) {
+			triggers = append(triggers, "Takes arguments ($ARGUMENTS)")
+		}
+	case "agent":
+		triggers = append(triggers, "When Claude delegates a task matching its description")
+	}
+
+	// Sentences of the description that say when to use it
+	for _, sentence := range sentences(fields["description"]) {
+		lower := strings.ToLower(sentence)
+		if strings.Contains(lower, "when") || strings.Contains(lower, "proactively") || strings.Contains(lower, "trigger") {
+			triggers = append(triggers, sentence)
+		}
+	}
+	return triggers
+}
+
+// splitFrontmatter parses the frontmatter of markdown content into single-line
+// values (lists are joined with ", ") and returns the body after it
+func splitFrontmatter(content string) (map[string]string, string) {
+	doc := frontmatter.Split(strings.ReplaceAll(content, "\r\n", "\n"))
+	fields := doc.Values()
+	// Argument hints are written like "[message]", which YAML reads as a list
+	for _, line := range strings.Split(doc.Frontmatter, "\n") {
+		if hint, ok := strings.CutPrefix(line, "argument-hint:"); ok {
+			fields["argument-hint"] = strings.Trim(strings.TrimSpace(hint), `"'`)
+		}
+	}
+	return fields, doc.Body
+}
+
+// splitList splits a comma-separated list, such as allowed-tools
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// scanBody returns the headings outside code blocks and the commands in shell code blocks
+func scanBody(body string) (headings, runs []string) {
+	inCode, shell := false, false
+	fence := ""
+	topLevel := 0
+	type heading struct {
+		level int
+		text  string
+	}
+	var found []heading
+
+	for _, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if !inCode && (strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")) {
+			inCode, fence = true, trimmed[:3]
+			lang := strings.ToLower(strings.Fields(strings.TrimLeft(trimmed, "`~") + " ")[0])
+			shell = shellLanguages[lang]
+			continue
+		}
+		if inCode {
+			if strings.HasPrefix(trimmed, fence) {
+				inCode = false
+				continue
+			}
+			if shell {
+				if cmd := shellCommand(trimmed); cmd != "" {
+					runs = appendUnique(runs, cmd)
+				}
+			}
+			continue
+		}
+		if m := headingPattern.FindStringSubmatch(line); m != nil {
+			level := len(m[1])
+			if topLevel == 0 || level < topLevel {
+				topLevel = level
+			}
+			found = append(found, heading{level, m[2]})
+		}
+	}
+
+	for _, h := range found {
+		headings = append(headings, strings.Repeat("  ", h.level-topLevel)+h.text)
+	}
+	return headings, runs
+}
+
+// shellCommand returns the command on a line of a shell code block, without a
+// prompt, or "" for comments, output, and blank lines
+func shellCommand(line string) string {
+	if line == "" || strings.HasPrefix(line, "#") {
+		return ""
+	}
+	if cmd, ok := strings.CutPrefix(line, "$ "); ok {
+		return strings.TrimSpace(cmd)
+	}
+	return line
+}
+
+// referencedTools returns the known tools and MCP tools named in text, sorted
+func referencedTools(text string) []string {
+	var tools []string
+	for _, m := range toolRefPattern.FindAllString(text, -1) {
+		tools = appendUnique(tools, m)
+	}
+	sort.Strings(tools)
+	return tools
+}
+
+// firstParagraph returns the first paragraph of text that is not a heading or code
+func firstParagraph(body string) string {
+	var lines []string
+	inCode := false
+	for _, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inCode = !inCode
+			continue
+		}
+		if inCode || strings.HasPrefix(trimmed, "#") {
+			if len(lines) > 0 {
+				break
+			}
+			continue
+		}
+		if trimmed == "" {
+			if len(lines) > 0 {
+				break
+			}
+			continue
+		}
+		lines = append(lines, trimmed)
+	}
+	return strings.Join(lines, " ")
+}
+
+// sentences splits text into sentences ending with '.', '!', or '?'
+func sentences(text string) []string {
+	var result []string
+	start := 0
+	for i, r := range text {
+		if (r == '.' || r == '!' || r == '?') && (i+1 == len(text) || text[i+1] == ' ') {
+			if s := strings.TrimSpace(text[start : i+1]); s != "" {
+				result = append(result, s)
+			}
+			start = i + 1
+		}
+	}
+	if s := strings.TrimSpace(text[start:]); s != "" {
+		result = append(result, s)
+	}
+	return result
+}
+
+// appendUnique appends s to list unless it is already there
+func appendUnique(list []string, s string) []string {
+	for _, item := range list {
+		if item == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
+
+// mergeSorted returns the sorted union of two lists
+func mergeSorted(a, b []string) []string {
+	for _, s := range b {
+		a = appendUnique(a, s)
+	}
+	sort.Strings(a)
+	return a
+}
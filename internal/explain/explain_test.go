@@ -0,0 +1,122 @@
+package explain
+
+import (
+	"reflect"
+	"testing"
+)
+
+const skillContent = `---
+name: web-fetch
+description: Fetch web pages. Use when the user shares a URL.
+allowed-tools: Bash, WebFetch
+model: haiku
+---
+# Web Fetch
+
+Downloads pages with curl.
+
+## Usage
+
+` + "```bash" + `
+# Download a page
+$ curl -sL "$URL"
+curl -sL "$URL"
+` + "```" + `
+
+` + "```json" + `
+{"Read": true}
+` + "```" + `
+
+### Notes
+Prefer WebFetch; fall back to Read for local files, or mcp__browser_open.
+`
+
+func TestMarkdownSkill(t *testing.T) {
+	s := Markdown("skill", "web-fetch", skillContent)
+
+	want := &Summary{
+		Type:            "skill",
+		Name:            "web-fetch",
+		Description:     "Fetch web pages. Use when the user shares a URL.",
+		Triggers:        []string{"When a request matches its description", "Use when the user shares a URL."},
+		Runs:            []string{`curl -sL "$URL"`},
+		AllowedTools:    []string{"Bash", "WebFetch"},
+		ReferencedTools: []string{"Read", "WebFetch", "mcp__browser_open"},
+		Model:           "haiku",
+		Headings:        []string{"Web Fetch", "  Usage", "    Notes"},
+		Frontmatter: map[string]string{
+			"name":          "web-fetch",
+			"description":   "Fetch web pages. Use when the user shares a URL.",
+			"allowed-tools": "Bash, WebFetch",
+			"model":         "haiku",
+		},
+	}
+	if !reflect.DeepEqual(s, want) {
+		t.Errorf("Markdown() =\n%+v\nwant\n%+v", s, want)
+	}
+}
+
+func TestMarkdownCommand(t *testing.T) {
+	content := "---\nargument-hint: [message]\n---\n# Commit\n\nCommit staged changes.\n\n- Status: !`git status --short`\n\nWrite a message for $ARGUMENTS.\n"
+	s := Markdown("command", "commit", content)
+
+	if s.Description != "Commit staged changes." {
+		t.Errorf("Description = %q, want the first paragraph", s.Description)
+	}
+	wantTriggers := []string{"When typed as /commit [message]", "Takes arguments ($ARGUMENTS)"}
+	if !reflect.DeepEqual(s.Triggers, wantTriggers) {
+		t.Errorf("Triggers = %v, want %v", s.Triggers, wantTriggers)
+	}
+	if !reflect.DeepEqual(s.Runs, []string{"git status --short"}) {
+		t.Errorf("Runs = %v, want the !` command", s.Runs)
+	}
+}
+
+func TestMarkdownWithoutFrontmatter(t *testing.T) {
+	s := Markdown("agent", "helper", "Answers questions.\n\nUse Grep to search.")
+	if s.Description != "Answers questions." || len(s.Runs) != 0 || !reflect.DeepEqual(s.ReferencedTools, []string{"Grep"}) {
+		t.Errorf("Markdown() = %+v", s)
+	}
+
+	// Unquoted colons break YAML; simple key: value parsing still finds the description
+	s = Markdown("agent", "reviewer", "---\ndescription: Reviews code: use proactively\n  extra: [\n---\nBody")
+	if s.Description != "Reviews code: use proactively" {
+		t.Errorf("Description = %q, want the fallback parse", s.Description)
+	}
+	if !reflect.DeepEqual(s.Triggers, []string{"When Claude delegates a task matching its description", "Reviews code: use proactively"}) {
+		t.Errorf("Triggers = %v", s.Triggers)
+	}
+}
+
+func TestHook(t *testing.T) {
+	s := Hook("pre-bash-0", "PreToolUse", "Write|Edit", []string{"~/.claude/hooks/fmt.sh"})
+	if !reflect.DeepEqual(s.Triggers, []string{"On PreToolUse of tools matching Write|Edit"}) {
+		t.Errorf("Triggers = %v", s.Triggers)
+	}
+	if !reflect.DeepEqual(s.ReferencedTools, []string{"Edit", "Write"}) {
+		t.Errorf("ReferencedTools = %v, want the matched tools", s.ReferencedTools)
+	}
+	if !reflect.DeepEqual(s.Runs, []string{"~/.claude/hooks/fmt.sh"}) {
+		t.Errorf("Runs = %v", s.Runs)
+	}
+
+	if s := Hook("stop-0", "Stop", "", nil); !reflect.DeepEqual(s.Triggers, []string{"On Stop"}) || s.Runs == nil {
+		t.Errorf("Hook(Stop) = %+v", s)
+	}
+}
+
+func TestScript(t *testing.T) {
+	content := "#!/bin/sh\n# Hook: PostToolUse\n# Matcher: Edit\nif [ -n \"$FILE\" ]; then\n  gofmt -w \"$FILE\"\nfi\n"
+	s := Script("fmt.sh", "PostToolUse", "Edit", content)
+	want := []string{`if [ -n "$FILE" ]; then`, `gofmt -w "$FILE"`}
+	if !reflect.DeepEqual(s.Runs, want) {
+		t.Errorf("Runs = %v, want %v", s.Runs, want)
+	}
+	if !reflect.DeepEqual(s.Triggers, []string{"On PostToolUse of tools matching Edit"}) {
+		t.Errorf("Triggers = %v", s.Triggers)
+	}
+
+	if s := Script("x.sh", "", "", "echo hi"); len(s.Triggers) != 1 || s.Description != "" {
+		t.Errorf("Script() without an event = %+v", s)
+	}
+}
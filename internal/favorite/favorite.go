@@ -0,0 +1,115 @@
+package favorite
+
+import (
+	"sort"
+
+	"github.com/itda-skills/jindo/pkg/config"
+)
+
+// ConfigKey is the config key holding the favorites list
+const ConfigKey = "jindo.favorites"
+
+// Set holds favorite resource and package names
+// Names are matched exactly: a skill ID, command or agent name,
+// or a namespaced package name (e.g., affa-ever--web-fetch)
+type Set struct {
+	names map[string]bool
+}
+
+// Load reads favorites from the config file
+// Returns an empty set if the config file doesn't exist
+func Load() (*Set, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	return fromConfig(cfg), nil
+}
+
+// fromConfig builds a set from a loaded config
+func fromConfig(cfg *config.Config) *Set {
+	s := &Set{names: make(map[string]bool)}
+	for _, name := range cfg.GetStringSlice(ConfigKey) {
+		s.names[name] = true
+	}
+	return s
+}
+
+// Contains reports whether name is a favorite
+func (s *Set) Contains(name string) bool {
+	if s == nil {
+		return false
+	}
+	return s.names[name]
+}
+
+// Names returns all favorite names in sorted order
+func (s *Set) Names() []string {
+	if s == nil {
+		return nil
+	}
+	names := make([]string, 0, len(s.names))
+	for name := range s.names {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Add adds names to the favorites and saves the config
+// Returns the names that were newly added
+func Add(names ...string) ([]string, error) {
+	return update(func(s *Set) []string {
+		var changed []string
+		for _, name := range names {
+			if !s.names[name] {
+				s.names[name] = true
+				changed = append(changed, name)
+			}
+		}
+		return changed
+	})
+}
+
+// Remove removes names from the favorites and saves the config
+// Returns the names that were actually removed
+func Remove(names ...string) ([]string, error) {
+	return update(func(s *Set) []string {
+		var changed []string
+		for _, name := range names {
+			if s.names[name] {
+				delete(s.names, name)
+				changed = append(changed, name)
+			}
+		}
+		return changed
+	})
+}
+
+// update loads the config, applies fn to the favorites and saves if anything changed
+func update(fn func(s *Set) []string) ([]string, error) {
+	// Favorites are saved to the global config, so the project layer is not read here
+	cfg, err := config.LoadGlobal()
+	if err != nil {
+		return nil, err
+	}
+
+	s := fromConfig(cfg)
+	changed := fn(s)
+	if len(changed) == 0 {
+		return nil, nil
+	}
+
+	if len(s.names) == 0 {
+		if err := cfg.Delete(ConfigKey); err != nil && err != config.ErrKeyNotFound {
+			return nil, err
+		}
+	} else if err := cfg.Set(ConfigKey, s.Names()); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Save(); err != nil {
+		return nil, err
+	}
+	return changed, nil
+}
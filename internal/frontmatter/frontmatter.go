@@ -0,0 +1,272 @@
+// Package frontmatter reads the YAML frontmatter of skill, command, and agent
+// markdown files. Frontmatter that is not valid YAML, such as a description
+// with an unquoted colon, is read line by line as "key: value" instead, so
+// hand-written files still load.
+package frontmatter
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Delimiter opens and closes a frontmatter block
+const Delimiter = "---"
+
+// Document is markdown content split into its frontmatter and body
+type Document struct {
+	// Frontmatter is the text between the delimiters, without them
+	Frontmatter string
+	// Found is false if the content has no closed frontmatter block
+	Found bool
+	// Body is the content after the closing delimiter, or all of it without one
+	Body string
+	// BodyOffset is the byte offset of Body in the content
+	BodyOffset int
+	// BodyLine is the 1-based line number of the first line of Body
+	BodyLine int
+}
+
+// Split separates the frontmatter of markdown content from its body. The
+// frontmatter starts on the first line, and lines of only "---" open and
+// close it.
+func Split(content string) Document {
+	doc := Document{Body: content, BodyLine: 1}
+
+	first, rest, ok := strings.Cut(content, "\n")
+	if !ok || strings.TrimSpace(first) != Delimiter {
+		return doc
+	}
+
+	offset := len(first) + 1
+	start := offset
+	for line := 2; ; line++ {
+		text, next, more := strings.Cut(rest, "\n")
+		if strings.TrimSpace(text) == Delimiter {
+			doc.Found = true
+			doc.Frontmatter = strings.TrimSuffix(content[start:offset], "\n")
+			doc.BodyOffset = offset + len(text)
+			doc.BodyLine = line
+			if more {
+				doc.BodyOffset++
+				doc.BodyLine++
+			}
+			doc.Body = content[doc.BodyOffset:]
+			return doc
+		}
+		if !more {
+			return doc
+		}
+		offset += len(text) + 1
+		rest = next
+	}
+}
+
+// Unmarshal decodes the frontmatter into v, as yaml.Unmarshal does. If the
+// frontmatter is not valid YAML, each top-level "key: value" line is decoded
+// as a string instead. Content without frontmatter leaves v unchanged.
+func (d Document) Unmarshal(v any) error {
+	if d.Frontmatter == "" {
+		return nil
+	}
+	if err := yaml.Unmarshal([]byte(d.Frontmatter), v); err == nil {
+		return nil
+	}
+	return simpleNode(d.Frontmatter).Decode(v)
+}
+
+// Parse splits markdown content and decodes its frontmatter into v
+func Parse(content string, v any) (Document, error) {
+	doc := Split(content)
+	return doc, doc.Unmarshal(v)
+}
+
+// Values decodes the frontmatter into single-line strings by key: lists are
+// joined with ", " and whitespace runs in strings are collapsed. Keys without a
+// value are left out.
+func (d Document) Values() map[string]string {
+	var raw map[string]any
+	_ = d.Unmarshal(&raw) // Line by line values always decode into a map
+
+	values := make(map[string]string, len(raw))
+	for key, value := range raw {
+		if v := formatValue(value); v != "" {
+			values[key] = v
+		}
+	}
+	return values
+}
+
+// formatValue renders a frontmatter value on one line
+func formatValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []any:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = formatValue(item)
+		}
+		return strings.Join(parts, ", ")
+	case string:
+		return strings.Join(strings.Fields(v), " ")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// Set sets the top-level key of markdown content's frontmatter to value, a YAML
+// value written as is, and returns the updated content. An existing entry is
+// replaced in place, with its block list items or indented continuation lines;
+// a new one is added at the end of the frontmatter, and a frontmatter block is
+// added if the content has none. An empty value removes the entry.
+func Set(content, key, value string) string {
+	entry := key + ": " + value
+	doc := Split(content)
+	if !doc.Found {
+		if value == "" {
+			return content
+		}
+		return Delimiter + "\n" + entry + "\n" + Delimiter + "\n\n" + content
+	}
+
+	var lines []string
+	if doc.Frontmatter != "" {
+		lines = strings.Split(doc.Frontmatter, "\n")
+	}
+	var kept []string
+	replaced, inEntry := false, false
+	for _, line := range lines {
+		if inEntry && line != "" && (line[0] == ' ' || line[0] == '\t' || line[0] == '-') {
+			continue
+		}
+		inEntry = false
+		if name, _, ok := strings.Cut(line, ":"); ok && name == key {
+			inEntry = true
+			if !replaced && value != "" {
+				kept = append(kept, entry)
+			}
+			replaced = true
+			continue
+		}
+		kept = append(kept, line)
+	}
+	if !replaced && value != "" {
+		kept = append(kept, entry)
+	}
+
+	start := strings.Index(content, "\n") + 1
+	closing := content[start+len(doc.Frontmatter):]
+	if doc.Frontmatter != "" {
+		closing = closing[1:] // The newline before the closing delimiter
+	}
+	frontmatter := strings.Join(kept, "\n")
+	if len(kept) > 0 {
+		frontmatter += "\n"
+	}
+	return content[:start] + frontmatter + closing
+}
+
+// NormalizeTags trims whitespace and quotes from tags and drops empty ones and
+// duplicates, which differ only in case from an earlier tag (as HasTag matches)
+func NormalizeTags(tags []string) []string {
+	var result []string
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		tag = strings.Trim(strings.TrimSpace(tag), `"'`)
+		if tag == "" || seen[strings.ToLower(tag)] {
+			continue
+		}
+		seen[strings.ToLower(tag)] = true
+		result = append(result, tag)
+	}
+	return result
+}
+
+// HasTag reports whether tags contains tag, ignoring case
+func HasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// simpleNode reads frontmatter line by line into a mapping of strings trimmed
+// of quotes, the last of repeated keys winning. Indented lines, such as list items and
+// continuations, are skipped.
+func simpleNode(frontmatter string) *yaml.Node {
+	var keys []string
+	values := make(map[string]string)
+	for _, line := range strings.Split(frontmatter, "\n") {
+		if line == "" || line[0] == ' ' || line[0] == '\t' || line[0] == '-' || line[0] == '#' {
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			continue
+		}
+		if _, seen := values[key]; !seen {
+			keys = append(keys, key)
+		}
+		values[key] = strings.Trim(strings.TrimSpace(value), `"'`)
+	}
+
+	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for _, key := range keys {
+		node.Content = append(node.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: values[key]},
+		)
+	}
+	return node
+}
+
+// List is a frontmatter list, written as a YAML sequence ([Bash, Read] or one
+// "- item" per line) or as a comma-separated string ("Bash, Read"). Items are
+// trimmed of whitespace and quotes, and empty ones dropped.
+type List []string
+
+// UnmarshalYAML implements yaml.Unmarshaler
+func (l *List) UnmarshalYAML(node *yaml.Node) error {
+	var items []string
+	switch node.Kind {
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			var s string
+			if err := item.Decode(&s); err != nil {
+				return err
+			}
+			items = append(items, s)
+		}
+	case yaml.ScalarNode:
+		if node.Tag == "!!null" {
+			break
+		}
+		// "[a, b]" read line by line is still a list
+		value := strings.TrimSpace(node.Value)
+		if strings.HasPrefix(value, "[") && strings.HasSuffix(value, "]") {
+			value = value[1 : len(value)-1]
+		}
+		items = strings.Split(value, ",")
+	default:
+		var s string
+		return node.Decode(&s)
+	}
+
+	*l = nil
+	for _, item := range items {
+		if item = strings.Trim(strings.TrimSpace(item), `"'`); item != "" {
+			*l = append(*l, item)
+		}
+	}
+	return nil
+}
+
+// String returns the items joined by ", "
+func (l List) String() string {
+	return strings.Join(l, ", ")
+}
@@ -0,0 +1,146 @@
+package frontmatter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		found    bool
+		fm       string
+		body     string
+		bodyLine int
+	}{
+		{"frontmatter", "---\nname: a\ndescription: b\n---\n# Title\n", true, "name: a\ndescription: b", "# Title\n", 5},
+		{"empty frontmatter", "---\n---\nbody", true, "", "body", 3},
+		{"no body", "---\nname: a\n---", true, "name: a", "", 3},
+		{"crlf", "---\r\nname: a\r\n---\r\nbody", true, "name: a\r", "body", 4},
+		{"no frontmatter", "# Title\nbody", false, "", "# Title\nbody", 1},
+		{"unclosed", "---\nname: a\nbody", false, "", "---\nname: a\nbody", 1},
+		{"delimiter only", "---", false, "", "---", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := Split(tt.content)
+			if doc.Found != tt.found || doc.Frontmatter != tt.fm || doc.Body != tt.body || doc.BodyLine != tt.bodyLine {
+				t.Errorf("Split() = %+v", doc)
+			}
+			if tt.content[doc.BodyOffset:] != doc.Body {
+				t.Errorf("BodyOffset %d does not point at the body", doc.BodyOffset)
+			}
+		})
+	}
+}
+
+type meta struct {
+	Name  string `yaml:"name"`
+	Desc  string `yaml:"description"`
+	Tools List   `yaml:"allowed-tools"`
+	Tags  List   `yaml:"tags"`
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    meta
+	}{
+		{
+			"csv list",
+			"---\nname: a\nallowed-tools: Bash, Read\ntags: [deploy, ci]\n---\n",
+			meta{Name: "a", Tools: List{"Bash", "Read"}, Tags: List{"deploy", "ci"}},
+		},
+		{
+			"block list",
+			"---\nname: a\nallowed-tools:\n  - Bash\n  - Read\n---\n",
+			meta{Name: "a", Tools: List{"Bash", "Read"}},
+		},
+		{
+			"invalid yaml falls back to lines",
+			"---\nname: a\ndescription: Use when: deploying\nallowed-tools: Bash,Read\ntags: [deploy, 'ci']\n---\n",
+			meta{Name: "a", Desc: "Use when: deploying", Tools: List{"Bash", "Read"}, Tags: List{"deploy", "ci"}},
+		},
+		{
+			"empty list",
+			"---\nname: a\nallowed-tools:\n---\n",
+			meta{Name: "a"},
+		},
+		{"no frontmatter", "# a\n", meta{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got meta
+			if _, err := Parse(tt.content, &got); err != nil {
+				t.Fatalf("Parse() error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValues(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    map[string]string
+	}{
+		{
+			"yaml",
+			"---\nname: a\ndescription: >\n  Formats\n  code\ntags: [x, y]\nmodel:\n---\n",
+			map[string]string{"name": "a", "description": "Formats code", "tags": "x, y"},
+		},
+		{
+			"invalid yaml",
+			"---\ndescription: Use when: deploying\nmodel: \"opus\"\n---\n",
+			map[string]string{"description": "Use when: deploying", "model": "opus"},
+		},
+		{"no frontmatter", "# a\n", map[string]string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Split(tt.content).Values(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Values() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSet(t *testing.T) {
+	tests := []struct {
+		name, content, key, value, want string
+	}{
+		{"replace", "---\nname: a\ndescription: b\n---\nbody\n", "name", "c", "---\nname: c\ndescription: b\n---\nbody\n"},
+		{"add", "---\nname: a\n---\nbody\n", "tags", "[x]", "---\nname: a\ntags: [x]\n---\nbody\n"},
+		{"replace block list", "---\ntags:\n  - x\n  - y\nname: a\n---\n", "tags", "[z]", "---\ntags: [z]\nname: a\n---\n"},
+		{"replace unindented list", "---\ntags:\n- x\nname: a\n---\n", "tags", "[z]", "---\ntags: [z]\nname: a\n---\n"},
+		{"remove", "---\nname: a\ntags: [x]\n---\nbody\n", "tags", "", "---\nname: a\n---\nbody\n"},
+		{"remove only entry", "---\ntags: [x]\n---\nbody\n", "tags", "", "---\n---\nbody\n"},
+		{"add to empty", "---\n---\nbody\n", "name", "a", "---\nname: a\n---\nbody\n"},
+		{"prefix of another key", "---\nnames: x\n---\n", "name", "a", "---\nnames: x\nname: a\n---\n"},
+		{"no frontmatter", "# Title\n", "name", "a", "---\nname: a\n---\n\n# Title\n"},
+		{"remove without frontmatter", "# Title\n", "tags", "", "# Title\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Set(tt.content, tt.key, tt.value); got != tt.want {
+				t.Errorf("Set() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeTags(t *testing.T) {
+	got := NormalizeTags([]string{" deploy ", "'ci'", "", "Deploy", "CI", `"web"`})
+	want := []string{"deploy", "ci", "web"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NormalizeTags() = %v, want %v", got, want)
+	}
+	if !HasTag(want, "WEB") || HasTag(want, "we") {
+		t.Errorf("HasTag() does not match tags ignoring case only")
+	}
+}
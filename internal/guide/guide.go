@@ -1,12 +1,16 @@
 package guide
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
 	"time"
+
+	"github.com/itda-skills/jindo/internal/basedir"
 )
 
 // GuideType represents the type of guide
@@ -27,6 +31,22 @@ type Guide struct {
 	Content   string
 	CreatedAt time.Time
 	Path      string
+	// SourceHash is the hash of the resource content the guide was generated from
+	// (see HashSource). Empty for guides not generated from a resource, and for
+	// guides cached before hashes were recorded.
+	SourceHash string
+}
+
+// HashSource returns the hash recorded with a guide for the resource content it explains
+func HashSource(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// Outdated reports whether the guide may not match the resource whose content hashes
+// to sourceHash: it was generated from different content, or its source is unknown.
+func (g *Guide) Outdated(sourceHash string) bool {
+	return sourceHash != "" && g.SourceHash != sourceHash
 }
 
 // Store manages cached guides
@@ -36,14 +56,25 @@ type Store struct {
 
 // NewStore creates a new guide store
 func NewStore() (*Store, error) {
-	home, err := os.UserHomeDir()
+	baseDir, err := basedir.ClaudePath("jindo", "guides")
 	if err != nil {
 		return nil, err
 	}
-	baseDir := filepath.Join(home, ".claude", "jindo", "guides")
 	return &Store{baseDir: baseDir}, nil
 }
 
+// CacheDirs returns the directories holding cached guides and their HTML renderings
+func CacheDirs() ([]string, error) {
+	dir, err := basedir.ClaudePath("jindo")
+	if err != nil {
+		return nil, err
+	}
+	return []string{
+		filepath.Join(dir, "guides"),
+		filepath.Join(dir, "guides-html"),
+	}, nil
+}
+
 // GetDir returns the directory for a guide type
 func (s *Store) GetDir(guideType GuideType) string {
 	return filepath.Join(s.baseDir, string(guideType))
@@ -77,26 +108,29 @@ func (s *Store) Get(guideType GuideType, id string) (*Guide, error) {
 		return nil, err
 	}
 
-	// Parse frontmatter to get created_at if present
+	// Parse frontmatter to get created_at and source_hash if present
 	createdAt := info.ModTime()
 	contentStr := string(content)
 
-	if parsedTime, body, ok := parseFrontmatter(contentStr); ok {
+	parsedTime, sourceHash, body, ok := parseFrontmatter(contentStr)
+	if ok {
 		createdAt = parsedTime
-		contentStr = body
 	}
+	contentStr = body
 
 	return &Guide{
-		Type:      guideType,
-		ID:        id,
-		Content:   contentStr,
-		CreatedAt: createdAt,
-		Path:      path,
+		Type:       guideType,
+		ID:         id,
+		Content:    contentStr,
+		CreatedAt:  createdAt,
+		Path:       path,
+		SourceHash: sourceHash,
 	}, nil
 }
 
-// Save saves a guide to cache
-func (s *Store) Save(guideType GuideType, id string, content string) (*Guide, error) {
+// Save saves a guide to cache. sourceHash is the HashSource of the resource content
+// the guide was generated from, or empty if it explains no particular resource.
+func (s *Store) Save(guideType GuideType, id string, content string, sourceHash string) (*Guide, error) {
 	dir := s.GetDir(guideType)
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return nil, err
@@ -105,25 +139,30 @@ func (s *Store) Save(guideType GuideType, id string, content string) (*Guide, er
 	path := s.GetPath(guideType, id)
 	now := time.Now()
 
-	// Add frontmatter with timestamp
+	// Add frontmatter with timestamp and source hash
+	var source string
+	if sourceHash != "" {
+		source = fmt.Sprintf("source_hash: %s\n", sourceHash)
+	}
 	fullContent := fmt.Sprintf(`---
 type: %s
 id: %s
 created_at: %s
----
+%s---
 
-%s`, guideType, id, now.Format(time.RFC3339), content)
+%s`, guideType, id, now.Format(time.RFC3339), source, content)
 
 	if err := os.WriteFile(path, []byte(fullContent), 0644); err != nil {
 		return nil, err
 	}
 
 	return &Guide{
-		Type:      guideType,
-		ID:        id,
-		Content:   content,
-		CreatedAt: now,
-		Path:      path,
+		Type:       guideType,
+		ID:         id,
+		Content:    content,
+		CreatedAt:  now,
+		Path:       path,
+		SourceHash: sourceHash,
 	}, nil
 }
 
@@ -185,35 +224,43 @@ func FormatAge(t time.Time) string {
 	}
 }
 
-// parseFrontmatter extracts created_at from frontmatter
-func parseFrontmatter(content string) (time.Time, string, bool) {
+// parseFrontmatter extracts created_at and source_hash from frontmatter
+func parseFrontmatter(content string) (time.Time, string, string, bool) {
 	if !strings.HasPrefix(content, "---\n") {
-		return time.Time{}, content, false
+		return time.Time{}, "", content, false
 	}
 
 	endIdx := strings.Index(content[4:], "\n---")
 	if endIdx == -1 {
-		return time.Time{}, content, false
+		return time.Time{}, "", content, false
 	}
 
 	frontmatter := content[4 : 4+endIdx]
 	body := strings.TrimPrefix(content[4+endIdx+4:], "\n")
 
+	// Parse source_hash
+	var sourceHash string
+	if matches := sourceHashPattern.FindStringSubmatch(frontmatter); len(matches) == 2 {
+		sourceHash = strings.TrimSpace(matches[1])
+	}
+
 	// Parse created_at
 	re := regexp.MustCompile(`created_at:\s*(.+)`)
 	matches := re.FindStringSubmatch(frontmatter)
 	if len(matches) < 2 {
-		return time.Time{}, body, false
+		return time.Time{}, sourceHash, body, false
 	}
 
 	t, err := time.Parse(time.RFC3339, strings.TrimSpace(matches[1]))
 	if err != nil {
-		return time.Time{}, body, false
+		return time.Time{}, sourceHash, body, false
 	}
 
-	return t, body, true
+	return t, sourceHash, body, true
 }
 
+var sourceHashPattern = regexp.MustCompile(`source_hash:\s*(.+)`)
+
 // sanitizeFilename makes a string safe for use as a filename
 func sanitizeFilename(s string) string {
 	// Replace problematic characters
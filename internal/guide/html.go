@@ -8,6 +8,8 @@ import (
 	"runtime"
 	"strings"
 	"time"
+
+	"github.com/itda-skills/jindo/internal/basedir"
 )
 
 // HTMLTemplate is the template for HTML output
@@ -177,12 +179,10 @@ type HTMLData struct {
 // GenerateHTML generates an HTML file from markdown content
 func GenerateHTML(guideType GuideType, id string, markdownContent string, createdAt time.Time) (string, error) {
 	// Get HTML output directory
-	home, err := os.UserHomeDir()
+	htmlDir, err := basedir.ClaudePath("jindo", "guides-html", string(guideType))
 	if err != nil {
 		return "", err
 	}
-
-	htmlDir := filepath.Join(home, ".claude", "jindo", "guides-html", string(guideType))
 	if err := os.MkdirAll(htmlDir, 0755); err != nil {
 		return "", err
 	}
@@ -2,6 +2,9 @@ package guide
 
 import (
 	"bufio"
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -103,6 +106,26 @@ func RunClaudeWithSpinner(systemPrompt, userPrompt string) (string, error) {
 	return output.String(), nil
 }
 
+// RunClaude runs claude in print mode and returns its output, without a spinner,
+// for generating guides in the background. Claude's stderr is part of the error.
+func RunClaude(ctx context.Context, systemPrompt, userPrompt string) (string, error) {
+	cmd := exec.CommandContext(ctx, "claude",
+		"-p", userPrompt,
+		"--system-prompt", systemPrompt,
+		"--output-format", "text",
+	)
+
+	output, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(bytes.TrimSpace(exitErr.Stderr)) > 0 {
+			return "", fmt.Errorf("%w: %s", err, bytes.TrimSpace(exitErr.Stderr))
+		}
+		return "", err
+	}
+	return string(output), nil
+}
+
 // PrintGuide prints the guide content with formatting
 func PrintGuide(title string, content string, createdAt time.Time, cached bool) {
 	// Header
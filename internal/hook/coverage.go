@@ -0,0 +1,45 @@
+package hook
+
+import (
+	"regexp"
+	"strings"
+)
+
+// IsToolEvent reports whether rules for eventType are matched against a tool name.
+// Rules for other events fire on every occurrence of the event.
+func IsToolEvent(eventType EventType) bool {
+	return eventType == PreToolUse || eventType == PostToolUse
+}
+
+// MatchesTool reports whether a rule matcher selects tool. An empty matcher or "*"
+// matches every tool; otherwise the matcher is a regular expression that must match
+// the whole tool name (e.g., "Edit|Write"). A matcher that is not a valid regular
+// expression is treated as a "|"-separated list of tool names.
+func MatchesTool(matcher, tool string) bool {
+	if matcher == "" || matcher == "*" {
+		return true
+	}
+	re, err := regexp.Compile("^(?:" + matcher + ")$")
+	if err != nil {
+		for _, alt := range strings.Split(matcher, "|") {
+			if strings.TrimSpace(alt) == tool {
+				return true
+			}
+		}
+		return false
+	}
+	return re.MatchString(tool)
+}
+
+// MatcherTools returns the tool names a matcher lists literally, such as "Edit" and
+// "Write" in "Edit|Write". Alternatives that are patterns are skipped.
+func MatcherTools(matcher string) []string {
+	var tools []string
+	for _, alt := range strings.Split(matcher, "|") {
+		alt = strings.TrimSpace(alt)
+		if alt != "" && regexp.QuoteMeta(alt) == alt {
+			tools = append(tools, alt)
+		}
+	}
+	return tools
+}
@@ -0,0 +1,29 @@
+package hook
+
+import "github.com/itda-skills/jindo/internal/events"
+
+// Actions recorded in hook-change events
+const (
+	actionAdd     = "add"
+	actionUpdate  = "update"
+	actionDelete  = "delete"
+	actionDisable = "disable"
+	actionEnable  = "enable"
+	actionRemove  = "remove-command"
+)
+
+// emit records a change to a hook rule in the event log
+func (s *Store) emit(action string, h *Hook) {
+	data := map[string]any{
+		"action":   action,
+		"event":    string(h.EventType),
+		"settings": s.settingsPath,
+	}
+	if h.Matcher != "" {
+		data["matcher"] = h.Matcher
+	}
+	if len(h.Commands) > 0 {
+		data["commands"] = h.Commands
+	}
+	events.Emit(events.Event{Type: events.HookChange, Subject: h.Name, Data: data})
+}
@@ -14,9 +14,10 @@ const historySubDir = ".history/hooks"
 
 // Version represents a single version in history
 type Version struct {
-	Number    int       `json:"number"`
-	Timestamp time.Time `json:"timestamp"`
-	Filename  string    `json:"filename"`
+	Number       int       `json:"number"`
+	Timestamp    time.Time `json:"timestamp"`
+	Filename     string    `json:"filename"`
+	SettingsPath string    `json:"settings_path,omitempty"` // settings.json the version was saved from
 }
 
 // HookSnapshot represents a saved hook configuration
@@ -58,6 +59,11 @@ func sanitizeHookName(name string) string {
 	return name
 }
 
+// SettingsPath returns the settings.json path of the scope this history belongs to
+func (h *HistoryManager) SettingsPath() string {
+	return filepath.Join(h.claudeDir, "settings.json")
+}
+
 // getHistoryDir returns the .history/hooks/hook-name directory path
 func (h *HistoryManager) getHistoryDir() string {
 	return filepath.Join(h.claudeDir, historySubDir, sanitizeHookName(h.hookName))
@@ -150,9 +156,10 @@ func (h *HistoryManager) SaveVersion(hook *Hook) (*Version, error) {
 
 	// Update manifest
 	version := Version{
-		Number:    nextNum,
-		Timestamp: now,
-		Filename:  filename,
+		Number:       nextNum,
+		Timestamp:    now,
+		Filename:     filename,
+		SettingsPath: h.SettingsPath(),
 	}
 	manifest.Versions = append(manifest.Versions, version)
 
@@ -220,6 +227,11 @@ func (h *HistoryManager) GetLatestVersion() (*Version, error) {
 	return &manifest.Versions[len(manifest.Versions)-1], nil
 }
 
+// VersionPath returns the file path of a version
+func (h *HistoryManager) VersionPath(v *Version) string {
+	return filepath.Join(h.getHistoryDir(), v.Filename)
+}
+
 // HasHistory checks if any history exists
 func (h *HistoryManager) HasHistory() bool {
 	manifest, err := h.loadManifest()
@@ -5,7 +5,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/settings"
 )
 
 // EventType represents the type of hook event
@@ -67,6 +71,8 @@ func ParseEventType(s string) (EventType, error) {
 type HookCommand struct {
 	Type    string `json:"type"`
 	Command string `json:"command"`
+
+	index int // Position in the rule's hooks array in settings.json
 }
 
 // HookRule represents a single hook rule with matcher and commands
@@ -74,19 +80,32 @@ type HookCommand struct {
 type HookRule struct {
 	Matcher string        `json:"matcher"`
 	Hooks   []HookCommand `json:"hooks"`
+
+	index int // Position in the event's rules array in settings.json
 }
 
 // Hook represents a named hook configuration for display/management
 type Hook struct {
 	Name      string    `json:"name"`
 	EventType EventType `json:"event_type"`
-	Matcher   string    `json:"matcher"`  // pattern: "Bash", "Edit|Write", "*"
-	Commands  []string  `json:"commands"` // from hooks[].command
+	Matcher   string    `json:"matcher"`            // pattern: "Bash", "Edit|Write", "*"
+	Commands  []string  `json:"commands"`           // from hooks[].command
+	Disabled  bool      `json:"disabled,omitempty"` // Moved to hooks_disabled by Disable
 }
 
+const (
+	hooksKey         = "hooks"
+	disabledHooksKey = "hooks_disabled" // Rules set aside by Disable; Claude Code ignores them
+	positionKey      = "position"       // Index a disabled rule had among the event's active rules
+
+	// DisabledPrefix starts the names of disabled hooks, keeping them apart from active ones
+	DisabledPrefix = "disabled-"
+)
+
 // Settings represents the Claude Code settings.json structure
 type Settings struct {
-	Hooks map[EventType][]HookRule `json:"hooks,omitempty"`
+	Hooks    map[EventType][]HookRule `json:"hooks,omitempty"`
+	Disabled map[EventType][]HookRule `json:"hooks_disabled,omitempty"`
 	// Other settings fields can be added here
 	Other map[string]interface{} `json:"-"`
 }
@@ -114,8 +133,8 @@ func (s *Store) expandPath() (string, error) {
 	return path, nil
 }
 
-// readSettings reads and parses settings.json
-func (s *Store) readSettings() (*Settings, map[string]interface{}, error) {
+// readSettings reads and parses settings.json, returning the document for editing
+func (s *Store) readSettings() (*Settings, *jsonDoc, error) {
 	path, err := s.expandPath()
 	if err != nil {
 		return nil, nil, err
@@ -124,120 +143,118 @@ func (s *Store) readSettings() (*Settings, map[string]interface{}, error) {
 	content, err := os.ReadFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return &Settings{Hooks: make(map[EventType][]HookRule)}, make(map[string]interface{}), nil
+			return &Settings{Hooks: make(map[EventType][]HookRule), Disabled: make(map[EventType][]HookRule)}, newJSONDoc(nil), nil
 		}
 		return nil, nil, err
 	}
 
-	// Parse into generic map to preserve unknown fields
+	// Parse into generic map to tolerate unknown fields
 	var raw map[string]interface{}
-	if err := json.Unmarshal(content, &raw); err != nil {
-		return nil, nil, fmt.Errorf("failed to parse settings.json: %w", err)
+	if len(strings.TrimSpace(string(content))) > 0 {
+		if err := json.Unmarshal(content, &raw); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse settings.json: %w", err)
+		}
+	}
+
+	settings := &Settings{
+		Hooks:    parseRules(raw[hooksKey]),
+		Disabled: parseRules(raw[disabledHooksKey]),
 	}
 
-	// Parse hooks
-	settings := &Settings{Hooks: make(map[EventType][]HookRule)}
+	return settings, newJSONDoc(content), nil
+}
 
-	if hooksRaw, ok := raw["hooks"].(map[string]interface{}); ok {
-		for eventType, rules := range hooksRaw {
-			rulesArr, ok := rules.([]interface{})
+// parseRules parses a hooks object ({"<event>": [rules]}) from settings.json
+func parseRules(value interface{}) map[EventType][]HookRule {
+	result := make(map[EventType][]HookRule)
+	hooksRaw, ok := value.(map[string]interface{})
+	if !ok {
+		return result
+	}
+
+	for eventType, rules := range hooksRaw {
+		rulesArr, ok := rules.([]interface{})
+		if !ok {
+			continue
+		}
+
+		var hookRules []HookRule
+		for i, r := range rulesArr {
+			ruleMap, ok := r.(map[string]interface{})
 			if !ok {
 				continue
 			}
 
-			var hookRules []HookRule
-			for _, r := range rulesArr {
-				ruleMap, ok := r.(map[string]interface{})
-				if !ok {
-					continue
-				}
-
-				rule := HookRule{}
+			rule := HookRule{index: i}
 
-				// Parse matcher string: "Bash", "Edit|Write", "*"
-				if matcher, ok := ruleMap["matcher"].(string); ok {
-					rule.Matcher = matcher
-				}
+			// Parse matcher string: "Bash", "Edit|Write", "*"
+			if matcher, ok := ruleMap["matcher"].(string); ok {
+				rule.Matcher = matcher
+			}
 
-				// Parse hooks array: [{"type": "command", "command": "..."}]
-				if hooksArr, ok := ruleMap["hooks"].([]interface{}); ok {
-					for _, h := range hooksArr {
-						if hookMap, ok := h.(map[string]interface{}); ok {
-							hookCmd := HookCommand{}
-							if t, ok := hookMap["type"].(string); ok {
-								hookCmd.Type = t
-							}
-							if cmd, ok := hookMap["command"].(string); ok {
-								hookCmd.Command = cmd
-							}
-							if hookCmd.Command != "" {
-								rule.Hooks = append(rule.Hooks, hookCmd)
-							}
+			// Parse hooks array: [{"type": "command", "command": "..."}]
+			if hooksArr, ok := ruleMap["hooks"].([]interface{}); ok {
+				for j, h := range hooksArr {
+					if hookMap, ok := h.(map[string]interface{}); ok {
+						hookCmd := HookCommand{index: j}
+						if t, ok := hookMap["type"].(string); ok {
+							hookCmd.Type = t
+						}
+						if cmd, ok := hookMap["command"].(string); ok {
+							hookCmd.Command = cmd
+						}
+						if hookCmd.Command != "" {
+							rule.Hooks = append(rule.Hooks, hookCmd)
 						}
 					}
 				}
-
-				hookRules = append(hookRules, rule)
 			}
-			settings.Hooks[EventType(eventType)] = hookRules
+
+			hookRules = append(hookRules, rule)
 		}
+		result[EventType(eventType)] = hookRules
 	}
-
-	return settings, raw, nil
+	return result
 }
 
-// writeSettings writes settings back to settings.json
-func (s *Store) writeSettings(settings *Settings, raw map[string]interface{}) error {
+// writeSettings writes the edited document back to settings.json, atomically
+// and after a backup of the previous content (see settings.Write)
+func (s *Store) writeSettings(doc *jsonDoc) error {
 	path, err := s.expandPath()
 	if err != nil {
 		return err
 	}
+	return settings.Write(path, doc.content)
+}
 
-	// Convert hooks to JSON format
-	hooksMap := make(map[string][]map[string]interface{})
-	for eventType, rules := range settings.Hooks {
-		if len(rules) == 0 {
-			continue
-		}
+// rulePath returns the path of a rule within settings.json
+func rulePath(eventType EventType, rule HookRule) jsonPath {
+	return sectionRulePath(hooksKey, eventType, rule)
+}
 
-		var rulesOutput []map[string]interface{}
-		for _, rule := range rules {
-			ruleMap := map[string]interface{}{
-				"matcher": rule.Matcher, // string: "Bash", "Edit|Write", "*"
-				"hooks": func() []map[string]interface{} {
-					var hooks []map[string]interface{}
-					for _, h := range rule.Hooks {
-						hooks = append(hooks, map[string]interface{}{
-							"type":    h.Type,
-							"command": h.Command,
-						})
-					}
-					return hooks
-				}(),
-			}
-			rulesOutput = append(rulesOutput, ruleMap)
-		}
-		hooksMap[string(eventType)] = rulesOutput
-	}
+// sectionRulePath returns the path of a rule within a hooks section (hooks or hooks_disabled)
+func sectionRulePath(section string, eventType EventType, rule HookRule) jsonPath {
+	return jsonPath{section, string(eventType), rule.index}
+}
 
-	if len(hooksMap) > 0 {
-		raw["hooks"] = hooksMap
-	} else {
-		delete(raw, "hooks")
-	}
+// pruneEvent removes the rules array of eventType if it is empty, and the
+// hooks object if no events are left
+func pruneEvent(doc *jsonDoc, eventType EventType) error {
+	return pruneSectionEvent(doc, hooksKey, eventType)
+}
 
-	content, err := json.MarshalIndent(raw, "", "  ")
-	if err != nil {
+// pruneSectionEvent is pruneEvent for a hooks section (hooks or hooks_disabled)
+func pruneSectionEvent(doc *jsonDoc, section string, eventType EventType) error {
+	if n, found, err := doc.len(jsonPath{section, string(eventType)}); err != nil || (found && n > 0) {
 		return err
 	}
-
-	// Ensure directory exists
-	dir := filepath.Dir(path)
-	if err := os.MkdirAll(dir, 0755); err != nil {
+	if err := doc.remove(jsonPath{section, string(eventType)}); err != nil {
 		return err
 	}
-
-	return os.WriteFile(path, content, 0644)
+	if n, found, err := doc.len(jsonPath{section}); err != nil || !found || n > 0 {
+		return err
+	}
+	return doc.remove(jsonPath{section})
 }
 
 // List returns all hooks as a flat list
@@ -246,32 +263,47 @@ func (s *Store) List() ([]*Hook, error) {
 	if err != nil {
 		return nil, err
 	}
+	return hooksFromRules(settings.Hooks, false), nil
+}
 
+// ListDisabled returns the hooks set aside by Disable. Their names start with DisabledPrefix.
+func (s *Store) ListDisabled() ([]*Hook, error) {
+	settings, _, err := s.readSettings()
+	if err != nil {
+		return nil, err
+	}
+	return hooksFromRules(settings.Disabled, true), nil
+}
+
+// hooksFromRules converts the rules of a hooks section to named hooks
+func hooksFromRules(rulesByEvent map[EventType][]HookRule, disabled bool) []*Hook {
 	var hooks []*Hook
-	for eventType, rules := range settings.Hooks {
+	for eventType, rules := range rulesByEvent {
 		for i, rule := range rules {
 			name := generateHookName(eventType, rule.Matcher, i)
-
-			var commands []string
-			for _, h := range rule.Hooks {
-				commands = append(commands, h.Command)
+			if disabled {
+				name = DisabledPrefix + name
 			}
 
 			hooks = append(hooks, &Hook{
 				Name:      name,
 				EventType: eventType,
 				Matcher:   rule.Matcher,
-				Commands:  commands,
+				Commands:  ruleCommands(rule),
+				Disabled:  disabled,
 			})
 		}
 	}
-
-	return hooks, nil
+	return hooks
 }
 
-// Get retrieves a specific hook by name
+// Get retrieves a specific hook by name, including disabled hooks
 func (s *Store) Get(name string) (*Hook, error) {
-	hooks, err := s.List()
+	list := s.List
+	if strings.HasPrefix(name, DisabledPrefix) {
+		list = s.ListDisabled
+	}
+	hooks, err := list()
 	if err != nil {
 		return nil, err
 	}
@@ -287,10 +319,19 @@ func (s *Store) Get(name string) (*Hook, error) {
 
 // Add adds a new hook rule
 func (s *Store) Add(eventType EventType, matcher string, commands []string) (*Hook, error) {
-	settings, raw, err := s.readSettings()
+	return s.Insert(eventType, matcher, commands, -1)
+}
+
+// Insert adds a new hook rule before the rule at index at among the rules of
+// eventType, or after the last one if at is negative or past it.
+func (s *Store) Insert(eventType EventType, matcher string, commands []string, at int) (*Hook, error) {
+	settings, doc, err := s.readSettings()
 	if err != nil {
 		return nil, err
 	}
+	if at < 0 {
+		at = len(settings.Hooks[eventType])
+	}
 
 	// Build hook commands
 	var hookCmds []HookCommand
@@ -306,24 +347,31 @@ func (s *Store) Add(eventType EventType, matcher string, commands []string) (*Ho
 		Hooks:   hookCmds,
 	}
 
-	settings.Hooks[eventType] = append(settings.Hooks[eventType], rule)
-
-	if err := s.writeSettings(settings, raw); err != nil {
+	idx, err := doc.insertAt(jsonPath{"hooks", string(eventType)}, at, rule)
+	if err != nil {
+		return nil, fmt.Errorf("add hook to settings.json: %w", err)
+	}
+	if err := s.pinCommands(doc, commands); err != nil {
+		return nil, err
+	}
+	if err := s.writeSettings(doc); err != nil {
 		return nil, err
 	}
 
-	idx := len(settings.Hooks[eventType]) - 1
-	return &Hook{
+	added := &Hook{
 		Name:      generateHookName(eventType, matcher, idx),
 		EventType: eventType,
 		Matcher:   matcher,
 		Commands:  commands,
-	}, nil
+	}
+	s.emit(actionAdd, added)
+	return added, nil
 }
 
-// Update updates an existing hook
+// Update updates an existing hook. Commands are changed in place, so other
+// fields of the rule and its commands (e.g., timeout) are kept.
 func (s *Store) Update(name string, matcher string, commands []string) (*Hook, error) {
-	settings, raw, err := s.readSettings()
+	settings, doc, err := s.readSettings()
 	if err != nil {
 		return nil, err
 	}
@@ -338,52 +386,285 @@ func (s *Store) Update(name string, matcher string, commands []string) (*Hook, e
 		return nil, os.ErrNotExist
 	}
 
-	// Build hook commands
-	var hookCmds []HookCommand
-	for _, cmd := range commands {
-		hookCmds = append(hookCmds, HookCommand{
-			Type:    "command",
-			Command: cmd,
-		})
+	rule := rules[idx]
+	base := rulePath(eventType, rule)
+	if rule.Matcher != matcher {
+		if err := doc.set(append(base, "matcher"), matcher); err != nil {
+			return nil, fmt.Errorf("update hook in settings.json: %w", err)
+		}
+	}
+	for i, cmd := range commands {
+		if i < len(rule.Hooks) {
+			if rule.Hooks[i].Command != cmd {
+				err = doc.set(append(base, "hooks", rule.Hooks[i].index, "command"), cmd)
+			}
+		} else {
+			err = doc.append(append(base, "hooks"), HookCommand{Type: "command", Command: cmd})
+		}
+		if err != nil {
+			return nil, fmt.Errorf("update hook in settings.json: %w", err)
+		}
+	}
+	for i := len(rule.Hooks) - 1; i >= len(commands); i-- {
+		if err := doc.remove(append(base, "hooks", rule.Hooks[i].index)); err != nil {
+			return nil, fmt.Errorf("update hook in settings.json: %w", err)
+		}
+	}
+	if err := s.pinCommands(doc, commands); err != nil {
+		return nil, err
+	}
+	if err := prunePins(doc); err != nil {
+		return nil, fmt.Errorf("update hook in settings.json: %w", err)
 	}
 
-	rules[idx].Matcher = matcher
-	rules[idx].Hooks = hookCmds
-	settings.Hooks[eventType] = rules
-
-	if err := s.writeSettings(settings, raw); err != nil {
+	if err := s.writeSettings(doc); err != nil {
 		return nil, err
 	}
 
-	return &Hook{
+	updated := &Hook{
 		Name:      generateHookName(eventType, matcher, idx),
 		EventType: eventType,
 		Matcher:   matcher,
 		Commands:  commands,
-	}, nil
+	}
+	s.emit(actionUpdate, updated)
+	return updated, nil
 }
 
-// Delete removes a hook by name
+// Delete removes a hook by name, including disabled hooks
 func (s *Store) Delete(name string) error {
-	settings, raw, err := s.readSettings()
+	settings, doc, err := s.readSettings()
 	if err != nil {
 		return err
 	}
 
+	section, rulesByEvent := hooksKey, settings.Hooks
+	if strings.HasPrefix(name, DisabledPrefix) {
+		section, rulesByEvent = disabledHooksKey, settings.Disabled
+		name = strings.TrimPrefix(name, DisabledPrefix)
+	}
+
 	eventType, idx, err := parseHookName(name)
 	if err != nil {
 		return err
 	}
 
-	rules, ok := settings.Hooks[eventType]
+	rules, ok := rulesByEvent[eventType]
 	if !ok || idx >= len(rules) {
 		return os.ErrNotExist
 	}
 
 	// Remove the rule at index
-	settings.Hooks[eventType] = append(rules[:idx], rules[idx+1:]...)
+	if err := doc.remove(sectionRulePath(section, eventType, rules[idx])); err != nil {
+		return fmt.Errorf("delete hook from settings.json: %w", err)
+	}
+	if err := pruneSectionEvent(doc, section, eventType); err != nil {
+		return fmt.Errorf("delete hook from settings.json: %w", err)
+	}
+	if err := prunePins(doc); err != nil {
+		return fmt.Errorf("delete hook from settings.json: %w", err)
+	}
+
+	if err := s.writeSettings(doc); err != nil {
+		return err
+	}
+	deleted := &Hook{Name: name, EventType: eventType, Matcher: rules[idx].Matcher, Commands: ruleCommands(rules[idx])}
+	if section == disabledHooksKey {
+		deleted.Name, deleted.Disabled = DisabledPrefix+name, true
+	}
+	s.emit(actionDelete, deleted)
+	return nil
+}
+
+// Disable moves a hook's rule to the hooks_disabled section of settings.json, where
+// Claude Code ignores it. The rule is moved as is, keeping fields jd does not know,
+// with its position among the event's rules added so Enable can put it back there.
+// Returns the hook under its disabled name.
+func (s *Store) Disable(name string) (*Hook, error) {
+	settings, doc, err := s.readSettings()
+	if err != nil {
+		return nil, err
+	}
 
-	return s.writeSettings(settings, raw)
+	eventType, idx, err := parseHookName(name)
+	if err != nil {
+		return nil, err
+	}
+	rules := settings.Hooks[eventType]
+	if idx >= len(rules) {
+		return nil, os.ErrNotExist
+	}
+
+	rule := rules[idx]
+	at, err := moveRule(doc, hooksKey, disabledHooksKey, eventType, rule, len(settings.Disabled[eventType]))
+	if err == nil {
+		err = doc.set(jsonPath{disabledHooksKey, string(eventType), at, positionKey}, idx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("disable hook in settings.json: %w", err)
+	}
+	if err := s.writeSettings(doc); err != nil {
+		return nil, err
+	}
+
+	disabled := &Hook{
+		Name:      DisabledPrefix + generateHookName(eventType, rule.Matcher, at),
+		EventType: eventType,
+		Matcher:   rule.Matcher,
+		Commands:  ruleCommands(rule),
+		Disabled:  true,
+	}
+	s.emit(actionDisable, disabled)
+	return disabled, nil
+}
+
+// Enable moves a disabled hook's rule back to the hooks section of settings.json,
+// at the position it had when it was disabled (after the event's active rules if
+// there are fewer now). Returns the hook under its new active name.
+func (s *Store) Enable(name string) (*Hook, error) {
+	if !strings.HasPrefix(name, DisabledPrefix) {
+		return nil, fmt.Errorf("hook %s is not disabled (disabled hook names start with %q)", name, DisabledPrefix)
+	}
+
+	settings, doc, err := s.readSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	eventType, idx, err := parseHookName(strings.TrimPrefix(name, DisabledPrefix))
+	if err != nil {
+		return nil, err
+	}
+	rules := settings.Disabled[eventType]
+	if idx >= len(rules) {
+		return nil, os.ErrNotExist
+	}
+
+	rule := rules[idx]
+	position := len(settings.Hooks[eventType])
+	positionPath := append(sectionRulePath(disabledHooksKey, eventType, rule), positionKey)
+	if start, end, found, err := doc.find(positionPath); err == nil && found {
+		// Rules disabled before positions were recorded go after the active ones
+		_ = json.Unmarshal(doc.content[start:end], &position)
+	}
+	err = doc.remove(positionPath)
+	if err == nil {
+		position, err = moveRule(doc, disabledHooksKey, hooksKey, eventType, rule, position)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("enable hook in settings.json: %w", err)
+	}
+	if err := s.writeSettings(doc); err != nil {
+		return nil, err
+	}
+
+	enabled := &Hook{
+		Name:      generateHookName(eventType, rule.Matcher, position),
+		EventType: eventType,
+		Matcher:   rule.Matcher,
+		Commands:  ruleCommands(rule),
+	}
+	s.emit(actionEnable, enabled)
+	return enabled, nil
+}
+
+// moveRule moves a rule of eventType from one hooks section to another, before
+// the rule at index at there (at the end if at is past the last one). Returns
+// the index the rule was moved to.
+func moveRule(doc *jsonDoc, from, to string, eventType EventType, rule HookRule, at int) (int, error) {
+	path := sectionRulePath(from, eventType, rule)
+	start, end, found, err := doc.find(path)
+	if err != nil {
+		return 0, err
+	}
+	if !found {
+		return 0, os.ErrNotExist
+	}
+	raw := json.RawMessage(append([]byte(nil), doc.content[start:end]...))
+
+	if err := doc.remove(path); err != nil {
+		return 0, err
+	}
+	if err := pruneSectionEvent(doc, from, eventType); err != nil {
+		return 0, err
+	}
+	return doc.insertAt(jsonPath{to, string(eventType)}, at, raw)
+}
+
+// ruleCommands returns the commands of a rule
+func ruleCommands(rule HookRule) []string {
+	var commands []string
+	for _, h := range rule.Hooks {
+		commands = append(commands, h.Command)
+	}
+	return commands
+}
+
+// RuleIndex returns the index among the rules of eventType of the first rule
+// that runs command, or -1 if none does.
+func (s *Store) RuleIndex(eventType EventType, command string) (int, error) {
+	settings, _, err := s.readSettings()
+	if err != nil {
+		return -1, err
+	}
+	for i, rule := range settings.Hooks[eventType] {
+		if slices.Contains(ruleCommands(rule), command) {
+			return i, nil
+		}
+	}
+	return -1, nil
+}
+
+// RemoveCommand removes every occurrence of command from the rules of eventType.
+// Rules left without commands are removed. It reports whether anything was removed.
+func (s *Store) RemoveCommand(eventType EventType, command string) (bool, error) {
+	settings, doc, err := s.readSettings()
+	if err != nil {
+		return false, err
+	}
+
+	removed := false
+	rules := settings.Hooks[eventType]
+	// Work backwards so earlier indexes stay valid
+	for i := len(rules) - 1; i >= 0; i-- {
+		rule := rules[i]
+		var matches []HookCommand
+		for _, h := range rule.Hooks {
+			if h.Command == command {
+				matches = append(matches, h)
+			}
+		}
+		if len(matches) == 0 {
+			continue
+		}
+		removed = true
+
+		if len(matches) == len(rule.Hooks) {
+			err = doc.remove(rulePath(eventType, rule))
+		} else {
+			for j := len(matches) - 1; j >= 0 && err == nil; j-- {
+				err = doc.remove(append(rulePath(eventType, rule), "hooks", matches[j].index))
+			}
+		}
+		if err != nil {
+			return false, fmt.Errorf("remove hook from settings.json: %w", err)
+		}
+	}
+	if !removed {
+		return false, nil
+	}
+
+	if err := pruneEvent(doc, eventType); err != nil {
+		return false, fmt.Errorf("remove hook from settings.json: %w", err)
+	}
+	if err := prunePins(doc); err != nil {
+		return false, fmt.Errorf("remove hook from settings.json: %w", err)
+	}
+	if err := s.writeSettings(doc); err != nil {
+		return false, err
+	}
+	s.emit(actionRemove, &Hook{Name: command, EventType: eventType, Commands: []string{command}})
+	return true, nil
 }
 
 // generateHookName creates a unique name for a hook
@@ -421,11 +702,7 @@ func parseHookName(name string) (EventType, int, error) {
 
 // GetHooksDir returns the hooks script directory path
 func GetHooksDir() (string, error) {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return "", err
-	}
-	return filepath.Join(home, ".claude", "hooks"), nil
+	return basedir.ClaudePath("hooks")
 }
 
 // EnsureHooksDir creates the hooks directory if it doesn't exist
@@ -446,6 +723,14 @@ func CreateScript(name, content string) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	return CreateScriptIn(dir, name, content)
+}
+
+// CreateScriptIn creates a hook script file in dir, such as a project's .claude/hooks
+func CreateScriptIn(dir, name, content string) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
 
 	scriptPath := filepath.Join(dir, name)
 	if err := os.WriteFile(scriptPath, []byte(content), 0755); err != nil {
@@ -0,0 +1,309 @@
+package hook
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newTestStore returns a store for a settings.json with content, keeping the
+// event log and backups out of the user's home
+func newTestStore(t *testing.T, content string) (*Store, string) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), "settings.json")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return NewStore(path), path
+}
+
+func readTestSettings(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// threeRules has fields jd does not know on a rule and a command
+const threeRules = `{
+  "model": "opus",
+  "hooks": {
+    "PreToolUse": [
+      {
+        "matcher": "Bash",
+        "hooks": [
+          {
+            "type": "command",
+            "command": "a"
+          }
+        ]
+      },
+      {
+        "matcher": "Edit",
+        "hooks": [
+          {
+            "type": "command",
+            "command": "b",
+            "timeout": 30
+          }
+        ],
+        "note": "kept"
+      },
+      {
+        "matcher": "Write",
+        "hooks": [
+          {
+            "type": "command",
+            "command": "c"
+          }
+        ]
+      }
+    ]
+  }
+}
+`
+
+func TestDisableEnableRoundTrip(t *testing.T) {
+	for name, disabledName := range map[string]string{
+		"PreToolUse-Bash-0":  "disabled-PreToolUse-Bash-0",
+		"PreToolUse-Edit-1":  "disabled-PreToolUse-Edit-0",
+		"PreToolUse-Write-2": "disabled-PreToolUse-Write-0",
+	} {
+		t.Run(name, func(t *testing.T) {
+			store, path := newTestStore(t, threeRules)
+
+			disabled, err := store.Disable(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if disabled.Name != disabledName {
+				t.Errorf("disabled as %s, want %s", disabled.Name, disabledName)
+			}
+			active, err := store.List()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(active) != 2 {
+				t.Errorf("%d active hooks after disable, want 2", len(active))
+			}
+
+			enabled, err := store.Enable(disabled.Name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if enabled.Name != name {
+				t.Errorf("enabled as %s, want %s", enabled.Name, name)
+			}
+			if got := readTestSettings(t, path); got != threeRules {
+				t.Errorf("settings.json after disable and enable:\n%s\nwant:\n%s", got, threeRules)
+			}
+		})
+	}
+}
+
+func TestDisableKeepsUnknownFields(t *testing.T) {
+	store, path := newTestStore(t, threeRules)
+	if _, err := store.Disable("PreToolUse-Edit-1"); err != nil {
+		t.Fatal(err)
+	}
+	got := readTestSettings(t, path)
+	for _, want := range []string{`"timeout": 30`, `"note": "kept"`, `"position": 1`, `"hooks_disabled"`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("settings.json has no %s:\n%s", want, got)
+		}
+	}
+}
+
+func TestDisableEnableRemovesEmptySections(t *testing.T) {
+	const oneRule = `{
+  "model": "opus",
+  "hooks": {
+    "Stop": [
+      {
+        "matcher": "",
+        "hooks": [
+          {
+            "type": "command",
+            "command": "a",
+            "timeout": 5
+          }
+        ]
+      }
+    ]
+  }
+}
+`
+	store, path := newTestStore(t, oneRule)
+	disabled, err := store.Disable("Stop-all-0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := readTestSettings(t, path)
+	if strings.Contains(got, "\n  \"hooks\":") {
+		t.Errorf("the emptied hooks section is kept:\n%s", got)
+	}
+
+	if _, err := store.Enable(disabled.Name); err != nil {
+		t.Fatal(err)
+	}
+	if got := readTestSettings(t, path); got != oneRule {
+		t.Errorf("settings.json after disable and enable:\n%s\nwant:\n%s", got, oneRule)
+	}
+}
+
+func TestEnableAfterChanges(t *testing.T) {
+	store, _ := newTestStore(t, threeRules)
+	if _, err := store.Disable("PreToolUse-Write-2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Delete("PreToolUse-Edit-1"); err != nil {
+		t.Fatal(err)
+	}
+	// Its position is past the active rules left, so it goes after them
+	enabled, err := store.Enable("disabled-PreToolUse-Write-0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if enabled.Name != "PreToolUse-Write-1" {
+		t.Errorf("enabled as %s, want PreToolUse-Write-1", enabled.Name)
+	}
+
+	// A rule disabled without a recorded position goes after the active rules
+	const noPosition = `{"hooks":{"Stop":[{"hooks":[{"type":"command","command":"a"}]}]},"hooks_disabled":{"Stop":[{"hooks":[{"type":"command","command":"b"}]}]}}`
+	store, path := newTestStore(t, noPosition)
+	if _, err := store.Enable("disabled-Stop-all-0"); err != nil {
+		t.Fatal(err)
+	}
+	want := `{"hooks":{"Stop":[{"hooks":[{"type":"command","command":"a"}]},{"hooks":[{"type":"command","command":"b"}]}]}}`
+	if got := readTestSettings(t, path); got != want {
+		t.Errorf("settings.json = %s, want %s", got, want)
+	}
+}
+
+func TestRemoveCommand(t *testing.T) {
+	const rules = `{"hooks":{"PreToolUse":[{"matcher":"Bash","hooks":[{"type":"command","command":"a"}]},{"matcher":"Edit","hooks":[{"type":"command","command":"b"},{"type":"command","command":"x","timeout":5}]},{"matcher":"Write","hooks":[{"type":"command","command":"x"}]}],"Stop":[{"hooks":[{"type":"command","command":"s"}]}]}}`
+	tests := []struct {
+		name      string
+		eventType EventType
+		command   string
+		want      string // settings.json after, or "" if unchanged
+	}{
+		{
+			name:      "whole rule",
+			eventType: PreToolUse,
+			command:   "a",
+			want:      `{"hooks":{"PreToolUse":[{"matcher":"Edit","hooks":[{"type":"command","command":"b"},{"type":"command","command":"x","timeout":5}]},{"matcher":"Write","hooks":[{"type":"command","command":"x"}]}],"Stop":[{"hooks":[{"type":"command","command":"s"}]}]}}`,
+		},
+		{
+			name:      "every occurrence",
+			eventType: PreToolUse,
+			command:   "x",
+			want:      `{"hooks":{"PreToolUse":[{"matcher":"Bash","hooks":[{"type":"command","command":"a"}]},{"matcher":"Edit","hooks":[{"type":"command","command":"b"}]}],"Stop":[{"hooks":[{"type":"command","command":"s"}]}]}}`,
+		},
+		{
+			name:      "last rule of an event",
+			eventType: Stop,
+			command:   "s",
+			want:      `{"hooks":{"PreToolUse":[{"matcher":"Bash","hooks":[{"type":"command","command":"a"}]},{"matcher":"Edit","hooks":[{"type":"command","command":"b"},{"type":"command","command":"x","timeout":5}]},{"matcher":"Write","hooks":[{"type":"command","command":"x"}]}]}}`,
+		},
+		{
+			name:      "another event's command",
+			eventType: PreToolUse,
+			command:   "s",
+		},
+		{
+			name:      "no such command",
+			eventType: PreToolUse,
+			command:   "missing",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, path := newTestStore(t, rules)
+			removed, err := store.RemoveCommand(tt.eventType, tt.command)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if removed != (tt.want != "") {
+				t.Errorf("RemoveCommand() = %v, want %v", removed, tt.want != "")
+			}
+			want := tt.want
+			if want == "" {
+				want = rules
+			}
+			if got := readTestSettings(t, path); got != want {
+				t.Errorf("settings.json = %s\nwant %s", got, want)
+			}
+		})
+	}
+}
+
+func TestInsert(t *testing.T) {
+	const rules = `{"hooks":{"Stop":[{"matcher":"*","hooks":[{"type":"command","command":"a"}]},{"matcher":"*","hooks":[{"type":"command","command":"b"}]}]}}`
+	tests := []struct {
+		name     string
+		at       int
+		wantName string
+		want     string
+	}{
+		{"first", 0, "Stop-all-0", `{"hooks":{"Stop":[{"matcher":"*","hooks":[{"type":"command","command":"c"}]},{"matcher":"*","hooks":[{"type":"command","command":"a"}]},{"matcher":"*","hooks":[{"type":"command","command":"b"}]}]}}`},
+		{"between", 1, "Stop-all-1", `{"hooks":{"Stop":[{"matcher":"*","hooks":[{"type":"command","command":"a"}]},{"matcher":"*","hooks":[{"type":"command","command":"c"}]},{"matcher":"*","hooks":[{"type":"command","command":"b"}]}]}}`},
+		{"negative", -1, "Stop-all-2", `{"hooks":{"Stop":[{"matcher":"*","hooks":[{"type":"command","command":"a"}]},{"matcher":"*","hooks":[{"type":"command","command":"b"}]},{"matcher":"*","hooks":[{"type":"command","command":"c"}]}]}}`},
+		{"past the end", 9, "Stop-all-2", `{"hooks":{"Stop":[{"matcher":"*","hooks":[{"type":"command","command":"a"}]},{"matcher":"*","hooks":[{"type":"command","command":"b"}]},{"matcher":"*","hooks":[{"type":"command","command":"c"}]}]}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, path := newTestStore(t, rules)
+			added, err := store.Insert(Stop, "*", []string{"c"}, tt.at)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if added.Name != tt.wantName {
+				t.Errorf("added as %s, want %s", added.Name, tt.wantName)
+			}
+			if got := readTestSettings(t, path); got != tt.want {
+				t.Errorf("settings.json = %s\nwant %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRemoveInsertKeepsPosition(t *testing.T) {
+	store, path := newTestStore(t, threeRules)
+	at, err := store.RuleIndex(PreToolUse, "b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if at != 1 {
+		t.Fatalf("RuleIndex() = %d, want 1", at)
+	}
+	if _, err := store.RemoveCommand(PreToolUse, "b"); err != nil {
+		t.Fatal(err)
+	}
+	if at, _ := store.RuleIndex(PreToolUse, "b"); at != -1 {
+		t.Errorf("RuleIndex() of a removed command = %d, want -1", at)
+	}
+
+	added, err := store.Insert(PreToolUse, "Edit", []string{"b"}, at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if added.Name != "PreToolUse-Edit-1" {
+		t.Errorf("added as %s, want PreToolUse-Edit-1", added.Name)
+	}
+	rules, err := store.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var order []string
+	for _, h := range rules {
+		order = append(order, h.Name)
+	}
+	if want := "PreToolUse-Bash-0 PreToolUse-Edit-1 PreToolUse-Write-2"; strings.Join(order, " ") != want {
+		t.Errorf("hooks after remove and insert = %v, want %s\n%s", order, want, readTestSettings(t, path))
+	}
+}
@@ -0,0 +1,373 @@
+package hook
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// jsonDoc edits a JSON document in place. Only the bytes of the values being
+// changed are rewritten, so key order, indentation, and every unrelated part of
+// the file stay byte-identical.
+type jsonDoc struct {
+	content []byte
+	indent  string // Indentation unit detected from the document
+	pretty  bool   // Whether the document is indented (not minified)
+}
+
+// jsonPath addresses a value: object keys are strings, array indexes are ints
+type jsonPath []any
+
+// jsonEntry is an object member or array element within a document
+type jsonEntry struct {
+	key        string // Object key (empty for array elements)
+	start      int    // Offset of the key, or of the value for array elements
+	valueStart int
+	valueEnd   int
+}
+
+var errNotContainer = errors.New("not an object or array")
+
+// newJSONDoc wraps content, treating an empty document as an empty object
+func newJSONDoc(content []byte) *jsonDoc {
+	if len(bytes.TrimSpace(content)) == 0 {
+		content = []byte("{}\n")
+	}
+	d := &jsonDoc{content: content, indent: "  "}
+	if start, end, err := d.root(); err == nil {
+		d.pretty = bytes.IndexByte(content[start:end], '\n') >= 0 || end-start <= 2
+	}
+	if unit := detectIndent(content); unit != "" {
+		d.indent = unit
+	}
+	return d
+}
+
+// root returns the span of the top-level value
+func (d *jsonDoc) root() (int, int, error) {
+	start := skipSpace(d.content, 0)
+	dec := json.NewDecoder(bytes.NewReader(d.content[start:]))
+	var raw json.RawMessage
+	if err := dec.Decode(&raw); err != nil {
+		return 0, 0, err
+	}
+	return start, start + int(dec.InputOffset()), nil
+}
+
+// entries lists the members or elements of the container spanning content[start:end]
+func (d *jsonDoc) entries(start, end int) (bool, []jsonEntry, error) {
+	dec := json.NewDecoder(bytes.NewReader(d.content[start:end]))
+	tok, err := dec.Token()
+	if err != nil {
+		return false, nil, err
+	}
+	delim, ok := tok.(json.Delim)
+	if !ok || (delim != '{' && delim != '[') {
+		return false, nil, errNotContainer
+	}
+	object := delim == '{'
+
+	var entries []jsonEntry
+	for dec.More() {
+		e := jsonEntry{start: skipSpace(d.content, start+int(dec.InputOffset()))}
+		if object {
+			tok, err := dec.Token()
+			if err != nil {
+				return false, nil, err
+			}
+			e.key, _ = tok.(string)
+		}
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return false, nil, err
+		}
+		e.valueEnd = start + int(dec.InputOffset())
+		e.valueStart = e.valueEnd - len(raw)
+		entries = append(entries, e)
+	}
+	return object, entries, nil
+}
+
+// find returns the span of the value at path
+func (d *jsonDoc) find(path jsonPath) (start, end int, found bool, err error) {
+	start, end, err = d.root()
+	if err != nil {
+		return 0, 0, false, err
+	}
+	for _, elem := range path {
+		object, entries, err := d.entries(start, end)
+		if err != nil {
+			return 0, 0, false, err
+		}
+		i := entryIndex(object, entries, elem)
+		if i < 0 {
+			return 0, 0, false, nil
+		}
+		start, end = entries[i].valueStart, entries[i].valueEnd
+	}
+	return start, end, true, nil
+}
+
+// len returns the number of entries of the container at path
+func (d *jsonDoc) len(path jsonPath) (int, bool, error) {
+	start, end, found, err := d.find(path)
+	if err != nil || !found {
+		return 0, false, err
+	}
+	_, entries, err := d.entries(start, end)
+	return len(entries), true, err
+}
+
+// set replaces the value at path, adding the member (and missing parent
+// objects) if needed. An array index equal to the array length appends.
+func (d *jsonDoc) set(path jsonPath, value any) error {
+	parent, last := path[:len(path)-1], path[len(path)-1]
+	start, end, found, err := d.find(parent)
+	if err != nil {
+		return err
+	}
+	if !found {
+		if _, ok := parent[len(parent)-1].(string); !ok {
+			return fmt.Errorf("array index out of range at %v", parent)
+		}
+		return d.set(parent, wrapValue(last, value))
+	}
+
+	object, entries, err := d.entries(start, end)
+	if err != nil {
+		return err
+	}
+	if i := entryIndex(object, entries, last); i >= 0 {
+		e := entries[i]
+		encoded, err := d.encode(value, lineIndent(d.content, e.valueStart))
+		if err != nil {
+			return err
+		}
+		d.replace(e.valueStart, e.valueEnd, encoded)
+		return nil
+	}
+
+	key, isKey := last.(string)
+	index, isIndex := last.(int)
+	if (object && !isKey) || (!object && (!isIndex || index != len(entries))) {
+		return fmt.Errorf("cannot add %v to %v", last, parent)
+	}
+	return d.insert(start, end, entries, object, key, value)
+}
+
+// append adds value to the end of the array at path, creating the array if needed
+func (d *jsonDoc) append(path jsonPath, value any) error {
+	n, found, err := d.len(path)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return d.set(path, []any{value})
+	}
+	return d.set(append(path[:len(path):len(path)], n), value)
+}
+
+// insertAt adds value to the array at path before the element at index, or at
+// its end if index is past it, creating the array if needed. Returns the index
+// value was added at.
+func (d *jsonDoc) insertAt(path jsonPath, index int, value any) (int, error) {
+	start, end, found, err := d.find(path)
+	if err != nil {
+		return 0, err
+	}
+	if !found {
+		return 0, d.set(path, []any{value})
+	}
+	object, entries, err := d.entries(start, end)
+	if err != nil {
+		return 0, err
+	}
+	if object {
+		return 0, fmt.Errorf("%v is not an array", path)
+	}
+	if index >= len(entries) {
+		return len(entries), d.insert(start, end, entries, false, "", value)
+	}
+	index = max(index, 0)
+
+	next := entries[index]
+	indent := lineIndent(d.content, next.start)
+	multiline := bytes.IndexByte(d.content[start:entries[0].start], '\n') >= 0
+	encoded, err := d.encodeWith(value, indent, multiline)
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case multiline:
+		encoded = append(encoded, ",\n"+indent...)
+	case d.pretty:
+		encoded = append(encoded, ", "...)
+	default:
+		encoded = append(encoded, ',')
+	}
+	d.replace(next.start, next.start, encoded)
+	return index, nil
+}
+
+// remove deletes the member or element at path. A missing path is not an error.
+func (d *jsonDoc) remove(path jsonPath) error {
+	start, end, found, err := d.find(path[:len(path)-1])
+	if err != nil || !found {
+		return err
+	}
+	object, entries, err := d.entries(start, end)
+	if err != nil {
+		return err
+	}
+	i := entryIndex(object, entries, path[len(path)-1])
+	switch {
+	case i < 0:
+		return nil
+	case len(entries) == 1:
+		d.replace(start, end, []byte{d.content[start], d.content[end-1]})
+	case i > 0:
+		// Remove from the comma after the previous entry through this entry
+		d.replace(entries[i-1].valueEnd, entries[i].valueEnd, nil)
+	default:
+		// First entry: remove through the start of the next one
+		d.replace(entries[0].start, entries[1].start, nil)
+	}
+	return nil
+}
+
+// insert adds a member or element at the end of the container spanning content[start:end]
+func (d *jsonDoc) insert(start, end int, entries []jsonEntry, object bool, key string, value any) error {
+	var childIndent string
+	multiline := d.pretty
+	if len(entries) == 0 {
+		childIndent = lineIndent(d.content, start) + d.indent
+	} else {
+		childIndent = lineIndent(d.content, entries[0].start)
+		multiline = bytes.IndexByte(d.content[start:entries[0].start], '\n') >= 0
+	}
+
+	encoded, err := d.encodeWith(value, childIndent, multiline)
+	if err != nil {
+		return err
+	}
+	if object {
+		name, err := d.encodeWith(key, "", false)
+		if err != nil {
+			return err
+		}
+		separator := ":"
+		if multiline {
+			separator = ": "
+		}
+		encoded = append(append(name, separator...), encoded...)
+	}
+
+	var text []byte
+	switch {
+	case len(entries) > 0 && multiline:
+		text = append([]byte(",\n"+childIndent), encoded...)
+		d.replace(entries[len(entries)-1].valueEnd, entries[len(entries)-1].valueEnd, text)
+	case len(entries) > 0 && d.pretty:
+		text = append([]byte(", "), encoded...)
+		d.replace(entries[len(entries)-1].valueEnd, entries[len(entries)-1].valueEnd, text)
+	case len(entries) > 0:
+		text = append([]byte(","), encoded...)
+		d.replace(entries[len(entries)-1].valueEnd, entries[len(entries)-1].valueEnd, text)
+	case multiline:
+		text = append(append([]byte{d.content[start], '\n'}, childIndent...), encoded...)
+		text = append(append(append(text, '\n'), lineIndent(d.content, start)...), d.content[end-1])
+		d.replace(start, end, text)
+	default:
+		text = append(append([]byte{d.content[start]}, encoded...), d.content[end-1])
+		d.replace(start, end, text)
+	}
+	return nil
+}
+
+// encode marshals value for a position whose line is indented by prefix
+func (d *jsonDoc) encode(value any, prefix string) ([]byte, error) {
+	return d.encodeWith(value, prefix, d.pretty)
+}
+
+func (d *jsonDoc) encodeWith(value any, prefix string, multiline bool) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if multiline {
+		enc.SetIndent(prefix, d.indent)
+	}
+	if err := enc.Encode(value); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
+
+func (d *jsonDoc) replace(start, end int, text []byte) {
+	content := make([]byte, 0, len(d.content)-(end-start)+len(text))
+	content = append(content, d.content[:start]...)
+	content = append(content, text...)
+	d.content = append(content, d.content[end:]...)
+}
+
+// entryIndex returns the position of the entry addressed by elem, or -1
+func entryIndex(object bool, entries []jsonEntry, elem any) int {
+	switch e := elem.(type) {
+	case string:
+		if !object {
+			return -1
+		}
+		// The last duplicate key wins, as with encoding/json
+		for i := len(entries) - 1; i >= 0; i-- {
+			if entries[i].key == e {
+				return i
+			}
+		}
+	case int:
+		if !object && e >= 0 && e < len(entries) {
+			return e
+		}
+	}
+	return -1
+}
+
+// wrapValue nests value under elem for a parent that does not exist yet
+func wrapValue(elem, value any) any {
+	if key, ok := elem.(string); ok {
+		return map[string]any{key: value}
+	}
+	return []any{value}
+}
+
+func skipSpace(content []byte, pos int) int {
+	for pos < len(content) {
+		switch content[pos] {
+		case ' ', '\t', '\n', '\r', ',':
+			pos++
+		default:
+			return pos
+		}
+	}
+	return pos
+}
+
+// lineIndent returns the leading whitespace of the line containing pos
+func lineIndent(content []byte, pos int) string {
+	lineStart := bytes.LastIndexByte(content[:pos], '\n') + 1
+	end := lineStart
+	for end < len(content) && (content[end] == ' ' || content[end] == '\t') {
+		end++
+	}
+	return string(content[lineStart:end])
+}
+
+// detectIndent returns the indentation of the first indented line, or ""
+func detectIndent(content []byte) string {
+	for _, line := range bytes.Split(content, []byte("\n"))[1:] {
+		trimmed := bytes.TrimLeft(line, " \t")
+		if len(trimmed) > 0 && len(trimmed) < len(line) {
+			return string(line[:len(line)-len(trimmed)])
+		}
+	}
+	return ""
+}
@@ -0,0 +1,303 @@
+package hook
+
+import (
+	"strings"
+	"testing"
+)
+
+// prettySettings is indented by hand, with an unrelated member kept on one line
+// with uneven spacing, which edits elsewhere must leave as it is
+const prettySettings = `{
+  "env": {"A": "1",   "B": 2},
+  "hooks": {
+    "PreToolUse": [
+      {"matcher": "Bash", "hooks": [{"type": "command", "command": "a"}]},
+      {"matcher": "Edit", "hooks": []},
+      {"matcher": "Write", "hooks": []}
+    ]
+  },
+  "model": "opus"
+}
+`
+
+const minifiedSettings = `{"env":{"A":"1"},"hooks":{"PreToolUse":[{"matcher":"a"},{"matcher":"b"},{"matcher":"c"}]},"model":"opus"}`
+
+func TestJSONDocEdits(t *testing.T) {
+	const prettyEnv = `"env": {"A": "1",   "B": 2},`
+	const minifiedEnv = `{"env":{"A":"1"},`
+	tests := []struct {
+		name      string
+		input     string
+		edit      func(d *jsonDoc) error
+		want      string
+		unrelated string // Bytes the edit must not touch
+	}{
+		{
+			name:  "pretty remove first",
+			input: prettySettings,
+			edit:  func(d *jsonDoc) error { return d.remove(jsonPath{"hooks", "PreToolUse", 0}) },
+			want: `{
+  "env": {"A": "1",   "B": 2},
+  "hooks": {
+    "PreToolUse": [
+      {"matcher": "Edit", "hooks": []},
+      {"matcher": "Write", "hooks": []}
+    ]
+  },
+  "model": "opus"
+}
+`,
+			unrelated: prettyEnv,
+		},
+		{
+			name:  "pretty remove middle",
+			input: prettySettings,
+			edit:  func(d *jsonDoc) error { return d.remove(jsonPath{"hooks", "PreToolUse", 1}) },
+			want: `{
+  "env": {"A": "1",   "B": 2},
+  "hooks": {
+    "PreToolUse": [
+      {"matcher": "Bash", "hooks": [{"type": "command", "command": "a"}]},
+      {"matcher": "Write", "hooks": []}
+    ]
+  },
+  "model": "opus"
+}
+`,
+			unrelated: prettyEnv,
+		},
+		{
+			name:  "pretty remove last",
+			input: prettySettings,
+			edit:  func(d *jsonDoc) error { return d.remove(jsonPath{"hooks", "PreToolUse", 2}) },
+			want: `{
+  "env": {"A": "1",   "B": 2},
+  "hooks": {
+    "PreToolUse": [
+      {"matcher": "Bash", "hooks": [{"type": "command", "command": "a"}]},
+      {"matcher": "Edit", "hooks": []}
+    ]
+  },
+  "model": "opus"
+}
+`,
+			unrelated: prettyEnv,
+		},
+		{
+			name:  "pretty remove only and prune the event",
+			input: "{\n  \"hooks\": {\n    \"Stop\": [\n      {\"hooks\": []}\n    ]\n  },\n  \"model\":   \"opus\"\n}\n",
+			edit: func(d *jsonDoc) error {
+				if err := d.remove(jsonPath{"hooks", "Stop", 0}); err != nil {
+					return err
+				}
+				return pruneEvent(d, "Stop")
+			},
+			want:      "{\n  \"model\":   \"opus\"\n}\n",
+			unrelated: `"model":   "opus"`,
+		},
+		{
+			name:      "pretty remove only without pruning",
+			input:     "{\n  \"hooks\": {\n    \"Stop\": [\n      {\"hooks\": []}\n    ]\n  },\n  \"model\":   \"opus\"\n}\n",
+			edit:      func(d *jsonDoc) error { return d.remove(jsonPath{"hooks", "Stop", 0}) },
+			want:      "{\n  \"hooks\": {\n    \"Stop\": []\n  },\n  \"model\":   \"opus\"\n}\n",
+			unrelated: `"model":   "opus"`,
+		},
+		{
+			name:  "pretty set under a missing parent",
+			input: prettySettings,
+			edit:  func(d *jsonDoc) error { return d.set(jsonPath{"permissions", "allow"}, []any{"Bash(ls)"}) },
+			want: `{
+  "env": {"A": "1",   "B": 2},
+  "hooks": {
+    "PreToolUse": [
+      {"matcher": "Bash", "hooks": [{"type": "command", "command": "a"}]},
+      {"matcher": "Edit", "hooks": []},
+      {"matcher": "Write", "hooks": []}
+    ]
+  },
+  "model": "opus",
+  "permissions": {
+    "allow": [
+      "Bash(ls)"
+    ]
+  }
+}
+`,
+			unrelated: prettyEnv,
+		},
+		{
+			name:  "pretty replace a value",
+			input: prettySettings,
+			edit:  func(d *jsonDoc) error { return d.set(jsonPath{"model"}, "sonnet") },
+			want: `{
+  "env": {"A": "1",   "B": 2},
+  "hooks": {
+    "PreToolUse": [
+      {"matcher": "Bash", "hooks": [{"type": "command", "command": "a"}]},
+      {"matcher": "Edit", "hooks": []},
+      {"matcher": "Write", "hooks": []}
+    ]
+  },
+  "model": "sonnet"
+}
+`,
+			unrelated: prettyEnv,
+		},
+		{
+			name:  "pretty append",
+			input: prettySettings,
+			edit: func(d *jsonDoc) error {
+				return d.append(jsonPath{"hooks", "PreToolUse"}, map[string]any{"matcher": "Read"})
+			},
+			want: `{
+  "env": {"A": "1",   "B": 2},
+  "hooks": {
+    "PreToolUse": [
+      {"matcher": "Bash", "hooks": [{"type": "command", "command": "a"}]},
+      {"matcher": "Edit", "hooks": []},
+      {"matcher": "Write", "hooks": []},
+      {
+        "matcher": "Read"
+      }
+    ]
+  },
+  "model": "opus"
+}
+`,
+			unrelated: prettyEnv,
+		},
+		{
+			name:  "pretty insert in the middle",
+			input: prettySettings,
+			edit: func(d *jsonDoc) error {
+				_, err := d.insertAt(jsonPath{"hooks", "PreToolUse"}, 1, map[string]any{"matcher": "Read"})
+				return err
+			},
+			want: `{
+  "env": {"A": "1",   "B": 2},
+  "hooks": {
+    "PreToolUse": [
+      {"matcher": "Bash", "hooks": [{"type": "command", "command": "a"}]},
+      {
+        "matcher": "Read"
+      },
+      {"matcher": "Edit", "hooks": []},
+      {"matcher": "Write", "hooks": []}
+    ]
+  },
+  "model": "opus"
+}
+`,
+			unrelated: prettyEnv,
+		},
+		{
+			name:      "minified remove first",
+			input:     minifiedSettings,
+			edit:      func(d *jsonDoc) error { return d.remove(jsonPath{"hooks", "PreToolUse", 0}) },
+			want:      `{"env":{"A":"1"},"hooks":{"PreToolUse":[{"matcher":"b"},{"matcher":"c"}]},"model":"opus"}`,
+			unrelated: minifiedEnv,
+		},
+		{
+			name:      "minified remove middle",
+			input:     minifiedSettings,
+			edit:      func(d *jsonDoc) error { return d.remove(jsonPath{"hooks", "PreToolUse", 1}) },
+			want:      `{"env":{"A":"1"},"hooks":{"PreToolUse":[{"matcher":"a"},{"matcher":"c"}]},"model":"opus"}`,
+			unrelated: minifiedEnv,
+		},
+		{
+			name:      "minified remove last",
+			input:     minifiedSettings,
+			edit:      func(d *jsonDoc) error { return d.remove(jsonPath{"hooks", "PreToolUse", 2}) },
+			want:      `{"env":{"A":"1"},"hooks":{"PreToolUse":[{"matcher":"a"},{"matcher":"b"}]},"model":"opus"}`,
+			unrelated: minifiedEnv,
+		},
+		{
+			name:  "minified remove only and prune the event",
+			input: `{"model":"opus","hooks":{"Stop":[{"hooks":[]}]}}`,
+			edit: func(d *jsonDoc) error {
+				if err := d.remove(jsonPath{"hooks", "Stop", 0}); err != nil {
+					return err
+				}
+				return pruneEvent(d, "Stop")
+			},
+			want:      `{"model":"opus"}`,
+			unrelated: `"model":"opus"`,
+		},
+		{
+			name:      "minified set under a missing parent",
+			input:     minifiedSettings,
+			edit:      func(d *jsonDoc) error { return d.set(jsonPath{"permissions", "allow"}, []any{"Bash(ls)"}) },
+			want:      `{"env":{"A":"1"},"hooks":{"PreToolUse":[{"matcher":"a"},{"matcher":"b"},{"matcher":"c"}]},"model":"opus","permissions":{"allow":["Bash(ls)"]}}`,
+			unrelated: minifiedEnv,
+		},
+		{
+			name:  "minified append",
+			input: minifiedSettings,
+			edit: func(d *jsonDoc) error {
+				return d.append(jsonPath{"hooks", "PreToolUse"}, map[string]any{"matcher": "Read"})
+			},
+			want:      `{"env":{"A":"1"},"hooks":{"PreToolUse":[{"matcher":"a"},{"matcher":"b"},{"matcher":"c"},{"matcher":"Read"}]},"model":"opus"}`,
+			unrelated: minifiedEnv,
+		},
+		{
+			name:  "minified insert first",
+			input: minifiedSettings,
+			edit: func(d *jsonDoc) error {
+				_, err := d.insertAt(jsonPath{"hooks", "PreToolUse"}, 0, map[string]any{"matcher": "Read"})
+				return err
+			},
+			want:      `{"env":{"A":"1"},"hooks":{"PreToolUse":[{"matcher":"Read"},{"matcher":"a"},{"matcher":"b"},{"matcher":"c"}]},"model":"opus"}`,
+			unrelated: minifiedEnv,
+		},
+		{
+			name:  "minified insert past the end",
+			input: minifiedSettings,
+			edit: func(d *jsonDoc) error {
+				_, err := d.insertAt(jsonPath{"hooks", "PreToolUse"}, 7, map[string]any{"matcher": "Read"})
+				return err
+			},
+			want:      `{"env":{"A":"1"},"hooks":{"PreToolUse":[{"matcher":"a"},{"matcher":"b"},{"matcher":"c"},{"matcher":"Read"}]},"model":"opus"}`,
+			unrelated: minifiedEnv,
+		},
+		{
+			name:      "remove a missing path",
+			input:     minifiedSettings,
+			edit:      func(d *jsonDoc) error { return d.remove(jsonPath{"hooks", "Stop", 0}) },
+			want:      minifiedSettings,
+			unrelated: minifiedSettings,
+		},
+		{
+			name:      "set in an empty document",
+			input:     "",
+			edit:      func(d *jsonDoc) error { return d.set(jsonPath{"hooks", "Stop"}, []any{}) },
+			want:      "{\n  \"hooks\": {\n    \"Stop\": []\n  }\n}\n",
+			unrelated: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newJSONDoc([]byte(tt.input))
+			if err := tt.edit(d); err != nil {
+				t.Fatal(err)
+			}
+			got := string(d.content)
+			if got != tt.want {
+				t.Errorf("content =\n%s\nwant\n%s", got, tt.want)
+			}
+			if !strings.Contains(got, tt.unrelated) {
+				t.Errorf("the edit changed unrelated bytes %q:\n%s", tt.unrelated, got)
+			}
+		})
+	}
+}
+
+func TestJSONDocSetRejectsIndexOutOfRange(t *testing.T) {
+	d := newJSONDoc([]byte(minifiedSettings))
+	if err := d.set(jsonPath{"hooks", "PreToolUse", 5}, "x"); err == nil {
+		t.Error("set() past the end of an array succeeded")
+	}
+	if string(d.content) != minifiedSettings {
+		t.Errorf("a failed set() changed the document:\n%s", d.content)
+	}
+}
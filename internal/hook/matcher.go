@@ -0,0 +1,99 @@
+package hook
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// mcpToolPrefix starts the names of tools provided by MCP servers, which are not
+// known in advance (e.g., mcp__github__create_issue)
+const mcpToolPrefix = "mcp__"
+
+// CheckMatcher returns the problems with a rule's matcher, given the names of the
+// known tools: a matcher on an event that is not matched against tools, one that
+// is not a valid regular expression, alternatives padded with spaces, tool names
+// that are not known (suggesting the closest known one), and patterns that match
+// no known tool. A matcher Claude Code would match as intended has none.
+func CheckMatcher(eventType EventType, matcher string, tools []string) []string {
+	if matcher == "" || matcher == "*" {
+		return nil
+	}
+	if !IsToolEvent(eventType) {
+		return []string{fmt.Sprintf("the matcher is ignored: %s rules run on every %s event, not per tool", eventType, eventType)}
+	}
+
+	var problems []string
+	if _, err := regexp.Compile(matcher); err != nil {
+		problems = append(problems, fmt.Sprintf("not a valid regular expression (%v); Claude Code may never match it", err))
+	}
+
+	for _, alt := range strings.Split(matcher, "|") {
+		trimmed := strings.TrimSpace(alt)
+		switch {
+		case trimmed == "":
+			problems = append(problems, "empty alternative: remove the extra |")
+			continue
+		case trimmed != alt:
+			problems = append(problems, fmt.Sprintf("%q has spaces around it, so it never matches a tool name; use %q", alt, trimmed))
+		}
+
+		if regexp.QuoteMeta(trimmed) == trimmed {
+			if slices.Contains(tools, trimmed) || strings.HasPrefix(trimmed, mcpToolPrefix) {
+				continue
+			}
+			if suggestion := closestTool(trimmed, tools); suggestion != "" {
+				problems = append(problems, fmt.Sprintf("unknown tool %q (did you mean %q?)", trimmed, suggestion))
+			} else {
+				problems = append(problems, fmt.Sprintf("unknown tool %q", trimmed))
+			}
+			continue
+		}
+
+		re, err := regexp.Compile("^(?:" + trimmed + ")$")
+		if err != nil || strings.HasPrefix(trimmed, mcpToolPrefix) {
+			continue
+		}
+		if !slices.ContainsFunc(tools, re.MatchString) {
+			problems = append(problems, fmt.Sprintf("pattern %q matches no known tool", trimmed))
+		}
+	}
+	return problems
+}
+
+// closestTool returns the known tool an unknown name is most likely a typo of:
+// one that differs only in case, or by at most two edits
+func closestTool(name string, tools []string) string {
+	best, bestDistance := "", 3
+	for _, tool := range tools {
+		if strings.EqualFold(name, tool) {
+			return tool
+		}
+		d := levenshtein(strings.ToLower(name), strings.ToLower(tool))
+		if d < bestDistance || (d == bestDistance && best != "" && tool < best) {
+			best, bestDistance = tool, d
+		}
+	}
+	return best
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr := make([]int, len(b)+1)
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev = curr
+	}
+	return prev[len(b)]
+}
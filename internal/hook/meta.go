@@ -0,0 +1,104 @@
+package hook
+
+import (
+	"bufio"
+	"os"
+	"strings"
+)
+
+// ScriptMeta is the registration metadata a hook script declares in its header comments,
+// in the same format 'jd hooks new' writes:
+//
+//	#!/usr/bin/env sh
+//	# Hook: PostToolUse
+//	# Matcher: Edit|Write
+type ScriptMeta struct {
+	EventType EventType
+	Matcher   string
+}
+
+// ParseScriptMeta reads hook registration metadata from the leading comment block of a script.
+// It returns nil if the script does not declare an event type.
+func ParseScriptMeta(path string) (*ScriptMeta, error) {
+	header, err := parseScriptHeader(path)
+	if err != nil {
+		return nil, err
+	}
+
+	event, matcher := last(header["hook"]), last(header["matcher"])
+	if event == "" {
+		return nil, nil
+	}
+	eventType, err := ParseEventType(event)
+	if err != nil {
+		return nil, err
+	}
+	if matcher == "" {
+		matcher = "*"
+	}
+
+	return &ScriptMeta{EventType: eventType, Matcher: matcher}, nil
+}
+
+// ParseScriptSetup returns the one-time setup commands a hook script declares
+// in its leading comment block, one per "# Setup:" line, in order:
+//
+//	#!/usr/bin/env sh
+//	# Hook: PostToolUse
+//	# Setup: pip install --user ruff
+func ParseScriptSetup(path string) ([]string, error) {
+	header, err := parseScriptHeader(path)
+	if err != nil {
+		return nil, err
+	}
+	return header["setup"], nil
+}
+
+// parseScriptHeader reads the "key: value" lines of the leading comment block
+// of a script, by lowercased key, in order
+func parseScriptHeader(path string) (map[string][]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	header := make(map[string][]string)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#!") {
+			continue
+		}
+
+		// Only the leading comment block is read
+		comment, ok := strings.CutPrefix(line, "#")
+		if !ok {
+			comment, ok = strings.CutPrefix(line, "//")
+		}
+		if !ok {
+			break
+		}
+
+		key, value, ok := strings.Cut(comment, ":")
+		if !ok {
+			continue
+		}
+		if value = strings.TrimSpace(value); value != "" {
+			key = strings.ToLower(strings.TrimSpace(key))
+			header[key] = append(header[key], value)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return header, nil
+}
+
+// last returns the last of values, or "" if there are none
+func last(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[len(values)-1]
+}
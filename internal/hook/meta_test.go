@@ -0,0 +1,101 @@
+package hook
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func writeTestScript(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "script")
+	if err := os.WriteFile(path, []byte(content), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestParseScriptMeta(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  string
+		want    *ScriptMeta
+		wantErr bool
+	}{
+		{
+			name:   "event and matcher",
+			script: "#!/usr/bin/env sh\n# Hook: PostToolUse\n# Matcher: Edit|Write\necho\n",
+			want:   &ScriptMeta{EventType: PostToolUse, Matcher: "Edit|Write"},
+		},
+		{
+			name:   "any tool without a matcher",
+			script: "#!/usr/bin/env sh\n# Hook: PreToolUse\necho\n",
+			want:   &ScriptMeta{EventType: PreToolUse, Matcher: "*"},
+		},
+		{
+			name:   "alias and key case",
+			script: "# HOOK: post\n# matcher: Bash\n",
+			want:   &ScriptMeta{EventType: PostToolUse, Matcher: "Bash"},
+		},
+		{
+			name:   "slash comments and blank lines",
+			script: "#!/usr/bin/env node\n\n// Hook: Stop\n\n// Description: notify\n",
+			want:   &ScriptMeta{EventType: Stop, Matcher: "*"},
+		},
+		{
+			name:   "last of repeated keys",
+			script: "# Hook: PreToolUse\n# Hook: PostToolUse\n",
+			want:   &ScriptMeta{EventType: PostToolUse, Matcher: "*"},
+		},
+		{
+			name:   "only the leading comment block",
+			script: "#!/usr/bin/env sh\n# Description: lint\nset -e\n# Hook: PreToolUse\n",
+		},
+		{
+			name:   "empty value",
+			script: "# Hook:\n# Matcher: Bash\n",
+		},
+		{
+			name:   "no header",
+			script: "echo hello\n",
+		},
+		{
+			name:    "unknown event",
+			script:  "# Hook: BeforeEverything\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseScriptMeta(writeTestScript(t, tt.script))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseScriptMeta() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.want == nil {
+				if got != nil {
+					t.Errorf("ParseScriptMeta() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil || *got != *tt.want {
+				t.Errorf("ParseScriptMeta() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := ParseScriptMeta(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("ParseScriptMeta() of a missing script: want an error")
+	}
+}
+
+func TestParseScriptSetup(t *testing.T) {
+	script := "#!/usr/bin/env sh\n# Hook: PostToolUse\n# Setup: pip install --user ruff\n# setup: ruff --version\nruff check\n# Setup: not read\n"
+	got, err := ParseScriptSetup(writeTestScript(t, script))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"pip install --user ruff", "ruff --version"}; !slices.Equal(got, want) {
+		t.Errorf("ParseScriptSetup() = %q, want %q", got, want)
+	}
+}
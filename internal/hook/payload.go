@@ -0,0 +1,112 @@
+package hook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ScriptLibName is the shell helper library for hook scripts, installed in the
+// lib directory of the hooks directory
+const ScriptLibName = "jd-hook.sh"
+
+// scriptLib reads the hook payload Claude Code passes on stdin. Fields are
+// extracted with jq if available, otherwise with 'jd hooks payload'.
+const scriptLib = `# jd-hook.sh - helpers for Claude Code hook scripts (installed by jd hooks new)
+#
+# Claude Code passes the hook event as JSON on stdin. Usage:
+#
+#   . "$(dirname "$0")/lib/jd-hook.sh"
+#   hook_read                                  # read stdin into $HOOK_PAYLOAD
+#   tool_name=$(hook_get tool_name)
+#   command=$(hook_get tool_input.command)     # dotted path; array items by index
+#
+# hook_get prints strings as is and other values as JSON, and nothing for a
+# missing field or null. It uses jq if available, otherwise 'jd hooks payload'.
+#
+# This file is overwritten when jd creates a shell hook script; do not edit it.
+
+hook_read() {
+  HOOK_PAYLOAD=$(cat)
+}
+
+hook_get() {
+  if command -v jq >/dev/null 2>&1; then
+    printf '%s' "$HOOK_PAYLOAD" | jq -r --arg p "$1" \
+      'getpath($p | split(".") | map(tonumber? // .)) // empty | if type == "string" then . else tojson end'
+  elif command -v jd >/dev/null 2>&1; then
+    printf '%s' "$HOOK_PAYLOAD" | jd hooks payload "$1"
+  else
+    echo "jd-hook.sh: jq or jd is required to read the hook payload" >&2
+    return 1
+  fi
+}
+`
+
+// InstallScriptLib writes the shell helper library to the lib directory of the
+// hooks directory, replacing an outdated copy. Returns its path.
+func InstallScriptLib() (string, error) {
+	dir, err := EnsureHooksDir()
+	if err != nil {
+		return "", err
+	}
+
+	libDir := filepath.Join(dir, "lib")
+	if err := os.MkdirAll(libDir, 0755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(libDir, ScriptLibName)
+	if existing, err := os.ReadFile(path); err == nil && string(existing) == scriptLib {
+		return path, nil
+	}
+	if err := os.WriteFile(path, []byte(scriptLib), 0644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// PayloadField returns the value at a dotted path (e.g., tool_input.command) in a hook
+// payload. Array items are addressed by index. Strings are returned as is and other
+// values as compact JSON. It returns false if the field is missing or null.
+func PayloadField(payload []byte, path string) (string, bool, error) {
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return "", false, fmt.Errorf("parse hook payload: %w", err)
+	}
+
+	if path != "" && path != "." {
+		for _, key := range strings.Split(strings.TrimPrefix(path, "."), ".") {
+			switch v := value.(type) {
+			case map[string]interface{}:
+				value = v[key]
+			case []interface{}:
+				i, err := strconv.Atoi(key)
+				if err != nil || i < 0 || i >= len(v) {
+					return "", false, nil
+				}
+				value = v[i]
+			default:
+				return "", false, nil
+			}
+		}
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return "", false, nil
+	case string:
+		return v, true, nil
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return "", false, err
+		}
+		return string(data), true, nil
+	}
+}
@@ -0,0 +1,360 @@
+package hook
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/itda-skills/jindo/internal/basedir"
+)
+
+// pinsKey is the section of settings.json where the checksums of the scripts hook
+// commands run are recorded, by the script as written in the command. Claude Code
+// ignores it.
+const pinsKey = "hooks_checksums"
+
+// unquote removes the quotes around a word of a command, or part of it, as in
+// "$CLAUDE_PROJECT_DIR"/.claude/hooks/fmt.sh
+var unquote = strings.NewReplacer(`"`, "", "'", "")
+
+// Statuses of a script checked by Verify
+const (
+	PinOK       = "ok"       // Unchanged since it was pinned
+	PinChanged  = "changed"  // Different from when it was pinned
+	PinMissing  = "missing"  // Pinned, but the file is gone
+	PinUnpinned = "unpinned" // Run by a rule created without a checksum, such as by hand
+)
+
+// ScriptCheck is a script run by hook commands, compared with its pinned checksum
+type ScriptCheck struct {
+	Script   string   `json:"script"` // As written in the command (e.g., ~/.claude/hooks/fmt.sh)
+	Path     string   `json:"path"`
+	Status   string   `json:"status"`
+	Pinned   string   `json:"pinned,omitempty"`
+	Current  string   `json:"current,omitempty"`
+	Hooks    []string `json:"hooks"`
+	Disabled bool     `json:"disabled,omitempty"` // Only disabled hooks run it
+}
+
+// FileChecksum returns the checksum of a file as recorded in settings.json ("sha256:<hex>")
+func FileChecksum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// projectDir returns the project whose .claude directory holds the settings
+// file, which $CLAUDE_PROJECT_DIR and relative paths in its commands refer to,
+// or "" for the global settings
+func (s *Store) projectDir() string {
+	path, err := s.expandPath()
+	if err != nil {
+		return ""
+	}
+	dir := filepath.Dir(path)
+	if global, err := basedir.Expand(basedir.ClaudeDir()); err == nil && filepath.Clean(global) == filepath.Clean(dir) {
+		return ""
+	}
+	if filepath.Base(dir) != ".claude" {
+		return ""
+	}
+	return filepath.Dir(dir)
+}
+
+// resolveScript returns the file a word of a hook command names, with quotes
+// removed and ~, $HOME, and $CLAUDE_PROJECT_DIR expanded. Relative paths are
+// in the project, where Claude Code runs hooks.
+func resolveScript(word, projectDir string) string {
+	home, _ := os.UserHomeDir()
+	vars := []struct{ prefix, value string }{
+		{"~/", home},
+		{"$HOME/", home},
+		{"${HOME}/", home},
+		{"$CLAUDE_PROJECT_DIR/", projectDir},
+		{"${CLAUDE_PROJECT_DIR}/", projectDir},
+	}
+	for _, v := range vars {
+		if rest, ok := strings.CutPrefix(word, v.prefix); ok {
+			if v.value == "" {
+				return ""
+			}
+			return filepath.Join(v.value, filepath.FromSlash(rest))
+		}
+	}
+	if strings.HasPrefix(word, "$") {
+		return ""
+	}
+	if !filepath.IsAbs(word) {
+		if projectDir == "" || !strings.Contains(word, "/") {
+			return ""
+		}
+		return filepath.Join(projectDir, filepath.FromSlash(word))
+	}
+	return word
+}
+
+// commandScripts returns the words of a command naming script files, mapped to
+// the files. Programs in $PATH directories, such as interpreters, are not scripts
+// of the hook.
+func commandScripts(command, projectDir string) map[string]string {
+	pathDirs := make(map[string]bool)
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir != "" {
+			pathDirs[filepath.Clean(dir)] = true
+		}
+	}
+
+	scripts := make(map[string]string)
+	for _, field := range strings.Fields(command) {
+		word := unquote.Replace(field)
+		path := resolveScript(word, projectDir)
+		if path == "" || pathDirs[filepath.Dir(path)] {
+			continue
+		}
+		if info, err := os.Stat(path); err == nil && info.Mode().IsRegular() {
+			scripts[word] = path
+		}
+	}
+	return scripts
+}
+
+// readPins returns the checksums recorded in settings.json, by script
+func readPins(doc *jsonDoc) map[string]string {
+	pins := make(map[string]string)
+	start, end, found, err := doc.find(jsonPath{pinsKey})
+	if err != nil || !found {
+		return pins
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(doc.content[start:end], &raw); err != nil {
+		return pins
+	}
+	for script, sum := range raw {
+		if s, ok := sum.(string); ok {
+			pins[script] = s
+		}
+	}
+	return pins
+}
+
+// pinCommands records the checksums of the scripts the commands run. Scripts
+// already pinned keep their checksum, so a new rule for a script that changed
+// does not hide the change; Accept pins it again.
+func (s *Store) pinCommands(doc *jsonDoc, commands []string) error {
+	projectDir := s.projectDir()
+	pins := readPins(doc)
+	for _, command := range commands {
+		for word, path := range commandScripts(command, projectDir) {
+			if _, pinned := pins[word]; pinned {
+				continue
+			}
+			sum, err := FileChecksum(path)
+			if err != nil {
+				return fmt.Errorf("checksum %s: %w", path, err)
+			}
+			if err := doc.set(jsonPath{pinsKey, word}, sum); err != nil {
+				return fmt.Errorf("pin %s in settings.json: %w", word, err)
+			}
+			pins[word] = sum
+		}
+	}
+	return nil
+}
+
+// prunePins removes the checksums of scripts no rule runs anymore, active or disabled
+func prunePins(doc *jsonDoc) error {
+	pins := readPins(doc)
+	if len(pins) == 0 {
+		return nil
+	}
+
+	var raw map[string]any
+	if err := json.Unmarshal(doc.content, &raw); err != nil {
+		return err
+	}
+	used := make(map[string]bool)
+	for _, section := range []string{hooksKey, disabledHooksKey} {
+		for _, rules := range parseRules(raw[section]) {
+			for _, rule := range rules {
+				for _, h := range rule.Hooks {
+					for _, field := range strings.Fields(h.Command) {
+						used[unquote.Replace(field)] = true
+					}
+				}
+			}
+		}
+	}
+
+	for script := range pins {
+		if used[script] {
+			continue
+		}
+		if err := doc.remove(jsonPath{pinsKey, script}); err != nil {
+			return err
+		}
+		delete(pins, script)
+	}
+	if len(pins) == 0 {
+		return doc.remove(jsonPath{pinsKey})
+	}
+	return nil
+}
+
+// Verify compares the scripts that hook commands run, active or disabled, with
+// the checksums pinned when their rules were created, ordered by script
+func (s *Store) Verify() ([]ScriptCheck, error) {
+	settings, doc, err := s.readSettings()
+	if err != nil {
+		return nil, err
+	}
+	pins := readPins(doc)
+	projectDir := s.projectDir()
+
+	checks := make(map[string]*ScriptCheck)
+	add := func(hooks []*Hook) {
+		for _, h := range hooks {
+			for _, command := range h.Commands {
+				scripts := commandScripts(command, projectDir)
+				// A pinned script that is gone is still run by the command
+				for _, field := range strings.Fields(command) {
+					word := unquote.Replace(field)
+					if _, pinned := pins[word]; pinned {
+						if _, found := scripts[word]; !found {
+							scripts[word] = resolveScript(word, projectDir)
+						}
+					}
+				}
+
+				for word, path := range scripts {
+					c, ok := checks[word]
+					if !ok {
+						c = &ScriptCheck{Script: word, Path: path, Pinned: pins[word], Disabled: true}
+						checks[word] = c
+					}
+					if len(c.Hooks) == 0 || c.Hooks[len(c.Hooks)-1] != h.Name {
+						c.Hooks = append(c.Hooks, h.Name)
+					}
+					c.Disabled = c.Disabled && h.Disabled
+				}
+			}
+		}
+	}
+	add(hooksFromRules(settings.Hooks, false))
+	add(hooksFromRules(settings.Disabled, true))
+
+	result := make([]ScriptCheck, 0, len(checks))
+	for _, c := range checks {
+		sort.Strings(c.Hooks)
+		c.Current, err = FileChecksum(c.Path)
+		switch {
+		case err != nil:
+			c.Current = ""
+			c.Status = PinMissing
+		case c.Pinned == "":
+			c.Status = PinUnpinned
+		case c.Pinned != c.Current:
+			c.Status = PinChanged
+		default:
+			c.Status = PinOK
+		}
+		result = append(result, *c)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Script < result[j].Script })
+	return result, nil
+}
+
+// Pins returns the checksums recorded for the scripts a command runs
+func (s *Store) Pins(command string) (map[string]string, error) {
+	_, doc, err := s.readSettings()
+	if err != nil {
+		return nil, err
+	}
+	all := readPins(doc)
+	pins := make(map[string]string)
+	for _, field := range strings.Fields(command) {
+		word := unquote.Replace(field)
+		if sum, ok := all[word]; ok {
+			pins[word] = sum
+		}
+	}
+	return pins, nil
+}
+
+// RestorePins records checksums taken earlier with Pins, such as the ones of
+// a hook package's script from before it was updated, so that the update is
+// reported as a change instead of pinned. Scripts no rule runs are skipped.
+func (s *Store) RestorePins(pins map[string]string) error {
+	if len(pins) == 0 {
+		return nil
+	}
+	_, doc, err := s.readSettings()
+	if err != nil {
+		return err
+	}
+	current := readPins(doc)
+	changed := false
+	for script, sum := range pins {
+		if have, ok := current[script]; !ok || have == sum {
+			continue
+		}
+		if err := doc.set(jsonPath{pinsKey, script}, sum); err != nil {
+			return fmt.Errorf("pin %s in settings.json: %w", script, err)
+		}
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	return s.writeSettings(doc)
+}
+
+// Accept pins the current checksums of the given scripts, as written in hook
+// commands, and forgets the ones of missing scripts
+func (s *Store) Accept(scripts []string) error {
+	checks, err := s.Verify()
+	if err != nil {
+		return err
+	}
+	_, doc, err := s.readSettings()
+	if err != nil {
+		return err
+	}
+
+	want := make(map[string]bool)
+	for _, script := range scripts {
+		want[script] = true
+	}
+	for _, c := range checks {
+		if !want[c.Script] {
+			continue
+		}
+		if c.Current == "" {
+			err = doc.remove(jsonPath{pinsKey, c.Script})
+		} else {
+			err = doc.set(jsonPath{pinsKey, c.Script}, c.Current)
+		}
+		if err != nil {
+			return fmt.Errorf("pin %s in settings.json: %w", c.Script, err)
+		}
+	}
+	if n, found, err := doc.len(jsonPath{pinsKey}); err == nil && found && n == 0 {
+		if err := doc.remove(jsonPath{pinsKey}); err != nil {
+			return err
+		}
+	}
+	return s.writeSettings(doc)
+}
@@ -0,0 +1,238 @@
+package hook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/itda-skills/jindo/internal/shell"
+)
+
+// Decision is what Claude Code does after a hook command runs
+type Decision string
+
+const (
+	DecisionAllow Decision = "allow" // Exit 0: the tool call proceeds
+	DecisionError Decision = "error" // Exit code other than 0 and 2: reported, but does not block
+	DecisionAsk   Decision = "ask"   // permissionDecision "ask": the user is asked to confirm
+	DecisionBlock Decision = "block" // Exit 2, decision "block", or permissionDecision "deny"
+	DecisionStop  Decision = "stop"  // "continue": false: Claude stops
+)
+
+// decisionRank orders decisions by how much they hold Claude back
+var decisionRank = map[Decision]int{
+	DecisionAllow: 0,
+	DecisionError: 1,
+	DecisionAsk:   2,
+	DecisionBlock: 3,
+	DecisionStop:  4,
+}
+
+// ParseDecision parses a decision name, accepting Claude Code's "approve" and "deny"
+func ParseDecision(s string) (Decision, error) {
+	switch strings.ToLower(s) {
+	case "allow", "approve":
+		return DecisionAllow, nil
+	case "error":
+		return DecisionError, nil
+	case "ask":
+		return DecisionAsk, nil
+	case "block", "deny":
+		return DecisionBlock, nil
+	case "stop":
+		return DecisionStop, nil
+	}
+	return "", fmt.Errorf("invalid decision: %s (use: allow, ask, block, stop, error)", s)
+}
+
+// Stronger returns the decision that holds Claude back more
+func Stronger(a, b Decision) Decision {
+	if decisionRank[b] > decisionRank[a] {
+		return b
+	}
+	return a
+}
+
+// Fixture is a recorded hook payload, as Claude Code passes it on stdin
+type Fixture struct {
+	Payload   json.RawMessage // Passed to the hook commands, without the expect field
+	EventType EventType
+	ToolName  string
+	Cwd       string
+	Expect    Decision // Decision the hooks are expected to make, or "" for none
+}
+
+// ParseFixtures parses a fixture file: one payload or an array of payloads. A
+// payload may add an "expect" field naming the decision the hooks should make.
+func ParseFixtures(data []byte) ([]Fixture, error) {
+	var raws []json.RawMessage
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &raws); err != nil {
+			return nil, fmt.Errorf("parse fixtures: %w", err)
+		}
+	} else {
+		raws = []json.RawMessage{trimmed}
+	}
+
+	fixtures := make([]Fixture, 0, len(raws))
+	for i, raw := range raws {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			return nil, fmt.Errorf("parse fixture %d: %w", i+1, err)
+		}
+
+		var f Fixture
+		var event, expect string
+		for key, target := range map[string]*string{"hook_event_name": &event, "tool_name": &f.ToolName, "cwd": &f.Cwd, "expect": &expect} {
+			if value, ok := fields[key]; ok {
+				if err := json.Unmarshal(value, target); err != nil {
+					return nil, fmt.Errorf("parse fixture %d: %s: %w", i+1, key, err)
+				}
+			}
+		}
+		if event != "" {
+			et, err := ParseEventType(event)
+			if err != nil {
+				return nil, fmt.Errorf("fixture %d: %w", i+1, err)
+			}
+			f.EventType = et
+		}
+		if expect != "" {
+			d, err := ParseDecision(expect)
+			if err != nil {
+				return nil, fmt.Errorf("fixture %d: %w", i+1, err)
+			}
+			f.Expect = d
+		}
+
+		delete(fields, "expect")
+		payload, err := json.Marshal(fields)
+		if err != nil {
+			return nil, err
+		}
+		f.Payload = payload
+		fixtures = append(fixtures, f)
+	}
+	return fixtures, nil
+}
+
+// MatchingHooks returns the hooks that fire for an event and tool, in the order of
+// their rules in settings.json
+func MatchingHooks(hooks []*Hook, eventType EventType, tool string) []*Hook {
+	var matched []*Hook
+	for _, h := range hooks {
+		if h.Disabled || h.EventType != eventType {
+			continue
+		}
+		if IsToolEvent(eventType) && !MatchesTool(h.Matcher, tool) {
+			continue
+		}
+		matched = append(matched, h)
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		_, a, _ := parseHookName(matched[i].Name)
+		_, b, _ := parseHookName(matched[j].Name)
+		return a < b
+	})
+	return matched
+}
+
+// CommandResult is the outcome of running a hook command on a payload
+type CommandResult struct {
+	Command  string        `json:"command"`
+	ExitCode int           `json:"exit_code"`
+	Decision Decision      `json:"decision"`
+	Reason   string        `json:"reason,omitempty"`
+	Stdout   string        `json:"stdout,omitempty"`
+	Stderr   string        `json:"stderr,omitempty"`
+	Duration time.Duration `json:"duration_ns"`
+}
+
+// RunCommand runs a hook command with the platform shell, passing the payload on
+// stdin as Claude Code does, and decides what Claude Code would do with its result.
+// A command that cannot start or outlives the timeout is an error.
+func RunCommand(ctx context.Context, command string, payload []byte, dir string, timeout time.Duration) CommandResult {
+	var stdout, stderr bytes.Buffer
+	start := time.Now()
+	err := shell.Run(ctx, command, shell.Options{
+		Dir:     dir,
+		Env:     append(os.Environ(), "CLAUDE_PROJECT_DIR="+dir),
+		Stdin:   bytes.NewReader(payload),
+		Stdout:  &stdout,
+		Stderr:  &stderr,
+		Timeout: timeout,
+	})
+	result := CommandResult{
+		Command:  command,
+		Stdout:   strings.TrimRight(stdout.String(), "\n"),
+		Stderr:   strings.TrimRight(stderr.String(), "\n"),
+		Duration: time.Since(start),
+	}
+
+	var exitErr *exec.ExitError
+	switch {
+	case errors.As(err, &exitErr):
+		result.ExitCode = exitErr.ExitCode()
+		result.Decision, result.Reason = exitDecision(result.ExitCode, result.Stderr)
+	case err != nil:
+		result.ExitCode = -1
+		result.Decision = DecisionError
+		result.Reason = err.Error()
+	default:
+		result.Decision, result.Reason = outputDecision(stdout.Bytes())
+	}
+	return result
+}
+
+// exitDecision decides on a command that exited with a non-zero code: 2 blocks
+// and feeds stderr to Claude, others are non-blocking errors
+func exitDecision(code int, stderr string) (Decision, string) {
+	if code == 2 {
+		return DecisionBlock, stderr
+	}
+	return DecisionError, stderr
+}
+
+// hookOutput is the JSON a hook command may print on stdout to control Claude Code
+type hookOutput struct {
+	Continue           *bool  `json:"continue"`
+	StopReason         string `json:"stopReason"`
+	Decision           string `json:"decision"`
+	Reason             string `json:"reason"`
+	HookSpecificOutput struct {
+		PermissionDecision       string `json:"permissionDecision"`
+		PermissionDecisionReason string `json:"permissionDecisionReason"`
+	} `json:"hookSpecificOutput"`
+}
+
+// outputDecision decides on a command that exited with 0 from the JSON it printed,
+// if any; plain output allows
+func outputDecision(stdout []byte) (Decision, string) {
+	var out hookOutput
+	trimmed := bytes.TrimSpace(stdout)
+	if len(trimmed) == 0 || trimmed[0] != '{' || json.Unmarshal(trimmed, &out) != nil {
+		return DecisionAllow, ""
+	}
+
+	if out.Continue != nil && !*out.Continue {
+		return DecisionStop, out.StopReason
+	}
+	if p := out.HookSpecificOutput.PermissionDecision; p != "" {
+		if d, err := ParseDecision(p); err == nil {
+			return d, out.HookSpecificOutput.PermissionDecisionReason
+		}
+	}
+	if out.Decision != "" {
+		if d, err := ParseDecision(out.Decision); err == nil {
+			return d, out.Reason
+		}
+	}
+	return DecisionAllow, ""
+}
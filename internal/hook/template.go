@@ -0,0 +1,236 @@
+package hook
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// ScriptLang is the language of a hook script created by 'jd hooks new --script'
+type ScriptLang string
+
+const (
+	LangShell      ScriptLang = "sh"
+	LangPython     ScriptLang = "python"
+	LangNode       ScriptLang = "node"
+	LangPowerShell ScriptLang = "powershell"
+)
+
+// AllScriptLangs returns all supported script languages
+func AllScriptLangs() []ScriptLang {
+	return []ScriptLang{LangShell, LangPython, LangNode, LangPowerShell}
+}
+
+// ParseScriptLang parses a script language name with alias support
+// Accepts: sh (shell, bash), python (py), node (js, javascript), powershell (pwsh, ps1)
+func ParseScriptLang(s string) (ScriptLang, error) {
+	aliases := map[string]ScriptLang{
+		"sh":         LangShell,
+		"shell":      LangShell,
+		"bash":       LangShell,
+		"python":     LangPython,
+		"py":         LangPython,
+		"node":       LangNode,
+		"js":         LangNode,
+		"javascript": LangNode,
+		"powershell": LangPowerShell,
+		"pwsh":       LangPowerShell,
+		"ps1":        LangPowerShell,
+	}
+
+	if lang, ok := aliases[strings.ToLower(strings.TrimSpace(s))]; ok {
+		return lang, nil
+	}
+	return "", fmt.Errorf("invalid script language: %s\nValid languages: sh, python(py), node(js), powershell(pwsh)", s)
+}
+
+// Extension returns the file extension of scripts in the language
+func (l ScriptLang) Extension() string {
+	switch l {
+	case LangPython:
+		return ".py"
+	case LangNode:
+		return ".js"
+	case LangPowerShell:
+		return ".ps1"
+	default:
+		return ".sh"
+	}
+}
+
+// interpreters returns the interpreter commands for the language, preferred first.
+// The first one is used in the script's shebang.
+func (l ScriptLang) interpreters() []string {
+	switch l {
+	case LangPython:
+		return []string{"python3", "python"}
+	case LangNode:
+		return []string{"node"}
+	case LangPowerShell:
+		return []string{"pwsh", "powershell"}
+	default:
+		return []string{"sh"}
+	}
+}
+
+// FindInterpreter returns the first interpreter for the language found in PATH
+func FindInterpreter(lang ScriptLang) (string, error) {
+	candidates := lang.interpreters()
+	for _, name := range candidates {
+		if _, err := exec.LookPath(name); err == nil {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("no %s interpreter found in PATH (looked for %s)", lang, strings.Join(candidates, ", "))
+}
+
+// ScriptCommand returns the settings.json command that runs a script at path with
+// interpreter (the preferred one if empty). Scripts whose shebang names the interpreter run directly; PowerShell
+// scripts, and scripts needing a different interpreter than their shebang, are passed
+// to the interpreter.
+func ScriptCommand(lang ScriptLang, interpreter, path string) string {
+	if interpreter == "" {
+		interpreter = lang.interpreters()[0]
+	}
+	switch {
+	case lang == LangPowerShell:
+		return fmt.Sprintf("%s -NoProfile -File %q", interpreter, path)
+	case interpreter != "" && interpreter != lang.interpreters()[0]:
+		return fmt.Sprintf("%s %q", interpreter, path)
+	default:
+		return path
+	}
+}
+
+// ScriptTemplate returns the starter script for a hook in the language. The header
+// declares the event type and matcher in the format ParseScriptMeta reads.
+func ScriptTemplate(lang ScriptLang, eventType EventType, matcher string) string {
+	template, comment := shellTemplate, "#"
+	switch lang {
+	case LangPython:
+		template = pythonTemplate
+	case LangNode:
+		template, comment = nodeTemplate, "//"
+	case LangPowerShell:
+		template = powerShellTemplate
+	}
+	header := fmt.Sprintf("%[1]s Hook: %[2]s\n%[1]s Matcher: %[3]s\n%[1]s Created by jd hooks new", comment, eventType, matcher)
+	return fmt.Sprintf(template, comment, header, eventType)
+}
+
+// hookProtocol documents the hook protocol in each template's header comments
+const hookProtocol = `%[1]s Claude Code passes the event as JSON on stdin, e.g. for PreToolUse:
+%[1]s   {"session_id": "...", "transcript_path": "...", "cwd": "...",
+%[1]s    "hook_event_name": "PreToolUse", "tool_name": "Bash",
+%[1]s    "tool_input": {"command": "ls"}}
+%[1]s PostToolUse adds "tool_response"; Notification adds "message".
+%[1]s
+%[1]s Exit codes:
+%[1]s   0     success; stdout is shown in transcript mode (Ctrl-R)
+%[1]s   2     blocking error; stderr is fed back to Claude
+%[1]s         (PreToolUse: the tool call is blocked; Stop: Claude keeps working)
+%[1]s   other non-blocking error; stderr is shown to the user`
+
+const shellTemplate = `#!/usr/bin/env sh
+%[2]s
+%[1]s
+` + hookProtocol + `
+
+# Helpers to read the JSON payload (installed by jd hooks new)
+. "$(dirname "$0")/lib/jd-hook.sh"
+
+hook_read
+tool_name=$(hook_get tool_name)
+
+# To block the tool call (PreToolUse), explain why on stderr and exit 2:
+# echo "reason shown to Claude" >&2
+# exit 2
+
+echo "Hook triggered: %[3]s for ${tool_name:-unknown tool}"
+exit 0
+`
+
+const pythonTemplate = `#!/usr/bin/env python3
+%[2]s
+%[1]s
+` + hookProtocol + `
+
+import json
+import sys
+
+
+def main() -> int:
+    try:
+        payload = json.load(sys.stdin)
+    except json.JSONDecodeError as e:
+        print(f"invalid hook payload: {e}", file=sys.stderr)
+        return 1
+
+    tool_name = payload.get("tool_name", "")
+    tool_input = payload.get("tool_input", {})
+
+    # To block the tool call (PreToolUse), explain why on stderr and exit 2:
+    # print("reason shown to Claude", file=sys.stderr)
+    # return 2
+
+    print(f"Hook triggered: %[3]s for {tool_name or 'unknown tool'}")
+    return 0
+
+
+if __name__ == "__main__":
+    sys.exit(main())
+`
+
+const nodeTemplate = `#!/usr/bin/env node
+%[2]s
+%[1]s
+` + hookProtocol + `
+
+let input = "";
+process.stdin.setEncoding("utf8");
+process.stdin.on("data", (chunk) => (input += chunk));
+process.stdin.on("end", () => {
+  let payload;
+  try {
+    payload = JSON.parse(input);
+  } catch (e) {
+    console.error(` + "`invalid hook payload: ${e.message}`" + `);
+    process.exit(1);
+  }
+
+  const toolName = payload.tool_name || "";
+  const toolInput = payload.tool_input || {};
+
+  // To block the tool call (PreToolUse), explain why on stderr and exit 2:
+  // console.error("reason shown to Claude");
+  // process.exit(2);
+
+  console.log(` + "`Hook triggered: %[3]s for ${toolName || \"unknown tool\"}`" + `);
+  process.exit(0);
+});
+`
+
+const powerShellTemplate = `#!/usr/bin/env pwsh
+%[2]s
+%[1]s
+` + hookProtocol + `
+
+$ErrorActionPreference = "Stop"
+
+try {
+    $payload = [Console]::In.ReadToEnd() | ConvertFrom-Json
+} catch {
+    [Console]::Error.WriteLine("invalid hook payload: $_")
+    exit 1
+}
+
+$toolName = $payload.tool_name
+$toolInput = $payload.tool_input
+
+# To block the tool call (PreToolUse), explain why on stderr and exit 2:
+# [Console]::Error.WriteLine("reason shown to Claude")
+# exit 2
+
+Write-Output "Hook triggered: %[3]s for $(if ($toolName) { $toolName } else { 'unknown tool' })"
+exit 0
+`
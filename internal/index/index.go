@@ -0,0 +1,424 @@
+// Package index maintains an on-disk inverted index of skills, commands, agents,
+// and hooks: those installed in Claude Code and those in registered repositories.
+// Each source of documents is replaced as a whole when its fingerprint changes,
+// so the index is updated incrementally as packages are installed and repositories pulled.
+package index
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/itda-skills/jindo/internal/frontmatter"
+)
+
+// version is the version of the index file format
+const version = 1
+
+// SourceInstalled is the source of the resources installed in Claude Code
+const SourceInstalled = "installed"
+
+// repoSourcePrefix prefixes the source of a repository's packages
+const repoSourcePrefix = "repo:"
+
+// ErrCorrupt is returned by Open when the index file cannot be read; rebuild it
+var ErrCorrupt = errors.New("index is corrupt or from another version")
+
+// RepoSource returns the source of a repository's packages
+func RepoSource(namespace string) string {
+	return repoSourcePrefix + namespace
+}
+
+// RepoNamespace returns the namespace of a repository source, or "" for other sources
+func RepoNamespace(source string) string {
+	namespace, ok := strings.CutPrefix(source, repoSourcePrefix)
+	if !ok {
+		return ""
+	}
+	return namespace
+}
+
+// Fields of a document a token appears in
+const (
+	FieldContent     = 1 << iota // File content
+	FieldDescription             // Frontmatter description
+	FieldName                    // Name or path
+)
+
+// Doc is an indexed skill, command, agent, or hook
+type Doc struct {
+	Source      string `json:"source"`
+	Namespace   string `json:"namespace,omitempty"` // Repository of a package
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Path        string `json:"path"` // Package path in the repository, or the file of an installed resource
+	Description string `json:"description,omitempty"`
+}
+
+// Entry is a document to index, with the content of its file
+type Entry struct {
+	Doc
+	Content string
+}
+
+// NewEntry returns the entry of a document with the content of its file. A
+// document without a description takes the one in the content's frontmatter.
+func NewEntry(doc Doc, content string) Entry {
+	if doc.Description == "" {
+		doc.Description = frontmatterDescription(content)
+	}
+	return Entry{Doc: doc, Content: content}
+}
+
+// frontmatterDescription returns the description field of markdown frontmatter
+func frontmatterDescription(content string) string {
+	return frontmatter.Split(content).Values()["description"]
+}
+
+// posting is an occurrence of a token in a document
+type posting struct {
+	Doc    int   `json:"d"`
+	Fields uint8 `json:"f"`
+}
+
+// Source records when a source was indexed
+type Source struct {
+	Fingerprint string `json:"fingerprint"` // Changes when the source's documents change; empty to always refresh
+	Docs        int    `json:"docs"`
+}
+
+// indexFile is the structure of the index file
+type indexFile struct {
+	Version  int                  `json:"version"`
+	Sources  map[string]Source    `json:"sources"`
+	Docs     []Doc                `json:"docs"`
+	Postings map[string][]posting `json:"postings"`
+}
+
+// Index is an inverted index of documents, grouped by source
+type Index struct {
+	path     string
+	sources  map[string]Source
+	docs     []Doc
+	removed  map[int]bool
+	postings map[string][]posting
+	vocab    []string // Sorted tokens, built on the first search
+}
+
+// New returns an empty index saved to path
+func New(path string) *Index {
+	return &Index{
+		path:     path,
+		sources:  make(map[string]Source),
+		removed:  make(map[int]bool),
+		postings: make(map[string][]posting),
+	}
+}
+
+// Open reads the index saved at path. A missing file yields an empty index; an
+// unreadable one yields an empty index and ErrCorrupt.
+func Open(path string) (*Index, error) {
+	ix := New(path)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return ix, nil
+	}
+	if err != nil {
+		return ix, err
+	}
+
+	var file indexFile
+	if err := json.Unmarshal(data, &file); err != nil || file.Version != version {
+		return ix, ErrCorrupt
+	}
+	for _, list := range file.Postings {
+		for _, p := range list {
+			if p.Doc < 0 || p.Doc >= len(file.Docs) {
+				return ix, ErrCorrupt
+			}
+		}
+	}
+	if file.Sources != nil {
+		ix.sources = file.Sources
+	}
+	if file.Postings != nil {
+		ix.postings = file.Postings
+	}
+	ix.docs = file.Docs
+	return ix, nil
+}
+
+// Path returns the file the index is saved to
+func (ix *Index) Path() string {
+	return ix.path
+}
+
+// Sources returns the indexed sources by name
+func (ix *Index) Sources() map[string]Source {
+	return ix.sources
+}
+
+// Fingerprint returns the fingerprint a source was indexed with, and whether it was indexed
+func (ix *Index) Fingerprint(source string) (string, bool) {
+	s, ok := ix.sources[source]
+	return s.Fingerprint, ok
+}
+
+// Set replaces the documents of a source
+func (ix *Index) Set(source, fingerprint string, entries []Entry) {
+	ix.Remove(source)
+
+	for _, e := range entries {
+		id := len(ix.docs)
+		e.Source = source
+		ix.docs = append(ix.docs, e.Doc)
+
+		fields := make(map[string]uint8)
+		for _, t := range Tokenize(e.Name + " " + e.Path) {
+			fields[t] |= FieldName
+		}
+		for _, t := range Tokenize(e.Description) {
+			fields[t] |= FieldDescription
+		}
+		for _, t := range Tokenize(e.Content) {
+			fields[t] |= FieldContent
+		}
+		for t, f := range fields {
+			ix.postings[t] = append(ix.postings[t], posting{Doc: id, Fields: f})
+		}
+	}
+	ix.sources[source] = Source{Fingerprint: fingerprint, Docs: len(entries)}
+	ix.vocab = nil
+}
+
+// Remove removes the documents of a source
+func (ix *Index) Remove(source string) {
+	if _, ok := ix.sources[source]; !ok {
+		return
+	}
+	delete(ix.sources, source)
+	for id, doc := range ix.docs {
+		if doc.Source == source {
+			ix.removed[id] = true
+		}
+	}
+}
+
+// Docs returns the documents of the sources, or of every source if none are given
+func (ix *Index) Docs(sources ...string) []Doc {
+	var docs []Doc
+	for id, doc := range ix.docs {
+		if !ix.removed[id] && inSources(doc.Source, sources) {
+			docs = append(docs, doc)
+		}
+	}
+	return docs
+}
+
+// Hit is a document that matches a query
+type Hit struct {
+	Doc
+	Score   int    // Higher for matches in the name, then the description
+	MatchIn string // Best field of the first query token: "name", "description", or "content"
+}
+
+// Search returns the documents of the sources (every source if none are given)
+// that contain, for every word of the query, a word starting with it; best first
+func (ix *Index) Search(query string, sources ...string) []Hit {
+	tokens := Tokenize(query)
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	var scores map[int]int
+	var firstFields map[int]uint8
+	for i, q := range tokens {
+		best := ix.matchTokens(q, true)
+		if i == 0 {
+			firstFields = best
+		}
+		next := make(map[int]int)
+		for id, fields := range best {
+			if prev, ok := scores[id]; ok || i == 0 {
+				next[id] = prev + fieldScore(fields)
+			}
+		}
+		scores = next
+	}
+
+	var hits []Hit
+	for id, score := range scores {
+		doc := ix.docs[id]
+		if ix.removed[id] || !inSources(doc.Source, sources) {
+			continue
+		}
+		hits = append(hits, Hit{Doc: doc, Score: score, MatchIn: fieldName(firstFields[id])})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		if hits[i].Namespace != hits[j].Namespace {
+			return hits[i].Namespace < hits[j].Namespace
+		}
+		if hits[i].Name != hits[j].Name {
+			return hits[i].Name < hits[j].Name
+		}
+		return hits[i].Path < hits[j].Path
+	})
+	return hits
+}
+
+// Candidates returns the paths of documents in the sources that may contain query
+// as a substring: every word of the query appears inside a word of the document.
+// Searchers still need to check the content, but can skip every other document.
+// A query without words narrows nothing and yields nil.
+func (ix *Index) Candidates(query string, sources ...string) map[string]bool {
+	tokens := Tokenize(query)
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	var matched map[int]bool
+	for i, q := range tokens {
+		next := make(map[int]bool)
+		for id := range ix.matchTokens(q, false) {
+			if i == 0 || matched[id] {
+				next[id] = true
+			}
+		}
+		matched = next
+	}
+
+	paths := make(map[string]bool)
+	for id := range matched {
+		if doc := ix.docs[id]; !ix.removed[id] && inSources(doc.Source, sources) {
+			paths[doc.Path] = true
+		}
+	}
+	return paths
+}
+
+// matchTokens returns the fields each document contains a token in that starts with
+// q, or with prefix false, that contains q
+func (ix *Index) matchTokens(q string, prefix bool) map[int]uint8 {
+	if ix.vocab == nil {
+		ix.vocab = make([]string, 0, len(ix.postings))
+		for t := range ix.postings {
+			ix.vocab = append(ix.vocab, t)
+		}
+		sort.Strings(ix.vocab)
+	}
+
+	// Prefix matches are a contiguous range of the sorted vocabulary
+	vocab := ix.vocab
+	if prefix {
+		start := sort.SearchStrings(vocab, q)
+		end := start
+		for end < len(vocab) && strings.HasPrefix(vocab[end], q) {
+			end++
+		}
+		vocab = vocab[start:end]
+	}
+
+	fields := make(map[int]uint8)
+	for _, t := range vocab {
+		if !prefix && !strings.Contains(t, q) {
+			continue
+		}
+		for _, p := range ix.postings[t] {
+			fields[p.Doc] |= p.Fields
+		}
+	}
+	return fields
+}
+
+// fieldScore weighs a match by the most important field it is in
+func fieldScore(fields uint8) int {
+	switch {
+	case fields&FieldName != 0:
+		return 3
+	case fields&FieldDescription != 0:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// fieldName names the most important field of a match
+func fieldName(fields uint8) string {
+	switch {
+	case fields&FieldName != 0:
+		return "name"
+	case fields&FieldDescription != 0:
+		return "description"
+	default:
+		return "content"
+	}
+}
+
+// inSources reports whether source is one of sources, or sources is empty
+func inSources(source string, sources []string) bool {
+	if len(sources) == 0 {
+		return true
+	}
+	for _, s := range sources {
+		if s == source {
+			return true
+		}
+	}
+	return false
+}
+
+// Tokenize splits text into lowercase words of letters and digits
+func Tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// Save writes the index, dropping removed documents
+func (ix *Index) Save() error {
+	// Renumber the remaining documents
+	ids := make(map[int]int, len(ix.docs))
+	docs := make([]Doc, 0, len(ix.docs)-len(ix.removed))
+	for id, doc := range ix.docs {
+		if ix.removed[id] {
+			continue
+		}
+		ids[id] = len(docs)
+		docs = append(docs, doc)
+	}
+	postings := make(map[string][]posting, len(ix.postings))
+	for t, list := range ix.postings {
+		var kept []posting
+		for _, p := range list {
+			if newID, ok := ids[p.Doc]; ok {
+				kept = append(kept, posting{Doc: newID, Fields: p.Fields})
+			}
+		}
+		if len(kept) > 0 {
+			postings[t] = kept
+		}
+	}
+	ix.docs, ix.postings, ix.removed, ix.vocab = docs, postings, make(map[int]bool), nil
+
+	data, err := json.Marshal(indexFile{Version: version, Sources: ix.sources, Docs: ix.docs, Postings: ix.postings})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(ix.path), 0755); err != nil {
+		return fmt.Errorf("create index directory: %w", err)
+	}
+	// Write to a temporary file first, so a concurrent reader never sees a partial index
+	tmp := ix.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, ix.path)
+}
@@ -0,0 +1,148 @@
+package index
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func names(hits []Hit) []string {
+	var names []string
+	for _, h := range hits {
+		names = append(names, h.Name)
+	}
+	return names
+}
+
+func newTestIndex(t *testing.T) *Index {
+	t.Helper()
+	ix := New(filepath.Join(t.TempDir(), "index.json"))
+	ix.Set(RepoSource("team"), "abc", []Entry{
+		{Doc: Doc{Namespace: "team", Type: "skill", Name: "web-fetch", Path: "skills/web-fetch"}, Content: "Fetch web pages"},
+		{Doc: Doc{Namespace: "team", Type: "command", Name: "commit", Path: "commands/commit.md", Description: "Write a git commit"}, Content: "Commit staged changes"},
+		{Doc: Doc{Namespace: "team", Type: "agent", Name: "reviewer", Path: "agents/reviewer.md"}, Content: "Reviews git diffs before fetching"},
+	})
+	ix.Set(SourceInstalled, "", []Entry{
+		{Doc: Doc{Type: "skill", Name: "notes", Path: "/home/me/.claude/skills/notes/SKILL.md"}, Content: "Keep Notes in Markdown"},
+	})
+	return ix
+}
+
+func TestSearch(t *testing.T) {
+	ix := newTestIndex(t)
+
+	tests := []struct {
+		query   string
+		sources []string
+		want    []string
+	}{
+		{"fetch", nil, []string{"web-fetch", "reviewer"}}, // Name before content
+		{"git", nil, []string{"commit", "reviewer"}},      // Description before content
+		{"GIT comm", nil, []string{"commit"}},             // Every word must match
+		{"mark", nil, []string{"notes"}},                  // Words match by prefix
+		{"mark", []string{RepoSource("team")}, nil},
+		{"etch", nil, nil},
+		{"--", nil, nil},
+	}
+	for _, tt := range tests {
+		if got := names(ix.Search(tt.query, tt.sources...)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Search(%q, %v) = %v, want %v", tt.query, tt.sources, got, tt.want)
+		}
+	}
+
+	if hit := ix.Search("fetch")[1]; hit.MatchIn != "content" || hit.Source != RepoSource("team") {
+		t.Errorf("Search(fetch)[1] = %+v, want a content match from the team repository", hit)
+	}
+}
+
+func TestCandidates(t *testing.T) {
+	ix := newTestIndex(t)
+
+	got := ix.Candidates("etch", RepoSource("team"))
+	want := map[string]bool{"skills/web-fetch": true, "agents/reviewer.md": true}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Candidates(etch) = %v, want %v", got, want)
+	}
+	if got := ix.Candidates("s in mark"); !reflect.DeepEqual(got, map[string]bool{"/home/me/.claude/skills/notes/SKILL.md": true}) {
+		t.Errorf("Candidates(s in mark) = %v, want the notes skill", got)
+	}
+	if got := ix.Candidates("-"); got != nil {
+		t.Errorf("Candidates(-) = %v, want nil", got)
+	}
+}
+
+func TestSetRemoveAndSave(t *testing.T) {
+	ix := newTestIndex(t)
+
+	// Replacing a source drops its old documents
+	ix.Set(RepoSource("team"), "def", []Entry{
+		{Doc: Doc{Namespace: "team", Type: "skill", Name: "pdf", Path: "skills/pdf"}, Content: "Read PDF files"},
+	})
+	if got := names(ix.Search("fetch")); got != nil {
+		t.Errorf("Search(fetch) after replacing the source = %v, want none", got)
+	}
+	ix.Remove(SourceInstalled)
+	if err := ix.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	saved, err := Open(ix.Path())
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	if fp, ok := saved.Fingerprint(RepoSource("team")); !ok || fp != "def" {
+		t.Errorf("Fingerprint(team) = %q, %v; want def, true", fp, ok)
+	}
+	if _, ok := saved.Fingerprint(SourceInstalled); ok {
+		t.Error("removed source is still indexed")
+	}
+	var docs []string
+	for _, d := range saved.Docs() {
+		docs = append(docs, d.Name)
+	}
+	sort.Strings(docs)
+	if !reflect.DeepEqual(docs, []string{"pdf"}) {
+		t.Errorf("Docs() = %v, want [pdf]", docs)
+	}
+	if got := names(saved.Search("pdf")); !reflect.DeepEqual(got, []string{"pdf"}) {
+		t.Errorf("Search(pdf) after reopening = %v, want [pdf]", got)
+	}
+}
+
+func TestOpen(t *testing.T) {
+	dir := t.TempDir()
+
+	ix, err := Open(filepath.Join(dir, "missing.json"))
+	if err != nil || len(ix.Docs()) != 0 {
+		t.Errorf("Open(missing) = %d docs, %v; want an empty index", len(ix.Docs()), err)
+	}
+
+	for name, content := range map[string]string{
+		"garbage.json":  "not json",
+		"version.json":  `{"version": 99}`,
+		"dangling.json": `{"version": 1, "docs": [], "postings": {"a": [{"d": 3, "f": 1}]}}`,
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Open(path); err != ErrCorrupt {
+			t.Errorf("Open(%s) error = %v, want ErrCorrupt", name, err)
+		}
+	}
+}
+
+func TestNewEntry(t *testing.T) {
+	content := "---\nname: fetch\ndescription: \"Fetch web pages\"\n---\nBody"
+	if got := NewEntry(Doc{Name: "fetch"}, content).Description; got != "Fetch web pages" {
+		t.Errorf("NewEntry() description = %q, want the frontmatter description", got)
+	}
+	if got := NewEntry(Doc{Name: "fetch", Description: "Given"}, content).Description; got != "Given" {
+		t.Errorf("NewEntry() description = %q, want the given description", got)
+	}
+	if got := NewEntry(Doc{Name: "hook"}, "#!/bin/sh\ndescription: no"); got.Description != "" {
+		t.Errorf("NewEntry() description = %q, want none without frontmatter", got.Description)
+	}
+}
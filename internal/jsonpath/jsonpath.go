@@ -0,0 +1,599 @@
+// Package jsonpath evaluates kubectl-style JSONPath templates on decoded JSON,
+// such as '{.packages[*].name}' or
+// '{range .packages[*]}{.name}{"\t"}{.version.sha}{"\n"}{end}'.
+//
+// Text outside braces is printed as is. Inside braces are paths, "string"
+// literals, and range <path> ... end blocks. A path is made of .field, ['field'],
+// .* and [*] (every element or value), [n] (negative counts from the end),
+// [start:end], ..field (at any depth), and filters such as [?(@.type == "skill")].
+// Paths start at the current element of a range, or the root outside one; $
+// always starts at the root. A template without braces is one path.
+package jsonpath
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Template is a parsed JSONPath template
+type Template struct {
+	nodes []node
+}
+
+type nodeKind int
+
+const (
+	textNode  nodeKind = iota // Literal text
+	pathNode                  // Values of a path
+	rangeNode                 // The body, for each value of a path
+)
+
+type node struct {
+	kind nodeKind
+	text string
+	path path
+	body []node
+}
+
+// Parse parses a JSONPath template
+func Parse(template string) (*Template, error) {
+	if !strings.Contains(template, "{") {
+		template = "{" + template + "}"
+	}
+	p := &parser{src: template}
+	nodes, err := p.parseNodes(false)
+	if err != nil {
+		return nil, err
+	}
+	return &Template{nodes: nodes}, nil
+}
+
+type parser struct {
+	src string
+	pos int
+}
+
+// parseNodes parses until the end of the template, or of the range body if inRange
+func (p *parser) parseNodes(inRange bool) ([]node, error) {
+	var nodes []node
+	for p.pos < len(p.src) {
+		open := strings.IndexByte(p.src[p.pos:], '{')
+		if open < 0 {
+			nodes = append(nodes, node{kind: textNode, text: p.src[p.pos:]})
+			p.pos = len(p.src)
+			break
+		}
+		if open > 0 {
+			nodes = append(nodes, node{kind: textNode, text: p.src[p.pos : p.pos+open]})
+		}
+		start := p.pos + open + 1
+		end := closingBrace(p.src, start)
+		if end < 0 {
+			return nil, fmt.Errorf("unclosed { at offset %d", start-1)
+		}
+		action := strings.TrimSpace(p.src[start:end])
+		p.pos = end + 1
+
+		switch {
+		case action == "end":
+			if !inRange {
+				return nil, fmt.Errorf("{end} without {range}")
+			}
+			return nodes, nil
+		case strings.HasPrefix(action, "range ") || action == "range":
+			pth, err := parsePath(strings.TrimSpace(strings.TrimPrefix(action, "range")))
+			if err != nil {
+				return nil, err
+			}
+			body, err := p.parseNodes(true)
+			if err != nil {
+				return nil, err
+			}
+			nodes = append(nodes, node{kind: rangeNode, path: pth, body: body})
+		case strings.HasPrefix(action, `"`):
+			text, err := strconv.Unquote(action)
+			if err != nil {
+				return nil, fmt.Errorf("invalid string %s: %w", action, err)
+			}
+			nodes = append(nodes, node{kind: textNode, text: text})
+		default:
+			pth, err := parsePath(action)
+			if err != nil {
+				return nil, err
+			}
+			nodes = append(nodes, node{kind: pathNode, path: pth})
+		}
+	}
+	if inRange {
+		return nil, fmt.Errorf("{range} without {end}")
+	}
+	return nodes, nil
+}
+
+// closingBrace returns the index of the } closing an action starting at start,
+// skipping braces in quoted strings, or -1
+func closingBrace(s string, start int) int {
+	var quote byte
+	for i := start; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '}':
+			return i
+		}
+	}
+	return -1
+}
+
+// Execute writes the template evaluated on data, a value decoded from JSON
+// (maps, slices, strings, float64 or json.Number, bools, and nil)
+func (t *Template) Execute(w io.Writer, data any) error {
+	var b strings.Builder
+	if err := execute(&b, t.nodes, data, data); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func execute(b *strings.Builder, nodes []node, root, current any) error {
+	for _, n := range nodes {
+		switch n.kind {
+		case textNode:
+			b.WriteString(n.text)
+		case pathNode:
+			values := n.path.eval(root, current)
+			for i, v := range values {
+				if i > 0 {
+					b.WriteByte(' ')
+				}
+				b.WriteString(format(v))
+			}
+		case rangeNode:
+			for _, v := range n.path.eval(root, current) {
+				items := []any{v}
+				if list, ok := v.([]any); ok {
+					items = list
+				}
+				for _, item := range items {
+					if err := execute(b, n.body, root, item); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// format prints a value: strings and numbers as is, objects and arrays as JSON
+func format(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	}
+}
+
+type stepKind int
+
+const (
+	fieldStep     stepKind = iota // .name or ['name']
+	wildcardStep                  // .* or [*]
+	indexStep                     // [n]
+	sliceStep                     // [start:end]
+	recursiveStep                 // .. before a field or wildcard
+	filterStep                    // [?(...)]
+)
+
+type step struct {
+	kind       stepKind
+	name       string
+	index      int
+	start, end *int
+	filter     *filter
+}
+
+// path is a parsed path, from the root if absolute
+type path struct {
+	absolute bool
+	steps    []step
+}
+
+// parsePath parses a path such as .packages[0].name or $..sha
+func parsePath(s string) (path, error) {
+	var p path
+	src := s
+	switch {
+	case strings.HasPrefix(s, "$"):
+		p.absolute = true
+		s = s[1:]
+	case strings.HasPrefix(s, "@"):
+		s = s[1:]
+	}
+
+	for s != "" {
+		switch {
+		case strings.HasPrefix(s, ".."):
+			p.steps = append(p.steps, step{kind: recursiveStep})
+			s = s[1:] // The field after .. is parsed as .field
+		case strings.HasPrefix(s, ".*"):
+			p.steps = append(p.steps, step{kind: wildcardStep})
+			s = s[2:]
+		case strings.HasPrefix(s, "."):
+			name, rest := splitName(s[1:])
+			if name == "" {
+				if rest == "" {
+					return p, nil // "." is the current value
+				}
+				return p, fmt.Errorf("invalid path %q: expected a field name at %q", src, s)
+			}
+			p.steps = append(p.steps, step{kind: fieldStep, name: name})
+			s = rest
+		case strings.HasPrefix(s, "["):
+			end := closingBracket(s)
+			if end < 0 {
+				return p, fmt.Errorf("invalid path %q: unclosed [", src)
+			}
+			st, err := parseBracket(strings.TrimSpace(s[1:end]))
+			if err != nil {
+				return p, fmt.Errorf("invalid path %q: %w", src, err)
+			}
+			p.steps = append(p.steps, st)
+			s = s[end+1:]
+		default:
+			// A leading field name without a dot, as in {packages[0]}
+			name, rest := splitName(s)
+			if name == "" || len(p.steps) > 0 {
+				return p, fmt.Errorf("invalid path %q at %q", src, s)
+			}
+			p.steps = append(p.steps, step{kind: fieldStep, name: name})
+			s = rest
+		}
+	}
+	return p, nil
+}
+
+// splitName splits a field name off the front of s
+func splitName(s string) (string, string) {
+	i := 0
+	for i < len(s) && s[i] != '.' && s[i] != '[' && s[i] != ' ' {
+		i++
+	}
+	return s[:i], s[i:]
+}
+
+// closingBracket returns the index of the ] closing the [ at the start of s,
+// skipping brackets in quotes and nested ones in filters, or -1
+func closingBracket(s string) int {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// parseBracket parses what is between [ and ]
+func parseBracket(s string) (step, error) {
+	switch {
+	case s == "*":
+		return step{kind: wildcardStep}, nil
+	case strings.HasPrefix(s, "?"):
+		f, err := parseFilter(s[1:])
+		if err != nil {
+			return step{}, err
+		}
+		return step{kind: filterStep, filter: f}, nil
+	case strings.HasPrefix(s, "'") || strings.HasPrefix(s, `"`):
+		name, err := unquote(s)
+		if err != nil {
+			return step{}, err
+		}
+		return step{kind: fieldStep, name: name}, nil
+	case strings.Contains(s, ":"):
+		from, to, _ := strings.Cut(s, ":")
+		st := step{kind: sliceStep}
+		for _, bound := range []struct {
+			text string
+			dest **int
+		}{{from, &st.start}, {to, &st.end}} {
+			text := strings.TrimSpace(bound.text)
+			if text == "" {
+				continue
+			}
+			n, err := strconv.Atoi(text)
+			if err != nil {
+				return step{}, fmt.Errorf("invalid slice [%s]", s)
+			}
+			*bound.dest = &n
+		}
+		return st, nil
+	default:
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return step{}, fmt.Errorf("invalid index [%s]", s)
+		}
+		return step{kind: indexStep, index: n}, nil
+	}
+}
+
+// unquote removes single or double quotes around a string
+func unquote(s string) (string, error) {
+	if strings.HasPrefix(s, "'") {
+		if len(s) < 2 || !strings.HasSuffix(s, "'") {
+			return "", fmt.Errorf("unclosed quote in %s", s)
+		}
+		return s[1 : len(s)-1], nil
+	}
+	return strconv.Unquote(s)
+}
+
+// filter is a condition on the elements of an array: a path relative to the
+// element, compared with a literal, or on its own, that the path has a value
+type filter struct {
+	left  path
+	op    string
+	right any
+}
+
+var filterOps = []string{"==", "!=", "<=", ">=", "<", ">"}
+
+// parseFilter parses a filter such as (@.type == "skill") or (@.pin)
+func parseFilter(s string) (*filter, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "(") || !strings.HasSuffix(s, ")") {
+		return nil, fmt.Errorf("invalid filter ?%s: expected ?(...)", s)
+	}
+	expr := strings.TrimSpace(s[1 : len(s)-1])
+
+	f := &filter{}
+	left := expr
+	for _, op := range filterOps {
+		if i := strings.Index(expr, op); i >= 0 {
+			left, f.op = strings.TrimSpace(expr[:i]), op
+			right := strings.TrimSpace(expr[i+len(op):])
+			value, err := parseLiteral(right)
+			if err != nil {
+				return nil, fmt.Errorf("invalid filter ?%s: %w", s, err)
+			}
+			f.right = value
+			break
+		}
+	}
+	if !strings.HasPrefix(left, "@") {
+		return nil, fmt.Errorf("invalid filter ?%s: expected a path starting with @", s)
+	}
+	pth, err := parsePath(left)
+	if err != nil {
+		return nil, err
+	}
+	f.left = pth
+	return f, nil
+}
+
+// parseLiteral parses the right side of a filter: a quoted string, a number,
+// true, false, or null
+func parseLiteral(s string) (any, error) {
+	switch s {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+	if strings.HasPrefix(s, "'") || strings.HasPrefix(s, `"`) {
+		return unquote(s)
+	}
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value %s", s)
+	}
+	return n, nil
+}
+
+// eval returns the values the path selects
+func (p path) eval(root, current any) []any {
+	values := []any{current}
+	if p.absolute {
+		values = []any{root}
+	}
+	for i := 0; i < len(p.steps); i++ {
+		st := p.steps[i]
+		if st.kind == recursiveStep {
+			var all []any
+			for _, v := range values {
+				all = append(all, descendants(v)...)
+			}
+			values = all
+			continue
+		}
+		var next []any
+		for _, v := range values {
+			next = append(next, st.apply(root, v)...)
+		}
+		values = next
+	}
+	return values
+}
+
+// apply returns the values a step selects in v
+func (st step) apply(root, v any) []any {
+	switch st.kind {
+	case fieldStep:
+		if m, ok := v.(map[string]any); ok {
+			if value, found := m[st.name]; found {
+				return []any{value}
+			}
+		}
+	case wildcardStep:
+		switch v := v.(type) {
+		case []any:
+			return v
+		case map[string]any:
+			keys := make([]string, 0, len(v))
+			for k := range v {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			values := make([]any, 0, len(v))
+			for _, k := range keys {
+				values = append(values, v[k])
+			}
+			return values
+		}
+	case indexStep:
+		if list, ok := v.([]any); ok {
+			i := st.index
+			if i < 0 {
+				i += len(list)
+			}
+			if i >= 0 && i < len(list) {
+				return []any{list[i]}
+			}
+		}
+	case sliceStep:
+		if list, ok := v.([]any); ok {
+			start, end := 0, len(list)
+			if st.start != nil {
+				start = bound(*st.start, len(list))
+			}
+			if st.end != nil {
+				end = bound(*st.end, len(list))
+			}
+			if start < end {
+				return list[start:end]
+			}
+		}
+	case filterStep:
+		if list, ok := v.([]any); ok {
+			var values []any
+			for _, item := range list {
+				if st.filter.match(root, item) {
+					values = append(values, item)
+				}
+			}
+			return values
+		}
+	}
+	return nil
+}
+
+// bound resolves a slice bound, negative from the end, within 0 and n
+func bound(i, n int) int {
+	if i < 0 {
+		i += n
+	}
+	return max(0, min(i, n))
+}
+
+// descendants returns v and all values nested in it, depth first
+func descendants(v any) []any {
+	values := []any{v}
+	switch v := v.(type) {
+	case []any:
+		for _, item := range v {
+			values = append(values, descendants(item)...)
+		}
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			values = append(values, descendants(v[k])...)
+		}
+	}
+	return values
+}
+
+// match reports whether an element meets the filter
+func (f *filter) match(root, item any) bool {
+	values := f.left.eval(root, item)
+	if f.op == "" {
+		return len(values) > 0 && values[0] != nil && values[0] != false
+	}
+	if len(values) == 0 {
+		return f.op == "!="
+	}
+	left := values[0]
+	if n, ok := left.(json.Number); ok {
+		if parsed, err := n.Float64(); err == nil {
+			left = parsed
+		}
+	}
+
+	switch f.op {
+	case "==":
+		return left == f.right
+	case "!=":
+		return left != f.right
+	}
+	switch l := left.(type) {
+	case float64:
+		r, ok := f.right.(float64)
+		return ok && compare(l < r, l > r, f.op)
+	case string:
+		r, ok := f.right.(string)
+		return ok && compare(l < r, l > r, f.op)
+	}
+	return false
+}
+
+// compare applies an ordering operator given whether left is less or greater
+func compare(less, greater bool, op string) bool {
+	switch op {
+	case "<":
+		return less
+	case ">":
+		return greater
+	case "<=":
+		return !greater
+	case ">=":
+		return !less
+	}
+	return false
+}
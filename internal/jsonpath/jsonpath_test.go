@@ -0,0 +1,83 @@
+package jsonpath
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const testData = `{
+  "packages": [
+    {"name": "ns--fetch", "type": "skill", "version": {"sha": "abc123", "ref": "main"}, "files": 3},
+    {"name": "ns--lint", "type": "hook", "version": {"sha": "def456", "ref": "v1.2.0"}, "files": 1, "pin": {"ref": "v1.2.0"}},
+    {"name": "team--deploy", "type": "command", "version": {"sha": "789abc", "ref": "main"}, "files": 2}
+  ],
+  "total": 3
+}`
+
+func TestExecute(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{"field", "{.total}", "3"},
+		{"without braces", ".packages[0].name", "ns--fetch"},
+		{"without leading dot", "{packages[1].name}", "ns--lint"},
+		{"wildcard joins with spaces", "{.packages[*].name}", "ns--fetch ns--lint team--deploy"},
+		{"negative index", "{.packages[-1].name}", "team--deploy"},
+		{"slice", "{.packages[0:2].version.ref}", "main v1.2.0"},
+		{"open slice", "{.packages[1:].type}", "hook command"},
+		{"quoted field", "{.packages[0]['version'].sha}", "abc123"},
+		{"recursive", "{..sha}", "abc123 def456 789abc"},
+		{"root in range", `{range .packages[*]}{.name}/{$.total}{"\n"}{end}`, "ns--fetch/3\nns--lint/3\nteam--deploy/3\n"},
+		{"range over array", `{range .packages}{.name}{"\t"}{.version.sha}{"\n"}{end}`, "ns--fetch\tabc123\nns--lint\tdef456\nteam--deploy\t789abc\n"},
+		{"text around", "total: {.total} packages", "total: 3 packages"},
+		{"filter equals", `{.packages[?(@.type == "hook")].name}`, "ns--lint"},
+		{"filter single quotes", `{.packages[?(@.version.ref=='main')].name}`, "ns--fetch team--deploy"},
+		{"filter not equals", `{.packages[?(@.type != "hook")].name}`, "ns--fetch team--deploy"},
+		{"filter number", `{.packages[?(@.files > 1)].name}`, "ns--fetch team--deploy"},
+		{"filter exists", `{.packages[?(@.pin)].name}`, "ns--lint"},
+		{"object as json", "{.packages[1].pin}", `{"ref":"v1.2.0"}`},
+		{"missing field is empty", "{.packages[0].pin}", ""},
+		{"index out of range is empty", "{.packages[5].name}", ""},
+		{"current value", "{.packages[0].version.ref}{.}", `main{"packages":[{"files":3,"name":"ns--fetch","type":"skill","version":{"ref":"main","sha":"abc123"}},{"files":1,"name":"ns--lint","pin":{"ref":"v1.2.0"},"type":"hook","version":{"ref":"v1.2.0","sha":"def456"}},{"files":2,"name":"team--deploy","type":"command","version":{"ref":"main","sha":"789abc"}}],"total":3}`},
+	}
+
+	var data any
+	if err := json.Unmarshal([]byte(testData), &data); err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := Parse(tt.template)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.template, err)
+			}
+			var b strings.Builder
+			if err := tmpl.Execute(&b, data); err != nil {
+				t.Fatalf("Execute(%q) error = %v", tt.template, err)
+			}
+			if got := b.String(); got != tt.want {
+				t.Errorf("Execute(%q) = %q, want %q", tt.template, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, template := range []string{
+		"{.packages[0}",
+		"{.packages",
+		"{range .packages[*]}{.name}",
+		"{.name}{end}",
+		"{.packages[x]}",
+		"{.packages[?(@.type ==)]}",
+		"{.packages[?(.type)]}",
+		`{"unclosed}`,
+	} {
+		if _, err := Parse(template); err == nil {
+			t.Errorf("Parse(%q) error = nil, want an error", template)
+		}
+	}
+}
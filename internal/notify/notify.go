@@ -0,0 +1,157 @@
+// Package notify posts messages about available package updates to a team's
+// chat webhook (Slack, Discord) or any endpoint that takes JSON, and remembers
+// which versions it has announced so each is announced once.
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// SentFile records the versions announced, in the jd data directory
+const SentFile = "notified.json"
+
+// Format is the payload shape a webhook expects
+type Format string
+
+const (
+	Slack   Format = "slack"   // {"text": ...}
+	Discord Format = "discord" // {"content": ...}
+	JSON    Format = "json"    // {"event", "text", "host", "updates"}, for any other endpoint
+)
+
+// Formats are the payload formats, in the order they are documented
+var Formats = []Format{Slack, Discord, JSON}
+
+// ParseFormat returns the format named s
+func ParseFormat(s string) (Format, error) {
+	for _, f := range Formats {
+		if string(f) == strings.ToLower(s) {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("invalid webhook format: %s (slack, discord, or json)", s)
+}
+
+// DetectFormat returns the format of a webhook URL: Slack and Discord by their
+// host, JSON for any other
+func DetectFormat(url string) Format {
+	switch {
+	case strings.Contains(url, "://hooks.slack.com/"):
+		return Slack
+	case strings.Contains(url, "://discord.com/api/webhooks/"), strings.Contains(url, "://discordapp.com/api/webhooks/"):
+		return Discord
+	}
+	return JSON
+}
+
+// Update is an available package update in a message
+type Update struct {
+	Name         string `json:"name"`
+	Namespace    string `json:"namespace"`
+	Type         string `json:"type"`
+	Current      string `json:"current"` // Short SHA or tag installed
+	Latest       string `json:"latest"`
+	ChangedFiles int    `json:"changed_files"`
+	Pinned       bool   `json:"pinned"`
+	ChangelogURL string `json:"changelog_url,omitempty"` // The changes on the web, if the source has a web page
+}
+
+// Message is what a notification is rendered from
+type Message struct {
+	Host    string   `json:"host"`
+	Updates []Update `json:"updates"`
+}
+
+// DefaultTemplate is the text of a notification unless one is configured
+const DefaultTemplate = `{{len .Updates}} package update{{if ne (len .Updates) 1}}s{{end}} available on {{.Host}}:
+{{range .Updates}}• {{.Name}} {{.Current}} → {{.Latest}} ({{.ChangedFiles}} file{{if ne .ChangedFiles 1}}s{{end}} changed{{if .Pinned}}, pinned{{end}}){{if .ChangelogURL}} {{.ChangelogURL}}{{end}}
+{{end}}Install them with: jd pkg update --apply`
+
+// Render renders a message with a text/template, the default one if tmpl is empty
+func Render(tmpl string, msg Message) (string, error) {
+	if tmpl == "" {
+		tmpl = DefaultTemplate
+	}
+	t, err := template.New("notify").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("parse template: %w", err)
+	}
+	var b strings.Builder
+	if err := t.Execute(&b, msg); err != nil {
+		return "", fmt.Errorf("render template: %w", err)
+	}
+	return strings.TrimSpace(b.String()), nil
+}
+
+// Payload returns the request body that posts text in a format. The JSON
+// format also carries the updates, for endpoints that process them.
+func Payload(format Format, text string, msg Message) ([]byte, error) {
+	switch format {
+	case Slack:
+		return json.Marshal(map[string]string{"text": text})
+	case Discord:
+		return json.Marshal(map[string]string{"content": text})
+	default:
+		return json.Marshal(struct {
+			Event string `json:"event"`
+			Text  string `json:"text"`
+			Message
+		}{"updates-available", text, msg})
+	}
+}
+
+// Send posts a payload to a webhook URL
+func Send(url string, payload []byte) error {
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Post(url, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+		return fmt.Errorf("webhook returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// Sent maps package names to the latest version announced for each
+type Sent map[string]string
+
+// LoadSent reads the versions announced from the file at path. A missing file
+// has none.
+func LoadSent(path string) (Sent, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return Sent{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	sent := Sent{}
+	if err := json.Unmarshal(data, &sent); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return sent, nil
+}
+
+// Save writes the versions announced to the file at path
+func (s Sent) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
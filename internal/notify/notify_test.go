@@ -0,0 +1,108 @@
+package notify
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		url  string
+		want Format
+	}{
+		{"https://hooks.slack.com/services/T0/B0/x", Slack},
+		{"https://discord.com/api/webhooks/1/abc", Discord},
+		{"https://discordapp.com/api/webhooks/1/abc", Discord},
+		{"https://example.com/hooks/jd", JSON},
+	}
+	for _, tt := range tests {
+		if got := DetectFormat(tt.url); got != tt.want {
+			t.Errorf("DetectFormat(%q) = %s, want %s", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestRender(t *testing.T) {
+	msg := Message{Host: "ci", Updates: []Update{
+		{Name: "affa-ever--web-fetch", Current: "abc12345", Latest: "def67890", ChangedFiles: 2, ChangelogURL: "https://github.com/o/r/compare/abc...def"},
+		{Name: "affa-ever--lint", Current: "v1.0.0", Latest: "v1.1.0", ChangedFiles: 1, Pinned: true},
+	}}
+	got, err := Render("", msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `2 package updates available on ci:
+• affa-ever--web-fetch abc12345 → def67890 (2 files changed) https://github.com/o/r/compare/abc...def
+• affa-ever--lint v1.0.0 → v1.1.0 (1 file changed, pinned)
+Install them with: jd pkg update --apply`
+	if got != want {
+		t.Errorf("Render() =\n%s\nwant\n%s", got, want)
+	}
+
+	got, err = Render("{{range .Updates}}{{.Name}} {{end}}", msg)
+	if err != nil || got != "affa-ever--web-fetch affa-ever--lint" {
+		t.Errorf("Render() with a template = %q, %v", got, err)
+	}
+	if _, err := Render("{{.Missing", msg); err == nil {
+		t.Error("Render() of a malformed template should fail")
+	}
+}
+
+func TestPayload(t *testing.T) {
+	msg := Message{Host: "ci", Updates: []Update{{Name: "web-fetch"}}}
+	for format, key := range map[Format]string{Slack: "text", Discord: "content", JSON: "text"} {
+		data, err := Payload(format, "hello", msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var body map[string]any
+		if err := json.Unmarshal(data, &body); err != nil {
+			t.Fatal(err)
+		}
+		if body[key] != "hello" {
+			t.Errorf("%s payload = %s, want %s set", format, data, key)
+		}
+		if _, ok := body["updates"]; ok != (format == JSON) {
+			t.Errorf("%s payload = %s: updates only belong to json", format, data)
+		}
+	}
+}
+
+func TestSend(t *testing.T) {
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got = string(body)
+		if strings.Contains(got, "fail") {
+			http.Error(w, "no such channel", http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	if err := Send(server.URL, []byte(`{"text":"hi"}`)); err != nil || got != `{"text":"hi"}` {
+		t.Errorf("Send() = %v, server got %s", err, got)
+	}
+	if err := Send(server.URL, []byte(`{"text":"fail"}`)); err == nil || !strings.Contains(err.Error(), "no such channel") {
+		t.Errorf("Send() to a failing webhook = %v", err)
+	}
+}
+
+func TestSent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), SentFile)
+	sent, err := LoadSent(path)
+	if err != nil || len(sent) != 0 {
+		t.Fatalf("LoadSent() of a missing file = %v, %v", sent, err)
+	}
+	sent["web-fetch"] = "def67890"
+	if err := sent.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	if sent, err = LoadSent(path); err != nil || sent["web-fetch"] != "def67890" {
+		t.Errorf("LoadSent() = %v, %v", sent, err)
+	}
+}
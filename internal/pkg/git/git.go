@@ -2,13 +2,59 @@ package git
 
 import (
 	"bufio"
+	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"regexp"
 	"runtime"
+	"strconv"
 	"strings"
+
+	"github.com/itda-skills/jindo/internal/progress"
+	"github.com/itda-skills/jindo/internal/shell"
 )
 
+// RemoteError is returned when a git operation cannot reach the remote.
+type RemoteError struct {
+	Op  string // clone, pull, or fetch
+	Err error
+}
+
+func (e *RemoteError) Error() string { return fmt.Sprintf("git %s: %v", e.Op, e.Err) }
+
+func (e *RemoteError) Unwrap() error { return e.Err }
+
+// networkErrorPattern matches git's messages for a remote that cannot be
+// reached, as opposed to one refusing the operation (a pull that cannot
+// fast-forward, a missing repository or branch, or failed authentication).
+var networkErrorPattern = regexp.MustCompile(`(?i)could not resolve (host|proxy)|temporary failure in name resolution|failed to connect|couldn.t connect to server|connection (refused|reset|timed out)|operation timed out|network is unreachable|no route to host|remote end hung up unexpectedly|early eof|ssl_|gnutls`)
+
+// remoteError wraps a failed remote operation, keeping nil as nil. It is a
+// RemoteError only if git's error output says the remote could not be reached.
+func remoteError(op string, err error, stderr string) error {
+	if err == nil {
+		return nil
+	}
+	if networkErrorPattern.MatchString(stderr) {
+		return &RemoteError{Op: op, Err: err}
+	}
+	return fmt.Errorf("git %s: %w", op, err)
+}
+
+// runRemote runs a git command that talks to the remote, keeping a copy of its
+// error output to tell network failures apart.
+func runRemote(op string, cmd *exec.Cmd) error {
+	var stderr strings.Builder
+	if cmd.Stderr != nil {
+		cmd.Stderr = io.MultiWriter(cmd.Stderr, &stderr)
+	} else {
+		cmd.Stderr = &stderr
+	}
+	return remoteError(op, cmd.Run(), stderr.String())
+}
+
 // IsInstalled checks if git is installed and available in PATH.
 func IsInstalled() bool {
 	_, err := exec.LookPath("git")
@@ -102,19 +148,8 @@ func Install() error {
 	}
 
 	// Use shell to execute the install command
-	var cmd *exec.Cmd
-	switch runtime.GOOS {
-	case "windows":
-		cmd = exec.Command("cmd", "/C", installCmd)
-	default:
-		cmd = exec.Command("sh", "-c", installCmd)
-	}
-
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	cmd.Stdin = os.Stdin
-
-	if err := cmd.Run(); err != nil {
+	err := shell.Run(context.Background(), installCmd, shell.Options{Stdin: os.Stdin, Stdout: os.Stdout, Stderr: os.Stderr})
+	if err != nil {
 		return fmt.Errorf("install git: %w", err)
 	}
 
@@ -137,17 +172,91 @@ func EnsureInstalled() error {
 }
 
 // Clone clones a repository to the specified path.
-func Clone(url, destPath string) error {
-	cmd := exec.Command("git", "clone", "--depth", "1", url, destPath)
+// If branch is empty, the remote's default branch is checked out.
+func Clone(url, destPath, branch string) error {
+	args := []string{"clone", "--depth", "1"}
+	if branch != "" {
+		args = append(args, "--branch", branch)
+	}
+	cmd := exec.Command("git", append(args, url, destPath)...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	return cmd.Run()
+	return runRemote("clone", cmd)
+}
+
+// cloneProgressRegex matches git's progress lines, e.g.
+// "Receiving objects:  45% (450/1000), 1.20 MiB | 2.00 MiB/s".
+var cloneProgressRegex = regexp.MustCompile(`^(?:remote: )?([A-Za-z ]+):\s+\d+% \((\d+)/(\d+)\)`)
+
+// CloneWithProgress clones a repository like Clone, reporting git's progress
+// (counting, receiving, resolving) to r instead of printing git's output.
+// git's other output is printed only if the clone fails.
+func CloneWithProgress(url, destPath, branch string, r progress.Reporter) error {
+	args := []string{"clone", "--progress", "--depth", "1"}
+	if branch != "" {
+		args = append(args, "--branch", branch)
+	}
+	cmd := exec.Command("git", append(args, url, destPath)...)
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("git clone: %w", err)
+	}
+
+	var output []string
+	phase := ""
+	scanner := bufio.NewScanner(stderr)
+	scanner.Split(scanProgressLines)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		m := cloneProgressRegex.FindStringSubmatch(line)
+		if m == nil {
+			if line != "" {
+				output = append(output, line)
+			}
+			continue
+		}
+		done, _ := strconv.ParseInt(m[2], 10, 64)
+		total, _ := strconv.ParseInt(m[3], 10, 64)
+		if m[1] != phase {
+			phase = m[1]
+			r.Start(phase, total, progress.Items)
+		}
+		r.Update(done)
+	}
+	if phase != "" {
+		r.Finish()
+	}
+
+	if err := cmd.Wait(); err != nil {
+		for _, line := range output {
+			fmt.Fprintln(os.Stderr, line)
+		}
+		return remoteError("clone", err, strings.Join(output, "\n"))
+	}
+	return nil
+}
+
+// scanProgressLines splits git's stderr on both newlines and the carriage
+// returns git uses to redraw progress lines.
+func scanProgressLines(data []byte, atEOF bool) (int, []byte, error) {
+	for i, b := range data {
+		if b == '\n' || b == '\r' {
+			return i + 1, data[:i], nil
+		}
+	}
+	if atEOF && len(data) > 0 {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
 }
 
 // CloneQuiet clones a repository quietly.
 func CloneQuiet(url, destPath string) error {
 	cmd := exec.Command("git", "clone", "--depth", "1", "--quiet", url, destPath)
-	return cmd.Run()
+	return runRemote("clone", cmd)
 }
 
 // Pull pulls the latest changes in a repository.
@@ -155,19 +264,36 @@ func Pull(repoPath string) error {
 	cmd := exec.Command("git", "-C", repoPath, "pull", "--ff-only")
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	return cmd.Run()
+	return runRemote("pull", cmd)
 }
 
 // PullQuiet pulls quietly.
 func PullQuiet(repoPath string) error {
 	cmd := exec.Command("git", "-C", repoPath, "pull", "--ff-only", "--quiet")
-	return cmd.Run()
+	return runRemote("pull", cmd)
 }
 
 // Fetch fetches the latest changes without merging.
 func Fetch(repoPath string) error {
 	cmd := exec.Command("git", "-C", repoPath, "fetch", "--quiet")
-	return cmd.Run()
+	return runRemote("fetch", cmd)
+}
+
+// SwitchBranch re-points a shallow clone at another remote branch.
+// The clone then fetches and tracks only that branch.
+func SwitchBranch(repoPath, branch string) error {
+	steps := [][]string{
+		{"remote", "set-branches", "origin", branch},
+		{"fetch", "--quiet", "--depth", "1", "origin", branch},
+		{"checkout", "--quiet", "-B", branch, "origin/" + branch},
+	}
+	for _, args := range steps {
+		cmd := exec.Command("git", append([]string{"-C", repoPath}, args...)...)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("git %s: %s", args[0], strings.TrimSpace(string(output)))
+		}
+	}
+	return nil
 }
 
 // GetCurrentCommit returns the current commit SHA.
@@ -180,6 +306,16 @@ func GetCurrentCommit(repoPath string) (string, error) {
 	return strings.TrimSpace(string(output)), nil
 }
 
+// GetCurrentBranch returns the currently checked out branch name.
+func GetCurrentBranch(repoPath string) (string, error) {
+	cmd := exec.Command("git", "-C", repoPath, "rev-parse", "--abbrev-ref", "HEAD")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
 // GetRemoteCommit returns the latest remote commit SHA.
 func GetRemoteCommit(repoPath, branch string) (string, error) {
 	cmd := exec.Command("git", "-C", repoPath, "rev-parse", "origin/"+branch)
@@ -204,6 +340,19 @@ func GetDefaultBranch(repoPath string) (string, error) {
 		}
 	}
 
+	// Ask the remote (single-branch clones of another branch have no origin/HEAD)
+	cmd = exec.Command("git", "-C", repoPath, "ls-remote", "--symref", "origin", "HEAD")
+	if output, err := cmd.Output(); err == nil {
+		// ref: refs/heads/main	HEAD
+		for _, line := range strings.Split(string(output), "\n") {
+			if ref, ok := strings.CutPrefix(line, "ref: refs/heads/"); ok {
+				if branch, _, ok := strings.Cut(ref, "\t"); ok {
+					return branch, nil
+				}
+			}
+		}
+	}
+
 	// Fallback: try common branch names
 	for _, branch := range []string{"main", "master"} {
 		cmd := exec.Command("git", "-C", repoPath, "show-ref", "--verify", "--quiet", "refs/remotes/origin/"+branch)
@@ -215,6 +364,36 @@ func GetDefaultBranch(repoPath string) (string, error) {
 	return "", fmt.Errorf("cannot determine default branch")
 }
 
+// VerifyHead checks that HEAD of a clone resolves to a commit that is present,
+// which an interrupted clone or pull can leave it without.
+func VerifyHead(repoPath string) error {
+	cmd := exec.Command("git", "-C", repoPath, "rev-parse", "--verify", "HEAD^{commit}")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("HEAD does not resolve to a commit: %s", firstLine(output, err))
+	}
+	return nil
+}
+
+// Fsck checks that every object reachable in a clone is present and readable.
+// It reads the whole history, so it is slower than VerifyHead.
+func Fsck(repoPath string) error {
+	cmd := exec.Command("git", "-C", repoPath, "fsck", "--connectivity-only", "--no-dangling", "--no-progress")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git fsck: %s", firstLine(output, err))
+	}
+	return nil
+}
+
+// firstLine returns the first line of a failed command's output, or the error
+// when it printed nothing
+func firstLine(output []byte, err error) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(string(output)), "\n")
+	if line == "" {
+		return err.Error()
+	}
+	return line
+}
+
 // HasChanges checks if there are new commits on remote.
 func HasChanges(repoPath, branch string) (bool, error) {
 	if err := Fetch(repoPath); err != nil {
@@ -234,20 +413,104 @@ func HasChanges(repoPath, branch string) (bool, error) {
 	return local != remote, nil
 }
 
-// ListChangedFiles returns files changed between two commits.
-func ListChangedFiles(repoPath, fromCommit, toCommit string) ([]string, error) {
-	cmd := exec.Command("git", "-C", repoPath, "diff", "--name-only", fromCommit, toCommit)
+// FileChange is a file added, modified, deleted, or renamed between two commits.
+type FileChange struct {
+	Status  byte   // 'A', 'M', 'D', 'R', ... as git diff --name-status reports it
+	Path    string // Path relative to the repository, after a rename
+	OldPath string // Path before a rename; empty otherwise
+}
+
+// ListFileChanges returns the files changed between two commits, with renames detected.
+func ListFileChanges(repoPath, fromCommit, toCommit string) ([]FileChange, error) {
+	cmd := exec.Command("git", "-C", repoPath, "diff", "--name-status", "-M", "-z", fromCommit, toCommit)
 	output, err := cmd.Output()
 	if err != nil {
 		return nil, err
 	}
 
-	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-	var files []string
-	for _, line := range lines {
-		if line != "" {
-			files = append(files, line)
+	// <status>\0<path>\0, or <status><score>\0<old path>\0<new path>\0 for renames and copies
+	fields := strings.Split(strings.TrimSuffix(string(output), "\x00"), "\x00")
+	var changes []FileChange
+	for i := 0; i+1 < len(fields); i += 2 {
+		c := FileChange{Status: fields[i][0], Path: fields[i+1]}
+		if (c.Status == 'R' || c.Status == 'C') && i+2 < len(fields) {
+			c.OldPath, c.Path = fields[i+1], fields[i+2]
+			i++
 		}
+		changes = append(changes, c)
+	}
+	return changes, nil
+}
+
+// Unshallow fetches the full history of a shallow clone. Clones that already
+// have it are left as they are.
+func Unshallow(repoPath string) error {
+	output, err := exec.Command("git", "-C", repoPath, "rev-parse", "--is-shallow-repository").Output()
+	if err != nil || strings.TrimSpace(string(output)) != "true" {
+		return err
+	}
+	cmd := exec.Command("git", "-C", repoPath, "fetch", "--quiet", "--unshallow")
+	return runRemote("fetch", cmd)
+}
+
+// Diff returns the changes to path between two commits as a unified diff.
+func Diff(repoPath, fromCommit, toCommit, path string) (string, error) {
+	cmd := exec.Command("git", "-C", repoPath, "diff", "--no-color", fromCommit, toCommit, "--", path)
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return string(output), nil
+}
+
+// PathCommits returns the commits reachable from HEAD that changed path, newest first.
+func PathCommits(repoPath, path string) ([]string, error) {
+	cmd := exec.Command("git", "-C", repoPath, "log", "--format=%H", "--", path)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(string(output)), nil
+}
+
+// TreeBlobs returns the blob IDs of the files at or under path in a commit, keyed
+// by their path relative to repoPath.
+func TreeBlobs(repoPath, commit, path string) (map[string]string, error) {
+	cmd := exec.Command("git", "-C", repoPath, "ls-tree", "-r", "-z", commit, "--", path)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	blobs := make(map[string]string)
+	for _, entry := range strings.Split(string(output), "\x00") {
+		// <mode> blob <id>\t<path>
+		info, name, ok := strings.Cut(entry, "\t")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(info)
+		if len(fields) == 3 && fields[1] == "blob" {
+			blobs[name] = fields[2]
+		}
+	}
+	return blobs, nil
+}
+
+// HashObjects returns the blob IDs files would have in the repository at repoPath,
+// in the order given.
+func HashObjects(repoPath string, files []string) ([]string, error) {
+	if len(files) == 0 {
+		return nil, nil
+	}
+	args := append([]string{"-C", repoPath, "hash-object", "--no-filters", "--"}, files...)
+	output, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return nil, err
+	}
+	ids := strings.Fields(string(output))
+	if len(ids) != len(files) {
+		return nil, fmt.Errorf("git hash-object: got %d IDs for %d files", len(ids), len(files))
 	}
-	return files, nil
+	return ids, nil
 }
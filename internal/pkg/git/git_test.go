@@ -0,0 +1,71 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestRemoteError(t *testing.T) {
+	tests := []struct {
+		name    string
+		stderr  string
+		network bool
+	}{
+		{"unknown host", "fatal: unable to access 'https://example.invalid/x.git/': Could not resolve host: example.invalid", true},
+		{"refused", "fatal: unable to access 'http://127.0.0.1:1/x.git/': Failed to connect to 127.0.0.1 port 1: Connection refused", true},
+		{"hung up", "fatal: the remote end hung up unexpectedly", true},
+		{"not fast-forward", "hint: Diverging branches can't be fast-forwarded\nfatal: Not possible to fast-forward, aborting.", false},
+		{"no repository", "fatal: repository 'https://github.com/a/missing/' not found", false},
+		{"authentication", "git@github.com: Permission denied (publickey).\nfatal: Could not read from remote repository.", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := remoteError("pull", errors.New("exit status 1"), tt.stderr)
+			var remoteErr *RemoteError
+			if errors.As(err, &remoteErr) != tt.network {
+				t.Errorf("remoteError() = %#v, want a RemoteError: %v", err, tt.network)
+			}
+		})
+	}
+	if err := remoteError("pull", nil, "warning"); err != nil {
+		t.Errorf("remoteError(nil) = %v", err)
+	}
+}
+
+func TestPullNotFastForward(t *testing.T) {
+	if !IsInstalled() {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	origin, clone := filepath.Join(dir, "origin"), filepath.Join(dir, "clone")
+	commit := func(repo, file string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repo, file), []byte(file), 0644); err != nil {
+			t.Fatal(err)
+		}
+		for _, args := range [][]string{{"add", "-A"}, {"-c", "user.name=a", "-c", "user.email=a@b", "commit", "-qm", file}} {
+			if out, err := exec.Command("git", append([]string{"-C", repo}, args...)...).CombinedOutput(); err != nil {
+				t.Fatalf("git %v: %v\n%s", args, err, out)
+			}
+		}
+	}
+
+	if out, err := exec.Command("git", "init", "-q", origin).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v\n%s", err, out)
+	}
+	commit(origin, "a")
+	if err := CloneQuiet("file://"+origin, clone); err != nil {
+		t.Fatal(err)
+	}
+	commit(origin, "b")
+	commit(clone, "c")
+
+	err := PullQuiet(clone)
+	var remoteErr *RemoteError
+	if err == nil || errors.As(err, &remoteErr) {
+		t.Errorf("PullQuiet() = %v, want a failure that is not a RemoteError", err)
+	}
+}
@@ -0,0 +1,112 @@
+package git
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// FetchTags fetches the remote's tags. In a shallow clone this also fetches the
+// history between them and the clone's commit; fetching them shallow would cut
+// it and keep later pulls from fast-forwarding.
+func FetchTags(repoPath string) error {
+	cmd := exec.Command("git", "-C", repoPath, "fetch", "--quiet", "--force", "origin", "+refs/tags/*:refs/tags/*")
+	return runRemote("fetch", cmd)
+}
+
+// Tags returns the tags of the repository, highest version first.
+func Tags(repoPath string) ([]string, error) {
+	cmd := exec.Command("git", "-C", repoPath, "tag", "--list", "--sort=-v:refname")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(string(output)), nil
+}
+
+// ResolveCommit returns the SHA of the commit a ref, such as an annotated tag,
+// points to.
+func ResolveCommit(repoPath, ref string) (string, error) {
+	cmd := exec.Command("git", "-C", repoPath, "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("unknown revision: %s", ref)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// Export writes the files at or under path in a commit into dest, at their
+// path relative to repoPath, without touching the checkout. File modes and
+// symlinks are kept.
+func Export(repoPath, commit, path, dest string) error {
+	cmd := exec.Command("git", "-C", repoPath, "archive", "--format=tar", commit, "--", path)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	extractErr := extractTar(stdout, dest)
+	// Drain the rest so git does not block writing it
+	_, _ = io.Copy(io.Discard, stdout)
+	if err := cmd.Wait(); err != nil {
+		return fmt.Errorf("git archive: %s", strings.TrimSpace(stderr.String()))
+	}
+	return extractErr
+}
+
+// extractTar writes the entries of a tar stream into dir.
+func extractTar(r io.Reader, dir string) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		target := filepath.Join(dir, filepath.FromSlash(hdr.Name))
+		if target != dir && !strings.HasPrefix(target, dir+string(os.PathSeparator)) {
+			return fmt.Errorf("invalid path in archive: %s", hdr.Name)
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(target, 0755)
+		case tar.TypeReg:
+			err = writeFile(target, tr, os.FileMode(hdr.Mode).Perm())
+		case tar.TypeSymlink:
+			if err = os.MkdirAll(filepath.Dir(target), 0755); err == nil {
+				err = os.Symlink(hdr.Linkname, target)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// writeFile writes the content of r to path with mode perm.
+func writeFile(path string, r io.Reader, perm os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,320 @@
+package pkgmgr
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/events"
+	"github.com/itda-skills/jindo/internal/pkg/git"
+	"github.com/itda-skills/jindo/internal/pkg/repo"
+)
+
+// Adoption is the outcome of Adopt.
+type Adoption struct {
+	Package *InstalledPackage
+	Matched bool // False when adopted with force although no version matched
+	Behind  int  // Commits changing the package in the repository since the adopted version
+}
+
+// Adopt brings a resource that was copied from a repository by hand under
+// management. The resource at path is recorded as the package specStr names, at
+// the newest commit whose version of the package has exactly the resource's
+// files. With force, a resource matching no version is recorded at the current
+// commit with the repository's files, so its differences show as local
+// modifications. Updates then replace it in place, under its current name.
+func (m *Manager) Adopt(specStr, path string, force bool) (*Adoption, error) {
+	spec, err := ParseSpec(specStr)
+	if err != nil {
+		return nil, err
+	}
+	if spec.Version != "" {
+		return nil, fmt.Errorf("%w: adopt finds the version from the content; remove @%s", ErrInvalidSpec, spec.Version)
+	}
+
+	repoConfig, err := m.repoStore.Get(spec.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("repository not found: %w", err)
+	}
+	packageRoot, err := m.repoStore.PackageRoot(spec.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	pkgType, relPath, err := m.resolvePackage(spec)
+	if err != nil {
+		return nil, err
+	}
+	originalName := extractPackageName(relPath, pkgType)
+	if originalName == "" {
+		return nil, fmt.Errorf("cannot extract package name from path: %s", spec.Path)
+	}
+
+	claudeDir, name, err := resourceLocation(path, pkgType)
+	if err != nil {
+		return nil, err
+	}
+
+	installed, err := m.load()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := m.resolveName(installed, spec.Namespace, originalName, pkgType, name); err != nil {
+		return nil, err
+	}
+
+	local, err := packageFiles(path, pkgType)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	localBlobs, err := blobIDs(packageRoot, local)
+	if err != nil {
+		return nil, fmt.Errorf("hash %s: %w", path, err)
+	}
+
+	// Repositories are cloned shallow; the versions to compare with are in the history
+	if err := git.Unshallow(packageRoot); err != nil {
+		return nil, fmt.Errorf("fetch history of %s: %w", spec.Namespace, err)
+	}
+	commits, err := git.PathCommits(packageRoot, spec.Path)
+	if err != nil {
+		return nil, fmt.Errorf("list commits of %s: %w", spec.Path, err)
+	}
+	if len(commits) == 0 {
+		return nil, fmt.Errorf("%s not found in repository %s", spec.Path, spec.Namespace)
+	}
+	headSHA, err := git.GetCurrentCommit(packageRoot)
+	if err != nil {
+		return nil, fmt.Errorf("get current commit: %w", err)
+	}
+
+	adoption := &Adoption{}
+	sha := headSHA
+	for i, commit := range commits {
+		blobs, err := git.TreeBlobs(packageRoot, commit, spec.Path)
+		if err != nil {
+			return nil, fmt.Errorf("list files of %s at %s: %w", spec.Path, commit, err)
+		}
+		if sameBlobs(localBlobs, packageBlobs(blobs, spec.Path)) {
+			adoption.Matched, adoption.Behind = true, i
+			if i > 0 {
+				sha = commit
+			}
+			break
+		}
+	}
+	if !adoption.Matched && !force {
+		return nil, ErrContentMismatch
+	}
+
+	// Matched files are recorded with their own content; with force, with the
+	// repository's current content, so that local differences read as modifications
+	var files []InstalledFile
+	if adoption.Matched {
+		files, err = adoptedFiles(local, path, spec.Path)
+	} else {
+		var current map[string]string
+		if current, err = packageFiles(filepath.Join(packageRoot, spec.Path), pkgType); err == nil {
+			files, err = adoptedFiles(current, path, spec.Path)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("hash files: %w", err)
+	}
+
+	now := time.Now().UTC()
+	pkg := InstalledPackage{
+		Name:         name,
+		OriginalName: originalName,
+		Type:         pkgType,
+		Namespace:    spec.Namespace,
+		SourcePath:   spec.Path,
+		Version: VersionInfo{
+			Type: "commit",
+			SHA:  sha,
+			Ref:  repoConfig.TrackedBranch(),
+		},
+		Files:       files,
+		Scope:       ScopeGlobal,
+		RepoURL:     repoConfig.URL,
+		Installer:   InstallerVersion,
+		InstalledAt: now,
+		UpdatedAt:   now,
+	}
+	if globalDir, err := expandPath(basedir.ClaudeDir()); err != nil || claudeDir != globalDir {
+		pkg.ClaudeDir = claudeDir
+		pkg.Scope = ScopeLocal
+	}
+
+	installed.Packages = append(installed.Packages, pkg)
+	if err := m.save(installed); err != nil {
+		return nil, err
+	}
+	adoption.Package = &pkg
+	m.emit(events.Install, &pkg, map[string]any{"adopted": true})
+	return adoption, nil
+}
+
+// typeDirs are the directories of a Claude directory each package type is installed into
+var typeDirs = map[repo.PackageType]string{
+	repo.TypeSkill:   "skills",
+	repo.TypeCommand: "commands",
+	repo.TypeAgent:   "agents",
+	repo.TypeHook:    "hooks",
+}
+
+// resourceLocation returns the Claude directory a resource of a type is in and
+// the name it would be installed under there, such as ~/.claude and web-fetch
+// for ~/.claude/skills/web-fetch. A nested command's name joins its
+// directories with ":", as install does.
+func resourceLocation(path string, pkgType repo.PackageType) (string, string, error) {
+	typeDir, ok := typeDirs[pkgType]
+	if !ok {
+		return "", "", fmt.Errorf("unsupported package type: %s", pkgType)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", "", err
+	}
+	if pkgType == repo.TypeSkill && !info.IsDir() {
+		return "", "", fmt.Errorf("%s is not a skill directory", path)
+	}
+	if pkgType != repo.TypeSkill && info.IsDir() {
+		return "", "", fmt.Errorf("%s is a directory, not a %s file", path, pkgType)
+	}
+
+	for dir := filepath.Dir(path); dir != filepath.Dir(dir); dir = filepath.Dir(dir) {
+		if filepath.Base(dir) != typeDir {
+			continue
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return "", "", err
+		}
+		name := filepath.ToSlash(rel)
+		switch pkgType {
+		case repo.TypeSkill, repo.TypeHook:
+			if strings.Contains(name, "/") {
+				continue
+			}
+		case repo.TypeCommand, repo.TypeAgent:
+			name = strings.ReplaceAll(strings.TrimSuffix(name, ".md"), "/", ":")
+		}
+		return filepath.Dir(dir), name, nil
+	}
+	return "", "", fmt.Errorf("%s is not in a %s directory, where jd installs %ss", path, typeDir, pkgType)
+}
+
+// packageFiles returns the files of a resource by their slash-separated path
+// within it, "" for a single-file resource. A skill's history is not part of it.
+func packageFiles(path string, pkgType repo.PackageType) (map[string]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return map[string]string{"": path}, nil
+	}
+
+	files := make(map[string]string)
+	err = filepath.WalkDir(path, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != path && (d.Name() == ".git" || (pkgType == repo.TypeSkill && d.Name() == ".history")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(path, p)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = p
+		return nil
+	})
+	return files, err
+}
+
+// blobIDs returns the git blob IDs of files by their path within the resource
+func blobIDs(repoPath string, files map[string]string) (map[string]string, error) {
+	rels := make([]string, 0, len(files))
+	for rel := range files {
+		rels = append(rels, rel)
+	}
+	sort.Strings(rels)
+	paths := make([]string, len(rels))
+	for i, rel := range rels {
+		paths[i] = files[rel]
+	}
+
+	ids, err := git.HashObjects(repoPath, paths)
+	if err != nil {
+		return nil, err
+	}
+	blobs := make(map[string]string, len(rels))
+	for i, rel := range rels {
+		blobs[rel] = ids[i]
+	}
+	return blobs, nil
+}
+
+// packageBlobs keys the blobs of a package's files by their path within the
+// package, "" for a single-file package at path
+func packageBlobs(blobs map[string]string, path string) map[string]string {
+	rels := make(map[string]string, len(blobs))
+	for name, id := range blobs {
+		if name == path {
+			rels[""] = id
+		} else if rel, ok := strings.CutPrefix(name, path+"/"); ok {
+			rels[rel] = id
+		}
+	}
+	return rels
+}
+
+// sameBlobs reports whether two sets of files have the same paths and content
+func sameBlobs(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for rel, id := range a {
+		if b[rel] != id {
+			return false
+		}
+	}
+	return true
+}
+
+// adoptedFiles returns the installed files of an adopted resource at target,
+// with the SHA-256 of the files in content, keyed like packageFiles
+func adoptedFiles(content map[string]string, target, sourcePath string) ([]InstalledFile, error) {
+	rels := make([]string, 0, len(content))
+	for rel := range content {
+		rels = append(rels, rel)
+	}
+	sort.Strings(rels)
+
+	files := make([]InstalledFile, 0, len(rels))
+	for _, rel := range rels {
+		sha, err := fileSHA256(content[rel])
+		if err != nil {
+			return nil, err
+		}
+		f := InstalledFile{Source: sourcePath, Target: target, SHA: sha}
+		if rel != "" {
+			f.Source = filepath.Join(sourcePath, filepath.FromSlash(rel))
+			f.Target = filepath.Join(target, filepath.FromSlash(rel))
+		}
+		files = append(files, f)
+	}
+	return files, nil
+}
@@ -0,0 +1,436 @@
+package pkgmgr
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/events"
+	"github.com/itda-skills/jindo/internal/pkg/repo"
+)
+
+// ArchiveNamespace is the default namespace for packages installed from an archive.
+const ArchiveNamespace = "archive"
+
+// ErrUnsupportedArchive is returned when the archive format is not supported.
+var ErrUnsupportedArchive = errors.New("unsupported archive format (use .tar.gz, .tgz, or .zip)")
+
+// maxArchiveSize is the largest archive downloaded, in bytes; packages are far smaller.
+var maxArchiveSize int64 = 100 << 20
+
+// maxExtractedSize is the most an archive extracts to, in bytes, so that a small
+// archive of highly compressed files cannot fill the disk.
+var maxExtractedSize int64 = 500 << 20
+
+// archiveFormat returns the archive format ("tar.gz" or "zip") from a file name.
+func archiveFormat(name string) string {
+	name = strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		return "tar.gz"
+	case strings.HasSuffix(name, ".zip"):
+		return "zip"
+	default:
+		return ""
+	}
+}
+
+// archiveBaseName returns the file name without the archive extension.
+func archiveBaseName(name string) string {
+	base := filepath.Base(name)
+	for _, ext := range []string{".tar.gz", ".tgz", ".zip"} {
+		if strings.HasSuffix(strings.ToLower(base), ext) {
+			return base[:len(base)-len(ext)]
+		}
+	}
+	return base
+}
+
+// isRemoteSource reports whether source is an http(s) URL.
+func isRemoteSource(source string) bool {
+	return strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://")
+}
+
+// InstallArchive installs a single package from a .tar.gz/.tgz/.zip archive.
+// The source is an http(s) URL or a local file path. The archive must contain
+// either a skill (SKILL.md at its root) or exactly one package in the usual
+// repository layout (skills/, commands/, agents/, hooks/).
+func (m *Manager) InstallArchive(source, namespace string) (*InstalledPackage, error) {
+	pkg, err := m.installArchive(source, namespace, "")
+	if err == nil {
+		m.emit(events.Install, pkg, nil)
+	}
+	return pkg, err
+}
+
+// installArchive installs a package from an archive under installName, or under
+// the name the manager's naming gives it if installName is empty.
+func (m *Manager) installArchive(source, namespace, installName string) (*InstalledPackage, error) {
+	if namespace == "" {
+		namespace = ArchiveNamespace
+	}
+
+	name := source
+	if isRemoteSource(source) {
+		u, err := url.Parse(source)
+		if err != nil {
+			return nil, fmt.Errorf("parse url: %w", err)
+		}
+		name = u.Path
+	} else {
+		abs, err := filepath.Abs(source)
+		if err != nil {
+			return nil, err
+		}
+		source = abs
+		name = abs
+	}
+
+	format := archiveFormat(name)
+	if format == "" {
+		return nil, ErrUnsupportedArchive
+	}
+
+	tmpDir, err := os.MkdirTemp("", "jd-archive-*")
+	if err != nil {
+		return nil, fmt.Errorf("create temp directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	archivePath := source
+	if isRemoteSource(source) {
+		archivePath = filepath.Join(tmpDir, filepath.Base(name))
+		if err := downloadFile(source, archivePath); err != nil {
+			return nil, fmt.Errorf("download archive: %w", err)
+		}
+	}
+
+	sha, err := fileSHA256(archivePath)
+	if err != nil {
+		return nil, fmt.Errorf("read archive: %w", err)
+	}
+
+	extractDir := filepath.Join(tmpDir, "extract")
+	switch format {
+	case "tar.gz":
+		err = extractTarGz(archivePath, extractDir)
+	case "zip":
+		err = extractZip(archivePath, extractDir)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("extract archive: %w", err)
+	}
+
+	root := archiveRoot(extractDir)
+	item, err := findArchivePackage(root, extractDir, archiveBaseName(name))
+	if err != nil {
+		return nil, err
+	}
+
+	installed, err := m.load()
+	if err != nil {
+		return nil, err
+	}
+	namespacedName, err := m.resolveName(installed, namespace, item.Name, item.Type, installName)
+	if err != nil {
+		return nil, err
+	}
+
+	claudeDir, err := m.expandClaudeDir()
+	if err != nil {
+		return nil, err
+	}
+
+	var files []InstalledFile
+
+	switch item.Type {
+	case repo.TypeSkill:
+		files, err = m.installSkill(root, item.Path, namespacedName, claudeDir)
+	case repo.TypeCommand:
+		files, err = m.installCommand(root, item.Path, namespacedName, claudeDir)
+	case repo.TypeAgent:
+		files, err = m.installAgent(root, item.Path, namespacedName, claudeDir)
+	case repo.TypeHook:
+		files, err = m.installHook(root, item.Path, namespacedName, claudeDir)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	pkg := InstalledPackage{
+		Name:         namespacedName,
+		OriginalName: item.Name,
+		Type:         item.Type,
+		Namespace:    namespace,
+		SourcePath:   item.Path,
+		Source:       source,
+		Version: VersionInfo{
+			Type: "archive",
+			SHA:  sha,
+			Ref:  filepath.Base(name),
+		},
+		Files:       files,
+		Scope:       ScopeGlobal,
+		Installer:   InstallerVersion,
+		InstalledAt: now,
+		UpdatedAt:   now,
+	}
+	if m.claudeDir != basedir.ClaudeDir() {
+		pkg.ClaudeDir = claudeDir
+		pkg.Scope = ScopeLocal
+	}
+
+	installed.Packages = append(installed.Packages, pkg)
+
+	if err := m.save(installed); err != nil {
+		// Try to clean up installed files
+		for _, f := range files {
+			_ = os.RemoveAll(f.Target)
+		}
+		return nil, err
+	}
+
+	return &pkg, nil
+}
+
+// findArchivePackage validates the extracted archive and returns its single package.
+func findArchivePackage(root, extractDir, archiveName string) (*repo.BrowseItem, error) {
+	// A skill directory packed on its own
+	for _, skillFile := range []string{"SKILL.md", "skill.md"} {
+		if _, err := os.Stat(filepath.Join(root, skillFile)); err == nil {
+			name := archiveName
+			if root != extractDir {
+				name = filepath.Base(root)
+			}
+			return &repo.BrowseItem{Name: name, Path: ".", Type: repo.TypeSkill}, nil
+		}
+	}
+
+	items := repo.ScanPackages(root, "")
+	switch len(items) {
+	case 0:
+		return nil, errors.New("no package found in archive (expected SKILL.md or skills/, commands/, agents/, hooks/)")
+	case 1:
+		return &items[0], nil
+	default:
+		return nil, fmt.Errorf("archive contains %d packages; only single-package archives are supported", len(items))
+	}
+}
+
+// archiveRoot returns the package root of an extracted archive.
+// Archives with a single top-level directory (other than a package layout
+// directory such as skills/) are rooted at that directory.
+func archiveRoot(dir string) string {
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) != 1 || !entries[0].IsDir() {
+		return dir
+	}
+
+	switch entries[0].Name() {
+	case "skills", "commands", "agents", "hooks", ".claude":
+		return dir
+	}
+	return filepath.Join(dir, entries[0].Name())
+}
+
+// downloadFile downloads url to dest, refusing files larger than maxArchiveSize.
+func downloadFile(url, dest string) error {
+	client := &http.Client{Timeout: 60 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+	if resp.ContentLength > maxArchiveSize {
+		return fmt.Errorf("archive is %d bytes; the limit is %d", resp.ContentLength, maxArchiveSize)
+	}
+
+	out, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = out.Close() }()
+
+	// The server may not send the length, or send a wrong one
+	n, err := io.Copy(out, io.LimitReader(resp.Body, maxArchiveSize+1))
+	if err != nil {
+		return err
+	}
+	if n > maxArchiveSize {
+		return fmt.Errorf("archive is larger than the limit of %d bytes", maxArchiveSize)
+	}
+	return nil
+}
+
+// archiveSHA256 returns the SHA-256 of an archive at a URL or local path.
+func archiveSHA256(source string) (string, error) {
+	if !isRemoteSource(source) {
+		return fileSHA256(source)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "jd-archive-*")
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	archivePath := filepath.Join(tmpDir, "archive")
+	if err := downloadFile(source, archivePath); err != nil {
+		return "", err
+	}
+	return fileSHA256(archivePath)
+}
+
+// fileSHA256 returns the hex-encoded SHA-256 of a file.
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// safeJoin joins an archive entry name to dir, rejecting paths that escape dir.
+func safeJoin(dir, name string) (string, error) {
+	target := filepath.Join(dir, name)
+	if target != dir && !strings.HasPrefix(target, dir+string(os.PathSeparator)) {
+		return "", fmt.Errorf("invalid path in archive: %s", name)
+	}
+	return target, nil
+}
+
+// writeArchiveFile writes an archive entry to target, taking its size from the
+// bytes the archive has left to extract, *remaining. Fails if the entry is larger.
+func writeArchiveFile(target string, r io.Reader, mode os.FileMode, remaining *int64) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode.Perm()|0600)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = out.Close() }()
+
+	n, err := io.Copy(out, io.LimitReader(r, *remaining+1))
+	if err != nil {
+		return err
+	}
+	if *remaining -= n; *remaining < 0 {
+		return fmt.Errorf("archive extracts to more than the limit of %d bytes", maxExtractedSize)
+	}
+	return nil
+}
+
+// extractTarGz extracts a .tar.gz archive into dir.
+// Only regular files and directories are extracted.
+func extractTarGz(path, dir string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = gz.Close() }()
+
+	remaining := maxExtractedSize
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		target, err := safeJoin(dir, hdr.Name)
+		if err != nil {
+			return err
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := writeArchiveFile(target, tr, os.FileMode(hdr.Mode), &remaining); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// extractZip extracts a .zip archive into dir.
+// Only regular files and directories are extracted.
+func extractZip(path, dir string) error {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = zr.Close() }()
+
+	remaining := maxExtractedSize
+	for _, f := range zr.File {
+		// Skip macOS resource fork metadata
+		if strings.HasPrefix(f.Name, "__MACOSX/") {
+			continue
+		}
+
+		target, err := safeJoin(dir, f.Name)
+		if err != nil {
+			return err
+		}
+
+		if f.FileInfo().IsDir() {
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+			continue
+		}
+		if !f.Mode().IsRegular() {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+		err = writeArchiveFile(target, rc, f.Mode(), &remaining)
+		_ = rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
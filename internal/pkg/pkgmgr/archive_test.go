@@ -0,0 +1,237 @@
+package pkgmgr
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/itda-skills/jindo/internal/pkg/repo"
+)
+
+// archiveEntry is a file, directory, or symlink written into a test archive.
+type archiveEntry struct {
+	name, body, link string
+}
+
+func writeTestTarGz(t *testing.T, path string, entries []archiveEntry) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	for _, e := range entries {
+		hdr := &tar.Header{Name: e.name, Mode: 0644, Size: int64(len(e.body)), Typeflag: tar.TypeReg}
+		if e.link != "" {
+			hdr = &tar.Header{Name: e.name, Mode: 0777, Linkname: e.link, Typeflag: tar.TypeSymlink}
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(e.body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeTestZip(t *testing.T, path string, entries []archiveEntry) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+	zw := zip.NewWriter(f)
+	for _, e := range entries {
+		hdr := &zip.FileHeader{Name: e.name, Method: zip.Deflate}
+		hdr.SetMode(0644)
+		body := e.body
+		if e.link != "" {
+			hdr.SetMode(os.ModeSymlink | 0777)
+			body = e.link
+		}
+		w, err := zw.CreateHeader(hdr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSafeJoin(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"skills/tool/SKILL.md", false},
+		{"./tool/SKILL.md", false},
+		{"tool/../SKILL.md", false},
+		{"../evil", true},
+		{"tool/../../evil", true},
+		{"..", true},
+	}
+	for _, tt := range tests {
+		_, err := safeJoin(dir, tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("safeJoin(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestExtractArchives(t *testing.T) {
+	extractors := []struct {
+		ext     string
+		write   func(*testing.T, string, []archiveEntry)
+		extract func(string, string) error
+	}{
+		{".tar.gz", writeTestTarGz, extractTarGz},
+		{".zip", writeTestZip, extractZip},
+	}
+	for _, x := range extractors {
+		t.Run(x.ext, func(t *testing.T) {
+			t.Run("escape", func(t *testing.T) {
+				tmp := t.TempDir()
+				archive := filepath.Join(tmp, "pkg"+x.ext)
+				x.write(t, archive, []archiveEntry{
+					{name: "tool/SKILL.md", body: "# tool\n"},
+					{name: "../evil", body: "evil\n"},
+				})
+				dir := filepath.Join(tmp, "out")
+				if err := x.extract(archive, dir); err == nil || !strings.Contains(err.Error(), "invalid path") {
+					t.Errorf("extract error = %v, want invalid path", err)
+				}
+				if _, err := os.Stat(filepath.Join(tmp, "evil")); !os.IsNotExist(err) {
+					t.Errorf("an entry was written outside the extraction directory: %v", err)
+				}
+			})
+
+			t.Run("symlink", func(t *testing.T) {
+				tmp := t.TempDir()
+				archive := filepath.Join(tmp, "pkg"+x.ext)
+				x.write(t, archive, []archiveEntry{
+					{name: "tool/SKILL.md", body: "# tool\n"},
+					{name: "tool/key", link: "/etc/passwd"},
+				})
+				dir := filepath.Join(tmp, "out")
+				if err := x.extract(archive, dir); err != nil {
+					t.Fatal(err)
+				}
+				if _, err := os.Lstat(filepath.Join(dir, "tool", "key")); !os.IsNotExist(err) {
+					t.Errorf("a symlink entry was extracted: %v", err)
+				}
+				if data, err := os.ReadFile(filepath.Join(dir, "tool", "SKILL.md")); err != nil || string(data) != "# tool\n" {
+					t.Errorf("SKILL.md = %q, %v", data, err)
+				}
+			})
+
+			t.Run("size limit", func(t *testing.T) {
+				defer func(size int64) { maxExtractedSize = size }(maxExtractedSize)
+				maxExtractedSize = 64
+
+				tmp := t.TempDir()
+				archive := filepath.Join(tmp, "pkg"+x.ext)
+				// Each entry is within the limit, but not all of them together
+				x.write(t, archive, []archiveEntry{
+					{name: "tool/SKILL.md", body: strings.Repeat("a", 40)},
+					{name: "tool/ref.md", body: strings.Repeat("b", 40)},
+				})
+				if err := x.extract(archive, filepath.Join(tmp, "out")); err == nil || !strings.Contains(err.Error(), "limit of 64 bytes") {
+					t.Errorf("extract error = %v, want the limit", err)
+				}
+
+				x.write(t, archive, []archiveEntry{{name: "tool/SKILL.md", body: strings.Repeat("a", 64)}})
+				if err := x.extract(archive, filepath.Join(tmp, "exact")); err != nil {
+					t.Errorf("extract of exactly the limit error = %v", err)
+				}
+			})
+		})
+	}
+}
+
+func TestFindArchivePackage(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    []string
+		wantName string
+		wantType repo.PackageType
+		wantErr  string
+	}{
+		{"skill at the root", []string{"SKILL.md"}, "archive", repo.TypeSkill, ""},
+		{"skill in a directory", []string{"tool/SKILL.md"}, "tool", repo.TypeSkill, ""},
+		{"one command", []string{"commands/review.md"}, "review", repo.TypeCommand, ""},
+		{"several packages", []string{"skills/tool/SKILL.md", "commands/review.md"}, "", "", "2 packages"},
+		{"no package", []string{"README.md"}, "", "", "no package"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for _, f := range tt.files {
+				writeTestFile(t, filepath.Join(dir, filepath.FromSlash(f)), "# test\n", 0644)
+			}
+			item, err := findArchivePackage(archiveRoot(dir), dir, "archive")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if item.Name != tt.wantName || item.Type != tt.wantType {
+				t.Errorf("found %s %s, want %s %s", item.Type, item.Name, tt.wantType, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestDownloadFileLimit(t *testing.T) {
+	defer func(size int64) { maxArchiveSize = size }(maxArchiveSize)
+	maxArchiveSize = 16
+
+	body := strings.Repeat("x", 32)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/chunked" {
+			// Without a Content-Length, the limit is found while copying
+			w.(http.Flusher).Flush()
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	for _, p := range []string{"/sized", "/chunked"} {
+		dest := filepath.Join(t.TempDir(), "pkg.zip")
+		if err := downloadFile(srv.URL+p, dest); err == nil || !strings.Contains(err.Error(), "limit") {
+			t.Errorf("downloadFile(%s) error = %v, want the size limit", p, err)
+		}
+	}
+
+	body = "small"
+	dest := filepath.Join(t.TempDir(), "pkg.zip")
+	if err := downloadFile(srv.URL+"/sized", dest); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(dest); string(data) != body {
+		t.Errorf("downloaded %q, want %q", data, body)
+	}
+}
@@ -0,0 +1,109 @@
+package pkgmgr
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+
+	"github.com/itda-skills/jindo/internal/events"
+	"github.com/itda-skills/jindo/internal/pkg/git"
+)
+
+// Update channels of a package, recorded in InstalledPackage.Channel
+const (
+	ChannelEdge   = "edge"   // Follows the head of the repository's tracked branch (the default)
+	ChannelStable = "stable" // Follows the repository's release tags
+)
+
+// ErrNoReleases is returned when a package is installed on the stable channel
+// from a repository without release tags.
+var ErrNoReleases = errors.New("repository has no release tags (such as v1.2.0)")
+
+// releaseTagPattern matches the tags the stable channel follows: versions such
+// as v1.2.0 or 2.0, but not pre-releases such as v1.3.0-rc1.
+var releaseTagPattern = regexp.MustCompile(`^v?\d+(\.\d+)*$`)
+
+// Channels returns the update channel names.
+func Channels() []string {
+	return []string{ChannelEdge, ChannelStable}
+}
+
+// ValidChannel reports whether name is an update channel.
+func ValidChannel(name string) bool {
+	return name == ChannelEdge || name == ChannelStable
+}
+
+// SetChannel sets the channel packages are installed from; "" installs from
+// the tracked branch, as ChannelEdge does, without recording a channel.
+func (m *Manager) SetChannel(channel string) {
+	m.channel = channel
+}
+
+// stable reports whether a package follows the stable channel.
+func (p *InstalledPackage) stable() bool {
+	return p.Channel == ChannelStable
+}
+
+// latestRelease returns the highest release tag of the clone at localPath and the
+// commit it points to, fetching the remote's tags first if fetch is set.
+func latestRelease(localPath string, fetch bool) (string, string, error) {
+	if fetch {
+		if err := git.FetchTags(localPath); err != nil {
+			return "", "", fmt.Errorf("fetch tags: %w", err)
+		}
+	}
+	tags, err := git.Tags(localPath)
+	if err != nil {
+		return "", "", fmt.Errorf("list tags: %w", err)
+	}
+	for _, tag := range tags {
+		if releaseTagPattern.MatchString(tag) {
+			sha, err := git.ResolveCommit(localPath, tag)
+			return tag, sha, err
+		}
+	}
+	return "", "", ErrNoReleases
+}
+
+// exportRelease writes a package's files at a release into a new temporary
+// directory, laid out as the clone is, and returns the directory and the package
+// root in it. The caller removes the directory.
+func exportRelease(localPath, root, pkgPath, sha string) (string, string, error) {
+	dir, err := os.MkdirTemp("", "jd-release-*")
+	if err != nil {
+		return "", "", err
+	}
+	if err := git.Export(localPath, sha, path.Join(root, pkgPath), dir); err != nil {
+		_ = os.RemoveAll(dir)
+		return "", "", err
+	}
+	return dir, filepath.Join(dir, filepath.FromSlash(root)), nil
+}
+
+// SwitchChannel reinstalls a package from another update channel: the latest
+// release for ChannelStable, or the head of the tracked branch for ChannelEdge.
+// The package keeps its name, install directory, and file selection, and
+// loses its pin.
+func (m *Manager) SwitchChannel(name, channel string) (*InstalledPackage, error) {
+	if !ValidChannel(channel) {
+		return nil, fmt.Errorf("unknown channel: %s", channel)
+	}
+	prev, err := m.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	if prev.Source != "" || prev.Version.Type == PluginVersionType {
+		return nil, fmt.Errorf("%s was not installed from a repository and has no channels", name)
+	}
+
+	defer m.SetChannel(m.channel)
+	m.SetChannel(channel)
+	updated, err := m.update(name)
+	if err == nil {
+		m.emit(events.Update, updated, map[string]any{"previous_version": prev.Version.SHA, "channel": channel})
+	}
+	return updated, err
+}
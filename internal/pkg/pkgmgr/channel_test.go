@@ -0,0 +1,33 @@
+package pkgmgr
+
+import "testing"
+
+func TestReleaseTagPattern(t *testing.T) {
+	for tag, want := range map[string]bool{
+		"v1.2.0":     true,
+		"2.0":        true,
+		"v3":         true,
+		"v1.3.0-rc1": false,
+		"nightly":    false,
+		"release-1":  false,
+	} {
+		if got := releaseTagPattern.MatchString(tag); got != want {
+			t.Errorf("releaseTagPattern.MatchString(%q) = %v, want %v", tag, got, want)
+		}
+	}
+}
+
+func TestPinnedStableChannel(t *testing.T) {
+	pkg := &InstalledPackage{Version: VersionInfo{Type: "tag", Ref: "v1.0.0"}}
+	if !pkg.Pinned() {
+		t.Error("a package installed at a tag is not pinned")
+	}
+	pkg.Channel = ChannelStable
+	if pkg.Pinned() {
+		t.Error("a package following the stable channel is pinned")
+	}
+	pkg.Pin = &PinInfo{Ref: "v1.0.0"}
+	if !pkg.Pinned() {
+		t.Error("a pinned package on the stable channel is not pinned")
+	}
+}
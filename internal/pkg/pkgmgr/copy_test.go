@@ -0,0 +1,168 @@
+package pkgmgr
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeTestFile writes content to path, creating its directory
+func writeTestFile(t *testing.T, path, content string, perm os.FileMode) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), perm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(path, perm); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestInstallSkillKeepsScriptsAndLinks(t *testing.T) {
+	repoDir := t.TempDir()
+	skillDir := filepath.Join(repoDir, "skills", "tool")
+	writeTestFile(t, filepath.Join(skillDir, "SKILL.md"), "# tool\n", 0644)
+	writeTestFile(t, filepath.Join(skillDir, "scripts", "run.sh"), "#!/bin/sh\necho run\n", 0755)
+	writeTestFile(t, filepath.Join(repoDir, "shared", "common.sh"), "#!/bin/sh\necho common\n", 0750)
+	writeTestFile(t, filepath.Join(repoDir, "shared", "lib", "util.py"), "print('util')\n", 0644)
+
+	// A link within the skill, and links to a file and a directory outside of it
+	if err := os.Symlink(filepath.Join("scripts", "run.sh"), filepath.Join(skillDir, "run")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+	if err := os.Symlink(filepath.Join("..", "..", "..", "shared", "common.sh"), filepath.Join(skillDir, "scripts", "common.sh")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(repoDir, "shared", "lib"), filepath.Join(skillDir, "lib")); err != nil {
+		t.Fatal(err)
+	}
+
+	claudeDir := t.TempDir()
+	m := NewManager(t.TempDir())
+	files, err := m.installSkill(repoDir, "skills/tool", "demo--tool", claudeDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 5 {
+		t.Errorf("installSkill() installed %d files, want 5", len(files))
+	}
+	destDir := filepath.Join(claudeDir, "skills", "demo--tool")
+
+	for rel, want := range map[string]os.FileMode{
+		"SKILL.md":          0644,
+		"scripts/run.sh":    0755,
+		"scripts/common.sh": 0750,
+		"lib/util.py":       0644,
+	} {
+		info, err := os.Lstat(filepath.Join(destDir, rel))
+		if err != nil {
+			t.Errorf("%s: %v", rel, err)
+			continue
+		}
+		if !info.Mode().IsRegular() {
+			t.Errorf("%s is %v, want a copy of the file", rel, info.Mode().Type())
+		}
+		if info.Mode().Perm() != want {
+			t.Errorf("%s mode = %v, want %v", rel, info.Mode().Perm(), want)
+		}
+	}
+
+	link, err := os.Readlink(filepath.Join(destDir, "run"))
+	if err != nil {
+		t.Fatalf("run is not a symlink: %v", err)
+	}
+	if link != filepath.Join("scripts", "run.sh") {
+		t.Errorf("run links to %s, want scripts/run.sh", link)
+	}
+	if content, err := os.ReadFile(filepath.Join(destDir, "run")); err != nil || string(content) != "#!/bin/sh\necho run\n" {
+		t.Errorf("run does not reach the installed script: %q, %v", content, err)
+	}
+}
+
+func TestInstallSkillRejectsLinkToItsParent(t *testing.T) {
+	repoDir := t.TempDir()
+	skillDir := filepath.Join(repoDir, "skills", "tool")
+	writeTestFile(t, filepath.Join(skillDir, "SKILL.md"), "# tool\n", 0644)
+	if err := os.Symlink("..", filepath.Join(skillDir, "up")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+
+	claudeDir := t.TempDir()
+	m := NewManager(t.TempDir())
+	if _, err := m.installSkill(repoDir, "skills/tool", "demo--tool", claudeDir); err == nil {
+		t.Fatal("installSkill() of a skill linking to its parent succeeded, want an error")
+	}
+	if _, err := os.Stat(filepath.Join(claudeDir, "skills", "demo--tool")); !os.IsNotExist(err) {
+		t.Errorf("the failed install left its directory behind: %v", err)
+	}
+}
+
+func TestCopyFileReplacesSymlink(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src.sh")
+	other := filepath.Join(dir, "other")
+	dest := filepath.Join(dir, "dest.sh")
+	writeTestFile(t, src, "new", 0755)
+	writeTestFile(t, other, "keep", 0644)
+	if err := os.Symlink(other, dest); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+
+	if err := copyFile(src, dest); err != nil {
+		t.Fatal(err)
+	}
+	if content, _ := os.ReadFile(other); string(content) != "keep" {
+		t.Errorf("copyFile() wrote through the symlink at dest: %q", content)
+	}
+	info, err := os.Lstat(dest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !info.Mode().IsRegular() || info.Mode().Perm() != 0755 {
+		t.Errorf("dest is %v, want a regular file with mode 0755", info.Mode())
+	}
+}
+
+func TestInstallSkillRejectsLinksOutOfTheRepository(t *testing.T) {
+	outside := t.TempDir()
+	writeTestFile(t, filepath.Join(outside, "id_rsa"), "secret\n", 0600)
+	writeTestFile(t, filepath.Join(outside, "etc", "passwd"), "root\n", 0644)
+
+	for name, link := range map[string]func(repoDir string) string{
+		"absolute file":      func(string) string { return filepath.Join(outside, "id_rsa") },
+		"absolute directory": func(string) string { return filepath.Join(outside, "etc") },
+		"relative": func(repoDir string) string {
+			return relativeLink(t, filepath.Join(repoDir, "skills", "tool"), outside, "id_rsa")
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			repoDir := t.TempDir()
+			skillDir := filepath.Join(repoDir, "skills", "tool")
+			writeTestFile(t, filepath.Join(skillDir, "SKILL.md"), "# tool\n", 0644)
+			if err := os.Symlink(link(repoDir), filepath.Join(skillDir, "key")); err != nil {
+				t.Skipf("symlinks not supported: %v", err)
+			}
+
+			claudeDir := t.TempDir()
+			m := NewManager(t.TempDir())
+			if _, err := m.installSkill(repoDir, "skills/tool", "demo--tool", claudeDir); err == nil {
+				t.Fatal("installSkill() followed a link out of the repository, want an error")
+			}
+			if _, err := os.Stat(filepath.Join(claudeDir, "skills", "demo--tool")); !os.IsNotExist(err) {
+				t.Errorf("the failed install left its directory behind: %v", err)
+			}
+		})
+	}
+}
+
+// relativeLink returns a ../ path from dir to name in target
+func relativeLink(t *testing.T, dir, target, name string) string {
+	t.Helper()
+	rel, err := filepath.Rel(dir, filepath.Join(target, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rel
+}
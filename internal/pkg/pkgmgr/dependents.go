@@ -0,0 +1,141 @@
+package pkgmgr
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Reference is a file that mentions an installed package by name
+type Reference struct {
+	Package string `json:"package,omitempty"` // Installed package the file belongs to; empty for the user's own files
+	Path    string `json:"path"`
+}
+
+// Label returns the referencing package name, or the file path for the user's own files
+func (r Reference) Label() string {
+	if r.Package != "" {
+		return r.Package
+	}
+	return r.Path
+}
+
+// maxReferenceFileSize skips large files, which are data rather than instructions
+const maxReferenceFileSize = 1 << 20
+
+// Dependents returns, for each of the named packages, the files that mention it by
+// its installed name: files of other installed packages, and the user's own skills,
+// commands, and agents in the Claude directories packages are installed into.
+// A skill is invoked, a command run (/name), and an agent delegated to by this name,
+// so a mention is a likely dependency.
+func (m *Manager) Dependents(names []string) (map[string][]Reference, error) {
+	return m.References(names)
+}
+
+// MentionPattern matches a name where it stands on its own, as a skill, command
+// (/name), or agent is mentioned, and not as part of a longer name
+func MentionPattern(name string) *regexp.Regexp {
+	return regexp.MustCompile(`(^|[^\w:-])` + regexp.QuoteMeta(name) + `($|[^\w:-])`)
+}
+
+// References is Dependents for any names, such as of the user's own skills,
+// also searching the resources in the given Claude directories (e.g., a project's).
+func (m *Manager) References(names []string, claudeDirs ...string) (map[string][]Reference, error) {
+	packages, err := m.List()
+	if err != nil {
+		return nil, err
+	}
+
+	patterns := make(map[string]*regexp.Regexp, len(names))
+	for _, name := range names {
+		patterns[name] = MentionPattern(name)
+	}
+
+	owners := make(map[string]string) // Installed file -> package
+	dirs := make(map[string]bool)
+	for _, dir := range claudeDirs {
+		if dir, err := expandPath(dir); err == nil {
+			dirs[dir] = true
+		}
+	}
+	if dir, err := m.expandClaudeDir(); err == nil {
+		dirs[dir] = true
+	}
+	for _, pkg := range packages {
+		for _, f := range pkg.Files {
+			owners[f.Target] = pkg.Name
+		}
+		if pkg.ClaudeDir != "" && pkg.Target == "" {
+			if dir, err := expandPath(pkg.ClaudeDir); err == nil {
+				dirs[dir] = true
+			}
+		}
+	}
+
+	files := make(map[string]bool)
+	for path := range owners {
+		files[path] = true
+	}
+	for dir := range dirs {
+		for _, path := range resourceFiles(dir) {
+			files[path] = true
+		}
+	}
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	result := make(map[string][]Reference)
+	for _, path := range paths {
+		content, ok := readReferenceFile(path)
+		if !ok {
+			continue
+		}
+		owner := owners[path]
+		for _, name := range names {
+			if owner == name || !patterns[name].MatchString(content) {
+				continue
+			}
+			result[name] = append(result[name], Reference{Package: owner, Path: path})
+		}
+	}
+	return result, nil
+}
+
+// resourceFiles returns the markdown files of the skills, commands, and agents in a
+// Claude directory
+func resourceFiles(claudeDir string) []string {
+	var files []string
+	for _, sub := range []string{"commands", "agents"} {
+		root := filepath.Join(claudeDir, sub)
+		_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			if !d.IsDir() && strings.HasSuffix(path, ".md") {
+				files = append(files, path)
+			}
+			return nil
+		})
+	}
+	skills, _ := filepath.Glob(filepath.Join(claudeDir, "skills", "*", "SKILL.md"))
+	return append(files, skills...)
+}
+
+// readReferenceFile reads a file to search for references, skipping large and binary files
+func readReferenceFile(path string) (string, bool) {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() || info.Size() > maxReferenceFileSize {
+		return "", false
+	}
+	content, err := os.ReadFile(path)
+	if err != nil || strings.ContainsRune(string(content[:min(len(content), 512)]), 0) {
+		return "", false
+	}
+	return string(content), true
+}
@@ -0,0 +1,52 @@
+package pkgmgr
+
+import "github.com/itda-skills/jindo/internal/pkg/repo"
+
+// ShortName is how a listing can show an installed package: under its original
+// name, with the namespace it came from set apart
+type ShortName struct {
+	Name      string // Original name (e.g., web-fetch)
+	Namespace string // Namespace of the package's repository (e.g., affa-ever)
+}
+
+// ShortNames returns the short names of installed packages, by type and
+// installed name, for listings of the resources in listed (names by type). A
+// package keeps its installed name, and has no short name, where the short one
+// would be ambiguous: when another package of the same type has the same
+// original name, or a listed resource of the type is called that. Packages
+// installed under their original name, and rules for other editors, have none.
+func ShortNames(packages []InstalledPackage, listed map[repo.PackageType][]string) map[repo.PackageType]map[string]ShortName {
+	taken := make(map[repo.PackageType]map[string]int)
+	count := func(t repo.PackageType, name string) {
+		if taken[t] == nil {
+			taken[t] = make(map[string]int)
+		}
+		taken[t][name]++
+	}
+	for t, names := range listed {
+		for _, name := range names {
+			count(t, name)
+		}
+	}
+
+	var candidates []InstalledPackage
+	for _, pkg := range packages {
+		if pkg.Target != "" || pkg.OriginalName == "" || pkg.Name == pkg.OriginalName {
+			continue
+		}
+		candidates = append(candidates, pkg)
+		count(pkg.Type, pkg.OriginalName)
+	}
+
+	short := make(map[repo.PackageType]map[string]ShortName)
+	for _, pkg := range candidates {
+		if taken[pkg.Type][pkg.OriginalName] > 1 {
+			continue
+		}
+		if short[pkg.Type] == nil {
+			short[pkg.Type] = make(map[string]ShortName)
+		}
+		short[pkg.Type][pkg.Name] = ShortName{Name: pkg.OriginalName, Namespace: pkg.Namespace}
+	}
+	return short
+}
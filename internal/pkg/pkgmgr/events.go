@@ -0,0 +1,32 @@
+package pkgmgr
+
+import "github.com/itda-skills/jindo/internal/events"
+
+// emit records a change to an installed package in the event log of the
+// manager's data directory, with details of the package and any extra data
+func (m *Manager) emit(t events.Type, pkg *InstalledPackage, extra map[string]any) {
+	data := map[string]any{
+		"type":      string(pkg.Type),
+		"namespace": pkg.Namespace,
+		"version":   pkg.Version.SHA,
+	}
+	if pkg.Version.Ref != "" {
+		data["ref"] = pkg.Version.Ref
+	}
+	if pkg.SourcePath != "" {
+		data["source_path"] = pkg.SourcePath
+	}
+	if pkg.Source != "" {
+		data["source"] = pkg.Source
+	}
+	if pkg.Scope != "" {
+		data["scope"] = pkg.Scope
+	}
+	if pkg.Target != "" {
+		data["target"] = pkg.Target
+	}
+	for k, v := range extra {
+		data[k] = v
+	}
+	events.EmitTo(m.baseDir, events.Event{Type: t, Subject: pkg.Name, Data: data})
+}
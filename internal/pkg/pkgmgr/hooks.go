@@ -0,0 +1,136 @@
+package pkgmgr
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/itda-skills/jindo/internal/hook"
+	"github.com/itda-skills/jindo/internal/pkg/repo"
+)
+
+// ErrNoHookMetadata is returned when a hook script does not declare its event type.
+var ErrNoHookMetadata = errors.New("hook script does not declare an event type (add a '# Hook: <event>' header)")
+
+// HookMeta returns the registration metadata declared by an installed hook package's script.
+// It returns nil if the package is not a hook or its script declares no event type.
+func (m *Manager) HookMeta(name string) (*hook.ScriptMeta, error) {
+	pkg, err := m.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	if pkg.Type != repo.TypeHook || len(pkg.Files) == 0 {
+		return nil, nil
+	}
+	return hook.ParseScriptMeta(pkg.Files[0].Target)
+}
+
+// RegisterHook adds a settings.json rule that runs an installed hook package's script,
+// using the event type and matcher declared in the script, and records the rule so
+// that uninstalling the package removes it.
+func (m *Manager) RegisterHook(name, settingsPath string) (*InstalledPackage, error) {
+	meta, err := m.HookMeta(name)
+	if err != nil {
+		return nil, err
+	}
+	if meta == nil {
+		return nil, ErrNoHookMetadata
+	}
+	return m.registerHook(name, settingsPath, meta, "", -1)
+}
+
+// registerHook adds a settings.json rule for an installed hook package with the given
+// metadata, running command, or the package's script if command is empty. The rule
+// is added before the rule at index at among the event's rules, or after the last
+// one if at is negative.
+func (m *Manager) registerHook(name, settingsPath string, meta *hook.ScriptMeta, command string, at int) (*InstalledPackage, error) {
+	installed, err := m.load()
+	if err != nil {
+		return nil, err
+	}
+
+	var pkg *InstalledPackage
+	for i := range installed.Packages {
+		if installed.Packages[i].Name == name {
+			pkg = &installed.Packages[i]
+			break
+		}
+	}
+	if pkg == nil {
+		return nil, ErrPackageNotFound
+	}
+	if pkg.Type != repo.TypeHook || len(pkg.Files) == 0 {
+		return nil, fmt.Errorf("package %s is not a hook", name)
+	}
+	if pkg.Hook != nil {
+		return pkg, nil // Already registered
+	}
+
+	if command == "" {
+		command = pkg.Files[0].Target
+	}
+	if _, err := hook.NewStore(settingsPath).Insert(meta.EventType, meta.Matcher, []string{command}, at); err != nil {
+		return nil, fmt.Errorf("add hook to settings: %w", err)
+	}
+
+	pkg.Hook = &HookRegistration{
+		SettingsPath: settingsPath,
+		EventType:    meta.EventType,
+		Matcher:      meta.Matcher,
+		Command:      command,
+	}
+	if err := m.save(installed); err != nil {
+		return nil, err
+	}
+
+	return pkg, nil
+}
+
+// unregisterHook removes the settings.json rule created for an installed hook package.
+func unregisterHook(reg *HookRegistration) error {
+	if _, err := hook.NewStore(reg.SettingsPath).RemoveCommand(reg.EventType, reg.Command); err != nil {
+		return fmt.Errorf("remove hook from settings: %w", err)
+	}
+	return nil
+}
+
+// reregisterHook restores the settings.json rule of a hook package after it was reinstalled,
+// at index at among the event's rules, where the previous rule was (see hookPosition).
+// The reinstalled script's metadata is used, falling back to the previous registration;
+// a rule for another event type goes after the rules of that event.
+func (m *Manager) reregisterHook(pkg *InstalledPackage, prev *HookRegistration, at int) (*InstalledPackage, error) {
+	meta, err := m.HookMeta(pkg.Name)
+	if err != nil || meta == nil {
+		meta = &hook.ScriptMeta{EventType: prev.EventType, Matcher: prev.Matcher}
+	}
+	if meta.EventType != prev.EventType {
+		at = -1
+	}
+	return m.registerHook(pkg.Name, prev.SettingsPath, meta, "", at)
+}
+
+// hookPosition returns the index among its event's rules of the settings.json
+// rule of a registered hook, or -1 if it is not there anymore, so that an
+// update puts the new rule where the old one was.
+func hookPosition(reg *HookRegistration) int {
+	if reg == nil {
+		return -1
+	}
+	at, err := hook.NewStore(reg.SettingsPath).RuleIndex(reg.EventType, reg.Command)
+	if err != nil {
+		return -1
+	}
+	return at
+}
+
+// movePins returns pins, taken with hook.Store.Pins, with the scripts that were
+// renamed moved to their new paths, so they keep their checksums.
+func movePins(pins, renamed map[string]string) map[string]string {
+	moved := make(map[string]string, len(pins))
+	for script, sum := range pins {
+		if to, ok := renamed[script]; ok {
+			script = to
+		}
+		moved[script] = sum
+	}
+	return moved
+}
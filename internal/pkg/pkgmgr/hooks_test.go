@@ -0,0 +1,69 @@
+package pkgmgr
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/itda-skills/jindo/internal/hook"
+	"github.com/itda-skills/jindo/internal/pkg/repo"
+)
+
+func TestUpdateKeepsHookPosition(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	repoDir := t.TempDir()
+	writeTestFile(t, filepath.Join(repoDir, "hooks", "format.sh"), "#!/bin/sh\n# Hook: PreToolUse\n# Matcher: Edit\n", 0755)
+	settingsPath := filepath.Join(t.TempDir(), "settings.json")
+	writeTestFile(t, settingsPath, `{"hooks":{"PreToolUse":[{"matcher":"Bash","hooks":[{"type":"command","command":"a"}]}]}}`, 0644)
+
+	claudeDir := t.TempDir()
+	m := NewManager(t.TempDir())
+	install := func() *InstalledPackage {
+		t.Helper()
+		files, err := m.installHook(repoDir, "hooks/format.sh", "demo--format.sh", claudeDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		installed, err := m.load()
+		if err != nil {
+			t.Fatal(err)
+		}
+		pkg := InstalledPackage{Name: "demo--format.sh", Type: repo.TypeHook, SourcePath: "hooks/format.sh", Files: files}
+		installed.Packages = append(installed.Packages, pkg)
+		if err := m.save(installed); err != nil {
+			t.Fatal(err)
+		}
+		return &pkg
+	}
+	install()
+	pkg, err := m.RegisterHook("demo--format.sh", settingsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := hook.NewStore(settingsPath)
+	if _, err := store.Add(hook.PreToolUse, "Write", []string{"c"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// As update does: the rule is removed with the old version and added back
+	hookAt := hookPosition(pkg.Hook)
+	prev := *pkg
+	if err := m.uninstall(pkg.Name); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.finishUpdate(install(), &prev, hookAt); err != nil {
+		t.Fatal(err)
+	}
+
+	hooks, err := store.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var order []string
+	for _, h := range hooks {
+		order = append(order, h.Name)
+	}
+	if want := "PreToolUse-Bash-0 PreToolUse-Edit-1 PreToolUse-Write-2"; strings.Join(order, " ") != want {
+		t.Errorf("hooks after update = %v, want %s", order, want)
+	}
+}
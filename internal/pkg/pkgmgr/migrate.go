@@ -0,0 +1,134 @@
+package pkgmgr
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// SchemaVersion is the installed.json schema version written by this version of jd.
+//
+// Version 2 records each package's install scope, source repository URL, pin, and
+// bundle, and a hash of every installed file. Version 1 files are migrated when
+// loaded, after a backup copy is written next to them.
+const SchemaVersion = 2
+
+// MigrateResult describes a migration of installed.json.
+type MigrateResult struct {
+	From     int    // Schema version before migrating (0 if there is no installed.json)
+	To       int    // Schema version after migrating
+	Backfill int    // Packages whose missing metadata was filled in
+	Backup   string // Copy of installed.json before migrating; empty if nothing changed
+}
+
+// Migrate upgrades installed.json to SchemaVersion and fills in metadata missing from
+// its packages, such as the URL of a repository registered after they were installed.
+// installed.json is backed up first; nothing is written if nothing changed.
+func (m *Manager) Migrate() (*MigrateResult, error) {
+	installed, data, err := m.read()
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return &MigrateResult{To: SchemaVersion}, nil
+	}
+	return m.migrate(installed, data)
+}
+
+// migrate upgrades installed, read from data, in place and saves it after backing up data
+func (m *Manager) migrate(installed *InstalledManifest, data []byte) (*MigrateResult, error) {
+	result := &MigrateResult{From: installed.Version, To: SchemaVersion}
+	if installed.Version > SchemaVersion {
+		return nil, fmt.Errorf("installed.json schema version %d is newer than this jd supports (%d); upgrade jd", installed.Version, SchemaVersion)
+	}
+
+	for i := range installed.Packages {
+		if m.backfill(&installed.Packages[i]) {
+			result.Backfill++
+		}
+	}
+	if installed.Version == SchemaVersion && result.Backfill == 0 {
+		return result, nil
+	}
+
+	path, err := m.installedFilePath()
+	if err != nil {
+		return nil, err
+	}
+	result.Backup = fmt.Sprintf("%s.v%d-%s.bak", path, result.From, time.Now().Format("20060102-150405"))
+	if err := os.WriteFile(result.Backup, data, 0644); err != nil {
+		return nil, fmt.Errorf("back up installed.json: %w", err)
+	}
+
+	installed.Version = SchemaVersion
+	if err := m.save(installed); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// backfill fills in the schema v2 metadata a package is missing. Missing file hashes
+// are taken from the files as they are now. Returns whether anything changed.
+func (m *Manager) backfill(pkg *InstalledPackage) bool {
+	changed := false
+
+	if pkg.Scope == "" {
+		pkg.Scope = ScopeGlobal
+		if pkg.ClaudeDir != "" {
+			pkg.Scope = ScopeLocal
+		}
+		changed = true
+	}
+
+	if pkg.RepoURL == "" && pkg.Source == "" {
+		if config, err := m.repoStore.Get(pkg.Namespace); err == nil {
+			pkg.RepoURL = config.URL
+			changed = true
+		}
+	}
+
+	if pkg.Pin == nil && pkg.Version.Type == "tag" && !pkg.stable() {
+		pkg.Pin = &PinInfo{Ref: pkg.Version.Ref}
+		changed = true
+	}
+
+	for i := range pkg.Files {
+		f := &pkg.Files[i]
+		if f.SHA != "" {
+			continue
+		}
+		if sha, err := fileSHA256(f.Target); err == nil {
+			f.SHA = sha
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+// read reads installed.json without migrating it. data is nil if the file does not exist.
+func (m *Manager) read() (*InstalledManifest, []byte, error) {
+	path, err := m.installedFilePath()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &InstalledManifest{Version: SchemaVersion, Packages: []InstalledPackage{}}, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	var installed InstalledManifest
+	if err := json.Unmarshal(data, &installed); err != nil {
+		return nil, nil, fmt.Errorf("parse installed.json: %w", err)
+	}
+	if installed.Version == 0 {
+		installed.Version = 1
+	}
+
+	return &installed, data, nil
+}
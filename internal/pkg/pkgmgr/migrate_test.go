@@ -0,0 +1,158 @@
+package pkgmgr
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeInstalled writes installed.json into the data directory of m
+func writeInstalled(t *testing.T, m *Manager, content string) string {
+	t.Helper()
+	path, err := m.installedFilePath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeTestFile(t, path, content, 0644)
+	return path
+}
+
+// backups returns the installed.json backups next to path
+func backups(t *testing.T, path string) []string {
+	t.Helper()
+	matches, err := filepath.Glob(path + ".v*.bak")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return matches
+}
+
+func TestMigrateV1(t *testing.T) {
+	m := NewManager(t.TempDir())
+	target := filepath.Join(t.TempDir(), "skills", "demo--tool", "SKILL.md")
+	writeTestFile(t, target, "# tool\n", 0644)
+	v1, _ := json.Marshal(map[string]any{
+		"packages": []map[string]any{{
+			"name":    "demo--tool",
+			"type":    "skill",
+			"version": map[string]any{"type": "tag", "sha": "abc", "ref": "v1.0.0"},
+			"files":   []map[string]any{{"source": "skills/tool/SKILL.md", "target": target}},
+		}},
+	})
+	path := writeInstalled(t, m, string(v1))
+
+	result, err := m.Migrate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.From != 1 || result.To != SchemaVersion || result.Backfill != 1 {
+		t.Errorf("Migrate() = %+v, want from 1 to %d with 1 package backfilled", result, SchemaVersion)
+	}
+
+	// The file before migrating is backed up as it was
+	if backup, err := os.ReadFile(result.Backup); err != nil || string(backup) != string(v1) {
+		t.Errorf("backup = %q, %v; want the v1 file", backup, err)
+	}
+	if !strings.HasPrefix(result.Backup, path+".v1-") {
+		t.Errorf("backup path = %s, want %s.v1-<timestamp>.bak", result.Backup, path)
+	}
+
+	installed, _, err := m.read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if installed.Version != SchemaVersion {
+		t.Errorf("saved version = %d, want %d", installed.Version, SchemaVersion)
+	}
+	pkg := installed.Packages[0]
+	if pkg.Scope != ScopeGlobal {
+		t.Errorf("scope = %q, want %q", pkg.Scope, ScopeGlobal)
+	}
+	if pkg.Pin == nil || pkg.Pin.Ref != "v1.0.0" {
+		t.Errorf("pin = %+v, want v1.0.0 for a package installed at a tag", pkg.Pin)
+	}
+	if sha, _ := fileSHA256(target); pkg.Files[0].SHA != sha {
+		t.Errorf("file hash = %q, want the hash of the file as it is", pkg.Files[0].SHA)
+	}
+}
+
+func TestMigrateWithoutChanges(t *testing.T) {
+	m := NewManager(t.TempDir())
+	current := `{"version": 2, "packages": [{"name": "demo--tool", "type": "skill", "scope": "global", "repo_url": "https://example.com/demo.git"}]}`
+	path := writeInstalled(t, m, current)
+
+	result, err := m.Migrate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Backup != "" || result.Backfill != 0 {
+		t.Errorf("Migrate() of a current file = %+v, want nothing done", result)
+	}
+	if content, _ := os.ReadFile(path); string(content) != current {
+		t.Errorf("Migrate() rewrote a current file:\n%s", content)
+	}
+	if b := backups(t, path); len(b) != 0 {
+		t.Errorf("Migrate() backed up a current file: %v", b)
+	}
+}
+
+func TestMigrateRefusesNewerSchema(t *testing.T) {
+	m := NewManager(t.TempDir())
+	newer := `{"version": 99, "packages": []}`
+	path := writeInstalled(t, m, newer)
+
+	if _, err := m.Migrate(); err == nil {
+		t.Error("Migrate() of a newer schema succeeded")
+	}
+	if _, err := m.load(); err == nil {
+		t.Error("load() of a newer schema succeeded")
+	}
+	if content, _ := os.ReadFile(path); string(content) != newer {
+		t.Errorf("a newer file was rewritten:\n%s", content)
+	}
+}
+
+func TestLoadMigratesOnce(t *testing.T) {
+	m := NewManager(t.TempDir())
+	path := writeInstalled(t, m, `{"packages": [{"name": "demo--tool", "type": "skill"}]}`)
+
+	for range 2 {
+		if _, err := m.load(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if b := backups(t, path); len(b) != 1 {
+		t.Errorf("loading twice wrote %d backups, want 1", len(b))
+	}
+}
+
+func TestBackfill(t *testing.T) {
+	m := NewManager(t.TempDir())
+	tests := []struct {
+		name    string
+		pkg     InstalledPackage
+		changed bool
+		scope   string
+		pinned  bool
+	}{
+		{"global", InstalledPackage{}, true, ScopeGlobal, false},
+		{"local", InstalledPackage{ClaudeDir: "/project/.claude"}, true, ScopeLocal, false},
+		{"tag", InstalledPackage{Scope: ScopeGlobal, Source: "a.zip", Version: VersionInfo{Type: "tag", Ref: "v1"}}, true, ScopeGlobal, true},
+		{"stable", InstalledPackage{Scope: ScopeGlobal, Source: "a.zip", Channel: ChannelStable, Version: VersionInfo{Type: "tag", Ref: "v1"}}, false, ScopeGlobal, false},
+		{"complete", InstalledPackage{Scope: ScopeGlobal, Source: "a.zip"}, false, ScopeGlobal, false},
+	}
+	for _, tt := range tests {
+		pkg := tt.pkg
+		if changed := m.backfill(&pkg); changed != tt.changed {
+			t.Errorf("%s: backfill() = %v, want %v", tt.name, changed, tt.changed)
+		}
+		if pkg.Scope != tt.scope {
+			t.Errorf("%s: scope = %q, want %q", tt.name, pkg.Scope, tt.scope)
+		}
+		if (pkg.Pin != nil) != tt.pinned {
+			t.Errorf("%s: pin = %+v, want pinned %v", tt.name, pkg.Pin, tt.pinned)
+		}
+	}
+}
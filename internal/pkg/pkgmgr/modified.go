@@ -0,0 +1,141 @@
+package pkgmgr
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// trashDirName is the directory under the data directory that keeps copies of
+// modified files removed by uninstall, and resources the user deleted.
+const trashDirName = "trash"
+
+// ModifiedFiles returns the installed files of a package whose content no longer
+// matches the SHA-256 recorded at install time. Missing files and files installed
+// before hashes were recorded are not reported.
+func (m *Manager) ModifiedFiles(pkg *InstalledPackage) ([]string, error) {
+	var modified []string
+	for _, f := range pkg.Files {
+		if f.SHA == "" {
+			continue
+		}
+		sha, err := fileSHA256(f.Target)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("hash %s: %w", f.Target, err)
+		}
+		if sha != f.SHA {
+			modified = append(modified, f.Target)
+		}
+	}
+	return modified, nil
+}
+
+// SetDiscardModified makes Uninstall remove files edited since installation
+// without keeping a copy of them in the trash.
+func (m *Manager) SetDiscardModified(discard bool) {
+	m.discardModified = discard
+}
+
+// KeepModified copies the files of a package edited since installation to the
+// trash, and returns the directory they were copied to, or "" if none were edited.
+func (m *Manager) KeepModified(pkg *InstalledPackage) (string, error) {
+	modified, err := m.ModifiedFiles(pkg)
+	if err != nil {
+		return "", fmt.Errorf("check for modified files: %w", err)
+	}
+	if len(modified) == 0 {
+		return "", nil
+	}
+	dir, err := m.KeepCopies(pkg, modified)
+	if err != nil {
+		return "", fmt.Errorf("keep modified files: %w", err)
+	}
+	return dir, nil
+}
+
+// TrashDir returns the directory that keeps copies of modified files removed by
+// uninstall, and resources the user deleted.
+func (m *Manager) TrashDir() (string, error) {
+	base, err := m.expandDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, trashDirName), nil
+}
+
+// KeepCopies copies files of a package into a new timestamped directory in the
+// trash, keeping their paths relative to the install directory, and returns it.
+func (m *Manager) KeepCopies(pkg *InstalledPackage, files []string) (string, error) {
+	trashDir, err := m.TrashDir()
+	if err != nil {
+		return "", err
+	}
+	claudeDir := pkg.ClaudeDir
+	if claudeDir == "" {
+		if claudeDir, err = m.expandClaudeDir(); err != nil {
+			return "", err
+		}
+	}
+
+	dir := filepath.Join(trashDir, fmt.Sprintf("%s-%s", pkg.Name, time.Now().Format("20060102-150405")))
+	for _, file := range files {
+		rel, err := filepath.Rel(claudeDir, file)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			rel = filepath.Base(file)
+		}
+		dest := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return "", fmt.Errorf("create trash directory: %w", err)
+		}
+		if err := copyFile(file, dest); err != nil {
+			return "", fmt.Errorf("copy %s: %w", file, err)
+		}
+	}
+	return dir, nil
+}
+
+// MoveToTrash moves a file or directory, such as a skill the user deleted, into a
+// new timestamped directory in the trash named after label, and returns its new path.
+func (m *Manager) MoveToTrash(label, path string) (string, error) {
+	trashDir, err := m.TrashDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(trashDir, fmt.Sprintf("%s-%s", label, time.Now().Format("20060102-150405")))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("create trash directory: %w", err)
+	}
+
+	dest := filepath.Join(dir, filepath.Base(path))
+	if err := os.Rename(path, dest); err == nil {
+		return dest, nil
+	}
+	// The trash may be on another file system; copy, then remove
+	err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(path, p)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dest, rel)
+		if d.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		return copyFile(p, target)
+	})
+	if err != nil {
+		return "", fmt.Errorf("copy %s to the trash: %w", path, err)
+	}
+	if err := os.RemoveAll(path); err != nil {
+		return "", err
+	}
+	return dest, nil
+}
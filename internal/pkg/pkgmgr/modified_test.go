@@ -0,0 +1,120 @@
+package pkgmgr
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/itda-skills/jindo/internal/pkg/repo"
+)
+
+// installTestSkill installs a skill with two files under claudeDir and records it
+func installTestSkill(t *testing.T, m *Manager, claudeDir string) InstalledPackage {
+	t.Helper()
+	repoDir := t.TempDir()
+	writeTestFile(t, filepath.Join(repoDir, "skills", "tool", "SKILL.md"), "# tool\n", 0644)
+	writeTestFile(t, filepath.Join(repoDir, "skills", "tool", "ref", "guide.md"), "guide\n", 0644)
+	files, err := m.installSkill(repoDir, "skills/tool", "demo--tool", claudeDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg := InstalledPackage{Name: "demo--tool", Type: repo.TypeSkill, SourcePath: "skills/tool", ClaudeDir: claudeDir, Files: files}
+	if err := m.save(&InstalledManifest{Packages: []InstalledPackage{pkg}}); err != nil {
+		t.Fatal(err)
+	}
+	return pkg
+}
+
+func TestModifiedFiles(t *testing.T) {
+	claudeDir := t.TempDir()
+	m := NewManager(t.TempDir())
+	pkg := installTestSkill(t, m, claudeDir)
+	skillDir := filepath.Join(claudeDir, "skills", "demo--tool")
+
+	if modified, err := m.ModifiedFiles(&pkg); err != nil || len(modified) != 0 {
+		t.Fatalf("ModifiedFiles() of a fresh install = %v, %v", modified, err)
+	}
+
+	// An edited file is reported; a deleted one and one without a hash are not
+	writeTestFile(t, filepath.Join(skillDir, "SKILL.md"), "# tool, edited\n", 0644)
+	if err := os.Remove(filepath.Join(skillDir, "ref", "guide.md")); err != nil {
+		t.Fatal(err)
+	}
+	modified, err := m.ModifiedFiles(&pkg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(modified) != 1 || modified[0] != filepath.Join(skillDir, "SKILL.md") {
+		t.Errorf("ModifiedFiles() = %v, want [SKILL.md]", modified)
+	}
+	pkg.Files[0].SHA = ""
+	if modified, _ := m.ModifiedFiles(&pkg); len(modified) != 0 {
+		t.Errorf("ModifiedFiles() reported a file installed without a hash: %v", modified)
+	}
+}
+
+func TestKeepCopies(t *testing.T) {
+	claudeDir := t.TempDir()
+	m := NewManager(t.TempDir())
+	pkg := installTestSkill(t, m, claudeDir)
+	guide := filepath.Join(claudeDir, "skills", "demo--tool", "ref", "guide.md")
+
+	dir, err := m.KeepCopies(&pkg, []string{guide})
+	if err != nil {
+		t.Fatal(err)
+	}
+	trash, _ := m.TrashDir()
+	if filepath.Dir(dir) != trash {
+		t.Errorf("KeepCopies() kept the files in %s, want a directory in %s", dir, trash)
+	}
+	// The path relative to the Claude directory is kept
+	if content, err := os.ReadFile(filepath.Join(dir, "skills", "demo--tool", "ref", "guide.md")); err != nil || string(content) != "guide\n" {
+		t.Errorf("kept copy = %q, %v", content, err)
+	}
+}
+
+func TestUninstallKeepsModifiedFiles(t *testing.T) {
+	for _, discard := range []bool{false, true} {
+		claudeDir := t.TempDir()
+		m := NewManager(t.TempDir())
+		installTestSkill(t, m, claudeDir)
+		skillFile := filepath.Join(claudeDir, "skills", "demo--tool", "SKILL.md")
+		writeTestFile(t, skillFile, "# tool, edited\n", 0644)
+
+		m.SetDiscardModified(discard)
+		kept, err := m.UninstallKeepingModified("demo--tool")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := os.Stat(skillFile); !os.IsNotExist(err) {
+			t.Errorf("uninstall left the edited file in place: %v", err)
+		}
+		if discard {
+			if kept != "" {
+				t.Errorf("UninstallKeepingModified() with SetDiscardModified kept files in %s", kept)
+			}
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(kept, "skills", "demo--tool", "SKILL.md"))
+		if err != nil || string(content) != "# tool, edited\n" {
+			t.Errorf("the edited file was not kept: %q, %v", content, err)
+		}
+	}
+}
+
+func TestMoveToTrash(t *testing.T) {
+	m := NewManager(t.TempDir())
+	src := filepath.Join(t.TempDir(), "my-skill")
+	writeTestFile(t, filepath.Join(src, "SKILL.md"), "# mine\n", 0644)
+
+	dest, err := m.MoveToTrash("my-skill", src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(src); !os.IsNotExist(err) {
+		t.Errorf("MoveToTrash() left the source in place: %v", err)
+	}
+	if content, err := os.ReadFile(filepath.Join(dest, "SKILL.md")); err != nil || string(content) != "# mine\n" {
+		t.Errorf("moved file = %q, %v", content, err)
+	}
+}
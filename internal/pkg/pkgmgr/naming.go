@@ -0,0 +1,160 @@
+package pkgmgr
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/itda-skills/jindo/internal/pkg/repo"
+)
+
+// DefaultNamespaceSep separates the namespace from the package name in the
+// name a package is installed under (e.g., affa-ever--web-fetch).
+const DefaultNamespaceSep = "--"
+
+// Naming decides the names packages are installed under.
+type Naming struct {
+	// Separator joins the namespace and the package name.
+	Separator string
+	// Flat installs packages under their original name when no installed package
+	// or existing resource uses it, and namespaced only when one does.
+	Flat bool
+}
+
+// DefaultNaming installs every package as <namespace>--<name>.
+var DefaultNaming = Naming{Separator: DefaultNamespaceSep}
+
+// ValidateSeparator checks that a separator cannot appear in a namespace, so a
+// namespaced name reads back unambiguously, and is safe in file and command
+// names: one or more of "-", "_", and ".", and at least "--" when only hyphens.
+func ValidateSeparator(sep string) error {
+	if sep == "" {
+		return fmt.Errorf("separator is empty")
+	}
+	if strings.Trim(sep, "-_.") != "" {
+		return fmt.Errorf("separator %q may only contain -, _, and .", sep)
+	}
+	if sep == "-" {
+		return fmt.Errorf(`separator "-" is used inside namespaces; use "--" or another character`)
+	}
+	return nil
+}
+
+// SetNaming sets the names new packages are installed under. Updates keep the
+// name a package was installed under.
+func (m *Manager) SetNaming(n Naming) {
+	if n.Separator == "" {
+		n.Separator = DefaultNamespaceSep
+	}
+	m.naming = n
+}
+
+// Naming returns the names new packages are installed under.
+func (m *Manager) Naming() Naming {
+	return m.naming
+}
+
+// MakeNamespacedName joins a namespace and a package name with the separator.
+func (n Naming) MakeNamespacedName(namespace, name string) string {
+	return namespace + n.Separator + name
+}
+
+// MakeNamespacedName returns the name a package would be installed under: its
+// original name in flat mode when that is free, otherwise namespaced. Returns
+// ErrNameCollision if another installed package already has that name.
+func (m *Manager) MakeNamespacedName(namespace, originalName string, pkgType repo.PackageType) (string, error) {
+	installed, err := m.load()
+	if err != nil {
+		return "", err
+	}
+	return m.resolveName(installed, namespace, originalName, pkgType, "")
+}
+
+// resolveName returns the name to install a package under: name if it is set,
+// such as the name of the version being updated, otherwise the naming's, with
+// the target appended when the package is installed under that name for another
+// assistant or project (demo--tool-cursor). Returns ErrPackageAlreadyInstalled if
+// the package is installed for the current target and project, and
+// ErrNameCollision if another package has the name.
+func (m *Manager) resolveName(installed *InstalledManifest, namespace, originalName string, pkgType repo.PackageType, name string) (string, error) {
+	samePackage := func(pkg *InstalledPackage) bool {
+		return pkg.Namespace == namespace && pkg.OriginalName == originalName && pkg.Type == pkgType
+	}
+	for i := range installed.Packages {
+		pkg := &installed.Packages[i]
+		if samePackage(pkg) && pkg.Target == m.target && pkg.ProjectRoot == m.projectRoot {
+			return "", ErrPackageAlreadyInstalled
+		}
+	}
+
+	if name == "" {
+		if m.naming.Flat && m.target == "" && m.flatNameFree(installed, originalName, pkgType) {
+			return originalName, nil
+		}
+		name = m.naming.MakeNamespacedName(namespace, originalName)
+		if pkg := installed.find(name); pkg != nil && samePackage(pkg) {
+			name = targetName(installed, name, m.target)
+		}
+	}
+
+	if pkg := installed.find(name); pkg != nil {
+		return "", fmt.Errorf("%w: %s is already the name of %s %s from %s", ErrNameCollision, name, pkg.Type, pkg.OriginalName, pkg.Namespace)
+	}
+	return name, nil
+}
+
+// targetName returns name with the target appended, numbered from 2 if a
+// package installed for the target in another project has that name.
+func targetName(installed *InstalledManifest, name, target string) string {
+	if target == "" {
+		target = TargetClaude
+	}
+	base := name + "-" + target
+	name = base
+	for n := 2; installed.find(name) != nil; n++ {
+		name = fmt.Sprintf("%s-%d", base, n)
+	}
+	return name
+}
+
+// find returns the installed package named name, or nil.
+func (im *InstalledManifest) find(name string) *InstalledPackage {
+	for i := range im.Packages {
+		if im.Packages[i].Name == name {
+			return &im.Packages[i]
+		}
+	}
+	return nil
+}
+
+// flatNameFree reports whether a package can be installed under its original
+// name: no installed package has it, and no resource of its type exists there
+// (such as a skill the user wrote).
+func (m *Manager) flatNameFree(installed *InstalledManifest, name string, pkgType repo.PackageType) bool {
+	for _, pkg := range installed.Packages {
+		if pkg.Name == name {
+			return false
+		}
+	}
+
+	claudeDir, err := m.expandClaudeDir()
+	if err != nil {
+		return false
+	}
+	var dest string
+	switch pkgType {
+	case repo.TypeSkill:
+		dest = filepath.Join(claudeDir, "skills", name)
+	case repo.TypeCommand:
+		dest = markdownPackagePath(filepath.Join(claudeDir, "commands"), name)
+	case repo.TypeAgent:
+		dest = markdownPackagePath(filepath.Join(claudeDir, "agents"), name)
+	case repo.TypeHook:
+		dest = filepath.Join(claudeDir, "hooks", name)
+	default:
+		return false
+	}
+	_, err = os.Stat(dest)
+	return os.IsNotExist(err)
+}
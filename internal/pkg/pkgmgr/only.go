@@ -0,0 +1,125 @@
+package pkgmgr
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/itda-skills/jindo/internal/pkg/repo"
+)
+
+// ErrOnlySkills is returned when a selection of files is given for a package
+// that is not a skill.
+var ErrOnlySkills = errors.New("only skills can be installed in part")
+
+// SetOnly sets glob patterns that select the files of skills to install (see
+// MatchOnly). Nil installs every file.
+func (m *Manager) SetOnly(patterns []string) {
+	m.only = patterns
+}
+
+// ValidateOnly checks the syntax of file selection patterns.
+func ValidateOnly(patterns []string) error {
+	for _, p := range patterns {
+		if _, err := path.Match(p, ""); err != nil || p == "" {
+			return fmt.Errorf("invalid pattern: %q", p)
+		}
+	}
+	return nil
+}
+
+// MatchOnly reports whether patterns select the skill file rel (slash-separated,
+// relative to the skill directory): the file or one of its parent directories
+// matches one of them, so "templates" selects everything in templates/. The
+// skill's SKILL.md is always selected, and no patterns select every file.
+func MatchOnly(patterns []string, rel string) bool {
+	if len(patterns) == 0 || isSkillEntry(rel) {
+		return true
+	}
+	for _, p := range patterns {
+		if selects(p, rel) {
+			return true
+		}
+	}
+	return false
+}
+
+// UnmatchedOnly returns the patterns that select none of files, such as a
+// mistyped name.
+func UnmatchedOnly(patterns, files []string) []string {
+	var unmatched []string
+	for _, p := range patterns {
+		found := false
+		for _, f := range files {
+			if selects(p, f) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			unmatched = append(unmatched, p)
+		}
+	}
+	return unmatched
+}
+
+// selects reports whether pattern matches rel or one of its parent directories.
+func selects(pattern, rel string) bool {
+	pattern = strings.TrimSuffix(pattern, "/")
+	for dir := rel; dir != "." && dir != "/"; dir = path.Dir(dir) {
+		if ok, _ := path.Match(pattern, dir); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isSkillEntry reports whether rel is a skill's SKILL.md.
+func isSkillEntry(rel string) bool {
+	return strings.EqualFold(rel, "SKILL.md")
+}
+
+// SkillFiles returns the files of the skill a spec refers to, slash-separated
+// and relative to the skill directory, as install would copy them.
+func (m *Manager) SkillFiles(specStr string) ([]string, error) {
+	spec, err := ParseSpec(specStr)
+	if err != nil {
+		return nil, err
+	}
+	pkgType, _, err := m.resolvePackage(spec)
+	if err != nil {
+		return nil, err
+	}
+	if pkgType != repo.TypeSkill {
+		return nil, fmt.Errorf("%w: %s is a %s", ErrOnlySkills, spec.Path, pkgType)
+	}
+	packageRoot, err := m.repoStore.PackageRoot(spec.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	skillFiles, err := listSkillFiles(packageRoot, filepath.Join(packageRoot, spec.Path))
+	if err != nil {
+		return nil, err
+	}
+	files := make([]string, len(skillFiles))
+	for i, f := range skillFiles {
+		files[i] = filepath.ToSlash(f.relPath)
+	}
+	return files, nil
+}
+
+// selectSkillFiles returns the skill files the manager's patterns select.
+func (m *Manager) selectSkillFiles(skillFiles []skillFile) []skillFile {
+	if len(m.only) == 0 {
+		return skillFiles
+	}
+	var selected []skillFile
+	for _, f := range skillFiles {
+		if MatchOnly(m.only, filepath.ToSlash(f.relPath)) {
+			selected = append(selected, f)
+		}
+	}
+	return selected
+}
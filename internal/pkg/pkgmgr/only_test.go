@@ -0,0 +1,77 @@
+package pkgmgr
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+func TestMatchOnly(t *testing.T) {
+	patterns := []string{"templates/*", "scripts", "*.py"}
+	tests := []struct {
+		rel  string
+		want bool
+	}{
+		{"SKILL.md", true},
+		{"templates/a.md", true},
+		{"templates/sub/b.md", true},
+		{"scripts/run.sh", true},
+		{"tool.py", true},
+		{"reference/guide.md", false},
+		{"templates.md", false},
+	}
+	for _, tt := range tests {
+		if got := MatchOnly(patterns, tt.rel); got != tt.want {
+			t.Errorf("MatchOnly(%q) = %v, want %v", tt.rel, got, tt.want)
+		}
+	}
+	if !MatchOnly(nil, "reference/guide.md") {
+		t.Error("MatchOnly() with no patterns left a file out")
+	}
+}
+
+func TestUnmatchedOnly(t *testing.T) {
+	files := []string{"SKILL.md", "templates/a.md", "reference/guide.md"}
+	got := UnmatchedOnly([]string{"SKILL.md", "templates/*", "refrence"}, files)
+	if len(got) != 1 || got[0] != "refrence" {
+		t.Errorf("UnmatchedOnly() = %v, want [refrence]", got)
+	}
+}
+
+func TestValidateOnly(t *testing.T) {
+	if err := ValidateOnly([]string{"SKILL.md", "templates/*"}); err != nil {
+		t.Errorf("ValidateOnly() = %v", err)
+	}
+	if err := ValidateOnly([]string{"templates/["}); err == nil {
+		t.Error("ValidateOnly() accepted a malformed pattern")
+	}
+}
+
+func TestInstallSkillOnly(t *testing.T) {
+	repoDir := t.TempDir()
+	skillDir := filepath.Join(repoDir, "skills", "tool")
+	writeTestFile(t, filepath.Join(skillDir, "SKILL.md"), "# tool\n", 0644)
+	writeTestFile(t, filepath.Join(skillDir, "templates", "a.md"), "a\n", 0644)
+	writeTestFile(t, filepath.Join(skillDir, "reference", "guide.md"), "guide\n", 0644)
+
+	claudeDir := t.TempDir()
+	m := NewManager(t.TempDir())
+	m.SetOnly([]string{"templates/*"})
+	files, err := m.installSkill(repoDir, "skills/tool", "demo--tool", claudeDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, f := range files {
+		rel, _ := filepath.Rel(filepath.Join(claudeDir, "skills", "demo--tool"), f.Target)
+		got = append(got, filepath.ToSlash(rel))
+	}
+	sort.Strings(got)
+	if len(got) != 2 || got[0] != "SKILL.md" || got[1] != "templates/a.md" {
+		t.Errorf("installSkill() installed %v, want SKILL.md and templates/a.md", got)
+	}
+	if _, err := os.Stat(filepath.Join(claudeDir, "skills", "demo--tool", "reference")); !os.IsNotExist(err) {
+		t.Errorf("a file left out of the selection was installed: %v", err)
+	}
+}
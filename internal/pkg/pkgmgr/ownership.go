@@ -0,0 +1,240 @@
+package pkgmgr
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/itda-skills/jindo/internal/pkg/git"
+	"github.com/itda-skills/jindo/internal/pkg/repo"
+)
+
+// ErrSourceRemoved is returned when an update finds that a package is no longer
+// in its repository, neither where it was installed from nor renamed.
+var ErrSourceRemoved = errors.New("package was removed from its repository")
+
+// followSource returns the source path and name to reinstall a package from
+// on update: where the package is now in its repository, and the name that
+// follows a rename there. Returns ErrSourceRemoved if it is gone, so that the
+// installed version is kept.
+func (m *Manager) followSource(pkg *InstalledPackage) (string, string, error) {
+	repoConfig, err := m.repoStore.Get(pkg.Namespace)
+	if err != nil {
+		return "", "", fmt.Errorf("repository not found: %w", err)
+	}
+	localPath, err := m.repoStore.RepoLocalPath(pkg.Namespace)
+	if err != nil {
+		return "", "", err
+	}
+
+	commit := "HEAD"
+	if m.channel == ChannelStable || (m.channel == "" && pkg.stable()) {
+		_, sha, err := latestRelease(localPath, !repoConfig.Link)
+		if err != nil {
+			return "", "", err
+		}
+		commit = sha
+	} else {
+		// The working tree of a linked repository may be ahead of its commits
+		packageRoot, err := m.repoStore.PackageRoot(pkg.Namespace)
+		if err != nil {
+			return "", "", err
+		}
+		if _, err := os.Stat(filepath.Join(packageRoot, filepath.FromSlash(pkg.SourcePath))); err == nil {
+			return pkg.SourcePath, pkg.Name, nil
+		}
+	}
+
+	sourcePath, err := movedSource(localPath, repoConfig.Root, pkg, commit)
+	if errors.Is(err, ErrSourceRemoved) {
+		return "", "", fmt.Errorf("%w: %s is not in %s anymore (uninstall it with 'jd pkg uninstall %s')", err, pkg.SourcePath, pkg.Namespace, pkg.Name)
+	}
+	if err != nil || sourcePath == pkg.SourcePath {
+		return sourcePath, pkg.Name, err
+	}
+
+	pkgType, relPath, err := m.resolvePackage(&InstallSpec{Namespace: pkg.Namespace, Path: sourcePath})
+	if err != nil {
+		return "", "", err
+	}
+	if pkgType != pkg.Type {
+		return "", "", fmt.Errorf("%s was moved to %s, which is a %s, not a %s", pkg.SourcePath, sourcePath, pkgType, pkg.Type)
+	}
+	originalName := extractPackageName(relPath, pkgType)
+	name := renamedName(pkg, originalName)
+
+	// Check the new name is free before the old version is uninstalled
+	installed, err := m.load()
+	if err != nil {
+		return "", "", err
+	}
+	others := &InstalledManifest{Packages: slices.DeleteFunc(slices.Clone(installed.Packages), func(p InstalledPackage) bool {
+		return p.Name == pkg.Name
+	})}
+	if _, err := m.resolveName(others, pkg.Namespace, originalName, pkgType, name); err != nil {
+		return "", "", fmt.Errorf("%s was renamed to %s: %w", pkg.SourcePath, sourcePath, err)
+	}
+	return sourcePath, name, nil
+}
+
+// movedSource returns the path, relative to the package root, a package's source
+// has in commit: its own path if it is still there, or the path it was renamed
+// to since the installed version. A hook whose script was replaced by one with
+// the same name and another extension (format.sh by format.py) counts as renamed.
+// Returns ErrSourceRemoved if the package is gone.
+func movedSource(localPath, root string, pkg *InstalledPackage, commit string) (string, error) {
+	// A skill is found by its SKILL.md, which moves with the directory
+	keyFile := path.Join(root, pkg.SourcePath)
+	if pkg.Type == repo.TypeSkill {
+		keyFile = path.Join(keyFile, "SKILL.md")
+	}
+	blobs, err := git.TreeBlobs(localPath, commit, keyFile)
+	if err != nil {
+		return "", fmt.Errorf("list files at %s: %w", commit, err)
+	}
+	if len(blobs) > 0 {
+		return pkg.SourcePath, nil
+	}
+	if pkg.Version.SHA == "" || pkg.Version.SHA == "unknown" {
+		return "", ErrSourceRemoved
+	}
+
+	changes, err := git.ListFileChanges(localPath, pkg.Version.SHA, commit)
+	if err != nil {
+		return "", fmt.Errorf("list changed files: %w", err)
+	}
+	moved := ""
+	for _, c := range changes {
+		if c.Status == 'R' && c.OldPath == keyFile {
+			moved = c.Path
+			break
+		}
+	}
+	if moved == "" && pkg.Type == repo.TypeHook {
+		stem := strings.TrimSuffix(keyFile, path.Ext(keyFile))
+		for _, c := range changes {
+			if c.Status == 'A' && c.Path != keyFile && strings.TrimSuffix(c.Path, path.Ext(c.Path)) == stem {
+				moved = c.Path
+				break
+			}
+		}
+	}
+	if moved == "" {
+		return "", ErrSourceRemoved
+	}
+
+	if pkg.Type == repo.TypeSkill {
+		moved = path.Dir(moved)
+	}
+	if root != "" {
+		moved = strings.TrimPrefix(moved, root+"/")
+	}
+	return moved, nil
+}
+
+// renamedName returns the name a package installed as pkg.Name is updated
+// under when its original name changes upstream: the installed name with the
+// new original name in place of the old one, or, for a name chosen by hand, the
+// same name (without a hook's old extension, as the new one is added).
+func renamedName(pkg *InstalledPackage, originalName string) string {
+	if strings.HasSuffix(pkg.Name, pkg.OriginalName) {
+		return strings.TrimSuffix(pkg.Name, pkg.OriginalName) + originalName
+	}
+	if pkg.Type == repo.TypeHook {
+		return strings.TrimSuffix(pkg.Name, filepath.Ext(pkg.OriginalName))
+	}
+	return pkg.Name
+}
+
+// skillDir returns the directory an installed skill's files are in, or "" if
+// the package is not a skill installed as one.
+func (p *InstalledPackage) skillDir() string {
+	if p.Type != repo.TypeSkill || p.Target != "" {
+		return ""
+	}
+	for _, f := range p.Files {
+		rel, err := filepath.Rel(filepath.FromSlash(p.SourcePath), f.Source)
+		if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		if dir, ok := strings.CutSuffix(f.Target, string(os.PathSeparator)+rel); ok {
+			return dir
+		}
+	}
+	return ""
+}
+
+// removeEmptyDirs removes the empty directories under root, deepest first, and
+// root itself if that leaves it empty.
+func removeEmptyDirs(root string) {
+	var dirs []string
+	_ = filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err == nil && d.IsDir() {
+			dirs = append(dirs, p)
+		}
+		return nil
+	})
+	for _, dir := range slices.Backward(dirs) {
+		_ = os.Remove(dir) // Fails if not empty
+	}
+}
+
+// Orphans returns the files where an installed package keeps its own that no
+// installed package owns: left behind by an earlier version of it, such as a
+// hook script with another extension, or added by hand. For a skill these are
+// the unowned files in its directory; for a hook, command, or agent, the unowned
+// files next to it with its name and another extension.
+func (m *Manager) Orphans(pkg *InstalledPackage) ([]string, error) {
+	installed, err := m.load()
+	if err != nil {
+		return nil, err
+	}
+	owned := make(map[string]bool)
+	for _, p := range installed.Packages {
+		for _, f := range p.Files {
+			owned[f.Target] = true
+		}
+	}
+
+	var orphans []string
+	if dir := pkg.skillDir(); dir != "" {
+		err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			if !d.IsDir() && !owned[p] {
+				orphans = append(orphans, p)
+			}
+			return nil
+		})
+		return orphans, err
+	}
+
+	if pkg.Type == repo.TypeSkill || pkg.Target != "" || len(pkg.Files) != 1 {
+		return nil, nil
+	}
+	target := pkg.Files[0].Target
+	stem := strings.TrimSuffix(filepath.Base(target), filepath.Ext(target))
+	entries, err := os.ReadDir(filepath.Dir(target))
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || (name != stem && !strings.HasPrefix(name, stem+".")) {
+			continue
+		}
+		if p := filepath.Join(filepath.Dir(target), name); !owned[p] {
+			orphans = append(orphans, p)
+		}
+	}
+	return orphans, nil
+}
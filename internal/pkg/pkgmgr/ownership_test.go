@@ -0,0 +1,94 @@
+package pkgmgr
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/itda-skills/jindo/internal/pkg/repo"
+)
+
+func TestRenamedName(t *testing.T) {
+	tests := []struct {
+		name, original, pkgType, renamed, want string
+	}{
+		{"demo--format.sh", "format.sh", "hook", "format.py", "demo--format.py"},
+		{"format.sh", "format.sh", "hook", "format.py", "format.py"},
+		{"lint", "format.sh", "hook", "format.py", "lint"},
+		{"lint.sh", "format.sh", "hook", "format.py", "lint"},
+		{"demo--tool", "tool", "skill", "tools", "demo--tools"},
+	}
+	for _, tt := range tests {
+		pkg := &InstalledPackage{Name: tt.name, OriginalName: tt.original, Type: repo.PackageType(tt.pkgType)}
+		if got := renamedName(pkg, tt.renamed); got != tt.want {
+			t.Errorf("renamedName(%s, %s) = %s, want %s", tt.name, tt.renamed, got, tt.want)
+		}
+	}
+}
+
+func TestOrphans(t *testing.T) {
+	repoDir := t.TempDir()
+	writeTestFile(t, filepath.Join(repoDir, "skills", "tool", "SKILL.md"), "# tool\n", 0644)
+	writeTestFile(t, filepath.Join(repoDir, "skills", "tool", "ref", "guide.md"), "guide\n", 0644)
+	writeTestFile(t, filepath.Join(repoDir, "hooks", "format.py"), "print(1)\n", 0755)
+
+	claudeDir := t.TempDir()
+	m := NewManager(t.TempDir())
+	skillFiles, err := m.installSkill(repoDir, "skills/tool", "demo--tool", claudeDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hookFiles, err := m.installHook(repoDir, "hooks/format.py", "demo--format.py", claudeDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	skill := InstalledPackage{Name: "demo--tool", Type: repo.TypeSkill, SourcePath: "skills/tool", Files: skillFiles}
+	hook := InstalledPackage{Name: "demo--format.py", Type: repo.TypeHook, SourcePath: "hooks/format.py", Files: hookFiles}
+	if err := m.save(&InstalledManifest{Packages: []InstalledPackage{skill, hook}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Left by an earlier version, added by hand, and another hook's script
+	notes := filepath.Join(claudeDir, "skills", "demo--tool", "notes.txt")
+	oldScript := filepath.Join(claudeDir, "hooks", "demo--format.sh")
+	writeTestFile(t, notes, "notes\n", 0644)
+	writeTestFile(t, oldScript, "echo\n", 0755)
+	writeTestFile(t, filepath.Join(claudeDir, "hooks", "demo--formatter.sh"), "echo\n", 0755)
+
+	for _, tt := range []struct {
+		pkg  InstalledPackage
+		want string
+	}{{skill, notes}, {hook, oldScript}} {
+		got, err := m.Orphans(&tt.pkg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 1 || got[0] != tt.want {
+			t.Errorf("Orphans(%s) = %v, want [%s]", tt.pkg.Name, got, tt.want)
+		}
+	}
+}
+
+func TestUninstallRemovesEmptySkillDirs(t *testing.T) {
+	repoDir := t.TempDir()
+	writeTestFile(t, filepath.Join(repoDir, "skills", "tool", "SKILL.md"), "# tool\n", 0644)
+	writeTestFile(t, filepath.Join(repoDir, "skills", "tool", "ref", "deep", "guide.md"), "guide\n", 0644)
+
+	claudeDir := t.TempDir()
+	m := NewManager(t.TempDir())
+	files, err := m.installSkill(repoDir, "skills/tool", "demo--tool", claudeDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg := InstalledPackage{Name: "demo--tool", Type: repo.TypeSkill, SourcePath: "skills/tool", Files: files}
+	if err := m.save(&InstalledManifest{Packages: []InstalledPackage{pkg}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.uninstall("demo--tool"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(claudeDir, "skills", "demo--tool")); !os.IsNotExist(err) {
+		t.Errorf("the skill directory is left after uninstall: %v", err)
+	}
+}
@@ -0,0 +1,31 @@
+package pkgmgr
+
+import "time"
+
+// Pin holds a package at the commit it is installed at: 'jd pkg update --apply'
+// then skips it. Updating the package by name moves it to the latest version and
+// drops the pin.
+func (m *Manager) Pin(name string) (*InstalledPackage, error) {
+	installed, err := m.load()
+	if err != nil {
+		return nil, err
+	}
+	for i := range installed.Packages {
+		pkg := &installed.Packages[i]
+		if pkg.Name != name {
+			continue
+		}
+		if pkg.Pin == nil {
+			ref := pkg.Version.SHA
+			if pkg.Version.Type == "tag" {
+				ref = pkg.Version.Ref
+			}
+			pkg.Pin = &PinInfo{Ref: ref, PinnedAt: time.Now().UTC()}
+			if err := m.save(installed); err != nil {
+				return nil, err
+			}
+		}
+		return pkg, nil
+	}
+	return nil, ErrPackageNotFound
+}
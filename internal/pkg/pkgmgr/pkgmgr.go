@@ -9,16 +9,18 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/events"
+	"github.com/itda-skills/jindo/internal/hook"
 	"github.com/itda-skills/jindo/internal/pkg/git"
 	"github.com/itda-skills/jindo/internal/pkg/repo"
+	"github.com/itda-skills/jindo/internal/progress"
 )
 
-const (
-	installedFileName = "installed.json"
-	namespaceSep      = "--"
-)
+const installedFileName = "installed.json"
 
 var (
 	// ErrPackageNotFound is returned when a package is not found.
@@ -27,6 +29,10 @@ var (
 	ErrPackageAlreadyInstalled = errors.New("package already installed")
 	// ErrInvalidSpec is returned when the install spec is invalid.
 	ErrInvalidSpec = errors.New("invalid package specification")
+	// ErrNameCollision is returned when the name a package would be installed under is taken.
+	ErrNameCollision = errors.New("package name collision")
+	// ErrContentMismatch is returned by Adopt when a resource matches no version of the package.
+	ErrContentMismatch = errors.New("content does not match any version of the package")
 )
 
 // installSpecRegex matches namespace:path[@version] format.
@@ -34,17 +40,59 @@ var installSpecRegex = regexp.MustCompile(`^([a-z0-9-]+):(.+?)(?:@(.+))?$`)
 
 // Manager manages installed packages.
 type Manager struct {
-	baseDir   string          // ~/.itda-skills (for metadata: installed.json, repos)
-	claudeDir string          // ~/.claude (for actual installed files)
+	baseDir   string // ~/.itda-skills (for metadata: installed.json, repos)
+	claudeDir string // ~/.claude (for actual installed files)
 	repoStore *repo.Store
+
+	target      string // Assistant packages are installed for when not Claude Code (see Targets)
+	projectRoot string // Project whose rules directory target packages are written to
+
+	only    []string // Patterns selecting the files of skills to install (nil: all)
+	channel string   // Channel packages are installed from (see Channels; "": the tracked branch)
+
+	naming          Naming            // Names packages are installed under
+	discardModified bool              // Uninstall without keeping copies of edited files
+	beforeOverwrite func(path string) // Called before an existing installed file is replaced
+	progress        progress.Reporter // Reports clone and copy progress
+	pulled          map[string]bool   // Namespaces already pulled by Update
 }
 
 // NewManager creates a new package manager.
 func NewManager(baseDir string) *Manager {
 	return &Manager{
 		baseDir:   baseDir,
-		claudeDir: "~/.claude",
+		claudeDir: basedir.ClaudeDir(),
 		repoStore: repo.NewStore(baseDir),
+		naming:    DefaultNaming,
+		progress:  progress.Discard,
+	}
+}
+
+// SetProgress sets the reporter for repository clones and skill file copies.
+func (m *Manager) SetProgress(r progress.Reporter) {
+	m.progress = r
+	m.repoStore.SetProgress(r)
+}
+
+// SetClaudeDir sets the directory packages are installed into (default ~/.claude).
+// Use a project's .claude directory to install packages locally.
+func (m *Manager) SetClaudeDir(dir string) {
+	m.claudeDir = dir
+}
+
+// SetBeforeOverwrite sets a function called with the path of each existing file
+// before install or update replaces it (e.g., to snapshot history).
+func (m *Manager) SetBeforeOverwrite(fn func(path string)) {
+	m.beforeOverwrite = fn
+}
+
+// notifyOverwrite calls the beforeOverwrite hook if path already exists.
+func (m *Manager) notifyOverwrite(path string) {
+	if m.beforeOverwrite == nil {
+		return
+	}
+	if _, err := os.Stat(path); err == nil {
+		m.beforeOverwrite(path)
 	}
 }
 
@@ -79,31 +127,43 @@ func (m *Manager) installedFilePath() (string, error) {
 	return filepath.Join(base, installedFileName), nil
 }
 
-// load loads the installed packages file.
-func (m *Manager) load() (*InstalledFile2, error) {
-	path, err := m.installedFilePath()
+// StateFiles returns the files that record what is installed: installed.json,
+// and the settings.json hook packages are registered in.
+func (m *Manager) StateFiles() ([]string, error) {
+	installedPath, err := m.installedFilePath()
 	if err != nil {
 		return nil, err
 	}
+	claudeDir, err := m.expandClaudeDir()
+	if err != nil {
+		return nil, err
+	}
+	return []string{installedPath, filepath.Join(claudeDir, "settings.json")}, nil
+}
 
-	data, err := os.ReadFile(path)
+// load loads the installed packages file, migrating it to SchemaVersion if it is older.
+// A migration that cannot be backed up or saved is an error, rather than being
+// tried again, silently, on every load.
+func (m *Manager) load() (*InstalledManifest, error) {
+	installed, data, err := m.read()
 	if err != nil {
-		if os.IsNotExist(err) {
-			return &InstalledFile2{Version: 1, Packages: []InstalledPackage{}}, nil
-		}
 		return nil, err
 	}
 
-	var installed InstalledFile2
-	if err := json.Unmarshal(data, &installed); err != nil {
-		return nil, fmt.Errorf("parse installed.json: %w", err)
+	if installed.Version > SchemaVersion {
+		return nil, fmt.Errorf("installed.json schema version %d is newer than this jd supports (%d); upgrade jd", installed.Version, SchemaVersion)
+	}
+	if data != nil && installed.Version < SchemaVersion {
+		if _, err := m.migrate(installed, data); err != nil {
+			return nil, fmt.Errorf("migrate installed.json: %w", err)
+		}
 	}
 
-	return &installed, nil
+	return installed, nil
 }
 
 // save saves the installed packages file.
-func (m *Manager) save(installed *InstalledFile2) error {
+func (m *Manager) save(installed *InstalledManifest) error {
 	baseDir, err := m.expandDir()
 	if err != nil {
 		return err
@@ -118,6 +178,7 @@ func (m *Manager) save(installed *InstalledFile2) error {
 		return err
 	}
 
+	installed.Version = SchemaVersion
 	data, err := json.MarshalIndent(installed, "", "  ")
 	if err != nil {
 		return fmt.Errorf("marshal installed.json: %w", err)
@@ -144,20 +205,6 @@ func ParseSpec(spec string) (*InstallSpec, error) {
 	}, nil
 }
 
-// MakeNamespacedName creates a namespaced name.
-func MakeNamespacedName(namespace, name string) string {
-	return namespace + namespaceSep + name
-}
-
-// ParseNamespacedName parses a namespaced name.
-func ParseNamespacedName(name string) (namespace, originalName string) {
-	parts := strings.SplitN(name, namespaceSep, 2)
-	if len(parts) == 2 {
-		return parts[0], parts[1]
-	}
-	return "", name
-}
-
 // determinePackageType determines the package type from the path.
 func determinePackageType(path string) repo.PackageType {
 	parts := strings.Split(path, "/")
@@ -179,31 +226,67 @@ func determinePackageType(path string) repo.PackageType {
 	}
 }
 
-// extractPackageName extracts the package name from the path.
-func extractPackageName(path string, pkgType repo.PackageType) string {
-	parts := strings.Split(path, "/")
-	if len(parts) < 2 {
-		return ""
-	}
-
+// extractPackageName extracts the package name from its path relative to the
+// layout directory it was found in (e.g., "<name>/SKILL.md" or "<name>.md").
+func extractPackageName(relPath string, pkgType repo.PackageType) string {
 	switch pkgType {
-	case repo.TypeSkill:
-		// skills/<name>/...
-		return parts[1]
+	case repo.TypeSkill, repo.TypeHook:
+		// skills/<name>/... or hooks/<name>
+		name, _, _ := strings.Cut(relPath, "/")
+		return name
 	case repo.TypeCommand, repo.TypeAgent:
-		// commands/<name>.md or agents/<name>.md
-		name := parts[1]
-		return strings.TrimSuffix(name, ".md")
-	case repo.TypeHook:
-		// hooks/<name>
-		return parts[1]
+		// commands/<name>.md or nested commands/<team>/<project>/<name>.md -> team:project:name
+		return strings.ReplaceAll(strings.TrimSuffix(relPath, ".md"), "/", ":")
 	default:
 		return ""
 	}
 }
 
-// Install installs a package from local repository clone.
+// markdownPackagePath returns the file path of a command or agent under dir.
+// Nested names map to subdirectories (e.g., ns--team:project:task -> ns--team/project/task.md).
+func markdownPackagePath(dir, namespacedName string) string {
+	return filepath.Join(dir, filepath.Join(strings.Split(namespacedName, ":")...)+".md")
+}
+
+// resolvePackage determines a spec's package type and its path relative to the layout
+// directory it is in, from the repository's scan layout, falling back to the standard
+// layout for paths browse does not list.
+func (m *Manager) resolvePackage(spec *InstallSpec) (repo.PackageType, string, error) {
+	pkgType := determinePackageType(spec.Path)
+	_, relPath, _ := strings.Cut(spec.Path, "/")
+	if item, err := m.repoStore.FindPackage(spec.Namespace, spec.Path); err == nil {
+		pkgType = item.Type
+		relPath = strings.TrimPrefix(spec.Path, item.Dir+"/")
+	}
+	if pkgType == "" {
+		return "", "", fmt.Errorf("cannot determine package type from path: %s", spec.Path)
+	}
+	return pkgType, relPath, nil
+}
+
+// SpecType returns the type of the package a spec refers to, without installing it.
+func (m *Manager) SpecType(specStr string) (repo.PackageType, error) {
+	spec, err := ParseSpec(specStr)
+	if err != nil {
+		return "", err
+	}
+	pkgType, _, err := m.resolvePackage(spec)
+	return pkgType, err
+}
+
+// Install installs a package from local repository clone, under the name the
+// manager's naming gives it.
 func (m *Manager) Install(specStr string) (*InstalledPackage, error) {
+	pkg, err := m.install(specStr, "")
+	if err == nil {
+		m.emit(events.Install, pkg, nil)
+	}
+	return pkg, err
+}
+
+// install installs a package from local repository clone under name, or under
+// the name the manager's naming gives it if name is empty.
+func (m *Manager) install(specStr, name string) (*InstalledPackage, error) {
 	spec, err := ParseSpec(specStr)
 	if err != nil {
 		return nil, err
@@ -219,30 +302,28 @@ func (m *Manager) Install(specStr string) (*InstalledPackage, error) {
 	if err != nil {
 		return nil, err
 	}
+	packageRoot, err := m.repoStore.PackageRoot(spec.Namespace)
+	if err != nil {
+		return nil, err
+	}
 
-	// Determine package type and name
-	pkgType := determinePackageType(spec.Path)
-	if pkgType == "" {
-		return nil, fmt.Errorf("cannot determine package type from path: %s", spec.Path)
+	pkgType, relPath, err := m.resolvePackage(spec)
+	if err != nil {
+		return nil, err
 	}
 
-	originalName := extractPackageName(spec.Path, pkgType)
+	originalName := extractPackageName(relPath, pkgType)
 	if originalName == "" {
 		return nil, fmt.Errorf("cannot extract package name from path: %s", spec.Path)
 	}
 
-	namespacedName := MakeNamespacedName(spec.Namespace, originalName)
-
-	// Check if already installed
 	installed, err := m.load()
 	if err != nil {
 		return nil, err
 	}
-
-	for _, pkg := range installed.Packages {
-		if pkg.Name == namespacedName {
-			return nil, ErrPackageAlreadyInstalled
-		}
+	namespacedName, err := m.resolveName(installed, spec.Namespace, originalName, pkgType, name)
+	if err != nil {
+		return nil, err
 	}
 
 	// Get current commit SHA from local repo
@@ -250,6 +331,22 @@ func (m *Manager) Install(specStr string) (*InstalledPackage, error) {
 	if err != nil {
 		currentSHA = "unknown"
 	}
+	version := VersionInfo{Type: "commit", SHA: currentSHA, Ref: repoConfig.TrackedBranch()}
+
+	// The stable channel installs the package as it is at the latest release
+	if m.channel == ChannelStable {
+		tag, sha, err := latestRelease(repoLocalPath, !repoConfig.Link)
+		if err != nil {
+			return nil, err
+		}
+		dir, root, err := exportRelease(repoLocalPath, repoConfig.Root, spec.Path, sha)
+		if err != nil {
+			return nil, fmt.Errorf("read %s at %s: %w", spec.Path, tag, err)
+		}
+		defer func() { _ = os.RemoveAll(dir) }()
+		packageRoot = root
+		version = VersionInfo{Type: "tag", SHA: sha, Ref: tag}
+	}
 
 	// Install files to ~/.claude directory
 	claudeDir, err := m.expandClaudeDir()
@@ -257,17 +354,23 @@ func (m *Manager) Install(specStr string) (*InstalledPackage, error) {
 		return nil, err
 	}
 
+	if len(m.only) > 0 && (pkgType != repo.TypeSkill || m.target != "") {
+		return nil, fmt.Errorf("%w: %s is not installed as a skill", ErrOnlySkills, spec.Path)
+	}
+
 	var files []InstalledFile
 
-	switch pkgType {
-	case repo.TypeSkill:
-		files, err = m.installSkill(repoLocalPath, spec.Path, namespacedName, claudeDir)
-	case repo.TypeCommand:
-		files, err = m.installCommand(repoLocalPath, spec.Path, namespacedName, claudeDir)
-	case repo.TypeAgent:
-		files, err = m.installAgent(repoLocalPath, spec.Path, namespacedName, claudeDir)
-	case repo.TypeHook:
-		files, err = m.installHook(repoLocalPath, spec.Path, namespacedName, claudeDir)
+	switch {
+	case m.target != "":
+		files, err = m.installRule(packageRoot, spec.Path, pkgType, namespacedName)
+	case pkgType == repo.TypeSkill:
+		files, err = m.installSkill(packageRoot, spec.Path, namespacedName, claudeDir)
+	case pkgType == repo.TypeCommand:
+		files, err = m.installCommand(packageRoot, spec.Path, namespacedName, claudeDir)
+	case pkgType == repo.TypeAgent:
+		files, err = m.installAgent(packageRoot, spec.Path, namespacedName, claudeDir)
+	case pkgType == repo.TypeHook:
+		files, err = m.installHook(packageRoot, spec.Path, namespacedName, claudeDir)
 	}
 
 	if err != nil {
@@ -281,14 +384,25 @@ func (m *Manager) Install(specStr string) (*InstalledPackage, error) {
 		Type:         pkgType,
 		Namespace:    spec.Namespace,
 		SourcePath:   spec.Path,
-		Version: VersionInfo{
-			Type: "commit",
-			SHA:  currentSHA,
-			Ref:  repoConfig.DefaultBranch,
-		},
-		Files:       files,
-		InstalledAt: now,
-		UpdatedAt:   now,
+		Version:      version,
+		Channel:      m.channel,
+		Files:        files,
+		Scope:        ScopeGlobal,
+		RepoURL:      repoConfig.URL,
+		Installer:    InstallerVersion,
+		InstalledAt:  now,
+		UpdatedAt:    now,
+	}
+	if m.target != "" {
+		pkg.Target = m.target
+		pkg.ProjectRoot = m.projectRoot
+		pkg.Scope = ScopeLocal
+	} else if m.claudeDir != basedir.ClaudeDir() {
+		pkg.ClaudeDir = claudeDir
+		pkg.Scope = ScopeLocal
+	}
+	if len(m.only) > 0 {
+		pkg.Only = m.only
 	}
 
 	installed.Packages = append(installed.Packages, pkg)
@@ -313,54 +427,183 @@ func (m *Manager) installSkill(repoLocalPath, path, namespacedName, baseDir stri
 		return nil, fmt.Errorf("create skill directory: %w", err)
 	}
 
+	// List the files first so that progress can be reported against the total size
+	skillFiles, err := listSkillFiles(repoLocalPath, srcDir)
+	skillFiles = m.selectSkillFiles(skillFiles)
+	var totalBytes int64
+	for _, f := range skillFiles {
+		totalBytes += f.size
+	}
+
 	var files []InstalledFile
+	if err == nil && len(skillFiles) > 0 {
+		m.progress.Start("Copying "+namespacedName, totalBytes, progress.Bytes)
+		files, err = m.copySkillFiles(destDir, path, skillFiles)
+		m.progress.Finish()
+	}
+
+	if err != nil {
+		_ = os.RemoveAll(destDir)
+		return nil, fmt.Errorf("copy skill files: %w", err)
+	}
+
+	if len(files) == 0 {
+		_ = os.RemoveAll(destDir)
+		return nil, fmt.Errorf("no files found in skill: %s", path)
+	}
+
+	return files, nil
+}
+
+// skillFile is a file of a skill package to copy
+type skillFile struct {
+	relPath string
+	src     string // path of the content to copy; "" for a link
+	link    string // target of a symlink to recreate as it is
+	size    int64
+}
+
+// listSkillFiles lists the files of the skill directory srcDir in the repository
+// at repoRoot. A symlink whose target is inside the skill is kept as a link; one
+// that points outside of it (or by an absolute path) is replaced by a copy of
+// what it points to, so the installed skill does not depend on the repository
+// clone. A symlink that leads out of the repository is refused, so a skill
+// cannot copy files such as ~/.ssh/id_rsa or /etc into the Claude directory.
+func listSkillFiles(repoRoot, srcDir string) ([]skillFile, error) {
+	repoRoot, err := filepath.EvalSymlinks(repoRoot)
+	if err != nil {
+		return nil, err
+	}
+	root, err := filepath.EvalSymlinks(srcDir)
+	if err != nil {
+		return nil, err
+	}
+	return listLinkedFiles(srcDir, repoRoot, []string{root})
+}
 
-	err := filepath.Walk(srcDir, func(srcPath string, info os.FileInfo, err error) error {
+// listLinkedFiles lists the files of dir as listSkillFiles does. repoRoot is the
+// resolved repository directory links may not leave, and within holds the
+// resolved directories being listed, to refuse a link to one of them, which
+// would copy a directory into itself.
+func listLinkedFiles(dir, repoRoot string, within []string) ([]skillFile, error) {
+	var files []skillFile
+	err := filepath.Walk(dir, func(srcPath string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
-
 		if info.IsDir() {
 			return nil
 		}
 
 		// Calculate relative path
-		relPath, err := filepath.Rel(srcDir, srcPath)
+		relPath, err := filepath.Rel(dir, srcPath)
 		if err != nil {
 			return err
 		}
+		if info.Mode()&os.ModeSymlink == 0 {
+			files = append(files, skillFile{relPath: relPath, src: srcPath, size: info.Size()})
+			return nil
+		}
 
-		destPath := filepath.Join(destDir, relPath)
+		link, err := os.Readlink(srcPath)
+		if err != nil {
+			return err
+		}
+		if linkWithin(dir, srcPath, link) {
+			files = append(files, skillFile{relPath: relPath, link: link})
+			return nil
+		}
+		resolved, err := filepath.EvalSymlinks(srcPath)
+		if err != nil {
+			return fmt.Errorf("symlink %s: %w", relPath, err)
+		}
+		if !pathWithin(repoRoot, resolved) {
+			return fmt.Errorf("symlink %s: links to %s, outside of the repository", relPath, link)
+		}
+		target, err := os.Stat(resolved)
+		if err != nil {
+			return fmt.Errorf("symlink %s: %w", relPath, err)
+		}
+		if !target.IsDir() {
+			files = append(files, skillFile{relPath: relPath, src: resolved, size: target.Size()})
+			return nil
+		}
+		for _, d := range within {
+			if pathWithin(resolved, d) {
+				return fmt.Errorf("symlink %s: links to a directory that contains it", relPath)
+			}
+		}
+		linked, err := listLinkedFiles(resolved, repoRoot, append(within, resolved))
+		if err != nil {
+			return err
+		}
+		for _, f := range linked {
+			f.relPath = filepath.Join(relPath, f.relPath)
+			files = append(files, f)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// linkWithin reports whether link, the target of the symlink at path, is a
+// relative path that stays inside dir.
+func linkWithin(dir, path, link string) bool {
+	if filepath.IsAbs(link) {
+		return false
+	}
+	return pathWithin(dir, filepath.Join(filepath.Dir(path), link))
+}
+
+// pathWithin reports whether path is dir or inside it.
+func pathWithin(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(os.PathSeparator))
+}
+
+// copySkillFiles copies skill files to destDir, reporting the bytes copied so far.
+func (m *Manager) copySkillFiles(destDir, path string, skillFiles []skillFile) ([]InstalledFile, error) {
+	var files []InstalledFile
+	var copied int64
+	for _, f := range skillFiles {
+		destPath := filepath.Join(destDir, f.relPath)
 
 		// Create parent directories
 		if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
-			return err
+			return nil, err
+		}
+
+		m.notifyOverwrite(destPath)
+		if f.link != "" {
+			// The file linked to is installed, and hashed, on its own
+			if err := copySymlink(f.link, destPath); err != nil {
+				return nil, err
+			}
+			files = append(files, InstalledFile{
+				Source: filepath.Join(path, f.relPath),
+				Target: destPath,
+			})
+			continue
 		}
 
 		// Copy file
-		if err := copyFile(srcPath, destPath); err != nil {
-			return err
+		if err := copyFile(f.src, destPath); err != nil {
+			return nil, err
 		}
 
+		sha, err := fileSHA256(destPath)
+		if err != nil {
+			return nil, err
+		}
 		files = append(files, InstalledFile{
-			Source: filepath.Join(path, relPath),
+			Source: filepath.Join(path, f.relPath),
 			Target: destPath,
-			SHA:    "", // Not tracking file SHA for local copies
+			SHA:    sha,
 		})
 
-		return nil
-	})
-
-	if err != nil {
-		_ = os.RemoveAll(destDir)
-		return nil, fmt.Errorf("copy skill files: %w", err)
-	}
-
-	if len(files) == 0 {
-		_ = os.RemoveAll(destDir)
-		return nil, fmt.Errorf("no files found in skill: %s", path)
+		copied += f.size
+		m.progress.Update(copied)
 	}
-
 	return files, nil
 }
 
@@ -373,15 +616,23 @@ func (m *Manager) installCommand(repoLocalPath, path, namespacedName, baseDir st
 		return nil, fmt.Errorf("create commands directory: %w", err)
 	}
 
-	destPath := filepath.Join(commandsDir, namespacedName+".md")
+	destPath := markdownPackagePath(commandsDir, namespacedName)
+	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+		return nil, fmt.Errorf("create command directory: %w", err)
+	}
+	m.notifyOverwrite(destPath)
 	if err := copyFile(srcPath, destPath); err != nil {
 		return nil, fmt.Errorf("copy command file: %w", err)
 	}
+	sha, err := fileSHA256(destPath)
+	if err != nil {
+		return nil, fmt.Errorf("hash command file: %w", err)
+	}
 
 	return []InstalledFile{{
 		Source: path,
 		Target: destPath,
-		SHA:    "",
+		SHA:    sha,
 	}}, nil
 }
 
@@ -394,15 +645,23 @@ func (m *Manager) installAgent(repoLocalPath, path, namespacedName, baseDir stri
 		return nil, fmt.Errorf("create agents directory: %w", err)
 	}
 
-	destPath := filepath.Join(agentsDir, namespacedName+".md")
+	destPath := markdownPackagePath(agentsDir, namespacedName)
+	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+		return nil, fmt.Errorf("create agent directory: %w", err)
+	}
+	m.notifyOverwrite(destPath)
 	if err := copyFile(srcPath, destPath); err != nil {
 		return nil, fmt.Errorf("copy agent file: %w", err)
 	}
+	sha, err := fileSHA256(destPath)
+	if err != nil {
+		return nil, fmt.Errorf("hash agent file: %w", err)
+	}
 
 	return []InstalledFile{{
 		Source: path,
 		Target: destPath,
-		SHA:    "",
+		SHA:    sha,
 	}}, nil
 }
 
@@ -419,11 +678,12 @@ func (m *Manager) installHook(repoLocalPath, path, namespacedName, baseDir strin
 	originalName := filepath.Base(path)
 	// Prepend namespace
 	destName := namespacedName
-	if ext := filepath.Ext(originalName); ext != "" {
+	if ext := filepath.Ext(originalName); ext != "" && !strings.HasSuffix(destName, ext) {
 		destName += ext
 	}
 
 	destPath := filepath.Join(hooksDir, destName)
+	m.notifyOverwrite(destPath)
 	if err := copyFile(srcPath, destPath); err != nil {
 		return nil, fmt.Errorf("copy hook file: %w", err)
 	}
@@ -432,34 +692,94 @@ func (m *Manager) installHook(repoLocalPath, path, namespacedName, baseDir strin
 	if err := os.Chmod(destPath, 0755); err != nil {
 		return nil, fmt.Errorf("make hook executable: %w", err)
 	}
+	sha, err := fileSHA256(destPath)
+	if err != nil {
+		return nil, fmt.Errorf("hash hook file: %w", err)
+	}
 
 	return []InstalledFile{{
 		Source: path,
 		Target: destPath,
-		SHA:    "",
+		SHA:    sha,
 	}}, nil
 }
 
-// copyFile copies a file from src to dest.
+// copyFile copies a file from src to dest, keeping its permissions so bundled
+// scripts stay executable. A symlink at dest is replaced rather than written through.
 func copyFile(src, dest string) error {
 	srcFile, err := os.Open(src)
 	if err != nil {
 		return err
 	}
 	defer func() { _ = srcFile.Close() }()
+	info, err := srcFile.Stat()
+	if err != nil {
+		return err
+	}
 
-	destFile, err := os.Create(dest)
+	if err := removeSymlink(dest); err != nil {
+		return err
+	}
+	destFile, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode().Perm())
 	if err != nil {
 		return err
 	}
 	defer func() { _ = destFile.Close() }()
 
-	_, err = io.Copy(destFile, srcFile)
-	return err
+	if _, err := io.Copy(destFile, srcFile); err != nil {
+		return err
+	}
+	// The mode given to OpenFile is masked by the umask, and an existing file keeps its own
+	return destFile.Chmod(info.Mode().Perm())
+}
+
+// copySymlink creates a symlink to link at dest, replacing a file there.
+func copySymlink(link, dest string) error {
+	if err := os.Remove(dest); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return os.Symlink(link, dest)
+}
+
+// removeSymlink removes path if it is a symlink.
+func removeSymlink(path string) error {
+	info, err := os.Lstat(path)
+	if err != nil || info.Mode()&os.ModeSymlink == 0 {
+		return nil
+	}
+	return os.Remove(path)
 }
 
-// Uninstall removes an installed package.
+// Uninstall removes an installed package. Files edited since installation are
+// copied to the trash first, unless SetDiscardModified is set.
 func (m *Manager) Uninstall(name string) error {
+	_, err := m.UninstallKeepingModified(name)
+	return err
+}
+
+// UninstallKeepingModified removes an installed package as Uninstall does, and
+// returns the trash directory its edited files were copied to, or "" if none were.
+func (m *Manager) UninstallKeepingModified(name string) (string, error) {
+	pkg, err := m.Get(name)
+	if err != nil {
+		return "", err
+	}
+	var kept string
+	if !m.discardModified {
+		if kept, err = m.KeepModified(pkg); err != nil {
+			return "", err
+		}
+	}
+	if err := m.uninstall(name); err != nil {
+		return kept, err
+	}
+	m.emit(events.Uninstall, pkg, nil)
+	return kept, nil
+}
+
+// uninstall removes an installed package, as Uninstall does without recording
+// an event (Update records the reinstall as one update)
+func (m *Manager) uninstall(name string) error {
 	installed, err := m.load()
 	if err != nil {
 		return err
@@ -479,12 +799,35 @@ func (m *Manager) Uninstall(name string) error {
 		return ErrPackageNotFound
 	}
 
+	// Remove the settings.json rule before the script it runs
+	if pkg.Hook != nil {
+		if err := unregisterHook(pkg.Hook); err != nil {
+			return err
+		}
+	}
+
 	// Remove files
 	for _, f := range pkg.Files {
 		_ = os.Remove(f.Target)
 	}
 
-	// For skills, remove the directory
+	// For nested commands and agents, remove the now-empty namespace directories
+	if pkg.Type == repo.TypeCommand || pkg.Type == repo.TypeAgent {
+		for _, f := range pkg.Files {
+			dir := filepath.Dir(f.Target)
+			for range strings.Count(pkg.Name, ":") {
+				if os.Remove(dir) != nil {
+					break // Not empty
+				}
+				dir = filepath.Dir(dir)
+			}
+		}
+	}
+
+	// For skills, remove the directories its files leave empty, then the directory
+	if dir := pkg.skillDir(); dir != "" {
+		removeEmptyDirs(dir)
+	}
 	if pkg.Type == repo.TypeSkill {
 		baseDir, err := m.expandDir()
 		if err == nil {
@@ -508,6 +851,21 @@ func (m *Manager) List() ([]InstalledPackage, error) {
 	return installed.Packages, nil
 }
 
+// ListNamespace returns the installed packages from a namespace.
+func (m *Manager) ListNamespace(namespace string) ([]InstalledPackage, error) {
+	packages, err := m.List()
+	if err != nil {
+		return nil, err
+	}
+	var result []InstalledPackage
+	for _, p := range packages {
+		if p.Namespace == namespace {
+			result = append(result, p)
+		}
+	}
+	return result, nil
+}
+
 // Get returns an installed package by name.
 func (m *Manager) Get(name string) (*InstalledPackage, error) {
 	installed, err := m.load()
@@ -526,14 +884,35 @@ func (m *Manager) Get(name string) (*InstalledPackage, error) {
 
 // CheckUpdates checks for updates for installed packages.
 func (m *Manager) CheckUpdates(names ...string) ([]UpdateInfo, error) {
-	installed, err := m.load()
+	infos, err := m.CheckAll(names...)
 	if err != nil {
 		return nil, err
 	}
 
 	var results []UpdateInfo
+	for _, info := range infos {
+		// Skip packages that fail to check
+		if info.Err == nil {
+			results = append(results, info)
+		}
+	}
+
+	return results, nil
+}
+
+// CheckAll checks installed packages for updates, optionally filtered by name.
+// Unlike CheckUpdates, packages that fail to check are included with Err set.
+// Each repository is fetched once, concurrently, however many of its packages are checked.
+func (m *Manager) CheckAll(names ...string) ([]UpdateInfo, error) {
+	installed, err := m.load()
+	if err != nil {
+		return nil, err
+	}
+
+	var packages []*InstalledPackage
+	for i := range installed.Packages {
+		pkg := &installed.Packages[i]
 
-	for _, pkg := range installed.Packages {
 		// Filter by names if provided
 		if len(names) > 0 {
 			found := false
@@ -547,11 +926,24 @@ func (m *Manager) CheckUpdates(names ...string) ([]UpdateInfo, error) {
 				continue
 			}
 		}
+		packages = append(packages, pkg)
+	}
 
-		info, err := m.checkPackageUpdate(&pkg)
+	var namespaces []string
+	releases := make(map[string]bool)
+	for _, pkg := range packages {
+		if pkg.Source == "" {
+			namespaces = append(namespaces, pkg.Namespace)
+			releases[pkg.Namespace] = releases[pkg.Namespace] || pkg.stable()
+		}
+	}
+	heads := m.fetchRepoHeads(namespaces, releases)
+
+	var results []UpdateInfo
+	for _, pkg := range packages {
+		info, err := m.checkPackageUpdate(pkg, heads[pkg.Namespace])
 		if err != nil {
-			// Skip packages that fail to check
-			continue
+			info = &UpdateInfo{Package: pkg, CurrentSHA: pkg.Version.SHA, Err: err}
 		}
 
 		results = append(results, *info)
@@ -560,28 +952,118 @@ func (m *Manager) CheckUpdates(names ...string) ([]UpdateInfo, error) {
 	return results, nil
 }
 
-// checkPackageUpdate checks for updates for a single package.
-func (m *Manager) checkPackageUpdate(pkg *InstalledPackage) (*UpdateInfo, error) {
-	repoLocalPath, err := m.repoStore.RepoLocalPath(pkg.Namespace)
-	if err != nil {
-		return nil, err
+// maxConcurrentFetches limits the repositories fetched at once by CheckAll
+const maxConcurrentFetches = 4
+
+// repoHead is the latest commit of a repository, as fetched by fetchRepoHeads
+type repoHead struct {
+	localPath string
+	config    *repo.RepoConfig
+	latestRef string // Ref changed files are listed against
+	latestSHA string
+	err       error
+
+	release    string // Highest release tag, for packages on the stable channel
+	releaseSHA string
+	releaseErr error
+}
+
+// fetchRepoHeads fetches each distinct namespace once, concurrently, and returns the
+// latest commit of each, and its latest release for the namespaces releases are
+// needed of. Linked repositories are read from their live checkout.
+func (m *Manager) fetchRepoHeads(namespaces []string, releases map[string]bool) map[string]*repoHead {
+	heads := make(map[string]*repoHead)
+	for _, ns := range namespaces {
+		if _, ok := heads[ns]; ok {
+			continue
+		}
+		head := &repoHead{}
+		heads[ns] = head
+
+		head.localPath, head.err = m.repoStore.RepoLocalPath(ns)
+		if head.err == nil {
+			head.config, head.err = m.repoStore.Get(ns)
+		}
 	}
 
-	repoConfig, err := m.repoStore.Get(pkg.Namespace)
-	if err != nil {
-		return nil, err
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxConcurrentFetches)
+	for _, head := range heads {
+		if head.err != nil {
+			continue
+		}
+		wg.Add(1)
+		go func(head *repoHead) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			// The latest release is read after the head, unless fetching the head failed
+			if releases[head.config.Namespace] {
+				defer func() {
+					if head.err == nil {
+						head.release, head.releaseSHA, head.releaseErr = latestRelease(head.localPath, !head.config.Link)
+					}
+				}()
+			}
+
+			// Linked repositories are compared against the live checkout
+			if head.config.Link {
+				head.latestRef = "HEAD"
+				head.latestSHA, head.err = git.GetCurrentCommit(head.localPath)
+				return
+			}
+
+			// Fetch latest changes
+			if head.err = git.Fetch(head.localPath); head.err != nil {
+				return
+			}
+
+			// Get remote commit
+			head.latestRef = "origin/" + head.config.TrackedBranch()
+			head.latestSHA, head.err = git.GetRemoteCommit(head.localPath, head.config.TrackedBranch())
+		}(head)
 	}
+	wg.Wait()
 
-	// Fetch latest changes
-	if err := git.Fetch(repoLocalPath); err != nil {
-		return nil, err
+	return heads
+}
+
+// checkPackageUpdate checks for updates for a single package against the latest
+// commit of its repository (nil for packages installed from an archive).
+func (m *Manager) checkPackageUpdate(pkg *InstalledPackage, head *repoHead) (*UpdateInfo, error) {
+	// Packages imported from a plugin are compared by the checksum of their component
+	if pkg.Version.Type == PluginVersionType {
+		latestSHA, err := m.latestPluginSHA(pkg)
+		if err != nil {
+			return nil, err
+		}
+		return &UpdateInfo{
+			Package:    pkg,
+			CurrentSHA: pkg.Version.SHA,
+			LatestSHA:  latestSHA,
+			HasUpdate:  pkg.Version.SHA != latestSHA,
+		}, nil
 	}
 
-	// Get remote commit
-	latestSHA, err := git.GetRemoteCommit(repoLocalPath, repoConfig.DefaultBranch)
-	if err != nil {
-		return nil, err
+	// Packages installed from an archive are compared by archive checksum
+	if pkg.Source != "" {
+		latestSHA, err := archiveSHA256(pkg.Source)
+		if err != nil {
+			return nil, err
+		}
+		return &UpdateInfo{
+			Package:    pkg,
+			CurrentSHA: pkg.Version.SHA,
+			LatestSHA:  latestSHA,
+			HasUpdate:  pkg.Version.SHA != latestSHA,
+		}, nil
+	}
+
+	if head.err != nil {
+		return nil, head.err
 	}
+	repoLocalPath, repoConfig, latestRef, latestSHA := head.localPath, head.config, head.latestRef, head.latestSHA
 
 	info := &UpdateInfo{
 		Package:    pkg,
@@ -590,12 +1072,34 @@ func (m *Manager) checkPackageUpdate(pkg *InstalledPackage) (*UpdateInfo, error)
 		HasUpdate:  pkg.Version.SHA != latestSHA,
 	}
 
+	// Packages on the stable channel move from release to release
+	if pkg.stable() {
+		if head.releaseErr != nil {
+			return nil, head.releaseErr
+		}
+		latestRef = head.releaseSHA
+		info.LatestSHA = head.releaseSHA
+		info.LatestRef = head.release
+		info.HasUpdate = pkg.Version.SHA != head.releaseSHA
+	}
+
 	if info.HasUpdate {
-		// Get changed files
-		changedFiles, err := git.ListChangedFiles(repoLocalPath, pkg.Version.SHA, "origin/"+repoConfig.DefaultBranch)
+		// Get changed files (git paths are relative to the clone, not the package root)
+		sourcePath := pkg.SourcePath
+		if repoConfig.Root != "" {
+			sourcePath = repoConfig.Root + "/" + sourcePath
+		}
+		inSource := func(f string) bool { return f == sourcePath || strings.HasPrefix(f, sourcePath+"/") }
+		changes, err := git.ListFileChanges(repoLocalPath, pkg.Version.SHA, latestRef)
 		if err == nil {
-			for _, f := range changedFiles {
-				if strings.HasPrefix(f, pkg.SourcePath) {
+			for _, c := range changes {
+				// A file renamed away from the package changes it as well
+				f := c.Path
+				if !inSource(f) && c.OldPath != "" {
+					f = c.OldPath
+				}
+				// Files left out of a skill installed in part do not change it
+				if inSource(f) && MatchOnly(pkg.Only, strings.TrimPrefix(f, sourcePath+"/")) {
 					info.ChangedFiles = append(info.ChangedFiles, f)
 				}
 			}
@@ -605,31 +1109,177 @@ func (m *Manager) checkPackageUpdate(pkg *InstalledPackage) (*UpdateInfo, error)
 	return info, nil
 }
 
-// Update updates a package to the latest version.
+// UpdateDiff returns the changes an update brings to a package's files as a
+// unified diff. Packages installed from an archive have no history to diff.
+func (m *Manager) UpdateDiff(info *UpdateInfo) (string, error) {
+	pkg := info.Package
+	if pkg.Version.Type == PluginVersionType {
+		return "", fmt.Errorf("%s was imported from plugin %s and has no history to diff", pkg.Name, pkg.Bundle)
+	}
+	if pkg.Source != "" {
+		return "", fmt.Errorf("%s was installed from an archive and has no history to diff", pkg.Name)
+	}
+	repoConfig, err := m.repoStore.Get(pkg.Namespace)
+	if err != nil {
+		return "", err
+	}
+	localPath, err := m.repoStore.RepoLocalPath(pkg.Namespace)
+	if err != nil {
+		return "", err
+	}
+
+	// git paths are relative to the clone, not the package root
+	sourcePath := pkg.SourcePath
+	if repoConfig.Root != "" {
+		sourcePath = repoConfig.Root + "/" + sourcePath
+	}
+	return git.Diff(localPath, info.CurrentSHA, info.LatestSHA, sourcePath)
+}
+
+// Update updates a package to the latest version. A package renamed in its
+// repository is followed and installed under a name that follows the rename;
+// the files of the old version are removed either way.
 func (m *Manager) Update(name string) (*InstalledPackage, error) {
-	pkg, err := m.Get(name)
+	prev, err := m.Get(name)
 	if err != nil {
 		return nil, err
 	}
+	updated, err := m.update(name)
+	if err == nil {
+		meta := map[string]any{"previous_version": prev.Version.SHA}
+		if updated.Name != prev.Name {
+			meta["previous_name"] = prev.Name
+		}
+		m.emit(events.Update, updated, meta)
+	}
+	return updated, err
+}
 
-	// Pull latest changes in the repo first
-	repoLocalPath, err := m.repoStore.RepoLocalPath(pkg.Namespace)
+// update updates a package to the latest version without recording an event
+func (m *Manager) update(name string) (*InstalledPackage, error) {
+	pkg, err := m.Get(name)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := git.Pull(repoLocalPath); err != nil {
-		return nil, fmt.Errorf("pull latest changes: %w", err)
+	// Installed files are removed before reinstalling
+	for _, f := range pkg.Files {
+		m.notifyOverwrite(f.Target)
+	}
+
+	// The rule of a registered hook is created again where it was among the
+	// rules of its event, and keeps the checksum its script was pinned with:
+	// 'jd hooks verify' reports the update as a change until it is accepted,
+	// also when the script was renamed
+	var renamedScripts map[string]string
+	hookAt := hookPosition(pkg.Hook)
+	if pkg.Hook != nil {
+		store := hook.NewStore(pkg.Hook.SettingsPath)
+		if pins, err := store.Pins(pkg.Hook.Command); err == nil {
+			defer func() { _ = store.RestorePins(movePins(pins, renamedScripts)) }()
+		}
+	}
+
+	// Packages imported from a plugin are reimported from the same plugin
+	if pkg.Version.Type == PluginVersionType {
+		return m.updatePlugin(pkg, hookAt)
+	}
+
+	// Packages installed from an archive are reinstalled from the same source
+	if pkg.Source != "" {
+		if err := m.uninstall(name); err != nil {
+			return nil, fmt.Errorf("uninstall old version: %w", err)
+		}
+		if pkg.ClaudeDir != "" {
+			m.SetClaudeDir(pkg.ClaudeDir)
+			defer m.SetClaudeDir(basedir.ClaudeDir())
+		}
+		updated, err := m.installArchive(pkg.Source, pkg.Namespace, pkg.Name)
+		if err != nil {
+			return nil, err
+		}
+		return m.finishUpdate(updated, pkg, hookAt)
+	}
+
+	// Pull latest changes in the repo first (linked repositories are already live),
+	// once per repository when several of its packages are updated
+	if !m.pulled[pkg.Namespace] {
+		if err := m.repoStore.Update(pkg.Namespace); err != nil {
+			return nil, fmt.Errorf("pull latest changes: %w", err)
+		}
+		if m.pulled == nil {
+			m.pulled = make(map[string]bool)
+		}
+		m.pulled[pkg.Namespace] = true
+	}
+
+	// Follow the package if it was renamed upstream, and keep it if it was
+	// removed, before the old version is uninstalled
+	sourcePath, newName, err := m.followSource(pkg)
+	if err != nil {
+		return nil, err
 	}
 
 	// Uninstall old version
-	if err := m.Uninstall(name); err != nil {
+	if err := m.uninstall(name); err != nil {
 		return nil, fmt.Errorf("uninstall old version: %w", err)
 	}
 
-	// Reinstall
-	spec := fmt.Sprintf("%s:%s", pkg.Namespace, pkg.SourcePath)
-	return m.Install(spec)
+	// Reinstall into the same directory it was installed to, for the same assistant
+	if pkg.ClaudeDir != "" {
+		m.SetClaudeDir(pkg.ClaudeDir)
+		defer m.SetClaudeDir(basedir.ClaudeDir())
+	}
+	if pkg.Target != "" {
+		m.SetTarget(pkg.Target, pkg.ProjectRoot)
+		defer m.SetTarget("", "")
+	}
+	// Reinstall from the same channel, unless SwitchChannel picked another
+	if m.channel == "" && pkg.Channel != "" {
+		m.SetChannel(pkg.Channel)
+		defer m.SetChannel("")
+	}
+	// Install the same files of a skill installed in part
+	if len(pkg.Only) > 0 {
+		m.SetOnly(pkg.Only)
+		defer m.SetOnly(nil)
+	}
+	// Keep the name it was installed under, whatever the naming is now
+	spec := fmt.Sprintf("%s:%s", pkg.Namespace, sourcePath)
+	updated, err := m.install(spec, newName)
+	if err != nil {
+		return nil, err
+	}
+	if updated.Type == repo.TypeHook && len(updated.Files) == 1 && len(pkg.Files) == 1 {
+		renamedScripts = map[string]string{pkg.Files[0].Target: updated.Files[0].Target}
+	}
+	return m.finishUpdate(updated, pkg, hookAt)
+}
+
+// finishUpdate keeps the install time of the old version in a reinstalled package,
+// and registers its hook again, at index hookAt among the event's rules, if the
+// old version's hook was registered.
+func (m *Manager) finishUpdate(updated, prev *InstalledPackage, hookAt int) (*InstalledPackage, error) {
+	if !prev.InstalledAt.IsZero() {
+		installed, err := m.load()
+		if err != nil {
+			return nil, err
+		}
+		for i := range installed.Packages {
+			if installed.Packages[i].Name == updated.Name {
+				installed.Packages[i].InstalledAt = prev.InstalledAt
+				updated = &installed.Packages[i]
+				break
+			}
+		}
+		if err := m.save(installed); err != nil {
+			return nil, err
+		}
+	}
+	if prev.Hook == nil {
+		return updated, nil
+	}
+	return m.reregisterHook(updated, prev.Hook, hookAt)
 }
 
 // RepoStore returns the repository store.
@@ -0,0 +1,592 @@
+package pkgmgr
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/itda-skills/jindo/internal/basedir"
+	"github.com/itda-skills/jindo/internal/events"
+	"github.com/itda-skills/jindo/internal/hook"
+	"github.com/itda-skills/jindo/internal/pkg/git"
+	"github.com/itda-skills/jindo/internal/pkg/repo"
+	"github.com/itda-skills/jindo/internal/plugin"
+)
+
+// PluginVersionType is the version type of packages imported from a Claude Code plugin.
+// Their Bundle is the plugin's name, and Source where the plugin was imported from.
+const PluginVersionType = "plugin"
+
+// ErrPluginNotFound is returned when a plugin asked for is not at the import source.
+var ErrPluginNotFound = errors.New("plugin not found")
+
+// PluginImportOptions are the options of ImportPlugin.
+type PluginImportOptions struct {
+	Namespace    string   // Namespace of the packages (default: the plugin's name)
+	Plugins      []string // Plugins of a marketplace to import (default: all)
+	SettingsPath string   // settings.json to register hooks in (empty: not registered)
+
+	// ConfirmHooks is called with the hook commands of a plugin before its hook
+	// scripts are imported, which are skipped if it returns false (nil: imported)
+	ConfirmHooks func(plugin string, commands []string) bool
+}
+
+// PluginImport is the result of importing one plugin.
+type PluginImport struct {
+	Plugin   string              // Plugin name
+	Version  string              // Plugin version, if its manifest has one
+	Packages []*InstalledPackage // A package per skill, command, agent, and hook script
+	Skipped  []string            // Components that were not imported, and why
+}
+
+// pluginComponent is a skill, command, agent, or hook script of a plugin, as a package
+type pluginComponent struct {
+	Type repo.PackageType
+	Name string // Original name of the package
+	Path string // Relative to the plugin root, slash-separated
+
+	// Hook rule running the script, for hooks
+	EventType string
+	Matcher   string
+	Command   string // Command as in the plugin
+	Field     string // Word of Command naming the script
+}
+
+// ImportPlugin installs the skills, commands, agents, and hook scripts of a Claude
+// Code plugin as packages, or of each plugin of a marketplace. The source is a
+// plugin or marketplace directory, a .tar.gz/.tgz/.zip archive (path or URL), or
+// a git repository (gh:owner/repo or a clone URL). Packages record the source and
+// the plugin they came from, so Update reimports them from it.
+//
+// Hooks running a script in the plugin install the script, registered in
+// SettingsPath with the rule's event and matcher. Components that cannot be
+// imported, such as hooks running no script of the plugin and packages already
+// installed, are reported as skipped.
+func (m *Manager) ImportPlugin(source string, opts PluginImportOptions) ([]PluginImport, error) {
+	src, err := m.openPluginSource(source)
+	if err != nil {
+		return nil, err
+	}
+	defer src.close()
+
+	plugins, skipped, err := src.plugins(opts.Plugins)
+	if err != nil {
+		return nil, err
+	}
+
+	results := skipped
+	for _, p := range plugins {
+		result := PluginImport{Plugin: p.Manifest.Name, Version: p.Manifest.Version}
+		namespace := opts.Namespace
+		if namespace == "" {
+			namespace = p.Manifest.Name
+		}
+		// The namespace is part of the installed names, and so of their paths
+		if issue := repo.NamespaceIssue(namespace); issue != "" {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("invalid namespace %q: %s (choose one with --namespace)", namespace, issue))
+			results = append(results, result)
+			continue
+		}
+
+		components, notes := pluginComponents(p)
+		result.Skipped = append(result.Skipped, notes...)
+		if !confirmPluginHooks(p.Manifest.Name, components, opts.ConfirmHooks) {
+			var kept []pluginComponent
+			for _, c := range components {
+				if c.Type != repo.TypeHook {
+					kept = append(kept, c)
+					continue
+				}
+				result.Skipped = append(result.Skipped, fmt.Sprintf("%s hook %q: not confirmed", c.EventType, c.Command))
+			}
+			components = kept
+		}
+		for _, c := range components {
+			pkg, err := m.installPluginComponent(p, c, namespace, src.source, "")
+			switch {
+			case errors.Is(err, ErrPackageAlreadyInstalled):
+				result.Skipped = append(result.Skipped, fmt.Sprintf("%s %s: already installed", c.Type, c.Name))
+				continue
+			case errors.Is(err, ErrNameCollision):
+				result.Skipped = append(result.Skipped, fmt.Sprintf("%s %s: %v", c.Type, c.Name, err))
+				continue
+			case err != nil:
+				return append(results, result), fmt.Errorf("import %s %s: %w", c.Type, c.Name, err)
+			}
+
+			if c.Type == repo.TypeHook && opts.SettingsPath != "" {
+				if pkg, err = m.registerPluginHook(pkg, c, opts.SettingsPath, -1); err != nil {
+					return append(results, result), err
+				}
+			}
+			m.emit(events.Install, pkg, map[string]any{"plugin": p.Manifest.Name})
+			result.Packages = append(result.Packages, pkg)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// confirmPluginHooks asks confirm whether to import the hook scripts of a plugin, if it has any
+func confirmPluginHooks(name string, components []pluginComponent, confirm func(string, []string) bool) bool {
+	if confirm == nil {
+		return true
+	}
+	var commands []string
+	for _, c := range components {
+		if c.Type == repo.TypeHook {
+			commands = append(commands, fmt.Sprintf("%s: %s", c.EventType, c.Command))
+		}
+	}
+	return len(commands) == 0 || confirm(name, commands)
+}
+
+// pluginComponents returns the components of a plugin that can be installed as
+// packages, and notes on the hooks that cannot
+func pluginComponents(p *plugin.Plugin) ([]pluginComponent, []string) {
+	var components []pluginComponent
+	for _, r := range p.Skills {
+		components = append(components, pluginComponent{Type: repo.TypeSkill, Name: r.Name, Path: r.Path})
+	}
+	for _, r := range p.Commands {
+		components = append(components, pluginComponent{Type: repo.TypeCommand, Name: r.Name, Path: r.Path})
+	}
+	for _, r := range p.Agents {
+		components = append(components, pluginComponent{Type: repo.TypeAgent, Name: r.Name, Path: r.Path})
+	}
+
+	var notes []string
+	scripts := make(map[string]bool)
+	for _, h := range p.Hooks {
+		for _, command := range h.Commands {
+			rel, field, ok := plugin.ScriptPath(command)
+			if ok {
+				if info, err := os.Stat(filepath.Join(p.Dir, filepath.FromSlash(rel))); err != nil || info.IsDir() {
+					ok = false
+				}
+			}
+			switch {
+			case !ok:
+				notes = append(notes, fmt.Sprintf("%s hook %q: runs no script in the plugin", h.EventType, command))
+			case scripts[rel]:
+				notes = append(notes, fmt.Sprintf("%s hook %q: %s is already imported for another hook", h.EventType, command, rel))
+			default:
+				scripts[rel] = true
+				components = append(components, pluginComponent{
+					Type:      repo.TypeHook,
+					Name:      path.Base(rel),
+					Path:      rel,
+					EventType: h.EventType,
+					Matcher:   h.Matcher,
+					Command:   command,
+					Field:     field,
+				})
+			}
+		}
+	}
+	return components, notes
+}
+
+// installPluginComponent installs a component of a plugin as a package, under
+// installName, or under the name the manager's naming gives it if installName is empty.
+func (m *Manager) installPluginComponent(p *plugin.Plugin, c pluginComponent, namespace, source, installName string) (*InstalledPackage, error) {
+	installed, err := m.load()
+	if err != nil {
+		return nil, err
+	}
+	namespacedName, err := m.resolveName(installed, namespace, c.Name, c.Type, installName)
+	if err != nil {
+		return nil, err
+	}
+
+	claudeDir, err := m.expandClaudeDir()
+	if err != nil {
+		return nil, err
+	}
+
+	sha, err := componentSHA(p.Dir, c)
+	if err != nil {
+		return nil, fmt.Errorf("hash %s: %w", c.Path, err)
+	}
+
+	var files []InstalledFile
+	switch c.Type {
+	case repo.TypeSkill:
+		files, err = m.installSkill(p.Dir, c.Path, namespacedName, claudeDir)
+	case repo.TypeCommand:
+		files, err = m.installCommand(p.Dir, c.Path, namespacedName, claudeDir)
+	case repo.TypeAgent:
+		files, err = m.installAgent(p.Dir, c.Path, namespacedName, claudeDir)
+	case repo.TypeHook:
+		files, err = m.installHook(p.Dir, c.Path, namespacedName, claudeDir)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ref := p.Manifest.Name
+	if p.Manifest.Version != "" {
+		ref += "@" + p.Manifest.Version
+	}
+	now := time.Now().UTC()
+	pkg := InstalledPackage{
+		Name:         namespacedName,
+		OriginalName: c.Name,
+		Type:         c.Type,
+		Namespace:    namespace,
+		SourcePath:   c.Path,
+		Source:       source,
+		Version: VersionInfo{
+			Type: PluginVersionType,
+			SHA:  sha,
+			Ref:  ref,
+		},
+		Files:       files,
+		Scope:       ScopeGlobal,
+		Bundle:      p.Manifest.Name,
+		Installer:   InstallerVersion,
+		InstalledAt: now,
+		UpdatedAt:   now,
+	}
+	if m.claudeDir != basedir.ClaudeDir() {
+		pkg.ClaudeDir = claudeDir
+		pkg.Scope = ScopeLocal
+	}
+
+	installed.Packages = append(installed.Packages, pkg)
+	if err := m.save(installed); err != nil {
+		// Try to clean up installed files
+		for _, f := range files {
+			_ = os.RemoveAll(f.Target)
+		}
+		return nil, err
+	}
+	return &pkg, nil
+}
+
+// registerPluginHook adds the settings.json rule of an imported hook script,
+// running it with the arguments the plugin's rule gives it, before the rule at
+// index at (after the last one if at is negative)
+func (m *Manager) registerPluginHook(pkg *InstalledPackage, c pluginComponent, settingsPath string, at int) (*InstalledPackage, error) {
+	script := pkg.Files[0].Target
+	if strings.ContainsAny(c.Field, `"'`) {
+		script = `"` + script + `"`
+	}
+	command := strings.Replace(c.Command, c.Field, script, 1)
+	meta := &hook.ScriptMeta{EventType: hook.EventType(c.EventType), Matcher: c.Matcher}
+	return m.registerHook(pkg.Name, settingsPath, meta, command, at)
+}
+
+// componentSHA returns a checksum of a component's files, and for hooks of the
+// rule running it, which changes when the plugin changes the component
+func componentSHA(dir string, c pluginComponent) (string, error) {
+	h := sha256.New()
+	root := filepath.Join(dir, filepath.FromSlash(c.Path))
+
+	var files []string
+	err := filepath.WalkDir(root, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			files = append(files, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		rel, _ := filepath.Rel(root, file)
+		fmt.Fprintf(h, "%s\x00", filepath.ToSlash(rel))
+		f, err := os.Open(file)
+		if err != nil {
+			return "", err
+		}
+		_, err = io.Copy(h, f)
+		_ = f.Close()
+		if err != nil {
+			return "", err
+		}
+	}
+	if c.Type == repo.TypeHook {
+		fmt.Fprintf(h, "\x00%s\x00%s\x00%s", c.EventType, c.Matcher, c.Command)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// findPluginComponent opens the source of a package imported from a plugin and
+// returns the component it was installed from. The caller closes the source.
+func (m *Manager) findPluginComponent(pkg *InstalledPackage) (*pluginSource, *plugin.Plugin, pluginComponent, error) {
+	src, err := m.openPluginSource(pkg.Source)
+	if err != nil {
+		return nil, nil, pluginComponent{}, err
+	}
+	plugins, _, err := src.plugins([]string{pkg.Bundle})
+	if err != nil {
+		src.close()
+		return nil, nil, pluginComponent{}, err
+	}
+	if len(plugins) == 1 {
+		components, _ := pluginComponents(plugins[0])
+		for _, c := range components {
+			if c.Type == pkg.Type && c.Path == pkg.SourcePath {
+				return src, plugins[0], c, nil
+			}
+		}
+	}
+	src.close()
+	return nil, nil, pluginComponent{}, fmt.Errorf("%s %s is no longer in plugin %s", pkg.Type, pkg.SourcePath, pkg.Bundle)
+}
+
+// latestPluginSHA returns the checksum of the component a package was imported
+// from, as it is in the plugin now
+func (m *Manager) latestPluginSHA(pkg *InstalledPackage) (string, error) {
+	src, p, c, err := m.findPluginComponent(pkg)
+	if err != nil {
+		return "", err
+	}
+	defer src.close()
+	return componentSHA(p.Dir, c)
+}
+
+// updatePlugin reimports a package from the plugin it was imported from, under
+// the same name, registering a hook with the plugin's current rule
+func (m *Manager) updatePlugin(pkg *InstalledPackage, hookAt int) (*InstalledPackage, error) {
+	src, p, c, err := m.findPluginComponent(pkg)
+	if err != nil {
+		return nil, err
+	}
+	defer src.close()
+
+	if err := m.uninstall(pkg.Name); err != nil {
+		return nil, fmt.Errorf("uninstall old version: %w", err)
+	}
+	if pkg.ClaudeDir != "" {
+		m.SetClaudeDir(pkg.ClaudeDir)
+		defer m.SetClaudeDir(basedir.ClaudeDir())
+	}
+	updated, err := m.installPluginComponent(p, c, pkg.Namespace, pkg.Source, pkg.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	prev := *pkg
+	prev.Hook = nil
+	if updated, err = m.finishUpdate(updated, &prev, -1); err != nil {
+		return nil, err
+	}
+	if pkg.Hook == nil {
+		return updated, nil
+	}
+	at := hookAt
+	if hook.EventType(c.EventType) != pkg.Hook.EventType {
+		at = -1
+	}
+	return m.registerPluginHook(updated, c, pkg.Hook.SettingsPath, at)
+}
+
+// pluginSource is an opened import source: a directory with a plugin or a
+// marketplace, and the temporary directories it was downloaded to
+type pluginSource struct {
+	source  string // Source as recorded in installed packages
+	dir     string
+	tmpDirs []string
+	m       *Manager
+}
+
+// close removes the temporary directories of the source
+func (s *pluginSource) close() {
+	for _, dir := range s.tmpDirs {
+		_ = os.RemoveAll(dir)
+	}
+}
+
+// isGitSource reports whether an import source is a git repository to clone
+func isGitSource(source string) bool {
+	return strings.HasPrefix(source, "gh:") || strings.HasPrefix(source, "git@") ||
+		strings.HasSuffix(source, ".git") || isRemoteSource(source)
+}
+
+// openPluginSource makes an import source available as a directory, downloading
+// and extracting archives and cloning git repositories
+func (m *Manager) openPluginSource(source string) (*pluginSource, error) {
+	src := &pluginSource{source: source, m: m}
+
+	switch {
+	case archiveFormat(source) != "":
+		tmpDir, err := src.tempDir()
+		if err != nil {
+			return nil, err
+		}
+		archivePath := source
+		if isRemoteSource(source) {
+			archivePath = filepath.Join(tmpDir, path.Base(source))
+			if err := downloadFile(source, archivePath); err != nil {
+				src.close()
+				return nil, fmt.Errorf("download archive: %w", err)
+			}
+		} else if src.source, err = filepath.Abs(source); err != nil {
+			src.close()
+			return nil, err
+		}
+
+		extractDir := filepath.Join(tmpDir, "extract")
+		if archiveFormat(source) == "zip" {
+			err = extractZip(archivePath, extractDir)
+		} else {
+			err = extractTarGz(archivePath, extractDir)
+		}
+		if err != nil {
+			src.close()
+			return nil, fmt.Errorf("extract archive: %w", err)
+		}
+		src.dir = archiveRoot(extractDir)
+
+	case isGitSource(source):
+		url := source
+		if owner, name, err := repo.ParseURL(source); err == nil {
+			url = fmt.Sprintf("https://github.com/%s/%s.git", owner, name)
+		}
+		dir, err := src.clone(url, "")
+		if err != nil {
+			src.close()
+			return nil, err
+		}
+		src.dir = dir
+
+	default:
+		dir, err := filepath.Abs(source)
+		if err != nil {
+			return nil, err
+		}
+		info, err := os.Stat(dir)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			return nil, fmt.Errorf("%s is not a plugin directory, archive, or git repository", source)
+		}
+		src.source, src.dir = dir, dir
+	}
+	return src, nil
+}
+
+// tempDir creates a temporary directory removed when the source is closed
+func (s *pluginSource) tempDir() (string, error) {
+	dir, err := os.MkdirTemp("", "jd-plugin-*")
+	if err != nil {
+		return "", fmt.Errorf("create temp directory: %w", err)
+	}
+	s.tmpDirs = append(s.tmpDirs, dir)
+	return dir, nil
+}
+
+// clone clones a git repository at ref (empty: its default branch) into a
+// temporary directory
+func (s *pluginSource) clone(url, ref string) (string, error) {
+	if err := git.EnsureInstalled(); err != nil {
+		return "", err
+	}
+	tmpDir, err := s.tempDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(tmpDir, "clone")
+	if err := git.CloneWithProgress(url, dir, ref, s.m.progress); err != nil {
+		return "", fmt.Errorf("clone %s: %w", url, err)
+	}
+	return dir, nil
+}
+
+// plugins reads the plugin in the source, or the plugins of its marketplace,
+// keeping those named in names if any. Marketplace plugins that cannot be read
+// are returned as imports with the reason they were skipped.
+func (s *pluginSource) plugins(names []string) ([]*plugin.Plugin, []PluginImport, error) {
+	wanted := func(name string) bool {
+		if len(names) == 0 {
+			return true
+		}
+		for _, n := range names {
+			if n == name {
+				return true
+			}
+		}
+		return false
+	}
+
+	market, err := plugin.ReadMarketplace(s.dir)
+	if os.IsNotExist(err) {
+		p, err := plugin.Read(s.dir)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !wanted(p.Manifest.Name) {
+			return nil, nil, fmt.Errorf("%w: %s (%s has plugin %s)", ErrPluginNotFound, strings.Join(names, ", "), s.source, p.Manifest.Name)
+		}
+		return []*plugin.Plugin{p}, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var plugins []*plugin.Plugin
+	var skipped []PluginImport
+	found := make(map[string]bool)
+	for _, e := range market.Plugins {
+		if !wanted(e.Name) {
+			continue
+		}
+		found[e.Name] = true
+
+		p, err := s.readEntry(market, e)
+		if err != nil {
+			skipped = append(skipped, PluginImport{Plugin: e.Name, Version: e.Version, Skipped: []string{err.Error()}})
+			continue
+		}
+		plugins = append(plugins, p)
+	}
+	for _, name := range names {
+		if !found[name] {
+			return nil, nil, fmt.Errorf("%w in marketplace %s: %s", ErrPluginNotFound, market.Name, name)
+		}
+	}
+	return plugins, skipped, nil
+}
+
+// readEntry reads a plugin listed in the source's marketplace, which names it
+func (s *pluginSource) readEntry(market *plugin.Marketplace, e plugin.MarketplaceEntry) (*plugin.Plugin, error) {
+	var dir string
+	var err error
+	if e.Source.URL != "" {
+		dir, err = s.clone(e.Source.URL, e.Source.Ref)
+	} else {
+		dir, err = market.Dir(s.dir, e)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := plugin.Read(dir)
+	if err != nil {
+		return nil, err
+	}
+	p.Manifest.Name = e.Name
+	if p.Manifest.Version == "" {
+		p.Manifest.Version = e.Version
+	}
+	if p.Manifest.Description == "" {
+		p.Manifest.Description = e.Description
+	}
+	return p, nil
+}
@@ -0,0 +1,44 @@
+package pkgmgr
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/itda-skills/jindo/internal/plugin"
+)
+
+func TestImportPluginRejectsInvalidNamespace(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	base := t.TempDir()
+	pluginDir := filepath.Join(base, "src", "tools")
+	writeTestFile(t, filepath.Join(pluginDir, plugin.ManifestDir, plugin.ManifestFile), `{"name": "../../.ssh"}`, 0644)
+	writeTestFile(t, filepath.Join(pluginDir, "skills", "keys", "SKILL.md"), "# keys\n", 0644)
+	claudeDir := filepath.Join(base, "home", ".claude")
+
+	m := NewManager(t.TempDir())
+	m.SetClaudeDir(claudeDir)
+	results, err := m.ImportPlugin(pluginDir, PluginImportOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || len(results[0].Packages) != 0 || len(results[0].Skipped) != 1 ||
+		!strings.Contains(results[0].Skipped[0], "invalid namespace") {
+		t.Fatalf("ImportPlugin() = %+v, want the plugin skipped for its namespace", results)
+	}
+	for _, dir := range []string{claudeDir, filepath.Join(base, ".ssh")} {
+		if _, err := os.Stat(dir); !os.IsNotExist(err) {
+			t.Errorf("%s was written: %v", dir, err)
+		}
+	}
+
+	// A namespace given for it is used instead
+	results, err = m.ImportPlugin(pluginDir, PluginImportOptions{Namespace: "tools"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || len(results[0].Packages) != 1 || results[0].Packages[0].Name != "tools--keys" {
+		t.Errorf("ImportPlugin() with a namespace = %+v, want tools--keys", results)
+	}
+}
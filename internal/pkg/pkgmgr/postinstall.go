@@ -0,0 +1,135 @@
+package pkgmgr
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/itda-skills/jindo/internal/frontmatter"
+	"github.com/itda-skills/jindo/internal/pkg/repo"
+	"github.com/itda-skills/jindo/internal/skill"
+	"github.com/itda-skills/jindo/pkg/config"
+	"gopkg.in/yaml.v3"
+)
+
+// PostInstallFile is the file in a skill package shown after installation.
+const PostInstallFile = "POST_INSTALL.md"
+
+// ConfigRequirement is a jd configuration key a package needs to work.
+// In POST_INSTALL.md frontmatter it is either a key or a mapping:
+//
+//	requires_config:
+//	  - common.default_market
+//	  - key: common.api_keys.tiingo
+//	    description: Tiingo API key
+//	    env: TIINGO_API_KEY
+type ConfigRequirement struct {
+	Key         string `yaml:"key"`
+	Description string `yaml:"description"`
+	Env         string `yaml:"env"`    // Vendor environment variable that also satisfies the requirement
+	Type        string `yaml:"type"`   // Value type (see config.ParseTyped); empty guesses it
+	Secret      bool   `yaml:"secret"` // Not echoed when asked for
+}
+
+// UnmarshalYAML accepts a plain key as well as a mapping.
+func (r *ConfigRequirement) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		r.Key = node.Value
+		return nil
+	}
+	type plain ConfigRequirement
+	return node.Decode((*plain)(r))
+}
+
+// PostInstall holds the notes and setup steps a package declares for after installation.
+type PostInstall struct {
+	Notes          string              // Markdown body of POST_INSTALL.md
+	RequiresConfig []ConfigRequirement // Configuration keys the package reads
+	Setup          []SetupStep         // Commands to run once after installation
+}
+
+// postInstallFrontmatter is the YAML frontmatter of POST_INSTALL.md.
+type postInstallFrontmatter struct {
+	RequiresConfig []ConfigRequirement `yaml:"requires_config"`
+	Setup          []SetupStep         `yaml:"setup"`
+}
+
+// PostInstallInfo returns the post-install notes of an installed package.
+// It returns nil if the package has no POST_INSTALL.md.
+func PostInstallInfo(pkg *InstalledPackage) (*PostInstall, error) {
+	for _, f := range pkg.Files {
+		// Only the file at the package root counts, not one in a subdirectory
+		if filepath.Base(f.Source) != PostInstallFile || filepath.Dir(f.Source) != filepath.Clean(pkg.SourcePath) {
+			continue
+		}
+
+		content, err := os.ReadFile(f.Target)
+		if err != nil {
+			return nil, err
+		}
+		return parsePostInstall(string(content))
+	}
+	return nil, nil
+}
+
+// parsePostInstall parses POST_INSTALL.md content with optional YAML frontmatter.
+func parsePostInstall(content string) (*PostInstall, error) {
+	doc := frontmatter.Split(content)
+	info := &PostInstall{Notes: strings.TrimSpace(doc.Body)}
+
+	// Unlike other frontmatter, this is structured, so it must be valid YAML
+	var fm postInstallFrontmatter
+	if err := yaml.Unmarshal([]byte(doc.Frontmatter), &fm); err != nil {
+		return nil, fmt.Errorf("parse %s frontmatter: %w", PostInstallFile, err)
+	}
+	for _, r := range fm.RequiresConfig {
+		if r.Key != "" {
+			info.RequiresConfig = append(info.RequiresConfig, r)
+		}
+	}
+	for _, step := range fm.Setup {
+		if strings.TrimSpace(step.Run) != "" {
+			info.Setup = append(info.Setup, step)
+		}
+	}
+	return info, nil
+}
+
+// SkillConfigRequirements returns the required configuration keys declared
+// under config: in the SKILL.md of an installed skill package. Keys with a
+// default, or declared required: false, are left out.
+func SkillConfigRequirements(pkg *InstalledPackage) ([]ConfigRequirement, error) {
+	if pkg.Type != repo.TypeSkill {
+		return nil, nil
+	}
+	for _, f := range pkg.Files {
+		if !strings.EqualFold(filepath.Base(f.Source), "SKILL.md") || filepath.Dir(f.Source) != filepath.Clean(pkg.SourcePath) {
+			continue
+		}
+
+		s, err := skill.ParseSkillFile(f.Target)
+		if err != nil {
+			return nil, err
+		}
+		var reqs []ConfigRequirement
+		for _, key := range s.Config {
+			if key.Name == "" || !key.IsRequired() {
+				continue
+			}
+			typ := key.Type
+			if typ == "" {
+				typ = config.TypeString
+			}
+			reqs = append(reqs, ConfigRequirement{
+				Key:         key.Name,
+				Description: key.Description,
+				Env:         key.Env,
+				Type:        typ,
+				Secret:      key.Secret,
+			})
+		}
+		return reqs, nil
+	}
+	return nil, nil
+}
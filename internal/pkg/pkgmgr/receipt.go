@@ -0,0 +1,64 @@
+package pkgmgr
+
+import (
+	"time"
+
+	"github.com/itda-skills/jindo/internal/pkg/repo"
+)
+
+// InstallerVersion is the jd version recorded in packages installed or updated by
+// this process. The CLI sets it to its build version.
+var InstallerVersion = "dev"
+
+// Receipt is the provenance of an installed package: where it came from, which jd
+// installed it and when, and the exact files it wrote with their hashes.
+type Receipt struct {
+	Name        string           `json:"name"`
+	Type        repo.PackageType `json:"type"`
+	Namespace   string           `json:"namespace"`
+	SourcePath  string           `json:"source_path"`
+	RepoURL     string           `json:"repo_url,omitempty"` // Empty for packages installed from an archive
+	Archive     string           `json:"archive,omitempty"`  // Archive URL or path for packages installed with --from-url
+	Commit      string           `json:"commit"`             // Commit SHA, or the archive's SHA-256
+	Ref         string           `json:"ref,omitempty"`      // Branch or tag followed, or the archive name
+	Pinned      bool             `json:"pinned,omitempty"`
+	Installer   string           `json:"installer"` // "unknown" for packages installed before jd recorded it
+	Scope       string           `json:"scope,omitempty"`
+	InstalledAt time.Time        `json:"installed_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+	Files       []ReceiptFile    `json:"files"`
+}
+
+// ReceiptFile is a file an installed package wrote.
+type ReceiptFile struct {
+	Path   string `json:"path"`   // Installed file
+	Source string `json:"source"` // Path in the source repository
+	SHA256 string `json:"sha256"` // Empty for files installed before hashes were recorded
+}
+
+// Receipt returns the install receipt of the package.
+func (p *InstalledPackage) Receipt() *Receipt {
+	r := &Receipt{
+		Name:        p.Name,
+		Type:        p.Type,
+		Namespace:   p.Namespace,
+		SourcePath:  p.SourcePath,
+		RepoURL:     p.RepoURL,
+		Archive:     p.Source,
+		Commit:      p.Version.SHA,
+		Ref:         p.Version.Ref,
+		Pinned:      p.Pinned(),
+		Installer:   p.Installer,
+		Scope:       p.Scope,
+		InstalledAt: p.InstalledAt,
+		UpdatedAt:   p.UpdatedAt,
+		Files:       make([]ReceiptFile, 0, len(p.Files)),
+	}
+	if r.Installer == "" {
+		r.Installer = "unknown"
+	}
+	for _, f := range p.Files {
+		r.Files = append(r.Files, ReceiptFile{Path: f.Target, Source: f.Source, SHA256: f.SHA})
+	}
+	return r
+}
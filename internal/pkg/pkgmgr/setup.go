@@ -0,0 +1,322 @@
+package pkgmgr
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/itda-skills/jindo/internal/events"
+	"github.com/itda-skills/jindo/internal/hook"
+	"github.com/itda-skills/jindo/internal/pkg/repo"
+	"github.com/itda-skills/jindo/internal/shell"
+	"gopkg.in/yaml.v3"
+)
+
+// Files and directories under the data directory that keep setup state
+const (
+	setupFileName   = "setup.json" // Setup steps that have run
+	setupLogDirName = "setup-logs" // Output of each setup run
+	setupWorkDir    = "setup"      // Working directories of packages that are not directories
+)
+
+// DefaultSetupTimeout is how long a setup step may run unless it declares a timeout
+const DefaultSetupTimeout = 10 * time.Minute
+
+// setupEnv are the environment variables setup steps inherit; others, such as
+// tokens and cloud credentials, are not passed on
+var setupEnv = []string{"PATH", "HOME", "USER", "LOGNAME", "LANG", "LC_ALL", "LC_CTYPE", "TERM", "TMPDIR", "SHELL", "SYSTEMROOT", "COMSPEC", "PATHEXT", "TEMP", "TMP", "USERPROFILE"}
+
+// SetupStep is a command a package declares to run once after installation,
+// such as installing a linter or downloading a model. In POST_INSTALL.md
+// frontmatter it is either a command or a mapping:
+//
+//	setup:
+//	  - pip install --user ruff
+//	  - name: model
+//	    run: ./download-model.sh
+//	    description: Download the embedding model (400 MB)
+//	    timeout: 30m
+type SetupStep struct {
+	Name        string `yaml:"name"`
+	Run         string `yaml:"run"`
+	Description string `yaml:"description"`
+	Timeout     string `yaml:"timeout"` // Go duration (e.g., 30m); DefaultSetupTimeout if empty
+}
+
+// UnmarshalYAML accepts a plain command as well as a mapping.
+func (s *SetupStep) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		s.Run = node.Value
+		return nil
+	}
+	type plain SetupStep
+	return node.Decode((*plain)(s))
+}
+
+// Label returns the step's name, or its command if it has none.
+func (s SetupStep) Label() string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return s.Run
+}
+
+// commandSHA identifies a step by its command, so a step runs again only if its command changes
+func (s SetupStep) commandSHA() string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(s.Run)))
+	return hex.EncodeToString(sum[:])
+}
+
+// timeout returns how long the step may run
+func (s SetupStep) timeout() (time.Duration, error) {
+	if s.Timeout == "" {
+		return DefaultSetupTimeout, nil
+	}
+	d, err := time.ParseDuration(s.Timeout)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid timeout %q of setup step %s", s.Timeout, s.Label())
+	}
+	return d, nil
+}
+
+// SetupRun records a setup step that ran successfully.
+type SetupRun struct {
+	Package    string    `json:"package"` // Installed name at the time
+	Namespace  string    `json:"namespace"`
+	SourcePath string    `json:"source_path"`
+	Step       string    `json:"step"`
+	CommandSHA string    `json:"command_sha256"`
+	Version    string    `json:"version"` // Package version that declared the step
+	RanAt      time.Time `json:"ran_at"`
+	Log        string    `json:"log"`
+}
+
+// setupManifest is the setup.json file structure
+type setupManifest struct {
+	Runs []SetupRun `json:"runs"`
+}
+
+// SetupSteps returns the setup steps an installed package declares: under
+// setup: in the frontmatter of its POST_INSTALL.md, or, for a hook, in
+// "# Setup:" lines of its script header.
+func SetupSteps(pkg *InstalledPackage) ([]SetupStep, error) {
+	if pkg.Type == repo.TypeHook {
+		if len(pkg.Files) == 0 {
+			return nil, nil
+		}
+		commands, err := hook.ParseScriptSetup(pkg.Files[0].Target)
+		if err != nil {
+			return nil, err
+		}
+		steps := make([]SetupStep, 0, len(commands))
+		for _, c := range commands {
+			steps = append(steps, SetupStep{Run: c})
+		}
+		return steps, nil
+	}
+
+	info, err := PostInstallInfo(pkg)
+	if err != nil || info == nil {
+		return nil, err
+	}
+	return info.Setup, nil
+}
+
+// setupFilePath returns the path to setup.json
+func (m *Manager) setupFilePath() (string, error) {
+	base, err := m.expandDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, setupFileName), nil
+}
+
+// loadSetup reads setup.json; a missing file has no runs
+func (m *Manager) loadSetup() (*setupManifest, error) {
+	path, err := m.setupFilePath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &setupManifest{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var manifest setupManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", setupFileName, err)
+	}
+	return &manifest, nil
+}
+
+// saveSetup writes setup.json
+func (m *Manager) saveSetup(manifest *setupManifest) error {
+	path, err := m.setupFilePath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("create data directory: %w", err)
+	}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// SetupRunOf returns the recorded run of a package's setup step, or nil if it
+// has not run. Runs are recorded by source, not installed name, so a step does
+// not run again when its package is reinstalled, and runs again only if its
+// command changes.
+func (m *Manager) SetupRunOf(pkg *InstalledPackage, step SetupStep) (*SetupRun, error) {
+	manifest, err := m.loadSetup()
+	if err != nil {
+		return nil, err
+	}
+	sha := step.commandSHA()
+	for i := len(manifest.Runs) - 1; i >= 0; i-- {
+		r := manifest.Runs[i]
+		if r.Namespace == pkg.Namespace && r.SourcePath == pkg.SourcePath && r.CommandSHA == sha {
+			return &manifest.Runs[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// PendingSetup returns the setup steps of an installed package that have not run.
+func (m *Manager) PendingSetup(pkg *InstalledPackage) ([]SetupStep, error) {
+	steps, err := SetupSteps(pkg)
+	if err != nil {
+		return nil, err
+	}
+	var pending []SetupStep
+	for _, step := range steps {
+		run, err := m.SetupRunOf(pkg, step)
+		if err != nil {
+			return nil, err
+		}
+		if run == nil {
+			pending = append(pending, step)
+		}
+	}
+	return pending, nil
+}
+
+// SetupDir returns the directory setup steps of a package run in: a skill's
+// installed directory, or, for packages that are a single file, a directory
+// for the package under setup/ in the data directory.
+func (m *Manager) SetupDir(pkg *InstalledPackage) (string, error) {
+	if pkg.Type == repo.TypeSkill {
+		for _, f := range pkg.Files {
+			if filepath.Dir(f.Source) == filepath.Clean(pkg.SourcePath) {
+				return filepath.Dir(f.Target), nil
+			}
+		}
+	}
+	base, err := m.expandDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, setupWorkDir, pkg.Name), nil
+}
+
+// RunSetup runs a setup step of an installed package, writing its output to
+// out and to a log file under setup-logs/ in the data directory, and records
+// it when it succeeds so it does not run again.
+//
+// The step runs with the platform shell in the package's SetupDir, with no
+// input, only the basic environment variables of setupEnv plus JD_PACKAGE,
+// JD_PACKAGE_DIR, and JD_SETUP_DIR, and the step's timeout. This keeps
+// credentials out of its reach, but it is not a security boundary: the command
+// runs as the user.
+func (m *Manager) RunSetup(ctx context.Context, pkg *InstalledPackage, step SetupStep, out io.Writer) (*SetupRun, error) {
+	timeout, err := step.timeout()
+	if err != nil {
+		return nil, err
+	}
+	dir, err := m.SetupDir(pkg)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("create setup directory: %w", err)
+	}
+	packageDir := dir
+	if len(pkg.Files) > 0 && pkg.Type != repo.TypeSkill {
+		packageDir = filepath.Dir(pkg.Files[0].Target)
+	}
+
+	base, err := m.expandDir()
+	if err != nil {
+		return nil, err
+	}
+	logDir := filepath.Join(base, setupLogDirName)
+	if err := os.MkdirAll(logDir, 0755); err != nil {
+		return nil, fmt.Errorf("create setup log directory: %w", err)
+	}
+	started := time.Now()
+	logPath := filepath.Join(logDir, fmt.Sprintf("%s-%s-%s.log", pkg.Name, started.Format("20060102-150405"), step.commandSHA()[:8]))
+	logFile, err := os.Create(logPath)
+	if err != nil {
+		return nil, fmt.Errorf("create setup log: %w", err)
+	}
+	defer func() { _ = logFile.Close() }()
+	fmt.Fprintf(logFile, "# Package: %s (%s:%s @ %s)\n# Step: %s\n# Command: %s\n# Directory: %s\n# Started: %s\n\n",
+		pkg.Name, pkg.Namespace, pkg.SourcePath, pkg.Version.SHA, step.Label(), step.Run, dir, started.Format(time.RFC3339))
+
+	env := []string{"JD_PACKAGE=" + pkg.Name, "JD_PACKAGE_DIR=" + packageDir, "JD_SETUP_DIR=" + dir}
+	for _, key := range setupEnv {
+		if value, ok := os.LookupEnv(key); ok {
+			env = append(env, key+"="+value)
+		}
+	}
+	output := io.MultiWriter(out, logFile)
+	runErr := shell.Run(ctx, step.Run, shell.Options{Dir: dir, Env: env, Stdout: output, Stderr: output, Timeout: timeout})
+	exitCode := shell.ExitCode(runErr)
+	var exitErr *exec.ExitError
+	if errors.As(runErr, &exitErr) {
+		runErr = fmt.Errorf("exited with status %d", exitCode)
+	}
+	fmt.Fprintf(logFile, "\n# Finished: %s (exit %d)\n", time.Now().Format(time.RFC3339), exitCode)
+
+	data := map[string]any{"command": step.Run, "exit_code": exitCode, "log": logPath}
+	if step.Name != "" {
+		data["step"] = step.Name
+	}
+	m.emit(events.Setup, pkg, data)
+	if runErr != nil {
+		return nil, fmt.Errorf("setup step %s of %s failed: %w (log: %s)", step.Label(), pkg.Name, runErr, logPath)
+	}
+
+	run := SetupRun{
+		Package:    pkg.Name,
+		Namespace:  pkg.Namespace,
+		SourcePath: pkg.SourcePath,
+		Step:       step.Label(),
+		CommandSHA: step.commandSHA(),
+		Version:    pkg.Version.SHA,
+		RanAt:      started.UTC().Truncate(time.Second),
+		Log:        logPath,
+	}
+	manifest, err := m.loadSetup()
+	if err != nil {
+		return nil, err
+	}
+	manifest.Runs = append(manifest.Runs, run)
+	if err := m.saveSetup(manifest); err != nil {
+		return nil, fmt.Errorf("record setup run: %w", err)
+	}
+	return &run, nil
+}
@@ -0,0 +1,170 @@
+package pkgmgr
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/itda-skills/jindo/internal/frontmatter"
+	"github.com/itda-skills/jindo/internal/pkg/repo"
+	"gopkg.in/yaml.v3"
+)
+
+// TargetClaude is the default install target, Claude Code. It is recorded as an
+// empty InstalledPackage.Target.
+const TargetClaude = "claude"
+
+// ErrTargetUnsupported is returned when a package type cannot be converted for a target.
+var ErrTargetUnsupported = errors.New("only skills, commands, and agents can be installed as rules")
+
+// targetAdapter converts markdown packages into the project rules of an assistant
+// other than Claude Code.
+type targetAdapter struct {
+	name        string // Display name
+	dir         string // Rules directory relative to the project root
+	ext         string // Rule file extension
+	frontmatter string // Rule frontmatter, formatted with the package description
+}
+
+// targetAdapters are the non-Claude install targets by name
+var targetAdapters = map[string]targetAdapter{
+	"cursor": {
+		name:        "Cursor",
+		dir:         ".cursor/rules",
+		ext:         ".mdc",
+		frontmatter: "---\ndescription: %s\nglobs:\nalwaysApply: false\n---\n\n",
+	},
+	"windsurf": {
+		name:        "Windsurf",
+		dir:         ".windsurf/rules",
+		ext:         ".md",
+		frontmatter: "---\ntrigger: model_decision\ndescription: %s\n---\n\n",
+	},
+	"copilot": {
+		name:        "GitHub Copilot",
+		dir:         ".github/instructions",
+		ext:         ".instructions.md",
+		frontmatter: "---\ndescription: %s\napplyTo: \"**\"\n---\n\n",
+	},
+}
+
+// Targets returns the names of the install targets other than Claude Code.
+func Targets() []string {
+	names := make([]string, 0, len(targetAdapters))
+	for name := range targetAdapters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidTarget reports whether target is TargetClaude or one of Targets.
+func ValidTarget(target string) bool {
+	_, ok := targetAdapters[target]
+	return ok || target == TargetClaude
+}
+
+// TargetName returns the display name of an install target (e.g., "Cursor").
+func TargetName(target string) string {
+	if a, ok := targetAdapters[target]; ok {
+		return a.name
+	}
+	return "Claude Code"
+}
+
+// TargetRulesDir returns the directory a target's rules are installed into under a project root.
+func TargetRulesDir(target, projectRoot string) string {
+	return filepath.Join(projectRoot, filepath.FromSlash(targetAdapters[target].dir))
+}
+
+// SetTarget sets the assistant packages are installed for, with the project whose
+// rules directory they are written to. An empty target or TargetClaude installs
+// into the Claude directory as usual.
+func (m *Manager) SetTarget(target, projectRoot string) {
+	if target == TargetClaude {
+		target = ""
+	}
+	m.target = target
+	m.projectRoot = projectRoot
+}
+
+// installRule converts a skill, command, or agent package into a rule file for the
+// current target. Only the package's markdown is converted; a skill's other files
+// are not copied.
+func (m *Manager) installRule(packageRoot, path string, pkgType repo.PackageType, namespacedName string) ([]InstalledFile, error) {
+	adapter, ok := targetAdapters[m.target]
+	if !ok {
+		return nil, fmt.Errorf("unknown install target: %s", m.target)
+	}
+
+	srcPath := filepath.Join(packageRoot, path)
+	switch pkgType {
+	case repo.TypeSkill:
+		found := false
+		for _, name := range []string{"SKILL.md", "skill.md"} {
+			if _, err := os.Stat(filepath.Join(srcPath, name)); err == nil {
+				srcPath = filepath.Join(srcPath, name)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("no SKILL.md found in skill: %s", path)
+		}
+		path = filepath.ToSlash(filepath.Join(path, filepath.Base(srcPath)))
+	case repo.TypeCommand, repo.TypeAgent:
+	default:
+		return nil, fmt.Errorf("install %s for %s: %w", pkgType, adapter.name, ErrTargetUnsupported)
+	}
+
+	content, err := os.ReadFile(srcPath)
+	if err != nil {
+		return nil, fmt.Errorf("read package file: %w", err)
+	}
+	description, body := splitRuleSource(string(content))
+
+	rulesDir := TargetRulesDir(m.target, m.projectRoot)
+	if err := os.MkdirAll(rulesDir, 0755); err != nil {
+		return nil, fmt.Errorf("create rules directory: %w", err)
+	}
+
+	// Nested command names (ns--team:task) become flat file names (ns--team-task)
+	destPath := filepath.Join(rulesDir, strings.ReplaceAll(namespacedName, ":", "-")+adapter.ext)
+	rule := fmt.Sprintf(adapter.frontmatter, yamlScalar(description)) + body
+	m.notifyOverwrite(destPath)
+	if err := os.WriteFile(destPath, []byte(rule), 0644); err != nil {
+		return nil, fmt.Errorf("write rule file: %w", err)
+	}
+	sha, err := fileSHA256(destPath)
+	if err != nil {
+		return nil, fmt.Errorf("hash rule file: %w", err)
+	}
+
+	return []InstalledFile{{
+		Source: path,
+		Target: destPath,
+		SHA:    sha,
+	}}, nil
+}
+
+// splitRuleSource returns the description from a package's frontmatter and the
+// markdown body after it
+func splitRuleSource(content string) (description, body string) {
+	doc := frontmatter.Split(content)
+	if !doc.Found {
+		return "", content
+	}
+	return doc.Values()["description"], strings.TrimLeft(doc.Body, "\n")
+}
+
+// yamlScalar formats s as a single-line YAML scalar
+func yamlScalar(s string) string {
+	out, err := yaml.Marshal(s)
+	if err != nil {
+		return s
+	}
+	return strings.TrimSpace(string(out))
+}
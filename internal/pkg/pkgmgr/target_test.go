@@ -0,0 +1,128 @@
+package pkgmgr
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/itda-skills/jindo/internal/pkg/repo"
+)
+
+func TestSplitRuleSource(t *testing.T) {
+	tests := []struct {
+		name, content, description, body string
+	}{
+		{"frontmatter", "---\nname: tool\ndescription: Formats code\n---\n\n# Tool\n", "Formats code", "# Tool\n"},
+		{"folded description", "---\ndescription: >\n  Formats\n  code\n---\nBody\n", "Formats code", "Body\n"},
+		{"unquoted colon", "---\ndescription: Use: formatting\nname: [x\n---\nBody\n", "Use: formatting", "Body\n"},
+		{"no description", "---\nname: tool\n---\nBody\n", "", "Body\n"},
+		{"no frontmatter", "# Tool\n", "", "# Tool\n"},
+		{"unclosed frontmatter", "---\ndescription: x\n", "", "---\ndescription: x\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			description, body := splitRuleSource(tt.content)
+			if description != tt.description || body != tt.body {
+				t.Errorf("splitRuleSource() = %q, %q, want %q, %q", description, body, tt.description, tt.body)
+			}
+		})
+	}
+}
+
+func TestInstallRule(t *testing.T) {
+	repoDir := t.TempDir()
+	writeTestFile(t, filepath.Join(repoDir, "skills", "tool", "SKILL.md"), "---\ndescription: Formats: code\n---\n\n# Tool\n", 0644)
+	writeTestFile(t, filepath.Join(repoDir, "skills", "tool", "ref.md"), "ref\n", 0644)
+	writeTestFile(t, filepath.Join(repoDir, "commands", "team", "task.md"), "# Task\n", 0644)
+	writeTestFile(t, filepath.Join(repoDir, "hooks", "format.sh"), "echo\n", 0755)
+
+	tests := []struct {
+		target, path string
+		pkgType      repo.PackageType
+		name, file   string
+		want         string
+	}{
+		{"cursor", "skills/tool", repo.TypeSkill, "demo--tool", ".cursor/rules/demo--tool.mdc",
+			"---\ndescription: 'Formats: code'\nglobs:\nalwaysApply: false\n---\n\n# Tool\n"},
+		{"windsurf", "commands/team/task.md", repo.TypeCommand, "demo--team:task", ".windsurf/rules/demo--team-task.md",
+			"---\ntrigger: model_decision\ndescription: \"\"\n---\n\n# Task\n"},
+		{"copilot", "skills/tool", repo.TypeSkill, "demo--tool", ".github/instructions/demo--tool.instructions.md",
+			"---\ndescription: 'Formats: code'\napplyTo: \"**\"\n---\n\n# Tool\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			projectRoot := t.TempDir()
+			m := NewManager(t.TempDir())
+			m.SetTarget(tt.target, projectRoot)
+			files, err := m.installRule(repoDir, tt.path, tt.pkgType, tt.name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			dest := filepath.Join(projectRoot, filepath.FromSlash(tt.file))
+			if len(files) != 1 || files[0].Target != dest {
+				t.Fatalf("installed %+v, want only %s", files, dest)
+			}
+			data, err := os.ReadFile(dest)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("rule = %q, want %q", data, tt.want)
+			}
+			// Only the markdown is converted
+			if _, err := os.Stat(filepath.Join(filepath.Dir(dest), "ref.md")); !os.IsNotExist(err) {
+				t.Errorf("a skill file other than SKILL.md was copied: %v", err)
+			}
+		})
+	}
+
+	m := NewManager(t.TempDir())
+	m.SetTarget("cursor", t.TempDir())
+	if _, err := m.installRule(repoDir, "hooks/format.sh", repo.TypeHook, "demo--format.sh"); !errors.Is(err, ErrTargetUnsupported) {
+		t.Errorf("installing a hook as a rule: error = %v, want ErrTargetUnsupported", err)
+	}
+}
+
+func TestResolveNameByTarget(t *testing.T) {
+	skill := func(name, target, projectRoot string) InstalledPackage {
+		return InstalledPackage{Name: name, OriginalName: "tool", Namespace: "demo", Type: repo.TypeSkill, Target: target, ProjectRoot: projectRoot}
+	}
+	tests := []struct {
+		name                string
+		installed           []InstalledPackage
+		target, projectRoot string
+		want, wantErr       string
+	}{
+		{"claude installed", []InstalledPackage{skill("demo--tool", "", "")}, "", "", "", "already installed"},
+		{"for cursor after claude", []InstalledPackage{skill("demo--tool", "", "")}, "cursor", "/a", "demo--tool-cursor", ""},
+		{"for claude after cursor", []InstalledPackage{skill("demo--tool", "cursor", "/a")}, "", "", "demo--tool-claude", ""},
+		{"cursor installed", []InstalledPackage{skill("demo--tool", "cursor", "/a")}, "cursor", "/a", "", "already installed"},
+		{"for cursor in another project", []InstalledPackage{
+			skill("demo--tool", "", ""), skill("demo--tool-cursor", "cursor", "/a"),
+		}, "cursor", "/b", "demo--tool-cursor-2", ""},
+		{"another package has the name", []InstalledPackage{
+			{Name: "demo--tool", OriginalName: "tool", Namespace: "demo", Type: repo.TypeCommand},
+		}, "cursor", "/a", "", "already the name of command tool"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewManager(t.TempDir())
+			m.SetTarget(tt.target, tt.projectRoot)
+			got, err := m.resolveName(&InstalledManifest{Packages: tt.installed}, "demo", "tool", repo.TypeSkill, "")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("resolveName() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package pkgmgr
 import (
 	"time"
 
+	"github.com/itda-skills/jindo/internal/hook"
 	"github.com/itda-skills/jindo/internal/pkg/repo"
 )
 
@@ -10,31 +11,70 @@ import (
 type InstalledFile struct {
 	Source string `json:"source"` // Source path in repository
 	Target string `json:"target"` // Target path on filesystem
-	SHA    string `json:"sha"`    // Content SHA for change detection
+	SHA    string `json:"sha"`    // SHA-256 of the installed content, for change detection
 }
 
 // VersionInfo represents version information for an installed package.
 type VersionInfo struct {
-	Type string `json:"type"` // "commit" or "tag"
+	Type string `json:"type"` // "commit", "tag", "archive", or "plugin"
 	SHA  string `json:"sha"`
 	Ref  string `json:"ref"` // branch name or tag name
 }
 
 // InstalledPackage represents an installed package.
 type InstalledPackage struct {
-	Name         string          `json:"name"`          // Full name with namespace (e.g., affa-ever--web-fetch)
-	OriginalName string          `json:"original_name"` // Original name without namespace
-	Type         repo.PackageType `json:"type"`         // skill, command, agent
-	Namespace    string          `json:"namespace"`     // Repository namespace
-	SourcePath   string          `json:"source_path"`   // Path in source repository
-	Version      VersionInfo     `json:"version"`
-	Files        []InstalledFile `json:"files"`
-	InstalledAt  time.Time       `json:"installed_at"`
-	UpdatedAt    time.Time       `json:"updated_at"`
-}
-
-// InstalledFile represents the installed.json file structure.
-type InstalledFile2 struct {
+	Name         string            `json:"name"`          // Full name with namespace (e.g., affa-ever--web-fetch)
+	OriginalName string            `json:"original_name"` // Original name without namespace
+	Type         repo.PackageType  `json:"type"`          // skill, command, agent
+	Namespace    string            `json:"namespace"`     // Repository namespace
+	SourcePath   string            `json:"source_path"`   // Path in source repository
+	Version      VersionInfo       `json:"version"`
+	Source       string            `json:"source,omitempty"` // Archive URL or path when installed with --from-url, or plugin source when imported
+	Files        []InstalledFile   `json:"files"`
+	ClaudeDir    string            `json:"claude_dir,omitempty"`   // Install directory when not ~/.claude (e.g., a project's .claude)
+	Scope        string            `json:"scope,omitempty"`        // ScopeGlobal or ScopeLocal (schema v2)
+	RepoURL      string            `json:"repo_url,omitempty"`     // URL of the source repository at install time (schema v2)
+	Pin          *PinInfo          `json:"pin,omitempty"`          // Set when the package is held at a fixed ref (schema v2)
+	Bundle       string            `json:"bundle,omitempty"`       // Bundle the package was installed as part of (schema v2)
+	Hook         *HookRegistration `json:"hook,omitempty"`         // settings.json rule created for a hook package
+	Target       string            `json:"target,omitempty"`       // Assistant the package was installed for when not Claude Code (e.g., cursor)
+	ProjectRoot  string            `json:"project_root,omitempty"` // Project whose rules directory a Target package was written to
+	Only         []string          `json:"only,omitempty"`         // Patterns selecting the files of a skill installed in part
+	Channel      string            `json:"channel,omitempty"`      // Update channel (see Channels); empty follows the tracked branch, as edge does
+	Installer    string            `json:"installer,omitempty"`    // jd version that installed or last updated the package
+	InstalledAt  time.Time         `json:"installed_at"`
+	UpdatedAt    time.Time         `json:"updated_at"`
+}
+
+// Install scopes recorded in InstalledPackage.Scope
+const (
+	ScopeGlobal = "global" // Installed into ~/.claude
+	ScopeLocal  = "local"  // Installed into another .claude directory, such as a project's
+)
+
+// PinInfo records that a package is held at a fixed ref instead of following a branch.
+type PinInfo struct {
+	Ref      string    `json:"ref"`                 // Tag or commit the package is held at
+	PinnedAt time.Time `json:"pinned_at,omitempty"` // Zero for pins migrated from schema v1
+}
+
+// Pinned reports whether the package is installed at a fixed ref instead of
+// following a branch or, on the stable channel, the repository's releases.
+func (p *InstalledPackage) Pinned() bool {
+	return p.Pin != nil || (p.Version.Type == "tag" && !p.stable())
+}
+
+// HookRegistration records the settings.json rule created for an installed hook package.
+type HookRegistration struct {
+	SettingsPath string         `json:"settings_path"`
+	EventType    hook.EventType `json:"event_type"`
+	Matcher      string         `json:"matcher"`
+	Command      string         `json:"command"`
+}
+
+// InstalledManifest represents the installed.json file structure.
+// Version is the schema version; see SchemaVersion.
+type InstalledManifest struct {
 	Version  int                `json:"version"`
 	Packages []InstalledPackage `json:"packages"`
 }
@@ -48,9 +88,11 @@ type InstallSpec struct {
 
 // UpdateInfo represents update information for a package.
 type UpdateInfo struct {
-	Package     *InstalledPackage
-	CurrentSHA  string
-	LatestSHA   string
-	HasUpdate   bool
+	Package      *InstalledPackage
+	CurrentSHA   string
+	LatestSHA    string
+	HasUpdate    bool
+	LatestRef    string // Release tag a package on the stable channel would move to
 	ChangedFiles []string
+	Err          error // Set by CheckAll when the package could not be checked
 }
@@ -0,0 +1,139 @@
+package repo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// githubCacheFile caches GitHub API responses, in the jd data directory
+const githubCacheFile = "cache/github.json"
+
+// githubCacheTTL is how long a cached response is used without asking GitHub again
+const githubCacheTTL = 6 * time.Hour
+
+// githubCacheEntry is a cached GitHub API response
+type githubCacheEntry struct {
+	ETag      string          `json:"etag,omitempty"`
+	FetchedAt time.Time       `json:"fetched_at"`
+	Body      json.RawMessage `json:"body"`
+}
+
+// githubClient gets GitHub API responses through a cache on disk. A response
+// younger than githubCacheTTL is used without a request. An older one is
+// revalidated with its ETag, which does not count against the rate limit when
+// it is unchanged, and is still used if GitHub cannot be reached.
+type githubClient struct {
+	path    string
+	mu      sync.Mutex
+	entries map[string]githubCacheEntry
+	dirty   bool
+}
+
+// newGitHubClient returns a client caching responses in the file at path. A
+// missing or unreadable cache starts empty.
+func newGitHubClient(path string) *githubClient {
+	c := &githubClient{path: path, entries: make(map[string]githubCacheEntry)}
+	if data, err := os.ReadFile(path); err == nil {
+		_ = json.Unmarshal(data, &c.entries)
+	}
+	return c
+}
+
+// githubClient returns a client caching responses in the store's data directory
+func (s *Store) githubClient() (*githubClient, error) {
+	dir, err := s.expandDir()
+	if err != nil {
+		return nil, err
+	}
+	return newGitHubClient(filepath.Join(dir, githubCacheFile)), nil
+}
+
+// getJSON decodes the response of a GitHub API GET request into v, using the
+// cache where it can
+func (c *githubClient) getJSON(endpoint string, v any) error {
+	c.mu.Lock()
+	entry, cached := c.entries[endpoint]
+	c.mu.Unlock()
+
+	if cached && time.Since(entry.FetchedAt) < githubCacheTTL {
+		return decodeGitHubBody(entry.Body, v)
+	}
+
+	body, etag, err := c.fetch(endpoint, entry.ETag)
+	switch {
+	case err != nil && cached:
+		// Stale data is better than none, e.g. when offline or rate limited
+		return decodeGitHubBody(entry.Body, v)
+	case err != nil:
+		return err
+	case body == nil:
+		// Not modified since it was cached
+		entry.FetchedAt = time.Now()
+	default:
+		entry = githubCacheEntry{ETag: etag, FetchedAt: time.Now(), Body: body}
+	}
+
+	c.mu.Lock()
+	c.entries[endpoint] = entry
+	c.dirty = true
+	c.mu.Unlock()
+	return decodeGitHubBody(entry.Body, v)
+}
+
+// fetch requests an endpoint, conditionally if etag is set. The body is nil if
+// it was not modified.
+func (c *githubClient) fetch(endpoint, etag string) (json.RawMessage, string, error) {
+	resp, err := githubRequest(endpoint, "application/vnd.github+json", etag)
+	if err != nil {
+		return nil, "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, etag, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("read GitHub API response: %w", err)
+	}
+	if !json.Valid(body) {
+		return nil, "", fmt.Errorf("parse GitHub API response: invalid JSON from %s", endpoint)
+	}
+	return body, resp.Header.Get("ETag"), nil
+}
+
+// decodeGitHubBody decodes a cached or fetched response body into v
+func decodeGitHubBody(body json.RawMessage, v any) error {
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("parse GitHub API response: %w", err)
+	}
+	return nil
+}
+
+// save writes the cache if responses were fetched since it was read
+func (c *githubClient) save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.dirty {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(c.entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal GitHub cache: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return fmt.Errorf("create cache directory: %w", err)
+	}
+	if err := os.WriteFile(c.path, data, 0644); err != nil {
+		return fmt.Errorf("write GitHub cache: %w", err)
+	}
+	c.dirty = false
+	return nil
+}
@@ -0,0 +1,126 @@
+package repo
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"sync"
+	"time"
+)
+
+// metaWorkers is the number of packages whose metadata is fetched at once
+const metaWorkers = 4
+
+// PackageMeta is popularity and activity information about a package, from GitHub.
+type PackageMeta struct {
+	Stars     int       `json:"stars"`               // Stars of the repository
+	UpdatedAt time.Time `json:"updated_at,omitzero"` // Time of the last commit changing the package
+	Author    string    `json:"author,omitempty"`    // Author of that commit (GitHub login, or name)
+}
+
+// AddMeta sets the Meta of items browsed from a repository on GitHub, fetching
+// it through the GitHub API with responses cached in the data directory.
+// Repositories not on GitHub are left without metadata. If a request fails,
+// no more are sent, and the items fetched so far keep their metadata.
+func (s *Store) AddMeta(namespace string, items []BrowseItem) error {
+	config, err := s.Get(namespace)
+	if err != nil {
+		return err
+	}
+	if config.Owner == "" || config.Repo == "" || len(items) == 0 {
+		return nil
+	}
+
+	client, err := s.githubClient()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.save() }()
+
+	var info struct {
+		Stars int `json:"stargazers_count"`
+	}
+	if err := client.getJSON(fmt.Sprintf("/repos/%s/%s", config.Owner, config.Repo), &info); err != nil {
+		return fmt.Errorf("fetch %s/%s: %w", config.Owner, config.Repo, err)
+	}
+
+	// Stars are the repository's, so every item has them even if its history is not fetched
+	for i := range items {
+		items[i].Meta = &PackageMeta{Stars: info.Stars}
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	jobs := make(chan int)
+	for w := 0; w < metaWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				mu.Lock()
+				failed := firstErr != nil
+				mu.Unlock()
+				if failed {
+					continue
+				}
+
+				meta, err := lastChange(client, config, items[i].Path)
+				mu.Lock()
+				switch {
+				case err != nil && firstErr == nil:
+					firstErr = fmt.Errorf("fetch history of %s: %w", items[i].Path, err)
+				case err == nil:
+					items[i].Meta.UpdatedAt = meta.UpdatedAt
+					items[i].Meta.Author = meta.Author
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for i := range items {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return firstErr
+}
+
+// lastChange returns the time and author of the last commit changing a
+// package, on the repository's tracked branch
+func lastChange(client *githubClient, config *RepoConfig, rel string) (PackageMeta, error) {
+	query := url.Values{"path": {path.Join(config.Root, rel)}, "per_page": {"1"}}
+	if branch := config.TrackedBranch(); branch != "" {
+		query.Set("sha", branch)
+	}
+
+	var commits []struct {
+		Author *struct {
+			Login string `json:"login"`
+		} `json:"author"`
+		Commit struct {
+			Author struct {
+				Name string `json:"name"`
+			} `json:"author"`
+			Committer struct {
+				Date time.Time `json:"date"`
+			} `json:"committer"`
+		} `json:"commit"`
+	}
+	endpoint := fmt.Sprintf("/repos/%s/%s/commits?%s", config.Owner, config.Repo, query.Encode())
+	if err := client.getJSON(endpoint, &commits); err != nil {
+		return PackageMeta{}, err
+	}
+	if len(commits) == 0 {
+		return PackageMeta{}, nil
+	}
+
+	c := commits[0]
+	meta := PackageMeta{UpdatedAt: c.Commit.Committer.Date, Author: c.Commit.Author.Name}
+	if c.Author != nil && c.Author.Login != "" {
+		meta.Author = c.Author.Login
+	}
+	return meta, nil
+}
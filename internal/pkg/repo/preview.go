@@ -0,0 +1,220 @@
+package repo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// githubAPIURL is the base URL of the GitHub REST API (a variable for tests).
+var githubAPIURL = "https://api.github.com"
+
+// RemoteTree is the file listing of a GitHub repository branch, fetched through the
+// GitHub API without cloning it.
+type RemoteTree struct {
+	Owner       string
+	Repo        string
+	Branch      string
+	Description string
+	Files       []string // Slash-separated paths of every file
+	Truncated   bool     // GitHub truncated the listing of a very large repository
+}
+
+// FetchRemoteTree lists the files of a GitHub repository through the GitHub API.
+// An empty branch means the repository's default branch. GITHUB_TOKEN is used if set.
+func FetchRemoteTree(owner, repo, branch string) (*RemoteTree, error) {
+	var info struct {
+		Description   string `json:"description"`
+		DefaultBranch string `json:"default_branch"`
+	}
+	if err := githubGetJSON(fmt.Sprintf("/repos/%s/%s", owner, repo), &info); err != nil {
+		return nil, err
+	}
+	if branch == "" {
+		branch = info.DefaultBranch
+	}
+
+	var tree struct {
+		Tree []struct {
+			Path string `json:"path"`
+			Type string `json:"type"`
+		} `json:"tree"`
+		Truncated bool `json:"truncated"`
+	}
+	if err := githubGetJSON(fmt.Sprintf("/repos/%s/%s/git/trees/%s?recursive=1", owner, repo, url.PathEscape(branch)), &tree); err != nil {
+		return nil, err
+	}
+
+	t := &RemoteTree{Owner: owner, Repo: repo, Branch: branch, Description: info.Description, Truncated: tree.Truncated}
+	for _, entry := range tree.Tree {
+		if entry.Type == "blob" {
+			t.Files = append(t.Files, entry.Path)
+		}
+	}
+	return t, nil
+}
+
+// FetchFile returns the content of a file in the tree's branch through the GitHub API.
+func (t *RemoteTree) FetchFile(filePath string) ([]byte, error) {
+	escaped := strings.Split(filePath, "/")
+	for i, part := range escaped {
+		escaped[i] = url.PathEscape(part)
+	}
+	endpoint := fmt.Sprintf("/repos/%s/%s/contents/%s?ref=%s", t.Owner, t.Repo, strings.Join(escaped, "/"), url.QueryEscape(t.Branch))
+
+	resp, err := githubGet(endpoint, "application/vnd.github.raw")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	return io.ReadAll(resp.Body)
+}
+
+// Packages returns the packages in the tree, found the way ScanPackagesWithLayout finds
+// them in a clone. root is the sub-path packages are discovered under (empty: the
+// repository root); item paths are relative to it.
+func (t *RemoteTree) Packages(root string, layout Layout, typeFilter PackageType) []BrowseItem {
+	root = strings.Trim(root, "/")
+
+	// Files and directories relative to root
+	var files []string
+	dirs := make(map[string]bool)
+	for _, f := range t.Files {
+		if root != "" {
+			if !strings.HasPrefix(f, root+"/") {
+				continue
+			}
+			f = strings.TrimPrefix(f, root+"/")
+		}
+		files = append(files, f)
+		for d := path.Dir(f); d != "."; d = path.Dir(d) {
+			dirs[d] = true
+		}
+	}
+	sort.Strings(files)
+
+	var items []BrowseItem
+	for _, pkgType := range PackageTypes {
+		if typeFilter != "" && typeFilter != pkgType {
+			continue
+		}
+		for _, dir := range matchTreeDirs(dirs, layout.Dirs(pkgType)) {
+			items = append(items, treePackages(files, dir, pkgType)...)
+		}
+	}
+	return items
+}
+
+// matchTreeDirs returns the directories matching layout globs, in glob order
+func matchTreeDirs(dirs map[string]bool, globs []string) []string {
+	var matched []string
+	seen := make(map[string]bool)
+	for _, glob := range globs {
+		var found []string
+		for dir := range dirs {
+			if ok, err := path.Match(glob, dir); err == nil && ok && !seen[dir] {
+				seen[dir] = true
+				found = append(found, dir)
+			}
+		}
+		sort.Strings(found)
+		matched = append(matched, found...)
+	}
+	return matched
+}
+
+// treePackages returns the packages of pkgType in dir: skill directories with a
+// SKILL.md, command and agent .md files at any depth, and hook files
+func treePackages(files []string, dir string, pkgType PackageType) []BrowseItem {
+	var items []BrowseItem
+	seen := make(map[string]bool)
+	for _, f := range files {
+		rel, ok := strings.CutPrefix(f, dir+"/")
+		if !ok {
+			continue
+		}
+
+		switch pkgType {
+		case TypeSkill:
+			name, file, ok := strings.Cut(rel, "/")
+			if !ok || (file != "SKILL.md" && file != "skill.md") || seen[name] {
+				continue
+			}
+			seen[name] = true
+			items = append(items, BrowseItem{Name: name, Path: dir + "/" + name, Type: pkgType, Dir: dir})
+		case TypeCommand, TypeAgent:
+			if !strings.HasSuffix(rel, ".md") {
+				continue
+			}
+			name := strings.ReplaceAll(strings.TrimSuffix(rel, ".md"), "/", ":")
+			items = append(items, BrowseItem{Name: name, Path: f, Type: pkgType, Dir: dir})
+		case TypeHook:
+			if strings.Contains(rel, "/") {
+				continue
+			}
+			items = append(items, BrowseItem{Name: rel, Path: f, Type: pkgType, Dir: dir})
+		}
+	}
+	return items
+}
+
+// githubGetJSON decodes the response of a GitHub API GET request into v
+func githubGetJSON(endpoint string, v interface{}) error {
+	resp, err := githubGet(endpoint, "application/vnd.github+json")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("parse GitHub API response: %w", err)
+	}
+	return nil
+}
+
+// githubGet sends a GitHub API GET request. Non-200 responses are returned as errors.
+func githubGet(endpoint, accept string) (*http.Response, error) {
+	return githubRequest(endpoint, accept, "")
+}
+
+// githubRequest sends a GitHub API GET request. With an etag, the request is
+// conditional and a 304 Not Modified response is returned too; other non-200
+// responses are returned as errors.
+func githubRequest(endpoint, accept, etag string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, githubAPIURL+endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", accept)
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("GitHub API request: %w", err)
+	}
+	if resp.StatusCode == http.StatusOK || (etag != "" && resp.StatusCode == http.StatusNotModified) {
+		return resp, nil
+	}
+	_ = resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("not found on GitHub (private repositories need GITHUB_TOKEN): %s", endpoint)
+	case (resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests) && resp.Header.Get("X-RateLimit-Remaining") == "0":
+		return nil, fmt.Errorf("GitHub API rate limit exceeded; try again later or set GITHUB_TOKEN")
+	default:
+		return nil, fmt.Errorf("GitHub API request: %s", resp.Status)
+	}
+}
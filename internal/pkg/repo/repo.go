@@ -6,12 +6,14 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
 	"strings"
 	"time"
 
 	"github.com/itda-skills/jindo/internal/pkg/git"
+	"github.com/itda-skills/jindo/internal/progress"
 )
 
 const (
@@ -26,14 +28,30 @@ var (
 	ErrRepoNotFound = errors.New("repository not found")
 	// ErrInvalidURL is returned when the URL format is invalid.
 	ErrInvalidURL = errors.New("invalid repository URL format")
+	// ErrLinkedBranch is returned when selecting a branch for a linked repository.
+	ErrLinkedBranch = errors.New("linked repositories use the checked-out branch of the live checkout")
+	// ErrPackageNotFound is returned when no package exists at a path in a repository.
+	ErrPackageNotFound = errors.New("package not found in repository")
+	// ErrTemplateNotFound is returned when a repository has no template with a name.
+	ErrTemplateNotFound = errors.New("template not found in repository")
+	// ErrCloneBroken is returned when a repository's clone is missing or its git state is
+	// corrupt, e.g. after an interrupted clone or pull.
+	ErrCloneBroken = errors.New("repository clone is broken")
 )
 
 // ghURLRegex matches gh:owner/repo format.
 var ghURLRegex = regexp.MustCompile(`^gh:([a-zA-Z0-9_-]+)/([a-zA-Z0-9_.-]+)$`)
 
+// fileURLPrefix is the prefix for repositories added from a local path.
+const fileURLPrefix = "file://"
+
+// invalidNamespaceChars matches characters not allowed in a namespace.
+var invalidNamespaceChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
 // Store manages repository registrations.
 type Store struct {
-	baseDir string
+	baseDir  string
+	progress progress.Reporter // Reports clone progress; nil prints git's own output
 }
 
 // NewStore creates a new repository store.
@@ -41,6 +59,19 @@ func NewStore(baseDir string) *Store {
 	return &Store{baseDir: baseDir}
 }
 
+// SetProgress sets the reporter for clone progress.
+func (s *Store) SetProgress(r progress.Reporter) {
+	s.progress = r
+}
+
+// clone clones url into localPath, reporting progress if a reporter is set.
+func (s *Store) clone(url, localPath, branch string) error {
+	if s.progress == nil {
+		return git.Clone(url, localPath, branch)
+	}
+	return git.CloneWithProgress(url, localPath, branch, s.progress)
+}
+
 // expandDir expands ~ to home directory.
 func (s *Store) expandDir() (string, error) {
 	dir := s.baseDir
@@ -81,6 +112,20 @@ func (s *Store) RepoLocalPath(namespace string) (string, error) {
 	return filepath.Join(reposDir, namespace), nil
 }
 
+// PackageRoot returns the local directory packages of a repository are discovered
+// under: the clone itself, or its configured root sub-path.
+func (s *Store) PackageRoot(namespace string) (string, error) {
+	config, err := s.Get(namespace)
+	if err != nil {
+		return "", err
+	}
+	localPath, err := s.RepoLocalPath(namespace)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(localPath, filepath.FromSlash(config.Root)), nil
+}
+
 // load loads the repos file.
 func (s *Store) load() (*ReposFile, error) {
 	path, err := s.reposFilePath()
@@ -141,6 +186,39 @@ func ParseURL(url string) (owner, repo string, err error) {
 	return matches[1], matches[2], nil
 }
 
+// IsLocalURL reports whether url refers to a local path (file:///path).
+func IsLocalURL(url string) bool {
+	return strings.HasPrefix(url, fileURLPrefix)
+}
+
+// ParseLocalURL parses a file:///path URL and returns the absolute local path.
+func ParseLocalURL(url string) (string, error) {
+	if !IsLocalURL(url) {
+		return "", ErrInvalidURL
+	}
+
+	path := strings.TrimPrefix(url, fileURLPrefix)
+	if strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(home, path[2:])
+	}
+	if path == "" {
+		return "", ErrInvalidURL
+	}
+
+	return filepath.Abs(path)
+}
+
+// GenerateLocalNamespace generates a namespace from a local repository path.
+// Format: directory name, lowercased, with unsupported characters replaced by "-"
+func GenerateLocalNamespace(path string) string {
+	name := strings.ToLower(filepath.Base(path))
+	return strings.Trim(invalidNamespaceChars.ReplaceAllString(name, "-"), "-")
+}
+
 // GenerateNamespace generates a namespace from owner and repo.
 // Format: first 4 chars of owner + "-" + first 4 chars of repo
 func GenerateNamespace(owner, repo string) string {
@@ -157,6 +235,68 @@ func GenerateNamespace(owner, repo string) string {
 	return strings.ToLower(ownerPart + "-" + repoPart)
 }
 
+// NamespaceIssue describes what makes a namespace a poor choice, or returns "" if it is fine.
+// Installed packages are named <namespace>--<name>, so a namespace must not contain "--".
+func NamespaceIssue(namespace string) string {
+	switch {
+	case namespace == "":
+		return "is empty"
+	case invalidNamespaceChars.MatchString(namespace):
+		return "contains characters other than a-z, 0-9, and -"
+	case strings.Contains(namespace, "--"):
+		return `contains "--", which separates namespaces from package names`
+	case strings.HasPrefix(namespace, "-") || strings.HasSuffix(namespace, "-"):
+		return "starts or ends with -"
+	}
+	return ""
+}
+
+// cleanNamespace lowercases s and replaces unsupported characters and repeated hyphens with a single "-"
+func cleanNamespace(s string) string {
+	s = invalidNamespaceChars.ReplaceAllString(strings.ToLower(s), "-")
+	for strings.Contains(s, "--") {
+		s = strings.ReplaceAll(s, "--", "-")
+	}
+	return strings.Trim(s, "-")
+}
+
+// SuggestNamespaces returns free namespaces for a new repository, cleaned from candidates in
+// order. If the first candidate is taken, it is also offered with a numbered suffix (e.g., affa-ever-2).
+func (s *Store) SuggestNamespaces(candidates ...string) ([]string, error) {
+	repos, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	taken := make(map[string]bool)
+	for _, r := range repos.Repos {
+		taken[r.Namespace] = true
+	}
+
+	var base string
+	if len(candidates) > 0 {
+		base = cleanNamespace(candidates[0])
+	}
+	numbered := base != "" && taken[base]
+
+	var suggestions []string
+	for _, c := range candidates {
+		if ns := cleanNamespace(c); ns != "" && !taken[ns] {
+			suggestions = append(suggestions, ns)
+			taken[ns] = true
+		}
+	}
+
+	if numbered {
+		for i := 2; ; i++ {
+			if ns := fmt.Sprintf("%s-%d", base, i); !taken[ns] {
+				suggestions = append(suggestions, ns)
+				break
+			}
+		}
+	}
+	return suggestions, nil
+}
+
 // fetchGitHubDescription fetches the repository description from GitHub API.
 func fetchGitHubDescription(owner, repo string) string {
 	url := fmt.Sprintf("https://api.github.com/repos/%s/%s", owner, repo)
@@ -182,8 +322,79 @@ func fetchGitHubDescription(owner, repo string) string {
 	return result.Description
 }
 
+// AddOptions holds optional settings for registering a repository.
+type AddOptions struct {
+	Branch string // Branch to track (empty: the repository's default branch)
+	Root   string // Sub-path packages are discovered under (empty: the repository root)
+}
+
+// cleanRoot normalizes a package root to a clean slash-separated relative path.
+func cleanRoot(root string) (string, error) {
+	if root == "" {
+		return "", nil
+	}
+	cleaned := path.Clean(strings.Trim(filepath.ToSlash(root), "/"))
+	if cleaned == "." {
+		return "", nil
+	}
+	if cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("invalid root %q: must be inside the repository", root)
+	}
+	return cleaned, nil
+}
+
+// cleanLayout normalizes layout globs to slash-separated paths inside the package root.
+// Types left with no globs are dropped; an empty layout becomes nil.
+func cleanLayout(layout Layout) (Layout, error) {
+	cleaned := make(Layout)
+	for t, globs := range layout {
+		if _, ok := defaultLayout[t]; !ok {
+			return nil, fmt.Errorf("invalid package type %q", t)
+		}
+		var dirs []string
+		for _, glob := range globs {
+			dir, err := cleanRoot(glob)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s directory %q: must be inside the repository", t, glob)
+			}
+			if dir == "" {
+				continue
+			}
+			if _, err := path.Match(dir, ""); err != nil {
+				return nil, fmt.Errorf("invalid %s directory %q: %w", t, glob, err)
+			}
+			dirs = append(dirs, dir)
+		}
+		if len(dirs) > 0 {
+			cleaned[t] = dirs
+		}
+	}
+	if len(cleaned) == 0 {
+		return nil, nil
+	}
+	return cleaned, nil
+}
+
+// checkRoot verifies that root exists as a directory in the repository at localPath.
+func checkRoot(localPath, root string) error {
+	if root == "" {
+		return nil
+	}
+	info, err := os.Stat(filepath.Join(localPath, filepath.FromSlash(root)))
+	if err != nil || !info.IsDir() {
+		return fmt.Errorf("root directory not found in repository: %s", root)
+	}
+	return nil
+}
+
 // Add adds a new repository by cloning it locally.
-func (s *Store) Add(url, namespace string) (*RepoConfig, error) {
+func (s *Store) Add(url, namespace string, opts AddOptions) (*RepoConfig, error) {
+	root, err := cleanRoot(opts.Root)
+	if err != nil {
+		return nil, err
+	}
+	branch := opts.Branch
+
 	// Ensure git is installed
 	if err := git.EnsureInstalled(); err != nil {
 		return nil, err
@@ -226,15 +437,22 @@ func (s *Store) Add(url, namespace string) (*RepoConfig, error) {
 	gitURL := fmt.Sprintf("https://github.com/%s/%s.git", owner, repo)
 
 	fmt.Printf("Cloning %s...\n", gitURL)
-	if err := git.Clone(gitURL, localPath); err != nil {
+	if err := s.clone(gitURL, localPath, branch); err != nil {
 		return nil, fmt.Errorf("clone repository: %w", err)
 	}
+	if err := checkRoot(localPath, root); err != nil {
+		_ = os.RemoveAll(localPath)
+		return nil, err
+	}
 
 	// Get default branch
 	defaultBranch, err := git.GetDefaultBranch(localPath)
 	if err != nil {
 		defaultBranch = "main" // fallback
 	}
+	if branch == defaultBranch {
+		branch = ""
+	}
 
 	// Fetch description from GitHub API
 	description := fetchGitHubDescription(owner, repo)
@@ -245,6 +463,8 @@ func (s *Store) Add(url, namespace string) (*RepoConfig, error) {
 		Owner:         owner,
 		Repo:          repo,
 		DefaultBranch: defaultBranch,
+		Branch:        branch,
+		Root:          root,
 		Description:   description,
 		AddedAt:       time.Now().UTC(),
 	}
@@ -260,6 +480,112 @@ func (s *Store) Add(url, namespace string) (*RepoConfig, error) {
 	return &config, nil
 }
 
+// AddLocal adds a repository from a local filesystem path (file:///path).
+// With link, the repository is symlinked so installs always use the live checkout;
+// otherwise the local git repository is cloned like a remote one.
+func (s *Store) AddLocal(url, namespace string, link bool, opts AddOptions) (*RepoConfig, error) {
+	srcPath, err := ParseLocalURL(url)
+	if err != nil {
+		return nil, err
+	}
+	branch := opts.Branch
+	if link && branch != "" {
+		return nil, ErrLinkedBranch
+	}
+	root, err := cleanRoot(opts.Root)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkRoot(srcPath, root); err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(srcPath)
+	if err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("local repository not found: %s", srcPath)
+	}
+
+	if !link {
+		if err := git.EnsureInstalled(); err != nil {
+			return nil, err
+		}
+		if _, err := os.Stat(filepath.Join(srcPath, ".git")); err != nil {
+			return nil, fmt.Errorf("not a git repository: %s (use --link for a plain directory)", srcPath)
+		}
+	}
+
+	// Generate namespace if not provided
+	if namespace == "" {
+		namespace = GenerateLocalNamespace(srcPath)
+	}
+
+	// Load existing repos
+	repos, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	// Check for namespace conflict
+	for _, r := range repos.Repos {
+		if r.Namespace == namespace {
+			return nil, ErrNamespaceExists
+		}
+	}
+
+	// Create repos directory
+	reposDir, err := s.reposDir()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(reposDir, 0755); err != nil {
+		return nil, fmt.Errorf("create repos directory: %w", err)
+	}
+
+	localPath := filepath.Join(reposDir, namespace)
+	defaultBranch := ""
+
+	if link {
+		if err := os.Symlink(srcPath, localPath); err != nil {
+			return nil, fmt.Errorf("link repository: %w", err)
+		}
+		defaultBranch, _ = git.GetCurrentBranch(srcPath)
+	} else {
+		fmt.Printf("Cloning %s...\n", srcPath)
+		if err := s.clone(fileURLPrefix+srcPath, localPath, branch); err != nil {
+			return nil, fmt.Errorf("clone repository: %w", err)
+		}
+		defaultBranch, err = git.GetDefaultBranch(localPath)
+		if err != nil {
+			defaultBranch = "main" // fallback
+		}
+		if branch == defaultBranch {
+			branch = ""
+		}
+	}
+
+	config := RepoConfig{
+		Namespace:     namespace,
+		URL:           fileURLPrefix + srcPath,
+		Repo:          filepath.Base(srcPath),
+		DefaultBranch: defaultBranch,
+		Branch:        branch,
+		Root:          root,
+		Local:         true,
+		Link:          link,
+		AddedAt:       time.Now().UTC(),
+	}
+
+	repos.Repos = append(repos.Repos, config)
+
+	if err := s.save(repos); err != nil {
+		// Clean up clone or link on save failure (RemoveAll doesn't follow the link)
+		_ = os.RemoveAll(localPath)
+		return nil, err
+	}
+
+	return &config, nil
+}
+
 // List returns all registered repositories.
 func (s *Store) List() ([]RepoConfig, error) {
 	repos, err := s.load()
@@ -316,6 +642,132 @@ func (s *Store) Remove(namespace string) error {
 	return s.save(repos)
 }
 
+// SetBranch re-points a repository clone at branch and records it as the tracked branch.
+func (s *Store) SetBranch(namespace, branch string) (*RepoConfig, error) {
+	repos, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	idx := -1
+	for i, r := range repos.Repos {
+		if r.Namespace == namespace {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return nil, ErrRepoNotFound
+	}
+	if repos.Repos[idx].Link {
+		return nil, ErrLinkedBranch
+	}
+
+	if err := git.EnsureInstalled(); err != nil {
+		return nil, err
+	}
+
+	localPath, err := s.RepoLocalPath(namespace)
+	if err != nil {
+		return nil, err
+	}
+	if err := git.SwitchBranch(localPath, branch); err != nil {
+		return nil, fmt.Errorf("switch to branch %s: %w", branch, err)
+	}
+
+	if branch == repos.Repos[idx].DefaultBranch {
+		branch = ""
+	}
+	repos.Repos[idx].Branch = branch
+	if err := s.save(repos); err != nil {
+		return nil, err
+	}
+
+	return &repos.Repos[idx], nil
+}
+
+// SetLayout records the directories scanned for packages in a repository.
+// A nil or empty layout restores the default layout.
+func (s *Store) SetLayout(namespace string, layout Layout) (*RepoConfig, error) {
+	layout, err := cleanLayout(layout)
+	if err != nil {
+		return nil, err
+	}
+
+	repos, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range repos.Repos {
+		if repos.Repos[i].Namespace != namespace {
+			continue
+		}
+		repos.Repos[i].Layout = layout
+		if err := s.save(repos); err != nil {
+			return nil, err
+		}
+		return &repos.Repos[i], nil
+	}
+
+	return nil, ErrRepoNotFound
+}
+
+// SetTrusted marks whether hooks and commands from a repository install without a warning.
+func (s *Store) SetTrusted(namespace string, trusted bool) (*RepoConfig, error) {
+	repos, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range repos.Repos {
+		if repos.Repos[i].Namespace != namespace {
+			continue
+		}
+		repos.Repos[i].Trusted = trusted
+		if err := s.save(repos); err != nil {
+			return nil, err
+		}
+		return &repos.Repos[i], nil
+	}
+
+	return nil, ErrRepoNotFound
+}
+
+// OrphanedClones returns local clone directories with no registered repository.
+func (s *Store) OrphanedClones() ([]string, error) {
+	repos, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	registered := make(map[string]bool, len(repos.Repos))
+	for _, r := range repos.Repos {
+		registered[r.Namespace] = true
+	}
+
+	reposDir, err := s.reposDir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(reposDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var orphans []string
+	for _, entry := range entries {
+		if !registered[entry.Name()] {
+			orphans = append(orphans, filepath.Join(reposDir, entry.Name()))
+		}
+	}
+	return orphans, nil
+}
+
 // NamespaceExists checks if a namespace already exists.
 func (s *Store) NamespaceExists(namespace string) (bool, error) {
 	repos, err := s.load()
@@ -341,7 +793,8 @@ func (s *Store) refreshDescription(namespace string) error {
 
 	for i, r := range repos.Repos {
 		if r.Namespace == namespace {
-			if r.Description == "" {
+			// Local repositories have no GitHub description
+			if r.Description == "" && !r.Local {
 				desc := fetchGitHubDescription(r.Owner, r.Repo)
 				if desc != "" {
 					repos.Repos[i].Description = desc
@@ -356,7 +809,16 @@ func (s *Store) refreshDescription(namespace string) error {
 }
 
 // Update pulls the latest changes for a repository.
+// Linked local repositories are always live, so updating them is a no-op.
 func (s *Store) Update(namespace string) error {
+	config, err := s.Get(namespace)
+	if err != nil {
+		return err
+	}
+	if config.Link {
+		return nil
+	}
+
 	if err := git.EnsureInstalled(); err != nil {
 		return err
 	}
@@ -378,36 +840,141 @@ func (s *Store) Update(namespace string) error {
 	return s.refreshDescription(namespace)
 }
 
-// UpdateAll pulls the latest changes for all repositories.
-func (s *Store) UpdateAll() error {
+// UpdateAll pulls the latest changes for all repositories and returns the
+// namespaces of those that received new commits.
+func (s *Store) UpdateAll() ([]string, error) {
 	if err := git.EnsureInstalled(); err != nil {
-		return err
+		return nil, err
 	}
 
 	repos, err := s.List()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	var updated []string
 	for _, r := range repos {
+		if r.Link {
+			fmt.Printf("Skipping %s (linked to %s)\n", r.Namespace, strings.TrimPrefix(r.URL, fileURLPrefix))
+			continue
+		}
 		localPath, err := s.RepoLocalPath(r.Namespace)
 		if err != nil {
 			continue
 		}
 		fmt.Printf("Updating %s...\n", r.Namespace)
+		before, _ := git.GetCurrentCommit(localPath)
 		if err := git.PullQuiet(localPath); err != nil {
 			fmt.Printf("  Warning: failed to update %s: %v\n", r.Namespace, err)
+		} else if after, _ := git.GetCurrentCommit(localPath); after != before {
+			updated = append(updated, r.Namespace)
 		}
 		// Refresh description if missing
 		_ = s.refreshDescription(r.Namespace)
 	}
 
+	return updated, nil
+}
+
+// Head returns the commit a repository's clone is at
+func (s *Store) Head(namespace string) (string, error) {
+	localPath, err := s.RepoLocalPath(namespace)
+	if err != nil {
+		return "", err
+	}
+	return git.GetCurrentCommit(localPath)
+}
+
+// Check reports whether a repository's clone is usable, returning ErrCloneBroken
+// with the reason if it is missing or HEAD does not resolve. With thorough, every
+// object of the history is checked too, which reads the whole clone. Linked
+// repositories are the user's own checkout and are not checked.
+func (s *Store) Check(namespace string, thorough bool) error {
+	config, err := s.Get(namespace)
+	if err != nil {
+		return err
+	}
+	if config.Link {
+		return nil
+	}
+
+	localPath, err := s.RepoLocalPath(namespace)
+	if err != nil {
+		return err
+	}
+	// Without .git, git would look for a repository in the parent directories
+	if _, err := os.Stat(filepath.Join(localPath, ".git")); err != nil {
+		if _, err := os.Stat(localPath); os.IsNotExist(err) {
+			return fmt.Errorf("%w: clone not found at %s", ErrCloneBroken, localPath)
+		}
+		return fmt.Errorf("%w: %s is not a git repository", ErrCloneBroken, localPath)
+	}
+	if err := git.VerifyHead(localPath); err != nil {
+		return fmt.Errorf("%w: %v", ErrCloneBroken, err)
+	}
+	if thorough {
+		if err := git.Fsck(localPath); err != nil {
+			return fmt.Errorf("%w: %v", ErrCloneBroken, err)
+		}
+	}
+	return nil
+}
+
+// Reclone replaces a repository's clone with a fresh clone of its URL and tracked
+// branch, under the same namespace and settings. The old clone is kept aside until
+// the new one succeeds, and put back if it fails.
+func (s *Store) Reclone(namespace string) error {
+	config, err := s.Get(namespace)
+	if err != nil {
+		return err
+	}
+	if config.Link {
+		return fmt.Errorf("%s is linked to %s and has no clone to replace", namespace, strings.TrimPrefix(config.URL, fileURLPrefix))
+	}
+	if err := git.EnsureInstalled(); err != nil {
+		return err
+	}
+
+	localPath, err := s.RepoLocalPath(namespace)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(localPath), 0755); err != nil {
+		return fmt.Errorf("create repos directory: %w", err)
+	}
+
+	aside := localPath + ".broken"
+	_ = os.RemoveAll(aside)
+	if _, err := os.Lstat(localPath); err == nil {
+		if err := os.Rename(localPath, aside); err != nil {
+			return fmt.Errorf("move broken clone aside: %w", err)
+		}
+	}
+	restore := func() {
+		_ = os.RemoveAll(localPath)
+		if _, err := os.Lstat(aside); err == nil {
+			_ = os.Rename(aside, localPath)
+		}
+	}
+
+	fmt.Printf("Cloning %s...\n", config.URL)
+	if err := s.clone(config.URL, localPath, config.Branch); err != nil {
+		restore()
+		return fmt.Errorf("clone repository: %w", err)
+	}
+	if err := checkRoot(localPath, config.Root); err != nil {
+		restore()
+		return err
+	}
+
+	_ = os.RemoveAll(aside)
 	return nil
 }
 
 // Browse browses a repository for packages from local clone.
+// Only the repository's package root is scanned; item paths are relative to it.
 func (s *Store) Browse(namespace string, typeFilter PackageType) ([]BrowseItem, error) {
-	localPath, err := s.RepoLocalPath(namespace)
+	localPath, err := s.PackageRoot(namespace)
 	if err != nil {
 		return nil, err
 	}
@@ -416,50 +983,113 @@ func (s *Store) Browse(namespace string, typeFilter PackageType) ([]BrowseItem,
 		return nil, ErrRepoNotFound
 	}
 
+	config, err := s.Get(namespace)
+	if err != nil {
+		return nil, err
+	}
+	// The files of a clone left half-written would list packages that fail to install
+	if err := s.Check(namespace, false); err != nil {
+		return nil, err
+	}
+
+	items := ScanPackagesWithLayout(localPath, config.Layout, typeFilter)
+	for i := range items {
+		items[i].Namespace = namespace
+	}
+	return items, nil
+}
+
+// FindPackage returns the browse item for the package at path in a repository.
+func (s *Store) FindPackage(namespace, path string) (*BrowseItem, error) {
+	items, err := s.Browse(namespace, "")
+	if err != nil {
+		return nil, err
+	}
+	for i := range items {
+		if items[i].Path == path {
+			return &items[i], nil
+		}
+	}
+	return nil, ErrPackageNotFound
+}
+
+// ScanPackages scans a repository directory for packages using the default layout.
+// It is used for registered repositories as well as extracted package archives.
+func ScanPackages(repoPath string, typeFilter PackageType) []BrowseItem {
+	return ScanPackagesWithLayout(repoPath, nil, typeFilter)
+}
+
+// ScanPackagesWithLayout scans a repository directory for packages in the directories
+// matched by layout. Package types missing from layout use the default directories.
+func ScanPackagesWithLayout(repoPath string, layout Layout, typeFilter PackageType) []BrowseItem {
+	s := &Store{}
 	var items []BrowseItem
 
-	// Scan skills directory
+	// Scan skills directories
 	if typeFilter == "" || typeFilter == TypeSkill {
-		skillItems, _ := s.scanSkills(localPath)
+		skillItems, _ := s.scanSkills(layoutDirs(repoPath, layout.Dirs(TypeSkill)))
 		items = append(items, skillItems...)
 	}
 
-	// Scan commands directory
+	// Scan commands directories
 	if typeFilter == "" || typeFilter == TypeCommand {
-		cmdItems, _ := s.scanCommands(localPath)
+		cmdItems, _ := s.scanCommands(layoutDirs(repoPath, layout.Dirs(TypeCommand)))
 		items = append(items, cmdItems...)
 	}
 
-	// Scan agents directory
+	// Scan agents directories
 	if typeFilter == "" || typeFilter == TypeAgent {
-		agentItems, _ := s.scanAgents(localPath)
+		agentItems, _ := s.scanAgents(layoutDirs(repoPath, layout.Dirs(TypeAgent)))
 		items = append(items, agentItems...)
 	}
 
-	// Scan hooks directory
+	// Scan hooks directories
 	if typeFilter == "" || typeFilter == TypeHook {
-		hookItems, _ := s.scanHooks(localPath)
+		hookItems, _ := s.scanHooks(layoutDirs(repoPath, layout.Dirs(TypeHook)))
 		items = append(items, hookItems...)
 	}
 
-	return items, nil
+	return items
 }
 
-// scanSkills scans the skills directories for skill packages.
-// It checks both root-level skills/ and .claude/skills/ directories.
-func (s *Store) scanSkills(repoPath string) ([]BrowseItem, error) {
-	var items []BrowseItem
+// scanDir is a directory scanned for packages of one type.
+type scanDir struct {
+	dir    string // absolute directory path
+	rel    string // path relative to the package root, slash-separated
+	prefix string // rel with a trailing slash, prepended to package paths
+}
 
-	// Directories to scan: root-level and .claude/ subdirectory
-	scanDirs := []struct {
-		dir    string
-		prefix string
-	}{
-		{filepath.Join(repoPath, "skills"), "skills/"},
-		{filepath.Join(repoPath, ".claude", "skills"), ".claude/skills/"},
+// layoutDirs expands layout globs (relative to repoPath) into existing directories.
+func layoutDirs(repoPath string, globs []string) []scanDir {
+	var dirs []scanDir
+	seen := make(map[string]bool)
+	for _, glob := range globs {
+		matches, err := filepath.Glob(filepath.Join(repoPath, filepath.FromSlash(glob)))
+		if err != nil {
+			continue // Malformed pattern, skip
+		}
+		for _, dir := range matches {
+			info, err := os.Stat(dir)
+			if err != nil || !info.IsDir() || seen[dir] {
+				continue
+			}
+			seen[dir] = true
+			rel, err := filepath.Rel(repoPath, dir)
+			if err != nil {
+				continue
+			}
+			rel = filepath.ToSlash(rel)
+			dirs = append(dirs, scanDir{dir: dir, rel: rel, prefix: rel + "/"})
+		}
 	}
+	return dirs
+}
 
-	for _, sd := range scanDirs {
+// scanSkills scans the skills directories for skill packages.
+func (s *Store) scanSkills(dirs []scanDir) ([]BrowseItem, error) {
+	var items []BrowseItem
+
+	for _, sd := range dirs {
 		entries, err := os.ReadDir(sd.dir)
 		if err != nil {
 			continue // Directory doesn't exist, skip
@@ -478,6 +1108,7 @@ func (s *Store) scanSkills(repoPath string) ([]BrowseItem, error) {
 						Name: entry.Name(),
 						Path: sd.prefix + entry.Name(),
 						Type: TypeSkill,
+						Dir:  sd.rel,
 					})
 					break
 				}
@@ -489,147 +1120,56 @@ func (s *Store) scanSkills(repoPath string) ([]BrowseItem, error) {
 }
 
 // scanCommands scans the commands directories for command packages.
-// It checks both root-level commands/ and .claude/commands/ directories.
-// Supports one level of nesting (e.g., commands/game/init.md -> game:init).
-func (s *Store) scanCommands(repoPath string) ([]BrowseItem, error) {
+// Nested directories become colon-separated names at any depth
+// (e.g., commands/team/project/task.md -> team:project:task).
+func (s *Store) scanCommands(dirs []scanDir) ([]BrowseItem, error) {
 	var items []BrowseItem
-
-	// Directories to scan: root-level and .claude/ subdirectory
-	scanDirs := []struct {
-		dir    string
-		prefix string
-	}{
-		{filepath.Join(repoPath, "commands"), "commands/"},
-		{filepath.Join(repoPath, ".claude", "commands"), ".claude/commands/"},
-	}
-
-	for _, sd := range scanDirs {
-		entries, err := os.ReadDir(sd.dir)
-		if err != nil {
-			continue // Directory doesn't exist, skip
-		}
-
-		for _, entry := range entries {
-			if entry.IsDir() {
-				// Scan one level of subdirectory
-				subItems := s.scanCommandsSubdir(sd.dir, sd.prefix, entry.Name())
-				items = append(items, subItems...)
-				continue
-			}
-			if !strings.HasSuffix(entry.Name(), ".md") {
-				continue
-			}
-
-			name := strings.TrimSuffix(entry.Name(), ".md")
-			items = append(items, BrowseItem{
-				Name: name,
-				Path: sd.prefix + entry.Name(),
-				Type: TypeCommand,
-			})
-		}
+	for _, sd := range dirs {
+		items = append(items, s.scanMarkdownTree(sd, "", TypeCommand)...)
 	}
-
 	return items, nil
 }
 
-// scanCommandsSubdir scans a subdirectory within commands for .md files.
-// Files are named as "subdir:filename" (e.g., game:init for game/init.md).
-func (s *Store) scanCommandsSubdir(parentDir, prefix, subdir string) []BrowseItem {
-	var items []BrowseItem
-
-	subdirPath := filepath.Join(parentDir, subdir)
-	entries, err := os.ReadDir(subdirPath)
-	if err != nil {
-		return nil
-	}
-
-	for _, entry := range entries {
-		if entry.IsDir() {
-			continue // Only one level of nesting supported
-		}
-		if !strings.HasSuffix(entry.Name(), ".md") {
-			continue
-		}
-
-		baseName := strings.TrimSuffix(entry.Name(), ".md")
-		items = append(items, BrowseItem{
-			Name: subdir + ":" + baseName,
-			Path: prefix + subdir + "/" + entry.Name(),
-			Type: TypeCommand,
-		})
-	}
-
-	return items
-}
-
 // scanAgents scans the agents directories for agent packages.
-// It checks both root-level agents/ and .claude/agents/ directories.
-// Supports one level of nesting (e.g., agents/dev/tester.md -> dev:tester).
-func (s *Store) scanAgents(repoPath string) ([]BrowseItem, error) {
+// Nested directories become colon-separated names at any depth
+// (e.g., agents/dev/qa/tester.md -> dev:qa:tester).
+func (s *Store) scanAgents(dirs []scanDir) ([]BrowseItem, error) {
 	var items []BrowseItem
-
-	// Directories to scan: root-level and .claude/ subdirectory
-	scanDirs := []struct {
-		dir    string
-		prefix string
-	}{
-		{filepath.Join(repoPath, "agents"), "agents/"},
-		{filepath.Join(repoPath, ".claude", "agents"), ".claude/agents/"},
+	for _, sd := range dirs {
+		items = append(items, s.scanMarkdownTree(sd, "", TypeAgent)...)
 	}
-
-	for _, sd := range scanDirs {
-		entries, err := os.ReadDir(sd.dir)
-		if err != nil {
-			continue // Directory doesn't exist, skip
-		}
-
-		for _, entry := range entries {
-			if entry.IsDir() {
-				// Scan one level of subdirectory
-				subItems := s.scanAgentsSubdir(sd.dir, sd.prefix, entry.Name())
-				items = append(items, subItems...)
-				continue
-			}
-			if !strings.HasSuffix(entry.Name(), ".md") {
-				continue
-			}
-
-			name := strings.TrimSuffix(entry.Name(), ".md")
-			items = append(items, BrowseItem{
-				Name: name,
-				Path: sd.prefix + entry.Name(),
-				Type: TypeAgent,
-			})
-		}
-	}
-
 	return items, nil
 }
 
-// scanAgentsSubdir scans a subdirectory within agents for .md files.
-// Files are named as "subdir:filename" (e.g., dev:tester for dev/tester.md).
-func (s *Store) scanAgentsSubdir(parentDir, prefix, subdir string) []BrowseItem {
+// scanMarkdownTree recursively scans subdir (slash-separated, relative to sd) for .md files.
+// Each directory level is prepended to the package name with a colon.
+func (s *Store) scanMarkdownTree(sd scanDir, subdir string, pkgType PackageType) []BrowseItem {
 	var items []BrowseItem
 
-	subdirPath := filepath.Join(parentDir, subdir)
-	entries, err := os.ReadDir(subdirPath)
+	entries, err := os.ReadDir(filepath.Join(sd.dir, filepath.FromSlash(subdir)))
 	if err != nil {
-		return nil
+		return nil // Directory doesn't exist, skip
 	}
 
 	for _, entry := range entries {
+		relPath := entry.Name()
+		if subdir != "" {
+			relPath = subdir + "/" + entry.Name()
+		}
+
 		if entry.IsDir() {
-			continue // Only one level of nesting supported
+			items = append(items, s.scanMarkdownTree(sd, relPath, pkgType)...)
+			continue
 		}
 		if !strings.HasSuffix(entry.Name(), ".md") {
 			continue
 		}
 
-		baseName := strings.TrimSuffix(entry.Name(), ".md")
 		items = append(items, BrowseItem{
-			Name: subdir + ":" + baseName,
-			Path: prefix + subdir + "/" + entry.Name(),
-			Type: TypeAgent,
+			Name: strings.ReplaceAll(strings.TrimSuffix(relPath, ".md"), "/", ":"),
+			Path: sd.prefix + relPath,
+			Type: pkgType,
+			Dir:  sd.rel,
 		})
 	}
 
@@ -637,20 +1177,10 @@ func (s *Store) scanAgentsSubdir(parentDir, prefix, subdir string) []BrowseItem
 }
 
 // scanHooks scans the hooks directories for hook packages.
-// It checks both root-level hooks/ and .claude/hooks/ directories.
-func (s *Store) scanHooks(repoPath string) ([]BrowseItem, error) {
+func (s *Store) scanHooks(dirs []scanDir) ([]BrowseItem, error) {
 	var items []BrowseItem
 
-	// Directories to scan: root-level and .claude/ subdirectory
-	scanDirs := []struct {
-		dir    string
-		prefix string
-	}{
-		{filepath.Join(repoPath, "hooks"), "hooks/"},
-		{filepath.Join(repoPath, ".claude", "hooks"), ".claude/hooks/"},
-	}
-
-	for _, sd := range scanDirs {
+	for _, sd := range dirs {
 		entries, err := os.ReadDir(sd.dir)
 		if err != nil {
 			continue // Directory doesn't exist, skip
@@ -666,6 +1196,7 @@ func (s *Store) scanHooks(repoPath string) ([]BrowseItem, error) {
 				Name: name,
 				Path: sd.prefix + name,
 				Type: TypeHook,
+				Dir:  sd.rel,
 			})
 		}
 	}
@@ -1,8 +1,14 @@
 package repo
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"reflect"
 	"sort"
 	"testing"
 )
@@ -123,7 +129,7 @@ func TestScanSkills(t *testing.T) {
 			tt.setup(t, repoPath)
 
 			store := NewStore(repoPath)
-			items, err := store.scanSkills(repoPath)
+			items, err := store.scanSkills(layoutDirs(repoPath, defaultLayout[TypeSkill]))
 			if err != nil {
 				t.Fatalf("scanSkills failed: %v", err)
 			}
@@ -212,13 +218,16 @@ func TestScanCommands(t *testing.T) {
 			},
 		},
 		{
-			name: "deeply nested commands (only one level supported)",
+			name: "deeply nested commands",
 			setup: func(t *testing.T, repoPath string) {
 				createFile(t, filepath.Join(repoPath, "commands", "a", "b", "deep.md"), "# Deep")
 				createFile(t, filepath.Join(repoPath, "commands", "a", "shallow.md"), "# Shallow")
+				createFile(t, filepath.Join(repoPath, "commands", "team", "project", "sub", "task.md"), "# Task")
 			},
 			expected: []BrowseItem{
+				{Name: "a:b:deep", Path: "commands/a/b/deep.md", Type: TypeCommand},
 				{Name: "a:shallow", Path: "commands/a/shallow.md", Type: TypeCommand},
+				{Name: "team:project:sub:task", Path: "commands/team/project/sub/task.md", Type: TypeCommand},
 			},
 		},
 		{
@@ -253,7 +262,7 @@ func TestScanCommands(t *testing.T) {
 			tt.setup(t, repoPath)
 
 			store := NewStore(repoPath)
-			items, err := store.scanCommands(repoPath)
+			items, err := store.scanCommands(layoutDirs(repoPath, defaultLayout[TypeCommand]))
 			if err != nil {
 				t.Fatalf("scanCommands failed: %v", err)
 			}
@@ -345,6 +354,15 @@ func TestScanAgents(t *testing.T) {
 				{Name: "team:member", Path: ".claude/agents/team/member.md", Type: TypeAgent},
 			},
 		},
+		{
+			name: "deeply nested agents",
+			setup: func(t *testing.T, repoPath string) {
+				createFile(t, filepath.Join(repoPath, "agents", "dev", "qa", "tester.md"), "# Tester")
+			},
+			expected: []BrowseItem{
+				{Name: "dev:qa:tester", Path: "agents/dev/qa/tester.md", Type: TypeAgent},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -355,7 +373,7 @@ func TestScanAgents(t *testing.T) {
 			tt.setup(t, repoPath)
 
 			store := NewStore(repoPath)
-			items, err := store.scanAgents(repoPath)
+			items, err := store.scanAgents(layoutDirs(repoPath, defaultLayout[TypeAgent]))
 			if err != nil {
 				t.Fatalf("scanAgents failed: %v", err)
 			}
@@ -451,7 +469,7 @@ func TestScanHooks(t *testing.T) {
 			tt.setup(t, repoPath)
 
 			store := NewStore(repoPath)
-			items, err := store.scanHooks(repoPath)
+			items, err := store.scanHooks(layoutDirs(repoPath, defaultLayout[TypeHook]))
 			if err != nil {
 				t.Fatalf("scanHooks failed: %v", err)
 			}
@@ -543,19 +561,19 @@ func TestBrowse(t *testing.T) {
 			var items []BrowseItem
 
 			if tt.typeFilter == "" || tt.typeFilter == TypeSkill {
-				skillItems, _ := store.scanSkills(repoPath)
+				skillItems, _ := store.scanSkills(layoutDirs(repoPath, defaultLayout[TypeSkill]))
 				items = append(items, skillItems...)
 			}
 			if tt.typeFilter == "" || tt.typeFilter == TypeCommand {
-				cmdItems, _ := store.scanCommands(repoPath)
+				cmdItems, _ := store.scanCommands(layoutDirs(repoPath, defaultLayout[TypeCommand]))
 				items = append(items, cmdItems...)
 			}
 			if tt.typeFilter == "" || tt.typeFilter == TypeAgent {
-				agentItems, _ := store.scanAgents(repoPath)
+				agentItems, _ := store.scanAgents(layoutDirs(repoPath, defaultLayout[TypeAgent]))
 				items = append(items, agentItems...)
 			}
 			if tt.typeFilter == "" || tt.typeFilter == TypeHook {
-				hookItems, _ := store.scanHooks(repoPath)
+				hookItems, _ := store.scanHooks(layoutDirs(repoPath, defaultLayout[TypeHook]))
 				items = append(items, hookItems...)
 			}
 
@@ -566,3 +584,679 @@ func TestBrowse(t *testing.T) {
 		})
 	}
 }
+
+func TestParseLocalURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		want    string
+		wantErr bool
+	}{
+		{name: "absolute path", url: "file:///tmp/skills", want: "/tmp/skills"},
+		{name: "trailing slash", url: "file:///tmp/skills/", want: "/tmp/skills"},
+		{name: "github url", url: "gh:owner/repo", wantErr: true},
+		{name: "empty path", url: "file://", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLocalURL(tt.url)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLocalURL(%q) error = %v, wantErr %v", tt.url, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseLocalURL(%q) = %q, want %q", tt.url, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateLocalNamespace(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "/home/me/team-skills", want: "team-skills"},
+		{path: "/home/me/My_Skills.v2", want: "my-skills-v2"},
+	}
+
+	for _, tt := range tests {
+		if got := GenerateLocalNamespace(tt.path); got != tt.want {
+			t.Errorf("GenerateLocalNamespace(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestNamespaceIssue(t *testing.T) {
+	tests := []struct {
+		namespace string
+		wantIssue bool
+	}{
+		{namespace: "affa-ever", wantIssue: false},
+		{namespace: "team2", wantIssue: false},
+		{namespace: "", wantIssue: true},
+		{namespace: "my.r", wantIssue: true},
+		{namespace: "ab--repo", wantIssue: true},
+		{namespace: "team-", wantIssue: true},
+	}
+
+	for _, tt := range tests {
+		if got := NamespaceIssue(tt.namespace); (got != "") != tt.wantIssue {
+			t.Errorf("NamespaceIssue(%q) = %q, want issue %v", tt.namespace, got, tt.wantIssue)
+		}
+	}
+}
+
+func TestSuggestNamespaces(t *testing.T) {
+	baseDir := setupTestRepo(t)
+	defer func() { _ = os.RemoveAll(baseDir) }()
+	srcPath := setupTestRepo(t)
+	defer func() { _ = os.RemoveAll(srcPath) }()
+
+	store := NewStore(baseDir)
+	candidates := []string{"affa-ever", "everything-claude-code", "affaan-m-everything-claude-code"}
+
+	got, err := store.SuggestNamespaces(candidates...)
+	if err != nil {
+		t.Fatalf("SuggestNamespaces() error: %v", err)
+	}
+	if want := candidates; !reflect.DeepEqual(got, want) {
+		t.Errorf("SuggestNamespaces() = %v, want %v", got, want)
+	}
+
+	for _, ns := range []string{"affa-ever", "affa-ever-2"} {
+		if _, err := store.AddLocal("file://"+srcPath, ns, true, AddOptions{}); err != nil {
+			t.Fatalf("AddLocal() error: %v", err)
+		}
+	}
+
+	got, err = store.SuggestNamespaces("affa-ever", "My.Repo", "ab--my.repo")
+	if err != nil {
+		t.Fatalf("SuggestNamespaces() error: %v", err)
+	}
+	want := []string{"my-repo", "ab-my-repo", "affa-ever-3"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SuggestNamespaces() = %v, want %v", got, want)
+	}
+}
+
+func TestAddLocalLink(t *testing.T) {
+	baseDir := setupTestRepo(t)
+	defer func() { _ = os.RemoveAll(baseDir) }()
+	srcPath := setupTestRepo(t)
+	defer func() { _ = os.RemoveAll(srcPath) }()
+
+	createFile(t, filepath.Join(srcPath, "skills", "my-skill", "SKILL.md"), "# Skill")
+
+	store := NewStore(baseDir)
+	config, err := store.AddLocal("file://"+srcPath, "myteam", true, AddOptions{})
+	if err != nil {
+		t.Fatalf("AddLocal() error: %v", err)
+	}
+	if !config.Local || !config.Link {
+		t.Errorf("AddLocal() config = %+v, want Local and Link", config)
+	}
+
+	// Linked repositories are browsed live from the source directory
+	createFile(t, filepath.Join(srcPath, "commands", "my-cmd.md"), "# Cmd")
+	items, err := store.Browse("myteam", "")
+	if err != nil {
+		t.Fatalf("Browse() error: %v", err)
+	}
+	if len(items) != 2 {
+		t.Errorf("Browse() returned %d items, want 2", len(items))
+	}
+
+	// Update is a no-op for linked repositories
+	if err := store.Update("myteam"); err != nil {
+		t.Errorf("Update() error: %v", err)
+	}
+
+	// Remove deletes the link but keeps the source directory
+	if err := store.Remove("myteam"); err != nil {
+		t.Fatalf("Remove() error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(srcPath, "skills", "my-skill", "SKILL.md")); err != nil {
+		t.Errorf("source directory was modified by Remove(): %v", err)
+	}
+}
+
+func TestOrphanedClones(t *testing.T) {
+	baseDir := setupTestRepo(t)
+	defer func() { _ = os.RemoveAll(baseDir) }()
+	srcPath := setupTestRepo(t)
+	defer func() { _ = os.RemoveAll(srcPath) }()
+
+	store := NewStore(baseDir)
+
+	// No repos directory yet
+	orphans, err := store.OrphanedClones()
+	if err != nil {
+		t.Fatalf("OrphanedClones() error: %v", err)
+	}
+	if len(orphans) != 0 {
+		t.Errorf("OrphanedClones() = %v, want none", orphans)
+	}
+
+	if _, err := store.AddLocal("file://"+srcPath, "myteam", true, AddOptions{}); err != nil {
+		t.Fatalf("AddLocal() error: %v", err)
+	}
+	createDir(t, filepath.Join(baseDir, reposDirName, "stale"))
+
+	orphans, err = store.OrphanedClones()
+	if err != nil {
+		t.Fatalf("OrphanedClones() error: %v", err)
+	}
+	want := filepath.Join(baseDir, reposDirName, "stale")
+	if len(orphans) != 1 || orphans[0] != want {
+		t.Errorf("OrphanedClones() = %v, want [%s]", orphans, want)
+	}
+}
+
+func TestAddLocalBranch(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	baseDir := setupTestRepo(t)
+	defer func() { _ = os.RemoveAll(baseDir) }()
+	srcPath := setupTestRepo(t)
+	defer func() { _ = os.RemoveAll(srcPath) }()
+
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", srcPath}, args...)...)
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@t", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@t")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	// main has a skill, develop adds a command
+	git("init", "--quiet", "--initial-branch", "main")
+	createFile(t, filepath.Join(srcPath, "skills", "my-skill", "SKILL.md"), "# Skill")
+	git("add", "-A")
+	git("commit", "--quiet", "-m", "main")
+	git("checkout", "--quiet", "-b", "develop")
+	createFile(t, filepath.Join(srcPath, "commands", "my-cmd.md"), "# Cmd")
+	git("add", "-A")
+	git("commit", "--quiet", "-m", "develop")
+	git("checkout", "--quiet", "main")
+
+	store := NewStore(baseDir)
+	config, err := store.AddLocal("file://"+srcPath, "myteam", false, AddOptions{Branch: "develop"})
+	if err != nil {
+		t.Fatalf("AddLocal() error: %v", err)
+	}
+	if config.DefaultBranch != "main" || config.TrackedBranch() != "develop" {
+		t.Errorf("AddLocal() branches = %q/%q, want main/develop", config.DefaultBranch, config.TrackedBranch())
+	}
+	items, err := store.Browse("myteam", "")
+	if err != nil {
+		t.Fatalf("Browse() error: %v", err)
+	}
+	if len(items) != 2 {
+		t.Errorf("Browse() on develop returned %d items, want 2", len(items))
+	}
+
+	// Switching back to the default branch clears the override
+	config, err = store.SetBranch("myteam", "main")
+	if err != nil {
+		t.Fatalf("SetBranch() error: %v", err)
+	}
+	if config.Branch != "" || config.TrackedBranch() != "main" {
+		t.Errorf("SetBranch() config = %+v, want tracking default main", config)
+	}
+	items, err = store.Browse("myteam", "")
+	if err != nil {
+		t.Fatalf("Browse() error: %v", err)
+	}
+	if len(items) != 1 {
+		t.Errorf("Browse() on main returned %d items, want 1", len(items))
+	}
+
+	if _, err := store.SetBranch("myteam", "missing"); err == nil {
+		t.Error("SetBranch() to a missing branch should fail")
+	}
+	if _, err := store.AddLocal("file://"+srcPath, "linked", true, AddOptions{Branch: "develop"}); !errors.Is(err, ErrLinkedBranch) {
+		t.Errorf("AddLocal() with link and branch error = %v, want ErrLinkedBranch", err)
+	}
+}
+
+func TestCheckAndReclone(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	baseDir := setupTestRepo(t)
+	defer func() { _ = os.RemoveAll(baseDir) }()
+	srcPath := setupTestRepo(t)
+	defer func() { _ = os.RemoveAll(srcPath) }()
+
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", srcPath}, args...)...)
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@t", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@t")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "--quiet", "--initial-branch", "main")
+	createFile(t, filepath.Join(srcPath, "skills", "my-skill", "SKILL.md"), "# Skill")
+	git("add", "-A")
+	git("commit", "--quiet", "-m", "main")
+
+	store := NewStore(baseDir)
+	if _, err := store.AddLocal("file://"+srcPath, "myteam", false, AddOptions{}); err != nil {
+		t.Fatalf("AddLocal() error: %v", err)
+	}
+	if err := store.Check("myteam", true); err != nil {
+		t.Fatalf("Check() on a fresh clone error: %v", err)
+	}
+
+	// A clone whose HEAD points at a missing commit, as an interrupted pull can leave it
+	localPath, _ := store.RepoLocalPath("myteam")
+	head := filepath.Join(localPath, ".git", "refs", "heads", "main")
+	createFile(t, head, "0123456789012345678901234567890123456789\n")
+	if err := store.Check("myteam", false); !errors.Is(err, ErrCloneBroken) {
+		t.Errorf("Check() on a broken clone error = %v, want ErrCloneBroken", err)
+	}
+	if _, err := store.Browse("myteam", ""); !errors.Is(err, ErrCloneBroken) {
+		t.Errorf("Browse() on a broken clone error = %v, want ErrCloneBroken", err)
+	}
+
+	if err := store.Reclone("myteam"); err != nil {
+		t.Fatalf("Reclone() error: %v", err)
+	}
+	if err := store.Check("myteam", true); err != nil {
+		t.Errorf("Check() after Reclone() error: %v", err)
+	}
+	if _, err := os.Stat(localPath + ".broken"); !os.IsNotExist(err) {
+		t.Errorf("Reclone() left the broken clone aside")
+	}
+	items, err := store.Browse("myteam", "")
+	if err != nil || len(items) != 1 {
+		t.Errorf("Browse() after Reclone() = %v, %v; want 1 item", items, err)
+	}
+
+	// Without a clone at all
+	if err := os.RemoveAll(localPath); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Check("myteam", false); !errors.Is(err, ErrCloneBroken) {
+		t.Errorf("Check() without a clone error = %v, want ErrCloneBroken", err)
+	}
+}
+
+func TestAddLocalRoot(t *testing.T) {
+	baseDir := setupTestRepo(t)
+	defer func() { _ = os.RemoveAll(baseDir) }()
+	srcPath := setupTestRepo(t)
+	defer func() { _ = os.RemoveAll(srcPath) }()
+
+	// Only packages under tools/claude are discovered
+	createFile(t, filepath.Join(srcPath, "tools", "claude", "skills", "my-skill", "SKILL.md"), "# Skill")
+	createFile(t, filepath.Join(srcPath, "commands", "unrelated.md"), "# Not a package")
+
+	store := NewStore(baseDir)
+	if _, err := store.AddLocal("file://"+srcPath, "bad", true, AddOptions{Root: "../outside"}); err == nil {
+		t.Error("AddLocal() with a root outside the repository should fail")
+	}
+	if _, err := store.AddLocal("file://"+srcPath, "bad", true, AddOptions{Root: "missing"}); err == nil {
+		t.Error("AddLocal() with a missing root should fail")
+	}
+
+	config, err := store.AddLocal("file://"+srcPath, "mono", true, AddOptions{Root: "/tools/claude/"})
+	if err != nil {
+		t.Fatalf("AddLocal() error: %v", err)
+	}
+	if config.Root != "tools/claude" {
+		t.Errorf("AddLocal() root = %q, want tools/claude", config.Root)
+	}
+
+	items, err := store.Browse("mono", "")
+	if err != nil {
+		t.Fatalf("Browse() error: %v", err)
+	}
+	if len(items) != 1 || items[0].Path != "skills/my-skill" {
+		t.Errorf("Browse() = %+v, want only skills/my-skill", items)
+	}
+
+	root, err := store.PackageRoot("mono")
+	if err != nil {
+		t.Fatalf("PackageRoot() error: %v", err)
+	}
+	if want := filepath.Join(baseDir, reposDirName, "mono", "tools", "claude"); root != want {
+		t.Errorf("PackageRoot() = %q, want %q", root, want)
+	}
+}
+
+func TestSetLayout(t *testing.T) {
+	baseDir := setupTestRepo(t)
+	defer func() { _ = os.RemoveAll(baseDir) }()
+	srcPath := setupTestRepo(t)
+	defer func() { _ = os.RemoveAll(srcPath) }()
+
+	createFile(t, filepath.Join(srcPath, "ai", "skills", "my-skill", "SKILL.md"), "# Skill")
+	createFile(t, filepath.Join(srcPath, "plugins", "one", "prompts", "hello.md"), "# Hello")
+	createFile(t, filepath.Join(srcPath, "agents", "reviewer.md"), "# Reviewer")
+
+	store := NewStore(baseDir)
+	if _, err := store.AddLocal("file://"+srcPath, "custom", true, AddOptions{}); err != nil {
+		t.Fatalf("AddLocal() error: %v", err)
+	}
+
+	if _, err := store.SetLayout("custom", Layout{TypeSkill: {"../outside"}}); err == nil {
+		t.Error("SetLayout() with a directory outside the repository should fail")
+	}
+	if _, err := store.SetLayout("missing", Layout{TypeSkill: {"ai/skills"}}); !errors.Is(err, ErrRepoNotFound) {
+		t.Errorf("SetLayout() unknown namespace error = %v, want ErrRepoNotFound", err)
+	}
+
+	config, err := store.SetLayout("custom", Layout{
+		TypeSkill:   {"ai/skills/"},
+		TypeCommand: {"plugins/*/prompts"},
+	})
+	if err != nil {
+		t.Fatalf("SetLayout() error: %v", err)
+	}
+	if got := config.Layout.Dirs(TypeSkill); len(got) != 1 || got[0] != "ai/skills" {
+		t.Errorf("SetLayout() skills = %v, want [ai/skills]", got)
+	}
+
+	items, err := store.Browse("custom", "")
+	if err != nil {
+		t.Fatalf("Browse() error: %v", err)
+	}
+	want := map[string]PackageType{
+		"ai/skills/my-skill":           TypeSkill,
+		"plugins/one/prompts/hello.md": TypeCommand,
+		"agents/reviewer.md":           TypeAgent, // Unconfigured types use the default layout
+	}
+	if len(items) != len(want) {
+		t.Fatalf("Browse() = %+v, want %d items", items, len(want))
+	}
+	for _, item := range items {
+		if want[item.Path] != item.Type {
+			t.Errorf("Browse() item %s type = %s, want %s", item.Path, item.Type, want[item.Path])
+		}
+	}
+
+	item, err := store.FindPackage("custom", "ai/skills/my-skill")
+	if err != nil {
+		t.Fatalf("FindPackage() error: %v", err)
+	}
+	if item.Dir != "ai/skills" {
+		t.Errorf("FindPackage() dir = %q, want ai/skills", item.Dir)
+	}
+
+	// An empty layout restores the defaults
+	config, err = store.SetLayout("custom", nil)
+	if err != nil {
+		t.Fatalf("SetLayout() reset error: %v", err)
+	}
+	if config.Layout != nil {
+		t.Errorf("SetLayout() reset layout = %v, want nil", config.Layout)
+	}
+	if _, err := store.FindPackage("custom", "ai/skills/my-skill"); !errors.Is(err, ErrPackageNotFound) {
+		t.Errorf("FindPackage() after reset error = %v, want ErrPackageNotFound", err)
+	}
+}
+
+func TestFetchRemoteTree(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/owner/repo":
+			fmt.Fprint(w, `{"description": "Demo", "default_branch": "main"}`)
+		case "/repos/owner/repo/git/trees/main":
+			fmt.Fprint(w, `{"tree": [
+				{"path": "skills", "type": "tree"},
+				{"path": "skills/web-fetch/SKILL.md", "type": "blob"},
+				{"path": "skills/web-fetch/scripts/run.sh", "type": "blob"},
+				{"path": "skills/notes/README.md", "type": "blob"},
+				{"path": "commands/team/deploy.md", "type": "blob"},
+				{"path": ".claude/agents/reviewer.md", "type": "blob"},
+				{"path": "hooks/format.sh", "type": "blob"},
+				{"path": "hooks/lib/util.sh", "type": "blob"},
+				{"path": "tools/claude/skills/extra/SKILL.md", "type": "blob"}
+			]}`)
+		case "/repos/owner/repo/contents/skills/web-fetch/SKILL.md":
+			if r.URL.Query().Get("ref") != "main" {
+				t.Errorf("contents ref = %q, want main", r.URL.Query().Get("ref"))
+			}
+			fmt.Fprint(w, "---\nname: web-fetch\n---\n")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	orig := githubAPIURL
+	githubAPIURL = server.URL
+	defer func() { githubAPIURL = orig }()
+
+	tree, err := FetchRemoteTree("owner", "repo", "")
+	if err != nil {
+		t.Fatalf("FetchRemoteTree() error: %v", err)
+	}
+	if tree.Branch != "main" || tree.Description != "Demo" {
+		t.Errorf("FetchRemoteTree() branch = %q, description = %q", tree.Branch, tree.Description)
+	}
+
+	var got []string
+	for _, item := range tree.Packages("", nil, "") {
+		got = append(got, fmt.Sprintf("%s %s %s", item.Type, item.Name, item.Path))
+	}
+	want := []string{
+		"skill web-fetch skills/web-fetch",
+		"command team:deploy commands/team/deploy.md",
+		"agent reviewer .claude/agents/reviewer.md",
+		"hook format.sh hooks/format.sh",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Packages() = %q, want %q", got, want)
+	}
+
+	if items := tree.Packages("tools/claude", nil, TypeSkill); len(items) != 1 || items[0].Path != "skills/extra" {
+		t.Errorf("Packages(root) = %+v, want skills/extra", items)
+	}
+
+	content, err := tree.FetchFile("skills/web-fetch/SKILL.md")
+	if err != nil {
+		t.Fatalf("FetchFile() error: %v", err)
+	}
+	if string(content) != "---\nname: web-fetch\n---\n" {
+		t.Errorf("FetchFile() = %q", content)
+	}
+
+	if _, err := FetchRemoteTree("owner", "missing", ""); err == nil {
+		t.Error("FetchRemoteTree() of a missing repository succeeded")
+	}
+}
+
+func TestAddMeta(t *testing.T) {
+	var requests, notModified int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/repos/owner/repo":
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				notModified++
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("ETag", `"v1"`)
+			fmt.Fprint(w, `{"stargazers_count": 42}`)
+		case "/repos/owner/repo/commits":
+			if q := r.URL.Query(); q.Get("sha") != "main" || q.Get("per_page") != "1" {
+				t.Errorf("commits query = %v", q)
+			}
+			switch r.URL.Query().Get("path") {
+			case "tools/skills/web-fetch":
+				fmt.Fprint(w, `[{"author": {"login": "alice"}, "commit": {"author": {"name": "Alice"}, "committer": {"date": "2026-09-01T10:00:00Z"}}}]`)
+			case "tools/hooks/format.sh":
+				fmt.Fprint(w, `[{"author": null, "commit": {"author": {"name": "Bob"}, "committer": {"date": "2026-10-01T10:00:00Z"}}}]`)
+			default:
+				fmt.Fprint(w, `[]`)
+			}
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	orig := githubAPIURL
+	githubAPIURL = server.URL
+	defer func() { githubAPIURL = orig }()
+
+	baseDir := t.TempDir()
+	store := NewStore(baseDir)
+	if err := store.save(&ReposFile{Version: 1, Repos: []RepoConfig{
+		{Namespace: "gh", URL: "https://github.com/owner/repo", Owner: "owner", Repo: "repo", DefaultBranch: "main", Root: "tools"},
+		{Namespace: "local", URL: "file:///tmp/repo", Local: true},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+
+	items := []BrowseItem{
+		{Name: "web-fetch", Path: "skills/web-fetch", Type: TypeSkill},
+		{Name: "format.sh", Path: "hooks/format.sh", Type: TypeHook},
+		{Name: "new", Path: "agents/new.md", Type: TypeAgent},
+	}
+	if err := store.AddMeta("gh", items); err != nil {
+		t.Fatalf("AddMeta() error: %v", err)
+	}
+	if m := items[0].Meta; m == nil || m.Stars != 42 || m.Author != "alice" || m.UpdatedAt.Day() != 1 || m.UpdatedAt.Month() != 9 {
+		t.Errorf("web-fetch meta = %+v", m)
+	}
+	if m := items[1].Meta; m == nil || m.Author != "Bob" {
+		t.Errorf("format.sh meta = %+v, want the commit author's name without a GitHub login", m)
+	}
+	if m := items[2].Meta; m == nil || m.Stars != 42 || !m.UpdatedAt.IsZero() {
+		t.Errorf("new meta = %+v, want stars only", m)
+	}
+
+	// Fresh responses are read from the cache
+	fetched := requests
+	if err := store.AddMeta("gh", items); err != nil {
+		t.Fatalf("AddMeta() error: %v", err)
+	}
+	if requests != fetched {
+		t.Errorf("AddMeta() sent %d requests with a fresh cache, want 0", requests-fetched)
+	}
+
+	// Stale responses are revalidated with their ETag
+	client, err := store.githubClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for endpoint, entry := range client.entries {
+		entry.FetchedAt = entry.FetchedAt.Add(-2 * githubCacheTTL)
+		client.entries[endpoint] = entry
+	}
+	client.dirty = true
+	if err := client.save(); err != nil {
+		t.Fatal(err)
+	}
+	items[0].Meta = nil
+	if err := store.AddMeta("gh", items[:1]); err != nil {
+		t.Fatalf("AddMeta() error: %v", err)
+	}
+	if notModified != 1 || items[0].Meta == nil || items[0].Meta.Stars != 42 {
+		t.Errorf("revalidation: %d not modified responses, meta = %+v", notModified, items[0].Meta)
+	}
+
+	// Repositories not on GitHub have no metadata
+	localItems := []BrowseItem{{Name: "x", Path: "skills/x", Type: TypeSkill}}
+	if err := store.AddMeta("local", localItems); err != nil || localItems[0].Meta != nil {
+		t.Errorf("AddMeta(local) = %v, meta %+v", err, localItems[0].Meta)
+	}
+}
+
+func TestScanTemplates(t *testing.T) {
+	root := setupTestRepo(t)
+	defer func() { _ = os.RemoveAll(root) }()
+
+	createFile(t, filepath.Join(root, "templates", "skills", "go-review", "SKILL.md"), "---\nname: {{name}}\n---\n")
+	createFile(t, filepath.Join(root, "templates", "skills", "go-review", "checklist.md"), "- {{title}}\n")
+	createFile(t, filepath.Join(root, "templates", "skills", "empty", "README.md"), "Not a skill")
+	createFile(t, filepath.Join(root, "templates", "agents", "reviewer.md"), "---\nname: {{name}}\n---\n")
+	createFile(t, filepath.Join(root, "templates", "agents", "notes.txt"), "Not an agent")
+	createFile(t, filepath.Join(root, "skills", "fetch", "SKILL.md"), "# Fetch")
+
+	got := ScanTemplates(root, "")
+	want := []Template{
+		{Name: "go-review", Type: TypeSkill, Path: "templates/skills/go-review", Dir: filepath.Join(root, "templates", "skills", "go-review")},
+		{Name: "reviewer", Type: TypeAgent, Path: "templates/agents/reviewer.md", Dir: filepath.Join(root, "templates", "agents", "reviewer.md")},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ScanTemplates() = %+v, want %+v", got, want)
+	}
+
+	if got := ScanTemplates(root, TypeAgent); len(got) != 1 || got[0].Name != "reviewer" {
+		t.Errorf("ScanTemplates(agent) = %+v, want only reviewer", got)
+	}
+
+	// Templates are not packages
+	for _, item := range ScanPackages(root, "") {
+		if item.Name == "go-review" || item.Name == "reviewer" {
+			t.Errorf("template scanned as package: %+v", item)
+		}
+	}
+}
+
+func TestRenderTemplate(t *testing.T) {
+	content := "---\nname: {{name}}\ndescription: {{ description }}\nowner: {{team}}\n---\n# {{title}}\n{{team}} {{}}\n"
+	got, unknown := RenderTemplate(content, map[string]string{"name": "go-review", "description": "Review Go code", "title": "Go Review"})
+
+	want := "---\nname: go-review\ndescription: Review Go code\nowner: {{team}}\n---\n# Go Review\n{{team}} {{}}\n"
+	if got != want {
+		t.Errorf("RenderTemplate() = %q, want %q", got, want)
+	}
+	if !reflect.DeepEqual(unknown, []string{"team"}) {
+		t.Errorf("RenderTemplate() unknown = %v, want [team]", unknown)
+	}
+}
+
+func TestRepoConfigWebURL(t *testing.T) {
+	tests := []struct {
+		name   string
+		config RepoConfig
+		ref    string
+		rel    string
+		want   string
+	}{
+		{"github path at commit", RepoConfig{URL: "https://github.com/owner/repo", DefaultBranch: "main"}, "abc123", "skills/web-fetch", "https://github.com/owner/repo/tree/abc123/skills/web-fetch"},
+		{"github root on tracked branch", RepoConfig{URL: "https://github.com/owner/repo.git", DefaultBranch: "main", Branch: "develop"}, "", "", "https://github.com/owner/repo/tree/develop"},
+		{"github monorepo root", RepoConfig{URL: "https://github.com/org/mono", DefaultBranch: "main", Root: "tools/claude"}, "", ".claude/agents/a.md", "https://github.com/org/mono/tree/main/tools/claude/.claude/agents/a.md"},
+		{"github without branch", RepoConfig{URL: "https://github.com/owner/repo"}, "", "skills/x", "https://github.com/owner/repo"},
+		{"other host", RepoConfig{URL: "https://gitlab.com/owner/repo.git", DefaultBranch: "main"}, "abc123", "skills/x", "https://gitlab.com/owner/repo"},
+		{"local", RepoConfig{URL: "file:///tmp/repo", DefaultBranch: "main", Local: true}, "abc123", "skills/x", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.config.WebURL(tt.ref, tt.rel); got != tt.want {
+				t.Errorf("WebURL(%q, %q) = %q, want %q", tt.ref, tt.rel, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRepoConfigCompareURL(t *testing.T) {
+	tests := []struct {
+		name   string
+		config RepoConfig
+		want   string
+	}{
+		{"github", RepoConfig{URL: "https://github.com/owner/repo.git"}, "https://github.com/owner/repo/compare/abc123...def456"},
+		{"other host", RepoConfig{URL: "https://gitlab.com/owner/repo.git"}, "https://gitlab.com/owner/repo"},
+		{"local", RepoConfig{URL: "file:///tmp/repo", Local: true}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.config.CompareURL("abc123", "def456"); got != tt.want {
+				t.Errorf("CompareURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
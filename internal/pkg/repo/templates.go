@@ -0,0 +1,108 @@
+package repo
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// TemplatesDir is the directory, under a repository's package root, with scaffolds
+// for new skills (templates/skills/<name>/, a skill directory) and agents
+// (templates/agents/<name>.md).
+const TemplatesDir = "templates"
+
+// Template is a scaffold for new skills or agents contributed by a repository.
+type Template struct {
+	Namespace string      `json:"namespace"`
+	Name      string      `json:"name"`
+	Type      PackageType `json:"type"` // TypeSkill or TypeAgent
+	Path      string      `json:"path"` // Relative to the package root, slash-separated
+	Dir       string      `json:"-"`    // Absolute path: the skill directory, or the agent file
+}
+
+// templateDirs are the directories templates of each type are found in, under TemplatesDir
+var templateDirs = map[PackageType]string{
+	TypeSkill: "skills",
+	TypeAgent: "agents",
+}
+
+// ScanTemplates returns the templates of a type ("" for skills and agents) under a package root.
+func ScanTemplates(root string, typeFilter PackageType) []Template {
+	var templates []Template
+	for _, t := range []PackageType{TypeSkill, TypeAgent} {
+		if typeFilter != "" && typeFilter != t {
+			continue
+		}
+		rel := TemplatesDir + "/" + templateDirs[t]
+		entries, err := os.ReadDir(filepath.Join(root, filepath.FromSlash(rel)))
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			path := filepath.Join(root, filepath.FromSlash(rel), entry.Name())
+			switch {
+			case t == TypeSkill && entry.IsDir():
+				if _, err := os.Stat(filepath.Join(path, "SKILL.md")); err != nil {
+					continue
+				}
+				templates = append(templates, Template{Name: entry.Name(), Type: t, Path: rel + "/" + entry.Name(), Dir: path})
+			case t == TypeAgent && !entry.IsDir() && strings.HasSuffix(entry.Name(), ".md"):
+				templates = append(templates, Template{Name: strings.TrimSuffix(entry.Name(), ".md"), Type: t, Path: rel + "/" + entry.Name(), Dir: path})
+			}
+		}
+	}
+	return templates
+}
+
+// Templates returns a registered repository's templates of a type ("" for all).
+func (s *Store) Templates(namespace string, typeFilter PackageType) ([]Template, error) {
+	root, err := s.PackageRoot(namespace)
+	if err != nil {
+		return nil, err
+	}
+	templates := ScanTemplates(root, typeFilter)
+	for i := range templates {
+		templates[i].Namespace = namespace
+	}
+	return templates, nil
+}
+
+// FindTemplate returns a repository's template of a type by name.
+func (s *Store) FindTemplate(namespace string, t PackageType, name string) (*Template, error) {
+	templates, err := s.Templates(namespace, t)
+	if err != nil {
+		return nil, err
+	}
+	for i := range templates {
+		if templates[i].Name == name {
+			return &templates[i], nil
+		}
+	}
+	return nil, ErrTemplateNotFound
+}
+
+// placeholderRegex matches a template placeholder such as {{name}} or {{ description }}
+var placeholderRegex = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// RenderTemplate replaces the {{key}} placeholders of a template file with their
+// values. Placeholders without a value are left as they are and returned, sorted.
+func RenderTemplate(content string, values map[string]string) (string, []string) {
+	unknown := make(map[string]bool)
+	rendered := placeholderRegex.ReplaceAllStringFunc(content, func(match string) string {
+		key := placeholderRegex.FindStringSubmatch(match)[1]
+		if value, ok := values[key]; ok {
+			return value
+		}
+		unknown[key] = true
+		return match
+	})
+
+	var keys []string
+	for key := range unknown {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return rendered, keys
+}
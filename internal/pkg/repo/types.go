@@ -1,6 +1,10 @@
 package repo
 
-import "time"
+import (
+	"path"
+	"strings"
+	"time"
+)
 
 // RepoConfig represents a registered repository.
 type RepoConfig struct {
@@ -9,10 +13,24 @@ type RepoConfig struct {
 	Owner         string    `json:"owner"`
 	Repo          string    `json:"repo"`
 	DefaultBranch string    `json:"default_branch"`
+	Branch        string    `json:"branch,omitempty"` // Tracked branch if not the default branch
+	Root          string    `json:"root,omitempty"`   // Sub-path packages are discovered under (monorepos)
+	Layout        Layout    `json:"layout,omitempty"` // Directories scanned per package type (default layout if unset)
 	Description   string    `json:"description,omitempty"`
+	Local         bool      `json:"local,omitempty"`   // Added from a local filesystem path (file://)
+	Link          bool      `json:"link,omitempty"`    // Symlinked to a live local checkout instead of cloned
+	Trusted       bool      `json:"trusted,omitempty"` // Hooks and commands install without a warning
 	AddedAt       time.Time `json:"added_at"`
 }
 
+// TrackedBranch returns the branch packages are browsed, installed, and updated from.
+func (r *RepoConfig) TrackedBranch() string {
+	if r.Branch != "" {
+		return r.Branch
+	}
+	return r.DefaultBranch
+}
+
 // ReposFile represents the repos.json file structure.
 type ReposFile struct {
 	Version int          `json:"version"`
@@ -29,10 +47,91 @@ const (
 	TypeHook    PackageType = "hook"
 )
 
+// PackageTypes lists all package types in display order.
+var PackageTypes = []PackageType{TypeSkill, TypeCommand, TypeAgent, TypeHook}
+
+// RunsCommands reports whether packages of the type can run shell commands on the
+// user's machine: hooks run on every matching event, and commands can embed !`cmd` lines.
+// Installing these from an untrusted repository asks for confirmation.
+func (t PackageType) RunsCommands() bool {
+	return t == TypeHook || t == TypeCommand
+}
+
+// Layout maps package types to directory globs, relative to the package root,
+// that are scanned for packages of that type (e.g., "ai/skills", "prompts/*").
+type Layout map[PackageType][]string
+
+// defaultLayout is the standard repository layout.
+var defaultLayout = Layout{
+	TypeSkill:   {"skills", ".claude/skills"},
+	TypeCommand: {"commands", ".claude/commands"},
+	TypeAgent:   {"agents", ".claude/agents"},
+	TypeHook:    {"hooks", ".claude/hooks"},
+}
+
+// DefaultLayout returns a copy of the standard repository layout.
+func DefaultLayout() Layout {
+	layout := make(Layout, len(defaultLayout))
+	for t, dirs := range defaultLayout {
+		layout[t] = append([]string(nil), dirs...)
+	}
+	return layout
+}
+
+// Dirs returns the directory globs scanned for packages of type t.
+// Types not configured in the layout use the default directories.
+func (l Layout) Dirs(t PackageType) []string {
+	if dirs, ok := l[t]; ok {
+		return dirs
+	}
+	return defaultLayout[t]
+}
+
 // BrowseItem represents an item found during browsing.
 type BrowseItem struct {
-	Name        string      `json:"name"`
-	Path        string      `json:"path"`
-	Type        PackageType `json:"type"`
-	Description string      `json:"description,omitempty"`
+	Namespace   string       `json:"namespace,omitempty"` // Repository the item is in, when browsed from a registered repository
+	Name        string       `json:"name"`
+	Path        string       `json:"path"`
+	Type        PackageType  `json:"type"`
+	Description string       `json:"description,omitempty"`
+	Dir         string       `json:"-"`              // Layout directory the item was found in, relative to the package root
+	Meta        *PackageMeta `json:"meta,omitempty"` // Stars and last change, if fetched (see Store.AddMeta)
+}
+
+// WebURL returns the web page of a path in the repository at a ref: a deep link
+// for GitHub, the repository page for other http(s) hosts, and "" for local
+// repositories. rel is relative to the repository's package root; "" links the
+// root. An empty ref links the tracked branch.
+func (r *RepoConfig) WebURL(ref, rel string) string {
+	base := strings.TrimSuffix(r.URL, ".git")
+	if !strings.HasPrefix(base, "https://") && !strings.HasPrefix(base, "http://") {
+		return ""
+	}
+	if !strings.HasPrefix(base, "https://github.com/") {
+		return base
+	}
+
+	rel = path.Join(r.Root, rel)
+	if rel == "." {
+		rel = ""
+	}
+	if ref == "" {
+		ref = r.TrackedBranch()
+	}
+	if ref == "" {
+		return base
+	}
+	// GitHub redirects tree links of files to their blob page
+	return strings.TrimSuffix(base+"/tree/"+ref+"/"+rel, "/")
+}
+
+// CompareURL returns the web page of the changes between two commits: a compare
+// link for GitHub, the repository page for other http(s) hosts, and "" for
+// local repositories
+func (r *RepoConfig) CompareURL(from, to string) string {
+	base := strings.TrimSuffix(r.URL, ".git")
+	if !strings.HasPrefix(base, "https://github.com/") || from == "" || to == "" {
+		return r.WebURL(to, "")
+	}
+	return base + "/compare/" + from + "..." + to
 }
@@ -0,0 +1,328 @@
+// Package plugin converts resources to and from the Claude Code plugin layout:
+// a directory with a .claude-plugin/plugin.json manifest and the skills,
+// commands, agents, and hooks the plugin provides.
+//
+//	my-plugin/
+//	├── .claude-plugin/plugin.json
+//	├── skills/<name>/SKILL.md
+//	├── commands/<name>.md
+//	├── agents/<name>.md
+//	├── hooks/hooks.json
+//	└── scripts/<hook script>
+package plugin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// ManifestDir is the directory of a plugin's manifest
+	ManifestDir = ".claude-plugin"
+	// ManifestFile is the name of a plugin's manifest
+	ManifestFile = "plugin.json"
+	// HooksFile is the plugin's hook configuration, relative to its root
+	HooksFile = "hooks/hooks.json"
+	// ScriptsDir is where hook scripts are bundled, relative to the plugin root
+	ScriptsDir = "scripts"
+	// RootVar is replaced by Claude Code with the plugin's installed directory
+	RootVar = "${CLAUDE_PLUGIN_ROOT}"
+)
+
+// ErrNotEmpty is returned when writing a plugin into a directory with files in it.
+var ErrNotEmpty = errors.New("output directory is not empty")
+
+// nameRegex matches valid plugin names: kebab-case
+var nameRegex = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// ValidateName checks that a plugin name is kebab-case, as Claude Code requires
+func ValidateName(name string) error {
+	if !nameRegex.MatchString(name) {
+		return fmt.Errorf("invalid plugin name %q: use lowercase letters, digits, and hyphens (e.g., my-plugin)", name)
+	}
+	return nil
+}
+
+// Author is the author of a plugin
+type Author struct {
+	Name  string `json:"name"`
+	Email string `json:"email,omitempty"`
+}
+
+// Manifest is a plugin's .claude-plugin/plugin.json
+type Manifest struct {
+	Name        string  `json:"name"`
+	Version     string  `json:"version,omitempty"`
+	Description string  `json:"description,omitempty"`
+	Author      *Author `json:"author,omitempty"`
+}
+
+// Resource is a skill directory, or a command or agent file, to bundle
+type Resource struct {
+	Name string // Name as listed by jd; "team:deploy" is written as team/deploy.md
+	Path string // Skill directory, or markdown file
+}
+
+// Hook is a hook rule to bundle
+type Hook struct {
+	EventType string
+	Matcher   string
+	Commands  []string
+}
+
+// Bundle is the content of a plugin to write
+type Bundle struct {
+	Manifest Manifest
+	Skills   []Resource
+	Commands []Resource
+	Agents   []Resource
+	Hooks    []Hook
+
+	// HooksDir is the directory of hook scripts. Scripts in it that hooks run
+	// are copied to scripts/ and run from the plugin's directory.
+	HooksDir string
+	// ProjectDir expands $CLAUDE_PROJECT_DIR in hook commands (empty: not expanded)
+	ProjectDir string
+}
+
+// Result lists what Write wrote, relative to the plugin root
+type Result struct {
+	Files   []string
+	Scripts []string // Hook scripts bundled into scripts/
+}
+
+// Write writes the bundle as a plugin into dir, which must be empty or not
+// exist unless force is set, in which case files in it are overwritten.
+func (b *Bundle) Write(dir string, force bool) (*Result, error) {
+	if err := ValidateName(b.Manifest.Name); err != nil {
+		return nil, err
+	}
+	if entries, err := os.ReadDir(dir); err == nil && len(entries) > 0 && !force {
+		return nil, fmt.Errorf("%w: %s", ErrNotEmpty, dir)
+	}
+
+	w := &writer{root: dir, result: &Result{}}
+
+	manifest, err := json.MarshalIndent(b.Manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal manifest: %w", err)
+	}
+	if err := w.writeFile(filepath.Join(ManifestDir, ManifestFile), append(manifest, '\n'), 0644); err != nil {
+		return nil, err
+	}
+
+	for _, s := range b.Skills {
+		if err := w.copyDir(s.Path, filepath.Join("skills", filepath.Base(s.Name))); err != nil {
+			return nil, fmt.Errorf("copy skill %s: %w", s.Name, err)
+		}
+	}
+	for _, c := range b.Commands {
+		if err := w.copyFile(c.Path, markdownPath("commands", c.Name)); err != nil {
+			return nil, fmt.Errorf("copy command %s: %w", c.Name, err)
+		}
+	}
+	for _, a := range b.Agents {
+		if err := w.copyFile(a.Path, markdownPath("agents", a.Name)); err != nil {
+			return nil, fmt.Errorf("copy agent %s: %w", a.Name, err)
+		}
+	}
+
+	if len(b.Hooks) > 0 {
+		config, err := b.hooksConfig(w)
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.MarshalIndent(config, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("marshal hooks: %w", err)
+		}
+		if err := w.writeFile(HooksFile, append(data, '\n'), 0644); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Strings(w.result.Files)
+	return w.result, nil
+}
+
+// markdownPath returns where a command or agent is written: nested names map
+// to subdirectories, as Claude Code reads them
+func markdownPath(dir, name string) string {
+	name = strings.TrimSuffix(name, ".md")
+	return filepath.Join(dir, filepath.Join(strings.Split(name, ":")...)+".md")
+}
+
+// HooksConfig is a plugin's hooks/hooks.json
+type HooksConfig struct {
+	Description string                `json:"description,omitempty"`
+	Hooks       map[string][]HookRule `json:"hooks"`
+}
+
+// HookRule is a matcher and the commands it runs, as in settings.json
+type HookRule struct {
+	Matcher string        `json:"matcher,omitempty"`
+	Hooks   []HookCommand `json:"hooks"`
+}
+
+// HookCommand is a command a hook rule runs
+type HookCommand struct {
+	Type    string `json:"type"`
+	Command string `json:"command"`
+}
+
+// hooksConfig returns the hook rules of the bundle, bundling the scripts they run
+func (b *Bundle) hooksConfig(w *writer) (*HooksConfig, error) {
+	config := &HooksConfig{Hooks: make(map[string][]HookRule)}
+	for _, h := range b.Hooks {
+		rule := HookRule{Matcher: h.Matcher}
+		for _, command := range h.Commands {
+			command, err := b.bundleScripts(w, command)
+			if err != nil {
+				return nil, err
+			}
+			rule.Hooks = append(rule.Hooks, HookCommand{Type: "command", Command: command})
+		}
+		config.Hooks[h.EventType] = append(config.Hooks[h.EventType], rule)
+	}
+	return config, nil
+}
+
+// bundleScripts copies the scripts in HooksDir that a command runs to scripts/,
+// and returns the command running them from the plugin's directory
+func (b *Bundle) bundleScripts(w *writer, command string) (string, error) {
+	if b.HooksDir == "" {
+		return command, nil
+	}
+	hooksDir := filepath.Clean(b.HooksDir)
+
+	for _, field := range strings.Fields(command) {
+		// Quotes may wrap the path or part of it, as in "$CLAUDE_PROJECT_DIR"/x.sh
+		unquoted := strings.NewReplacer(`"`, "", "'", "").Replace(field)
+		path := b.expand(unquoted)
+		if !filepath.IsAbs(path) && b.ProjectDir != "" && strings.Contains(path, "/") {
+			// Claude Code runs hooks in the project directory
+			path = filepath.Join(b.ProjectDir, path)
+		}
+		if !filepath.IsAbs(path) || !strings.HasPrefix(filepath.Clean(path), hooksDir+string(filepath.Separator)) {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil || info.IsDir() {
+			continue
+		}
+
+		rel, err := filepath.Rel(hooksDir, path)
+		if err != nil {
+			continue
+		}
+		target := filepath.Join(ScriptsDir, rel)
+		if err := w.copyFile(path, target); err != nil {
+			return "", fmt.Errorf("copy hook script %s: %w", path, err)
+		}
+		w.result.Scripts = appendUnique(w.result.Scripts, filepath.ToSlash(target))
+		replacement := RootVar + "/" + filepath.ToSlash(target)
+		if unquoted != field {
+			replacement = `"` + replacement + `"`
+		}
+		command = strings.Replace(command, field, replacement, 1)
+	}
+	return command, nil
+}
+
+// expand expands ~, $HOME, and $CLAUDE_PROJECT_DIR at the start of a path in a command
+func (b *Bundle) expand(token string) string {
+	home, _ := os.UserHomeDir()
+	vars := []struct{ prefix, value string }{
+		{"~/", home + "/"},
+		{"$HOME/", home + "/"},
+		{"${HOME}/", home + "/"},
+	}
+	if b.ProjectDir != "" {
+		vars = append(vars,
+			struct{ prefix, value string }{"$CLAUDE_PROJECT_DIR/", b.ProjectDir + "/"},
+			struct{ prefix, value string }{"${CLAUDE_PROJECT_DIR}/", b.ProjectDir + "/"},
+		)
+	}
+	for _, v := range vars {
+		if rest, ok := strings.CutPrefix(token, v.prefix); ok && v.value != "/" {
+			return filepath.FromSlash(v.value + rest)
+		}
+	}
+	return token
+}
+
+// appendUnique appends s to list unless it is already in it
+func appendUnique(list []string, s string) []string {
+	for _, item := range list {
+		if item == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
+
+// writer writes files under a plugin root and records them
+type writer struct {
+	root   string
+	result *Result
+}
+
+// writeFile writes a file at a path relative to the plugin root
+func (w *writer) writeFile(rel string, data []byte, perm os.FileMode) error {
+	path := filepath.Join(w.root, rel)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("create directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, perm); err != nil {
+		return fmt.Errorf("write %s: %w", rel, err)
+	}
+	w.result.Files = appendUnique(w.result.Files, filepath.ToSlash(rel))
+	return nil
+}
+
+// copyFile copies a file to a path relative to the plugin root, keeping its
+// permissions so scripts stay executable
+func (w *writer) copyFile(src, rel string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = in.Close() }()
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+	return w.writeFile(rel, data, info.Mode().Perm())
+}
+
+// copyDir copies the files of a directory to a directory relative to the plugin
+// root, leaving out hidden directories such as a skill's .history
+func (w *writer) copyDir(src, rel string) error {
+	return filepath.WalkDir(src, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && path != src && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+		if d.IsDir() || !d.Type().IsRegular() {
+			return nil
+		}
+		sub, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		return w.copyFile(path, filepath.Join(rel, sub))
+	})
+}
@@ -0,0 +1,220 @@
+package plugin
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeTestFile(t *testing.T, path, content string, perm os.FileMode) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), perm); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBundleWrite(t *testing.T) {
+	src := t.TempDir()
+	project := filepath.Join(src, "project")
+	hooksDir := filepath.Join(project, ".claude", "hooks")
+	writeTestFile(t, filepath.Join(src, "skills", "web-fetch", "SKILL.md"), "---\nname: web-fetch\n---\n", 0644)
+	writeTestFile(t, filepath.Join(src, "skills", "web-fetch", "scripts", "fetch.sh"), "echo", 0755)
+	writeTestFile(t, filepath.Join(src, "skills", "web-fetch", ".history", "manifest.json"), "{}", 0644)
+	writeTestFile(t, filepath.Join(src, "commands", "team", "deploy.md"), "# Deploy", 0644)
+	writeTestFile(t, filepath.Join(src, "agents", "reviewer.md"), "# Reviewer", 0644)
+	writeTestFile(t, filepath.Join(hooksDir, "format.sh"), "#!/bin/sh\n", 0755)
+
+	bundle := &Bundle{
+		Manifest: Manifest{Name: "team-tools", Version: "1.0.0", Author: &Author{Name: "Team"}},
+		Skills:   []Resource{{Name: "web-fetch", Path: filepath.Join(src, "skills", "web-fetch")}},
+		Commands: []Resource{{Name: "team:deploy", Path: filepath.Join(src, "commands", "team", "deploy.md")}},
+		Agents:   []Resource{{Name: "reviewer", Path: filepath.Join(src, "agents", "reviewer.md")}},
+		Hooks: []Hook{
+			{EventType: "PostToolUse", Matcher: "Edit|Write", Commands: []string{`"$CLAUDE_PROJECT_DIR"/.claude/hooks/format.sh --quiet`}},
+			{EventType: "Stop", Commands: []string{"echo done"}},
+		},
+		HooksDir:   hooksDir,
+		ProjectDir: project,
+	}
+
+	out := filepath.Join(t.TempDir(), "team-tools")
+	result, err := bundle.Write(out, false)
+	if err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+
+	want := []string{
+		".claude-plugin/plugin.json",
+		"agents/reviewer.md",
+		"commands/team/deploy.md",
+		"hooks/hooks.json",
+		"scripts/format.sh",
+		"skills/web-fetch/SKILL.md",
+		"skills/web-fetch/scripts/fetch.sh",
+	}
+	if !reflect.DeepEqual(result.Files, want) {
+		t.Errorf("Files = %q, want %q", result.Files, want)
+	}
+	if !reflect.DeepEqual(result.Scripts, []string{"scripts/format.sh"}) {
+		t.Errorf("Scripts = %q", result.Scripts)
+	}
+	if info, err := os.Stat(filepath.Join(out, "scripts", "format.sh")); err != nil || info.Mode().Perm()&0100 == 0 {
+		t.Errorf("bundled script is not executable: %v", err)
+	}
+
+	var manifest Manifest
+	data, _ := os.ReadFile(filepath.Join(out, ManifestDir, ManifestFile))
+	if err := json.Unmarshal(data, &manifest); err != nil || manifest.Name != "team-tools" || manifest.Author.Name != "Team" {
+		t.Errorf("plugin.json = %s (%v)", data, err)
+	}
+
+	var hooks HooksConfig
+	data, _ = os.ReadFile(filepath.Join(out, HooksFile))
+	if err := json.Unmarshal(data, &hooks); err != nil {
+		t.Fatalf("hooks.json: %v", err)
+	}
+	post := hooks.Hooks["PostToolUse"]
+	if len(post) != 1 || post[0].Matcher != "Edit|Write" || post[0].Hooks[0].Command != `"${CLAUDE_PLUGIN_ROOT}/scripts/format.sh" --quiet` {
+		t.Errorf("PostToolUse = %+v", post)
+	}
+	if stop := hooks.Hooks["Stop"]; len(stop) != 1 || stop[0].Hooks[0].Command != "echo done" {
+		t.Errorf("Stop = %+v", stop)
+	}
+
+	// A directory with files is only written over with force
+	if _, err := bundle.Write(out, false); !errors.Is(err, ErrNotEmpty) {
+		t.Errorf("Write() into a non-empty directory error = %v, want ErrNotEmpty", err)
+	}
+	if _, err := bundle.Write(out, true); err != nil {
+		t.Errorf("Write(force) error: %v", err)
+	}
+}
+
+func TestValidateName(t *testing.T) {
+	for name, valid := range map[string]bool{"my-plugin": true, "tools2": true, "My-Plugin": false, "my_plugin": false, "-x": false, "": false} {
+		if err := ValidateName(name); (err == nil) != valid {
+			t.Errorf("ValidateName(%q) = %v, want valid %v", name, err, valid)
+		}
+	}
+}
+
+func TestRead(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "team-tools")
+	writeTestFile(t, filepath.Join(dir, ManifestDir, ManifestFile), `{"name": "team-tools", "version": "1.0.0", "commands": "./extra/release.md"}`, 0644)
+	writeTestFile(t, filepath.Join(dir, "skills", "web-fetch", "SKILL.md"), "---\nname: web-fetch\n---\n", 0644)
+	writeTestFile(t, filepath.Join(dir, "skills", "notes.txt"), "not a skill", 0644)
+	writeTestFile(t, filepath.Join(dir, "commands", "team", "deploy.md"), "# Deploy", 0644)
+	writeTestFile(t, filepath.Join(dir, "extra", "release.md"), "# Release", 0644)
+	writeTestFile(t, filepath.Join(dir, "agents", "reviewer.md"), "# Reviewer", 0644)
+	writeTestFile(t, filepath.Join(dir, HooksFile), `{"hooks": {
+		"Stop": [{"hooks": [{"type": "command", "command": "echo done"}]}],
+		"PostToolUse": [{"matcher": "Edit", "hooks": [{"type": "command", "command": "${CLAUDE_PLUGIN_ROOT}/scripts/format.sh"}, {"type": "prompt", "prompt": "Check"}]}]
+	}}`, 0644)
+
+	p, err := Read(dir)
+	if err != nil {
+		t.Fatalf("Read() error: %v", err)
+	}
+	if p.Manifest.Name != "team-tools" || p.Manifest.Version != "1.0.0" {
+		t.Errorf("Manifest = %+v", p.Manifest)
+	}
+	if want := []Resource{{Name: "web-fetch", Path: "skills/web-fetch"}}; !reflect.DeepEqual(p.Skills, want) {
+		t.Errorf("Skills = %+v, want %+v", p.Skills, want)
+	}
+	wantCommands := []Resource{{Name: "team:deploy", Path: "commands/team/deploy.md"}, {Name: "release", Path: "extra/release.md"}}
+	if !reflect.DeepEqual(p.Commands, wantCommands) {
+		t.Errorf("Commands = %+v, want %+v", p.Commands, wantCommands)
+	}
+	if len(p.Agents) != 1 || p.Agents[0].Name != "reviewer" {
+		t.Errorf("Agents = %+v", p.Agents)
+	}
+	wantHooks := []Hook{
+		{EventType: "PostToolUse", Matcher: "Edit", Commands: []string{"${CLAUDE_PLUGIN_ROOT}/scripts/format.sh"}},
+		{EventType: "Stop", Commands: []string{"echo done"}},
+	}
+	if !reflect.DeepEqual(p.Hooks, wantHooks) {
+		t.Errorf("Hooks = %+v, want %+v", p.Hooks, wantHooks)
+	}
+
+	if _, err := Read(t.TempDir()); !errors.Is(err, ErrNoPlugin) {
+		t.Errorf("Read() of an empty directory error = %v, want ErrNoPlugin", err)
+	}
+}
+
+func TestReadRejectsPathsOutside(t *testing.T) {
+	for _, manifest := range []string{
+		`{"name": "tools", "skills": "../../../home/u/.aws"}`,
+		`{"name": "tools", "commands": ["./commands", "./../outside"]}`,
+		`{"name": "tools", "agents": "/etc"}`,
+		`{"name": "tools", "hooks": "../hooks.json"}`,
+	} {
+		dir := t.TempDir()
+		writeTestFile(t, filepath.Join(dir, ManifestDir, ManifestFile), manifest, 0644)
+		if _, err := Read(dir); err == nil || !strings.Contains(err.Error(), "outside the plugin") {
+			t.Errorf("Read() of %s error = %v, want outside the plugin", manifest, err)
+		}
+	}
+}
+
+func TestScriptPath(t *testing.T) {
+	tests := []struct {
+		command, rel, field string
+		ok                  bool
+	}{
+		{"${CLAUDE_PLUGIN_ROOT}/scripts/format.sh --quiet", "scripts/format.sh", "${CLAUDE_PLUGIN_ROOT}/scripts/format.sh", true},
+		{`python3 "${CLAUDE_PLUGIN_ROOT}"/hooks/check.py`, "hooks/check.py", `"${CLAUDE_PLUGIN_ROOT}"/hooks/check.py`, true},
+		{"$CLAUDE_PLUGIN_ROOT/run.sh", "run.sh", "$CLAUDE_PLUGIN_ROOT/run.sh", true},
+		{"${CLAUDE_PLUGIN_ROOT}/../escape.sh", "", "", false},
+		{"echo done", "", "", false},
+	}
+	for _, tt := range tests {
+		rel, field, ok := ScriptPath(tt.command)
+		if rel != tt.rel || field != tt.field || ok != tt.ok {
+			t.Errorf("ScriptPath(%q) = %q, %q, %v, want %q, %q, %v", tt.command, rel, field, ok, tt.rel, tt.field, tt.ok)
+		}
+	}
+}
+
+func TestReadMarketplace(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, ManifestDir, MarketplaceFile), `{
+		"name": "acme",
+		"metadata": {"pluginRoot": "./plugins"},
+		"plugins": [
+			{"name": "formatter", "source": "formatter", "version": "1.2.0"},
+			{"name": "local", "source": "./tools/local"},
+			{"name": "remote", "source": {"source": "github", "repo": "acme/remote", "ref": "v2"}}
+		]
+	}`, 0644)
+
+	m, err := ReadMarketplace(dir)
+	if err != nil {
+		t.Fatalf("ReadMarketplace() error: %v", err)
+	}
+	if len(m.Plugins) != 3 {
+		t.Fatalf("Plugins = %+v", m.Plugins)
+	}
+	if got, _ := m.Dir(dir, m.Plugins[0]); got != filepath.Join(dir, "plugins", "formatter") {
+		t.Errorf("Dir(formatter) = %s", got)
+	}
+	if got, _ := m.Dir(dir, m.Plugins[1]); got != filepath.Join(dir, "tools", "local") {
+		t.Errorf("Dir(local) = %s", got)
+	}
+	if want := (Source{URL: "https://github.com/acme/remote.git", Ref: "v2"}); m.Plugins[2].Source != want {
+		t.Errorf("Source(remote) = %+v, want %+v", m.Plugins[2].Source, want)
+	}
+	if _, err := m.Dir(dir, MarketplaceEntry{Name: "bad", Source: Source{Path: "./../outside"}}); err == nil {
+		t.Error("Dir() of a source outside the marketplace succeeded")
+	}
+
+	if _, err := ReadMarketplace(t.TempDir()); !os.IsNotExist(err) {
+		t.Errorf("ReadMarketplace() without a manifest error = %v, want not exist", err)
+	}
+}
@@ -0,0 +1,374 @@
+package plugin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// MarketplaceFile is the name of a marketplace's manifest, in ManifestDir
+const MarketplaceFile = "marketplace.json"
+
+// ErrNoPlugin is returned when a directory has neither a plugin manifest nor
+// any skills, commands, agents, or hooks in the plugin layout.
+var ErrNoPlugin = errors.New("no Claude Code plugin found (expected .claude-plugin/plugin.json)")
+
+// Plugin is a plugin read from a directory. Paths of its resources are relative
+// to Dir and slash-separated.
+type Plugin struct {
+	Dir      string
+	Manifest Manifest
+	Skills   []Resource
+	Commands []Resource
+	Agents   []Resource
+	Hooks    []Hook
+}
+
+// paths is a manifest field listing component paths: a string or a list of strings
+type paths []string
+
+func (p *paths) UnmarshalJSON(data []byte) error {
+	var one string
+	if err := json.Unmarshal(data, &one); err == nil {
+		*p = paths{one}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return fmt.Errorf("expected a path or a list of paths")
+	}
+	*p = many
+	return nil
+}
+
+// componentPaths are the manifest fields adding component directories or files
+// to the default ones. Hooks may also be given inline.
+type componentPaths struct {
+	Skills   paths           `json:"skills"`
+	Commands paths           `json:"commands"`
+	Agents   paths           `json:"agents"`
+	Hooks    json.RawMessage `json:"hooks"`
+}
+
+// Read reads the plugin in dir: its manifest, and the skills, commands, agents,
+// and hooks in the default directories and in the ones the manifest adds. A
+// plugin without a manifest is named after its directory.
+func Read(dir string) (*Plugin, error) {
+	p := &Plugin{Dir: dir}
+	var extra componentPaths
+
+	data, err := os.ReadFile(filepath.Join(dir, ManifestDir, ManifestFile))
+	hasManifest := err == nil
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(data, &p.Manifest); err != nil {
+			return nil, fmt.Errorf("parse %s: %w", ManifestFile, err)
+		}
+		if err := json.Unmarshal(data, &extra); err != nil {
+			return nil, fmt.Errorf("parse %s: %w", ManifestFile, err)
+		}
+	case os.IsNotExist(err):
+		p.Manifest.Name = filepath.Base(dir)
+	default:
+		return nil, err
+	}
+	if p.Manifest.Name == "" {
+		return nil, fmt.Errorf("parse %s: missing name", ManifestFile)
+	}
+
+	for _, rel := range append(paths{"skills"}, extra.Skills...) {
+		if rel, err = manifestRel(rel); err != nil {
+			return nil, err
+		}
+		p.Skills = appendResources(p.Skills, scanSkills(dir, rel))
+	}
+	for _, rel := range append(paths{"commands"}, extra.Commands...) {
+		if rel, err = manifestRel(rel); err != nil {
+			return nil, err
+		}
+		p.Commands = appendResources(p.Commands, scanMarkdown(dir, rel))
+	}
+	for _, rel := range append(paths{"agents"}, extra.Agents...) {
+		if rel, err = manifestRel(rel); err != nil {
+			return nil, err
+		}
+		p.Agents = appendResources(p.Agents, scanMarkdown(dir, rel))
+	}
+
+	if p.Hooks, err = readHooks(dir, HooksFile); err != nil {
+		return nil, err
+	}
+	if len(extra.Hooks) > 0 {
+		var file string
+		var inline HooksConfig
+		switch {
+		case json.Unmarshal(extra.Hooks, &file) == nil:
+			if file, err = manifestRel(file); err != nil {
+				return nil, err
+			}
+			if file != HooksFile {
+				hooks, err := readHooks(dir, file)
+				if err != nil {
+					return nil, err
+				}
+				p.Hooks = append(p.Hooks, hooks...)
+			}
+		case json.Unmarshal(extra.Hooks, &inline) == nil:
+			p.Hooks = append(p.Hooks, inline.rules()...)
+		default:
+			return nil, fmt.Errorf("parse %s: hooks must be a path or a hooks object", ManifestFile)
+		}
+	}
+
+	if !hasManifest && len(p.Skills)+len(p.Commands)+len(p.Agents)+len(p.Hooks) == 0 {
+		return nil, ErrNoPlugin
+	}
+	return p, nil
+}
+
+// cleanRel cleans a manifest path, which is relative to the plugin root and may start with ./
+func cleanRel(rel string) string {
+	return path.Clean(strings.TrimPrefix(filepath.ToSlash(rel), "./"))
+}
+
+// manifestRel cleans a component path of the manifest, which must be in the plugin
+func manifestRel(rel string) (string, error) {
+	clean := cleanRel(rel)
+	if clean == ".." || strings.HasPrefix(clean, "../") || path.IsAbs(clean) || filepath.IsAbs(rel) {
+		return "", fmt.Errorf("parse %s: %s is outside the plugin", ManifestFile, rel)
+	}
+	return clean, nil
+}
+
+// appendResources appends the resources not in list yet, by path
+func appendResources(list, more []Resource) []Resource {
+	for _, r := range more {
+		found := false
+		for _, have := range list {
+			if have.Path == r.Path {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, r)
+		}
+	}
+	return list
+}
+
+// scanSkills returns the skill directories in rel: the directory itself if it
+// has a SKILL.md, or its subdirectories that do
+func scanSkills(dir, rel string) []Resource {
+	if hasSkillFile(filepath.Join(dir, filepath.FromSlash(rel))) {
+		return []Resource{{Name: path.Base(rel), Path: rel}}
+	}
+	entries, err := os.ReadDir(filepath.Join(dir, filepath.FromSlash(rel)))
+	if err != nil {
+		return nil
+	}
+	var skills []Resource
+	for _, e := range entries {
+		if e.IsDir() && hasSkillFile(filepath.Join(dir, filepath.FromSlash(rel), e.Name())) {
+			skills = append(skills, Resource{Name: e.Name(), Path: path.Join(rel, e.Name())})
+		}
+	}
+	return skills
+}
+
+// hasSkillFile reports whether dir has a SKILL.md
+func hasSkillFile(dir string) bool {
+	for _, name := range []string{"SKILL.md", "skill.md"} {
+		if info, err := os.Stat(filepath.Join(dir, name)); err == nil && !info.IsDir() {
+			return true
+		}
+	}
+	return false
+}
+
+// scanMarkdown returns the markdown files in rel, or rel itself if it is one.
+// Files in subdirectories are named with colons, as Claude Code does
+// (commands/team/deploy.md is team:deploy).
+func scanMarkdown(dir, rel string) []Resource {
+	root := filepath.Join(dir, filepath.FromSlash(rel))
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil
+	}
+	if !info.IsDir() {
+		if !strings.HasSuffix(rel, ".md") {
+			return nil
+		}
+		return []Resource{{Name: strings.TrimSuffix(path.Base(rel), ".md"), Path: rel}}
+	}
+
+	var resources []Resource
+	_ = filepath.WalkDir(root, func(p string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(d.Name(), ".md") {
+			return nil
+		}
+		sub, err := filepath.Rel(root, p)
+		if err != nil {
+			return nil
+		}
+		sub = filepath.ToSlash(sub)
+		resources = append(resources, Resource{
+			Name: strings.ReplaceAll(strings.TrimSuffix(sub, ".md"), "/", ":"),
+			Path: path.Join(rel, sub),
+		})
+		return nil
+	})
+	return resources
+}
+
+// readHooks reads the hook rules of a hooks file, relative to the plugin root.
+// A missing file has none.
+func readHooks(dir, rel string) ([]Hook, error) {
+	data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(rel)))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var config HooksConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", rel, err)
+	}
+	return config.rules(), nil
+}
+
+// rules returns the hook rules of a hooks configuration, ordered by event.
+// Hooks that are not commands (such as prompt hooks) are left out.
+func (c *HooksConfig) rules() []Hook {
+	events := make([]string, 0, len(c.Hooks))
+	for event := range c.Hooks {
+		events = append(events, event)
+	}
+	sort.Strings(events)
+
+	var hooks []Hook
+	for _, event := range events {
+		for _, rule := range c.Hooks[event] {
+			h := Hook{EventType: event, Matcher: rule.Matcher}
+			for _, cmd := range rule.Hooks {
+				if cmd.Type == "command" && cmd.Command != "" {
+					h.Commands = append(h.Commands, cmd.Command)
+				}
+			}
+			if len(h.Commands) > 0 {
+				hooks = append(hooks, h)
+			}
+		}
+	}
+	return hooks
+}
+
+// ScriptPath returns the path, relative to the plugin root, of the file a hook
+// command runs from the plugin's directory, such as scripts/format.sh in
+// "${CLAUDE_PLUGIN_ROOT}/scripts/format.sh" --quiet, and the command's word
+// naming it.
+func ScriptPath(command string) (rel, field string, ok bool) {
+	for _, field := range strings.Fields(command) {
+		unquoted := strings.NewReplacer(`"`, "", "'", "").Replace(field)
+		for _, prefix := range []string{RootVar + "/", "$CLAUDE_PLUGIN_ROOT/"} {
+			if rest, found := strings.CutPrefix(unquoted, prefix); found && rest != "" {
+				rel := path.Clean(rest)
+				if rel == ".." || strings.HasPrefix(rel, "../") {
+					return "", "", false
+				}
+				return rel, field, true
+			}
+		}
+	}
+	return "", "", false
+}
+
+// Marketplace is a marketplace read from its .claude-plugin/marketplace.json:
+// a catalog of plugins, each in the marketplace's repository or elsewhere.
+type Marketplace struct {
+	Name     string             `json:"name"`
+	Plugins  []MarketplaceEntry `json:"plugins"`
+	Metadata struct {
+		PluginRoot string `json:"pluginRoot"` // Directory relative sources are in
+	} `json:"metadata"`
+}
+
+// MarketplaceEntry is a plugin listed in a marketplace
+type MarketplaceEntry struct {
+	Name        string `json:"name"`
+	Source      Source `json:"source"`
+	Version     string `json:"version,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// Source is where a marketplace's plugin is: a path in the marketplace, or a
+// git repository
+type Source struct {
+	Path string // Relative to the marketplace root (or its plugin root)
+	URL  string // Git repository URL
+	Ref  string // Branch or tag of URL (empty: default branch)
+}
+
+// UnmarshalJSON reads a source as a path, or as an object such as
+// {"source": "github", "repo": "owner/repo"} or {"source": "url", "url": "..."}
+func (s *Source) UnmarshalJSON(data []byte) error {
+	var rel string
+	if err := json.Unmarshal(data, &rel); err == nil {
+		*s = Source{Path: rel}
+		return nil
+	}
+	var obj struct {
+		Source string `json:"source"`
+		Repo   string `json:"repo"`
+		URL    string `json:"url"`
+		Ref    string `json:"ref"`
+		Path   string `json:"path"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return fmt.Errorf("expected a path or a source object")
+	}
+	switch {
+	case obj.Source == "github" && obj.Repo != "":
+		*s = Source{URL: "https://github.com/" + obj.Repo + ".git", Ref: obj.Ref}
+	case obj.URL != "":
+		*s = Source{URL: obj.URL, Ref: obj.Ref}
+	case obj.Path != "":
+		*s = Source{Path: obj.Path}
+	default:
+		return fmt.Errorf("unsupported plugin source %q", obj.Source)
+	}
+	return nil
+}
+
+// ReadMarketplace reads the marketplace in dir. It returns an error satisfying
+// os.IsNotExist if dir has no marketplace manifest.
+func ReadMarketplace(dir string) (*Marketplace, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestDir, MarketplaceFile))
+	if err != nil {
+		return nil, err
+	}
+	var m Marketplace
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", MarketplaceFile, err)
+	}
+	return &m, nil
+}
+
+// Dir returns the directory of a plugin whose source is a path in the
+// marketplace in dir
+func (m *Marketplace) Dir(dir string, e MarketplaceEntry) (string, error) {
+	rel := cleanRel(e.Source.Path)
+	if m.Metadata.PluginRoot != "" && !strings.HasPrefix(filepath.ToSlash(e.Source.Path), "./") {
+		rel = path.Join(cleanRel(m.Metadata.PluginRoot), rel)
+	}
+	if rel == ".." || strings.HasPrefix(rel, "../") || path.IsAbs(rel) {
+		return "", fmt.Errorf("plugin %s: source %s is outside the marketplace", e.Name, e.Source.Path)
+	}
+	return filepath.Join(dir, filepath.FromSlash(rel)), nil
+}
@@ -0,0 +1,202 @@
+// Package progress reports the progress of long-running operations such as
+// cloning repositories and copying package files.
+package progress
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	bubblesprogress "github.com/charmbracelet/bubbles/progress"
+	"golang.org/x/term"
+)
+
+// Unit is what a progress total counts
+type Unit int
+
+const (
+	Items Unit = iota // Files, objects, and other countable things
+	Bytes
+)
+
+// Reporter receives the progress of a task. A task is started with its total
+// (0 if unknown), updated with the amount done so far, and finished once,
+// also when it fails. Starting a new task finishes the current one.
+type Reporter interface {
+	Start(label string, total int64, unit Unit)
+	Update(done int64)
+	Finish()
+}
+
+// Discard is a Reporter that reports nothing
+var Discard Reporter = discard{}
+
+type discard struct{}
+
+func (discard) Start(string, int64, Unit) {}
+func (discard) Update(int64)              {}
+func (discard) Finish()                   {}
+
+// New returns a Reporter writing to w: a progress bar redrawn in place if w is
+// a terminal, or plain lines at every quarter otherwise (e.g., in CI logs)
+func New(w io.Writer) Reporter {
+	if f, ok := w.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		bar := bubblesprogress.New(bubblesprogress.WithDefaultGradient(), bubblesprogress.WithWidth(30), bubblesprogress.WithoutPercentage())
+		return &barReporter{w: w, bar: bar}
+	}
+	return &lineReporter{w: w}
+}
+
+// task is the state shared by the reporters
+type task struct {
+	label   string
+	total   int64
+	unit    Unit
+	done    int64
+	started time.Time
+	active  bool
+}
+
+func (t *task) start(label string, total int64, unit Unit) {
+	*t = task{label: label, total: total, unit: unit, started: time.Now(), active: true}
+}
+
+func (t *task) percent() float64 {
+	if t.total <= 0 {
+		return 0
+	}
+	return min(float64(t.done)/float64(t.total), 1)
+}
+
+// eta estimates the remaining time from the average rate so far
+func (t *task) eta() time.Duration {
+	if t.total <= 0 || t.done <= 0 || t.done >= t.total {
+		return 0
+	}
+	elapsed := time.Since(t.started)
+	return time.Duration(float64(elapsed) / float64(t.done) * float64(t.total-t.done))
+}
+
+// counts formats done/total, e.g. "12/40" or "1.2 MiB/3.0 MiB"
+func (t *task) counts() string {
+	if t.unit == Bytes {
+		if t.total > 0 {
+			return formatBytes(t.done) + "/" + formatBytes(t.total)
+		}
+		return formatBytes(t.done)
+	}
+	if t.total > 0 {
+		return fmt.Sprintf("%d/%d", t.done, t.total)
+	}
+	return fmt.Sprintf("%d", t.done)
+}
+
+// barReporter redraws a progress bar on a single terminal line
+type barReporter struct {
+	w    io.Writer
+	bar  bubblesprogress.Model
+	task task
+	last time.Time
+}
+
+func (r *barReporter) Start(label string, total int64, unit Unit) {
+	r.Finish()
+	r.task.start(label, total, unit)
+	r.draw()
+}
+
+func (r *barReporter) Update(done int64) {
+	if !r.task.active {
+		return
+	}
+	r.task.done = done
+	// Redraw at most every 50ms; the final update is always drawn by Finish
+	if time.Since(r.last) >= 50*time.Millisecond {
+		r.draw()
+	}
+}
+
+func (r *barReporter) Finish() {
+	if !r.task.active {
+		return
+	}
+	r.draw()
+	fmt.Fprintln(r.w)
+	r.task.active = false
+}
+
+func (r *barReporter) draw() {
+	r.last = time.Now()
+	line := fmt.Sprintf("%s %s %3.0f%% %s", r.task.label, r.bar.ViewAs(r.task.percent()), r.task.percent()*100, r.task.counts())
+	if eta := r.task.eta(); eta >= time.Second {
+		line += " ETA " + formatDuration(eta)
+	}
+	// Return to the start of the line and clear it before redrawing
+	fmt.Fprint(r.w, "\r\033[K"+line)
+}
+
+// lineReporter prints a line when a task starts, at every quarter, and when it finishes
+type lineReporter struct {
+	w       io.Writer
+	task    task
+	quarter int64
+}
+
+func (r *lineReporter) Start(label string, total int64, unit Unit) {
+	r.Finish()
+	r.task.start(label, total, unit)
+	r.quarter = 0
+	if total > 0 {
+		fmt.Fprintf(r.w, "%s: %s\n", label, r.task.counts())
+	} else {
+		fmt.Fprintf(r.w, "%s...\n", label)
+	}
+}
+
+func (r *lineReporter) Update(done int64) {
+	if !r.task.active || r.task.total <= 0 {
+		return
+	}
+	r.task.done = done
+	quarter := int64(r.task.percent() * 4)
+	if quarter <= r.quarter || quarter >= 4 {
+		return
+	}
+	r.quarter = quarter
+	line := fmt.Sprintf("%s: %d%% (%s)", r.task.label, quarter*25, r.task.counts())
+	if eta := r.task.eta(); eta >= time.Second {
+		line += ", ETA " + formatDuration(eta)
+	}
+	fmt.Fprintln(r.w, line)
+}
+
+func (r *lineReporter) Finish() {
+	if !r.task.active {
+		return
+	}
+	fmt.Fprintf(r.w, "%s: %s in %s\n", r.task.label, r.task.counts(), formatDuration(time.Since(r.task.started)))
+	r.task.active = false
+}
+
+// formatBytes formats a byte count with binary units, e.g. "1.5 MiB"
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// formatDuration formats a duration rounded for display, e.g. "120ms", "3s", or "1m20s"
+func formatDuration(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+	return d.Round(time.Second).String()
+}
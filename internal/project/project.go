@@ -0,0 +1,143 @@
+// Package project bootstraps Claude Code configuration for a project: a starter
+// CLAUDE.md, recommended hooks for the detected stack, and a jd manifest.
+package project
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/itda-skills/jindo/internal/detect"
+	"github.com/itda-skills/jindo/internal/hook"
+)
+
+// editMatcher matches the tools that write files
+const editMatcher = "Edit|MultiEdit|Write"
+
+// Profile describes a project for bootstrapping
+type Profile struct {
+	Name    string // From the README title, or the directory name
+	Summary string // First paragraph of the README
+	Stack   *detect.Profile
+}
+
+// RecommendedHook is a hook suggested for a detected language
+type RecommendedHook struct {
+	Description string
+	Event       hook.EventType
+	Matcher     string
+	Command     string
+}
+
+// Detect reads the project's README and detects its stack
+func Detect(root string) *Profile {
+	p := &Profile{Name: filepath.Base(root), Stack: detect.Detect(root)}
+	title, summary := readmeIntro(root)
+	if title != "" {
+		p.Name = title
+	}
+	p.Summary = summary
+	return p
+}
+
+// markdownNoise matches README lines that are not prose: badges, images, and HTML
+var markdownNoise = regexp.MustCompile(`^(\[!\[|!\[|<)`)
+
+// readmeIntro returns the title and first prose paragraph of the project README
+func readmeIntro(root string) (title, summary string) {
+	f, err := os.Open(filepath.Join(root, "README.md"))
+	if err != nil {
+		return "", ""
+	}
+	defer f.Close()
+
+	var para []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "# ") && title == "" && len(para) == 0 {
+			title = strings.TrimSpace(strings.TrimPrefix(line, "# "))
+			continue
+		}
+		// The introduction ends at the first section, code block, or blank line after prose
+		if strings.HasPrefix(line, "#") || strings.HasPrefix(line, "```") || (line == "" && len(para) > 0) {
+			break
+		}
+		if line != "" && !markdownNoise.MatchString(line) {
+			para = append(para, line)
+		}
+	}
+	return title, strings.Join(para, " ")
+}
+
+// ClaudeMD returns a starter CLAUDE.md for the project
+func ClaudeMD(p *Profile) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", p.Name)
+	if p.Summary != "" {
+		fmt.Fprintf(&b, "%s\n\n", p.Summary)
+	}
+
+	if !p.Stack.Empty() {
+		b.WriteString("## Stack\n\n")
+		for _, line := range p.Stack.Lines() {
+			fmt.Fprintf(&b, "- %s\n", line)
+		}
+		b.WriteString("\n")
+	}
+
+	var cmds []string
+	languages := p.Stack.Languages
+	for _, l := range languages {
+		for _, c := range [][2]string{{"Build", l.Build}, {"Test", l.Test}, {"Lint", l.Lint}} {
+			if c[1] == "" {
+				continue
+			}
+			label := c[0]
+			if len(languages) > 1 {
+				label += " (" + l.Name + ")"
+			}
+			cmds = append(cmds, fmt.Sprintf("- %s: `%s`", label, c[1]))
+		}
+	}
+	if len(cmds) > 0 {
+		b.WriteString("## Commands\n\n")
+		b.WriteString(strings.Join(cmds, "\n") + "\n\n")
+	}
+
+	b.WriteString("## Conventions\n\n")
+	b.WriteString("<!-- Describe the architecture, coding conventions, and anything Claude should avoid. -->\n")
+	return b.String()
+}
+
+// formatterHooks maps formatters to the files they format and the command that formats one
+var formatterHooks = map[string]struct{ patterns, command string }{
+	"gofmt":    {"*.go", `gofmt -w "$f"`},
+	"prettier": {"*.js|*.jsx|*.ts|*.tsx|*.json|*.css|*.md", `npx --no-install prettier --write "$f"`},
+	"ruff":     {"*.py", `ruff format "$f"`},
+	"black":    {"*.py", `black -q "$f"`},
+	"rustfmt":  {"*.rs", `rustfmt "$f"`},
+}
+
+// RecommendedHooks returns hooks suited to the project's languages: formatting each
+// file Claude edits with the formatter the project uses
+func RecommendedHooks(p *Profile) []RecommendedHook {
+	var hooks []RecommendedHook
+	for _, l := range p.Stack.Languages {
+		fh, ok := formatterHooks[l.Formatter]
+		if !ok {
+			continue
+		}
+		hooks = append(hooks, RecommendedHook{
+			Description: fmt.Sprintf("Format edited %s files with %s", l.Name, l.Formatter),
+			Event:       hook.PostToolUse,
+			Matcher:     editMatcher,
+			Command: fmt.Sprintf(`f=$(jd hooks payload tool_input.file_path); case "$f" in %s) %s ;; esac`,
+				fh.patterns, fh.command),
+		})
+	}
+	return hooks
+}
@@ -0,0 +1,47 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDetect(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"README.md":      "# Widget\n\n[![ci](badge.svg)](ci)\nBuilds widgets\nquickly.\n\n## Install\n",
+		"go.mod":         "module widget\n",
+		"pyproject.toml": "[tool.ruff]\nline-length = 100\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	p := Detect(root)
+	if p.Name != "Widget" || p.Summary != "Builds widgets quickly." {
+		t.Errorf("Detect() name, summary = %q, %q", p.Name, p.Summary)
+	}
+
+	md := ClaudeMD(p)
+	for _, want := range []string{"# Widget\n\nBuilds widgets quickly.\n", "- Go (go.mod)\n", "- Test (Go): `go test ./...`", "- Lint (Python): `ruff check .`"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("ClaudeMD() missing %q:\n%s", want, md)
+		}
+	}
+
+	hooks := RecommendedHooks(p)
+	if len(hooks) != 2 || !strings.Contains(hooks[0].Command, "*.go) gofmt -w") || !strings.Contains(hooks[1].Command, "*.py) ruff format") {
+		t.Errorf("RecommendedHooks() = %+v", hooks)
+	}
+}
+
+func TestDetectNoReadme(t *testing.T) {
+	root := t.TempDir()
+	p := Detect(root)
+	if p.Name != filepath.Base(root) || p.Summary != "" || len(RecommendedHooks(p)) != 0 {
+		t.Errorf("Detect() on an empty directory = %+v", p)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/itda-skills/jindo/internal/basedir"
 )
 
 //go:embed prompts/*.md
@@ -15,16 +17,13 @@ var embeddedPrompts embed.FS
 type PromptInfo struct {
 	Name       string // e.g., "adapt-skill"
 	IsOverride bool   // true if loaded from override file
+	IsCustom   bool   // true if the prompt has no embedded version (e.g., a duplicate)
 	Path       string // path to the prompt file (empty for embedded)
 }
 
 // GetOverrideDir returns the directory for override prompts
 func GetOverrideDir() (string, error) {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return "", err
-	}
-	return filepath.Join(home, ".claude", "jindo", "prompts"), nil
+	return basedir.ClaudePath("jindo", "prompts")
 }
 
 // EnsureOverrideDir creates the override directory if it doesn't exist
@@ -161,5 +160,54 @@ func List() ([]PromptInfo, error) {
 		prompts = append(prompts, info)
 	}
 
+	// Custom prompts exist only in the override directory
+	dir, err := GetOverrideDir()
+	if err != nil {
+		return prompts, nil
+	}
+	overrides, err := os.ReadDir(dir)
+	if err != nil {
+		return prompts, nil
+	}
+	for _, entry := range overrides {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
+			continue
+		}
+		name := strings.TrimSuffix(entry.Name(), ".md")
+		if _, err := GetEmbedded(name); err == nil {
+			continue
+		}
+		prompts = append(prompts, PromptInfo{
+			Name:       name,
+			IsOverride: true,
+			IsCustom:   true,
+			Path:       filepath.Join(dir, entry.Name()),
+		})
+	}
+
 	return prompts, nil
 }
+
+// Exists checks if a prompt exists, either embedded or as an override
+func Exists(name string) bool {
+	if _, err := GetEmbedded(name); err == nil {
+		return true
+	}
+	return HasOverride(name)
+}
+
+// Duplicate copies the current content of prompt src to a new custom prompt dst
+func Duplicate(src, dst string) error {
+	if dst == "" || strings.ContainsAny(dst, `/\`) || strings.HasPrefix(dst, ".") {
+		return fmt.Errorf("invalid prompt name: %q", dst)
+	}
+	if Exists(dst) {
+		return fmt.Errorf("prompt already exists: %s", dst)
+	}
+
+	content, err := Load(src)
+	if err != nil {
+		return err
+	}
+	return SaveOverride(dst, content)
+}
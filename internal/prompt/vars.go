@@ -0,0 +1,107 @@
+package prompt
+
+import (
+	"sort"
+	"text/template"
+	"text/template/parse"
+)
+
+// Variable describes a template variable jd supplies when rendering a prompt
+type Variable struct {
+	Name string // e.g., "Content"
+	List bool   // true if the value is a list of strings (e.g., hook commands)
+}
+
+// runtimeVariables lists the variables each embedded prompt is rendered with
+var runtimeVariables = map[string][]Variable{
+	"adapt-agent":    {{Name: "AgentID"}, {Name: "AgentPath"}, {Name: "Content"}},
+	"adapt-hook":     {{Name: "HookName"}, {Name: "EventType"}, {Name: "Matcher"}, {Name: "Commands", List: true}},
+	"adapt-skill":    {{Name: "SkillID"}, {Name: "SkillPath"}, {Name: "Content"}},
+	"guide-agent":    {{Name: "AgentID"}, {Name: "AgentPath"}, {Name: "Content"}},
+	"guide-claudemd": {{Name: "Mode"}, {Name: "Content"}, {Name: "Stack"}},
+	"guide-command":  {{Name: "CommandName"}, {Name: "CommandPath"}, {Name: "Content"}},
+	"guide-hook":     {{Name: "HookName"}, {Name: "HookPath"}, {Name: "HookType"}, {Name: "Content"}},
+	"guide-skill":    {{Name: "SkillID"}, {Name: "SkillPath"}, {Name: "Content"}},
+	"import-skill":   {{Name: "SkillName"}, {Name: "Source"}, {Name: "Content"}},
+	"tidy-claudemd":  {{Name: "Content"}, {Name: "Style"}, {Name: "TargetTokens"}, {Name: "CurrentTokens"}},
+}
+
+// Variables returns the variables jd supplies when rendering the named prompt.
+// Returns false for prompts jd never renders itself (e.g., custom prompts).
+func Variables(name string) ([]Variable, bool) {
+	vars, ok := runtimeVariables[name]
+	return vars, ok
+}
+
+// ReferencedVariables parses a prompt template and returns the sorted names of the
+// top-level variables it references (e.g., "Content" for {{.Content}}).
+// Fields inside range and with blocks refer to the inner value and are not included,
+// except when accessed through $ (e.g., {{$.Content}}).
+func ReferencedVariables(name, content string) ([]string, error) {
+	tmpl, err := template.New(name).Parse(content)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	if tmpl.Tree != nil {
+		collectVariables(tmpl.Tree.Root, false, seen)
+	}
+
+	names := make([]string, 0, len(seen))
+	for n := range seen {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// collectVariables walks a template parse tree, recording top-level field names.
+// rebound is true inside range/with bodies, where dot no longer refers to the data.
+func collectVariables(node parse.Node, rebound bool, seen map[string]bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			collectVariables(child, rebound, seen)
+		}
+	case *parse.ActionNode:
+		collectVariables(n.Pipe, rebound, seen)
+	case *parse.IfNode:
+		collectBranch(&n.BranchNode, rebound, rebound, seen)
+	case *parse.RangeNode:
+		collectBranch(&n.BranchNode, rebound, true, seen)
+	case *parse.WithNode:
+		collectBranch(&n.BranchNode, rebound, true, seen)
+	case *parse.TemplateNode:
+		collectVariables(n.Pipe, rebound, seen)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, c := range n.Cmds {
+			for _, arg := range c.Args {
+				collectVariables(arg, rebound, seen)
+			}
+		}
+	case *parse.ChainNode:
+		collectVariables(n.Node, rebound, seen)
+	case *parse.FieldNode:
+		if !rebound && len(n.Ident) > 0 {
+			seen[n.Ident[0]] = true
+		}
+	case *parse.VariableNode:
+		if len(n.Ident) > 1 && n.Ident[0] == "$" {
+			seen[n.Ident[1]] = true
+		}
+	}
+}
+
+// collectBranch walks an if/range/with node; bodyRebound applies to the body only
+func collectBranch(n *parse.BranchNode, rebound, bodyRebound bool, seen map[string]bool) {
+	collectVariables(n.Pipe, rebound, seen)
+	collectVariables(n.List, bodyRebound, seen)
+	collectVariables(n.ElseList, rebound, seen)
+}
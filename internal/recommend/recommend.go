@@ -0,0 +1,187 @@
+// Package recommend ranks packages from registered repositories by how well they
+// fit a project's detected stack.
+package recommend
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/itda-skills/jindo/internal/agent"
+	"github.com/itda-skills/jindo/internal/command"
+	"github.com/itda-skills/jindo/internal/detect"
+	"github.com/itda-skills/jindo/internal/pkg/pkgmgr"
+	"github.com/itda-skills/jindo/internal/pkg/repo"
+	"github.com/itda-skills/jindo/internal/skill"
+)
+
+// Score weights for where a stack keyword is found
+const (
+	nameWeight        = 3 // In the package name or its tags
+	descriptionWeight = 1 // In the description (or a hook script's content)
+	formatterBonus    = 2 // A hook for the formatter the project is set up for
+)
+
+// keywords maps detect tags to the words that identify a package for them.
+// Tags without an entry match their own name.
+var keywords = map[string][]string{
+	"go":                  {"go", "golang"},
+	"gofmt":               {"gofmt", "goimports"},
+	"node":                {"javascript", "typescript", "node", "nodejs", "npm", "js", "ts"},
+	"python":              {"python", "pytest", "py"},
+	"rust":                {"rust", "cargo"},
+	"docker":              {"docker", "dockerfile", "container"},
+	"docker-compose":      {"compose", "docker-compose"},
+	"github-actions":      {"github actions", "github-actions", "gh actions"},
+	"gitlab-ci":           {"gitlab", "gitlab-ci"},
+	"circleci":            {"circleci"},
+	"jenkins":             {"jenkins", "jenkinsfile"},
+	"azure-pipelines":     {"azure pipelines", "azure-pipelines"},
+	"bitbucket-pipelines": {"bitbucket"},
+	"travis":              {"travis"},
+}
+
+// Recommendation is a package suggested for a project
+type Recommendation struct {
+	Namespace   string           `json:"namespace"`
+	Name        string           `json:"name"`
+	Path        string           `json:"path"`
+	Type        repo.PackageType `json:"type"`
+	Description string           `json:"description,omitempty"`
+	Score       int              `json:"score"`
+	Reasons     []string         `json:"reasons"`
+}
+
+// Spec returns the install specification (namespace:path)
+func (r *Recommendation) Spec() string {
+	return r.Namespace + ":" + r.Path
+}
+
+// Recommend ranks the packages of every registered repository against the profile,
+// skipping installed packages and packages that match nothing. Ties are ordered by
+// namespace and path.
+func Recommend(store *repo.Store, installed []pkgmgr.InstalledPackage, profile *detect.Profile, typeFilter repo.PackageType) ([]Recommendation, error) {
+	repos, err := store.List()
+	if err != nil {
+		return nil, err
+	}
+
+	isInstalled := make(map[string]bool, len(installed))
+	for _, p := range installed {
+		isInstalled[p.Namespace+":"+p.SourcePath] = true
+	}
+
+	var recs []Recommendation
+	for _, r := range repos {
+		items, err := store.Browse(r.Namespace, typeFilter)
+		if err != nil {
+			continue // Skip repos that fail, as search does
+		}
+		root, err := store.PackageRoot(r.Namespace)
+		if err != nil {
+			continue
+		}
+
+		for _, item := range items {
+			if isInstalled[r.Namespace+":"+item.Path] {
+				continue
+			}
+			rec := Recommendation{Namespace: r.Namespace, Name: item.Name, Path: item.Path, Type: item.Type}
+			score(&rec, filepath.Join(root, filepath.FromSlash(item.Path)), profile)
+			if rec.Score > 0 {
+				recs = append(recs, rec)
+			}
+		}
+	}
+
+	sort.SliceStable(recs, func(i, j int) bool {
+		if recs[i].Score != recs[j].Score {
+			return recs[i].Score > recs[j].Score
+		}
+		return recs[i].Spec() < recs[j].Spec()
+	})
+	return recs, nil
+}
+
+// score rates a package against each detected tag and records why it matched
+func score(rec *Recommendation, path string, profile *detect.Profile) {
+	description, tags, body := packageText(rec.Type, path)
+	rec.Description = description
+
+	nameWords := words(rec.Name + " " + strings.Join(tags, " "))
+	textWords := words(description + " " + body)
+
+	formatters := make(map[string]bool)
+	for _, l := range profile.Languages {
+		if l.Formatter != "" {
+			formatters[l.Formatter] = true
+		}
+	}
+
+	for _, tag := range profile.Tags() {
+		kws, ok := keywords[tag]
+		if !ok {
+			kws = []string{tag}
+		}
+		switch {
+		case matchesAny(nameWords, kws):
+			rec.Score += nameWeight
+			rec.Reasons = append(rec.Reasons, "name matches "+tag)
+		case matchesAny(textWords, kws):
+			rec.Score += descriptionWeight
+			rec.Reasons = append(rec.Reasons, "mentions "+tag)
+		default:
+			continue
+		}
+		if rec.Type == repo.TypeHook && formatters[tag] {
+			rec.Score += formatterBonus
+			rec.Reasons = append(rec.Reasons, "project is set up for "+tag)
+		}
+	}
+}
+
+// packageText returns a package's description and tags from its frontmatter. Hooks
+// have no frontmatter, so their script content is returned as body instead.
+func packageText(pkgType repo.PackageType, path string) (description string, tags []string, body string) {
+	switch pkgType {
+	case repo.TypeSkill:
+		for _, name := range []string{"SKILL.md", "skill.md"} {
+			if s, err := skill.ParseSkillFile(filepath.Join(path, name)); err == nil {
+				return s.Description, s.Tags, ""
+			}
+		}
+	case repo.TypeCommand:
+		if c, err := command.ParseCommandFile(path); err == nil {
+			return c.Description, c.Tags, ""
+		}
+	case repo.TypeAgent:
+		if a, err := agent.ParseAgentFile(path); err == nil {
+			return a.Description, a.Tags, ""
+		}
+	case repo.TypeHook:
+		if content, err := os.ReadFile(path); err == nil {
+			return "", nil, string(content)
+		}
+	}
+	return "", nil, ""
+}
+
+// words normalizes text to lowercase words separated by single spaces, padded with
+// a space on each side so keywords can be matched as whole words
+func words(text string) string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	})
+	return " " + strings.Join(fields, " ") + " "
+}
+
+// matchesAny reports whether any keyword appears as whole words in normalized text
+func matchesAny(text string, kws []string) bool {
+	for _, kw := range kws {
+		if strings.Contains(text, words(kw)) {
+			return true
+		}
+	}
+	return false
+}
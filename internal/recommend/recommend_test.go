@@ -0,0 +1,42 @@
+package recommend
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/itda-skills/jindo/internal/detect"
+	"github.com/itda-skills/jindo/internal/pkg/repo"
+)
+
+func TestScore(t *testing.T) {
+	dir := t.TempDir()
+	hookPath := filepath.Join(dir, "format.sh")
+	if err := os.WriteFile(hookPath, []byte("#!/bin/sh\nnpx prettier --write \"$f\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	profile := &detect.Profile{Languages: []detect.Language{{ID: "node", Formatter: "prettier"}}}
+
+	rec := Recommendation{Name: "format.sh", Type: repo.TypeHook}
+	score(&rec, hookPath, profile)
+	if rec.Score != descriptionWeight+formatterBonus {
+		t.Errorf("hook score = %d, want %d", rec.Score, descriptionWeight+formatterBonus)
+	}
+	if want := []string{"mentions prettier", "project is set up for prettier"}; !reflect.DeepEqual(rec.Reasons, want) {
+		t.Errorf("hook reasons = %v, want %v", rec.Reasons, want)
+	}
+
+	// Short keywords only match whole words: "ts" in "tests" is not TypeScript
+	rec = Recommendation{Name: "tests", Type: repo.TypeHook}
+	score(&rec, filepath.Join(dir, "missing"), profile)
+	if rec.Score != 0 {
+		t.Errorf("score for unrelated name = %d, reasons %v", rec.Score, rec.Reasons)
+	}
+
+	rec = Recommendation{Name: "node-ts-lint", Type: repo.TypeHook}
+	score(&rec, filepath.Join(dir, "missing"), profile)
+	if rec.Score != nameWeight {
+		t.Errorf("score for matching name = %d, want %d", rec.Score, nameWeight)
+	}
+}
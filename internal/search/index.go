@@ -0,0 +1,126 @@
+package search
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// indexVersion is the version of the index file format; other versions are discarded
+const indexVersion = 1
+
+// indexEntry is the lowercased content of a file, valid while its mtime and size match
+type indexEntry struct {
+	ModTime int64  `json:"mtime"` // Unix nanoseconds
+	Size    int64  `json:"size"`
+	Content string `json:"content"`
+}
+
+// indexFile is the structure of the index file
+type indexFile struct {
+	Version int                   `json:"version"`
+	Entries map[string]indexEntry `json:"entries"`
+}
+
+// Index caches the lowercased contents of searched files, keyed by path and
+// invalidated when a file's modification time or size changes. It is safe for
+// concurrent use.
+type Index struct {
+	path    string
+	mu      sync.Mutex
+	entries map[string]indexEntry
+	used    map[string]bool
+	dirty   bool
+}
+
+// NewIndex returns an empty index saved to path; an empty path keeps it in memory
+func NewIndex(path string) *Index {
+	return &Index{path: path, entries: make(map[string]indexEntry), used: make(map[string]bool)}
+}
+
+// LoadIndex reads the index saved at path. A missing, unreadable, or outdated
+// index file yields an empty index, since it only saves work.
+func LoadIndex(path string) *Index {
+	idx := NewIndex(path)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return idx
+	}
+	var file indexFile
+	if json.Unmarshal(data, &file) != nil || file.Version != indexVersion {
+		return idx
+	}
+	if file.Entries != nil {
+		idx.entries = file.Entries
+	}
+	return idx
+}
+
+// Content returns the lowercased content of a file, from the index if the file
+// has not changed since it was indexed
+func (i *Index) Content(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	modTime, size := info.ModTime().UnixNano(), info.Size()
+
+	i.mu.Lock()
+	entry, ok := i.entries[path]
+	i.used[path] = true
+	i.mu.Unlock()
+	if ok && entry.ModTime == modTime && entry.Size == size {
+		return entry.Content, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	content := strings.ToLower(string(data))
+
+	i.mu.Lock()
+	i.entries[path] = indexEntry{ModTime: modTime, Size: size, Content: content}
+	i.dirty = true
+	i.mu.Unlock()
+	return content, nil
+}
+
+// Save writes the index if it changed, dropping entries of files that no longer exist
+func (i *Index) Save() error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	for path := range i.entries {
+		if i.used[path] {
+			continue
+		}
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			delete(i.entries, path)
+			i.dirty = true
+		}
+	}
+	if i.path == "" || !i.dirty {
+		return nil
+	}
+
+	data, err := json.Marshal(indexFile{Version: indexVersion, Entries: i.entries})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(i.path), 0755); err != nil {
+		return err
+	}
+	// Write to a temporary file first, so a concurrent search never reads a partial index
+	tmp := i.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, i.path); err != nil {
+		return err
+	}
+	i.dirty = false
+	return nil
+}
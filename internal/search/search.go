@@ -0,0 +1,142 @@
+// Package search matches skills, commands, and agents against a query, scanning
+// file contents concurrently with a cache of their lowercased text.
+package search
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// Document is a resource to search
+type Document struct {
+	Type        string // "skill", "command", "agent"
+	Name        string
+	Description string
+	Tags        []string
+	Path        string // File whose content is searched
+}
+
+// Result is a document that matches the query
+type Result struct {
+	Document
+	MatchIn string // Where the match was found: "name", "tag", "description", "content"
+	Order   int    // Position of the document in the scanned list
+}
+
+// Options configures a scan
+type Options struct {
+	NameOnly bool   // Match names only, without reading files
+	Workers  int    // Concurrent content reads (default: number of CPUs)
+	Limit    int    // Stop after this many results (0: no limit)
+	Index    *Index // Cache of file contents (default: read every file)
+
+	// Candidates are the paths whose content may contain the query, from a
+	// full-text index; other documents are matched by metadata only (nil: read every file)
+	Candidates map[string]bool
+}
+
+// Scan matches docs against query, calling emit for each match as it is found,
+// from one goroutine at a time. Documents are checked by name, tags, and
+// description first; only those that miss are read. Scanning stops after
+// opts.Limit results or when ctx is done.
+func Scan(ctx context.Context, docs []Document, query string, opts Options, emit func(Result)) error {
+	query = strings.ToLower(query)
+	index := opts.Index
+	if index == nil {
+		index = NewIndex("")
+	}
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu      sync.Mutex
+		emitted int
+	)
+	// report emits a result unless the limit was reached, and reports whether to continue
+	report := func(r Result) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		if opts.Limit > 0 && emitted >= opts.Limit {
+			return false
+		}
+		emit(r)
+		emitted++
+		if opts.Limit > 0 && emitted >= opts.Limit {
+			cancel()
+			return false
+		}
+		return true
+	}
+
+	// Metadata matches need no file reads, so they are reported first
+	var unmatched []int
+	for i, doc := range docs {
+		if ctx.Err() != nil {
+			break
+		}
+		if matchIn := matchMetadata(doc, query, opts.NameOnly); matchIn != "" {
+			if !report(Result{Document: doc, MatchIn: matchIn, Order: i}) {
+				break
+			}
+		} else if !opts.NameOnly && (opts.Candidates == nil || opts.Candidates[doc.Path]) {
+			unmatched = append(unmatched, i)
+		}
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(workers, len(unmatched)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if ctx.Err() != nil {
+					continue
+				}
+				content, err := index.Content(docs[i].Path)
+				if err == nil && strings.Contains(content, query) {
+					report(Result{Document: docs[i], MatchIn: "content", Order: i})
+				}
+			}
+		}()
+	}
+	for _, i := range unmatched {
+		if ctx.Err() != nil {
+			break
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil && opts.Limit > 0 && emitted >= opts.Limit {
+		return nil // Stopped at the limit
+	}
+	return ctx.Err()
+}
+
+// matchMetadata returns where query matches a document's name, tags, or description
+func matchMetadata(doc Document, query string, nameOnly bool) string {
+	if strings.Contains(strings.ToLower(doc.Name), query) {
+		return "name"
+	}
+	if nameOnly {
+		return ""
+	}
+	for _, tag := range doc.Tags {
+		if strings.Contains(strings.ToLower(tag), query) {
+			return "tag"
+		}
+	}
+	if strings.Contains(strings.ToLower(doc.Description), query) {
+		return "description"
+	}
+	return ""
+}
@@ -0,0 +1,134 @@
+package search
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestScan(t *testing.T) {
+	dir := t.TempDir()
+	docs := []Document{
+		{Type: "skill", Name: "web-fetch", Path: writeFile(t, dir, "a.md", "Fetch pages")},
+		{Type: "command", Name: "commit", Tags: []string{"Git"}, Path: writeFile(t, dir, "b.md", "Commit changes")},
+		{Type: "agent", Name: "reviewer", Description: "Reviews git diffs", Path: writeFile(t, dir, "c.md", "Review")},
+		{Type: "agent", Name: "helper", Path: writeFile(t, dir, "d.md", "Uses GIT to look around")},
+		{Type: "skill", Name: "missing", Path: filepath.Join(dir, "missing.md")},
+	}
+
+	scan := func(query string, opts Options) []Result {
+		t.Helper()
+		var results []Result
+		if err := Scan(context.Background(), docs, query, opts, func(r Result) { results = append(results, r) }); err != nil {
+			t.Fatalf("Scan() error: %v", err)
+		}
+		sort.Slice(results, func(i, j int) bool { return results[i].Order < results[j].Order })
+		return results
+	}
+	matches := func(results []Result) map[string]string {
+		m := make(map[string]string)
+		for _, r := range results {
+			m[r.Name] = r.MatchIn
+		}
+		return m
+	}
+
+	t.Run("matches metadata and content", func(t *testing.T) {
+		got := matches(scan("git", Options{Workers: 2}))
+		want := map[string]string{"commit": "tag", "reviewer": "description", "helper": "content"}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Scan(git) = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("name only", func(t *testing.T) {
+		got := matches(scan("e", Options{NameOnly: true}))
+		want := map[string]string{"web-fetch": "name", "reviewer": "name", "helper": "name"}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Scan(e, name only) = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("reads candidates only", func(t *testing.T) {
+		got := matches(scan("git", Options{Candidates: map[string]bool{docs[2].Path: true}}))
+		want := map[string]string{"commit": "tag", "reviewer": "description"}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Scan(git, candidates) = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("stops at the limit", func(t *testing.T) {
+		if got := scan("git", Options{Limit: 1}); len(got) != 1 {
+			t.Errorf("Scan(git, limit 1) returned %d results, want 1", len(got))
+		}
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if err := Scan(ctx, docs, "git", Options{}, func(Result) {}); err == nil {
+			t.Error("Scan() with a cancelled context should return an error")
+		}
+	})
+}
+
+func TestIndex(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "skill.md", "Hello World")
+	mtime := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(path, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+
+	indexPath := filepath.Join(dir, "cache", "search-index.json")
+	idx := LoadIndex(indexPath)
+	if content, err := idx.Content(path); err != nil || content != "hello world" {
+		t.Fatalf("Content() = %q, %v; want lowercased content", content, err)
+	}
+	if err := idx.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	// Same size and mtime: the saved index is used without reading the file
+	writeFile(t, dir, "skill.md", "Other Words")
+	if err := os.Chtimes(path, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+	idx = LoadIndex(indexPath)
+	if content, _ := idx.Content(path); content != "hello world" {
+		t.Errorf("Content() from index = %q, want hello world", content)
+	}
+
+	// A new mtime invalidates the entry
+	if err := os.Chtimes(path, time.Now(), time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if content, _ := idx.Content(path); content != "other words" {
+		t.Errorf("Content() after change = %q, want other words", content)
+	}
+
+	// Entries of deleted files are dropped on save
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	idx = LoadIndex(indexPath)
+	if err := idx.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(LoadIndex(indexPath).entries); n != 0 {
+		t.Errorf("index has %d entries after the file was deleted, want 0", n)
+	}
+}
@@ -0,0 +1,147 @@
+// Package sessionlog reads the session transcripts Claude Code keeps in
+// ~/.claude/projects to find which skills, agents, and slash commands were used.
+package sessionlog
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Usage counts the uses of skills, agents, and slash commands, by name
+type Usage struct {
+	Skills   map[string]int `json:"skills"`
+	Agents   map[string]int `json:"agents"`
+	Commands map[string]int `json:"commands"` // Without the leading /
+	Sessions int            `json:"sessions"` // Transcripts read
+	Oldest   time.Time      `json:"oldest"`   // When the oldest transcript read was last written
+}
+
+// NewUsage returns an empty Usage
+func NewUsage() *Usage {
+	return &Usage{Skills: map[string]int{}, Agents: map[string]int{}, Commands: map[string]int{}}
+}
+
+// commandNamePattern matches the marker Claude Code records when a slash command is run
+var commandNamePattern = regexp.MustCompile(`<command-name>/?([^<\s]+)</command-name>`)
+
+// entry is the part of a transcript line that records tool calls
+type entry struct {
+	Message struct {
+		Content json.RawMessage `json:"content"`
+	} `json:"message"`
+}
+
+// toolUse is a tool call in an assistant message
+type toolUse struct {
+	Type  string `json:"type"`
+	Name  string `json:"name"`
+	Input struct {
+		Skill        string `json:"skill"`
+		Command      string `json:"command"`
+		SubagentType string `json:"subagent_type"`
+	} `json:"input"`
+}
+
+// Scan reads the transcripts (*.jsonl) under dir, such as ~/.claude/projects,
+// written since the given time (all of them if it is zero). A missing
+// directory has no usage.
+func Scan(dir string, since time.Time) (*Usage, error) {
+	u := NewUsage()
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".jsonl" {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil || info.ModTime().Before(since) {
+			return nil
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return nil
+		}
+		defer f.Close()
+		if err := u.Read(f); err != nil {
+			return nil
+		}
+		u.Sessions++
+		if u.Oldest.IsZero() || info.ModTime().Before(u.Oldest) {
+			u.Oldest = info.ModTime()
+		}
+		return nil
+	})
+	return u, err
+}
+
+// Read adds the uses recorded in one transcript
+func (u *Usage) Read(r io.Reader) error {
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadBytes('\n')
+		if len(line) > 0 {
+			u.readLine(line)
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// readLine adds the uses recorded in one line of a transcript. Lines are
+// checked for markers first, since most are long and record neither.
+func (u *Usage) readLine(line []byte) {
+	if bytes.Contains(line, []byte("<command-name>")) {
+		for _, m := range commandNamePattern.FindAllSubmatch(line, -1) {
+			u.Commands[string(m[1])]++
+		}
+	}
+	if !bytes.Contains(line, []byte(`"tool_use"`)) {
+		return
+	}
+	var e entry
+	if json.Unmarshal(line, &e) != nil {
+		return
+	}
+	var calls []toolUse
+	if json.Unmarshal(e.Message.Content, &calls) != nil {
+		return
+	}
+	for _, c := range calls {
+		if c.Type != "tool_use" {
+			continue
+		}
+		switch c.Name {
+		case "Skill":
+			if name := c.Input.Skill; name != "" {
+				u.Skills[name]++
+			} else if name := c.Input.Command; name != "" {
+				u.Skills[name]++
+			}
+		case "Task", "Agent":
+			if c.Input.SubagentType != "" {
+				u.Agents[c.Input.SubagentType]++
+			}
+		case "SlashCommand":
+			if fields := strings.Fields(c.Input.Command); len(fields) > 0 {
+				u.Commands[strings.TrimPrefix(fields[0], "/")]++
+			}
+		}
+	}
+}
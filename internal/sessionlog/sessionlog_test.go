@@ -0,0 +1,66 @@
+package sessionlog
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const transcript = `{"type":"user","message":{"role":"user","content":"<command-message>review is running…</command-message>\n<command-name>/review</command-name>"}}
+{"type":"assistant","message":{"role":"assistant","content":[{"type":"text","text":"Using the pdf skill"},{"type":"tool_use","id":"1","name":"Skill","input":{"skill":"pdf"}}]}}
+{"type":"assistant","message":{"role":"assistant","content":[{"type":"tool_use","id":"2","name":"Task","input":{"subagent_type":"code-reviewer","prompt":"..."}}]}}
+{"type":"assistant","message":{"role":"assistant","content":[{"type":"tool_use","id":"3","name":"SlashCommand","input":{"command":"/ops:deploy staging"}}]}}
+{"type":"user","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"1","content":"mentions <command-name> without a name"}]}}
+not json
+`
+
+func TestRead(t *testing.T) {
+	u := NewUsage()
+	if err := u.Read(strings.NewReader(transcript)); err != nil {
+		t.Fatal(err)
+	}
+	if u.Skills["pdf"] != 1 || len(u.Skills) != 1 {
+		t.Errorf("Skills = %v, want pdf once", u.Skills)
+	}
+	if u.Agents["code-reviewer"] != 1 || len(u.Agents) != 1 {
+		t.Errorf("Agents = %v, want code-reviewer once", u.Agents)
+	}
+	if u.Commands["review"] != 1 || u.Commands["ops:deploy"] != 1 || len(u.Commands) != 2 {
+		t.Errorf("Commands = %v, want review and ops:deploy once", u.Commands)
+	}
+}
+
+func TestScan(t *testing.T) {
+	dir := t.TempDir()
+	recent := filepath.Join(dir, "-home-me-app", "a.jsonl")
+	old := filepath.Join(dir, "-home-me-old", "b.jsonl")
+	for _, path := range []string{recent, old} {
+		os.MkdirAll(filepath.Dir(path), 0755)
+		os.WriteFile(path, []byte(transcript), 0644)
+	}
+	os.WriteFile(filepath.Join(dir, "notes.txt"), []byte(transcript), 0644)
+	monthAgo := time.Now().AddDate(0, -1, 0)
+	os.Chtimes(old, monthAgo, monthAgo)
+
+	u, err := Scan(dir, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Sessions != 2 || u.Skills["pdf"] != 2 {
+		t.Errorf("Scan() read %d sessions with pdf %d times, want 2 and 2", u.Sessions, u.Skills["pdf"])
+	}
+
+	u, err = Scan(dir, time.Now().AddDate(0, 0, -7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Sessions != 1 || u.Skills["pdf"] != 1 {
+		t.Errorf("Scan() since a week ago read %d sessions with pdf %d times, want 1 and 1", u.Sessions, u.Skills["pdf"])
+	}
+
+	if u, err := Scan(filepath.Join(dir, "missing"), time.Time{}); err != nil || u.Sessions != 0 {
+		t.Errorf("Scan() of a missing directory = %v, %v", u, err)
+	}
+}
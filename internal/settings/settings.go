@@ -0,0 +1,309 @@
+// Package settings validates Claude Code settings.json files against a
+// bundled JSON Schema of the known settings, and writes them safely.
+package settings
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+//go:embed schema.json
+var schemaJSON []byte
+
+// Severity classifies a validation issue
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Issue is a single problem found in a settings file
+type Issue struct {
+	Path     string   `json:"path"` // JSON path, e.g. $.hooks.PreToolUse[0].hooks[1].command
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+}
+
+// Schema is the subset of JSON Schema used by the bundled settings schema
+type Schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 string             `json:"type"`
+	Enum                 []any              `json:"enum"`
+	Minimum              *float64           `json:"minimum"`
+	Required             []string           `json:"required"`
+	Properties           map[string]*Schema `json:"properties"`
+	AdditionalProperties *Additional        `json:"additionalProperties"`
+	Items                *Schema            `json:"items"`
+	Defs                 map[string]*Schema `json:"$defs"`
+}
+
+// Additional is the additionalProperties keyword: false, true, or a schema
+type Additional struct {
+	Allowed bool
+	Schema  *Schema
+}
+
+// UnmarshalJSON accepts a boolean as well as a schema.
+func (a *Additional) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &a.Allowed); err == nil {
+		return nil
+	}
+	a.Allowed = true
+	return json.Unmarshal(data, &a.Schema)
+}
+
+// BundledSchema returns the bundled settings.json schema
+func BundledSchema() (*Schema, error) {
+	var s Schema
+	if err := json.Unmarshal(schemaJSON, &s); err != nil {
+		return nil, fmt.Errorf("parse bundled schema: %w", err)
+	}
+	return &s, nil
+}
+
+// Validate checks settings.json content against the bundled schema.
+// Unknown keys are reported as warnings, since the schema may lag behind
+// Claude Code; everything else is an error. Malformed JSON is reported as
+// a single error issue with its line and column.
+func Validate(content []byte) ([]Issue, error) {
+	schema, err := BundledSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	var doc any
+	if err := json.Unmarshal(content, &doc); err != nil {
+		return []Issue{{Path: "$", Severity: SeverityError, Message: syntaxMessage(content, err)}}, nil
+	}
+
+	v := &validator{root: schema}
+	v.validate(schema, doc, "$")
+	return v.issues, nil
+}
+
+type validator struct {
+	root   *Schema
+	issues []Issue
+}
+
+func (v *validator) add(path string, severity Severity, format string, args ...any) {
+	v.issues = append(v.issues, Issue{Path: path, Severity: severity, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) resolve(s *Schema) *Schema {
+	for s != nil && s.Ref != "" {
+		name, ok := strings.CutPrefix(s.Ref, "#/$defs/")
+		if !ok {
+			return nil
+		}
+		s = v.root.Defs[name]
+	}
+	return s
+}
+
+func (v *validator) validate(s *Schema, value any, path string) {
+	s = v.resolve(s)
+	if s == nil {
+		return
+	}
+
+	if s.Type != "" && !hasType(value, s.Type) {
+		v.add(path, SeverityError, "expected %s, got %s", s.Type, typeName(value))
+		return
+	}
+
+	if len(s.Enum) > 0 && !inEnum(value, s.Enum) {
+		v.add(path, SeverityError, "invalid value %s (expected one of %s)", formatValue(value), formatEnum(s.Enum))
+		return
+	}
+
+	switch val := value.(type) {
+	case float64:
+		if s.Minimum != nil && val < *s.Minimum {
+			v.add(path, SeverityError, "must be at least %v", *s.Minimum)
+		}
+	case []any:
+		if s.Items != nil {
+			for i, item := range val {
+				v.validate(s.Items, item, fmt.Sprintf("%s[%d]", path, i))
+			}
+		}
+	case map[string]any:
+		v.validateObject(s, val, path)
+	}
+}
+
+func (v *validator) validateObject(s *Schema, obj map[string]any, path string) {
+	for _, key := range s.Required {
+		if _, ok := obj[key]; !ok {
+			v.add(path, SeverityError, "missing required key %q", key)
+		}
+	}
+
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		childPath := joinPath(path, key)
+		if prop, ok := s.Properties[key]; ok {
+			v.validate(prop, obj[key], childPath)
+			continue
+		}
+
+		switch {
+		case s.AdditionalProperties == nil || (s.AdditionalProperties.Allowed && s.AdditionalProperties.Schema == nil):
+			// Any key is allowed
+		case s.AdditionalProperties.Schema != nil:
+			v.validate(s.AdditionalProperties.Schema, obj[key], childPath)
+		default:
+			if suggestion := closestKey(key, s.Properties); suggestion != "" {
+				v.add(childPath, SeverityWarning, "unknown key %q (did you mean %q?)", key, suggestion)
+			} else {
+				v.add(childPath, SeverityWarning, "unknown key %q", key)
+			}
+		}
+	}
+}
+
+// hasType reports whether a decoded JSON value matches a JSON Schema type
+func hasType(value any, t string) bool {
+	switch t {
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		n, ok := value.(float64)
+		return ok && n == math.Trunc(n)
+	case "null":
+		return value == nil
+	}
+	return true
+}
+
+// typeName returns the JSON type name of a decoded value
+func typeName(value any) string {
+	switch value.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case nil:
+		return "null"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func inEnum(value any, enum []any) bool {
+	for _, e := range enum {
+		if e == value {
+			return true
+		}
+	}
+	return false
+}
+
+func formatValue(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+func formatEnum(enum []any) string {
+	values := make([]string, len(enum))
+	for i, e := range enum {
+		values[i] = formatValue(e)
+	}
+	return strings.Join(values, ", ")
+}
+
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// joinPath appends an object key to a JSON path, quoting keys that are not identifiers
+func joinPath(path, key string) string {
+	if identifierPattern.MatchString(key) {
+		return path + "." + key
+	}
+	return fmt.Sprintf("%s[%s]", path, formatValue(key))
+}
+
+// closestKey returns the known key closest to an unknown one, if it is a likely typo
+func closestKey(key string, properties map[string]*Schema) string {
+	best, bestDistance := "", 3
+	for candidate := range properties {
+		d := levenshtein(strings.ToLower(key), strings.ToLower(candidate))
+		if d < bestDistance || (d == bestDistance && best != "" && candidate < best) {
+			best, bestDistance = candidate, d
+		}
+	}
+	return best
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr := make([]int, len(b)+1)
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev = curr
+	}
+	return prev[len(b)]
+}
+
+// syntaxMessage describes a JSON decoding error with its line and column
+func syntaxMessage(content []byte, err error) string {
+	var syntaxErr *json.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		return fmt.Sprintf("invalid JSON: %v", err)
+	}
+
+	offset := min(int(syntaxErr.Offset), len(content))
+	line, col := 1, 1
+	for _, b := range content[:offset] {
+		if b == '\n' {
+			line++
+			col = 1
+		} else {
+			col++
+		}
+	}
+	return fmt.Sprintf("invalid JSON at line %d, column %d: %v", line, col, err)
+}
@@ -0,0 +1,82 @@
+package settings
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected []Issue
+	}{
+		{
+			name: "valid settings",
+			content: `{
+  "model": "opus",
+  "env": {"FOO": "bar"},
+  "permissions": {"allow": ["Bash(go test:*)"], "defaultMode": "acceptEdits"},
+  "hooks": {
+    "PreToolUse": [
+      {"matcher": "Bash", "hooks": [{"type": "command", "command": "echo hi", "timeout": 30}]}
+    ]
+  }
+}`,
+		},
+		{
+			name:    "unknown key with suggestion",
+			content: `{"modle": "opus", "my custom": 1}`,
+			expected: []Issue{
+				{Path: "$.modle", Severity: SeverityWarning, Message: `unknown key "modle" (did you mean "model"?)`},
+				{Path: `$["my custom"]`, Severity: SeverityWarning, Message: `unknown key "my custom"`},
+			},
+		},
+		{
+			name:    "type errors",
+			content: `{"cleanupPeriodDays": 1.5, "env": {"DEBUG": true}, "permissions": {"defaultMode": "yolo"}}`,
+			expected: []Issue{
+				{Path: "$.cleanupPeriodDays", Severity: SeverityError, Message: "expected integer, got number"},
+				{Path: "$.env.DEBUG", Severity: SeverityError, Message: "expected string, got boolean"},
+				{Path: "$.permissions.defaultMode", Severity: SeverityError, Message: `invalid value "yolo" (expected one of "default", "acceptEdits", "plan", "bypassPermissions")`},
+			},
+		},
+		{
+			name: "malformed hooks",
+			content: `{"hooks": {
+  "pretooluse": [],
+  "PostToolUse": {"matcher": "*"},
+  "Stop": [{"matcher": "*", "hooks": [{"type": "shell", "cmd": "x"}]}]
+}}`,
+			expected: []Issue{
+				{Path: "$.hooks.PostToolUse", Severity: SeverityError, Message: "expected array, got object"},
+				{Path: "$.hooks.Stop[0].hooks[0]", Severity: SeverityError, Message: `missing required key "command"`},
+				{Path: "$.hooks.Stop[0].hooks[0].cmd", Severity: SeverityWarning, Message: `unknown key "cmd"`},
+				{Path: "$.hooks.Stop[0].hooks[0].type", Severity: SeverityError, Message: `invalid value "shell" (expected one of "command")`},
+				{Path: "$.hooks.pretooluse", Severity: SeverityWarning, Message: `unknown key "pretooluse" (did you mean "PreToolUse"?)`},
+			},
+		},
+		{
+			name:    "malformed JSON",
+			content: "{\n  \"model\": \"opus\",\n}",
+			expected: []Issue{
+				{Path: "$", Severity: SeverityError, Message: "invalid JSON at line 3, column 2: invalid character '}' looking for beginning of object key string"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues, err := Validate([]byte(tt.content))
+			if err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			if len(issues) == 0 && len(tt.expected) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(issues, tt.expected) {
+				t.Errorf("Validate() =\n%v\nwant\n%v", issues, tt.expected)
+			}
+		})
+	}
+}
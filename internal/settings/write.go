@@ -0,0 +1,155 @@
+package settings
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/itda-skills/jindo/pkg/config"
+)
+
+// BackupSubdir is where automatic backups of settings files are kept, under
+// the Claude directory of the file
+const BackupSubdir = "backups/settings"
+
+// KeepKey is the config key of how many automatic backups are kept per
+// settings file (0 keeps none)
+const KeepKey = "settings.backups.keep"
+
+// DefaultKeep is how many automatic backups are kept unless configured
+const DefaultKeep = 10
+
+// backupStampFormat names backups so they sort by when they were written, even
+// within a second
+const backupStampFormat = "20060102-150405.000000"
+
+// Backup is an automatic backup of a settings file
+type Backup struct {
+	Path    string    `json:"path"`
+	Written time.Time `json:"written"`
+}
+
+// BackupDir returns the directory that keeps the backups of the settings file at path
+func BackupDir(path string) string {
+	return filepath.Join(filepath.Dir(path), filepath.FromSlash(BackupSubdir))
+}
+
+// Write replaces the settings file at path with content. The content is
+// written to a temporary file in the same directory and renamed over the file,
+// so a crash leaves either the old file or the new one, never a partial one.
+// The old content is backed up first, and backups beyond the configured number
+// are removed. The file keeps its permissions, and a symlinked file (as in
+// dotfiles setups) is written through the link, which is kept.
+func Write(path string, content []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	perm := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+		if err := backup(path, keep()); err != nil {
+			return fmt.Errorf("back up %s: %w", filepath.Base(path), err)
+		}
+	}
+
+	target := path
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		target = resolved
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(target), "."+filepath.Base(target)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	// The temporary file is gone after the rename; removing it covers the failures before
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), target)
+}
+
+// keep returns the configured number of backups kept per settings file
+func keep() int {
+	cfg, err := config.Load()
+	if err != nil {
+		return DefaultKeep
+	}
+	return max(cfg.GetInt(KeepKey, DefaultKeep), 0)
+}
+
+// backup copies the settings file at path into its backup directory, then
+// removes all but the newest n backups. With n 0, nothing is backed up.
+func backup(path string, n int) error {
+	if n == 0 {
+		return nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	dir := BackupDir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%s.%s.bak", filepath.Base(path), time.Now().Format(backupStampFormat))
+	if err := os.WriteFile(filepath.Join(dir, name), content, 0600); err != nil {
+		return err
+	}
+
+	backups, err := Backups(path)
+	if err != nil {
+		return err
+	}
+	for _, b := range backups[min(n, len(backups)):] {
+		if err := os.Remove(b.Path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Backups returns the automatic backups of the settings file at path, newest first
+func Backups(path string) ([]Backup, error) {
+	dir := BackupDir(path)
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	prefix := filepath.Base(path) + "."
+	var backups []Backup
+	for _, e := range entries {
+		stamp, ok := strings.CutPrefix(e.Name(), prefix)
+		if !ok || e.IsDir() {
+			continue
+		}
+		if stamp, ok = strings.CutSuffix(stamp, ".bak"); !ok {
+			continue
+		}
+		written, err := time.ParseInLocation(backupStampFormat, stamp, time.Local)
+		if err != nil {
+			continue
+		}
+		backups = append(backups, Backup{Path: filepath.Join(dir, e.Name()), Written: written})
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].Written.After(backups[j].Written) })
+	return backups, nil
+}
@@ -0,0 +1,104 @@
+package settings
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWrite(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	path := filepath.Join(dir, "settings.json")
+
+	if err := Write(path, []byte(`{"v":1}`)); err != nil {
+		t.Fatal(err)
+	}
+	if backups, _ := Backups(path); len(backups) != 0 {
+		t.Errorf("Write() of a new file made %d backups, want none", len(backups))
+	}
+	os.Chmod(path, 0600)
+
+	for _, v := range []string{`{"v":2}`, `{"v":3}`} {
+		if err := Write(path, []byte(v)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, _ := os.ReadFile(path); string(got) != `{"v":3}` {
+		t.Errorf("settings.json = %s, want the last content", got)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0600 {
+		t.Errorf("settings.json mode = %v, want the file's own 0600", info.Mode().Perm())
+	}
+
+	backups, err := Backups(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backups) != 2 {
+		t.Fatalf("Backups() = %d, want 2", len(backups))
+	}
+	if got, _ := os.ReadFile(backups[0].Path); string(got) != `{"v":2}` {
+		t.Errorf("newest backup = %s, want the content before the last write", got)
+	}
+
+	entries, _ := os.ReadDir(dir)
+	for _, e := range entries {
+		if e.Name() != "settings.json" && e.Name() != "backups" {
+			t.Errorf("Write() left %s behind", e.Name())
+		}
+	}
+}
+
+func TestWriteThroughSymlink(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dotfiles := t.TempDir()
+	real := filepath.Join(dotfiles, "settings.json")
+	if err := os.WriteFile(real, []byte(`{"v":1}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	path := filepath.Join(dir, "settings.json")
+	if err := os.Symlink(real, path); err != nil {
+		t.Skipf("cannot create symlinks: %v", err)
+	}
+
+	if err := Write(path, []byte(`{"v":2}`)); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Lstat(path); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Fatalf("settings.json is no longer a symlink: %v", err)
+	}
+	if got, _ := os.ReadFile(real); string(got) != `{"v":2}` {
+		t.Errorf("linked file = %s, want the new content", got)
+	}
+	if backups, _ := Backups(path); len(backups) != 1 {
+		t.Errorf("Backups() = %d, want 1 next to the link", len(backups))
+	}
+	entries, _ := os.ReadDir(dotfiles)
+	if len(entries) != 1 {
+		t.Errorf("Write() left %d files next to the linked file, want only it", len(entries))
+	}
+}
+
+func TestBackupKeepsNewest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+	os.WriteFile(path, []byte(`{}`), 0644)
+	for range 5 {
+		if err := backup(path, 3); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if backups, _ := Backups(path); len(backups) != 3 {
+		t.Errorf("backup() kept %d backups, want 3", len(backups))
+	}
+
+	other := filepath.Join(filepath.Dir(path), "settings.local.json")
+	os.WriteFile(other, []byte(`{}`), 0644)
+	if err := backup(other, 1); err != nil {
+		t.Fatal(err)
+	}
+	if backups, _ := Backups(path); len(backups) != 3 {
+		t.Errorf("backups of another settings file removed those of settings.json: %d left", len(backups))
+	}
+}
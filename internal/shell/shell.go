@@ -0,0 +1,77 @@
+// Package shell runs command lines with the platform shell: sh -c, or cmd /C
+// on Windows. Hook commands, setup steps, post-update actions, and the git
+// installer all run this way.
+package shell
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"runtime"
+	"time"
+)
+
+// ErrTimeout is returned by Run when a command outlives its timeout
+var ErrTimeout = errors.New("timed out")
+
+// Options says where and how Run runs a command line. The zero value runs it
+// in the current directory, with the environment of jd, no input, its output
+// discarded, and no timeout.
+type Options struct {
+	Dir     string    // Working directory
+	Env     []string  // Environment; nil for the environment of jd
+	Stdin   io.Reader // Input
+	Stdout  io.Writer // Standard output
+	Stderr  io.Writer // Standard error
+	Timeout time.Duration
+}
+
+// Run runs a command line with the platform shell and waits for it. A command
+// that outlives the timeout is killed, and Run returns an error wrapping
+// ErrTimeout; one that exits with a non-zero status returns an
+// *exec.ExitError (see ExitCode).
+func Run(ctx context.Context, line string, opts Options) error {
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "windows":
+		cmd = exec.CommandContext(ctx, "cmd", "/C", line)
+	default:
+		cmd = exec.CommandContext(ctx, "sh", "-c", line)
+	}
+	cmd.Dir = opts.Dir
+	cmd.Env = opts.Env
+	cmd.Stdin = opts.Stdin
+	cmd.Stdout = opts.Stdout
+	cmd.Stderr = opts.Stderr
+	// Children of the shell may hold the output open after it is killed
+	cmd.WaitDelay = time.Second
+
+	err := cmd.Run()
+	if opts.Timeout > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w after %s", ErrTimeout, opts.Timeout)
+	}
+	return err
+}
+
+// ExitCode returns the exit status of a command from the error Run returned:
+// 0 for nil, and -1 if it did not exit by itself (it could not start, or was
+// killed).
+func ExitCode(err error) int {
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return 0
+	case errors.As(err, &exitErr):
+		return exitErr.ExitCode()
+	default:
+		return -1
+	}
+}
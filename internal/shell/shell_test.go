@@ -0,0 +1,55 @@
+package shell
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	var stdout, stderr bytes.Buffer
+	err := Run(context.Background(), `cat; echo "$GREETING $(pwd)"; echo oops >&2; exit 3`, Options{
+		Dir:    dir,
+		Env:    append(os.Environ(), "GREETING=hello"),
+		Stdin:  strings.NewReader("input\n"),
+		Stdout: &stdout,
+		Stderr: &stderr,
+	})
+	if got := ExitCode(err); got != 3 {
+		t.Errorf("ExitCode() = %d, want 3 (err: %v)", got, err)
+	}
+	if want := "input\nhello " + dir + "\n"; stdout.String() != want {
+		t.Errorf("stdout = %q, want %q", stdout.String(), want)
+	}
+	if stderr.String() != "oops\n" {
+		t.Errorf("stderr = %q, want %q", stderr.String(), "oops\n")
+	}
+
+	if err := Run(context.Background(), "true", Options{}); ExitCode(err) != 0 {
+		t.Errorf("Run(true) = %v, want nil", err)
+	}
+}
+
+func TestRunTimeout(t *testing.T) {
+	// The background child keeps the output open after the shell is killed
+	var stdout bytes.Buffer
+	start := time.Now()
+	err := Run(context.Background(), "sleep 10 & sleep 10", Options{Stdout: &stdout, Timeout: 100 * time.Millisecond})
+	if !errors.Is(err, ErrTimeout) {
+		t.Fatalf("Run() = %v, want ErrTimeout", err)
+	}
+	if err.Error() != "timed out after 100ms" {
+		t.Errorf("Run() = %q, want %q", err, "timed out after 100ms")
+	}
+	if ExitCode(err) != -1 {
+		t.Errorf("ExitCode() = %d, want -1", ExitCode(err))
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Run() returned after %s", elapsed)
+	}
+}
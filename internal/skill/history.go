@@ -180,6 +180,11 @@ func (h *HistoryManager) GetLatestVersion() (*Version, error) {
 	return &manifest.Versions[len(manifest.Versions)-1], nil
 }
 
+// VersionPath returns the file path of a version
+func (h *HistoryManager) VersionPath(v *Version) string {
+	return filepath.Join(h.getHistoryDir(), v.Filename)
+}
+
 // HasHistory checks if any history exists
 func (h *HistoryManager) HasHistory() bool {
 	manifest, err := h.loadManifest()
@@ -1,70 +1,82 @@
 package skill
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
-	"gopkg.in/yaml.v3"
+	"github.com/itda-skills/jindo/internal/frontmatter"
+	"github.com/itda-skills/jindo/pkg/config"
 )
 
 // Skill represents a Claude Code skill
 type Skill struct {
-	Name         string   `json:"name"`
-	Description  string   `json:"description"`
-	AllowedTools []string `json:"allowed_tools"`
-	Path         string   `json:"path"`
+	Name         string      `json:"name"`
+	Description  string      `json:"description"`
+	AllowedTools []string    `json:"allowed_tools"`
+	Tags         []string    `json:"tags,omitempty"`
+	Config       []ConfigKey `json:"config,omitempty"`
+	Path         string      `json:"path"`
 }
 
-// skillFrontmatter represents the YAML frontmatter structure
-type skillFrontmatter struct {
-	Name         string `yaml:"name"`
-	Description  string `yaml:"description"`
-	AllowedTools string `yaml:"allowed-tools"`
+// ConfigKey is a jd configuration key a skill reads, declared in its frontmatter:
+//
+//	config:
+//	  - name: common.api_keys.tiingo
+//	    description: Tiingo API key
+//	    secret: true
+//	    env: TIINGO_API_KEY
+//	  - name: skills.quant-data.market
+//	    default: KRX
+type ConfigKey struct {
+	Name        string `yaml:"name" json:"name"`
+	Type        string `yaml:"type" json:"type,omitempty"` // string (default), int, float, or bool
+	Default     any    `yaml:"default" json:"default,omitempty"`
+	Secret      bool   `yaml:"secret" json:"secret,omitempty"` // Masked when shown and not echoed when asked for
+	Description string `yaml:"description" json:"description,omitempty"`
+	Env         string `yaml:"env" json:"env,omitempty"`           // Vendor environment variable that also sets the key
+	Required    *bool  `yaml:"required" json:"required,omitempty"` // Default: required if there is no default
 }
 
-// extractFrontmatter extracts YAML frontmatter from markdown content
-func extractFrontmatter(content string) (string, bool) {
-	lines := strings.Split(content, "\n")
-	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
-		return "", false
+// IsRequired reports whether the skill needs the key set to work: unless the
+// declaration says otherwise, keys without a default are required
+func (k ConfigKey) IsRequired() bool {
+	if k.Required != nil {
+		return *k.Required
 	}
+	return k.Default == nil
+}
 
-	var frontmatterLines []string
-	for i := 1; i < len(lines); i++ {
-		if strings.TrimSpace(lines[i]) == "---" {
-			return strings.Join(frontmatterLines, "\n"), true
-		}
-		frontmatterLines = append(frontmatterLines, lines[i])
+// Issue describes what is wrong with the declaration of a key, or returns ""
+func (k ConfigKey) Issue() string {
+	if k.Name == "" {
+		return "config key without a name"
 	}
-
-	return "", false
+	if !config.ValidType(k.Type) {
+		return fmt.Sprintf("config key %s has unknown type %q (use string, int, float, or bool)", k.Name, k.Type)
+	}
+	if k.Default != nil && !config.HasType(k.Default, k.Type) {
+		return fmt.Sprintf("config key %s has a default that is not a %s", k.Name, k.typeName())
+	}
+	return ""
 }
 
-// parseSimpleFrontmatter parses frontmatter using simple line-based approach
-// This is a fallback for when YAML parsing fails due to special characters
-func parseSimpleFrontmatter(frontmatter string) map[string]string {
-	result := make(map[string]string)
-	lines := strings.Split(frontmatter, "\n")
-
-	for _, line := range lines {
-		// Find first colon
-		idx := strings.Index(line, ":")
-		if idx <= 0 {
-			continue
-		}
-
-		key := strings.TrimSpace(line[:idx])
-		value := strings.TrimSpace(line[idx+1:])
-
-		// Only capture simple keys we care about
-		switch key {
-		case "name", "description", "allowed-tools":
-			result[key] = value
-		}
+// typeName returns the key's type, string if not declared
+func (k ConfigKey) typeName() string {
+	if k.Type == "" {
+		return config.TypeString
 	}
+	return k.Type
+}
 
-	return result
+// skillFrontmatter represents the YAML frontmatter structure
+type skillFrontmatter struct {
+	Name         string           `yaml:"name"`
+	Description  string           `yaml:"description"`
+	AllowedTools frontmatter.List `yaml:"allowed-tools"`
+	Tags         frontmatter.List `yaml:"tags"`
+	Config       []ConfigKey      `yaml:"config"`
 }
 
 // ParseSkillFile parses a SKILL.md or skill.md file and returns a Skill
@@ -78,42 +90,16 @@ func ParseSkillFile(path string) (*Skill, error) {
 		Path: path,
 	}
 
-	frontmatter, found := extractFrontmatter(string(content))
-	if !found || frontmatter == "" {
-		return skill, nil
-	}
-
 	var fm skillFrontmatter
-	if err := yaml.Unmarshal([]byte(frontmatter), &fm); err != nil {
-		// If YAML parsing fails, fall back to simple parsing
-		simple := parseSimpleFrontmatter(frontmatter)
-		skill.Name = simple["name"]
-		skill.Description = simple["description"]
-		if allowedTools := simple["allowed-tools"]; allowedTools != "" {
-			tools := strings.Split(allowedTools, ",")
-			for _, tool := range tools {
-				tool = strings.TrimSpace(tool)
-				if tool != "" {
-					skill.AllowedTools = append(skill.AllowedTools, tool)
-				}
-			}
-		}
+	if _, err := frontmatter.Parse(string(content), &fm); err != nil {
 		return skill, nil
 	}
 
 	skill.Name = fm.Name
 	skill.Description = fm.Description
-
-	// Parse comma-separated tools
-	if fm.AllowedTools != "" {
-		tools := strings.Split(fm.AllowedTools, ",")
-		for _, tool := range tools {
-			tool = strings.TrimSpace(tool)
-			if tool != "" {
-				skill.AllowedTools = append(skill.AllowedTools, tool)
-			}
-		}
-	}
+	skill.AllowedTools = fm.AllowedTools
+	skill.Tags = frontmatter.NormalizeTags(fm.Tags)
+	skill.Config = fm.Config
 
 	return skill, nil
 }
@@ -213,6 +199,41 @@ func (s *Store) GetContent(name string) (string, error) {
 	return string(content), nil
 }
 
+// SetTags replaces the tags in a skill's frontmatter and returns the updated skill
+func (s *Store) SetTags(name string, tags []string) (*Skill, error) {
+	dir, err := s.expandDir()
+	if err != nil {
+		return nil, err
+	}
+
+	skillFile, err := findSkillFile(filepath.Join(dir, name))
+	if err != nil {
+		return nil, os.ErrNotExist
+	}
+
+	content, err := os.ReadFile(skillFile)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := SetFrontmatterTags(string(content), frontmatter.NormalizeTags(tags))
+	if err := os.WriteFile(skillFile, []byte(updated), 0644); err != nil {
+		return nil, err
+	}
+
+	return s.Get(name)
+}
+
+// SetFrontmatterTags rewrites the tags entry in markdown frontmatter.
+// Existing inline or block-style tags are replaced; an empty list removes the entry.
+// A frontmatter block is added if the content has none.
+func SetFrontmatterTags(content string, tags []string) string {
+	if len(tags) == 0 {
+		return frontmatter.Set(content, "tags", "")
+	}
+	return frontmatter.Set(content, "tags", "["+strings.Join(tags, ", ")+"]")
+}
+
 // List returns all skills in the store
 func (s *Store) List() ([]*Skill, error) {
 	var skills []*Skill
@@ -0,0 +1,83 @@
+package skill
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSetFrontmatterTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		tags    []string
+		want    string
+	}{
+		{
+			"inline",
+			"---\nname: tool\ntags: [a]\ndescription: Tool\n---\n# Tool\n",
+			[]string{"a", "b"},
+			"---\nname: tool\ntags: [a, b]\ndescription: Tool\n---\n# Tool\n",
+		},
+		{
+			"block list",
+			"---\nname: tool\ntags:\n  - a\n  - b\ndescription: Tool\n---\n# Tool\n",
+			[]string{"c"},
+			"---\nname: tool\ntags: [c]\ndescription: Tool\n---\n# Tool\n",
+		},
+		{
+			"added",
+			"---\nname: tool\n---\n# Tool\n",
+			[]string{"a"},
+			"---\nname: tool\ntags: [a]\n---\n# Tool\n",
+		},
+		{
+			"removed",
+			"---\nname: tool\ntags:\n- a\n---\n# Tool\n",
+			nil,
+			"---\nname: tool\n---\n# Tool\n",
+		},
+		{
+			"no frontmatter",
+			"# Tool\n",
+			[]string{"a"},
+			"---\ntags: [a]\n---\n\n# Tool\n",
+		},
+		{
+			"no frontmatter, no tags",
+			"# Tool\n",
+			nil,
+			"# Tool\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SetFrontmatterTags(tt.content, tt.tags); got != tt.want {
+				t.Errorf("SetFrontmatterTags() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStoreSetTags(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "tool"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := "---\nname: tool\ndescription: \"Use: formatting\"\ntags: [Deploy]\n---\n# Tool\n"
+	if err := os.WriteFile(filepath.Join(dir, "tool", "SKILL.md"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := NewStore(dir).SetTags("tool", []string{"Deploy", "deploy", " ci ", ""})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"Deploy", "ci"}; !reflect.DeepEqual(s.Tags, want) {
+		t.Errorf("tags = %v, want %v", s.Tags, want)
+	}
+	if s.Description != "Use: formatting" {
+		t.Errorf("description = %q, want it unchanged", s.Description)
+	}
+}
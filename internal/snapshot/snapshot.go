@@ -0,0 +1,260 @@
+// Package snapshot records the resources of a Claude Code setup (skills,
+// commands, agents, hooks, settings, and CLAUDE.md) with a hash of each and the
+// package version it was installed from, and compares two such manifests: of
+// two machines, or of one machine at two points in time.
+package snapshot
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// FormatVersion is the version of the manifest format written by this jd
+const FormatVersion = 1
+
+// Manifest is a snapshot of a Claude Code setup
+type Manifest struct {
+	Format    int        `json:"format"`
+	Created   time.Time  `json:"created"`
+	Host      string     `json:"host"`
+	JDVersion string     `json:"jd_version"`
+	Resources []Resource `json:"resources"`
+}
+
+// Resource is a resource of a snapshot
+type Resource struct {
+	Scope   string   `json:"scope"` // global or local
+	Type    string   `json:"type"`  // skill, command, agent, hook, hook-script, setting, or claudemd
+	Name    string   `json:"name"`
+	Hash    string   `json:"hash"`              // SHA-256 of the content, with the home directory written as ~
+	Package *Package `json:"package,omitempty"` // Set when the resource was installed from a package
+}
+
+// Package is the package a resource was installed from
+type Package struct {
+	Namespace  string `json:"namespace"`
+	SourcePath string `json:"source_path,omitempty"`
+	Version    string `json:"version"` // Commit SHA
+	Ref        string `json:"ref,omitempty"`
+}
+
+// key identifies a resource across snapshots
+func (r Resource) key() string {
+	return r.Scope + "\x00" + r.Type + "\x00" + r.Name
+}
+
+// Load reads a manifest written by Write
+func Load(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("%s is not a snapshot: %w", path, err)
+	}
+	if m.Format == 0 || m.Format > FormatVersion {
+		return nil, fmt.Errorf("%s has snapshot format %d; this jd reads up to %d", path, m.Format, FormatVersion)
+	}
+	return &m, nil
+}
+
+// Write writes the manifest as indented JSON, its resources sorted
+func (m *Manifest) Write(w io.Writer) error {
+	sortResources(m.Resources)
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// sortResources sorts resources by scope, type, and name
+func sortResources(resources []Resource) {
+	sort.Slice(resources, func(i, j int) bool {
+		return resources[i].key() < resources[j].key()
+	})
+}
+
+// Hasher hashes resource contents so that they compare equal across machines:
+// the home directory is written as ~ before hashing
+type Hasher struct {
+	home string
+}
+
+// NewHasher returns a hasher for contents that mention the home directory home
+func NewHasher(home string) *Hasher {
+	return &Hasher{home: home}
+}
+
+// Bytes returns the hash of data
+func (h *Hasher) Bytes(data []byte) string {
+	if h.home != "" {
+		data = []byte(strings.ReplaceAll(string(data), h.home, "~"))
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// File returns the hash of a file's contents
+func (h *Hasher) File(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return h.Bytes(data), nil
+}
+
+// Dir returns the hash of the files in a directory: their paths relative to
+// it and their contents. Hidden files and directories are left out.
+func (h *Hasher) Dir(dir string) (string, error) {
+	var b strings.Builder
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != dir && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		sum, err := h.File(path)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&b, "%s %s\n", filepath.ToSlash(rel), sum)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return h.Bytes([]byte(b.String())), nil
+}
+
+// Change kinds, from the first snapshot to the second
+const (
+	Added   = "added"
+	Removed = "removed"
+	Changed = "changed"
+)
+
+// Change is a resource that differs between two snapshots
+type Change struct {
+	Kind   string    `json:"kind"` // Added, Removed, or Changed
+	Scope  string    `json:"scope"`
+	Type   string    `json:"type"`
+	Name   string    `json:"name"`
+	Detail string    `json:"detail,omitempty"`
+	From   *Resource `json:"from,omitempty"`
+	To     *Resource `json:"to,omitempty"`
+}
+
+// Diff returns the resources that were added, removed, or changed from one
+// snapshot to another, sorted by scope, type, and name
+func Diff(from, to *Manifest) []Change {
+	old := make(map[string]*Resource, len(from.Resources))
+	for i := range from.Resources {
+		old[from.Resources[i].key()] = &from.Resources[i]
+	}
+
+	changes := []Change{}
+	seen := make(map[string]bool, len(to.Resources))
+	for i := range to.Resources {
+		r := &to.Resources[i]
+		seen[r.key()] = true
+		prev, ok := old[r.key()]
+		switch {
+		case !ok:
+			changes = append(changes, Change{Kind: Added, Scope: r.Scope, Type: r.Type, Name: r.Name, Detail: packageLabel(r.Package), To: r})
+		case prev.Hash != r.Hash || !samePackage(prev.Package, r.Package):
+			changes = append(changes, Change{Kind: Changed, Scope: r.Scope, Type: r.Type, Name: r.Name, Detail: changeDetail(prev, r), From: prev, To: r})
+		}
+	}
+	for i := range from.Resources {
+		r := &from.Resources[i]
+		if !seen[r.key()] {
+			changes = append(changes, Change{Kind: Removed, Scope: r.Scope, Type: r.Type, Name: r.Name, Detail: packageLabel(r.Package), From: r})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		a, b := changes[i], changes[j]
+		if a.Scope != b.Scope {
+			return a.Scope < b.Scope
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.Name < b.Name
+	})
+	return changes
+}
+
+// changeDetail describes how a resource changed: the package versions, if
+// installed from a package, and whether its content differs from what the
+// versions alone explain
+func changeDetail(from, to *Resource) string {
+	var details []string
+	switch {
+	case from.Package == nil && to.Package != nil:
+		details = append(details, "now installed from "+packageLabel(to.Package))
+	case from.Package != nil && to.Package == nil:
+		details = append(details, "no longer from a package")
+	case from.Package != nil && !samePackage(from.Package, to.Package):
+		if from.Package.Namespace != to.Package.Namespace {
+			details = append(details, fmt.Sprintf("package %s → %s", packageLabel(from.Package), packageLabel(to.Package)))
+		} else {
+			details = append(details, fmt.Sprintf("version %s → %s", shortSHA(from.Package.Version), shortSHA(to.Package.Version)))
+		}
+	}
+	if from.Hash != to.Hash {
+		if from.Package != nil && samePackage(from.Package, to.Package) {
+			details = append(details, "content differs (edited since installed)")
+		} else if len(details) == 0 {
+			details = append(details, "content differs")
+		}
+	}
+	return strings.Join(details, "; ")
+}
+
+// samePackage reports whether two resources were installed from the same version of a package
+func samePackage(a, b *Package) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Namespace == b.Namespace && a.SourcePath == b.SourcePath && a.Version == b.Version
+}
+
+// packageLabel returns namespace@version of a package, or "" for none
+func packageLabel(p *Package) string {
+	if p == nil {
+		return ""
+	}
+	return p.Namespace + "@" + shortSHA(p.Version)
+}
+
+// shortSHA abbreviates a commit SHA
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
@@ -0,0 +1,102 @@
+package snapshot
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHasher(t *testing.T) {
+	h := NewHasher("/home/alice")
+	other := NewHasher("/Users/bob")
+	if h.Bytes([]byte("run /home/alice/bin/lint")) != other.Bytes([]byte("run /Users/bob/bin/lint")) {
+		t.Error("contents differing only in the home directory hash differently")
+	}
+	if h.Bytes([]byte("a")) == h.Bytes([]byte("b")) {
+		t.Error("different contents hash the same")
+	}
+
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("skill"), 0644)
+	before, err := h.Dir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(filepath.Join(dir, ".DS_Store"), []byte("junk"), 0644)
+	if after, _ := h.Dir(dir); after != before {
+		t.Error("a hidden file changed the hash of a directory")
+	}
+	os.MkdirAll(filepath.Join(dir, "scripts"), 0755)
+	os.WriteFile(filepath.Join(dir, "scripts", "run.sh"), []byte("echo"), 0644)
+	if after, _ := h.Dir(dir); after == before {
+		t.Error("a new file did not change the hash of a directory")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	pkg := func(version string) *Package {
+		return &Package{Namespace: "affa-ever", SourcePath: "skills/web-fetch", Version: version}
+	}
+	from := &Manifest{Resources: []Resource{
+		{Scope: "global", Type: "skill", Name: "web-fetch", Hash: "1", Package: pkg("aaaaaaaaaa")},
+		{Scope: "global", Type: "skill", Name: "kept", Hash: "k"},
+		{Scope: "global", Type: "agent", Name: "reviewer", Hash: "r"},
+		{Scope: "global", Type: "setting", Name: "settings.json:model", Hash: "m1"},
+		{Scope: "local", Type: "command", Name: "deploy", Hash: "d", Package: pkg("cccccccccc")},
+	}}
+	to := &Manifest{Resources: []Resource{
+		{Scope: "global", Type: "skill", Name: "web-fetch", Hash: "2", Package: pkg("bbbbbbbbbb")},
+		{Scope: "global", Type: "skill", Name: "kept", Hash: "k"},
+		{Scope: "global", Type: "setting", Name: "settings.json:model", Hash: "m2"},
+		{Scope: "global", Type: "hook", Name: "PreToolUse Bash", Hash: "h"},
+		{Scope: "local", Type: "command", Name: "deploy", Hash: "edited", Package: pkg("cccccccccc")},
+	}}
+
+	got := Diff(from, to)
+	want := []struct{ kind, name, detail string }{
+		{Removed, "reviewer", ""},
+		{Added, "PreToolUse Bash", ""},
+		{Changed, "settings.json:model", "content differs"},
+		{Changed, "web-fetch", "version aaaaaaa → bbbbbbb"},
+		{Changed, "deploy", "content differs (edited since installed)"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Diff() = %+v, want %d changes", got, len(want))
+	}
+	for i, w := range want {
+		if got[i].Kind != w.kind || got[i].Name != w.name || got[i].Detail != w.detail {
+			t.Errorf("change %d = %s %s %q, want %s %s %q", i, got[i].Kind, got[i].Name, got[i].Detail, w.kind, w.name, w.detail)
+		}
+	}
+
+	if changes := Diff(to, to); len(changes) != 0 {
+		t.Errorf("Diff() of a snapshot with itself = %+v", changes)
+	}
+}
+
+func TestWriteLoad(t *testing.T) {
+	m := &Manifest{Format: FormatVersion, Host: "laptop", Resources: []Resource{
+		{Scope: "local", Type: "skill", Name: "b", Hash: "1"},
+		{Scope: "global", Type: "skill", Name: "a", Hash: "2"},
+	}}
+	var buf bytes.Buffer
+	if err := m.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	os.WriteFile(path, buf.Bytes(), 0644)
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Host != "laptop" || len(loaded.Resources) != 2 || loaded.Resources[0].Scope != "global" {
+		t.Errorf("Load() = %+v", loaded)
+	}
+
+	os.WriteFile(path, []byte(`{"format": 99}`), 0644)
+	if _, err := Load(path); err == nil {
+		t.Error("Load() of a newer format succeeded")
+	}
+}
@@ -0,0 +1,99 @@
+// Package sorting orders names the way people read them: case-insensitively,
+// numbers by value (skill-2 before skill-10), and letters by the collation of
+// the user's locale (LC_ALL, LC_COLLATE, or LANG), so that, e.g., Swedish
+// users find å, ä, and ö after z. It also names the sort orders lists share.
+package sorting
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+// Sort orders shared by jd list, jd pkg list, and jd pkg browse
+const (
+	Name      = "name"      // By name
+	Type      = "type"      // By type, then name
+	Installed = "installed" // Most recently installed first
+	Updated   = "updated"   // Most recently updated first
+)
+
+// Orders are the shared sort orders, in the order they are documented
+var Orders = []string{Name, Type, Installed, Updated}
+
+// ParseOrder checks a --sort value: one of Orders or extra, or "" for the
+// command's default order
+func ParseOrder(value string, extra ...string) (string, error) {
+	valid := append(append([]string{}, Orders...), extra...)
+	if value == "" {
+		return "", nil
+	}
+	for _, o := range valid {
+		if strings.EqualFold(value, o) {
+			return o, nil
+		}
+	}
+	return "", fmt.Errorf("invalid sort: %s (use: %s)", value, strings.Join(valid, ", "))
+}
+
+var (
+	mu       sync.Mutex // A Collator is not safe for concurrent use
+	collator *collate.Collator
+)
+
+// Compare compares two names in the user's locale, ignoring case and ordering
+// numbers by value. Names that collate equally are ordered by their bytes, so
+// the order is total and does not depend on the input order.
+func Compare(a, b string) int {
+	mu.Lock()
+	if collator == nil {
+		collator = newCollator(Locale())
+	}
+	c := collator.CompareString(a, b)
+	mu.Unlock()
+	if c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+// Less reports whether a sorts before b (see Compare)
+func Less(a, b string) bool {
+	return Compare(a, b) < 0
+}
+
+// newCollator returns the collator of a locale
+func newCollator(tag language.Tag) *collate.Collator {
+	return collate.New(tag, collate.IgnoreCase, collate.Numeric)
+}
+
+// Locale returns the locale names are collated in: from LC_ALL, LC_COLLATE, or
+// LANG, the first that is set, such as "sv_SE.UTF-8". The C and POSIX locales,
+// and values that are not a language, use the root collation, which suits
+// English and most Latin-script names.
+func Locale() language.Tag {
+	for _, key := range []string{"LC_ALL", "LC_COLLATE", "LANG"} {
+		if value := os.Getenv(key); value != "" {
+			return parseLocale(value)
+		}
+	}
+	return language.Und
+}
+
+// parseLocale parses a POSIX locale name (language_TERRITORY.codeset@modifier)
+func parseLocale(value string) language.Tag {
+	value, _, _ = strings.Cut(value, ".")
+	value, _, _ = strings.Cut(value, "@")
+	if value == "" || value == "C" || value == "POSIX" {
+		return language.Und
+	}
+	tag, err := language.Parse(strings.ReplaceAll(value, "_", "-"))
+	if err != nil {
+		return language.Und
+	}
+	return tag
+}
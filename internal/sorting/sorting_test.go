@@ -0,0 +1,64 @@
+package sorting
+
+import (
+	"slices"
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func TestCompare(t *testing.T) {
+	names := []string{"skill-10", "Zeta", "alpha", "skill-2", "Beta", "beta"}
+	slices.SortFunc(names, Compare)
+	want := []string{"alpha", "Beta", "beta", "skill-2", "skill-10", "Zeta"}
+	if !slices.Equal(names, want) {
+		t.Errorf("sorted = %v, want %v", names, want)
+	}
+}
+
+func TestLocaleCollation(t *testing.T) {
+	// Swedish sorts ö after z; German sorts it with o
+	sv := newCollator(language.Swedish)
+	if sv.CompareString("öl", "zebra") <= 0 {
+		t.Error("Swedish collation sorts ö before z")
+	}
+	de := newCollator(language.German)
+	if de.CompareString("öl", "zebra") >= 0 {
+		t.Error("German collation sorts ö after z")
+	}
+}
+
+func TestParseLocale(t *testing.T) {
+	tests := []struct {
+		value string
+		want  language.Tag
+	}{
+		{"sv_SE.UTF-8", language.MustParse("sv-SE")},
+		{"de_DE@euro", language.MustParse("de-DE")},
+		{"ko_KR.UTF-8", language.MustParse("ko-KR")},
+		{"C", language.Und},
+		{"POSIX", language.Und},
+		{"C.UTF-8", language.Und},
+		{"not a locale", language.Und},
+	}
+	for _, tt := range tests {
+		if got := parseLocale(tt.value); got != tt.want {
+			t.Errorf("parseLocale(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestParseOrder(t *testing.T) {
+	if o, err := ParseOrder("Updated"); err != nil || o != Updated {
+		t.Errorf("ParseOrder(Updated) = %q, %v", o, err)
+	}
+	if o, err := ParseOrder(""); err != nil || o != "" {
+		t.Errorf("ParseOrder(\"\") = %q, %v", o, err)
+	}
+	if _, err := ParseOrder("stars"); err == nil {
+		t.Error("ParseOrder(stars) without it as an extra order succeeded")
+	}
+	if o, err := ParseOrder("stars", "stars"); err != nil || o != "stars" {
+		t.Errorf("ParseOrder(stars, stars) = %q, %v", o, err)
+	}
+}
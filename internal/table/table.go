@@ -0,0 +1,221 @@
+// Package table renders the plain-text tables of the list commands. Widths are
+// measured in terminal cells, so CJK text and emoji line up, and columns shrink
+// to fit the terminal instead of overflowing it.
+package table
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+	"golang.org/x/term"
+)
+
+// ellipsis marks a truncated value
+const ellipsis = "..."
+
+// Column describes a table column
+type Column struct {
+	Header string
+	Max    int  // Width cap when the output width is unknown (0 for none)
+	Min    int  // Narrowest width to shrink to when fitting a width (0 for the header's width)
+	Fixed  bool // Never shrink or truncate, such as IDs that are typed into other commands
+	Wrap   bool // Wrap long values onto more lines instead of truncating them
+}
+
+// Table is a table of rows under a header and a dashed rule. Columns are as wide
+// as their widest value, capped at Max when Width is 0, or shrunk, widest first,
+// until the table fits Width. NoTrunc shows every value in full.
+type Table struct {
+	Columns []Column
+	Indent  string // Printed before every line
+	Width   int    // Output width in cells, 0 if unknown
+	NoTrunc bool
+	rows    [][]string
+}
+
+// New returns a table with the columns
+func New(columns ...Column) *Table {
+	return &Table{Columns: columns}
+}
+
+// AddRow adds a row, one value per column. Missing values are empty.
+func (t *Table) AddRow(cells ...string) {
+	row := make([]string, len(t.Columns))
+	for i := range row {
+		if i < len(cells) {
+			// Line breaks would tear the row apart
+			row[i] = strings.Join(strings.Fields(cells[i]), " ")
+		}
+	}
+	t.rows = append(t.rows, row)
+}
+
+// Render writes the table to w
+func (t *Table) Render(w io.Writer) {
+	widths := t.widths()
+
+	headers := make([]string, len(t.Columns))
+	rules := make([]string, len(t.Columns))
+	for i, c := range t.Columns {
+		headers[i] = Truncate(c.Header, widths[i])
+		rules[i] = strings.Repeat("-", widths[i])
+	}
+	t.writeLine(w, widths, headers)
+	t.writeLine(w, widths, rules)
+
+	for _, row := range t.rows {
+		lines := make([][]string, len(row))
+		height := 1
+		for i, cell := range row {
+			switch {
+			case t.NoTrunc || t.Columns[i].Fixed:
+				lines[i] = []string{cell}
+			case t.Columns[i].Wrap:
+				lines[i] = Wrap(cell, widths[i])
+			default:
+				lines[i] = []string{Truncate(cell, widths[i])}
+			}
+			height = max(height, len(lines[i]))
+		}
+
+		for n := 0; n < height; n++ {
+			cells := make([]string, len(row))
+			for i := range row {
+				if n < len(lines[i]) {
+					cells[i] = lines[i][n]
+				}
+			}
+			t.writeLine(w, widths, cells)
+		}
+	}
+}
+
+// writeLine writes one line of cells padded to the column widths, without
+// trailing spaces
+func (t *Table) writeLine(w io.Writer, widths []int, cells []string) {
+	var b strings.Builder
+	b.WriteString(t.Indent)
+	for i, cell := range cells {
+		if i > 0 {
+			b.WriteString("  ")
+		}
+		b.WriteString(runewidth.FillRight(cell, widths[i]))
+	}
+	_, _ = fmt.Fprintln(w, strings.TrimRight(b.String(), " "))
+}
+
+// widths returns the width of each column
+func (t *Table) widths() []int {
+	widths := make([]int, len(t.Columns))
+	for i, c := range t.Columns {
+		widths[i] = runewidth.StringWidth(c.Header)
+	}
+	for _, row := range t.rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], runewidth.StringWidth(cell))
+		}
+	}
+	if t.NoTrunc {
+		return widths
+	}
+
+	if t.Width <= 0 {
+		for i, c := range t.Columns {
+			if c.Max > 0 && !c.Fixed && widths[i] > c.Max {
+				widths[i] = max(c.Max, runewidth.StringWidth(c.Header))
+			}
+		}
+		return widths
+	}
+
+	total := runewidth.StringWidth(t.Indent) + 2*(len(widths)-1)
+	for _, w := range widths {
+		total += w
+	}
+	for total > t.Width {
+		widest := -1
+		for i, c := range t.Columns {
+			if c.Fixed || widths[i] <= t.minWidth(i) {
+				continue
+			}
+			if widest < 0 || widths[i] > widths[widest] {
+				widest = i
+			}
+		}
+		if widest < 0 {
+			// Too narrow for every column's minimum: overflow rather than lose the values
+			break
+		}
+		widths[widest]--
+		total--
+	}
+	return widths
+}
+
+// minWidth returns the narrowest a column may shrink to
+func (t *Table) minWidth(i int) int {
+	if m := t.Columns[i].Min; m > 0 {
+		return m
+	}
+	return max(runewidth.StringWidth(t.Columns[i].Header), len(ellipsis)+1)
+}
+
+// Truncate shortens s to at most width cells, ending it with "..." when cut
+func Truncate(s string, width int) string {
+	if runewidth.StringWidth(s) <= width {
+		return s
+	}
+	if width <= len(ellipsis) {
+		return runewidth.Truncate(s, width, "")
+	}
+	return runewidth.Truncate(s, width, ellipsis)
+}
+
+// Wrap breaks s into lines of at most width cells at spaces. Words wider than
+// a line, such as text in scripts written without spaces, are broken anywhere.
+func Wrap(s string, width int) []string {
+	words := strings.Fields(s)
+	if len(words) == 0 || width <= 0 {
+		return []string{""}
+	}
+
+	var lines []string
+	var line string
+	for _, word := range words {
+		switch {
+		case line == "":
+			line = word
+		case runewidth.StringWidth(line)+1+runewidth.StringWidth(word) <= width:
+			line += " " + word
+		default:
+			lines = append(lines, line)
+			line = word
+		}
+		for runewidth.StringWidth(line) > width {
+			head := runewidth.Truncate(line, width, "")
+			if head == "" {
+				// A character wider than the column
+				break
+			}
+			lines = append(lines, head)
+			line = line[len(head):]
+		}
+	}
+	return append(lines, line)
+}
+
+// TerminalWidth returns the width of the terminal f writes to: $COLUMNS if set,
+// otherwise the terminal's size, or 0 if f is not a terminal
+func TerminalWidth(f *os.File) int {
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	if width, _, err := term.GetSize(int(f.Fd())); err == nil && width > 0 {
+		return width
+	}
+	return 0
+}
@@ -0,0 +1,130 @@
+package table
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name  string
+		s     string
+		width int
+		want  string
+	}{
+		{"fits", "fetch", 5, "fetch"},
+		{"ascii", "web-fetch-tool", 10, "web-fet..."},
+		{"cjk counts two cells", "웹페이지가져오기", 10, "웹페이..."},
+		{"cjk never splits a character", "웹페이지가져오기", 8, "웹페..."},
+		{"emoji", "🚀🚀🚀🚀🚀", 7, "🚀🚀..."},
+		{"narrower than ellipsis", "fetch", 2, "fe"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Truncate(tt.s, tt.width); got != tt.want {
+				t.Errorf("Truncate(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		name  string
+		s     string
+		width int
+		want  []string
+	}{
+		{"empty", "", 10, []string{""}},
+		{"words", "fetch web pages as markdown", 10, []string{"fetch web", "pages as", "markdown"}},
+		{"long word is broken", "abcdefghijkl", 5, []string{"abcde", "fghij", "kl"}},
+		{"cjk without spaces", "웹페이지를가져옵니다", 8, []string{"웹페이지", "를가져옵", "니다"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Wrap(tt.s, tt.width); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Wrap(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.want)
+			}
+		})
+	}
+}
+
+func render(tbl *Table) string {
+	var b strings.Builder
+	tbl.Render(&b)
+	return b.String()
+}
+
+func TestRenderAlignsWideCharacters(t *testing.T) {
+	tbl := New(Column{Header: "NAME"}, Column{Header: "DESCRIPTION"})
+	tbl.AddRow("웹", "한국어 설명")
+	tbl.AddRow("fetch", "English")
+
+	want := "NAME   DESCRIPTION\n" +
+		"-----  -----------\n" +
+		"웹     한국어 설명\n" +
+		"fetch  English\n"
+	if got := render(tbl); got != want {
+		t.Errorf("Render() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRenderCapsColumnsWithoutWidth(t *testing.T) {
+	tbl := New(Column{Header: "ID", Fixed: true, Max: 4}, Column{Header: "DESCRIPTION", Max: 12})
+	tbl.AddRow("web-fetch", "Fetch web pages as markdown")
+
+	want := "ID         DESCRIPTION\n" +
+		"---------  ------------\n" +
+		"web-fetch  Fetch web...\n"
+	if got := render(tbl); got != want {
+		t.Errorf("Render() =\n%s\nwant\n%s", got, want)
+	}
+
+	tbl.NoTrunc = true
+	want = "ID         DESCRIPTION\n" +
+		"---------  ---------------------------\n" +
+		"web-fetch  Fetch web pages as markdown\n"
+	if got := render(tbl); got != want {
+		t.Errorf("Render() with NoTrunc =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRenderFitsWidth(t *testing.T) {
+	tbl := New(
+		Column{Header: "ID", Fixed: true},
+		Column{Header: "DESCRIPTION", Wrap: true},
+		Column{Header: "TOOLS"},
+	)
+	tbl.Width = 40
+	tbl.AddRow("web-fetch", "Fetch web pages and convert them to markdown", "Bash, Read, WebFetch")
+
+	got := render(tbl)
+	for _, line := range strings.Split(strings.TrimSuffix(got, "\n"), "\n") {
+		if len(line) > 40 {
+			t.Errorf("line wider than 40: %q", line)
+		}
+	}
+	want := "ID         DESCRIPTION    TOOLS\n" +
+		"---------  -------------  --------------\n" +
+		"web-fetch  Fetch web      Bash, Read,...\n" +
+		"           pages and\n" +
+		"           convert them\n" +
+		"           to markdown\n"
+	if got != want {
+		t.Errorf("Render() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRenderOverflowsWhenTooNarrow(t *testing.T) {
+	tbl := New(Column{Header: "NAME", Fixed: true}, Column{Header: "TYPE"})
+	tbl.Width = 5
+	tbl.AddRow("web-fetch", "skill")
+
+	want := "NAME       TYPE\n" +
+		"---------  ----\n" +
+		"web-fetch  s...\n"
+	if got := render(tbl); got != want {
+		t.Errorf("Render() =\n%s\nwant\n%s", got, want)
+	}
+}
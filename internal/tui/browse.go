@@ -1,14 +1,20 @@
 package tui
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/itda-skills/jindo/internal/claudemd"
+	"github.com/itda-skills/jindo/internal/favorite"
 	"github.com/itda-skills/jindo/internal/pkg/pkgmgr"
 	"github.com/itda-skills/jindo/internal/pkg/repo"
 )
@@ -61,10 +67,20 @@ type PackageItem struct {
 	LocalPath   string // Full local path for preview
 	Type        repo.PackageType
 	IsInstalled bool
+	InstalledAs string    // Name the package is installed under, if installed
+	InstalledAt time.Time // When the package was installed, if installed
+	IsFavorite  bool
 	HasUpdate   bool
 	Selected    bool
+	Only        []string          // Patterns of the skill files to install, when picked (nil: all)
+	Meta        *repo.PackageMeta // Stars and last change, once fetched from GitHub
+	order       int               // Load order, used to restore ordering after filtering
 }
 
+// FilterFunc returns the packages matching a filter query, keyed by
+// "namespace:path". A nil result falls back to matching package names.
+type FilterFunc func(query string) map[string]bool
+
 // installDoneMsg is sent when installation completes
 type installDoneMsg struct {
 	count  int
@@ -76,6 +92,7 @@ type uninstallDoneMsg struct {
 	success bool
 	name    string
 	err     error
+	kept    string // Trash directory edited files were copied to
 }
 
 // Model represents the TUI state
@@ -84,17 +101,33 @@ type Model struct {
 	activeTab           Tab
 	items               map[Tab][]PackageItem
 	cursor              int
-	listOffset          int    // Scroll offset for list panel
+	listOffset          int // Scroll offset for list panel
 	width               int
 	height              int
 	manager             *pkgmgr.Manager
 	message             string
 	quitting            bool
-	preview             string // Cached preview content
-	namespaceFilter     string // Filter by namespace (empty = all)
-	installing          bool   // True while installation is in progress
-	confirmingUninstall bool   // True when waiting for uninstall confirmation
+	preview             string         // Cached preview content (the body only in the details view)
+	previewFields       []previewField // Frontmatter shown above the body in the details view
+	rawPreview          bool           // True to preview files as they are instead of the details view
+	namespaceFilter     string         // Filter by namespace (empty = all)
+	installing          bool           // True while installation is in progress
+	confirmingUninstall bool           // True when waiting for uninstall confirmation
 	confirmingItem      *PackageItem
+	confirmingInstall   bool                  // True when waiting for confirmation to install untrusted hooks or commands
+	picker              *filePicker           // Files of a skill being picked to install, if any
+	favoritesOnly       bool                  // True when only favorites are shown
+	hiddenItems         map[Tab][]PackageItem // Items hidden by the favorites filter or the filter query
+	filtering           bool                  // True while the filter query is typed
+	filterQuery         string                // Shows only items matching the query (empty = all)
+	filterMatch         FilterFunc            // Matches the filter query against package contents (nil: names only)
+	keys                keyMap
+	help                help.Model
+	showHelp            bool         // True while the key binding overlay is shown
+	pendingKey          string       // First key of a two-key sequence (e.g., g of gg)
+	watcher             *fileWatcher // Notices changes to installed.json and settings.json made outside the TUI
+	sortOrder           SortOrder    // Order packages are listed in, favorites first
+	fetchMetaOnStart    bool         // True to fetch stars and last changes from GitHub when the TUI opens
 }
 
 // Styles
@@ -120,6 +153,9 @@ var (
 	installedStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("42"))
 
+	favoriteStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("220"))
+
 	helpStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("241"))
 
@@ -140,74 +176,24 @@ var (
 	previewContentStyle = lipgloss.NewStyle().
 				Foreground(lipgloss.Color("252"))
 
+	previewLabelStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(lipgloss.Color("99"))
+
 	listPaneStyle = lipgloss.NewStyle().
 			Padding(0, 1)
 )
 
-// Key bindings
-type keyMap struct {
-	Up        key.Binding
-	Down      key.Binding
-	Left      key.Binding
-	Right     key.Binding
-	Tab       key.Binding
-	Select    key.Binding
-	Install   key.Binding
-	Uninstall key.Binding
-	SelectAll key.Binding
-	Quit      key.Binding
-}
-
-var keys = keyMap{
-	Up: key.NewBinding(
-		key.WithKeys("up", "k"),
-		key.WithHelp("↑/k", "up"),
-	),
-	Down: key.NewBinding(
-		key.WithKeys("down", "j"),
-		key.WithHelp("↓/j", "down"),
-	),
-	Left: key.NewBinding(
-		key.WithKeys("left", "h"),
-		key.WithHelp("←/h", "prev tab"),
-	),
-	Right: key.NewBinding(
-		key.WithKeys("right", "l"),
-		key.WithHelp("→/l", "next tab"),
-	),
-	Tab: key.NewBinding(
-		key.WithKeys("tab"),
-		key.WithHelp("tab", "next tab"),
-	),
-	Select: key.NewBinding(
-		key.WithKeys(" "),
-		key.WithHelp("space", "select"),
-	),
-	Install: key.NewBinding(
-		key.WithKeys("enter"),
-		key.WithHelp("enter", "install"),
-	),
-	Uninstall: key.NewBinding(
-		key.WithKeys("d"),
-		key.WithHelp("d", "uninstall"),
-	),
-	SelectAll: key.NewBinding(
-		key.WithKeys("a"),
-		key.WithHelp("a", "select all"),
-	),
-	Quit: key.NewBinding(
-		key.WithKeys("q", "esc", "ctrl+c"),
-		key.WithHelp("q/esc", "quit"),
-	),
-}
-
-// NewModel creates a new browse TUI model
-func NewModel(manager *pkgmgr.Manager) *Model {
+// NewModel creates a new browse TUI model with the given key bindings
+func NewModel(manager *pkgmgr.Manager, keys keyMap) *Model {
 	return &Model{
-		tabs:      []Tab{TabSkills, TabCommands, TabAgents, TabHooks},
-		activeTab: TabSkills,
-		items:     make(map[Tab][]PackageItem),
-		manager:   manager,
+		keys:        keys,
+		help:        help.New(),
+		tabs:        []Tab{TabSkills, TabCommands, TabAgents, TabHooks},
+		activeTab:   TabSkills,
+		items:       make(map[Tab][]PackageItem),
+		hiddenItems: make(map[Tab][]PackageItem),
+		manager:     manager,
 	}
 }
 
@@ -224,17 +210,25 @@ func (m *Model) LoadPackages() error {
 	if err != nil {
 		return err
 	}
-	installedMap := make(map[string]bool)
+	// Installed names depend on the naming at install time, so packages are
+	// matched by where they come from
+	installedNames := make(map[string]string)
+	installedTimes := make(map[string]time.Time)
 	for _, pkg := range installed {
-		installedMap[pkg.Name] = true
+		installedNames[pkg.Namespace+":"+pkg.SourcePath] = pkg.Name
+		installedTimes[pkg.Namespace+":"+pkg.SourcePath] = pkg.InstalledAt
 	}
 
+	// Favorites are optional; a config error just means no favorites
+	favorites, _ := favorite.Load()
+
 	// Initialize items map
 	for _, tab := range m.tabs {
 		m.items[tab] = []PackageItem{}
 	}
 
 	// Load packages from each repository
+	var broken []string
 	for _, r := range repos {
 		// Apply namespace filter
 		if m.namespaceFilter != "" && r.Namespace != m.namespaceFilter {
@@ -242,12 +236,15 @@ func (m *Model) LoadPackages() error {
 		}
 
 		items, err := repoStore.Browse(r.Namespace, "")
+		if errors.Is(err, repo.ErrCloneBroken) {
+			broken = append(broken, r.Namespace)
+		}
 		if err != nil {
 			continue
 		}
 
-		// Get local repo path for preview
-		repoLocalPath, err := repoStore.RepoLocalPath(r.Namespace)
+		// Get package root (clone or its root sub-path) for preview
+		repoLocalPath, err := repoStore.PackageRoot(r.Namespace)
 		if err != nil {
 			continue
 		}
@@ -267,7 +264,11 @@ func (m *Model) LoadPackages() error {
 				continue
 			}
 
-			namespacedName := pkgmgr.MakeNamespacedName(r.Namespace, item.Name)
+			installedAs := installedNames[r.Namespace+":"+item.Path]
+			namespacedName := installedAs
+			if namespacedName == "" {
+				namespacedName = m.manager.Naming().MakeNamespacedName(r.Namespace, item.Name)
+			}
 
 			// Determine the file to preview
 			localPath := filepath.Join(repoLocalPath, item.Path)
@@ -288,11 +289,27 @@ func (m *Model) LoadPackages() error {
 				Path:        item.Path,
 				LocalPath:   localPath,
 				Type:        item.Type,
-				IsInstalled: installedMap[namespacedName],
+				IsInstalled: installedAs != "",
+				InstalledAs: installedAs,
+				InstalledAt: installedTimes[r.Namespace+":"+item.Path],
+				IsFavorite:  favorites.Contains(namespacedName) || favorites.Contains(item.Name),
+				order:       len(m.items[tab]),
 			}
 			m.items[tab] = append(m.items[tab], pkgItem)
 		}
 	}
+	if len(broken) > 0 {
+		m.message = fmt.Sprintf("⚠ Skipped broken clone(s) of %s. Repair with: jd pkg repo status --repair", strings.Join(broken, ", "))
+	}
+
+	// Favorites are shown first
+	for _, tab := range m.tabs {
+		items := m.items[tab]
+		sortItems(items, m.sortOrder)
+		sort.SliceStable(items, func(i, j int) bool {
+			return items[i].IsFavorite && !items[j].IsFavorite
+		})
+	}
 
 	// Load initial preview
 	m.updatePreview()
@@ -300,14 +317,81 @@ func (m *Model) LoadPackages() error {
 	return nil
 }
 
-// loadPreview loads and returns preview content from a file (max 30 lines)
-func loadPreview(path string, maxLines int) string {
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return fmt.Sprintf("Unable to load preview:\n%v", err)
+// toggleFavoritesOnly switches between showing all packages and favorites only
+func (m *Model) toggleFavoritesOnly() {
+	m.favoritesOnly = !m.favoritesOnly
+	m.applyFilters()
+}
+
+// setFilterQuery shows only the packages matching query, or all for an empty query
+func (m *Model) setFilterQuery(query string) {
+	m.filterQuery = query
+	m.applyFilters()
+}
+
+// applyFilters moves the items hidden by the favorites filter and the filter query
+// to hiddenItems, keeping favorites first and otherwise the load order
+func (m *Model) applyFilters() {
+	var matches map[string]bool
+	if m.filterQuery != "" && m.filterMatch != nil {
+		matches = m.filterMatch(m.filterQuery)
 	}
+	query := strings.ToLower(m.filterQuery)
 
-	content := string(data)
+	for _, tab := range m.tabs {
+		items := append(m.items[tab], m.hiddenItems[tab]...)
+		sort.SliceStable(items, func(i, j int) bool {
+			if items[i].IsFavorite != items[j].IsFavorite {
+				return items[i].IsFavorite
+			}
+			return items[i].order < items[j].order
+		})
+
+		var shown, hidden []PackageItem
+		for _, item := range items {
+			visible := !m.favoritesOnly || item.IsFavorite
+			if visible && query != "" {
+				if matches != nil {
+					visible = matches[item.Namespace+":"+item.Path]
+				} else {
+					visible = strings.Contains(strings.ToLower(item.Name), query)
+				}
+			}
+			if visible {
+				shown = append(shown, item)
+			} else {
+				hidden = append(hidden, item)
+			}
+		}
+		m.items[tab] = shown
+		m.hiddenItems[tab] = hidden
+	}
+
+	m.cursor = 0
+	m.listOffset = 0
+	m.updatePreview()
+}
+
+// updateFilter handles a key typed into the filter query: enter keeps the
+// filter, esc clears it, and other keys edit the query
+func (m *Model) updateFilter(msg tea.KeyMsg) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.filtering = false
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.filtering = false
+		m.setFilterQuery("")
+	case tea.KeyBackspace:
+		if r := []rune(m.filterQuery); len(r) > 0 {
+			m.setFilterQuery(string(r[:len(r)-1]))
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.setFilterQuery(m.filterQuery + string(msg.Runes))
+	}
+}
+
+// truncatePreview returns the first maxLines lines of content
+func truncatePreview(content string, maxLines int) string {
 	lines := strings.Split(content, "\n")
 
 	if len(lines) > maxLines {
@@ -327,13 +411,33 @@ func (m *Model) updatePreview() {
 	}
 
 	item := items[m.cursor]
-	m.preview = loadPreview(item.LocalPath, 50)
+	m.previewFields = nil
+	data, err := os.ReadFile(item.LocalPath)
+	if err != nil {
+		m.preview = fmt.Sprintf("Unable to load preview:\n%v", err)
+		return
+	}
+
+	content := string(data)
+	if item.hasDetails() && !m.rawPreview {
+		if fields, body, ok := splitFrontmatter(content); ok {
+			m.previewFields = fields
+			content = body
+		}
+	}
+	m.preview = truncatePreview(content, 50)
+}
+
+// hasDetails reports whether the item's file has frontmatter to show in the details
+// view. Hooks are scripts, so they are always shown raw.
+func (p PackageItem) hasDetails() bool {
+	return p.Type != repo.TypeHook
 }
 
 // listVisibleHeight returns the number of visible lines in the list panel
 func (m *Model) listVisibleHeight() int {
-	headerHeight := 5  // title + tabs + separator
-	footerHeight := 4  // message + help
+	headerHeight := 5 // title + tabs + separator
+	footerHeight := 4 // message + help
 	contentHeight := m.height - headerHeight - footerHeight
 	if contentHeight < 5 {
 		contentHeight = 5
@@ -341,6 +445,26 @@ func (m *Model) listVisibleHeight() int {
 	return contentHeight
 }
 
+// moveCursor moves the cursor by delta items, stopping at the first and last item
+func (m *Model) moveCursor(delta int) {
+	items := m.items[m.activeTab]
+	if len(items) == 0 {
+		return
+	}
+	cursor := m.cursor + delta
+	if cursor < 0 {
+		cursor = 0
+	}
+	if cursor > len(items)-1 {
+		cursor = len(items) - 1
+	}
+	if cursor != m.cursor {
+		m.cursor = cursor
+		m.adjustListScroll()
+		m.updatePreview()
+	}
+}
+
 // adjustListScroll adjusts listOffset to keep cursor visible
 func (m *Model) adjustListScroll() {
 	visibleHeight := m.listVisibleHeight()
@@ -350,6 +474,9 @@ func (m *Model) adjustListScroll() {
 	namespaces := make(map[string]bool)
 	lineIndex := 0
 	cursorLine := 0
+	if m.filtering || m.filterQuery != "" {
+		lineIndex++ // filter query line
+	}
 
 	for i, item := range items {
 		if !namespaces[item.Namespace] {
@@ -385,7 +512,14 @@ func (m *Model) getCurrentItem() *PackageItem {
 
 // Init initializes the model
 func (m Model) Init() tea.Cmd {
-	return nil
+	var cmds []tea.Cmd
+	if m.watcher != nil {
+		cmds = append(cmds, watchTick())
+	}
+	if m.fetchMetaOnStart {
+		cmds = append(cmds, m.fetchMeta())
+	}
+	return tea.Batch(cmds...)
 }
 
 // Update handles messages
@@ -396,8 +530,27 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.height = msg.Height
 		return m, nil
 
+	case watchTickMsg:
+		// Changes are checked again before installing or uninstalling
+		if !m.installing && !m.confirmingInstall && !m.confirmingUninstall {
+			if notice := m.checkExternalChanges(); notice != "" {
+				m.message = notice
+			}
+		}
+		return m, watchTick()
+
+	case metaLoadedMsg:
+		m.applyMeta(msg)
+		if len(msg.errs) > 0 && m.message == "" {
+			m.message = fmt.Sprintf("⚠ Could not fetch all package metadata (%s)", strings.Join(msg.errs, "; "))
+		}
+		return m, nil
+
 	case installDoneMsg:
 		m.installing = false
+		if m.watcher != nil {
+			m.watcher.reset()
+		}
 		if msg.count > 0 {
 			m.message = fmt.Sprintf("✓ Installed %d package(s)", msg.count)
 		}
@@ -411,20 +564,27 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case uninstallDoneMsg:
 		m.confirmingUninstall = false
+		if m.watcher != nil {
+			m.watcher.reset()
+		}
 		if msg.success {
 			// Update item state in all tabs
 			for tab := range m.items {
 				for i := range m.items[tab] {
 					item := &m.items[tab][i]
-					namespacedName := pkgmgr.MakeNamespacedName(item.Namespace, item.Name)
-					if namespacedName == msg.name {
+					if item.IsInstalled && item.InstalledAs == msg.name {
 						item.IsInstalled = false
+						item.InstalledAs = ""
+						item.InstalledAt = time.Time{}
 						item.Selected = false
 						break
 					}
 				}
 			}
 			m.message = fmt.Sprintf("✓ Uninstalled %s", msg.name)
+			if msg.kept != "" {
+				m.message += fmt.Sprintf("; kept edited files in %s", msg.kept)
+			}
 		} else {
 			m.message = fmt.Sprintf("✗ Failed to uninstall: %v", msg.err)
 		}
@@ -437,10 +597,37 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		// Handle untrusted install confirmation
+		if m.confirmingInstall {
+			switch msg.String() {
+			case "y", "Y":
+				m.confirmingInstall = false
+				if notice := m.checkExternalChanges(); notice != "" {
+					m.message = notice + "; check the selection and install again"
+					return m, nil
+				}
+				m.installing = true
+				m.message = "Installing..."
+				return m, m.installSelected()
+			case "n", "N", "esc", "q":
+				m.confirmingInstall = false
+				m.message = "Cancelled"
+				return m, nil
+			}
+			return m, nil
+		}
+
 		// Handle confirmation prompt
 		if m.confirmingUninstall {
 			switch msg.String() {
 			case "y", "Y":
+				// Installed state changed elsewhere is reloaded before uninstalling
+				if notice := m.checkExternalChanges(); notice != "" {
+					m.confirmingUninstall = false
+					m.confirmingItem = nil
+					m.message = notice + "; nothing was uninstalled"
+					return m, nil
+				}
 				// Proceed with uninstall
 				m.message = "Uninstalling..."
 				return m, m.uninstallPackage(m.confirmingItem)
@@ -458,26 +645,81 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Clear message on any key press
 		m.message = ""
 
+		// Keys move through and check the files being picked
+		if m.picker != nil {
+			m.updateFilePicker(msg)
+			return m, nil
+		}
+
+		// Keys edit the filter query while it is typed
+		if m.filtering {
+			m.updateFilter(msg)
+			return m, nil
+		}
+
+		// Keys after the first of a two-key sequence are matched as the sequence
+		var k fmt.Stringer = msg
+		if m.pendingKey != "" {
+			k = keySeq(m.pendingKey + " " + msg.String())
+			m.pendingKey = ""
+		} else if m.keys.isSequencePrefix(msg.String()) {
+			m.pendingKey = msg.String()
+			return m, nil
+		}
+
+		// The help overlay closes on help or quit and ignores other keys
+		if m.showHelp {
+			if key.Matches(k, m.keys.Help, m.keys.Quit) {
+				m.showHelp = false
+			}
+			return m, nil
+		}
+
 		switch {
-		case key.Matches(msg, keys.Quit):
+		case msg.Type == tea.KeyEsc && m.filterQuery != "":
+			// Esc clears an active filter before it quits
+			m.setFilterQuery("")
+			return m, nil
+
+		case key.Matches(k, m.keys.Quit):
 			m.quitting = true
 			return m, tea.Quit
 
-		case key.Matches(msg, keys.Tab), key.Matches(msg, keys.Right):
+		case key.Matches(k, m.keys.Help):
+			m.showHelp = true
+			return m, nil
+
+		case key.Matches(k, m.keys.Top):
+			m.moveCursor(-len(m.items[m.activeTab]))
+			return m, nil
+
+		case key.Matches(k, m.keys.Bottom):
+			m.moveCursor(len(m.items[m.activeTab]))
+			return m, nil
+
+		case key.Matches(k, m.keys.PageUp):
+			m.moveCursor(-m.listVisibleHeight())
+			return m, nil
+
+		case key.Matches(k, m.keys.PageDown):
+			m.moveCursor(m.listVisibleHeight())
+			return m, nil
+
+		case key.Matches(k, m.keys.Tab), key.Matches(k, m.keys.Right):
 			m.activeTab = Tab((int(m.activeTab) + 1) % len(m.tabs))
 			m.cursor = 0
 			m.listOffset = 0
 			m.updatePreview()
 			return m, nil
 
-		case key.Matches(msg, keys.Left):
+		case key.Matches(k, m.keys.Left):
 			m.activeTab = Tab((int(m.activeTab) - 1 + len(m.tabs)) % len(m.tabs))
 			m.cursor = 0
 			m.listOffset = 0
 			m.updatePreview()
 			return m, nil
 
-		case key.Matches(msg, keys.Up):
+		case key.Matches(k, m.keys.Up):
 			if m.cursor > 0 {
 				m.cursor--
 				m.adjustListScroll()
@@ -485,7 +727,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 
-		case key.Matches(msg, keys.Down):
+		case key.Matches(k, m.keys.Down):
 			items := m.items[m.activeTab]
 			if m.cursor < len(items)-1 {
 				m.cursor++
@@ -494,7 +736,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 
-		case key.Matches(msg, keys.Select):
+		case key.Matches(k, m.keys.Select):
 			items := m.items[m.activeTab]
 			if m.cursor < len(items) {
 				item := &m.items[m.activeTab][m.cursor]
@@ -504,7 +746,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 
-		case key.Matches(msg, keys.SelectAll):
+		case key.Matches(k, m.keys.SelectAll):
 			items := m.items[m.activeTab]
 			// Check if all non-installed items are selected
 			allSelected := true
@@ -522,7 +764,32 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 
-		case key.Matches(msg, keys.Install):
+		case key.Matches(k, m.keys.View):
+			m.rawPreview = !m.rawPreview
+			m.updatePreview()
+			return m, nil
+
+		case key.Matches(k, m.keys.Favorites):
+			m.toggleFavoritesOnly()
+			if m.favoritesOnly {
+				m.message = "Showing favorites only"
+			}
+			return m, nil
+
+		case key.Matches(k, m.keys.Filter):
+			m.filtering = true
+			return m, nil
+
+		case key.Matches(k, m.keys.Files):
+			m.openFilePicker()
+			return m, nil
+
+		case key.Matches(k, m.keys.Install):
+			// Packages installed elsewhere are deselected before installing
+			if notice := m.checkExternalChanges(); notice != "" {
+				m.message = notice + "; check the selection and install again"
+				return m, nil
+			}
 			// Check if any packages are selected
 			hasSelected := false
 			for tab := range m.items {
@@ -540,18 +807,27 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.message = "No packages selected"
 				return m, nil
 			}
+			if untrusted := m.untrustedSelection(); len(untrusted) > 0 {
+				m.confirmingInstall = true
+				m.message = fmt.Sprintf("⚠ Hooks/commands from untrusted %s can run shell commands. Install anyway? [y/N]",
+					strings.Join(untrusted, ", "))
+				return m, nil
+			}
 			m.installing = true
 			m.message = "Installing..."
 			return m, m.installSelected()
 
-		case key.Matches(msg, keys.Uninstall):
+		case key.Matches(k, m.keys.Uninstall):
+			if notice := m.checkExternalChanges(); notice != "" {
+				m.message = notice
+				return m, nil
+			}
 			item := m.getCurrentItem()
 			if item != nil && item.IsInstalled {
 				// Show confirmation prompt
 				m.confirmingUninstall = true
 				m.confirmingItem = item
-				namespacedName := pkgmgr.MakeNamespacedName(item.Namespace, item.Name)
-				m.message = fmt.Sprintf("Uninstall '%s'? [y/N]", namespacedName)
+				m.message = fmt.Sprintf("Uninstall '%s'? [y/N]", item.InstalledAs)
 				return m, nil
 			}
 			return m, nil
@@ -561,6 +837,27 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// untrustedSelection returns the namespaces of selected hook and command packages
+// whose repository is not trusted
+func (m *Model) untrustedSelection() []string {
+	var namespaces []string
+	seen := make(map[string]bool)
+	for tab := range m.items {
+		for _, item := range m.items[tab] {
+			if !item.Selected || item.IsInstalled || !item.Type.RunsCommands() || seen[item.Namespace] {
+				continue
+			}
+			seen[item.Namespace] = true
+			if config, err := m.manager.RepoStore().Get(item.Namespace); err == nil && config.Trusted {
+				continue
+			}
+			namespaces = append(namespaces, item.Namespace)
+		}
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
+
 // installSelected installs selected packages
 func (m *Model) installSelected() tea.Cmd {
 	return func() tea.Msg {
@@ -571,9 +868,16 @@ func (m *Model) installSelected() tea.Cmd {
 				item := &m.items[tab][i]
 				if item.Selected && !item.IsInstalled {
 					spec := fmt.Sprintf("%s:%s", item.Namespace, item.Path)
-					_, err := m.manager.Install(spec)
+					m.manager.SetOnly(item.Only)
+					pkg, err := m.manager.Install(spec)
+					m.manager.SetOnly(nil)
 					if err == nil {
+						if pkg.Type == repo.TypeSkill {
+							_, _, _ = claudemd.SyncSkill(pkg.ClaudeDir, pkg.Name, true)
+						}
 						item.IsInstalled = true
+						item.InstalledAs = pkg.Name
+						item.InstalledAt = pkg.InstalledAt
 						item.Selected = false
 						installedCount++
 					} else {
@@ -589,8 +893,13 @@ func (m *Model) installSelected() tea.Cmd {
 // uninstallPackage uninstalls a single package
 func (m *Model) uninstallPackage(item *PackageItem) tea.Cmd {
 	return func() tea.Msg {
-		namespacedName := pkgmgr.MakeNamespacedName(item.Namespace, item.Name)
-		err := m.manager.Uninstall(namespacedName)
+		namespacedName := item.InstalledAs
+		pkg, _ := m.manager.Get(namespacedName)
+		// Files the user edited are kept in the trash, as jd pkg uninstall does
+		kept, err := m.manager.UninstallKeepingModified(namespacedName)
+		if err == nil && pkg != nil && pkg.Type == repo.TypeSkill {
+			_, _, _ = claudemd.SyncSkill(pkg.ClaudeDir, pkg.Name, false)
+		}
 		if err != nil {
 			return uninstallDoneMsg{
 				success: false,
@@ -603,6 +912,7 @@ func (m *Model) uninstallPackage(item *PackageItem) tea.Cmd {
 			success: true,
 			name:    namespacedName,
 			err:     nil,
+			kept:    kept,
 		}
 	}
 }
@@ -612,7 +922,18 @@ func (m Model) renderList(width, height int) string {
 	var lines []string
 
 	items := m.items[m.activeTab]
-	if len(items) == 0 {
+	if m.filtering || m.filterQuery != "" {
+		cursor := ""
+		if m.filtering {
+			cursor = "█"
+		}
+		lines = append(lines, selectedStyle.Render(fmt.Sprintf("/%s%s", m.filterQuery, cursor)))
+	}
+	if len(items) == 0 && m.filterQuery != "" {
+		lines = append(lines, helpStyle.Render("No packages match (esc to clear)"))
+	} else if len(items) == 0 && m.favoritesOnly {
+		lines = append(lines, helpStyle.Render("No favorites (press f to show all)"))
+	} else if len(items) == 0 {
 		lines = append(lines, helpStyle.Render("No packages found"))
 	} else {
 		// Group by namespace
@@ -661,9 +982,14 @@ func (m Model) renderList(width, height int) string {
 
 				line := fmt.Sprintf("%s%s %s", cursor, checkbox, name)
 
-				// Add status indicator
+				// Add status indicators
+				if item.IsFavorite {
+					line += " " + favoriteStyle.Render("★")
+				}
 				if item.IsInstalled {
 					line += " " + installedStyle.Render("✓")
+				} else if item.Only != nil {
+					line += " " + helpStyle.Render("(part)")
 				}
 
 				lines = append(lines, line)
@@ -717,25 +1043,54 @@ func (m Model) renderPreview(width, height int) string {
 		return previewBorderStyle.Width(width - 4).Height(height - 4).Render("No package selected")
 	}
 
-	// Title
-	title := previewTitleStyle.Render(fmt.Sprintf("📄 %s", item.Name))
+	// Title, with the view and the key that switches it
+	titleText := fmt.Sprintf("📄 %s", item.Name)
+	if item.hasDetails() {
+		view, other := "details", "raw"
+		if m.rawPreview {
+			view, other = "raw", "details"
+		}
+		titleText += fmt.Sprintf(" (%s, %s: %s)", view, m.keys.View.Help().Key, other)
+	}
+	title := previewTitleStyle.Render(titleText)
 	b.WriteString(title)
 	b.WriteString("\n")
 
 	// Path info
 	pathInfo := helpStyle.Render(fmt.Sprintf("Path: %s", item.Path))
 	b.WriteString(pathInfo)
-	b.WriteString("\n\n")
+	b.WriteString("\n")
+	if item.Meta != nil {
+		b.WriteString(helpStyle.Render(metaSummary(item.Meta)))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
 
-	// Content
-	content := m.preview
-	// Wrap content to fit width
-	contentLines := strings.Split(content, "\n")
 	maxContentWidth := width - 6
 	if maxContentWidth < 20 {
 		maxContentWidth = 20
 	}
 
+	// Frontmatter summary above the body
+	headerLines := 0
+	if len(m.previewFields) > 0 {
+		for _, f := range m.previewFields {
+			field := lipgloss.NewStyle().Width(maxContentWidth).Render(
+				previewLabelStyle.Render(f.Label+": ") + previewContentStyle.Render(f.Value))
+			b.WriteString(field)
+			b.WriteString("\n")
+			headerLines += lipgloss.Height(field)
+		}
+		b.WriteString(helpStyle.Render(strings.Repeat("─", maxContentWidth)))
+		b.WriteString("\n")
+		headerLines++
+	}
+
+	// Content
+	content := m.preview
+	// Wrap content to fit width
+	contentLines := strings.Split(content, "\n")
+
 	var wrappedLines []string
 	for _, line := range contentLines {
 		if len(line) > maxContentWidth {
@@ -745,7 +1100,7 @@ func (m Model) renderPreview(width, height int) string {
 	}
 
 	// Limit height
-	maxLines := height - 8
+	maxLines := height - 8 - headerLines
 	if maxLines < 5 {
 		maxLines = 5
 	}
@@ -802,16 +1157,28 @@ func (m Model) View() string {
 		previewWidth = 30
 	}
 
-	// Render list and preview panes
-	listContent := m.renderList(listWidth, contentHeight)
-	previewContent := m.renderPreview(previewWidth, contentHeight)
+	if m.showHelp {
+		// Key binding overlay in place of the panes
+		m.help.ShowAll = true
+		m.help.Width = m.width
+		overlay := titleStyle.Render("Key bindings") + "\n\n" + m.help.View(m.keys) + "\n\n" +
+			helpStyle.Render("Rebind keys with tui.keys.<action> in the jd config. Press "+m.keys.Help.Help().Key+" to close.")
+		b.WriteString(lipgloss.NewStyle().Height(contentHeight).Padding(0, 2).Render(overlay))
+	} else {
+		// Render list and preview panes
+		listContent := m.renderList(listWidth, contentHeight)
+		previewContent := m.renderPreview(previewWidth, contentHeight)
+		if m.picker != nil {
+			previewContent = m.renderFilePicker(previewWidth, contentHeight)
+		}
 
-	// Style the list pane
-	listPane := listPaneStyle.Width(listWidth).Height(contentHeight).Render(listContent)
+		// Style the list pane
+		listPane := listPaneStyle.Width(listWidth).Height(contentHeight).Render(listContent)
 
-	// Join panes horizontally
-	mainContent := lipgloss.JoinHorizontal(lipgloss.Top, listPane, previewContent)
-	b.WriteString(mainContent)
+		// Join panes horizontally
+		mainContent := lipgloss.JoinHorizontal(lipgloss.Top, listPane, previewContent)
+		b.WriteString(mainContent)
+	}
 	b.WriteString("\n")
 
 	// Message
@@ -823,22 +1190,42 @@ func (m Model) View() string {
 	// Help
 	b.WriteString(strings.Repeat("─", m.width))
 	b.WriteString("\n")
-	help := helpStyle.Render("↑/↓: navigate  ←/→/tab: switch tab  space: select  a: select all  enter: install  d: uninstall  q: quit")
-	b.WriteString(help)
+	m.help.Width = m.width
+	b.WriteString(m.help.ShortHelpView(m.keys.ShortHelp()))
 
 	return b.String()
 }
 
+// Options configures the browse TUI
+type Options struct {
+	Namespace    string              // Shows only this repository's packages (empty: all)
+	StartTab     Tab                 // Tab shown first
+	KeyOverrides map[string][]string // Rebinds actions (see KeyActions) to other keys
+	Filter       FilterFunc          // Matches the filter query against package contents (nil: names only)
+	Sort         SortOrder           // Order packages are listed in
+	Metadata     bool                // Fetch stars and last changes from GitHub once the TUI is open
+}
+
 // Run starts the TUI
-func Run(manager *pkgmgr.Manager, namespace string, startTab Tab) error {
-	m := NewModel(manager)
-	m.namespaceFilter = namespace
-	m.activeTab = startTab
+func Run(manager *pkgmgr.Manager, opts Options) error {
+	keys, err := newKeyMap(opts.KeyOverrides)
+	if err != nil {
+		return err
+	}
+	m := NewModel(manager, keys)
+	m.namespaceFilter = opts.Namespace
+	m.filterMatch = opts.Filter
+	m.activeTab = opts.StartTab
+	m.sortOrder = opts.Sort
+	m.fetchMetaOnStart = opts.Metadata
+	if paths, err := manager.StateFiles(); err == nil {
+		m.watcher = newFileWatcher(paths)
+	}
 	if err := m.LoadPackages(); err != nil {
 		return err
 	}
 
 	p := tea.NewProgram(m, tea.WithAltScreen())
-	_, err := p.Run()
+	_, err = p.Run()
 	return err
 }
@@ -0,0 +1,40 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/itda-skills/jindo/internal/frontmatter"
+)
+
+// previewField is a frontmatter field shown above the body in the details view
+type previewField struct {
+	Label string
+	Value string
+}
+
+// detailFields are the frontmatter keys shown in the details view, in order
+var detailFields = []struct{ key, label string }{
+	{"name", "Name"},
+	{"description", "Description"},
+	{"model", "Model"},
+	{"allowed-tools", "Allowed tools"},
+	{"tools", "Tools"},
+	{"argument-hint", "Arguments"},
+	{"tags", "Tags"},
+}
+
+// splitFrontmatter splits markdown content into its frontmatter fields and body.
+func splitFrontmatter(content string) (fields []previewField, body string, ok bool) {
+	doc := frontmatter.Split(content)
+	if !doc.Found {
+		return nil, content, false
+	}
+
+	values := doc.Values()
+	for _, f := range detailFields {
+		if v := values[f.key]; v != "" {
+			fields = append(fields, previewField{Label: f.label, Value: v})
+		}
+	}
+	return fields, strings.TrimLeft(doc.Body, "\n"), true
+}
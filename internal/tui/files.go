@@ -0,0 +1,159 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/itda-skills/jindo/internal/pkg/pkgmgr"
+	"github.com/itda-skills/jindo/internal/pkg/repo"
+)
+
+// filePicker is the checklist of a skill's files, shown in place of the preview
+// while the files to install are picked
+type filePicker struct {
+	item     *PackageItem
+	files    []string // Paths relative to the skill directory
+	selected []bool
+	cursor   int
+}
+
+// openFilePicker shows the files of the skill under the cursor, checked as the
+// item's selection has them
+func (m *Model) openFilePicker() {
+	item := m.getCurrentItem()
+	if item == nil || item.Type != repo.TypeSkill || item.IsInstalled {
+		m.message = "Pick files of a skill that is not installed"
+		return
+	}
+	files, err := m.manager.SkillFiles(item.Namespace + ":" + item.Path)
+	if err != nil {
+		m.message = fmt.Sprintf("✗ Cannot list the files of %s: %v", item.Name, err)
+		return
+	}
+	p := &filePicker{item: item, files: files, selected: make([]bool, len(files))}
+	for i, f := range files {
+		p.selected[i] = pkgmgr.MatchOnly(item.Only, f)
+	}
+	m.picker = p
+}
+
+// updateFilePicker handles a key while files are picked: the list keys move,
+// select toggles a file (a skill's SKILL.md is always installed), install keeps
+// the selection, and quit closes the picker without changing it
+func (m *Model) updateFilePicker(msg tea.KeyMsg) {
+	p := m.picker
+	switch {
+	case key.Matches(msg, m.keys.Up):
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case key.Matches(msg, m.keys.Down):
+		if p.cursor < len(p.files)-1 {
+			p.cursor++
+		}
+	case key.Matches(msg, m.keys.Select):
+		if !isSkillFile(p.files[p.cursor]) {
+			p.selected[p.cursor] = !p.selected[p.cursor]
+		}
+	case key.Matches(msg, m.keys.SelectAll):
+		all := true
+		for _, s := range p.selected {
+			all = all && s
+		}
+		for i, f := range p.files {
+			p.selected[i] = !all || isSkillFile(f)
+		}
+	case key.Matches(msg, m.keys.Install):
+		only, count := p.selection()
+		p.item.Only = only
+		p.item.Selected = true
+		m.picker = nil
+		m.message = fmt.Sprintf("%s: %d of %d files selected; press %s to install", p.item.Name, count, len(p.files), m.keys.Install.Help().Key)
+	case key.Matches(msg, m.keys.Quit):
+		m.picker = nil
+		m.message = "Cancelled"
+	}
+}
+
+// selection returns the patterns that install the checked files, nil if all
+// are checked, and how many are
+func (p *filePicker) selection() ([]string, int) {
+	var only []string
+	for i, f := range p.files {
+		if p.selected[i] || isSkillFile(f) {
+			only = append(only, escapeGlob(f))
+		}
+	}
+	if len(only) == len(p.files) {
+		return nil, len(only)
+	}
+	return only, len(only)
+}
+
+// isSkillFile reports whether rel is the SKILL.md every install of a skill includes
+func isSkillFile(rel string) bool {
+	return strings.EqualFold(rel, "SKILL.md")
+}
+
+// escapeGlob quotes the glob characters in a file path, so it matches only itself
+func escapeGlob(path string) string {
+	var b strings.Builder
+	for _, r := range path {
+		if strings.ContainsRune(`*?[\`, r) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// renderFilePicker renders the file checklist in place of the preview pane
+func (m Model) renderFilePicker(width, height int) string {
+	p := m.picker
+	var b strings.Builder
+	b.WriteString(previewTitleStyle.Render(fmt.Sprintf("📂 Files of %s to install", p.item.Name)))
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render(fmt.Sprintf("%s: toggle, %s: all/none, %s: done, %s: cancel",
+		m.keys.Select.Help().Key, m.keys.SelectAll.Help().Key, m.keys.Install.Help().Key, m.keys.Quit.Help().Key)))
+	b.WriteString("\n\n")
+
+	// Keep the cursor in view
+	visible := height - 8
+	if visible < 5 {
+		visible = 5
+	}
+	start := 0
+	if p.cursor >= visible {
+		start = p.cursor - visible + 1
+	}
+	end := min(start+visible, len(p.files))
+
+	maxWidth := width - 12
+	for i := start; i < end; i++ {
+		cursor := "  "
+		if i == p.cursor {
+			cursor = "> "
+		}
+		checkbox := "[ ]"
+		if isSkillFile(p.files[i]) {
+			checkbox = "[✓]"
+		} else if p.selected[i] {
+			checkbox = "[*]"
+		}
+		name := p.files[i]
+		if maxWidth > 10 && len(name) > maxWidth {
+			name = "..." + name[len(name)-maxWidth+3:]
+		}
+		if i == p.cursor {
+			name = selectedStyle.Render(name)
+		}
+		b.WriteString(fmt.Sprintf("%s%s %s\n", cursor, checkbox, name))
+	}
+	if end < len(p.files) {
+		b.WriteString(helpStyle.Render("  ↓ more below"))
+	}
+
+	return previewBorderStyle.Width(width - 4).Render(b.String())
+}
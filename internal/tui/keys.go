@@ -0,0 +1,168 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// keyMap holds the browse TUI key bindings
+type keyMap struct {
+	Up        key.Binding
+	Down      key.Binding
+	Left      key.Binding
+	Right     key.Binding
+	Tab       key.Binding
+	Top       key.Binding
+	Bottom    key.Binding
+	PageUp    key.Binding
+	PageDown  key.Binding
+	Select    key.Binding
+	SelectAll key.Binding
+	Install   key.Binding
+	Uninstall key.Binding
+	Favorites key.Binding
+	Filter    key.Binding
+	Files     key.Binding
+	View      key.Binding
+	Help      key.Binding
+	Quit      key.Binding
+}
+
+// keyAction is a configurable action: its tui.keys name, default keys, and help text.
+// Keys with a space are two-key sequences (e.g., "g g" for gg).
+type keyAction struct {
+	name     string
+	binding  func(*keyMap) *key.Binding
+	defaults []string
+	desc     string
+}
+
+var keyActions = []keyAction{
+	{"up", func(k *keyMap) *key.Binding { return &k.Up }, []string{"up", "k"}, "up"},
+	{"down", func(k *keyMap) *key.Binding { return &k.Down }, []string{"down", "j"}, "down"},
+	{"left", func(k *keyMap) *key.Binding { return &k.Left }, []string{"left", "h"}, "prev tab"},
+	{"right", func(k *keyMap) *key.Binding { return &k.Right }, []string{"right", "l"}, "next tab"},
+	{"tab", func(k *keyMap) *key.Binding { return &k.Tab }, []string{"tab"}, "next tab"},
+	{"top", func(k *keyMap) *key.Binding { return &k.Top }, []string{"g g", "home"}, "first item"},
+	{"bottom", func(k *keyMap) *key.Binding { return &k.Bottom }, []string{"G", "end"}, "last item"},
+	{"page_up", func(k *keyMap) *key.Binding { return &k.PageUp }, []string{"pgup", "ctrl+b", "ctrl+u"}, "page up"},
+	{"page_down", func(k *keyMap) *key.Binding { return &k.PageDown }, []string{"pgdown", "ctrl+f", "ctrl+d"}, "page down"},
+	{"select", func(k *keyMap) *key.Binding { return &k.Select }, []string{" "}, "select"},
+	{"select_all", func(k *keyMap) *key.Binding { return &k.SelectAll }, []string{"a"}, "select all"},
+	{"install", func(k *keyMap) *key.Binding { return &k.Install }, []string{"enter"}, "install"},
+	{"uninstall", func(k *keyMap) *key.Binding { return &k.Uninstall }, []string{"d"}, "uninstall"},
+	{"favorites", func(k *keyMap) *key.Binding { return &k.Favorites }, []string{"f"}, "favorites only"},
+	{"filter", func(k *keyMap) *key.Binding { return &k.Filter }, []string{"/"}, "filter"},
+	{"files", func(k *keyMap) *key.Binding { return &k.Files }, []string{"o"}, "pick skill files"},
+	{"view", func(k *keyMap) *key.Binding { return &k.View }, []string{"v"}, "details/raw view"},
+	{"help", func(k *keyMap) *key.Binding { return &k.Help }, []string{"?"}, "toggle help"},
+	{"quit", func(k *keyMap) *key.Binding { return &k.Quit }, []string{"q", "esc", "ctrl+c"}, "quit"},
+}
+
+// KeyActions returns the names of the actions that can be rebound with tui.keys.<name>
+func KeyActions() []string {
+	names := make([]string, len(keyActions))
+	for i, a := range keyActions {
+		names[i] = a.name
+	}
+	return names
+}
+
+// newKeyMap returns the default bindings with overrides applied. Overrides map
+// action names to the keys that replace the defaults.
+func newKeyMap(overrides map[string][]string) (keyMap, error) {
+	var names []string
+	for name := range overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	actions := make(map[string]bool, len(keyActions))
+	for _, a := range keyActions {
+		actions[a.name] = true
+	}
+	for _, name := range names {
+		if !actions[name] {
+			return keyMap{}, fmt.Errorf("unknown key action %q (valid: %s)", name, strings.Join(KeyActions(), ", "))
+		}
+		if len(overrides[name]) == 0 {
+			return keyMap{}, fmt.Errorf("no keys given for %q", name)
+		}
+	}
+
+	var k keyMap
+	for _, a := range keyActions {
+		keys := a.defaults
+		if o, ok := overrides[a.name]; ok {
+			keys = o
+		}
+		*a.binding(&k) = key.NewBinding(
+			key.WithKeys(keys...),
+			key.WithHelp(keysHelp(keys), a.desc),
+		)
+	}
+	return k, nil
+}
+
+// keyNames are the help labels of keys that are not shown as typed
+var keyNames = map[string]string{
+	"up":    "↑",
+	"down":  "↓",
+	"left":  "←",
+	"right": "→",
+	" ":     "space",
+}
+
+// keysHelp returns the help label for keys (e.g., "↑/k", "gg/home")
+func keysHelp(keys []string) string {
+	labels := make([]string, len(keys))
+	for i, k := range keys {
+		if name, ok := keyNames[k]; ok {
+			labels[i] = name
+		} else {
+			labels[i] = strings.ReplaceAll(k, " ", "")
+		}
+	}
+	return strings.Join(labels, "/")
+}
+
+// isSequencePrefix reports whether s is the first key of a two-key sequence binding
+func (k keyMap) isSequencePrefix(s string) bool {
+	for _, b := range k.all() {
+		for _, bk := range b.Keys() {
+			if first, _, ok := strings.Cut(bk, " "); ok && bk != " " && first == s {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// all returns every binding
+func (k keyMap) all() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.Left, k.Right, k.Tab, k.Top, k.Bottom, k.PageUp, k.PageDown,
+		k.Select, k.SelectAll, k.Install, k.Uninstall, k.Favorites, k.Filter, k.Files, k.View, k.Help, k.Quit}
+}
+
+// ShortHelp returns the bindings shown in the footer (help.KeyMap)
+func (k keyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.Tab, k.Select, k.Install, k.Uninstall, k.Filter, k.Help, k.Quit}
+}
+
+// FullHelp returns the bindings shown in the help overlay, by column (help.KeyMap)
+func (k keyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down, k.Top, k.Bottom, k.PageUp, k.PageDown},
+		{k.Left, k.Right, k.Tab, k.Favorites, k.Filter, k.View},
+		{k.Select, k.SelectAll, k.Files, k.Install, k.Uninstall},
+		{k.Help, k.Quit},
+	}
+}
+
+// keySeq is a two-key sequence, matched against bindings like a single key
+type keySeq string
+
+func (s keySeq) String() string { return string(s) }
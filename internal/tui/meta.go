@@ -0,0 +1,158 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/itda-skills/jindo/internal/pkg/repo"
+	"github.com/itda-skills/jindo/internal/sorting"
+)
+
+// SortOrder is the order packages are listed in
+type SortOrder string
+
+const (
+	SortDefault   SortOrder = ""          // By repository, then name
+	SortName      SortOrder = "name"      // By name
+	SortType      SortOrder = "type"      // By type, then name: within a tab, as SortDefault
+	SortInstalled SortOrder = "installed" // Most recently installed first, then packages not installed
+	SortStars     SortOrder = "stars"     // Repositories with the most stars first
+	SortUpdated   SortOrder = "updated"   // Most recently changed first
+)
+
+// metaLoadedMsg is sent when the metadata of packages has been fetched
+type metaLoadedMsg struct {
+	meta map[string]*repo.PackageMeta // By "namespace:path"
+	errs []string
+}
+
+// fetchMeta fetches the stars and last changes of every package from GitHub,
+// one repository after another so they share the response cache
+func (m Model) fetchMeta() tea.Cmd {
+	byNamespace := make(map[string][]repo.BrowseItem)
+	var namespaces []string
+	for _, tab := range m.tabs {
+		for _, items := range [][]PackageItem{m.items[tab], m.hiddenItems[tab]} {
+			for _, item := range items {
+				if _, ok := byNamespace[item.Namespace]; !ok {
+					namespaces = append(namespaces, item.Namespace)
+				}
+				byNamespace[item.Namespace] = append(byNamespace[item.Namespace], repo.BrowseItem{Name: item.Name, Path: item.Path, Type: item.Type})
+			}
+		}
+	}
+
+	store := m.manager.RepoStore()
+	return func() tea.Msg {
+		msg := metaLoadedMsg{meta: make(map[string]*repo.PackageMeta)}
+		for _, ns := range namespaces {
+			items := byNamespace[ns]
+			if err := store.AddMeta(ns, items); err != nil {
+				msg.errs = append(msg.errs, fmt.Sprintf("%s: %v", ns, err))
+			}
+			for _, item := range items {
+				if item.Meta != nil {
+					msg.meta[ns+":"+item.Path] = item.Meta
+				}
+			}
+		}
+		return msg
+	}
+}
+
+// applyMeta sets the fetched metadata of packages and lists them in the sort
+// order again, keeping the cursor on the selected package
+func (m *Model) applyMeta(msg metaLoadedMsg) {
+	for _, items := range []map[Tab][]PackageItem{m.items, m.hiddenItems} {
+		for tab := range items {
+			for i := range items[tab] {
+				item := &items[tab][i]
+				if meta, ok := msg.meta[item.Namespace+":"+item.Path]; ok {
+					item.Meta = meta
+				}
+			}
+		}
+	}
+
+	if m.sortOrder != SortStars && m.sortOrder != SortUpdated {
+		return
+	}
+	var selected string
+	if item := m.getCurrentItem(); item != nil {
+		selected = item.Namespace + ":" + item.Path
+	}
+	for _, tab := range m.tabs {
+		all := append(m.items[tab], m.hiddenItems[tab]...)
+		sortItems(all, m.sortOrder)
+		m.items[tab], m.hiddenItems[tab] = all, nil
+	}
+	m.applyFilters()
+	for i, item := range m.items[m.activeTab] {
+		if item.Namespace+":"+item.Path == selected {
+			m.cursor = i
+			m.adjustListScroll()
+			m.updatePreview()
+			break
+		}
+	}
+}
+
+// sortItems sorts items by a sort order, with ties by repository and name (by
+// name and repository for SortName), and records it as their load order
+func sortItems(items []PackageItem, order SortOrder) {
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if order == SortName {
+			if c := sorting.Compare(a.Name, b.Name); c != 0 {
+				return c < 0
+			}
+			return sorting.Less(a.Namespace, b.Namespace)
+		}
+		if c := sorting.Compare(a.Namespace, b.Namespace); c != 0 {
+			return c < 0
+		}
+		return sorting.Less(a.Name, b.Name)
+	})
+	switch order {
+	case SortInstalled:
+		sort.SliceStable(items, func(i, j int) bool {
+			return items[i].InstalledAt.After(items[j].InstalledAt)
+		})
+	case SortStars:
+		sort.SliceStable(items, func(i, j int) bool {
+			return metaStars(items[i].Meta) > metaStars(items[j].Meta)
+		})
+	case SortUpdated:
+		sort.SliceStable(items, func(i, j int) bool {
+			a, b := items[i].Meta, items[j].Meta
+			return a != nil && (b == nil || a.UpdatedAt.After(b.UpdatedAt))
+		})
+	}
+	for i := range items {
+		items[i].order = i
+	}
+}
+
+// metaStars returns the stars of fetched metadata, or 0 if none was fetched
+func metaStars(meta *repo.PackageMeta) int {
+	if meta == nil {
+		return 0
+	}
+	return meta.Stars
+}
+
+// metaSummary renders metadata for the preview header, such as
+// "★ 42 · updated 2026-09-01 by alice"
+func metaSummary(meta *repo.PackageMeta) string {
+	parts := []string{fmt.Sprintf("★ %d", meta.Stars)}
+	if !meta.UpdatedAt.IsZero() {
+		updated := "updated " + meta.UpdatedAt.Local().Format("2006-01-02")
+		if meta.Author != "" {
+			updated += " by " + meta.Author
+		}
+		parts = append(parts, updated)
+	}
+	return strings.Join(parts, " · ")
+}
@@ -0,0 +1,582 @@
+package tui
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/itda-skills/jindo/internal/prompt"
+)
+
+// PromptOptions supplies the helpers the prompt browser needs from the CLI
+type PromptOptions struct {
+	// Diff renders an uncolored unified diff between two texts
+	Diff func(oldName, newName, oldContent, newContent string) string
+	// Editor returns the command that opens a file in the user's editor
+	Editor func(path string) *exec.Cmd
+}
+
+// templateVarPattern matches Go template actions such as {{.SkillID}}
+var templateVarPattern = regexp.MustCompile(`\{\{[^}]*\}\}`)
+
+// Prompt browser styles
+var (
+	templateVarStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("214")).
+				Bold(true)
+
+	diffAddStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("42"))
+
+	diffDeleteStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("196"))
+
+	diffHunkStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("39"))
+)
+
+// Prompt browser key bindings
+type promptKeyMap struct {
+	Up        key.Binding
+	Down      key.Binding
+	PageUp    key.Binding
+	PageDown  key.Binding
+	Diff      key.Binding
+	Edit      key.Binding
+	Reset     key.Binding
+	Duplicate key.Binding
+	Quit      key.Binding
+}
+
+var promptKeys = promptKeyMap{
+	Up: key.NewBinding(
+		key.WithKeys("up", "k"),
+		key.WithHelp("↑/k", "up"),
+	),
+	Down: key.NewBinding(
+		key.WithKeys("down", "j"),
+		key.WithHelp("↓/j", "down"),
+	),
+	PageUp: key.NewBinding(
+		key.WithKeys("pgup", "ctrl+u"),
+		key.WithHelp("pgup", "scroll preview up"),
+	),
+	PageDown: key.NewBinding(
+		key.WithKeys("pgdown", "ctrl+d"),
+		key.WithHelp("pgdn", "scroll preview down"),
+	),
+	Diff: key.NewBinding(
+		key.WithKeys("tab"),
+		key.WithHelp("tab", "content/diff"),
+	),
+	Edit: key.NewBinding(
+		key.WithKeys("e"),
+		key.WithHelp("e", "edit"),
+	),
+	Reset: key.NewBinding(
+		key.WithKeys("r"),
+		key.WithHelp("r", "reset"),
+	),
+	Duplicate: key.NewBinding(
+		key.WithKeys("c"),
+		key.WithHelp("c", "duplicate"),
+	),
+	Quit: key.NewBinding(
+		key.WithKeys("q", "esc", "ctrl+c"),
+		key.WithHelp("q/esc", "quit"),
+	),
+}
+
+// editorDoneMsg is sent when the editor exits
+type editorDoneMsg struct {
+	name string
+	err  error
+}
+
+// PromptModel represents the prompt browser state
+type PromptModel struct {
+	opts          PromptOptions
+	prompts       []prompt.PromptInfo
+	cursor        int
+	previewOffset int
+	showDiff      bool
+	width         int
+	height        int
+	message       string
+	quitting      bool
+	confirming    bool   // True when waiting for reset confirmation
+	naming        bool   // True while entering a name for a duplicate
+	nameInput     string // Name typed for the duplicate
+}
+
+// NewPromptModel creates a new prompt browser model
+func NewPromptModel(opts PromptOptions) *PromptModel {
+	return &PromptModel{opts: opts}
+}
+
+// LoadPrompts loads embedded, overridden, and custom prompts, keeping the cursor
+// on the prompt named selected if it still exists
+func (m *PromptModel) LoadPrompts(selected string) error {
+	prompts, err := prompt.List()
+	if err != nil {
+		return err
+	}
+	m.prompts = prompts
+
+	m.cursor = 0
+	for i, p := range prompts {
+		if p.Name == selected {
+			m.cursor = i
+			break
+		}
+	}
+	m.previewOffset = 0
+	return nil
+}
+
+// current returns the selected prompt or nil
+func (m *PromptModel) current() *prompt.PromptInfo {
+	if len(m.prompts) == 0 || m.cursor >= len(m.prompts) {
+		return nil
+	}
+	return &m.prompts[m.cursor]
+}
+
+// previewLines returns the preview of the selected prompt: its content, or its diff
+// against the embedded version when showDiff is set
+func (m *PromptModel) previewLines() []string {
+	p := m.current()
+	if p == nil {
+		return []string{"No prompt selected"}
+	}
+
+	content, _, err := prompt.LoadInfo(p.Name)
+	if err != nil {
+		return []string{fmt.Sprintf("Unable to load prompt:\n%v", err)}
+	}
+
+	if !m.showDiff {
+		return strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	}
+
+	switch {
+	case p.IsCustom:
+		return []string{"Custom prompt: no embedded version to compare against"}
+	case !p.IsOverride:
+		return []string{"Embedded prompt: no override to compare"}
+	}
+
+	embedded, err := prompt.GetEmbedded(p.Name)
+	if err != nil {
+		return []string{fmt.Sprintf("Unable to load embedded prompt:\n%v", err)}
+	}
+	diff := m.opts.Diff("embedded/"+p.Name+".md", "override/"+p.Name+".md", embedded, content)
+	if diff == "" {
+		return []string{"Override is identical to the embedded version"}
+	}
+	return strings.Split(strings.TrimSuffix(diff, "\n"), "\n")
+}
+
+// contentHeight returns the number of lines available for the list and preview panes
+func (m *PromptModel) contentHeight() int {
+	headerHeight := 3 // title + separator
+	footerHeight := 4 // message + help
+	height := m.height - headerHeight - footerHeight
+	if height < 10 {
+		height = 10
+	}
+	return height
+}
+
+// Init initializes the model
+func (m PromptModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m PromptModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case editorDoneMsg:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("✗ Editor failed: %v", msg.err)
+		} else {
+			m.message = fmt.Sprintf("✓ Saved %s", msg.name)
+		}
+		if err := m.LoadPrompts(msg.name); err != nil {
+			m.message = fmt.Sprintf("✗ Failed to reload prompts: %v", err)
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.naming {
+			return m.updateNaming(msg)
+		}
+
+		// Handle reset confirmation prompt
+		if m.confirming {
+			m.confirming = false
+			switch msg.String() {
+			case "y", "Y":
+				m.resetCurrent()
+			default:
+				m.message = "Cancelled"
+			}
+			return m, nil
+		}
+
+		// Clear message on any key press
+		m.message = ""
+
+		switch {
+		case key.Matches(msg, promptKeys.Quit):
+			m.quitting = true
+			return m, tea.Quit
+
+		case key.Matches(msg, promptKeys.Up):
+			if m.cursor > 0 {
+				m.cursor--
+				m.previewOffset = 0
+			}
+			return m, nil
+
+		case key.Matches(msg, promptKeys.Down):
+			if m.cursor < len(m.prompts)-1 {
+				m.cursor++
+				m.previewOffset = 0
+			}
+			return m, nil
+
+		case key.Matches(msg, promptKeys.PageUp):
+			m.previewOffset -= m.contentHeight() / 2
+			if m.previewOffset < 0 {
+				m.previewOffset = 0
+			}
+			return m, nil
+
+		case key.Matches(msg, promptKeys.PageDown):
+			m.previewOffset += m.contentHeight() / 2
+			if limit := len(m.previewLines()) - 1; m.previewOffset > limit {
+				m.previewOffset = max(limit, 0)
+			}
+			return m, nil
+
+		case key.Matches(msg, promptKeys.Diff):
+			m.showDiff = !m.showDiff
+			m.previewOffset = 0
+			return m, nil
+
+		case key.Matches(msg, promptKeys.Edit):
+			return m, m.editCurrent()
+
+		case key.Matches(msg, promptKeys.Reset):
+			p := m.current()
+			switch {
+			case p == nil:
+			case p.IsCustom:
+				m.confirming = true
+				m.message = fmt.Sprintf("Delete custom prompt '%s'? [y/N]", p.Name)
+			case p.IsOverride:
+				m.confirming = true
+				m.message = fmt.Sprintf("Reset '%s' to the embedded default? [y/N]", p.Name)
+			default:
+				m.message = fmt.Sprintf("%s already uses the embedded default", p.Name)
+			}
+			return m, nil
+
+		case key.Matches(msg, promptKeys.Duplicate):
+			if m.current() != nil {
+				m.naming = true
+				m.nameInput = m.current().Name + "-copy"
+			}
+			return m, nil
+		}
+	}
+
+	return m, nil
+}
+
+// updateNaming handles key presses while entering the name of a duplicate
+func (m PromptModel) updateNaming(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.naming = false
+		src := m.current().Name
+		name := strings.TrimSpace(m.nameInput)
+		if err := prompt.Duplicate(src, name); err != nil {
+			m.message = fmt.Sprintf("✗ %v", err)
+			return m, nil
+		}
+		if err := m.LoadPrompts(name); err != nil {
+			m.message = fmt.Sprintf("✗ Failed to reload prompts: %v", err)
+			return m, nil
+		}
+		m.message = fmt.Sprintf("✓ Duplicated %s as %s", src, name)
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.naming = false
+		m.message = "Cancelled"
+	case tea.KeyBackspace:
+		if r := []rune(m.nameInput); len(r) > 0 {
+			m.nameInput = string(r[:len(r)-1])
+		}
+	case tea.KeyRunes:
+		m.nameInput += string(msg.Runes)
+	}
+	return m, nil
+}
+
+// editCurrent opens the selected prompt in the editor, creating an override from the
+// embedded version first if needed
+func (m *PromptModel) editCurrent() tea.Cmd {
+	p := m.current()
+	if p == nil {
+		return nil
+	}
+	name := p.Name
+
+	if !p.IsOverride {
+		content, err := prompt.GetEmbedded(name)
+		if err == nil {
+			err = prompt.SaveOverride(name, content)
+		}
+		if err != nil {
+			m.message = fmt.Sprintf("✗ Failed to create override: %v", err)
+			return nil
+		}
+	}
+
+	path, err := prompt.GetOverridePath(name)
+	if err != nil {
+		m.message = fmt.Sprintf("✗ Failed to get override path: %v", err)
+		return nil
+	}
+
+	return tea.ExecProcess(m.opts.Editor(path), func(err error) tea.Msg {
+		return editorDoneMsg{name: name, err: err}
+	})
+}
+
+// resetCurrent removes the override of the selected prompt (deleting custom prompts)
+func (m *PromptModel) resetCurrent() {
+	p := m.current()
+	if p == nil {
+		return
+	}
+	name := p.Name
+	custom := p.IsCustom
+
+	if err := prompt.DeleteOverride(name); err != nil {
+		m.message = fmt.Sprintf("✗ Failed to reset: %v", err)
+		return
+	}
+	if err := m.LoadPrompts(name); err != nil {
+		m.message = fmt.Sprintf("✗ Failed to reload prompts: %v", err)
+		return
+	}
+
+	if custom {
+		m.message = fmt.Sprintf("✓ Deleted custom prompt %s", name)
+	} else {
+		m.message = fmt.Sprintf("✓ Reset %s to embedded default", name)
+	}
+}
+
+// renderList renders the left pane with the prompt list
+func (m PromptModel) renderList(width int) string {
+	if len(m.prompts) == 0 {
+		return helpStyle.Render("No prompts found")
+	}
+
+	var b strings.Builder
+	for i, p := range m.prompts {
+		cursor := "  "
+		name := p.Name
+
+		maxNameLen := width - 6
+		if maxNameLen < 10 {
+			maxNameLen = 10
+		}
+		if len(name) > maxNameLen {
+			name = name[:maxNameLen-3] + "..."
+		}
+
+		if i == m.cursor {
+			cursor = "> "
+			name = selectedStyle.Render(name)
+		}
+
+		line := cursor + name
+		switch {
+		case p.IsCustom:
+			line += " " + favoriteStyle.Render("+")
+		case p.IsOverride:
+			line += " " + installedStyle.Render("*")
+		}
+
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// renderPreview renders the right pane with the prompt content or diff
+func (m PromptModel) renderPreview(width, height int) string {
+	p := m.current()
+	if p == nil {
+		return previewBorderStyle.Width(width - 4).Render("No prompt selected")
+	}
+
+	var b strings.Builder
+
+	mode := "embedded"
+	switch {
+	case p.IsCustom:
+		mode = "custom"
+	case p.IsOverride:
+		mode = "override"
+	}
+	view := "content"
+	if m.showDiff {
+		view = "diff vs embedded"
+	}
+	b.WriteString(previewTitleStyle.Render(fmt.Sprintf("📄 %s (%s, %s)", p.Name, mode, view)))
+	b.WriteString("\n")
+	if p.Path != "" {
+		b.WriteString(helpStyle.Render(fmt.Sprintf("Path: %s", p.Path)))
+	}
+	b.WriteString("\n\n")
+
+	lines := m.previewLines()
+	start := min(m.previewOffset, len(lines))
+	lines = lines[start:]
+
+	maxLines := height - 8
+	if maxLines < 5 {
+		maxLines = 5
+	}
+	truncated := len(lines) > maxLines
+	if truncated {
+		lines = lines[:maxLines]
+	}
+
+	maxContentWidth := width - 6
+	if maxContentWidth < 20 {
+		maxContentWidth = 20
+	}
+
+	var rendered []string
+	for _, line := range lines {
+		if len(line) > maxContentWidth {
+			line = line[:maxContentWidth-3] + "..."
+		}
+		// Apply styles after truncation to preserve ANSI escape codes
+		if m.showDiff {
+			rendered = append(rendered, renderDiffLine(line))
+		} else {
+			rendered = append(rendered, highlightTemplateVars(line))
+		}
+	}
+	if truncated {
+		rendered = append(rendered, helpStyle.Render("... (pgdn for more)"))
+	}
+
+	b.WriteString(strings.Join(rendered, "\n"))
+
+	return previewBorderStyle.Width(width - 4).Render(b.String())
+}
+
+// highlightTemplateVars styles template actions like {{.Content}} in a line
+func highlightTemplateVars(line string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range templateVarPattern.FindAllStringIndex(line, -1) {
+		b.WriteString(previewContentStyle.Render(line[last:loc[0]]))
+		b.WriteString(templateVarStyle.Render(line[loc[0]:loc[1]]))
+		last = loc[1]
+	}
+	b.WriteString(previewContentStyle.Render(line[last:]))
+	return b.String()
+}
+
+// renderDiffLine colors a line of unified diff output
+func renderDiffLine(line string) string {
+	switch {
+	case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+		return titleStyle.Render(line)
+	case strings.HasPrefix(line, "@@"):
+		return diffHunkStyle.Render(line)
+	case strings.HasPrefix(line, "+"):
+		return diffAddStyle.Render(line)
+	case strings.HasPrefix(line, "-"):
+		return diffDeleteStyle.Render(line)
+	default:
+		return previewContentStyle.Render(line)
+	}
+}
+
+// View renders the UI
+func (m PromptModel) View() string {
+	if m.quitting {
+		return ""
+	}
+
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render("jd prompts browse"))
+	b.WriteString("\n")
+	b.WriteString(strings.Repeat("─", m.width))
+	b.WriteString("\n")
+
+	contentHeight := m.contentHeight()
+
+	listWidth := m.width * 30 / 100
+	if listWidth < 20 {
+		listWidth = 20
+	}
+	previewWidth := m.width - listWidth - 2 // 2 for separator
+	if previewWidth < 30 {
+		previewWidth = 30
+	}
+
+	listPane := listPaneStyle.Width(listWidth).Height(contentHeight).Render(m.renderList(listWidth))
+	previewPane := m.renderPreview(previewWidth, contentHeight)
+	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, listPane, previewPane))
+	b.WriteString("\n")
+
+	// Message or name input
+	if m.naming {
+		b.WriteString(messageStyle.Render(fmt.Sprintf("Duplicate as: %s█", m.nameInput)))
+		b.WriteString("\n")
+	} else if m.message != "" {
+		b.WriteString(messageStyle.Render(m.message))
+		b.WriteString("\n")
+	}
+
+	// Help
+	b.WriteString(strings.Repeat("─", m.width))
+	b.WriteString("\n")
+	help := helpStyle.Render("↑/↓: navigate  tab: content/diff  pgup/pgdn: scroll  e: edit  r: reset  c: duplicate  q: quit  (* override, + custom)")
+	b.WriteString(help)
+
+	return b.String()
+}
+
+// RunPrompts starts the prompt browser TUI
+func RunPrompts(opts PromptOptions) error {
+	m := NewPromptModel(opts)
+	if err := m.LoadPrompts(""); err != nil {
+		return err
+	}
+
+	p := tea.NewProgram(m, tea.WithAltScreen())
+	_, err := p.Run()
+	return err
+}
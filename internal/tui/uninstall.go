@@ -0,0 +1,235 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// UninstallItem is an installed package offered in the uninstall checklist
+type UninstallItem struct {
+	Name       string
+	Type       string
+	Dependents []string // Packages and files that mention the package
+}
+
+// Uninstall checklist key bindings
+type uninstallKeyMap struct {
+	Up      key.Binding
+	Down    key.Binding
+	Toggle  key.Binding
+	All     key.Binding
+	Confirm key.Binding
+	Quit    key.Binding
+}
+
+var uninstallKeys = uninstallKeyMap{
+	Up: key.NewBinding(
+		key.WithKeys("up", "k"),
+		key.WithHelp("↑/k", "up"),
+	),
+	Down: key.NewBinding(
+		key.WithKeys("down", "j"),
+		key.WithHelp("↓/j", "down"),
+	),
+	Toggle: key.NewBinding(
+		key.WithKeys(" ", "x"),
+		key.WithHelp("space", "select"),
+	),
+	All: key.NewBinding(
+		key.WithKeys("a"),
+		key.WithHelp("a", "select all/none"),
+	),
+	Confirm: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "uninstall selected"),
+	),
+	Quit: key.NewBinding(
+		key.WithKeys("q", "esc", "ctrl+c"),
+		key.WithHelp("q/esc", "cancel"),
+	),
+}
+
+// UninstallModel is the state of the uninstall checklist
+type UninstallModel struct {
+	items     []UninstallItem
+	selected  []bool
+	cursor    int
+	height    int
+	message   string
+	confirmed bool
+	done      bool
+}
+
+// NewUninstallModel creates an uninstall checklist of items, none selected
+func NewUninstallModel(items []UninstallItem) *UninstallModel {
+	return &UninstallModel{items: items, selected: make([]bool, len(items))}
+}
+
+// Selected returns the names of the selected packages, or nil unless the
+// selection was confirmed
+func (m *UninstallModel) Selected() []string {
+	if !m.confirmed {
+		return nil
+	}
+	var names []string
+	for i, item := range m.items {
+		if m.selected[i] {
+			names = append(names, item.Name)
+		}
+	}
+	return names
+}
+
+// Init initializes the model
+func (m UninstallModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m UninstallModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		m.message = ""
+
+		switch {
+		case key.Matches(msg, uninstallKeys.Quit):
+			m.done = true
+			return m, tea.Quit
+
+		case key.Matches(msg, uninstallKeys.Up):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+
+		case key.Matches(msg, uninstallKeys.Down):
+			if m.cursor < len(m.items)-1 {
+				m.cursor++
+			}
+
+		case key.Matches(msg, uninstallKeys.Toggle):
+			if len(m.items) > 0 {
+				m.selected[m.cursor] = !m.selected[m.cursor]
+			}
+
+		case key.Matches(msg, uninstallKeys.All):
+			all := !m.allSelected()
+			for i := range m.selected {
+				m.selected[i] = all
+			}
+
+		case key.Matches(msg, uninstallKeys.Confirm):
+			if m.selectedCount() == 0 {
+				m.message = "Select packages with space first"
+				return m, nil
+			}
+			m.confirmed = true
+			m.done = true
+			return m, tea.Quit
+		}
+	}
+
+	return m, nil
+}
+
+// allSelected reports whether every item is selected
+func (m UninstallModel) allSelected() bool {
+	return m.selectedCount() == len(m.items)
+}
+
+// selectedCount returns the number of selected items
+func (m UninstallModel) selectedCount() int {
+	count := 0
+	for _, s := range m.selected {
+		if s {
+			count++
+		}
+	}
+	return count
+}
+
+// visibleRange returns the items shown, keeping the cursor in view
+func (m UninstallModel) visibleRange() (int, int) {
+	rows := len(m.items)
+	if m.height > 0 {
+		rows = max(m.height-10, 5) // Title, details, message, and help
+	}
+	if rows >= len(m.items) {
+		return 0, len(m.items)
+	}
+	start := max(m.cursor-rows+1, 0)
+	return start, start + rows
+}
+
+// View renders the checklist
+func (m UninstallModel) View() string {
+	if m.done {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("jd pkg uninstall"))
+	b.WriteString(helpStyle.Render(fmt.Sprintf("  %d of %d selected", m.selectedCount(), len(m.items))))
+	b.WriteString("\n\n")
+
+	start, end := m.visibleRange()
+	for i := start; i < end; i++ {
+		item := m.items[i]
+		cursor := "  "
+		if i == m.cursor {
+			cursor = "> "
+		}
+		check := "[ ]"
+		if m.selected[i] {
+			check = "[x]"
+		}
+		name := item.Name
+		if i == m.cursor {
+			name = selectedStyle.Render(name)
+		}
+		line := fmt.Sprintf("%s%s %s %s", cursor, check, name, namespaceStyle.Render("("+item.Type+")"))
+		if n := len(item.Dependents); n > 0 {
+			line += " " + favoriteStyle.Render(fmt.Sprintf("← %d", n))
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
+	// Reverse dependencies of the package under the cursor
+	b.WriteString("\n")
+	if len(m.items) > 0 {
+		item := m.items[m.cursor]
+		if len(item.Dependents) == 0 {
+			b.WriteString(helpStyle.Render("Not referenced by other packages or commands"))
+		} else {
+			b.WriteString(helpStyle.Render("Referenced by: " + strings.Join(item.Dependents, ", ")))
+		}
+		b.WriteString("\n")
+	}
+	if m.message != "" {
+		b.WriteString(messageStyle.Render(m.message))
+		b.WriteString("\n")
+	}
+
+	b.WriteString(helpStyle.Render("↑/↓: navigate  space: select  a: all/none  enter: uninstall selected  q: cancel  (← referenced)"))
+	b.WriteString("\n")
+	return b.String()
+}
+
+// RunUninstall shows the uninstall checklist and returns the names of the
+// selected packages, or nil if it was cancelled
+func RunUninstall(items []UninstallItem) ([]string, error) {
+	p := tea.NewProgram(*NewUninstallModel(items))
+	final, err := p.Run()
+	if err != nil {
+		return nil, err
+	}
+	m := final.(UninstallModel)
+	return m.Selected(), nil
+}
@@ -0,0 +1,122 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/itda-skills/jindo/internal/pkg/pkgmgr"
+)
+
+// watchInterval is how often the browse TUI checks its state files for changes
+const watchInterval = 2 * time.Second
+
+// watchTickMsg is sent when the state files are due to be checked
+type watchTickMsg struct{}
+
+// watchTick schedules the next check of the state files
+func watchTick() tea.Cmd {
+	return tea.Tick(watchInterval, func(time.Time) tea.Msg { return watchTickMsg{} })
+}
+
+// fileStamp is what a check compares: whether a file exists, its size, and when it was written
+type fileStamp struct {
+	exists  bool
+	size    int64
+	modTime time.Time
+}
+
+// stampFile returns a file's stamp; a missing or unreadable file has the zero stamp
+func stampFile(path string) fileStamp {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{}
+	}
+	return fileStamp{exists: true, size: info.Size(), modTime: info.ModTime()}
+}
+
+// fileWatcher notices changes to files, such as installed.json written by jd in
+// another terminal or settings.json written by Claude, by polling their stamps
+type fileWatcher struct {
+	paths  []string
+	stamps map[string]fileStamp
+}
+
+// newFileWatcher returns a watcher of paths, stamped as they are now
+func newFileWatcher(paths []string) *fileWatcher {
+	w := &fileWatcher{paths: paths}
+	w.reset()
+	return w
+}
+
+// reset stamps the files as they are now, so changes made so far are not reported,
+// such as the TUI's own installs
+func (w *fileWatcher) reset() {
+	w.stamps = make(map[string]fileStamp, len(w.paths))
+	for _, path := range w.paths {
+		w.stamps[path] = stampFile(path)
+	}
+}
+
+// changed returns the names of the files changed since they were last stamped,
+// and stamps them again
+func (w *fileWatcher) changed() []string {
+	var names []string
+	for _, path := range w.paths {
+		stamp := stampFile(path)
+		if stamp != w.stamps[path] {
+			names = append(names, filepath.Base(path))
+			w.stamps[path] = stamp
+		}
+	}
+	return names
+}
+
+// checkExternalChanges reloads the installed state if a state file changed
+// outside the TUI, and returns the notice to show, or "" if nothing changed
+func (m *Model) checkExternalChanges() string {
+	if m.watcher == nil {
+		return ""
+	}
+	names := m.watcher.changed()
+	if len(names) == 0 {
+		return ""
+	}
+	if err := m.reloadInstalled(); err != nil {
+		return fmt.Sprintf("✗ Failed to reload external changes to %s: %v", strings.Join(names, ", "), err)
+	}
+	return fmt.Sprintf("↻ Reloaded external changes to %s", strings.Join(names, ", "))
+}
+
+// reloadInstalled updates which packages are installed, and under which name,
+// keeping the cursor, filters, and the selection of packages still not installed
+func (m *Model) reloadInstalled() error {
+	installed, err := m.manager.List()
+	if err != nil {
+		return err
+	}
+	byPath := make(map[string]*pkgmgr.InstalledPackage)
+	for i, pkg := range installed {
+		byPath[pkg.Namespace+":"+pkg.SourcePath] = &installed[i]
+	}
+
+	for _, items := range []map[Tab][]PackageItem{m.items, m.hiddenItems} {
+		for tab := range items {
+			for i := range items[tab] {
+				item := &items[tab][i]
+				item.InstalledAs, item.InstalledAt = "", time.Time{}
+				if pkg := byPath[item.Namespace+":"+item.Path]; pkg != nil {
+					item.InstalledAs, item.InstalledAt = pkg.Name, pkg.InstalledAt
+				}
+				item.IsInstalled = item.InstalledAs != ""
+				if item.IsInstalled {
+					item.Selected = false
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -2,14 +2,62 @@ package config
 
 import (
 	"bytes"
+	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/pelletier/go-toml/v2"
 )
 
 // Config represents the hierarchical configuration structure
 type Config struct {
-	data map[string]any
+	data  map[string]any
+	local map[string]any // Project config layered over data by Load; read-only
+}
+
+// LocalConfigName is the project config file, found in the project's .claude directory
+const LocalConfigName = "jindo.toml"
+
+// localPath is the project config file Load layers over the global config,
+// once set with SetLocalPath
+var (
+	localPath    string
+	localPathSet bool
+)
+
+// SetLocalPath sets the project config file (e.g., <project>/.claude/jindo.toml) that
+// Load layers over the global config. An empty path disables the layer.
+func SetLocalPath(path string) {
+	localPath, localPathSet = path, true
+}
+
+// LocalPath returns the project config file Load layers over the global config.
+// Unless set with SetLocalPath, it is the .claude/jindo.toml nearest to the current
+// directory, searching upward; "" if there is none.
+func LocalPath() string {
+	if localPathSet {
+		return localPath
+	}
+	return findLocalPath()
+}
+
+// findLocalPath walks up from the current directory to the nearest .claude/jindo.toml
+func findLocalPath() string {
+	dir, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	for {
+		path := filepath.Join(dir, ".claude", LocalConfigName)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
 }
 
 // New creates an empty Config
@@ -19,9 +67,30 @@ func New() *Config {
 	}
 }
 
-// Load reads config from the default path
-// Returns an empty config if file doesn't exist
+// Load reads config from the default path, with the project config file (see
+// SetLocalPath) layered over it. Lookups check the environment (GetWithEnv), then the
+// project file, then the global file; tables set in both are merged key by key.
+// Set, Delete, and Save change only the global file.
+// Returns an empty config if neither file exists
 func Load() (*Config, error) {
+	c, err := LoadGlobal()
+	path := LocalPath()
+	if err != nil || path == "" {
+		return c, err
+	}
+
+	local, err := LoadFromPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	c.local = local.data
+	return c, nil
+}
+
+// LoadGlobal reads config from the default path only, without the project layer.
+// Use it to change and save a value read from the global file.
+// Returns an empty config if file doesn't exist
+func LoadGlobal() (*Config, error) {
 	path, err := GetConfigPath()
 	if err != nil {
 		return nil, err
@@ -58,9 +127,9 @@ func (c *Config) Save() error {
 	return c.SaveToPath(path)
 }
 
-// SaveToPath writes config to a specific path
+// SaveToPath writes config to a specific path, creating its directory if needed
 func (c *Config) SaveToPath(path string) error {
-	if err := EnsureConfigDir(); err != nil {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
 		return err
 	}
 
@@ -74,13 +143,57 @@ func (c *Config) SaveToPath(path string) error {
 	return os.WriteFile(path, buf.Bytes(), 0644)
 }
 
-// Get retrieves a value using dot notation (e.g., "common.api_keys.tiingo")
+// Get retrieves a value using dot notation (e.g., "common.api_keys.tiingo").
+// A value in the project layer takes precedence; tables in both are merged.
 func (c *Config) Get(key string) (any, error) {
 	keys, err := parseDotKey(key)
 	if err != nil {
 		return nil, err
 	}
-	return getNestedValue(c.data, keys)
+	val, err := getNestedValue(c.data, keys)
+	if c.local == nil {
+		return val, err
+	}
+
+	localVal, localErr := getNestedValue(c.local, keys)
+	if localErr != nil {
+		return val, err
+	}
+	if table, ok := val.(map[string]any); ok {
+		if localTable, ok := localVal.(map[string]any); ok {
+			return MergeTables(table, localTable), nil
+		}
+	}
+	return localVal, nil
+}
+
+// IsLocal reports whether the project layer sets key
+func (c *Config) IsLocal(key string) bool {
+	keys, err := parseDotKey(key)
+	if err != nil || c.local == nil {
+		return false
+	}
+	_, err = getNestedValue(c.local, keys)
+	return err == nil
+}
+
+// MergeTables returns base with the values of over applied, merging nested tables.
+// Load layers the project config over the global config this way.
+func MergeTables(base, over map[string]any) map[string]any {
+	merged := make(map[string]any, len(base)+len(over))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range over {
+		if baseTable, ok := merged[k].(map[string]any); ok {
+			if overTable, ok := v.(map[string]any); ok {
+				merged[k] = MergeTables(baseTable, overTable)
+				continue
+			}
+		}
+		merged[k] = v
+	}
+	return merged
 }
 
 // Set sets a value using dot notation, creating intermediate maps as needed
@@ -128,12 +241,112 @@ func (c *Config) GetWithVendorEnv(key, vendorEnvVar string) (any, bool) {
 	return c.GetWithEnv(key)
 }
 
-// ToMap returns the full config as a nested map
+// GetInt retrieves an integer using dot notation
+// Returns def if the key doesn't exist or isn't a number
+func (c *Config) GetInt(key string, def int) int {
+	val, err := c.Get(key)
+	if err != nil {
+		return def
+	}
+
+	switch v := val.(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	default:
+		return def
+	}
+}
+
+// GetFloat retrieves a number using dot notation
+// Integers are converted; returns def if the key doesn't exist or isn't a number
+func (c *Config) GetFloat(key string, def float64) float64 {
+	val, err := c.Get(key)
+	if err != nil {
+		return def
+	}
+
+	switch v := val.(type) {
+	case float64:
+		return v
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	default:
+		return def
+	}
+}
+
+// GetBool retrieves a boolean using dot notation
+// Returns def if the key doesn't exist or isn't a boolean
+func (c *Config) GetBool(key string, def bool) bool {
+	val, err := c.Get(key)
+	if err != nil {
+		return def
+	}
+
+	if b, ok := val.(bool); ok {
+		return b
+	}
+	return def
+}
+
+// GetStringSlice retrieves a list of strings using dot notation
+// Non-string elements are skipped; returns nil if the key doesn't exist or isn't a list
+func (c *Config) GetStringSlice(key string) []string {
+	val, err := c.Get(key)
+	if err != nil {
+		return nil
+	}
+
+	switch v := val.(type) {
+	case []string:
+		return v
+	case []any:
+		result := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	default:
+		return nil
+	}
+}
+
+// GetStringMap retrieves a table of string values using dot notation
+// Non-string values are skipped; returns nil if the key doesn't exist or isn't a table
+func (c *Config) GetStringMap(key string) map[string]string {
+	val, err := c.Get(key)
+	if err != nil {
+		return nil
+	}
+
+	table, ok := val.(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	result := make(map[string]string, len(table))
+	for k, item := range table {
+		if s, ok := item.(string); ok {
+			result[k] = s
+		}
+	}
+	return result
+}
+
+// ToMap returns the full config of the loaded file as a nested map, without the project layer
 func (c *Config) ToMap() map[string]any {
 	return c.data
 }
 
-// IsEmpty returns true if config has no values
+// IsEmpty returns true if the loaded file has no values
 func (c *Config) IsEmpty() bool {
 	return len(c.data) == 0
 }
@@ -294,6 +294,169 @@ func TestGetWithVendorEnv(t *testing.T) {
 	})
 }
 
+func TestGetFloat(t *testing.T) {
+	c := New()
+	_ = c.Set("ai.price.input_per_mtok", ParseValue("2.5"))
+	_ = c.Set("ai.budget.monthly", ParseValue("20"))
+	_ = c.Set("common.market", "kr")
+
+	if got := c.GetFloat("ai.price.input_per_mtok", 3); got != 2.5 {
+		t.Errorf("GetFloat(float) = %v, want 2.5", got)
+	}
+	if got := c.GetFloat("ai.budget.monthly", 0); got != 20 {
+		t.Errorf("GetFloat(integer) = %v, want 20", got)
+	}
+	if got := c.GetFloat("common.market", 3); got != 3 {
+		t.Errorf("GetFloat(non-number) = %v, want 3", got)
+	}
+	if got := c.GetFloat("missing.key", 3); got != 3 {
+		t.Errorf("GetFloat(missing) = %v, want 3", got)
+	}
+}
+
+func TestGetInt(t *testing.T) {
+	t.Run("loaded from TOML", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "config.toml")
+		content := []byte("[gc.history]\nkeep = 5\n")
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		c, err := LoadFromPath(path)
+		if err != nil {
+			t.Fatalf("LoadFromPath() error: %v", err)
+		}
+
+		if got := c.GetInt("gc.history.keep", 10); got != 5 {
+			t.Errorf("GetInt() = %d, want 5", got)
+		}
+	})
+
+	t.Run("set with ParseValue", func(t *testing.T) {
+		c := New()
+		_ = c.Set("gc.history.keep", ParseValue("3"))
+
+		if got := c.GetInt("gc.history.keep", 10); got != 3 {
+			t.Errorf("GetInt() = %d, want 3", got)
+		}
+	})
+
+	t.Run("missing or non-number key", func(t *testing.T) {
+		c := New()
+		_ = c.Set("common.market", "kr")
+
+		if got := c.GetInt("common.market", 10); got != 10 {
+			t.Errorf("GetInt(non-number) = %d, want 10", got)
+		}
+		if got := c.GetInt("missing.key", 10); got != 10 {
+			t.Errorf("GetInt(missing) = %d, want 10", got)
+		}
+	})
+}
+
+func TestGetBool(t *testing.T) {
+	c := New()
+	_ = c.Set("history.auto", ParseValue("true"))
+	_ = c.Set("common.market", "kr")
+
+	if got := c.GetBool("history.auto", false); !got {
+		t.Errorf("GetBool() = %v, want true", got)
+	}
+	if got := c.GetBool("common.market", false); got {
+		t.Errorf("GetBool(non-bool) = %v, want false", got)
+	}
+	if got := c.GetBool("missing.key", true); !got {
+		t.Errorf("GetBool(missing) = %v, want true", got)
+	}
+}
+
+func TestGetStringSlice(t *testing.T) {
+	t.Run("set as string slice", func(t *testing.T) {
+		c := New()
+		_ = c.Set("jindo.favorites", []string{"a", "b"})
+
+		got := c.GetStringSlice("jindo.favorites")
+		if len(got) != 2 || got[0] != "a" || got[1] != "b" {
+			t.Errorf("GetStringSlice() = %v, want [a b]", got)
+		}
+	})
+
+	t.Run("loaded from TOML", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "config.toml")
+		content := []byte("[jindo]\nfavorites = [\"a\", 1, \"b\"]\n")
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		c, err := LoadFromPath(path)
+		if err != nil {
+			t.Fatalf("LoadFromPath() error: %v", err)
+		}
+
+		got := c.GetStringSlice("jindo.favorites")
+		if len(got) != 2 || got[0] != "a" || got[1] != "b" {
+			t.Errorf("GetStringSlice() = %v, want [a b]", got)
+		}
+	})
+
+	t.Run("missing or non-list key", func(t *testing.T) {
+		c := New()
+		_ = c.Set("common.market", "kr")
+
+		if got := c.GetStringSlice("common.market"); got != nil {
+			t.Errorf("GetStringSlice(non-list) = %v, want nil", got)
+		}
+		if got := c.GetStringSlice("missing.key"); got != nil {
+			t.Errorf("GetStringSlice(missing) = %v, want nil", got)
+		}
+	})
+}
+
+func TestGetStringMap(t *testing.T) {
+	t.Run("loaded from TOML", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "config.toml")
+		content := []byte("[jindo.scopes]\napp-a = \"/repo/packages/app-a\"\nbad = 1\n")
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		c, err := LoadFromPath(path)
+		if err != nil {
+			t.Fatalf("LoadFromPath() error: %v", err)
+		}
+
+		got := c.GetStringMap("jindo.scopes")
+		if len(got) != 1 || got["app-a"] != "/repo/packages/app-a" {
+			t.Errorf("GetStringMap() = %v, want map[app-a:/repo/packages/app-a]", got)
+		}
+	})
+
+	t.Run("set with dot notation", func(t *testing.T) {
+		c := New()
+		_ = c.Set("jindo.scopes.app-b", "/repo/packages/app-b")
+
+		got := c.GetStringMap("jindo.scopes")
+		if got["app-b"] != "/repo/packages/app-b" {
+			t.Errorf("GetStringMap() = %v, want app-b entry", got)
+		}
+	})
+
+	t.Run("missing or non-table key", func(t *testing.T) {
+		c := New()
+		_ = c.Set("common.market", "kr")
+
+		if got := c.GetStringMap("common.market"); got != nil {
+			t.Errorf("GetStringMap(non-table) = %v, want nil", got)
+		}
+		if got := c.GetStringMap("missing.key"); got != nil {
+			t.Errorf("GetStringMap(missing) = %v, want nil", got)
+		}
+	})
+}
+
 func TestIsEmpty(t *testing.T) {
 	t.Run("empty config", func(t *testing.T) {
 		c := New()
@@ -351,3 +514,92 @@ func TestToTOML(t *testing.T) {
 		t.Errorf("ToTOML round-trip: common.market = %v, want kr", val)
 	}
 }
+
+func TestLoadLocalLayer(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	globalPath, err := GetConfigPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(globalPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	global := "[common]\ndefault_market = \"kr\"\nformat = \"json\"\n\n[common.api_keys]\ntiingo = \"global-key\"\n"
+	if err := os.WriteFile(globalPath, []byte(global), 0644); err != nil {
+		t.Fatal(err)
+	}
+	projectPath := filepath.Join(dir, "project", ".claude", LocalConfigName)
+	if err := os.MkdirAll(filepath.Dir(projectPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	local := "[common]\ndefault_market = \"us\"\n\n[common.api_keys]\npolygon = \"local-key\"\n"
+	if err := os.WriteFile(projectPath, []byte(local), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	SetLocalPath(projectPath)
+	t.Cleanup(func() { localPath, localPathSet = "", false })
+
+	c, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+
+	t.Run("local value overrides global", func(t *testing.T) {
+		if val, _ := c.Get("common.default_market"); val != "us" {
+			t.Errorf("Get(common.default_market) = %v, want us", val)
+		}
+		if !c.IsLocal("common.default_market") || c.IsLocal("common.format") {
+			t.Error("IsLocal() should report only keys set in the local file")
+		}
+	})
+
+	t.Run("global value is used when not set locally", func(t *testing.T) {
+		if val, _ := c.Get("common.format"); val != "json" {
+			t.Errorf("Get(common.format) = %v, want json", val)
+		}
+	})
+
+	t.Run("tables are merged", func(t *testing.T) {
+		keys := c.GetStringMap("common.api_keys")
+		if keys["tiingo"] != "global-key" || keys["polygon"] != "local-key" {
+			t.Errorf("GetStringMap(common.api_keys) = %v, want both keys", keys)
+		}
+	})
+
+	t.Run("env overrides local", func(t *testing.T) {
+		t.Setenv("ITDA_COMMON_DEFAULT_MARKET", "jp")
+		if val, _ := c.GetWithEnv("common.default_market"); val != "jp" {
+			t.Errorf("GetWithEnv(common.default_market) = %v, want jp", val)
+		}
+	})
+
+	t.Run("save writes only the global file", func(t *testing.T) {
+		if err := c.Set("common.format", "table"); err != nil {
+			t.Fatal(err)
+		}
+		if err := c.Save(); err != nil {
+			t.Fatal(err)
+		}
+		saved, err := LoadGlobal()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if val, _ := saved.Get("common.default_market"); val != "kr" {
+			t.Errorf("global common.default_market = %v, want kr", val)
+		}
+		if _, err := saved.Get("common.api_keys.polygon"); err == nil {
+			t.Error("local value should not be saved to the global file")
+		}
+	})
+
+	t.Run("malformed local file", func(t *testing.T) {
+		if err := os.WriteFile(projectPath, []byte("broken ="), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Load(); err == nil {
+			t.Error("Load() with a malformed local file should return an error")
+		}
+	})
+}
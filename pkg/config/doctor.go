@@ -0,0 +1,92 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// DuplicateSection is a table header that appears more than once in a TOML file
+type DuplicateSection struct {
+	Section string `json:"section"`
+	Lines   []int  `json:"lines"` // 1-based line numbers of each header
+}
+
+// sectionHeader matches a [table] header, but not an [[array.of.tables]] header
+var sectionHeader = regexp.MustCompile(`^\[\s*([^\[\]]+?)\s*\]\s*(#.*)?$`)
+
+// DuplicateSections returns the table headers defined more than once in TOML content.
+// TOML rejects these, but the parser reports only the first, without the earlier line.
+func DuplicateSections(content []byte) []DuplicateSection {
+	lines := make(map[string][]int)
+	var order []string
+
+	inMultiline := false
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		// Headers inside multi-line strings are text, not sections
+		if strings.Count(line, `"""`)%2 == 1 || strings.Count(line, `'''`)%2 == 1 {
+			inMultiline = !inMultiline
+			continue
+		}
+		if inMultiline {
+			continue
+		}
+
+		m := sectionHeader.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		section := normalizeSection(m[1])
+		if _, ok := lines[section]; !ok {
+			order = append(order, section)
+		}
+		lines[section] = append(lines[section], n)
+	}
+
+	var dups []DuplicateSection
+	for _, section := range order {
+		if len(lines[section]) > 1 {
+			dups = append(dups, DuplicateSection{Section: section, Lines: lines[section]})
+		}
+	}
+	return dups
+}
+
+// normalizeSection removes the optional whitespace around the dots of a table name
+func normalizeSection(name string) string {
+	parts := strings.Split(name, ".")
+	for i, p := range parts {
+		parts[i] = strings.TrimSpace(p)
+	}
+	return strings.Join(parts, ".")
+}
+
+// ParseError describes why TOML content could not be parsed, with the line of the
+// error when the parser reports one
+func ParseError(content []byte) error {
+	var data map[string]any
+	err := toml.Unmarshal(content, &data)
+	if err == nil {
+		return nil
+	}
+	var decodeErr *toml.DecodeError
+	if errors.As(err, &decodeErr) {
+		row, _ := decodeErr.Position()
+		return fmt.Errorf("line %d: %w", row, err)
+	}
+	return err
+}
+
+// EnvKey returns the environment variable that GetWithEnv checks for key
+// "common.api_keys.tiingo" -> "ITDA_COMMON_API_KEYS_TIINGO"
+func EnvKey(key string) string {
+	return toEnvKey(key)
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDuplicateSections(t *testing.T) {
+	content := `[common]
+default_market = "kr"
+
+[ gc . repos ]  # spacing is insignificant
+unused_days = 30
+
+[[bundles]]
+name = "a"
+
+[[bundles]]
+name = "b"
+
+notes = """
+[common]
+"""
+
+[common]
+other = 1
+
+[gc.repos]
+`
+	got := DuplicateSections([]byte(content))
+	want := []DuplicateSection{
+		{Section: "common", Lines: []int{1, 17}},
+		{Section: "gc.repos", Lines: []int{4, 20}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DuplicateSections() = %+v, want %+v", got, want)
+	}
+
+	if got := DuplicateSections([]byte("[a]\nx = 1\n[b]\n")); got != nil {
+		t.Errorf("DuplicateSections() without duplicates = %+v, want nil", got)
+	}
+}
+
+func TestParseError(t *testing.T) {
+	if err := ParseError([]byte("[common]\nkey = \"v\"\n")); err != nil {
+		t.Errorf("ParseError() on valid TOML = %v, want nil", err)
+	}
+
+	err := ParseError([]byte("[common]\nkey = \"v\"\nbroken =\n"))
+	if err == nil {
+		t.Fatal("ParseError() on malformed TOML = nil, want error")
+	}
+	if !strings.HasPrefix(err.Error(), "line 3: ") {
+		t.Errorf("ParseError() = %q, want it to start with the line", err)
+	}
+}
+
+func TestEnvKey(t *testing.T) {
+	if got := EnvKey("common.api_keys.tiingo"); got != "ITDA_COMMON_API_KEYS_TIINGO" {
+		t.Errorf("EnvKey() = %q", got)
+	}
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Types of values a declared key holds, such as a key in a skill's config schema
+const (
+	TypeString = "string"
+	TypeInt    = "int"
+	TypeFloat  = "float"
+	TypeBool   = "bool"
+)
+
+// ValidType reports whether typ is a value type; empty means TypeString
+func ValidType(typ string) bool {
+	switch typ {
+	case "", TypeString, TypeInt, TypeFloat, TypeBool:
+		return true
+	}
+	return false
+}
+
+// ParseTyped parses a string into a value of the given type, unlike ParseValue,
+// which guesses it: "123" stays a string for TypeString, and "yes" is not a TypeBool.
+func ParseTyped(s, typ string) (any, error) {
+	switch typ {
+	case "", TypeString:
+		return s, nil
+	case TypeInt:
+		i, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an integer", s)
+		}
+		return i, nil
+	case TypeFloat:
+		f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", s)
+		}
+		return f, nil
+	case TypeBool:
+		b, err := strconv.ParseBool(strings.TrimSpace(s))
+		if err != nil {
+			return nil, fmt.Errorf("%q is not true or false", s)
+		}
+		return b, nil
+	}
+	return nil, fmt.Errorf("unknown type %q (use string, int, float, or bool)", typ)
+}
+
+// HasType reports whether a value read from the config, or parsed from an
+// environment variable by ParseValue, fits the given type. Integers are numbers
+// for TypeFloat, and any scalar can be a TypeString.
+func HasType(value any, typ string) bool {
+	switch v := value.(type) {
+	case bool:
+		return typ == TypeBool || typ == TypeString || typ == ""
+	case int, int64:
+		return typ == TypeInt || typ == TypeFloat || typ == TypeString || typ == ""
+	case float64:
+		if typ == TypeInt {
+			return v == float64(int64(v))
+		}
+		return typ == TypeFloat || typ == TypeString || typ == ""
+	case string:
+		return typ == TypeString || typ == ""
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package config
+
+import "testing"
+
+func TestParseTyped(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		typ     string
+		want    any
+		wantErr bool
+	}{
+		{name: "string keeps digits", input: "123", typ: TypeString, want: "123"},
+		{name: "empty type is string", input: "true", typ: "", want: "true"},
+		{name: "int", input: " 42 ", typ: TypeInt, want: int64(42)},
+		{name: "int rejects float", input: "4.2", typ: TypeInt, wantErr: true},
+		{name: "float", input: "0.5", typ: TypeFloat, want: 0.5},
+		{name: "float accepts integer", input: "3", typ: TypeFloat, want: float64(3)},
+		{name: "float rejects text", input: "abc", typ: TypeFloat, wantErr: true},
+		{name: "bool", input: "false", typ: TypeBool, want: false},
+		{name: "bool rejects yes", input: "yes", typ: TypeBool, wantErr: true},
+		{name: "unknown type", input: "x", typ: "list", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTyped(tt.input, tt.typ)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTyped(%q, %q) error = %v, wantErr %v", tt.input, tt.typ, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseTyped(%q, %q) = %v (%T), want %v (%T)", tt.input, tt.typ, got, got, tt.want, tt.want)
+			}
+		})
+	}
+}
+
+func TestHasType(t *testing.T) {
+	tests := []struct {
+		value any
+		typ   string
+		want  bool
+	}{
+		{"abc", TypeString, true},
+		{"abc", TypeInt, false},
+		{int64(3), TypeInt, true},
+		{int64(3), TypeFloat, true},
+		{int64(3), TypeString, true},
+		{3.0, TypeInt, true},
+		{3.5, TypeInt, false},
+		{3.5, TypeFloat, true},
+		{true, TypeBool, true},
+		{true, TypeInt, false},
+		{"true", TypeBool, false},
+		{[]any{"a"}, TypeString, false},
+		{map[string]any{}, TypeString, false},
+	}
+
+	for _, tt := range tests {
+		if got := HasType(tt.value, tt.typ); got != tt.want {
+			t.Errorf("HasType(%v, %q) = %v, want %v", tt.value, tt.typ, got, tt.want)
+		}
+	}
+}
+
+func TestValidType(t *testing.T) {
+	for _, typ := range []string{"", TypeString, TypeInt, TypeFloat, TypeBool} {
+		if !ValidType(typ) {
+			t.Errorf("ValidType(%q) = false, want true", typ)
+		}
+	}
+	for _, typ := range []string{"integer", "list", "String"} {
+		if ValidType(typ) {
+			t.Errorf("ValidType(%q) = true, want false", typ)
+		}
+	}
+}